@@ -1,8 +1,18 @@
 package binance
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
 	"github.com/lemconn/exlink/model"
 )
@@ -10,7 +20,7 @@ import (
 // Binance Binance 交易所实现
 type Binance struct {
 	client              *Client
-	signer              *Signer
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
 	spot                *BinanceSpot
 	perp                *BinancePerp
 	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
@@ -18,6 +28,15 @@ type Binance struct {
 	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
 	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
 	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	recvWindow          int                      // 签名请求接收窗口（毫秒），为 0 时不发送使用交易所默认值
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+	spotWS              *common.WSManager        // 现货公共 WebSocket 订阅
+	perpWS              *common.WSManager        // 合约公共 WebSocket 订阅
+	deliveryWS          *common.WSManager        // 币本位合约公共 WebSocket 订阅
+	spotUserWS          *common.WSManager        // 现货用户数据流（订单、余额推送）
+	perpUserWS          *common.WSManager        // U本位合约用户数据流（订单、余额推送）
 }
 
 // NewBinance 创建 Binance 交易所实例
@@ -27,21 +46,53 @@ func NewBinance(apiKey, secretKey string, options map[string]interface{}) (excha
 		return nil, err
 	}
 
-	signer := NewSigner(secretKey)
-
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
 	binance := &Binance{
 		client:              client,
-		signer:              signer,
 		spotMarketsBySymbol: make(map[string]*model.Market),
 		spotMarketsByID:     make(map[string]*model.Market),
 		perpMarketsBySymbol: make(map[string]*model.Market),
 		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		binance.lifecycle.SetCancelOrders(v)
+	}
+	if v, ok := options["recvWindow"].(int); ok && v > 0 {
+		binance.recvWindow = min(v, binanceMaxRecvWindow)
+	}
+	client.SpotClient.SetLifecycle(binance.lifecycle)
+	client.PerpClient.SetLifecycle(binance.lifecycle)
+	client.DeliveryClient.SetLifecycle(binance.lifecycle)
+
+	binance.UpdateCredentials(apiKey, secretKey, "")
+
+	// 公共 WebSocket 按需建立连接，所有订阅复用同一连接
+	binance.spotWS = common.NewWSManager(common.NewWSDialer(client.SpotWSURL, client.ProxyURL), &binanceWSProtocol{})
+	binance.perpWS = common.NewWSManager(common.NewWSDialer(client.PerpWSURL, client.ProxyURL), &binanceWSProtocol{})
+	binance.deliveryWS = common.NewWSManager(common.NewWSDialer(client.DeliveryWSURL, client.ProxyURL), &binanceWSProtocol{})
+
+	// 用户数据流在首次订阅时通过 REST 获取 listenKey 后建立连接
+	binance.spotUserWS = binance.newUserDataWS(client.SpotClient, binanceSpotListenKeyPath, client.SpotWSURL, binanceListenKeyKeepalive)
+	binance.perpUserWS = binance.newUserDataWS(client.PerpClient, binancePerpListenKeyPath, client.PerpWSURL, binanceListenKeyKeepalive)
+	if v, ok := options["streamStateHandler"].(common.WSStateHandler); ok {
+		binance.spotWS.SetStateHandler(binanceName, "spot", v)
+		binance.perpWS.SetStateHandler(binanceName, "perp", v)
+		binance.deliveryWS.SetStateHandler(binanceName, "delivery", v)
+		binance.spotUserWS.SetStateHandler(binanceName, "spot_user", v)
+		binance.perpUserWS.SetStateHandler(binanceName, "perp_user", v)
 	}
 
 	// 初始化现货和合约实现
 	binance.spot = NewBinanceSpot(binance)
 	binance.perp = NewBinancePerp(binance)
 
+	if v, ok := options["timeSync"].(bool); ok && v {
+		binance.clock.Start(common.TimeSyncInterval, binance.FetchTime)
+	}
+
 	return binance, nil
 }
 
@@ -59,3 +110,306 @@ func (b *Binance) Perp() exchange.PerpExchange {
 func (b *Binance) Name() string {
 	return binanceName
 }
+
+// Has 返回 Binance 支持的功能
+func (b *Binance) Has() model.Capabilities {
+	return binanceCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (b *Binance) Symbols() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketSymbols(b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (b *Binance) NormalizeSymbol(nativeID string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, b.spotMarketsByID, b.perpMarketsByID, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (b *Binance) DenormalizeSymbol(symbol string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, b.spotMarketsBySymbol, b.perpMarketsBySymbol, common.ToBinanceSymbol)
+}
+
+// FetchTickers 按标准化 symbol 批量获取现货和合约行情（结果以标准化 symbol 为键），
+// 合约 symbol 从 fapi/dapi 的 24hr 行情获取；symbols 为空时返回全部现货和合约行情
+func (b *Binance) FetchTickers(ctx context.Context, symbols ...string) (map[string]*model.Ticker, error) {
+	var spotMarkets []*model.Market
+	perpMarkets := make(map[string][]*model.Market) // 按 API 前缀（"/fapi/" 或 "/dapi/"）分组
+	for _, symbol := range symbols {
+		if market, err := b.spot.GetMarket(symbol); err == nil {
+			spotMarkets = append(spotMarkets, market)
+			continue
+		}
+		market, err := b.perp.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		prefix := perpPath(market, "/fapi/")
+		perpMarkets[prefix] = append(perpMarkets[prefix], market)
+	}
+
+	tickers := make(map[string]*model.Ticker)
+	if len(symbols) == 0 || len(spotMarkets) > 0 {
+		spotTickers, err := b.spot.FetchTickers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) == 0 {
+			maps.Copy(tickers, spotTickers)
+		}
+		for _, market := range spotMarkets {
+			if ticker, ok := spotTickers[market.Symbol]; ok {
+				tickers[market.Symbol] = ticker
+			}
+		}
+	}
+
+	for _, prefix := range []string{"/fapi/", "/dapi/"} {
+		markets, ok := perpMarkets[prefix]
+		if len(symbols) > 0 && !ok {
+			continue
+		}
+		items, err := b.perp.fetchTickers(ctx, prefix, "")
+		if err != nil {
+			return nil, err
+		}
+		wanted := make(map[string]bool, len(markets))
+		for _, market := range markets {
+			wanted[market.Symbol] = true
+		}
+		for _, ticker := range items {
+			if len(symbols) == 0 || wanted[ticker.Symbol] {
+				tickers[ticker.Symbol] = ticker
+			}
+		}
+	}
+
+	return tickers, nil
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (b *Binance) ExportMarkets() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarshalMarkets(binanceName, b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (b *Binance) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(binanceName, data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		b.spotMarketsBySymbol, b.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		b.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		b.perpMarketsBySymbol, b.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		b.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后关闭 WebSocket 连接，已建立的推送和轮询订阅通道随之关闭
+func (b *Binance) Drain(ctx context.Context) error {
+	b.clock.Stop()
+	err := b.lifecycle.Drain(ctx, b.spot, b.perp)
+	return errors.Join(err, common.CloseWSManagers(b.spotWS, b.perpWS, b.deliveryWS, b.spotUserWS, b.perpUserWS))
+}
+
+// FetchTime 获取交易所服务器时间
+func (b *Binance) FetchTime(ctx context.Context) (time.Time, error) {
+	resp, err := b.client.SpotClient.Get(ctx, "/api/v3/time", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+
+	var result binanceTimeResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal time: %w", err)
+	}
+	return result.ServerTime.Time, nil
+}
+
+// FetchStatus 获取系统状态（/sapi/v1/system/status），接口无法访问时视为维护中
+func (b *Binance) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	resp, err := b.client.SpotClient.Get(ctx, "/sapi/v1/system/status", nil)
+	if err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+
+	var result binanceSystemStatusResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("unmarshal system status: %w", err))
+	}
+
+	status := common.OKStatus()
+	if result.Status != 0 {
+		status.Status = model.ExchangeStatusMaintenance
+	}
+	return status, nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (b *Binance) Ping(ctx context.Context) error {
+	return common.Ping(ctx, b.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (b *Binance) VerifyCredentials(ctx context.Context) error {
+	creds := b.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", b.spot.FetchBalance)
+}
+
+// binanceCapabilities Binance 支持的功能
+var binanceCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported:             true,
+		CreateStopOrder:       true,
+		CreateOCOOrder:        true,
+		FetchOrder:            true,
+		FetchOrderByClientID:  true,
+		TrackOrder:            true,
+		FetchAggregatedTrades: true,
+		FetchMyTrades:         true,
+		WatchTicker:           true,
+		WatchOrderBook:        true,
+		WatchOHLCV:            true,
+		WatchOrders:           true,
+		WatchBalance:          true,
+		CreateConversion:      true,
+		FetchCurrencies:       true,
+		FetchDepositAddress:   true,
+		Withdraw:              true,
+		Transfer:              true,
+		FetchSubAccounts:      true,
+		Margin:                true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:               true,
+		CreateStopOrder:         true,
+		CreateTrailingStopOrder: true,
+		EditOrder:               true,
+		FetchOrder:              true,
+		FetchOrderByClientID:    true,
+		TrackOrder:              true,
+		FetchAggregatedTrades:   true,
+		FetchMyTrades:           true,
+		WatchTicker:             true,
+		WatchOrderBook:          true,
+		WatchOHLCV:              true,
+		WatchOrders:             true,
+		WatchBalance:            true,
+		WatchPositions:          true,
+		FetchFundingRate:        true,
+		FetchFundingRateHistory: true,
+		FetchOpenInterest:       true,
+		FetchMarkPrice:          true,
+		FetchIndexPrice:         true,
+		SetLeverage:             true,
+		SetMarginMode:           true,
+		SetPositionMode:         true,
+		GetPositionMode:         true,
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// headers 返回签名请求需要的请求头
+func (c *credentials) headers() map[string]string {
+	return map[string]string{"X-MBX-APIKEY": c.apiKey}
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// Binance 不需要 password，忽略该参数
+func (b *Binance) UpdateCredentials(apiKey, secretKey, password string) {
+	b.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (b *Binance) credentials() *credentials {
+	return b.creds.Load()
+}
+
+// setRecvWindow 配置了接收窗口时加入签名参数（需在签名前调用）
+func (b *Binance) setRecvWindow(params map[string]interface{}) {
+	if b.recvWindow > 0 {
+		params["recvWindow"] = b.recvWindow
+	}
+}
+
+// binanceTradePageLimit 成交记录每页最大条数
+const binanceTradePageLimit = 1000
+
+// binanceTradePages 返回 Binance 成交记录的翻页函数，request 发送带 params 的查询并返回按成交ID升序的一页
+// 先按 startTime/endTime 时间窗口（最长 window）查询，整页时改用 fromId 从下一条成交继续，窗口内不足一页时前进到下一个窗口
+// 游标为 "time:<毫秒>"（窗口起点）或 "id:<成交ID>"（fromId）
+func binanceTradePages(window time.Duration, request func(ctx context.Context, params map[string]interface{}) ([]*model.Trade, error)) common.TradePageFetcher {
+	return func(ctx context.Context, since, until time.Time, cursor string) ([]*model.Trade, string, error) {
+		params := map[string]interface{}{"limit": binanceTradePageLimit}
+		var windowEnd time.Time
+		if id, ok := strings.CutPrefix(cursor, "id:"); ok {
+			params["fromId"] = id
+		} else {
+			start := since
+			if ms, ok := strings.CutPrefix(cursor, "time:"); ok {
+				v, err := strconv.ParseInt(ms, 10, 64)
+				if err != nil {
+					return nil, "", fmt.Errorf("invalid trade cursor: %s", cursor)
+				}
+				start = time.UnixMilli(v)
+			}
+			windowEnd = start.Add(window)
+			if windowEnd.After(until) {
+				windowEnd = until
+			}
+			params["startTime"] = start.UnixMilli()
+			params["endTime"] = windowEnd.UnixMilli() - 1
+		}
+
+		trades, err := request(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		// 整页时从最后一条成交的下一条继续
+		if len(trades) >= binanceTradePageLimit {
+			last := trades[len(trades)-1]
+			if !last.Timestamp.Before(until) {
+				return trades, "", nil
+			}
+			id, err := strconv.ParseInt(last.ID, 10, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid trade ID: %s", last.ID)
+			}
+			return trades, "id:" + strconv.FormatInt(id+1, 10), nil
+		}
+		// 按 fromId 查询不足一页说明已取到最新成交
+		if windowEnd.IsZero() || !windowEnd.Before(until) {
+			return trades, "", nil
+		}
+		return trades, "time:" + strconv.FormatInt(windowEnd.UnixMilli(), 10), nil
+	}
+}
@@ -18,7 +18,8 @@ import (
 
 // BinancePerp Binance 永续合约实现
 type BinancePerp struct {
-	binance *Binance
+	binance      *Binance
+	positionMode common.PositionMode // U本位合约账户持仓模式（币本位合约需通过 option.WithHedgeMode 指定）
 }
 
 // NewBinancePerp 创建 Binance 永续合约实例
@@ -29,21 +30,25 @@ func NewBinancePerp(b *Binance) *BinancePerp {
 }
 
 // signAndRequest 统一处理签名和发送请求
-// method: HTTP 方法，支持 "GET", "POST", "DELETE"
+// method: HTTP 方法，支持 "GET", "POST", "PUT", "DELETE"
 // path: API 路径，例如 "/fapi/v1/order"
 // req: 已设置好参数的 ExValues 对象（不包含 timestamp 和 signature）
 func (p *BinancePerp) signAndRequest(ctx context.Context, method, path string, req *types.ExValues) ([]byte, error) {
 	// 检查认证
-	if p.binance.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
+	creds := p.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
 	}
 
-	// 添加 timestamp
-	req.SetQuery("timestamp", common.GetTimestamp())
+	// 添加 timestamp 和 recvWindow
+	req.SetQuery("timestamp", p.binance.clock.Timestamp())
+	if p.binance.recvWindow > 0 {
+		req.SetQuery("recvWindow", p.binance.recvWindow)
+	}
 
 	// 生成签名
 	queryString := req.EncodeQuery()
-	signature := p.binance.signer.Sign(queryString)
+	signature := creds.signer.Sign(queryString)
 	req.SetQuery("signature", signature)
 
 	// 构建完整路径
@@ -51,35 +56,87 @@ func (p *BinancePerp) signAndRequest(ctx context.Context, method, path string, r
 
 	// 根据方法发送请求
 	switch method {
-	case "GET":
-		return p.binance.client.PerpClient.Get(ctx, reqPath, nil)
-	case "POST":
-		return p.binance.client.PerpClient.Post(ctx, reqPath, nil)
-	case "DELETE":
-		return p.binance.client.PerpClient.Delete(ctx, reqPath, map[string]interface{}{}, nil)
+	case "GET", "POST", "PUT", "DELETE":
+		return p.httpClient(path).RequestWithHeaders(ctx, method, reqPath, nil, nil, creds.headers())
 	default:
 		return nil, fmt.Errorf("unsupported HTTP method: %s", method)
 	}
 }
 
+// httpClient 按接口路径选择客户端：/dapi/ 开头走币本位合约客户端，其余走 U本位合约客户端
+func (p *BinancePerp) httpClient(path string) *common.HTTPClient {
+	if strings.HasPrefix(path, "/dapi/") {
+		return p.binance.client.DeliveryClient
+	}
+	return p.binance.client.PerpClient
+}
+
+// perpPath 将 fapi 接口路径转换为市场对应的路径，币本位合约使用 dapi
+func perpPath(market *model.Market, path string) string {
+	if market == nil || !market.Inverse {
+		return path
+	}
+	if path == "/fapi/v2/positionRisk" {
+		return "/dapi/v1/positionRisk"
+	}
+	return strings.Replace(path, "/fapi/", "/dapi/", 1)
+}
+
+// binancePerpAPIName 返回接口路径所属的 API 名称（fapi 或 dapi），用于错误信息
+func binancePerpAPIName(path string) string {
+	if strings.HasPrefix(path, "/dapi/") {
+		return "dapi"
+	}
+	return "fapi"
+}
+
+// wsManager 返回市场对应的公共 WebSocket 订阅管理器
+func (p *BinancePerp) wsManager(market *model.Market) *common.WSManager {
+	if market.Inverse {
+		return p.binance.deliveryWS
+	}
+	return p.binance.perpWS
+}
+
 // ========== PerpExchange 接口实现 ==========
 
 // LoadMarkets 加载市场信息
 func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	p.binance.mu.RLock()
-	if !reload && len(p.binance.perpMarketsBySymbol) > 0 {
+	if !reload && len(p.binance.perpMarketsBySymbol) > 0 && !p.binance.marketCache.Expired(model.MarketTypeSwap) {
 		p.binance.mu.RUnlock()
 		return nil
 	}
 	p.binance.mu.RUnlock()
 
-	req := types.NewExValues()
-	reqPath := req.JoinPath("/fapi/v1/exchangeInfo")
-	// 获取永续合约市场信息
-	resp, err := p.binance.client.PerpClient.Get(ctx, reqPath, nil)
+	// U本位永续（fapi）和币本位永续（dapi）
+	markets, err := p.fetchMarkets(ctx, "/fapi/v1/exchangeInfo")
+	if err != nil {
+		return err
+	}
+	inverseMarkets, err := p.fetchMarkets(ctx, "/dapi/v1/exchangeInfo")
+	if err != nil {
+		return err
+	}
+	markets = append(markets, inverseMarkets...)
+
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
+	p.binance.mu.Lock()
+	p.binance.perpMarketsBySymbol, p.binance.perpMarketsByID = bySymbol, byID
+	p.binance.marketCache.Touch(model.MarketTypeSwap)
+	p.binance.mu.Unlock()
+
+	return nil
+}
+
+// fetchMarkets 获取 fapi 或 dapi 的永续合约市场列表
+func (p *BinancePerp) fetchMarkets(ctx context.Context, path string) ([]*model.Market, error) {
+	inverse := strings.HasPrefix(path, "/dapi/")
+	resp, err := p.httpClient(path).Get(ctx, path, nil)
 	if err != nil {
-		return fmt.Errorf("fetch fapi exchange info: %w", err)
+		return nil, fmt.Errorf("fetch %s exchange info: %w", binancePerpAPIName(path), err)
 	}
 
 	var respData struct {
@@ -91,6 +148,8 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 			QuoteAsset        string `json:"quoteAsset"`
 			MarginAsset       string `json:"marginAsset"`
 			Status            string `json:"status"`
+			ContractStatus    string `json:"contractStatus"` // dapi 使用 contractStatus
+			ContractSize      int    `json:"contractSize"`   // dapi 每张合约面值（USD）
 			PricePrecision    int    `json:"pricePrecision"`
 			QuantityPrecision int    `json:"quantityPrecision"`
 			Filters           []struct {
@@ -107,7 +166,7 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 	}
 
 	if err := json.Unmarshal(resp, &respData); err != nil {
-		return fmt.Errorf("unmarshal fapi exchange info: %w", err)
+		return nil, fmt.Errorf("unmarshal %s exchange info: %w", binancePerpAPIName(path), err)
 	}
 
 	markets := make(model.Markets, 0)
@@ -116,7 +175,11 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 		if s.ContractType != "PERPETUAL" {
 			continue
 		}
-		if s.Status != "TRADING" {
+		status := s.Status
+		if inverse {
+			status = s.ContractStatus
+		}
+		if status != "TRADING" {
 			continue
 		}
 
@@ -125,7 +188,7 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 			settle = s.QuoteAsset
 		}
 
-		// 转换为标准化格式 BTC/USDT:USDT
+		// 转换为标准化格式 BTC/USDT:USDT，币本位为 BTC/USD:BTC
 		normalizedSymbol := common.NormalizeContractSymbol(s.BaseAsset, s.QuoteAsset, settle)
 
 		market := &model.Market{
@@ -135,13 +198,19 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 			Quote:    s.QuoteAsset,
 			Settle:   settle,
 			Type:     model.MarketTypeSwap,
-			Active:   s.Status == "TRADING",
+			Active:   status == "TRADING",
 			Contract: true,
-			Linear:   true, // U本位永续合约
-			Inverse:  false,
+			Linear:   !inverse,
+			Inverse:  inverse,
+		}
+		if inverse {
+			market.ContractValue = strconv.Itoa(s.ContractSize)
+		} else {
+			// U本位合约按币数量下单，每张合约等于 1 个币
+			market.ContractValue = "1"
 		}
 
-		// 解析精度 - 合约订单优先使用 QuantityPrecision
+		// 解析精度 - 未返回 LOT_SIZE 步长时使用 QuantityPrecision
 		market.Precision.Amount = s.QuantityPrecision
 		market.Precision.Price = s.PricePrecision
 
@@ -155,6 +224,17 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 				if !filter.MaxQty.IsZero() {
 					market.Limits.Amount.Max = filter.MaxQty
 				}
+				if !filter.StepSize.IsZero() {
+					market.Precision.StepSize = filter.StepSize
+					// 从 StepSize 计算数量精度
+					stepSizeStr := filter.StepSize.String()
+					parts := strings.Split(stepSizeStr, ".")
+					if len(parts) > 1 {
+						market.Precision.Amount = len(strings.TrimRight(parts[1], "0"))
+					} else {
+						market.Precision.Amount = 0
+					}
+				}
 			case "PRICE_FILTER":
 				if !filter.MinPrice.IsZero() {
 					market.Limits.Price.Min = filter.MinPrice
@@ -163,6 +243,7 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 					market.Limits.Price.Max = filter.MaxPrice
 				}
 				if !filter.TickSize.IsZero() {
+					market.Precision.TickSize = filter.TickSize
 					// 从 TickSize 计算价格精度
 					tickSizeStr := filter.TickSize.String()
 					parts := strings.Split(tickSizeStr, ".")
@@ -180,19 +261,7 @@ func (p *BinancePerp) LoadMarkets(ctx context.Context, reload bool) error {
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
-	p.binance.mu.Lock()
-	if p.binance.perpMarketsBySymbol == nil {
-		p.binance.perpMarketsBySymbol = make(map[string]*model.Market)
-		p.binance.perpMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		p.binance.perpMarketsBySymbol[market.Symbol] = market
-		p.binance.perpMarketsByID[market.ID] = market
-	}
-	p.binance.mu.Unlock()
-
-	return nil
+	return markets, nil
 }
 
 // FetchMarkets 获取市场列表
@@ -241,7 +310,54 @@ func (p *BinancePerp) GetMarket(symbol string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", symbol)
+	return nil, common.MarketNotFound(symbol, p.binance.perpMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *BinancePerp) GetMarketByID(id string) (*model.Market, error) {
+	p.binance.mu.RLock()
+	defer p.binance.mu.RUnlock()
+
+	if market, ok := p.binance.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.binance.perpMarketsByID)
+}
+
+// AmountToPrecision 将数量向下对齐到市场的数量步长
+func (p *BinancePerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+// PriceToPrecision 将价格向下对齐到市场的价格步长
+func (p *BinancePerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// AmountToContracts 将币数量换算为合约张数（U本位合约面值为 1）
+func (p *BinancePerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+// ContractsToAmount 将合约张数换算为币数量
+func (p *BinancePerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
 }
 
 // FetchTicker 获取行情（单个）
@@ -264,35 +380,54 @@ func (p *BinancePerp) FetchTicker(ctx context.Context, symbol string) (*model.Ti
 	}
 
 	// 使用合约 API
-	resp, err := p.binance.client.PerpClient.Get(ctx, "/fapi/v1/ticker/24hr", map[string]interface{}{
+	path := perpPath(market, "/fapi/v1/ticker/24hr")
+	resp, err := p.httpClient(path).Get(ctx, path, map[string]interface{}{
 		"symbol": binanceSymbol,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch ticker: %w", err)
 	}
 
-	var data binancePerpTickerResponse
-
-	if err := json.Unmarshal(resp, &data); err != nil {
+	items, err := decodeObjectOrArray[binancePerpTickerResponse](resp)
+	if err != nil {
 		return nil, fmt.Errorf("unmarshal ticker: %w", err)
 	}
 
+	var data *binancePerpTickerResponse
+	for i := range items {
+		if items[i].Symbol == binanceSymbol {
+			data = &items[i]
+			break
+		}
+	}
+	if data == nil {
+		return nil, fmt.Errorf("ticker not found: %s", symbol)
+	}
+
+	books, err := p.fetchBookTickers(ctx, perpPath(market, "/fapi/v1/ticker/bookTicker"), binanceSymbol)
+	if err != nil {
+		return nil, err
+	}
+
 	// 转换回标准化格式 - 使用输入的symbol（已经是标准化格式）
 	ticker := &model.Ticker{
 		Symbol:    symbol, // 使用输入的标准化格式
 		Timestamp: data.CloseTime,
 	}
 
-	// 注意：永续合约 API 可能不返回 bidPrice 和 askPrice，需要从其他接口获取
-	// 这里先使用 lastPrice 作为默认值，或者留空
-	ticker.Bid = data.LastPrice // 如果没有 bidPrice，使用 lastPrice 作为近似值
-	ticker.Ask = data.LastPrice // 如果没有 askPrice，使用 lastPrice 作为近似值
+	// 永续合约 24hr 行情不返回 bidPrice 和 askPrice，从 bookTicker 获取
+	if book, ok := books[binanceSymbol]; ok {
+		ticker.Bid = book.BidPrice
+		ticker.Ask = book.AskPrice
+	}
 	ticker.Last = data.LastPrice
 	ticker.Open = data.OpenPrice
 	ticker.High = data.HighPrice
 	ticker.Low = data.LowPrice
 	ticker.Volume = data.Volume
 	ticker.QuoteVolume = data.QuoteVolume
+	ticker.VWAP = data.WeightedAvgPrice
+	ticker.TradeCount = data.Count
 
 	return ticker, nil
 }
@@ -305,44 +440,53 @@ func (p *BinancePerp) FetchTickers(ctx context.Context, opts ...option.ArgsOptio
 		opt(argsOpts)
 	}
 
-	req := types.NewExValues()
-
 	var querySymbol string
+	// 未指定交易对时同时查询 U本位和币本位合约
+	apiPrefixes := []string{"/fapi/", "/dapi/"}
 	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
 		market, err := p.GetMarket(symbol)
 		if err != nil {
 			return nil, err
 		}
 		querySymbol = market.ID
+		apiPrefixes = []string{perpPath(market, "/fapi/")}
 	}
 
-	reqPath := req.JoinPath("/fapi/v1/ticker/24hr")
-	resp, err := p.binance.client.PerpClient.Get(ctx, reqPath, nil)
+	// U本位和币本位合约并发查询
+	tickers, err := common.FetchConcurrently(ctx, apiPrefixes, func(ctx context.Context, prefix string) ([]*model.Ticker, error) {
+		return p.fetchTickers(ctx, prefix, querySymbol)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("fetch tickers: %w", err)
+		return nil, err
+	}
+
+	return tickers, nil
+}
+
+// fetchTickers 从 fapi 或 dapi（prefix 为 "/fapi/" 或 "/dapi/"）批量获取行情，querySymbol 为空时获取全部交易对
+func (p *BinancePerp) fetchTickers(ctx context.Context, prefix, querySymbol string) (model.Tickers, error) {
+	req := types.NewExValues()
+	if querySymbol != "" {
+		req.SetQuery("symbol", querySymbol)
 	}
 
-	var respData []struct {
-		Symbol             string            `json:"symbol"`
-		PriceChange        types.ExDecimal   `json:"priceChange"`
-		PriceChangePercent types.ExDecimal   `json:"priceChangePercent"`
-		WeightedAvgPrice   types.ExDecimal   `json:"weightedAvgPrice"`
-		LastPrice          types.ExDecimal   `json:"lastPrice"`
-		LastQty            types.ExDecimal   `json:"lastQty"`
-		OpenPrice          types.ExDecimal   `json:"openPrice"`
-		HighPrice          types.ExDecimal   `json:"highPrice"`
-		LowPrice           types.ExDecimal   `json:"lowPrice"`
-		Volume             types.ExDecimal   `json:"volume"`
-		QuoteVolume        types.ExDecimal   `json:"quoteVolume"`
-		OpenTime           types.ExTimestamp `json:"openTime"`
-		CloseTime          types.ExTimestamp `json:"closeTime"`
-		Count              int64             `json:"count"`
+	reqPath := req.JoinPath(prefix + "v1/ticker/24hr")
+	resp, err := p.httpClient(prefix).Get(ctx, reqPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
 	}
 
-	if err := json.Unmarshal(resp, &respData); err != nil {
+	// 传 symbol 时返回对象，不传时返回数组
+	respData, err := decodeObjectOrArray[binancePerpTickerResponse](resp)
+	if err != nil {
 		return nil, fmt.Errorf("unmarshal tickers: %w", err)
 	}
 
+	books, err := p.fetchBookTickers(ctx, prefix+"v1/ticker/bookTicker", querySymbol)
+	if err != nil {
+		return nil, err
+	}
+
 	tickers := make(model.Tickers, 0, len(respData))
 	for _, item := range respData {
 		// 尝试从市场信息中查找标准化格式
@@ -354,24 +498,183 @@ func (p *BinancePerp) FetchTickers(ctx context.Context, opts ...option.ArgsOptio
 			continue
 		}
 		ticker := &model.Ticker{
-			Symbol:    item.Symbol,
+			Symbol:    market.Symbol,
 			Timestamp: item.CloseTime,
 		}
-		// 注意：永续合约 API 可能不返回 bidPrice 和 askPrice，使用 lastPrice 作为近似值
-		ticker.Bid = item.LastPrice
-		ticker.Ask = item.LastPrice
+		// 永续合约 24hr 行情不返回 bidPrice 和 askPrice，从 bookTicker 获取
+		if book, ok := books[item.Symbol]; ok {
+			ticker.Bid = book.BidPrice
+			ticker.Ask = book.AskPrice
+		}
 		ticker.Last = item.LastPrice
 		ticker.Open = item.OpenPrice
 		ticker.High = item.HighPrice
 		ticker.Low = item.LowPrice
 		ticker.Volume = item.Volume
 		ticker.QuoteVolume = item.QuoteVolume
+		ticker.VWAP = item.WeightedAvgPrice
+		ticker.TradeCount = item.Count
 		tickers = append(tickers, ticker)
 	}
 
 	return tickers, nil
 }
 
+// fetchBookTickers 获取最优挂单（买一/卖一），binanceSymbol 为空时获取全部交易对
+func (p *BinancePerp) fetchBookTickers(ctx context.Context, path, binanceSymbol string) (map[string]*binancePerpBookTickerResponse, error) {
+	var params map[string]interface{}
+	if binanceSymbol != "" {
+		params = map[string]interface{}{"symbol": binanceSymbol}
+	}
+
+	resp, err := p.httpClient(path).Get(ctx, path, params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch book ticker: %w", err)
+	}
+
+	items, err := decodeObjectOrArray[binancePerpBookTickerResponse](resp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal book ticker: %w", err)
+	}
+
+	books := make(map[string]*binancePerpBookTickerResponse, len(items))
+	for i := range items {
+		books[items[i].Symbol] = &items[i]
+	}
+	return books, nil
+}
+
+// FetchOrderBook 获取订单簿
+func (p *BinancePerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("symbol", market.ID)
+	if depth := common.RoundOrderBookLimit(limit, binancePerpDepthLimits); depth > 0 {
+		req.SetQuery("limit", depth)
+	}
+
+	path := perpPath(market, "/fapi/v1/depth")
+	resp, err := p.httpClient(path).Get(ctx, req.JoinPath(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var data binanceOrderBookResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.LastUpdateID,
+		Timestamp: data.TransactTime,
+	}, nil
+}
+
+// WatchTicker 通过 WebSocket 订阅 24 小时行情
+// 合约 24 小时行情推送不包含买一/卖一价，同时订阅 bookTicker 流，以最近一次最优挂单填充 Bid/Ask
+func (p *BinancePerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	tickers, err := common.WatchTopic(ctx, p.wsManager(market), binanceTickerTopic(market.ID), parseBinanceWSTicker(market.Symbol))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	books, err := common.WatchTopic(ctx, p.wsManager(market), binanceBookTickerTopic(market.ID), parseBinancePerpWSBookTicker)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch := make(chan *model.Ticker)
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		var book *binancePerpWSBookTicker
+		for {
+			select {
+			case b, ok := <-books:
+				if !ok {
+					return
+				}
+				book = b
+			case ticker, ok := <-tickers:
+				if !ok {
+					return
+				}
+				if book != nil {
+					ticker.Bid = book.BidPrice
+					ticker.Ask = book.AskPrice
+				}
+				select {
+				case ch <- ticker:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// WatchOrderBook 通过 WebSocket 订阅深度并维护本地订单簿
+// 先订阅增量流再获取 REST 快照（1000 档），第一条增量须覆盖 lastUpdateId，之后每条增量的 pu 须等于上一条的 u，否则重新获取快照
+func (p *BinancePerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  p.wsManager(market),
+		Topic:    binanceDepthTopic(market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequencePrev,
+		Parse:    parseBinanceWSDepth,
+		FetchSnapshot: func(ctx context.Context) (*model.OrderBook, error) {
+			return p.FetchOrderBook(ctx, market.Symbol, binanceWSSnapshotLimit)
+		},
+	})
+}
+
+// WatchOHLCV 通过 WebSocket 订阅K线，每次更新推送当前K线，收盘K线的 Closed 为 true
+func (p *BinancePerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.BinanceTimeframe(timeframe), binancePerpTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.wsManager(market), binanceKlineTopic(market.ID, interval), parseBinanceWSKline)
+}
+
 // FetchOHLCVs 获取K线数据
 func (p *BinancePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	// 解析参数
@@ -387,17 +690,24 @@ func (p *BinancePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe
 	if err != nil {
 		return nil, err
 	}
+	interval, err := common.CheckTimeframe(timeframe, common.BinanceTimeframe(timeframe), binancePerpTimeframes)
+	if err != nil {
+		return nil, err
+	}
 	req.SetQuery("symbol", market.ID)
-	req.SetQuery("interval", common.BinanceTimeframe(timeframe))
+	req.SetQuery("interval", interval)
 	req.SetQuery("limit", limit)
 
 	if since, ok := option.GetTime(argsOpts.Since); ok {
 		req.SetQuery("startTime", since.UnixMilli())
 	}
+	if until, ok := option.GetTime(argsOpts.Until); ok {
+		req.SetQuery("endTime", until.UnixMilli())
+	}
 
-	reqPath := req.JoinPath("/fapi/v1/klines")
+	path := perpPath(market, "/fapi/v1/klines")
 	// 使用合约 API
-	resp, err := p.binance.client.PerpClient.Get(ctx, reqPath, nil)
+	resp, err := p.httpClient(path).Get(ctx, req.JoinPath(path), nil)
 	if err != nil {
 		return nil, fmt.Errorf("fetch ohlcv: %w", err)
 	}
@@ -429,102 +739,433 @@ func (p *BinancePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe
 		ohlcvs = append(ohlcvs, ohlcv)
 	}
 
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, p.binance.clock.Now())
+	}
 	return ohlcvs, nil
 }
 
-// FetchPositions 获取持仓
-func (p *BinancePerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
-	// 解析参数
-	argsOpts := &option.ExchangeArgsOptions{}
-	for _, opt := range opts {
-		opt(argsOpts)
-	}
-
-	req := types.NewExValues()
+// FetchOHLCVRange 分页获取开盘时间在 [since, until) 内的K线
+func (p *BinancePerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, binanceOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, limit, option.WithSince(start), option.WithUntil(end))
+	})
+}
 
-	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
-		market, err := p.GetMarket(symbol)
-		if err != nil {
-			return nil, err
-		}
-		req.SetQuery("symbol", market.ID)
+func (p *BinancePerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, 2)
+	})
+}
 
-	resp, err := p.signAndRequest(ctx, "GET", "/fapi/v2/positionRisk", req)
+func (p *BinancePerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := p.GetMarket(symbol)
 	if err != nil {
-		return nil, fmt.Errorf("fetch positions: %w", err)
+		return nil, err
 	}
 
-	var respData []struct {
-		Symbol           string            `json:"symbol"`
-		PositionAmt      types.ExDecimal   `json:"positionAmt"`
-		EntryPrice       types.ExDecimal   `json:"entryPrice"`
-		BreakEvenPrice   types.ExDecimal   `json:"breakEvenPrice"`
-		MarkPrice        types.ExDecimal   `json:"markPrice"`
-		UnRealizedProfit types.ExDecimal   `json:"unRealizedProfit"`
-		LiquidationPrice types.ExDecimal   `json:"liquidationPrice"`
-		Leverage         types.ExDecimal   `json:"leverage"`
-		MaxNotionalValue types.ExDecimal   `json:"maxNotionalValue"`
-		MarginType       string            `json:"marginType"`
-		IsolatedMargin   types.ExDecimal   `json:"isolatedMargin"`
-		IsAutoAddMargin  string            `json:"isAutoAddMargin"`
-		PositionSide     string            `json:"positionSide"`
-		Notional         types.ExDecimal   `json:"notional"`
-		IsolatedWallet   types.ExDecimal   `json:"isolatedWallet"`
-		UpdateTime       types.ExTimestamp `json:"updateTime"`
-		Isolated         bool              `json:"isolated"`
-		AdlQuantile      int               `json:"adlQuantile"`
+	req := types.NewExValues()
+	req.SetQuery("symbol", market.ID)
+	if limit > 0 {
+		req.SetQuery("limit", limit)
+	}
+	if !since.IsZero() {
+		req.SetQuery("startTime", since.UnixMilli())
 	}
 
-	if err := json.Unmarshal(resp, &respData); err != nil {
-		return nil, fmt.Errorf("unmarshal positions: %w", err)
+	path := perpPath(market, "/fapi/v1/aggTrades")
+	resp, err := p.httpClient(path).Get(ctx, req.JoinPath(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch agg trades: %w", err)
 	}
 
-	positions := make([]*model.Position, 0)
-	for _, item := range respData {
-		positionAmt, _ := item.PositionAmt.Float64()
-		if positionAmt == 0 {
-			continue // 跳过空仓
-		}
+	var respData []binanceAggTrade
+	if err = json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal agg trades: %w", err)
+	}
 
-		// 获取市场信息（通过 ID 查找）
-		market, err := p.GetMarket(item.Symbol)
-		if err != nil {
-			continue
-		}
+	return toAggTrades(market.Symbol, respData), nil
+}
 
-		var side string
-		amount := item.PositionAmt
-		if positionAmt > 0 {
-			side = string(types.PositionSideLong)
-		} else {
-			side = string(types.PositionSideShort)
-			amount = types.ExDecimal{Decimal: amount.Neg()}
-		}
+// FetchFundingRate 获取当期资金费率（/fapi/v1/premiumIndex）
+func (p *BinancePerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
 
-		position := &model.Position{
-			Symbol:           market.Symbol,
-			Side:             side,
-			Amount:           amount,
-			EntryPrice:       item.EntryPrice,
-			MarkPrice:        item.MarkPrice,
-			LiquidationPrice: item.LiquidationPrice,
-			UnrealizedPnl:    item.UnRealizedProfit,
-			RealizedPnl:      types.ExDecimal{},
-			Leverage:         item.Leverage,
-			Margin:           item.IsolatedMargin,
-			Percentage:       types.ExDecimal{},
-			Timestamp:        item.UpdateTime,
-		}
+	path := perpPath(market, "/fapi/v1/premiumIndex")
+	resp, err := p.httpClient(path).Get(ctx, path, map[string]interface{}{
+		"symbol": market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate: %w", err)
+	}
 
-		positions = append(positions, position)
+	// dapi 传 symbol 时仍返回数组
+	items, err := decodeObjectOrArray[binancePerpPremiumIndexResponse](resp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate: %w", err)
 	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("funding rate not found: %s", symbol)
+	}
+	data := items[0]
 
-	return positions, nil
+	return &model.FundingRate{
+		Symbol:          market.Symbol,
+		Rate:            data.LastFundingRate,
+		Timestamp:       data.Time,
+		NextFundingTime: data.NextFundingTime,
+	}, nil
 }
 
+// FetchFundingRateHistory 获取历史资金费率（/fapi/v1/fundingRate）
+func (p *BinancePerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("symbol", market.ID)
+	if !since.IsZero() {
+		req.SetQuery("startTime", since.UnixMilli())
+	}
+	if limit > 0 {
+		req.SetQuery("limit", limit)
+	}
+
+	path := perpPath(market, "/fapi/v1/fundingRate")
+	resp, err := p.httpClient(path).Get(ctx, req.JoinPath(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate history: %w", err)
+	}
+
+	var respData []binancePerpFundingRateResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate history: %w", err)
+	}
+
+	rates := make(model.FundingRates, 0, len(respData))
+	for _, item := range respData {
+		rates = append(rates, &model.FundingRate{
+			Symbol:    market.Symbol,
+			Rate:      item.FundingRate,
+			Timestamp: item.FundingTime,
+		})
+	}
+	return rates, nil
+}
+
+// FetchOpenInterest 获取持仓量（/fapi/v1/openInterest），U 本位合约按标记价格计算持仓价值，币本位合约按每张面值计算
+func (p *BinancePerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	path := perpPath(market, "/fapi/v1/openInterest")
+	resp, err := p.httpClient(path).Get(ctx, path, map[string]interface{}{
+		"symbol": market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch open interest: %w", err)
+	}
+
+	var data binancePerpOpenInterestResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal open interest: %w", err)
+	}
+
+	oi := &model.OpenInterest{
+		Symbol:             market.Symbol,
+		OpenInterestAmount: data.OpenInterest,
+		Timestamp:          data.Time,
+	}
+
+	// 币本位合约持仓量为张数，每张面值 ContractValue 美元
+	if market.Inverse {
+		contractValue, err := decimal.NewFromString(market.ContractValue)
+		if err != nil {
+			contractValue = decimal.NewFromInt(1)
+		}
+		oi.OpenInterestValue = types.ExDecimal{Decimal: data.OpenInterest.Mul(contractValue)}
+		return oi, nil
+	}
+
+	path = perpPath(market, "/fapi/v1/premiumIndex")
+	resp, err = p.httpClient(path).Get(ctx, path, map[string]interface{}{
+		"symbol": market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch mark price: %w", err)
+	}
+	items, err := decodeObjectOrArray[binancePerpPremiumIndexResponse](resp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal mark price: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("mark price not found: %s", symbol)
+	}
+	oi.OpenInterestValue = types.ExDecimal{Decimal: data.OpenInterest.Mul(items[0].MarkPrice.Decimal)}
+
+	return oi, nil
+}
+
+// FetchMarkPrice 获取标记价格和指数价格（/fapi/v1/premiumIndex），接口不返回最新成交价
+func (p *BinancePerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	path := perpPath(market, "/fapi/v1/premiumIndex")
+	resp, err := p.httpClient(path).Get(ctx, path, map[string]interface{}{
+		"symbol": market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch mark price: %w", err)
+	}
+
+	// dapi 传 symbol 时仍返回数组
+	items, err := decodeObjectOrArray[binancePerpPremiumIndexResponse](resp)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal mark price: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("mark price not found: %s", symbol)
+	}
+
+	return &model.Ticker{
+		Symbol:     market.Symbol,
+		MarkPrice:  items[0].MarkPrice,
+		IndexPrice: items[0].IndexPrice,
+		Timestamp:  items[0].Time,
+	}, nil
+}
+
+// FetchIndexPrice 获取指数价格（/fapi/v1/premiumIndex）
+func (p *BinancePerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.FetchMarkPrice(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
+// FetchPositions 获取持仓
+func (p *BinancePerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	// 解析参数
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 未指定交易对时同时查询 U本位和币本位合约持仓
+	paths := []string{"/fapi/v2/positionRisk", "/dapi/v1/positionRisk"}
+	var market *model.Market
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		var err error
+		market, err = p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		paths = []string{perpPath(market, "/fapi/v2/positionRisk")}
+	}
+
+	var respData []binancePerpPositionResponse
+	for _, path := range paths {
+		req := types.NewExValues()
+		if market != nil {
+			if market.Inverse {
+				// dapi 按标的对查询，如 BTCUSD
+				req.SetQuery("pair", market.Base+market.Quote)
+			} else {
+				req.SetQuery("symbol", market.ID)
+			}
+		}
+
+		resp, err := p.signAndRequest(ctx, "GET", path, req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch positions: %w", err)
+		}
+
+		var items []binancePerpPositionResponse
+		if err := json.Unmarshal(resp, &items); err != nil {
+			return nil, fmt.Errorf("unmarshal positions: %w", err)
+		}
+		respData = append(respData, items...)
+	}
+
+	positions := make([]*model.Position, 0)
+	for _, item := range respData {
+		positionAmt, _ := item.PositionAmt.Float64()
+		if positionAmt == 0 {
+			continue // 跳过空仓
+		}
+		if market != nil && item.Symbol != market.ID {
+			continue
+		}
+
+		// 获取市场信息（通过 ID 查找）
+		market, err := p.GetMarket(item.Symbol)
+		if err != nil {
+			continue
+		}
+
+		var side string
+		amount := item.PositionAmt
+		if positionAmt > 0 {
+			side = string(types.PositionSideLong)
+		} else {
+			side = string(types.PositionSideShort)
+			amount = types.ExDecimal{Decimal: amount.Neg()}
+		}
+
+		position := &model.Position{
+			Symbol:           market.Symbol,
+			Side:             side,
+			Amount:           amount,
+			EntryPrice:       item.EntryPrice,
+			MarkPrice:        item.MarkPrice,
+			LiquidationPrice: item.LiquidationPrice,
+			UnrealizedPnl:    item.UnRealizedProfit,
+			RealizedPnl:      types.ExDecimal{},
+			Leverage:         item.Leverage,
+			Margin:           item.IsolatedMargin,
+			Percentage:       types.ExDecimal{},
+			Timestamp:        item.UpdateTime,
+		}
+
+		positions = append(positions, position)
+	}
+
+	return positions, nil
+}
+
+// WatchPositions 轮询持仓并推送持仓变化，同时订阅U本位合约用户数据流，强平、自动减仓导致的减仓/平仓事件填充 Reason
+// 订单订阅失败时仍推送持仓变化，但不填充 Reason
+func (p *BinancePerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := p.WatchOrders(ctx)
+	if err != nil {
+		orders = nil
+	}
+	return common.PollPositionsWithOrders(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	}, orders)
+}
+
+// WatchOrders 通过 U本位合约用户数据流订阅订单更新，订单每次状态变化推送一次（币本位合约订单不推送）
+func (p *BinancePerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.binance.perpUserWS, binancePerpOrderUpdateEvent, parseBinancePerpWSOrderUpdate(p.marketSymbol))
+}
+
+// WatchBalance 通过 U本位合约用户数据流订阅合约账户余额更新，每次余额变化推送变化币种的最新余额
+func (p *BinancePerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.binance.perpUserWS, binancePerpAccountUpdateEvent, parseBinancePerpWSAccountUpdate)
+}
+
+// marketSymbol 将市场ID转换为标准化交易对，市场信息未加载时返回原始ID
+func (p *BinancePerp) marketSymbol(marketID string) string {
+	if market, err := p.GetMarket(marketID); err == nil {
+		return market.Symbol
+	}
+	return marketID
+}
+
+// 跟踪止损回调比例范围（百分比）
+var (
+	binanceMinCallbackRate = decimal.NewFromFloat(0.1)
+	binanceMaxCallbackRate = decimal.NewFromInt(5)
+)
+
 // CreateOrder 创建订单
 func (p *BinancePerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	order, err := common.CreateOrderIdempotent(ctx, p.binance.client.PerpClient, p.binance.Name(), orderSide.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return p.createOrder(ctx, symbol, amount, orderSide, orderType, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.PerpOrder, error) {
+		return p.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		p.binance.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+// binancePerpOrderParams 已构建好的下单参数（单笔下单和批量下单共用）
+type binancePerpOrderParams struct {
+	symbol        string
+	market        *model.Market
+	req           *types.ExValues
+	clientOrderID string
+	stopPrice     decimal.Decimal
+	callbackRate  decimal.Decimal
+	strict        bool
+}
+
+// createOrder 创建订单
+func (p *BinancePerp) createOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	params, err := p.buildOrderParams(symbol, amount, orderSide, orderType, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", perpPath(params.market, "/fapi/v1/order"), params.req)
+	if err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	// 解析响应（合约订单响应）
+	var respData binancePerpCreateOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal contract order response: %w", err)
+	}
+	if respData.OrderID == 0 {
+		return nil, fmt.Errorf("create order: missing orderId in response")
+	}
+
+	return params.toNewOrder(&respData)
+}
+
+// toNewOrder 将下单响应转换为 NewOrder，开启严格校验时检查客户端订单ID
+func (params *binancePerpOrderParams) toNewOrder(respData *binancePerpCreateOrderResponse) (*model.NewOrder, error) {
+	perpOrder := &model.NewOrder{
+		Symbol:        params.symbol,
+		OrderId:       strconv.FormatInt(respData.OrderID, 10),
+		ClientOrderID: respData.ClientOrderID,
+		Timestamp:     respData.UpdateTime,
+		TriggerPrice:  types.ExDecimal{Decimal: params.stopPrice},
+		CallbackRate:  types.ExDecimal{Decimal: params.callbackRate},
+	}
+
+	if params.strict {
+		if err := common.CheckClientOrderID(params.clientOrderID, perpOrder.ClientOrderID); err != nil {
+			return perpOrder, err
+		}
+	}
+
+	return perpOrder, nil
+}
+
+// buildOrderParams 校验并构建下单参数（不包含 timestamp 和 signature）
+func (p *BinancePerp) buildOrderParams(symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*binancePerpOrderParams, error) {
 	// 解析订单选项
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -546,76 +1187,219 @@ func (p *BinancePerp) CreateOrder(ctx context.Context, symbol string, amount str
 		if !ok || price.IsZero() {
 			return nil, fmt.Errorf("limit order requires price")
 		}
-		req.SetQuery("price", price.String())
+		priceStr, err := common.PriceToPrecision(market, price.String())
+		if err != nil {
+			return nil, err
+		}
+		req.SetQuery("price", priceStr)
 		// Limit 单默认使用 GTC
 		req.SetQuery("timeInForce", option.GTC.Upper())
 	}
 
-	if argsOpts.TimeInForce != nil {
+	// 市价单不接受 timeInForce
+	if err := common.CheckTimeInForce(argsOpts, orderType == option.Limit, false); err != nil {
+		return nil, err
+	}
+	if orderType == option.Limit && argsOpts.TimeInForce != nil {
 		req.SetQuery("timeInForce", argsOpts.TimeInForce.Upper())
 	}
 
-	// 设置数量
-	if quantity, ok := option.GetDecimalFromString(&amount); ok {
-		req.SetQuery("quantity", quantity.String())
-	} else {
+	// 只做 Maker 的限价单使用 GTX
+	postOnly, err := common.ParsePostOnly(argsOpts, orderType == option.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if postOnly {
+		req.SetQuery("timeInForce", "GTX")
+	}
+
+	// 设置数量（向下对齐到数量步长）
+	quantity, ok := option.GetDecimalFromString(&amount)
+	if !ok {
 		return nil, fmt.Errorf("amount is required and must be a valid decimal")
 	}
+	quantityStr, err := common.AmountToPrecision(market, quantity.String())
+	if err != nil {
+		return nil, err
+	}
+	req.SetQuery("quantity", quantityStr)
 
 	// 设置订单方向和类型
 	req.SetQuery("side", orderSide.ToSide())
-	if orderSide.ToReduceOnly() {
-		req.SetQuery("reduceOnly", "true")
-	} else {
-		req.SetQuery("reduceOnly", "false")
-	}
 	req.SetQuery("type", orderType.Upper())
 
-	if hedgeMode, ok := option.GetBool(argsOpts.HedgeMode); hedgeMode && ok {
-		// 双向持仓模式
+	// 设置触发价时为条件单：STOP_MARKET/TAKE_PROFIT_MARKET（市价）或 STOP/TAKE_PROFIT（限价）
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if isStop {
+		stopType := "STOP"
+		if triggerType.IsTakeProfit() {
+			stopType = "TAKE_PROFIT"
+		}
+		if orderType == option.Market {
+			stopType += "_MARKET"
+		}
+		req.SetQuery("type", stopType)
+		req.SetQuery("stopPrice", stopPrice.String())
+	}
+
+	// 跟踪止损：TRAILING_STOP_MARKET，回调比例 0.1%~5%
+	callbackRate, activationPrice, isTrailing, err := common.ParseTrailingStop(argsOpts, binanceMinCallbackRate, binanceMaxCallbackRate)
+	if err != nil {
+		return nil, err
+	}
+	if isTrailing {
+		if orderType != option.Market {
+			return nil, fmt.Errorf("trailing stop requires a market order: %w", common.ErrInvalidOrder)
+		}
+		req.SetQuery("type", "TRAILING_STOP_MARKET")
+		req.SetQuery("callbackRate", callbackRate.String())
+		if activationPrice.IsPositive() {
+			activationPriceStr, err := common.PriceToPrecision(market, activationPrice.String())
+			if err != nil {
+				return nil, err
+			}
+			req.SetQuery("activationPrice", activationPriceStr)
+		}
+	}
+
+	hedged := p.positionMode.Hedged(argsOpts.HedgeMode)
+	if market.Inverse {
+		hedged, _ = option.GetBool(argsOpts.HedgeMode)
+	}
+	if hedged {
+		// 双向持仓模式，由 positionSide 区分多空仓位，Binance 不接受 reduceOnly 参数（平仓单本身只减仓）
 		// 开多/平多: positionSide=LONG
 		// 开空/平空: positionSide=SHORT
 		req.SetQuery("positionSide", orderSide.ToPositionSide())
 	} else {
+		// 单向持仓模式，平仓单通过 reduceOnly 避免反向开仓
 		req.SetQuery("positionSide", "BOTH")
+		if common.ReduceOnly(orderSide, argsOpts) {
+			req.SetQuery("reduceOnly", "true")
+		} else {
+			req.SetQuery("reduceOnly", "false")
+		}
 	}
 
-	if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
-		req.SetQuery("newClientOrderId", clientOrderId)
-	} else {
+	clientOrderID, ok := option.GetString(argsOpts.ClientOrderID)
+	if !ok {
 		// 生成订单 ID
-		generatedID := common.GenerateClientOrderID(p.binance.Name(), orderSide.ToSide())
-		req.SetQuery("newClientOrderId", generatedID)
+		clientOrderID = common.GenerateClientOrderID(p.binance.Name(), orderSide.ToSide())
+	}
+	req.SetQuery("newClientOrderId", clientOrderID)
+
+	strict, ok := option.GetBool(argsOpts.StrictClientID)
+	return &binancePerpOrderParams{
+		symbol:        symbol,
+		market:        market,
+		req:           req,
+		clientOrderID: clientOrderID,
+		stopPrice:     stopPrice,
+		callbackRate:  callbackRate,
+		strict:        ok && strict,
+	}, nil
+}
+
+// CreateOrders 批量创建订单（POST /fapi/v1/batchOrders，每批最多 5 个）
+// U本位和币本位合约分别提交；参数校验失败的订单不提交，其错误写入对应位置
+func (p *BinancePerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
+
+	var items []common.BatchItem[*binancePerpOrderParams]
+	for i, r := range requests {
+		params, err := p.buildOrderParams(r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, common.BatchItem[*binancePerpOrderParams]{
+			Index:  i,
+			Group:  perpPath(params.market, "/fapi/v1/batchOrders"),
+			Params: params,
+		})
+	}
+
+	err := common.SubmitBatches(ctx, items, 5, orders, errs, p.submitBatchOrders)
+	for _, order := range orders {
+		if order != nil {
+			p.binance.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: order.Symbol, OrderID: order.OrderId})
+		}
 	}
+	return orders, errs, err
+}
 
-	resp, err := p.signAndRequest(ctx, "POST", "/fapi/v1/order", req)
+// submitBatchOrders 提交一批订单，响应数组中每一项为订单或 {"code","msg"} 错误
+func (p *BinancePerp) submitBatchOrders(ctx context.Context, path string, batch []*binancePerpOrderParams) ([]*model.NewOrder, []error, error) {
+	list := make([]map[string]any, len(batch))
+	for i, params := range batch {
+		list[i] = params.req.ToQueryMap()
+	}
+	batchOrders, err := json.Marshal(list)
 	if err != nil {
-		return nil, fmt.Errorf("create order: %w", err)
+		return nil, nil, fmt.Errorf("marshal batch orders: %w", err)
 	}
 
-	// 解析响应（合约订单响应）
-	var respData struct {
-		OrderID       int64             `json:"orderId"`       // 系统订单号
-		ClientOrderID string            `json:"clientOrderId"` // 客户端订单ID
-		UpdateTime    types.ExTimestamp `json:"updateTime"`    // 更新时间（毫秒时间戳）
+	req := types.NewExValues()
+	req.SetQuery("batchOrders", string(batchOrders))
+	resp, err := p.signAndRequest(ctx, "POST", path, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create orders: %w", err)
 	}
+
+	var respData []binancePerpBatchOrderResponse
 	if err := json.Unmarshal(resp, &respData); err != nil {
-		return nil, fmt.Errorf("unmarshal contract order response: %w", err)
+		return nil, nil, fmt.Errorf("unmarshal batch orders response: %w", err)
+	}
+	if len(respData) != len(batch) {
+		return nil, nil, fmt.Errorf("create orders: expected %d results, got %d", len(batch), len(respData))
 	}
 
-	// 构建 NewOrder 对象
-	perpOrder := &model.NewOrder{
-		Symbol:        symbol,
-		OrderId:       strconv.FormatInt(respData.OrderID, 10),
-		ClientOrderID: respData.ClientOrderID,
-		Timestamp:     respData.UpdateTime,
+	orders := make([]*model.NewOrder, len(batch))
+	errs := make([]error, len(batch))
+	for i := range respData {
+		if respData[i].Code != 0 {
+			errs[i] = common.NewExchangeError("binance", strconv.Itoa(respData[i].Code), respData[i].Msg, binanceErrorCodes)
+			continue
+		}
+		orders[i], errs[i] = batch[i].toNewOrder(&respData[i].binancePerpCreateOrderResponse)
 	}
+	return orders, errs, nil
+}
 
-	return perpOrder, nil
+// ClosePosition 以只减仓市价单平掉持仓，数量与持仓单位一致（U本位为币数量，币本位为张数）
+func (p *BinancePerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	_, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
 }
 
 // CancelOrder 取消订单
 func (p *BinancePerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if err := p.cancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	p.binance.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+// cancelOrder 取消订单
+func (p *BinancePerp) cancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -640,12 +1424,68 @@ func (p *BinancePerp) CancelOrder(ctx context.Context, symbol string, orderId st
 		return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
-	_, err = p.signAndRequest(ctx, "DELETE", "/fapi/v1/order", req)
+	_, err = p.signAndRequest(ctx, "DELETE", perpPath(market, "/fapi/v1/order"), req)
 	return err
 }
 
+// EditOrder 修改挂单（PUT /fapi/v1/order）
+// Binance 改单要求同时提供方向、数量和价格，未提供的数量或价格沿用当前订单的值
+func (p *BinancePerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// 查询当前订单，获取方向以及未修改的数量或价格
+	current, err := p.FetchOrder(ctx, symbol, orderId, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if newAmount == "" {
+		newAmount = current.Quantity.String()
+	}
+	if newPrice == "" {
+		newPrice = current.Price.String()
+	}
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("symbol", market.ID)
+	req.SetQuery("orderId", current.ID)
+	req.SetQuery("side", strings.ToUpper(current.Side))
+	req.SetQuery("quantity", newAmount)
+	req.SetQuery("price", newPrice)
+
+	resp, err := p.signAndRequest(ctx, "PUT", perpPath(market, "/fapi/v1/order"), req)
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var respData binancePerpFetchOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	if respData.OrderID == 0 {
+		return nil, fmt.Errorf("edit order: empty order in response")
+	}
+
+	return toBinancePerpOrder(symbol, &respData), nil
+}
+
 // FetchOrder 查询订单
 func (p *BinancePerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -669,29 +1509,13 @@ func (p *BinancePerp) FetchOrder(ctx context.Context, symbol string, orderId str
 		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
-	resp, err := p.signAndRequest(ctx, "GET", "/fapi/v1/order", req)
+	resp, err := p.signAndRequest(ctx, "GET", perpPath(market, "/fapi/v1/order"), req)
 	if err != nil {
 		return nil, fmt.Errorf("fetch order: %w", err)
 	}
 
 	// 解析响应
-	var respData struct {
-		OrderID       int64             `json:"orderId"`       // 订单ID（交易所唯一）
-		ClientOrderID string            `json:"clientOrderId"` // 客户端自定义订单ID
-		Symbol        string            `json:"symbol"`        // 交易对 / 合约标的
-		Price         types.ExDecimal   `json:"price"`         // 下单价格（市价单通常为0）
-		AvgPrice      types.ExDecimal   `json:"avgPrice"`      // 成交均价
-		OrigQty       types.ExDecimal   `json:"origQty"`       // 下单数量
-		ExecutedQty   types.ExDecimal   `json:"executedQty"`   // 实际成交数量
-		Status        string            `json:"status"`        // 订单状态
-		TimeInForce   string            `json:"timeInForce"`   // 订单有效方式
-		ReduceOnly    bool              `json:"reduceOnly"`    // 是否只减仓
-		Time          types.ExTimestamp `json:"time"`          // 创建时间（毫秒）
-		Type          string            `json:"type"`          // 订单类型
-		Side          string            `json:"side"`          // 订单方向
-		PositionSide  string            `json:"positionSide"`  // 单向持仓 BOTH，双向持仓 LONG / SHORT
-		UpdateTime    types.ExTimestamp `json:"updateTime"`    // 更新时间（毫秒）
-	}
+	var respData binancePerpFetchOrderResponse
 	if err := json.Unmarshal(resp, &respData); err != nil {
 		return nil, fmt.Errorf("unmarshal order: %w", err)
 	}
@@ -701,7 +1525,134 @@ func (p *BinancePerp) FetchOrder(ctx context.Context, symbol string, orderId str
 		return nil, fmt.Errorf("order not found")
 	}
 
-	// 将 Binance 响应转换为 model.PerpOrder
+	return toBinancePerpOrder(symbol, &respData), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (p *BinancePerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchOrders 查询交易对的历史订单（/fapi/v1/allOrders），since 为零值时不限制开始时间，limit <= 0 使用交易所默认值
+func (p *BinancePerp) FetchOrders(ctx context.Context, symbol string, since time.Time, limit int) ([]*model.PerpOrder, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("symbol", market.ID)
+	if !since.IsZero() {
+		req.SetQuery("startTime", since.UnixMilli())
+	}
+	if limit > 0 {
+		req.SetQuery("limit", limit)
+	}
+
+	resp, err := p.signAndRequest(ctx, "GET", perpPath(market, "/fapi/v1/allOrders"), req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch orders: %w", err)
+	}
+
+	var respData []binancePerpFetchOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal orders: %w", err)
+	}
+
+	orders := make([]*model.PerpOrder, 0, len(respData))
+	for i := range respData {
+		orders = append(orders, toBinancePerpOrder(market.Symbol, &respData[i]))
+	}
+	return orders, nil
+}
+
+// FetchOpenOrders 查询当前挂单（/fapi/v1/openOrders），symbol 为空时同时查询 U本位和币本位合约挂单并合并，按返回的合约 ID 还原标准化符号
+func (p *BinancePerp) FetchOpenOrders(ctx context.Context, symbol string) ([]*model.PerpOrder, error) {
+	paths := []string{"/fapi/v1/openOrders", "/dapi/v1/openOrders"}
+	var market *model.Market
+	if symbol != "" {
+		var err error
+		market, err = p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		paths = []string{perpPath(market, "/fapi/v1/openOrders")}
+	}
+
+	var respData []binancePerpFetchOrderResponse
+	for _, path := range paths {
+		req := types.NewExValues()
+		if market != nil {
+			req.SetQuery("symbol", market.ID)
+		}
+
+		resp, err := p.signAndRequest(ctx, "GET", path, req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch open orders: %w", err)
+		}
+
+		var items []binancePerpFetchOrderResponse
+		if err := json.Unmarshal(resp, &items); err != nil {
+			return nil, fmt.Errorf("unmarshal open orders: %w", err)
+		}
+		respData = append(respData, items...)
+	}
+
+	orders := make([]*model.PerpOrder, 0, len(respData))
+	for i := range respData {
+		// 未加载的合约保留原始 ID
+		orderSymbol := respData[i].Symbol
+		if market, err := p.GetMarketByID(respData[i].Symbol); err == nil {
+			orderSymbol = market.Symbol
+		}
+		orders = append(orders, toBinancePerpOrder(orderSymbol, &respData[i]))
+	}
+	return orders, nil
+}
+
+// FetchMyTradesRange 查询账户成交记录（/fapi/v1/userTrades，币本位为 /dapi/v1/userTrades），按 7 天时间窗口和 fromId 翻页
+func (p *BinancePerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.FetchTradesRange(ctx, since, until, binanceTradePages(7*24*time.Hour, func(ctx context.Context, params map[string]interface{}) ([]*model.Trade, error) {
+		req := types.NewExValues()
+		req.SetQuery("symbol", market.ID)
+		for k, v := range params {
+			req.SetQuery(k, v)
+		}
+
+		resp, err := p.signAndRequest(ctx, "GET", perpPath(market, "/fapi/v1/userTrades"), req)
+		if err != nil {
+			return nil, fmt.Errorf("fetch my trades: %w", err)
+		}
+
+		var data []binancePerpUserTrade
+		if err := json.Unmarshal(resp, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal my trades: %w", err)
+		}
+
+		trades := make([]*model.Trade, 0, len(data))
+		for _, item := range data {
+			trades = append(trades, &model.Trade{
+				ID:        strconv.FormatInt(item.ID, 10),
+				OrderID:   strconv.FormatInt(item.OrderID, 10),
+				Symbol:    market.Symbol,
+				Side:      strings.ToLower(item.Side),
+				Amount:    item.Qty.Decimal,
+				Price:     item.Price.Decimal,
+				Cost:      item.QuoteQty.Decimal,
+				Timestamp: item.Time.Time,
+			})
+		}
+		return trades, nil
+	}))
+}
+
+// toBinancePerpOrder 将 Binance 响应转换为 model.PerpOrder
+func toBinancePerpOrder(symbol string, respData *binancePerpFetchOrderResponse) *model.PerpOrder {
 	order := &model.PerpOrder{
 		ID:               strconv.FormatInt(respData.OrderID, 10),
 		ClientID:         respData.ClientOrderID,
@@ -718,9 +1669,49 @@ func (p *BinancePerp) FetchOrder(ctx context.Context, symbol string, orderId str
 		ReduceOnly:       respData.ReduceOnly,
 		CreateTime:       respData.Time,
 		UpdateTime:       respData.UpdateTime,
+		Reason:           binancePerpOrderReason(respData.ClientOrderID),
 	}
 
-	return order, nil
+	return order
+}
+
+// binancePerpOrderReason 根据系统订单的 clientOrderId 识别强平（autoclose-）和自动减仓（adl_autoclose）订单
+func binancePerpOrderReason(clientOrderID string) string {
+	switch {
+	case strings.HasPrefix(clientOrderID, "autoclose-"):
+		return model.PositionReasonLiquidation
+	case strings.HasPrefix(clientOrderID, "adl_autoclose"):
+		return model.PositionReasonADL
+	default:
+		return ""
+	}
+}
+
+// TrackOrder 通过 U本位合约用户数据流跟踪订单成交进度，断线重连后查询订单补齐遗漏的成交；
+// 币本位合约订单不在用户数据流中推送，改为轮询订单
+func (p *BinancePerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := p.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if market.Inverse {
+		return common.PollOrderFills(ctx, orderId, symbol, 0, func(ctx context.Context) (*common.OrderFillSnapshot, error) {
+			order, err := p.FetchOrder(ctx, symbol, orderId)
+			if err != nil {
+				return nil, err
+			}
+			return common.PerpOrderFillSnapshot(order), nil
+		})
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.PerpOrder]) (<-chan []*model.PerpOrder, error) {
+		return common.WatchTopicResync(ctx, p.binance.perpUserWS, binancePerpOrderUpdateEvent, binanceWSItems(parseBinancePerpWSOrderUpdate(p.marketSymbol)), resync)
+	}, func(ctx context.Context) (*model.PerpOrder, error) {
+		return p.FetchOrder(ctx, symbol, orderId)
+	}, common.PerpOrderFillSnapshot)
 }
 
 // SetLeverage 设置杠杆
@@ -743,10 +1734,15 @@ func (p *BinancePerp) SetLeverage(ctx context.Context, symbol string, leverage i
 	}
 	req.SetQuery("leverage", leverage)
 
-	_, err = p.signAndRequest(ctx, "POST", "/fapi/v1/leverage", req)
+	_, err = p.signAndRequest(ctx, "POST", perpPath(market, "/fapi/v1/leverage"), req)
 	return err
 }
 
+// SetLeverageSide Binance 杠杆按交易对设置，双向持仓模式下多空也共用同一杠杆，不支持单独设置
+func (p *BinancePerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	return fmt.Errorf("set leverage side: %w: binance leverage is per symbol, shared by long and short", common.ErrNotSupported)
+}
+
 // SetMarginType 设置保证金类型
 func (p *BinancePerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
 	argsOpts := &option.ExchangeArgsOptions{}
@@ -763,9 +1759,36 @@ func (p *BinancePerp) SetMarginType(ctx context.Context, symbol string, marginTy
 	req.SetQuery("symbol", market.ID)
 	req.SetQuery("marginType", marginType.Upper())
 
-	_, err = p.signAndRequest(ctx, "POST", "/fapi/v1/marginType", req)
+	_, err = p.signAndRequest(ctx, "POST", perpPath(market, "/fapi/v1/marginType"), req)
 	return err
 }
 
+// SetPositionMode 设置 U本位合约账户持仓模式（POST /fapi/v1/positionSide/dual），账户有持仓或挂单时无法修改
+func (p *BinancePerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	req := types.NewExValues()
+	req.SetQuery("dualSidePosition", strconv.FormatBool(hedged))
+
+	if _, err := p.signAndRequest(ctx, "POST", "/fapi/v1/positionSide/dual", req); err != nil {
+		return fmt.Errorf("set position mode: %w", err)
+	}
+	p.positionMode.Set(hedged)
+	return nil
+}
+
+// GetPositionMode 查询 U本位合约账户持仓模式（GET /fapi/v1/positionSide/dual）
+func (p *BinancePerp) GetPositionMode(ctx context.Context) (bool, error) {
+	resp, err := p.signAndRequest(ctx, "GET", "/fapi/v1/positionSide/dual", types.NewExValues())
+	if err != nil {
+		return false, fmt.Errorf("get position mode: %w", err)
+	}
+
+	var data binancePerpPositionModeResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return false, fmt.Errorf("unmarshal position mode: %w", err)
+	}
+	p.positionMode.Set(data.DualSidePosition)
+	return data.DualSidePosition, nil
+}
+
 // 确保 BinancePerp 实现了 exchange.PerpExchange 接口
 var _ exchange.PerpExchange = (*BinancePerp)(nil)
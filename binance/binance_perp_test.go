@@ -2,10 +2,19 @@ package binance
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -194,3 +203,922 @@ func TestBinancePerp_FetchTickers(t *testing.T) {
 		t.Logf("First ticker: %s, Last=%s", ticker.Symbol, ticker.Last.String())
 	}
 }
+
+// binancePerpTickerHandler 模拟永续合约 24hr 行情（传 symbol 返回对象，否则返回数组）和 bookTicker 接口
+func binancePerpTickerHandler(t *testing.T) http.HandlerFunc {
+	tickers := map[string]string{
+		"BTCUSDT": `{"symbol":"BTCUSDT","priceChange":"100","priceChangePercent":"0.2","weightedAvgPrice":"50010",
+			"lastPrice":"50100","lastQty":"0.01","openPrice":"50000","highPrice":"51000","lowPrice":"49000",
+			"volume":"1000","quoteVolume":"50010000","openTime":1699913600000,"closeTime":1700000000789,
+			"firstId":1,"lastId":100,"count":100}`,
+		"ETHUSDT": `{"symbol":"ETHUSDT","priceChange":"10","priceChangePercent":"0.3","weightedAvgPrice":"3001",
+			"lastPrice":"3010","lastQty":"0.1","openPrice":"3000","highPrice":"3100","lowPrice":"2900",
+			"volume":"5000","quoteVolume":"15005000","openTime":1699913600000,"closeTime":1700000000789,
+			"firstId":1,"lastId":50,"count":50}`,
+	}
+	books := map[string]string{
+		"BTCUSDT": `{"symbol":"BTCUSDT","bidPrice":"50099.9","bidQty":"3","askPrice":"50100.1","askQty":"4","time":1700000000800}`,
+		"ETHUSDT": `{"symbol":"ETHUSDT","bidPrice":"3009.9","bidQty":"30","askPrice":"3010.1","askQty":"40","time":1700000000800}`,
+	}
+
+	respond := func(w http.ResponseWriter, r *http.Request, data map[string]string) {
+		if symbol := r.URL.Query().Get("symbol"); symbol != "" {
+			w.Write([]byte(data[symbol]))
+			return
+		}
+		w.Write([]byte("[" + data["BTCUSDT"] + "," + data["ETHUSDT"] + "]"))
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/ticker/24hr":
+			respond(w, r, tickers)
+		case "/fapi/v1/ticker/bookTicker":
+			respond(w, r, books)
+		case "/dapi/v1/ticker/24hr", "/dapi/v1/ticker/bookTicker":
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newTestBinancePerp(t *testing.T, handler http.HandlerFunc) *Binance {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ex, err := NewBinance("", "", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"},
+		{ID: "ETHUSDT", Symbol: "ETH/USDT:USDT", Base: "ETH", Quote: "USDT", Settle: "USDT"},
+	} {
+		e.perpMarketsBySymbol[market.Symbol] = market
+		e.perpMarketsByID[market.ID] = market
+	}
+	return e
+}
+
+func TestBinancePerp_FetchTicker_ServerTimestamp(t *testing.T) {
+	ex := newTestBinancePerp(t, binancePerpTickerHandler(t))
+
+	ticker, err := ex.Perp().FetchTicker(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if got := ticker.Timestamp.UnixMilli(); got != 1700000000789 {
+		t.Errorf("Timestamp = %d, want server closeTime 1700000000789", got)
+	}
+}
+
+func TestBinancePerp_FetchTicker_BookTicker(t *testing.T) {
+	ex := newTestBinancePerp(t, binancePerpTickerHandler(t))
+
+	ticker, err := ex.Perp().FetchTicker(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Bid.String() != "50099.9" || ticker.Ask.String() != "50100.1" {
+		t.Errorf("Bid/Ask = %s/%s, want 50099.9/50100.1 from bookTicker", ticker.Bid, ticker.Ask)
+	}
+	if ticker.Last.String() != "50100" || ticker.TradeCount != 100 {
+		t.Errorf("Last = %s, TradeCount = %d, want 50100 and 100", ticker.Last, ticker.TradeCount)
+	}
+}
+
+func TestBinancePerp_FetchTickers_BookTicker(t *testing.T) {
+	ex := newTestBinancePerp(t, binancePerpTickerHandler(t))
+
+	tickers, err := ex.Perp().FetchTickers(context.Background())
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	if len(tickers) != 2 {
+		t.Fatalf("got %d tickers, want 2", len(tickers))
+	}
+	for _, ticker := range tickers {
+		switch ticker.Symbol {
+		case "BTC/USDT:USDT":
+			if ticker.Bid.String() != "50099.9" || ticker.Ask.String() != "50100.1" {
+				t.Errorf("BTC Bid/Ask = %s/%s", ticker.Bid, ticker.Ask)
+			}
+		case "ETH/USDT:USDT":
+			if ticker.Bid.String() != "3009.9" || ticker.Ask.String() != "3010.1" {
+				t.Errorf("ETH Bid/Ask = %s/%s", ticker.Bid, ticker.Ask)
+			}
+		default:
+			t.Errorf("unexpected ticker symbol %s", ticker.Symbol)
+		}
+	}
+
+	// 传 symbol 时 24hr 和 bookTicker 均返回对象
+	tickers, err = ex.Perp().FetchTickers(context.Background(), option.WithSymbol("ETH/USDT:USDT"))
+	if err != nil {
+		t.Fatalf("FetchTickers with symbol: %v", err)
+	}
+	if len(tickers) != 1 || tickers[0].Symbol != "ETH/USDT:USDT" || tickers[0].Ask.String() != "3010.1" {
+		t.Errorf("unexpected tickers for symbol filter: %+v", tickers)
+	}
+}
+
+func TestBinance_FetchTickers_SpotAndPerp(t *testing.T) {
+	perpHandler := binancePerpTickerHandler(t)
+	var dapiCalls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v3/ticker/24hr":
+			w.Write([]byte(`[{"symbol":"BTCUSDT","lastPrice":"50000","closeTime":1700000000000},
+				{"symbol":"ETHUSDT","lastPrice":"3000","closeTime":1700000000000}]`))
+		case strings.HasPrefix(r.URL.Path, "/dapi/"):
+			dapiCalls++
+			perpHandler(w, r)
+		default:
+			perpHandler(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	e, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL, "fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ex := e.(*Binance)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"},
+		{ID: "ETHUSDT", Symbol: "ETH/USDT", Base: "ETH", Quote: "USDT"},
+	} {
+		ex.spotMarketsBySymbol[market.Symbol] = market
+		ex.spotMarketsByID[market.ID] = market
+	}
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"},
+		{ID: "ETHUSDT", Symbol: "ETH/USDT:USDT", Base: "ETH", Quote: "USDT", Settle: "USDT"},
+	} {
+		ex.perpMarketsBySymbol[market.Symbol] = market
+		ex.perpMarketsByID[market.ID] = market
+	}
+
+	tickers, err := ex.FetchTickers(context.Background(), "BTC/USDT", "ETH/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	if len(tickers) != 2 {
+		t.Fatalf("got %d tickers, want 2: %v", len(tickers), tickers)
+	}
+	if ticker := tickers["BTC/USDT"]; ticker == nil || ticker.Symbol != "BTC/USDT" || ticker.Last.String() != "50000" {
+		t.Errorf("spot ticker = %+v", ticker)
+	}
+	if ticker := tickers["ETH/USDT:USDT"]; ticker == nil || ticker.Symbol != "ETH/USDT:USDT" || ticker.Ask.String() != "3010.1" {
+		t.Errorf("perp ticker = %+v", ticker)
+	}
+	if dapiCalls != 0 {
+		t.Errorf("dapi called %d times for linear symbols only", dapiCalls)
+	}
+
+	if _, err := ex.FetchTickers(context.Background(), "DOGE/USDT"); err == nil {
+		t.Error("expected error for unknown symbol")
+	}
+}
+
+func TestBinancePerp_FetchOrderBook(t *testing.T) {
+	var gotLimit string
+	ex := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/depth" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotLimit = r.URL.Query().Get("limit")
+		w.Write([]byte(`{"lastUpdateId":1027024,"E":1700000000123,"T":1700000000100,
+			"bids":[["50000.1","1.5"],["50000","2"],["49999.9","3"],["49999.8","4"],["49999.7","5"]],
+			"asks":[["50000.2","0.5"],["50000.3","1"],["50000.4","2"],["50000.5","3"],["50000.6","4"]]}`))
+	})
+
+	// 合约只支持固定档位，3 向上取整为 5，返回结果截断为 3 档
+	book, err := ex.Perp().FetchOrderBook(context.Background(), "BTC/USDT:USDT", 3)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+	if gotLimit != "5" {
+		t.Errorf("limit = %q, want 5", gotLimit)
+	}
+	if book.Symbol != "BTC/USDT:USDT" || book.Nonce != 1027024 {
+		t.Errorf("Symbol/Nonce = %s/%d, want BTC/USDT:USDT/1027024", book.Symbol, book.Nonce)
+	}
+	if len(book.Bids) != 3 || len(book.Asks) != 3 {
+		t.Fatalf("got %d bids / %d asks, want 3/3", len(book.Bids), len(book.Asks))
+	}
+	if book.Bids[0].Price.String() != "50000.1" || book.Bids[0].Amount.String() != "1.5" {
+		t.Errorf("best bid = %s@%s, want 1.5@50000.1", book.Bids[0].Amount, book.Bids[0].Price)
+	}
+	if book.Asks[0].Price.String() != "50000.2" || book.Asks[0].Amount.String() != "0.5" {
+		t.Errorf("best ask = %s@%s, want 0.5@50000.2", book.Asks[0].Amount, book.Asks[0].Price)
+	}
+	if got := book.Timestamp.UnixMilli(); got != 1700000000100 {
+		t.Errorf("Timestamp = %d, want transaction time 1700000000100", got)
+	}
+}
+
+func TestBinancePerp_FetchOrders(t *testing.T) {
+	var gotLimit, gotSignature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/allOrders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotLimit = r.URL.Query().Get("limit")
+		gotSignature = r.URL.Query().Get("signature")
+		// 录制的 allOrders 响应
+		w.Write([]byte(`[
+			{"avgPrice":"50010.5","clientOrderId":"abc","cumQuote":"500.105","executedQty":"0.010","orderId":1917641,
+			 "origQty":"0.010","origType":"MARKET","price":"0","reduceOnly":false,"side":"BUY","positionSide":"LONG",
+			 "status":"FILLED","stopPrice":"0","closePosition":false,"symbol":"BTCUSDT","time":1700000000000,
+			 "timeInForce":"GTC","type":"MARKET","updateTime":1700000000050,"workingType":"CONTRACT_PRICE","priceProtect":false}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	orders, err := e.perp.FetchOrders(context.Background(), "BTC/USDT:USDT", time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("FetchOrders: %v", err)
+	}
+	if gotLimit != "10" || gotSignature == "" {
+		t.Errorf("limit = %q, signature = %q, want signed request with limit 10", gotLimit, gotSignature)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1", len(orders))
+	}
+	order := orders[0]
+	if order.ID != "1917641" || order.Symbol != "BTC/USDT:USDT" || order.Status != "FILLED" || order.PositionSide != "LONG" {
+		t.Errorf("order = %s %s %s %s", order.ID, order.Symbol, order.Status, order.PositionSide)
+	}
+	if order.ExecutedQuantity.String() != "0.01" || order.AvgPrice.String() != "50010.5" {
+		t.Errorf("ExecutedQuantity/AvgPrice = %s/%s, want 0.01/50010.5", order.ExecutedQuantity, order.AvgPrice)
+	}
+}
+
+func TestBinancePerp_FetchFundingRate(t *testing.T) {
+	var gotStartTime, gotLimit string
+	ex := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"50000.10","indexPrice":"50001.20","estimatedSettlePrice":"50000.50",
+				"lastFundingRate":"0.00010000","interestRate":"0.00010000","nextFundingTime":1700006400000,"time":1700000000123}`))
+		case "/fapi/v1/fundingRate":
+			gotStartTime = r.URL.Query().Get("startTime")
+			gotLimit = r.URL.Query().Get("limit")
+			w.Write([]byte(`[
+				{"symbol":"BTCUSDT","fundingRate":"0.00010000","fundingTime":1699977600000,"markPrice":"49900.1"},
+				{"symbol":"BTCUSDT","fundingRate":"-0.00002500","fundingTime":1700006400000,"markPrice":"50000.2"}
+			]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	rate, err := ex.Perp().FetchFundingRate(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchFundingRate: %v", err)
+	}
+	if rate.Symbol != "BTC/USDT:USDT" || rate.Rate.String() != "0.0001" {
+		t.Errorf("Symbol/Rate = %s/%s, want BTC/USDT:USDT/0.0001", rate.Symbol, rate.Rate)
+	}
+	if rate.Timestamp.UnixMilli() != 1700000000123 || rate.NextFundingTime.UnixMilli() != 1700006400000 {
+		t.Errorf("Timestamp/NextFundingTime = %d/%d", rate.Timestamp.UnixMilli(), rate.NextFundingTime.UnixMilli())
+	}
+
+	rates, err := ex.Perp().FetchFundingRateHistory(context.Background(), "BTC/USDT:USDT", time.UnixMilli(1699977600000), 2)
+	if err != nil {
+		t.Fatalf("FetchFundingRateHistory: %v", err)
+	}
+	if gotStartTime != "1699977600000" || gotLimit != "2" {
+		t.Errorf("startTime/limit = %q/%q, want 1699977600000/2", gotStartTime, gotLimit)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("got %d rates, want 2", len(rates))
+	}
+	if rates[1].Symbol != "BTC/USDT:USDT" || rates[1].Rate.String() != "-0.000025" || rates[1].Timestamp.UnixMilli() != 1700006400000 {
+		t.Errorf("rates[1] = %s %s %d", rates[1].Symbol, rates[1].Rate, rates[1].Timestamp.UnixMilli())
+	}
+	if !rates[1].NextFundingTime.IsZero() {
+		t.Errorf("history NextFundingTime = %v, want zero", rates[1].NextFundingTime.Time)
+	}
+}
+
+func TestBinancePerp_CreateOrder_StopPrice(t *testing.T) {
+	var gotType, gotStopPrice string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotType = r.URL.Query().Get("type")
+		gotStopPrice = r.URL.Query().Get("stopPrice")
+		w.Write([]byte(`{"orderId":22542179,"clientOrderId":"tp-1","updateTime":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	tests := []struct {
+		orderType option.OrderType
+		opts      []option.ArgsOption
+		wantType  string
+	}{
+		{option.Market, nil, "MARKET"},
+		{option.Market, []option.ArgsOption{option.WithStopPrice("48000")}, "STOP_MARKET"},
+		{option.Market, []option.ArgsOption{option.WithStopPrice("48000"), option.WithTriggerType(option.TakeProfit)}, "TAKE_PROFIT_MARKET"},
+		{option.Limit, []option.ArgsOption{option.WithPrice("47900"), option.WithStopPrice("48000")}, "STOP"},
+	}
+	for _, tt := range tests {
+		gotStopPrice = ""
+		order, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.CloseLong, tt.orderType, tt.opts...)
+		if err != nil {
+			t.Fatalf("CreateOrder %s: %v", tt.wantType, err)
+		}
+		if gotType != tt.wantType {
+			t.Errorf("type = %s, want %s", gotType, tt.wantType)
+		}
+		if tt.wantType == "MARKET" {
+			if gotStopPrice != "" || !order.TriggerPrice.IsZero() {
+				t.Errorf("plain order stopPrice/TriggerPrice = %q/%s, want none", gotStopPrice, order.TriggerPrice)
+			}
+			continue
+		}
+		if gotStopPrice != "48000" || order.TriggerPrice.String() != "48000" {
+			t.Errorf("%s stopPrice/TriggerPrice = %q/%s, want 48000", tt.wantType, gotStopPrice, order.TriggerPrice)
+		}
+	}
+}
+
+func TestBinancePerp_CreateOrder_TrailingStop(t *testing.T) {
+	var query url.Values
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		query = r.URL.Query()
+		w.Write([]byte(`{"orderId":22542180,"clientOrderId":"ts-1","updateTime":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	market.Precision.Price = 1
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	order, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market,
+		option.WithTrailingStop("1.5", "51000.04"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if query.Get("type") != "TRAILING_STOP_MARKET" || query.Get("callbackRate") != "1.5" || query.Get("activationPrice") != "51000" {
+		t.Errorf("type/callbackRate/activationPrice = %s/%s/%s, want TRAILING_STOP_MARKET/1.5/51000",
+			query.Get("type"), query.Get("callbackRate"), query.Get("activationPrice"))
+	}
+	if query.Has("stopPrice") || query.Has("price") {
+		t.Errorf("unexpected stopPrice/price in trailing stop order: %s", query.Encode())
+	}
+	if order.CallbackRate.String() != "1.5" {
+		t.Errorf("CallbackRate = %s, want 1.5", order.CallbackRate)
+	}
+
+	// 回调比例越界、限价单、与触发价同时使用均不发送请求
+	calls = 0
+	invalid := [][]option.ArgsOption{
+		{option.WithTrailingStop("0.05", "")},
+		{option.WithTrailingStop("6", "")},
+		{option.WithTrailingStop("1", ""), option.WithStopPrice("48000")},
+	}
+	for _, opts := range invalid {
+		if _, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market, opts...); !errors.Is(err, common.ErrInvalidOrder) {
+			t.Errorf("CreateOrder err = %v, want ErrInvalidOrder", err)
+		}
+	}
+	_, err = ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.CloseLong, option.Limit,
+		option.WithPrice("50000"), option.WithTrailingStop("1", ""))
+	if !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("limit trailing stop err = %v, want ErrInvalidOrder", err)
+	}
+	if calls != 0 {
+		t.Errorf("invalid trailing stop orders sent %d requests", calls)
+	}
+}
+
+func TestBinancePerp_InverseMarket(t *testing.T) {
+	var orderPath, orderQty string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/exchangeInfo":
+			w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","contractType":"PERPETUAL","baseAsset":"BTC","quoteAsset":"USDT",
+				"marginAsset":"USDT","status":"TRADING","pricePrecision":2,"quantityPrecision":3,"filters":[]}]}`))
+		case "/dapi/v1/exchangeInfo":
+			w.Write([]byte(`{"symbols":[
+				{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","baseAsset":"BTC","quoteAsset":"USD",
+				"marginAsset":"BTC","contractStatus":"TRADING","contractSize":100,"pricePrecision":1,"quantityPrecision":0,"filters":[]},
+				{"symbol":"BTCUSD_251226","pair":"BTCUSD","contractType":"CURRENT_QUARTER","baseAsset":"BTC","quoteAsset":"USD",
+				"marginAsset":"BTC","contractStatus":"TRADING","contractSize":100,"filters":[]}]}`))
+		case "/dapi/v1/ticker/24hr":
+			// dapi 传 symbol 时也返回数组
+			w.Write([]byte(`[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","lastPrice":"50100","openPrice":"50000",
+				"highPrice":"51000","lowPrice":"49000","volume":"120000","closeTime":1700000000789,"count":100}]`))
+		case "/dapi/v1/ticker/bookTicker":
+			w.Write([]byte(`[{"symbol":"BTCUSD_PERP","bidPrice":"50099.9","askPrice":"50100.1"}]`))
+		case "/dapi/v1/order":
+			orderPath = r.URL.Path
+			orderQty = r.URL.Query().Get("quantity")
+			w.Write([]byte(`{"orderId":123,"clientOrderId":"inv-1","updateTime":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if err := ex.Perp().LoadMarkets(context.Background(), true); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+
+	market, err := ex.Perp().GetMarket("BTC/USD:BTC")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if market.ID != "BTCUSD_PERP" || !market.Inverse || market.Linear || market.Settle != "BTC" || market.ContractValue != "100" {
+		t.Errorf("unexpected inverse market: %+v", market)
+	}
+	if linear, err := ex.Perp().GetMarket("BTC/USDT:USDT"); err != nil || linear.Inverse {
+		t.Errorf("linear market = %+v, %v", linear, err)
+	}
+	if _, err := ex.Perp().GetMarket("BTCUSD_251226"); err == nil {
+		t.Error("quarterly delivery contract should be skipped")
+	}
+
+	ticker, err := ex.Perp().FetchTicker(context.Background(), "BTC/USD:BTC")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Last.String() != "50100" || ticker.Bid.String() != "50099.9" {
+		t.Errorf("Last/Bid = %s/%s, want 50100/50099.9", ticker.Last, ticker.Bid)
+	}
+
+	// 币本位合约数量单位为张
+	if _, err := ex.Perp().CreateOrder(context.Background(), "BTC/USD:BTC", "3", option.OpenLong, option.Market); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if orderPath != "/dapi/v1/order" || orderQty != "3" {
+		t.Errorf("order path/quantity = %s/%s, want /dapi/v1/order and 3", orderPath, orderQty)
+	}
+}
+
+func TestBinancePerp_FetchOpenInterest(t *testing.T) {
+	ex := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/openInterest":
+			w.Write([]byte(`{"openInterest":"10659.509","symbol":"BTCUSDT","time":1589437530011}`))
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"50000.00000000","indexPrice":"49990.00","lastFundingRate":"0.0001","nextFundingTime":1597392000000,"time":1589437530012}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	oi, err := ex.Perp().FetchOpenInterest(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchOpenInterest: %v", err)
+	}
+	if oi.Symbol != "BTC/USDT:USDT" || oi.OpenInterestAmount.String() != "10659.509" || oi.OpenInterestValue.String() != "532975450" {
+		t.Errorf("unexpected open interest: symbol=%s amount=%s value=%s", oi.Symbol, oi.OpenInterestAmount, oi.OpenInterestValue)
+	}
+	if got := oi.Timestamp.UnixMilli(); got != 1589437530011 {
+		t.Errorf("Timestamp = %d, want 1589437530011", got)
+	}
+
+	// 现货交易对不是合约市场
+	if _, err := ex.Perp().FetchOpenInterest(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestBinancePerp_CreateOrders_MixedResults(t *testing.T) {
+	var batches [][]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/batchOrders" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var batch []map[string]interface{}
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("batchOrders")), &batch); err != nil {
+			t.Errorf("decode batchOrders: %v", err)
+		}
+		batches = append(batches, batch)
+		w.Write([]byte(`[{"orderId":101,"clientOrderId":"c-1","updateTime":1700000000000},{"code":-2019,"msg":"Margin is insufficient."}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	orders, errs, err := ex.Perp().CreateOrders(context.Background(), []option.PerpOrderRequest{
+		{Symbol: "BTC/USDT:USDT", Amount: "0.01", Side: option.OpenLong, Type: option.Limit, Opts: []option.ArgsOption{option.WithPrice("30000"), option.WithClientOrderID("c-1")}},
+		// 限价单缺少价格，本地校验失败，不提交
+		{Symbol: "BTC/USDT:USDT", Amount: "0.01", Side: option.OpenLong, Type: option.Limit},
+		{Symbol: "BTC/USDT:USDT", Amount: "100", Side: option.OpenShort, Type: option.Market},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if len(orders) != 3 || len(errs) != 3 {
+		t.Fatalf("len(orders) = %d, len(errs) = %d, want 3", len(orders), len(errs))
+	}
+	if len(batches) != 1 || len(batches[0]) != 2 {
+		t.Fatalf("batches = %v, want one batch of 2 orders", batches)
+	}
+	if batches[0][0]["price"] != "30000" || batches[0][1]["side"] != "SELL" {
+		t.Errorf("unexpected batch: %v", batches[0])
+	}
+	if errs[0] != nil || orders[0] == nil || orders[0].OrderId != "101" || orders[0].ClientOrderID != "c-1" {
+		t.Errorf("order 0 = %+v, %v", orders[0], errs[0])
+	}
+	if errs[1] == nil || orders[1] != nil {
+		t.Errorf("order 1 = %+v, %v, want validation error", orders[1], errs[1])
+	}
+	if !errors.Is(errs[2], common.ErrInsufficientFunds) || orders[2] != nil {
+		t.Errorf("order 2 = %+v, %v, want ErrInsufficientFunds", orders[2], errs[2])
+	}
+}
+
+func TestBinancePerp_PositionMode_ReduceOnly(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/positionSide/dual":
+			if r.Method != http.MethodPost || r.URL.Query().Get("dualSidePosition") != "true" {
+				t.Errorf("unexpected position mode request: %s %s", r.Method, r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":200,"msg":"success"}`))
+		case "/fapi/v1/order":
+			query = r.URL.Query()
+			w.Write([]byte(`{"orderId":22542179,"clientOrderId":"c1","updateTime":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	// 单向持仓（默认）：平多为 positionSide=BOTH 的卖单，带 reduceOnly 避免反向开空
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if query.Get("positionSide") != "BOTH" || query.Get("side") != "SELL" || query.Get("reduceOnly") != "true" {
+		t.Errorf("one-way close: positionSide=%s side=%s reduceOnly=%s", query.Get("positionSide"), query.Get("side"), query.Get("reduceOnly"))
+	}
+
+	// 切换为双向持仓后无需 WithHedgeMode：平多为 positionSide=LONG，Binance 不接受 reduceOnly
+	if err := ex.Perp().SetPositionMode(ctx, true); err != nil {
+		t.Fatalf("SetPositionMode: %v", err)
+	}
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if query.Get("positionSide") != "LONG" || query.Get("side") != "SELL" || query.Has("reduceOnly") {
+		t.Errorf("hedge close: positionSide=%s side=%s reduceOnly=%q", query.Get("positionSide"), query.Get("side"), query.Get("reduceOnly"))
+	}
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.OpenShort, option.Market); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if query.Get("positionSide") != "SHORT" || query.Get("side") != "SELL" {
+		t.Errorf("hedge open short: positionSide=%s side=%s", query.Get("positionSide"), query.Get("side"))
+	}
+}
+
+func TestBinancePerp_FetchMarkPrice(t *testing.T) {
+	ex := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/premiumIndex" || r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"11793.63104562","indexPrice":"11781.80495970","estimatedSettlePrice":"11781.16138815","lastFundingRate":"0.00038246","interestRate":"0.00010000","nextFundingTime":1597392000000,"time":1597370495002}`))
+	})
+
+	ticker, err := ex.Perp().FetchMarkPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchMarkPrice: %v", err)
+	}
+	if ticker.Symbol != "BTC/USDT:USDT" || ticker.MarkPrice.String() != "11793.63104562" || ticker.IndexPrice.String() != "11781.8049597" {
+		t.Errorf("unexpected mark price: symbol=%s mark=%s index=%s", ticker.Symbol, ticker.MarkPrice, ticker.IndexPrice)
+	}
+	// premiumIndex 不返回最新成交价
+	if !ticker.Last.IsZero() || ticker.Timestamp.UnixMilli() != 1597370495002 {
+		t.Errorf("Last = %s, Timestamp = %d", ticker.Last, ticker.Timestamp.UnixMilli())
+	}
+
+	index, err := ex.Perp().FetchIndexPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil || index.String() != "11781.8049597" {
+		t.Errorf("FetchIndexPrice = %s, %v", index, err)
+	}
+
+	if _, err := ex.Perp().FetchMarkPrice(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestBinancePerp_CreateOrder_ReduceOnlyPostOnly(t *testing.T) {
+	e := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	params, err := e.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.OpenLong, option.Limit, option.WithPrice("50000"), option.WithPostOnly(true), option.WithReduceOnly(true))
+	if err != nil {
+		t.Fatalf("buildOrderParams: %v", err)
+	}
+	if tif := params.req.GetQuery("timeInForce"); tif != "GTX" {
+		t.Errorf("timeInForce = %s, want GTX", tif)
+	}
+	if reduceOnly := params.req.GetQuery("reduceOnly"); reduceOnly != "true" {
+		t.Errorf("reduceOnly = %s, want true", reduceOnly)
+	}
+
+	params, err = e.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.CloseLong, option.Market, option.WithReduceOnly(false))
+	if err != nil {
+		t.Fatalf("buildOrderParams: %v", err)
+	}
+	if reduceOnly := params.req.GetQuery("reduceOnly"); reduceOnly != "false" {
+		t.Errorf("reduceOnly = %s, want false", reduceOnly)
+	}
+
+	_, err = e.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.OpenLong, option.Market, option.WithPostOnly(true))
+	if !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only market order err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestBinancePerp_CreateOrder_TimeInForce(t *testing.T) {
+	e := newTestBinancePerp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	tests := []struct {
+		orderType option.OrderType
+		tif       option.TimeInForce
+		want      string
+	}{
+		{option.Limit, option.GTC, "GTC"},
+		{option.Limit, option.IOC, "IOC"},
+		{option.Limit, option.FOK, "FOK"},
+		{option.Limit, option.GTX, "GTX"},
+		{option.Market, option.IOC, ""}, // 市价单不发送 timeInForce
+	}
+	for _, tt := range tests {
+		params, err := e.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.OpenLong, tt.orderType, option.WithPrice("50000"), option.WithTimeInForce(tt.tif))
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.orderType, tt.tif, err)
+		}
+		if got := params.req.GetQuery("timeInForce"); got != tt.want {
+			t.Errorf("%s %s: timeInForce = %q, want %q", tt.orderType, tt.tif, got, tt.want)
+		}
+	}
+
+	for _, tif := range []option.TimeInForce{option.FOK, option.GTX} {
+		if _, err := e.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.OpenLong, option.Market, option.WithTimeInForce(tif)); !errors.Is(err, common.ErrInvalidOrder) {
+			t.Errorf("market %s: err = %v, want ErrInvalidOrder", tif, err)
+		}
+	}
+}
+
+func TestBinancePerp_FetchOpenOrders(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?symbol="+r.URL.Query().Get("symbol"))
+		if strings.Count(r.URL.RawQuery, "signature=") != 1 {
+			t.Errorf("query %q should carry exactly one signature", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/fapi/v1/openOrders":
+			w.Write([]byte(`[{"orderId":1,"clientOrderId":"a","symbol":"BTCUSDT","price":"50000","avgPrice":"0","origQty":"0.010",
+				"executedQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","positionSide":"BOTH",
+				"time":1700000000000,"updateTime":1700000000000}]`))
+		case "/dapi/v1/openOrders":
+			w.Write([]byte(`[{"orderId":2,"clientOrderId":"b","symbol":"BTCUSD_PERP","pair":"BTCUSD","price":"60000","avgPrice":"0","origQty":"3",
+				"executedQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL","positionSide":"BOTH",
+				"time":1700000000000,"updateTime":1700000000000}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD_PERP", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		e.perpMarketsBySymbol[market.Symbol] = market
+		e.perpMarketsByID[market.ID] = market
+	}
+	ctx := context.Background()
+
+	// 未指定交易对时合并 U本位和币本位挂单
+	orders, err := e.perp.FetchOpenOrders(ctx, "")
+	if err != nil {
+		t.Fatalf("FetchOpenOrders: %v", err)
+	}
+	if len(orders) != 2 || orders[0].Symbol != "BTC/USDT:USDT" || orders[1].Symbol != "BTC/USD:BTC" {
+		t.Fatalf("orders = %+v, want BTC/USDT:USDT and BTC/USD:BTC", orders)
+	}
+
+	paths = nil
+	if _, err := e.perp.FetchOpenOrders(ctx, "BTC/USD:BTC"); err != nil {
+		t.Fatalf("FetchOpenOrders inverse: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/dapi/v1/openOrders?symbol=BTCUSD_PERP" {
+		t.Errorf("requests = %v, want only dapi with symbol BTCUSD_PERP", paths)
+	}
+}
+
+func TestBinancePerp_LotSizeOrderQuantity(t *testing.T) {
+	var quantities []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/exchangeInfo":
+			// quantityPrecision 与 LOT_SIZE 不一致时以步长为准
+			w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","contractType":"PERPETUAL","baseAsset":"BTC","quoteAsset":"USDT",
+				"marginAsset":"USDT","status":"TRADING","pricePrecision":2,"quantityPrecision":1,"filters":[
+				{"filterType":"LOT_SIZE","minQty":"0.001","maxQty":"1000","stepSize":"0.001"},
+				{"filterType":"PRICE_FILTER","minPrice":"0.10","maxPrice":"1000000","tickSize":"0.10"}]}]}`))
+		case "/dapi/v1/exchangeInfo":
+			w.Write([]byte(`{"symbols":[]}`))
+		case "/fapi/v1/order":
+			quantities = append(quantities, r.URL.Query().Get("quantity"))
+			w.Write([]byte(`{"orderId":1,"clientOrderId":"a","updateTime":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+	if err := ex.Perp().LoadMarkets(ctx, true); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+	market, err := ex.Perp().GetMarket("BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if market.Precision.Amount != 3 || market.Precision.StepSize.String() != "0.001" || market.ContractValue != "1" {
+		t.Errorf("amount precision/step/contract value = %d/%s/%s, want 3/0.001/1", market.Precision.Amount, market.Precision.StepSize, market.ContractValue)
+	}
+
+	for _, amount := range []string{"0.001", "0.0019"} {
+		if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", amount, option.OpenLong, option.Market); err != nil {
+			t.Fatalf("CreateOrder %s: %v", amount, err)
+		}
+	}
+	if len(quantities) != 2 || quantities[0] != "0.001" || quantities[1] != "0.001" {
+		t.Errorf("quantities = %v, want [0.001 0.001]", quantities)
+	}
+
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.0009", option.OpenLong, option.Market); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("below lot size err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestBinancePerp_EditOrder(t *testing.T) {
+	var edits []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v1/order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"orderId":7,"clientOrderId":"a","symbol":"BTCUSDT","price":"50000","avgPrice":"0","origQty":"0.010",
+				"executedQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL","positionSide":"BOTH",
+				"time":1700000000000,"updateTime":1700000000000}`))
+		case http.MethodPut:
+			edits = append(edits, r.URL.Query())
+			w.Write([]byte(`{"orderId":7,"clientOrderId":"a","symbol":"BTCUSDT","price":"50000","avgPrice":"0","origQty":"0.010",
+				"executedQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL","positionSide":"BOTH",
+				"time":1700000000000,"updateTime":1700000000100}`))
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.1")}
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	// 只改数量时沿用原价格，只改价格时沿用原数量，均按市场精度对齐
+	tests := []struct {
+		amount, price      string
+		wantQty, wantPrice string
+	}{
+		{"0.0157", "", "0.015", "50000"},
+		{"", "50000.37", "0.01", "50000.3"},
+	}
+	for i, tt := range tests {
+		if _, err := ex.Perp().EditOrder(context.Background(), "BTC/USDT:USDT", "7", tt.amount, tt.price); err != nil {
+			t.Fatalf("EditOrder %d: %v", i, err)
+		}
+		q := edits[len(edits)-1]
+		if q.Get("symbol") != "BTCUSDT" || q.Get("orderId") != "7" || q.Get("side") != "SELL" ||
+			q.Get("quantity") != tt.wantQty || q.Get("price") != tt.wantPrice || q.Get("signature") == "" {
+			t.Errorf("edit %d query = %v, want quantity %s price %s", i, q, tt.wantQty, tt.wantPrice)
+		}
+	}
+}
+
+func TestBinancePerp_WSOrderReason(t *testing.T) {
+	parse := parseBinancePerpWSOrderUpdate(func(marketID string) string { return "BTC/USDT:USDT" })
+	tests := []struct {
+		clientID string
+		want     string
+	}{
+		{"autoclose-1700000000000000", model.PositionReasonLiquidation},
+		{"adl_autoclose", model.PositionReasonADL},
+		{"my-order", ""},
+	}
+	for _, tt := range tests {
+		msg := `{"e":"ORDER_TRADE_UPDATE","T":1700000000000,"o":{"s":"BTCUSDT","c":"` + tt.clientID + `","S":"SELL","o":"LIMIT","X":"FILLED","i":1,"q":"0.01","z":"0.01"}}`
+		order, ok := parse([]byte(msg))
+		if !ok {
+			t.Fatalf("%s: parse failed", tt.clientID)
+		}
+		if order.Reason != tt.want {
+			t.Errorf("%s: Reason = %q, want %q", tt.clientID, order.Reason, tt.want)
+		}
+	}
+}
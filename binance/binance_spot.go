@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -49,11 +50,39 @@ func (s *BinanceSpot) GetMarket(symbol string) (*model.Market, error) {
 	return s.market.GetMarket(symbol)
 }
 
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *BinanceSpot) GetMarketByID(id string) (*model.Market, error) {
+	return s.market.GetMarketByID(id)
+}
+
 // GetMarkets 从内存中获取所有市场信息
 func (s *BinanceSpot) GetMarkets() ([]*model.Market, error) {
 	return s.market.GetMarkets()
 }
 
+// AmountToPrecision 将数量向下对齐到市场的数量步长
+func (s *BinanceSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+// PriceToPrecision 将价格向下对齐到市场的价格步长
+func (s *BinanceSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *BinanceSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
 // FetchTicker 获取行情（单个）
 func (s *BinanceSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
 	return s.market.FetchTicker(ctx, symbol)
@@ -64,6 +93,74 @@ func (s *BinanceSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticke
 	return s.market.FetchTickers(ctx)
 }
 
+// FetchTickersOrdered 批量获取行情，结果与 symbols 顺序一一对应，缺失的交易对为 nil
+func (s *BinanceSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取订单簿
+func (s *BinanceSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return s.market.FetchOrderBook(ctx, symbol, limit)
+}
+
+// WatchTicker 通过 WebSocket 订阅 24 小时行情
+func (s *BinanceSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.binance.spotWS, binanceTickerTopic(market.ID), parseBinanceWSTicker(market.Symbol))
+}
+
+// WatchOrderBook 通过 WebSocket 订阅深度并维护本地订单簿
+// 先订阅增量流再获取 REST 快照（1000 档），按 lastUpdateId 与增量的 U/u 对齐，序号不连续时重新获取快照
+func (s *BinanceSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  s.binance.spotWS,
+		Topic:    binanceDepthTopic(market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequenceRange,
+		Parse:    parseBinanceWSDepth,
+		FetchSnapshot: func(ctx context.Context) (*model.OrderBook, error) {
+			return s.FetchOrderBook(ctx, market.Symbol, binanceWSSnapshotLimit)
+		},
+	})
+}
+
+// WatchOHLCV 通过 WebSocket 订阅K线，每次更新推送当前K线，收盘K线的 Closed 为 true
+func (s *BinanceSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.BinanceTimeframe(timeframe), binanceSpotTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.binance.spotWS, binanceKlineTopic(market.ID, interval), parseBinanceWSKline)
+}
+
 // FetchOHLCVs 获取K线数据
 func (s *BinanceSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	// 解析参数
@@ -82,23 +179,115 @@ func (s *BinanceSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe
 	if argsOpts.Since != nil {
 		since = *argsOpts.Since
 	}
+	until, _ := option.GetTime(argsOpts.Until)
+
+	ohlcvs, err := s.market.FetchOHLCVs(ctx, symbol, timeframe, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.binance.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+// FetchOHLCVRange 分页获取开盘时间在 [since, until) 内的K线
+func (s *BinanceSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, binanceOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *BinanceSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
 
-	return s.market.FetchOHLCVs(ctx, symbol, timeframe, since, limit)
+// FetchAggregatedTrades 获取归集交易
+func (s *BinanceSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return s.market.FetchAggregatedTrades(ctx, symbol, since, limit)
 }
 
 // FetchBalance 获取余额
-func (s *BinanceSpot) FetchBalance(ctx context.Context) (model.Balances, error) {
-	return s.order.FetchBalance(ctx)
+func (s *BinanceSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	return s.order.FetchBalance(ctx, opts...)
+}
+
+// WatchOrders 通过用户数据流订阅订单更新，订单每次状态变化（新建、部分成交、成交、撤销等）推送一次
+func (s *BinanceSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.binance.spotUserWS, binanceExecutionReportEvent, parseBinanceWSExecutionReport(s.marketSymbol))
+}
+
+// WatchBalance 通过用户数据流订阅余额更新，每次余额变化推送变化币种的最新余额
+func (s *BinanceSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.binance.spotUserWS, binanceAccountPositionEvent, parseBinanceWSAccountPosition)
+}
+
+// marketSymbol 将市场ID转换为标准化交易对，市场信息未加载时返回原始ID
+func (s *BinanceSpot) marketSymbol(marketID string) string {
+	if market, err := s.GetMarket(marketID); err == nil {
+		return market.Symbol
+	}
+	return marketID
 }
 
 // CreateOrder 创建订单
 func (s *BinanceSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	order, err := common.CreateOrderIdempotent(ctx, s.binance.client.SpotClient, s.binance.Name(), side.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.SpotOrder, error) {
+		return s.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		s.binance.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+// CreateOrders 批量创建订单（Binance 现货没有批量下单接口，逐个提交）
+func (s *BinanceSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+// CreateOCOOrder 创建 OCO 订单（/api/v3/orderList/oco），子订单加入 Drain 撤单跟踪
+func (s *BinanceSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	list, err := s.order.CreateOCOOrder(ctx, symbol, side, amount, price, stopPrice, stopLimitPrice, opts...)
+	if list != nil {
+		for _, order := range list.Orders {
+			s.binance.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.ID})
+		}
+	}
+	return list, err
 }
 
 // CancelOrder 取消订单
 func (s *BinanceSpot) CancelOrder(ctx context.Context, symbol string, orderID string, opts ...option.ArgsOption) error {
-	return s.order.CancelOrder(ctx, symbol, orderID, opts...)
+	if err := s.order.CancelOrder(ctx, symbol, orderID, opts...); err != nil {
+		return err
+	}
+	s.binance.lifecycle.RemoveOrder(false, orderID)
+	return nil
+}
+
+// EditOrder Binance 现货不支持改单，需要撤单后重新下单
+func (s *BinanceSpot) EditOrder(ctx context.Context, symbol string, orderID string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, fmt.Errorf("edit order: %w: Binance spot does not support amending orders", common.ErrNotSupported)
 }
 
 // FetchOrder 查询订单
@@ -106,6 +295,94 @@ func (s *BinanceSpot) FetchOrder(ctx context.Context, symbol string, orderID str
 	return s.order.FetchOrder(ctx, symbol, orderID, opts...)
 }
 
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *BinanceSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchOrders 查询交易对的历史订单，since 为零值时不限制开始时间，limit <= 0 使用交易所默认值
+func (s *BinanceSpot) FetchOrders(ctx context.Context, symbol string, since time.Time, limit int) ([]*model.SpotOrder, error) {
+	return s.order.FetchOrders(ctx, symbol, since, limit)
+}
+
+// FetchOpenOrders 查询当前挂单，symbol 为空时查询所有交易对
+func (s *BinanceSpot) FetchOpenOrders(ctx context.Context, symbol string) ([]*model.SpotOrder, error) {
+	return s.order.FetchOpenOrders(ctx, symbol)
+}
+
+// FetchMyTradesRange 查询账户成交记录（/api/v3/myTrades），按 24 小时时间窗口和 fromId 翻页
+func (s *BinanceSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return s.order.FetchMyTradesRange(ctx, symbol, since, until)
+}
+
+// TrackOrder 通过用户数据流跟踪订单成交进度，断线重连后查询订单补齐遗漏的成交
+func (s *BinanceSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := s.binance.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.SpotOrder]) (<-chan []*model.SpotOrder, error) {
+		return common.WatchTopicResync(ctx, s.binance.spotUserWS, binanceExecutionReportEvent, binanceWSItems(parseBinanceWSExecutionReport(s.marketSymbol)), resync)
+	}, func(ctx context.Context) (*model.SpotOrder, error) {
+		return s.FetchOrder(ctx, symbol, orderId)
+	}, common.SpotOrderFillSnapshot)
+}
+
+// CreateConversion 闪兑
+func (s *BinanceSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return s.order.CreateConversion(ctx, from, to, amount)
+}
+
+// FetchCurrencies 获取全部币种的充提状态及支持的网络
+func (s *BinanceSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return s.order.FetchCurrencies(ctx)
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *BinanceSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+// FetchDepositAddress 获取充值地址
+func (s *BinanceSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return s.order.FetchDepositAddress(ctx, currency, network)
+}
+
+// Withdraw 提币
+func (s *BinanceSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return s.order.Withdraw(ctx, currency, amount, address, network, params)
+}
+
+// Transfer 账户间资金划转
+func (s *BinanceSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return s.order.Transfer(ctx, currency, amount, fromAccount, toAccount)
+}
+
+// FetchBorrowRate 获取全仓杠杆借币利率
+func (s *BinanceSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return s.order.FetchBorrowRate(ctx, currency)
+}
+
+// Borrow 全仓杠杆借币
+func (s *BinanceSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "borrow", "BORROW", currency, amount)
+}
+
+// Repay 归还全仓杠杆借币
+func (s *BinanceSpot) Repay(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "repay", "REPAY", currency, amount)
+}
+
+// FetchSubAccounts 获取子账户列表
+func (s *BinanceSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return s.order.FetchSubAccounts(ctx)
+}
+
+// FetchBalanceFor 获取子账户余额
+func (s *BinanceSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return s.order.FetchBalanceFor(ctx, subAccountID)
+}
+
 // 确保 BinanceSpot 实现了 exchange.SpotExchange 接口
 var _ exchange.SpotExchange = (*BinanceSpot)(nil)
 
@@ -118,9 +395,9 @@ type binanceSpotMarket struct {
 
 // LoadMarkets 加载市场信息
 func (m *binanceSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	m.binance.mu.RLock()
-	if !reload && len(m.binance.spotMarketsBySymbol) > 0 {
+	if !reload && len(m.binance.spotMarketsBySymbol) > 0 && !m.binance.marketCache.Expired(model.MarketTypeSpot) {
 		m.binance.mu.RUnlock()
 		return nil
 	}
@@ -171,6 +448,7 @@ func (m *binanceSpotMarket) LoadMarkets(ctx context.Context, reload bool) error
 					market.Limits.Amount.Max = filter.MaxQty
 				}
 				if !filter.StepSize.IsZero() {
+					market.Precision.StepSize = filter.StepSize
 					// 从 StepSize 计算数量精度
 					stepSizeStr := filter.StepSize.String()
 					parts := strings.Split(stepSizeStr, ".")
@@ -186,6 +464,7 @@ func (m *binanceSpotMarket) LoadMarkets(ctx context.Context, reload bool) error
 					market.Limits.Price.Max = filter.MaxPrice
 				}
 				if !filter.TickSize.IsZero() {
+					market.Precision.TickSize = filter.TickSize
 					// 从 TickSize 计算价格精度
 					tickSizeStr := filter.TickSize.String()
 					parts := strings.Split(tickSizeStr, ".")
@@ -203,16 +482,11 @@ func (m *binanceSpotMarket) LoadMarkets(ctx context.Context, reload bool) error
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	m.binance.mu.Lock()
-	if m.binance.spotMarketsBySymbol == nil {
-		m.binance.spotMarketsBySymbol = make(map[string]*model.Market)
-		m.binance.spotMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		m.binance.spotMarketsBySymbol[market.Symbol] = market
-		m.binance.spotMarketsByID[market.ID] = market
-	}
+	m.binance.spotMarketsBySymbol, m.binance.spotMarketsByID = bySymbol, byID
+	m.binance.marketCache.Touch(model.MarketTypeSpot)
 	m.binance.mu.Unlock()
 
 	return nil
@@ -250,7 +524,18 @@ func (m *binanceSpotMarket) GetMarket(key string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", key)
+	return nil, common.MarketNotFound(key, m.binance.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (m *binanceSpotMarket) GetMarketByID(id string) (*model.Market, error) {
+	m.binance.mu.RLock()
+	defer m.binance.mu.RUnlock()
+
+	if market, ok := m.binance.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, m.binance.spotMarketsByID)
 }
 
 // GetMarkets 从内存中获取所有市场信息
@@ -293,12 +578,22 @@ func (m *binanceSpotMarket) FetchTicker(ctx context.Context, symbol string) (*mo
 		return nil, fmt.Errorf("fetch ticker: %w", err)
 	}
 
-	var data binanceSpotTickerResponse
-
-	if err := json.Unmarshal(resp, &data); err != nil {
+	items, err := decodeObjectOrArray[binanceSpotTickerResponse](resp)
+	if err != nil {
 		return nil, fmt.Errorf("unmarshal ticker: %w", err)
 	}
 
+	var data *binanceSpotTickerResponse
+	for i := range items {
+		if items[i].Symbol == binanceSymbol {
+			data = &items[i]
+			break
+		}
+	}
+	if data == nil {
+		return nil, fmt.Errorf("ticker not found: %s", symbol)
+	}
+
 	// 转换回标准化格式 - 使用输入的symbol（已经是标准化格式）
 	ticker := &model.Ticker{
 		Symbol:    symbol, // 使用输入的标准化格式
@@ -313,6 +608,8 @@ func (m *binanceSpotMarket) FetchTicker(ctx context.Context, symbol string) (*mo
 	ticker.Low = data.LowPrice
 	ticker.Volume = data.Volume
 	ticker.QuoteVolume = data.QuoteVolume
+	ticker.VWAP = data.WeightedAvgPrice
+	ticker.TradeCount = data.Count
 
 	return ticker, nil
 }
@@ -324,9 +621,8 @@ func (m *binanceSpotMarket) FetchTickers(ctx context.Context) (map[string]*model
 		return nil, fmt.Errorf("fetch tickers: %w", err)
 	}
 
-	var data []binanceSpotTickerResponse
-
-	if err := json.Unmarshal(resp, &data); err != nil {
+	data, err := decodeObjectOrArray[binanceSpotTickerResponse](resp)
+	if err != nil {
 		return nil, fmt.Errorf("unmarshal tickers: %w", err)
 	}
 
@@ -348,6 +644,8 @@ func (m *binanceSpotMarket) FetchTickers(ctx context.Context) (map[string]*model
 			ticker.Low = item.LowPrice
 			ticker.Volume = item.Volume
 			ticker.QuoteVolume = item.QuoteVolume
+			ticker.VWAP = item.WeightedAvgPrice
+			ticker.TradeCount = item.Count
 			tickers[item.Symbol] = ticker
 		} else {
 			ticker := &model.Ticker{
@@ -362,6 +660,8 @@ func (m *binanceSpotMarket) FetchTickers(ctx context.Context) (map[string]*model
 			ticker.Low = item.LowPrice
 			ticker.Volume = item.Volume
 			ticker.QuoteVolume = item.QuoteVolume
+			ticker.VWAP = item.WeightedAvgPrice
+			ticker.TradeCount = item.Count
 			tickers[market.Symbol] = ticker
 		}
 	}
@@ -369,8 +669,41 @@ func (m *binanceSpotMarket) FetchTickers(ctx context.Context) (map[string]*model
 	return tickers, nil
 }
 
+// FetchOrderBook 获取订单簿
+func (m *binanceSpotMarket) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol": market.ID,
+	}
+	if limit = common.ClampOrderBookLimit(limit, binanceSpotMaxDepthLimit); limit > 0 {
+		params["limit"] = limit
+	}
+
+	resp, err := m.binance.client.SpotClient.Get(ctx, "/api/v3/depth", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var data binanceOrderBookResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.LastUpdateID,
+		Timestamp: types.ExTimestamp{Time: time.Now()}, // Binance 现货深度接口没有返回时间戳
+	}, nil
+}
+
 // FetchOHLCVs 获取K线数据
-func (m *binanceSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since time.Time, limit int) (model.OHLCVs, error) {
+func (m *binanceSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since, until time.Time, limit int) (model.OHLCVs, error) {
 	// 获取市场信息
 	market, err := m.GetMarket(symbol)
 	if err != nil {
@@ -378,7 +711,10 @@ func (m *binanceSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, time
 	}
 
 	// 标准化时间框架
-	normalizedTimeframe := common.BinanceTimeframe(timeframe)
+	normalizedTimeframe, err := common.CheckTimeframe(timeframe, common.BinanceTimeframe(timeframe), binanceSpotTimeframes)
+	if err != nil {
+		return nil, err
+	}
 
 	params := map[string]interface{}{
 		"interval": normalizedTimeframe,
@@ -387,6 +723,9 @@ func (m *binanceSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, time
 	if !since.IsZero() {
 		params["startTime"] = since.UnixMilli()
 	}
+	if !until.IsZero() {
+		params["endTime"] = until.UnixMilli()
+	}
 
 	// 获取交易所格式的 symbol ID（优先使用 market.ID）
 	binanceSymbol := market.ID
@@ -427,27 +766,180 @@ func (m *binanceSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, time
 	return ohlcvs, nil
 }
 
+// FetchAggregatedTrades 获取归集交易
+func (m *binanceSpotMarket) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol": market.ID,
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if !since.IsZero() {
+		params["startTime"] = since.UnixMilli()
+	}
+
+	resp, err := m.binance.client.SpotClient.Get(ctx, "/api/v3/aggTrades", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch agg trades: %w", err)
+	}
+
+	var data binanceSpotAggTradesResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal agg trades: %w", err)
+	}
+
+	return toAggTrades(market.Symbol, data), nil
+}
+
 // binanceSpotOrder 现货订单相关方法
 type binanceSpotOrder struct {
 	binance *Binance
 }
 
-// FetchBalance 获取余额
-func (o *binanceSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error) {
-	if o.binance.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
+// FetchBalance 获取余额，按 option.WithAccountType 选择账户（默认现货账户）
+func (o *binanceSpotOrder) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	switch accountType := option.GetAccountType(argsOpts.AccountType); accountType {
+	case option.AccountSpot:
+		return o.fetchSpotBalance(ctx)
+	case option.AccountFutures:
+		return o.fetchFuturesBalance(ctx)
+	case option.AccountMargin:
+		return o.fetchMarginBalance(ctx)
+	case option.AccountFunding:
+		return o.fetchFundingBalance(ctx)
+	default:
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+}
+
+// signedRequest 签名并发送请求，client 为现货或 U本位合约客户端
+func (o *binanceSpotOrder) signedRequest(ctx context.Context, client *common.HTTPClient, method, path string, params map[string]interface{}) ([]byte, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["timestamp"] = o.binance.clock.Timestamp()
+	o.binance.setRecvWindow(params)
+	params["signature"] = creds.signer.Sign(BuildQueryString(params))
+
+	return client.RequestWithHeaders(ctx, method, path, params, nil, creds.headers())
+}
+
+// fetchFuturesBalance 获取 U本位合约账户余额（/fapi/v2/balance）
+// 总余额为钱包余额加全仓未实现盈亏，冻结部分为已占用的保证金
+func (o *binanceSpotOrder) fetchFuturesBalance(ctx context.Context) (model.Balances, error) {
+	resp, err := o.signedRequest(ctx, o.binance.client.PerpClient, http.MethodGet, "/fapi/v2/balance", nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch futures balance: %w", err)
+	}
+
+	var data []binancePerpBalanceItem
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal futures balance: %w", err)
+	}
+
+	balances := make(model.Balances, 0, len(data))
+	for _, bal := range data {
+		total := bal.Balance.Add(bal.CrossUnPnl.Decimal)
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.AvailableBalance,
+			Locked:    types.ExDecimal{Decimal: total.Sub(bal.AvailableBalance.Decimal)},
+			Total:     types.ExDecimal{Decimal: total},
+			UpdatedAt: bal.UpdateTime,
+		})
+	}
+
+	return balances, nil
+}
+
+// fetchMarginBalance 获取全仓杠杆账户余额（/sapi/v1/margin/account），总余额为净资产（扣除借款和利息）
+func (o *binanceSpotOrder) fetchMarginBalance(ctx context.Context) (model.Balances, error) {
+	resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodGet, "/sapi/v1/margin/account", nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch margin balance: %w", err)
+	}
+
+	var data binanceSpotMarginAccountResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal margin balance: %w", err)
+	}
+
+	now := types.ExTimestamp{Time: time.Now()}
+	balances := make(model.Balances, 0, len(data.UserAssets))
+	for _, bal := range data.UserAssets {
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.Free,
+			Locked:    bal.Locked,
+			Total:     bal.NetAsset,
+			UpdatedAt: now, // 杠杆账户接口没有返回更新时间
+		})
 	}
 
-	timestamp := common.GetTimestamp()
+	return balances, nil
+}
+
+// fetchFundingBalance 获取资金账户余额（/sapi/v1/asset/get-funding-asset）
+func (o *binanceSpotOrder) fetchFundingBalance(ctx context.Context) (model.Balances, error) {
+	resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodPost, "/sapi/v1/asset/get-funding-asset", nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding balance: %w", err)
+	}
+
+	var data []binanceSpotFundingAssetItem
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal funding balance: %w", err)
+	}
+
+	now := types.ExTimestamp{Time: time.Now()}
+	balances := make(model.Balances, 0, len(data))
+	for _, bal := range data {
+		locked := bal.Locked.Add(bal.Freeze.Decimal).Add(bal.Withdrawing.Decimal)
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.Free,
+			Locked:    types.ExDecimal{Decimal: locked},
+			Total:     types.ExDecimal{Decimal: bal.Free.Add(locked)},
+			UpdatedAt: now, // 资金账户接口没有返回更新时间
+		})
+	}
+
+	return balances, nil
+}
+
+// fetchSpotBalance 获取现货账户余额（/api/v3/account）
+func (o *binanceSpotOrder) fetchSpotBalance(ctx context.Context) (model.Balances, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	timestamp := o.binance.clock.Timestamp()
 	params := map[string]interface{}{
 		"timestamp": timestamp,
 	}
 
+	o.binance.setRecvWindow(params)
 	queryString := BuildQueryString(params)
-	signature := o.binance.signer.Sign(queryString)
+	signature := creds.signer.Sign(queryString)
 	params["signature"] = signature
 
-	resp, err := o.binance.client.SpotClient.Get(ctx, "/api/v3/account", params)
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/api/v3/account", params, nil, creds.headers())
 	if err != nil {
 		return nil, fmt.Errorf("fetch balance: %w", err)
 	}
@@ -475,8 +967,9 @@ func (o *binanceSpotOrder) FetchBalance(ctx context.Context) (model.Balances, er
 
 // CreateOrder 创建订单
 func (o *binanceSpotOrder) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	if o.binance.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
 	}
 
 	// 解析订单选项
@@ -533,52 +1026,89 @@ func (o *binanceSpotOrder) CreateOrder(ctx context.Context, symbol string, side
 		}
 	}
 
+	// 设置触发价时为条件单：STOP_LOSS/TAKE_PROFIT（市价）或 STOP_LOSS_LIMIT/TAKE_PROFIT_LIMIT（限价）
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(options)
+	if err != nil {
+		return nil, err
+	}
+	// 市价单不接受 timeInForce；只做 Maker 的限价单使用 LIMIT_MAKER（现货不支持 GTX），不接受 timeInForce
+	if err := common.CheckTimeInForce(options, orderType == model.OrderTypeLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(options, orderType == model.OrderTypeLimit)
+	if err != nil {
+		return nil, err
+	}
+	if postOnly && isStop {
+		return nil, fmt.Errorf("post-only is not supported for stop orders: %w", common.ErrInvalidOrder)
+	}
+	sendType := orderType.Upper()
+	if isStop {
+		sendType = triggerType.String()
+		if orderType == model.OrderTypeLimit {
+			sendType += "_LIMIT"
+		}
+	} else if postOnly {
+		sendType = "LIMIT_MAKER"
+	}
+
 	// 构建基础请求参数
-	reqTimestamp := common.GetTimestamp()
+	reqTimestamp := o.binance.clock.Timestamp()
 	reqParams := map[string]interface{}{
 		"symbol":    binanceSymbol,
 		"side":      side,
-		"type":      orderType.Upper(),
+		"type":      sendType,
 		"timestamp": reqTimestamp,
 	}
 
-	// 格式化数量（现货订单使用市场精度）
-	amountPrecision := market.Precision.Amount
-	if amountPrecision == 0 {
-		amountPrecision = 8 // 默认精度
+	// 数量和价格向下对齐到市场的步长
+	quantity, err := common.AmountToPrecision(market, amountDecimal.String())
+	if err != nil {
+		return nil, err
 	}
-	reqParams["quantity"] = amountDecimal.StringFixed(int32(amountPrecision))
+	reqParams["quantity"] = quantity
 
 	// 处理限价单的价格和 timeInForce
 	if orderType == model.OrderTypeLimit {
-		pricePrecision := market.Precision.Price
-		if pricePrecision == 0 {
-			pricePrecision = 8 // 默认精度
+		price, err := common.PriceToPrecision(market, priceDecimal.String())
+		if err != nil {
+			return nil, err
 		}
-		reqParams["price"] = priceDecimal.StringFixed(int32(pricePrecision))
+		reqParams["price"] = price
 
-		// 处理 timeInForce：如果设置了则使用，否则使用默认值 GTC
+		// 处理 timeInForce：如果设置了则使用，否则使用默认值 GTC（LIMIT_MAKER 不接受 timeInForce）
 		if options.TimeInForce != nil {
 			reqParams["timeInForce"] = options.TimeInForce.Upper()
 		} else {
 			reqParams["timeInForce"] = model.OrderTimeInForceGTC.Upper()
 		}
+		if postOnly {
+			delete(reqParams, "timeInForce")
+		}
+	}
+	if isStop {
+		triggerPrice, err := common.PriceToPrecision(market, stopPrice.String())
+		if err != nil {
+			return nil, err
+		}
+		reqParams["stopPrice"] = triggerPrice
 	}
 
 	// 生成客户端订单ID（如果未提供）
+	clientOrderID := common.GenerateClientOrderID(o.binance.Name(), side.ToSide())
 	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
-		reqParams["newClientOrderId"] = *options.ClientOrderID
-	} else {
-		reqParams["newClientOrderId"] = common.GenerateClientOrderID(o.binance.Name(), side.ToSide())
+		clientOrderID = *options.ClientOrderID
 	}
+	reqParams["newClientOrderId"] = clientOrderID
 
 	// 构建签名
+	o.binance.setRecvWindow(reqParams)
 	queryString := BuildQueryString(reqParams)
-	signature := o.binance.signer.Sign(queryString)
+	signature := creds.signer.Sign(queryString)
 	reqParams["signature"] = signature
 
 	// 发送请求（现货订单使用 SpotClient）
-	resp, err := o.binance.client.SpotClient.Request(ctx, "POST", "/api/v3/order", reqParams, nil)
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/api/v3/order", reqParams, nil, creds.headers())
 	if err != nil {
 		return nil, fmt.Errorf("create order: %w", err)
 	}
@@ -588,22 +1118,141 @@ func (o *binanceSpotOrder) CreateOrder(ctx context.Context, symbol string, side
 	if err := json.Unmarshal(resp, &respData); err != nil {
 		return nil, fmt.Errorf("unmarshal spot order response: %w", err)
 	}
+	if respData.OrderID == 0 {
+		return nil, fmt.Errorf("create order: missing orderId in response")
+	}
 
 	// 构建订单对象
 	order := &model.NewOrder{
 		OrderId:       strconv.FormatInt(respData.OrderID, 10),
 		ClientOrderID: respData.ClientOrderID,
 		Symbol:        symbol,
-		Timestamp:     respData.Time,
+		Timestamp:     respData.TransactTime,
+		TriggerPrice:  types.ExDecimal{Decimal: stopPrice},
+	}
+	for _, fill := range respData.Fills {
+		order.Fills = append(order.Fills, &model.Trade{
+			ID:        strconv.FormatInt(fill.TradeID, 10),
+			OrderID:   order.OrderId,
+			Symbol:    symbol,
+			Type:      strings.ToLower(respData.Type),
+			Side:      strings.ToLower(respData.Side),
+			Amount:    fill.Qty.Decimal,
+			Price:     fill.Price.Decimal,
+			Cost:      fill.Price.Mul(fill.Qty.Decimal),
+			Timestamp: respData.TransactTime.Time,
+			Fee:       &model.Fee{Currency: fill.CommissionAsset, Cost: fill.Commission.Decimal},
+		})
+	}
+	order.Fee = model.SumFees(order.Fills)
+
+	if strict, ok := option.GetBool(options.StrictClientID); ok && strict {
+		if err := common.CheckClientOrderID(clientOrderID, order.ClientOrderID); err != nil {
+			return order, err
+		}
 	}
 
 	return order, nil
 }
 
+// CreateOCOOrder 创建 OCO 订单（/api/v3/orderList/oco）
+// 限价子订单为 LIMIT_MAKER，止损子订单为 STOP_LOSS_LIMIT（stopLimitPrice 为空时为 STOP_LOSS）；
+// 卖出时限价单在上方（above）、止损单在下方（below），买入时相反
+func (o *binanceSpotOrder) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := common.ValidateOCOOrder(side, price, stopPrice, stopLimitPrice); err != nil {
+		return nil, err
+	}
+
+	market, err := o.binance.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	limitPrice, err := common.PriceToPrecision(market, price)
+	if err != nil {
+		return nil, err
+	}
+	triggerPrice, err := common.PriceToPrecision(market, stopPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	limitLeg, stopLeg := "above", "below"
+	if side == option.Buy {
+		limitLeg, stopLeg = "below", "above"
+	}
+
+	clientOrderID := common.GenerateClientOrderID(o.binance.Name(), side.ToSide())
+	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
+		clientOrderID = *options.ClientOrderID
+	}
+
+	reqParams := map[string]interface{}{
+		"symbol":              market.ID,
+		"side":                side.ToSide(),
+		"quantity":            quantity,
+		"listClientOrderId":   clientOrderID,
+		"newOrderRespType":    "FULL",
+		limitLeg + "Type":     "LIMIT_MAKER",
+		limitLeg + "Price":    limitPrice,
+		stopLeg + "StopPrice": triggerPrice,
+		"timestamp":           o.binance.clock.Timestamp(),
+	}
+	if stopLimitPrice != "" {
+		stopLimit, err := common.PriceToPrecision(market, stopLimitPrice)
+		if err != nil {
+			return nil, err
+		}
+		reqParams[stopLeg+"Type"] = "STOP_LOSS_LIMIT"
+		reqParams[stopLeg+"Price"] = stopLimit
+		reqParams[stopLeg+"TimeInForce"] = model.OrderTimeInForceGTC.Upper()
+	} else {
+		reqParams[stopLeg+"Type"] = "STOP_LOSS"
+	}
+
+	o.binance.setRecvWindow(reqParams)
+	queryString := BuildQueryString(reqParams)
+	reqParams["signature"] = creds.signer.Sign(queryString)
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/api/v3/orderList/oco", reqParams, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("create oco order: %w", err)
+	}
+
+	var respData binanceSpotOrderListResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal oco order response: %w", err)
+	}
+
+	list := toBinanceSpotOrderList(symbol, &respData)
+	if strict, ok := option.GetBool(options.StrictClientID); ok && strict {
+		if err := common.CheckClientOrderID(clientOrderID, list.ClientID); err != nil {
+			return list, err
+		}
+	}
+
+	return list, nil
+}
+
 // CancelOrder 取消订单
 func (o *binanceSpotOrder) CancelOrder(ctx context.Context, symbol string, orderID string, opts ...option.ArgsOption) error {
-	if o.binance.client.SecretKey == "" {
-		return fmt.Errorf("authentication required")
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
 	}
 
 	argsOpts := &option.ExchangeArgsOptions{}
@@ -627,28 +1276,38 @@ func (o *binanceSpotOrder) CancelOrder(ctx context.Context, symbol string, order
 		}
 	}
 
-	timestamp := common.GetTimestamp()
+	timestamp := o.binance.clock.Timestamp()
 	params := map[string]interface{}{
 		"symbol":    binanceSymbol,
-		"orderId":   orderID,
 		"timestamp": timestamp,
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		params["origClientOrderId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderID != "" {
+		params["orderId"] = orderID
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		params["origClientOrderId"] = clientOrderID
+	} else {
+		return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
+	o.binance.setRecvWindow(params)
 	queryString := BuildQueryString(params)
-	signature := o.binance.signer.Sign(queryString)
+	signature := creds.signer.Sign(queryString)
 	params["signature"] = signature
 
-	_, err = o.binance.client.SpotClient.Delete(ctx, "/api/v3/order", params, nil)
+	_, err = o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodDelete, "/api/v3/order", params, nil, creds.headers())
 	return err
 }
 
 // FetchOrder 查询订单
 func (o *binanceSpotOrder) FetchOrder(ctx context.Context, symbol string, orderID string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
-	if o.binance.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
 	}
 
 	argsOpts := &option.ExchangeArgsOptions{}
@@ -672,22 +1331,27 @@ func (o *binanceSpotOrder) FetchOrder(ctx context.Context, symbol string, orderI
 		}
 	}
 
-	timestamp := common.GetTimestamp()
+	timestamp := o.binance.clock.Timestamp()
 	params := map[string]interface{}{
 		"symbol":    binanceSymbol,
-		"orderId":   orderID,
 		"timestamp": timestamp,
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		params["origClientOrderId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderID != "" {
+		params["orderId"] = orderID
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		params["origClientOrderId"] = clientOrderID
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
+	o.binance.setRecvWindow(params)
 	queryString := BuildQueryString(params)
-	signature := o.binance.signer.Sign(queryString)
+	signature := creds.signer.Sign(queryString)
 	params["signature"] = signature
 
 	// 使用现货 API
-	resp, err := o.binance.client.SpotClient.Get(ctx, "/api/v3/order", params)
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/api/v3/order", params, nil, creds.headers())
 	if err != nil {
 		return nil, fmt.Errorf("fetch order: %w", err)
 	}
@@ -698,6 +1362,181 @@ func (o *binanceSpotOrder) FetchOrder(ctx context.Context, symbol string, orderI
 		return nil, fmt.Errorf("unmarshal order: %w", err)
 	}
 
+	return toBinanceSpotOrder(symbol, &data), nil
+}
+
+// FetchOrders 查询交易对的历史订单（/api/v3/allOrders）
+func (o *binanceSpotOrder) FetchOrders(ctx context.Context, symbol string, since time.Time, limit int) ([]*model.SpotOrder, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	market, err := o.binance.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol":    market.ID,
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	if !since.IsZero() {
+		params["startTime"] = since.UnixMilli()
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+
+	o.binance.setRecvWindow(params)
+	queryString := BuildQueryString(params)
+	params["signature"] = creds.signer.Sign(queryString)
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/api/v3/allOrders", params, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("fetch orders: %w", err)
+	}
+
+	var data []binanceSpotFetchOrderResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal orders: %w", err)
+	}
+
+	orders := make([]*model.SpotOrder, 0, len(data))
+	for i := range data {
+		orders = append(orders, toBinanceSpotOrder(market.Symbol, &data[i]))
+	}
+	return orders, nil
+}
+
+// FetchOpenOrders 查询当前挂单（/api/v3/openOrders），symbol 为空时查询所有交易对并按返回的交易对 ID 还原标准化符号
+func (o *binanceSpotOrder) FetchOpenOrders(ctx context.Context, symbol string) ([]*model.SpotOrder, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	params := map[string]interface{}{
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	if symbol != "" {
+		market, err := o.binance.spot.market.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		params["symbol"] = market.ID
+	}
+
+	o.binance.setRecvWindow(params)
+	queryString := BuildQueryString(params)
+	params["signature"] = creds.signer.Sign(queryString)
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/api/v3/openOrders", params, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("fetch open orders: %w", err)
+	}
+
+	var data []binanceSpotFetchOrderResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal open orders: %w", err)
+	}
+
+	orders := make([]*model.SpotOrder, 0, len(data))
+	for i := range data {
+		// 未加载的交易对保留原始 ID
+		orderSymbol := data[i].Symbol
+		if market, err := o.binance.spot.market.GetMarketByID(data[i].Symbol); err == nil {
+			orderSymbol = market.Symbol
+		}
+		orders = append(orders, toBinanceSpotOrder(orderSymbol, &data[i]))
+	}
+	return orders, nil
+}
+
+// FetchMyTradesRange 查询账户成交记录（/api/v3/myTrades），startTime 与 endTime 间隔不能超过 24 小时
+func (o *binanceSpotOrder) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+	market, err := o.binance.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.FetchTradesRange(ctx, since, until, binanceTradePages(24*time.Hour, func(ctx context.Context, params map[string]interface{}) ([]*model.Trade, error) {
+		params["symbol"] = market.ID
+		params["timestamp"] = o.binance.clock.Timestamp()
+		o.binance.setRecvWindow(params)
+		params["signature"] = creds.signer.Sign(BuildQueryString(params))
+
+		resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/api/v3/myTrades", params, nil, creds.headers())
+		if err != nil {
+			return nil, fmt.Errorf("fetch my trades: %w", err)
+		}
+
+		var data []binanceSpotMyTrade
+		if err := json.Unmarshal(resp, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal my trades: %w", err)
+		}
+
+		trades := make([]*model.Trade, 0, len(data))
+		for _, item := range data {
+			side := "sell"
+			if item.IsBuyer {
+				side = "buy"
+			}
+			trades = append(trades, &model.Trade{
+				ID:        strconv.FormatInt(item.ID, 10),
+				OrderID:   strconv.FormatInt(item.OrderID, 10),
+				Symbol:    market.Symbol,
+				Side:      side,
+				Amount:    item.Qty.Decimal,
+				Price:     item.Price.Decimal,
+				Cost:      item.QuoteQty.Decimal,
+				Timestamp: item.Time.Time,
+			})
+		}
+		return trades, nil
+	}))
+}
+
+// toBinanceSpotOrderList 将订单组响应转换为 model.OrderList，优先使用 orderReports 中的子订单详情
+func toBinanceSpotOrderList(symbol string, r *binanceSpotOrderListResponse) *model.OrderList {
+	list := &model.OrderList{
+		ID:        strconv.FormatInt(r.OrderListID, 10),
+		ClientID:  r.ListClientOrderID,
+		Symbol:    symbol,
+		Type:      r.ContingencyType,
+		Status:    r.ListOrderStatus,
+		Orders:    make([]*model.OrderListOrder, 0, len(r.Orders)),
+		Timestamp: r.TransactionTime,
+	}
+	if len(r.OrderReports) > 0 {
+		for _, report := range r.OrderReports {
+			list.Orders = append(list.Orders, &model.OrderListOrder{
+				ID:        strconv.FormatInt(report.OrderID, 10),
+				ClientID:  report.ClientOrderID,
+				Type:      report.Type,
+				Side:      model.OrderSide(strings.ToLower(report.Side)),
+				Amount:    report.OrigQty,
+				Price:     report.Price,
+				StopPrice: report.StopPrice,
+			})
+		}
+		return list
+	}
+	for _, order := range r.Orders {
+		list.Orders = append(list.Orders, &model.OrderListOrder{
+			ID:       strconv.FormatInt(order.OrderID, 10),
+			ClientID: order.ClientOrderID,
+		})
+	}
+	return list
+}
+
+// toBinanceSpotOrder 将 Binance 订单响应转换为标准化现货订单
+func toBinanceSpotOrder(symbol string, data *binanceSpotFetchOrderResponse) *model.SpotOrder {
 	// 计算剩余数量
 	remaining := data.OrigQty.Sub(data.ExecutedQty.Decimal)
 
@@ -755,5 +1594,381 @@ func (o *binanceSpotOrder) FetchOrder(ctx context.Context, symbol string, orderI
 		UpdatedAt:     data.UpdateTime,
 	}
 
-	return order, nil
+	return order
+}
+
+// CreateConversion 闪兑（先通过 getQuote 询价，再通过 acceptQuote 确认报价）
+func (o *binanceSpotOrder) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	if amountDecimal.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	// 询价
+	quoteParams := map[string]interface{}{
+		"fromAsset":  strings.ToUpper(from),
+		"toAsset":    strings.ToUpper(to),
+		"fromAmount": amountDecimal.String(),
+		"timestamp":  o.binance.clock.Timestamp(),
+	}
+	o.binance.setRecvWindow(quoteParams)
+	quoteParams["signature"] = creds.signer.Sign(BuildQueryString(quoteParams))
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/sapi/v1/convert/getQuote", quoteParams, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("get convert quote: %w", err)
+	}
+
+	var quote binanceSpotConvertQuoteResponse
+	if err := json.Unmarshal(resp, &quote); err != nil {
+		return nil, fmt.Errorf("unmarshal convert quote: %w", err)
+	}
+	if quote.QuoteID == "" {
+		return nil, fmt.Errorf("get convert quote: no quote returned")
+	}
+
+	// 确认报价
+	acceptParams := map[string]interface{}{
+		"quoteId":   quote.QuoteID,
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	o.binance.setRecvWindow(acceptParams)
+	acceptParams["signature"] = creds.signer.Sign(BuildQueryString(acceptParams))
+
+	resp, err = o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/sapi/v1/convert/acceptQuote", acceptParams, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("accept convert quote: %w", err)
+	}
+
+	var accept binanceSpotConvertAcceptResponse
+	if err := json.Unmarshal(resp, &accept); err != nil {
+		return nil, fmt.Errorf("unmarshal convert accept: %w", err)
+	}
+
+	conversion := &model.Conversion{
+		ID:         accept.OrderID,
+		QuoteID:    quote.QuoteID,
+		From:       strings.ToUpper(from),
+		To:         strings.ToUpper(to),
+		FromAmount: quote.FromAmount,
+		ToAmount:   quote.ToAmount,
+		Rate:       quote.Ratio,
+		Status:     accept.OrderStatus,
+		Timestamp:  accept.CreateTime,
+	}
+
+	return conversion, nil
+}
+
+// FetchCurrencies 获取全部币种的充提状态及支持的网络（/sapi/v1/capital/config/getall）
+func (o *binanceSpotOrder) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	params := map[string]interface{}{
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	o.binance.setRecvWindow(params)
+	params["signature"] = creds.signer.Sign(BuildQueryString(params))
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/sapi/v1/capital/config/getall", params, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("fetch currencies: %w", err)
+	}
+
+	var data binanceSpotCurrencyResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal currencies: %w", err)
+	}
+
+	currencies := make(map[string]*model.Currency, len(data))
+	for _, item := range data {
+		networks := make([]model.CurrencyNetwork, 0, len(item.NetworkList))
+		for _, n := range item.NetworkList {
+			networks = append(networks, model.CurrencyNetwork{
+				Network:         n.Network,
+				Name:            n.Name,
+				Default:         n.IsDefault,
+				DepositEnabled:  n.DepositEnable,
+				WithdrawEnabled: n.WithdrawEnable,
+				WithdrawFee:     n.WithdrawFee,
+				WithdrawMin:     n.WithdrawMin,
+			})
+		}
+		currencies[item.Coin] = common.NewCurrency(item.Coin, item.Name, networks)
+	}
+	return currencies, nil
+}
+
+// FetchDepositAddress 获取充值地址（/sapi/v1/capital/deposit/address）
+func (o *binanceSpotOrder) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	params := map[string]interface{}{
+		"coin":      strings.ToUpper(currency),
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	if network != "" {
+		params["network"] = strings.ToUpper(network)
+	}
+	o.binance.setRecvWindow(params)
+	params["signature"] = creds.signer.Sign(BuildQueryString(params))
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodGet, "/sapi/v1/capital/deposit/address", params, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("fetch deposit address: %w", err)
+	}
+
+	var data binanceSpotDepositAddressResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal deposit address: %w", err)
+	}
+
+	// 响应不包含网络，使用请求的网络
+	return &model.DepositAddress{
+		Currency: data.Coin,
+		Address:  data.Address,
+		Tag:      data.Tag,
+		Network:  strings.ToUpper(network),
+	}, nil
+}
+
+// Withdraw 提币（/sapi/v1/capital/withdraw/apply），params 中的 tag 转换为 addressTag
+func (o *binanceSpotOrder) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	if amountDecimal.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	tag, reqParams := common.SplitWithdrawParams(params)
+	if tag != "" {
+		reqParams["addressTag"] = tag
+	}
+	reqParams["coin"] = strings.ToUpper(currency)
+	reqParams["address"] = address
+	reqParams["amount"] = amountDecimal.String()
+	if network != "" {
+		reqParams["network"] = strings.ToUpper(network)
+	}
+	reqParams["timestamp"] = o.binance.clock.Timestamp()
+	o.binance.setRecvWindow(reqParams)
+	reqParams["signature"] = creds.signer.Sign(BuildQueryString(reqParams))
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/sapi/v1/capital/withdraw/apply", reqParams, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("withdraw: %w", err)
+	}
+
+	var data binanceSpotWithdrawResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal withdraw: %w", err)
+	}
+
+	return &model.Transaction{
+		ID:        data.ID,
+		Currency:  strings.ToUpper(currency),
+		Amount:    types.ExDecimal{Decimal: amountDecimal},
+		Address:   address,
+		Tag:       tag,
+		Network:   strings.ToUpper(network),
+		Status:    model.TransactionStatusPending,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
+
+// binanceAccountNames 万向划转的账户名称，划转类型为 FROM_TO（如 MAIN_UMFUTURE）
+var binanceAccountNames = map[option.AccountType]string{
+	option.AccountSpot:    "MAIN",
+	option.AccountFutures: "UMFUTURE",
+	option.AccountMargin:  "MARGIN",
+	option.AccountFunding: "FUNDING",
+}
+
+// Transfer 账户间资金划转（/sapi/v1/asset/transfer）
+func (o *binanceSpotOrder) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	creds := o.binance.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	from, okFrom := binanceAccountNames[fromAccount]
+	to, okTo := binanceAccountNames[toAccount]
+	if !okFrom || !okTo || from == to {
+		return nil, common.UnsupportedTransferError(binanceName, fromAccount, toAccount)
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	if amountDecimal.LessThanOrEqual(decimal.Zero) {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	params := map[string]interface{}{
+		"type":      from + "_" + to,
+		"asset":     strings.ToUpper(currency),
+		"amount":    amountDecimal.String(),
+		"timestamp": o.binance.clock.Timestamp(),
+	}
+	o.binance.setRecvWindow(params)
+	params["signature"] = creds.signer.Sign(BuildQueryString(params))
+
+	resp, err := o.binance.client.SpotClient.RequestWithHeaders(ctx, http.MethodPost, "/sapi/v1/asset/transfer", params, nil, creds.headers())
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	var data binanceSpotTransferResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer: %w", err)
+	}
+
+	return &model.Transaction{
+		ID:        strconv.FormatInt(data.TranID, 10),
+		Currency:  strings.ToUpper(currency),
+		Amount:    types.ExDecimal{Decimal: amountDecimal},
+		Status:    model.TransactionStatusOK,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
+
+// FetchBorrowRate 获取全仓杠杆下一小时借币利率（/sapi/v1/margin/next-hourly-interest-rate）
+func (o *binanceSpotOrder) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	currency = strings.ToUpper(currency)
+	resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodGet, "/sapi/v1/margin/next-hourly-interest-rate", map[string]interface{}{
+		"assets":     currency,
+		"isIsolated": "FALSE",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch borrow rate: %w", err)
+	}
+
+	var data []binanceMarginInterestRateItem
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal borrow rate: %w", err)
+	}
+	for _, item := range data {
+		if strings.EqualFold(item.Asset, currency) {
+			return &model.BorrowRate{
+				Currency:  currency,
+				Rate:      item.NextHourlyInterestRate,
+				Timestamp: types.ExTimestamp{Time: time.Now()},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("fetch borrow rate: no rate returned for %s", currency)
+}
+
+// borrowRepay 全仓杠杆借币或还币（/sapi/v1/margin/borrow-repay），borrowType 为 BORROW 或 REPAY
+func (o *binanceSpotOrder) borrowRepay(ctx context.Context, op, borrowType, currency, amount string) error {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if !amountDecimal.IsPositive() {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+
+	resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodPost, "/sapi/v1/margin/borrow-repay", map[string]interface{}{
+		"asset":      strings.ToUpper(currency),
+		"isIsolated": "FALSE",
+		"amount":     amountDecimal.String(),
+		"type":       borrowType,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var data binanceSpotTransferResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", op, err)
+	}
+	return nil
+}
+
+// binanceSubAccountPageSize 子账户列表每页数量（接口默认每页 1 条，最大 200）
+const binanceSubAccountPageSize = 200
+
+// FetchSubAccounts 获取子账户列表（/sapi/v1/sub-account/list），子账户以邮箱标识，自动翻页
+func (o *binanceSpotOrder) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	accounts := make([]*model.SubAccount, 0)
+	for page := 1; ; page++ {
+		resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodGet, "/sapi/v1/sub-account/list", map[string]interface{}{
+			"page":  page,
+			"limit": binanceSubAccountPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetch sub accounts: %w", err)
+		}
+
+		var data binanceSubAccountListResponse
+		if err := json.Unmarshal(resp, &data); err != nil {
+			return nil, fmt.Errorf("unmarshal sub accounts: %w", err)
+		}
+		for _, item := range data.SubAccounts {
+			accounts = append(accounts, &model.SubAccount{
+				ID:        item.Email,
+				Name:      item.Email,
+				Frozen:    item.IsFreeze,
+				CreatedAt: item.CreateTime,
+			})
+		}
+		if len(data.SubAccounts) < binanceSubAccountPageSize {
+			return accounts, nil
+		}
+	}
+}
+
+// FetchBalanceFor 获取子账户现货余额（/sapi/v3/sub-account/assets），subAccountID 为子账户邮箱
+func (o *binanceSpotOrder) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	if subAccountID == "" {
+		return nil, fmt.Errorf("fetch balance for: sub account id is required")
+	}
+
+	resp, err := o.signedRequest(ctx, o.binance.client.SpotClient, http.MethodGet, "/sapi/v3/sub-account/assets", map[string]interface{}{
+		"email": subAccountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch balance for: %w", err)
+	}
+
+	var data binanceSubAccountAssetsResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal sub account balance: %w", err)
+	}
+
+	now := types.ExTimestamp{Time: time.Now()}
+	balances := make(model.Balances, 0, len(data.Balances))
+	for _, bal := range data.Balances {
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.Free,
+			Locked:    bal.Locked,
+			Total:     types.ExDecimal{Decimal: bal.Free.Add(bal.Locked.Decimal)},
+			UpdatedAt: now,
+		})
+	}
+	return balances, nil
 }
@@ -2,12 +2,24 @@ package binance
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
 	"github.com/shopspring/decimal"
 )
@@ -243,3 +255,2095 @@ func TestBinanceSpot_FetchTickers(t *testing.T) {
 		}
 	}
 }
+
+// TestBinanceSpot_CreateOrder_StrictClientID 测试交易所截断客户端订单ID时 WithStrictClientID 返回错误
+func TestBinanceSpot_CreateOrder_StrictClientID(t *testing.T) {
+	const requestedID = "my-very-long-client-order-id-0123456789-abcdef"
+	const truncatedID = "my-very-long-client-order-id-0123456789"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("newClientOrderId"); got != requestedID {
+			t.Errorf("newClientOrderId = %s, want %s", got, requestedID)
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"` + truncatedID + `","transactTime":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+
+	// 默认不校验
+	order, err := ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.001", option.WithClientOrderID(requestedID))
+	if err != nil {
+		t.Fatalf("CreateOrder without strict: %v", err)
+	}
+	if order.ClientOrderID != truncatedID {
+		t.Errorf("ClientOrderID = %s, want %s", order.ClientOrderID, truncatedID)
+	}
+
+	// 开启校验后返回错误，同时仍返回已提交的订单
+	order, err = ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.001",
+		option.WithClientOrderID(requestedID),
+		option.WithStrictClientID(),
+	)
+	if !errors.Is(err, common.ErrClientOrderIDMismatch) {
+		t.Fatalf("err = %v, want ErrClientOrderIDMismatch", err)
+	}
+	if order == nil || order.OrderId != "28" {
+		t.Errorf("order = %+v, want submitted order 28", order)
+	}
+}
+
+func TestBinanceSpot_CreateOrder_Fills(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 市价买单 FULL 响应，分三笔成交
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"orderListId":-1,"clientOrderId":"abc","transactTime":1700000000000,
+			"price":"0.00000000","origQty":"0.03000000","executedQty":"0.03000000","cummulativeQuoteQty":"1500.60000000",
+			"status":"FILLED","timeInForce":"GTC","type":"MARKET","side":"BUY","fills":[
+			{"price":"50000.00","qty":"0.01000000","commission":"0.00001000","commissionAsset":"BTC","tradeId":56},
+			{"price":"50010.00","qty":"0.01000000","commission":"0.00001000","commissionAsset":"BTC","tradeId":57},
+			{"price":"50050.00","qty":"0.01000000","commission":"0.00001000","commissionAsset":"BTC","tradeId":58}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	order, err := ex.Spot().CreateOrder(context.Background(), "BTC/USDT", option.Buy, "0.03")
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if len(order.Fills) != 3 {
+		t.Fatalf("Fills = %d, want 3", len(order.Fills))
+	}
+	fill := order.Fills[1]
+	if fill.ID != "57" || fill.OrderID != "28" || fill.Side != "buy" || fill.Type != "market" || fill.Cost.String() != "500.1" || fill.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("fill = %+v", fill)
+	}
+	if order.Fee == nil || order.Fee.Currency != "BTC" || order.Fee.Cost.String() != "0.00003" {
+		t.Errorf("Fee = %+v, want 0.00003 BTC", order.Fee)
+	}
+	if got := order.Filled().String(); got != "0.03" {
+		t.Errorf("Filled = %s, want 0.03", got)
+	}
+	if got := order.Cost().String(); got != "1500.6" {
+		t.Errorf("Cost = %s, want 1500.6", got)
+	}
+	if got := order.AvgPrice().String(); got != "50020" {
+		t.Errorf("AvgPrice = %s, want 50020", got)
+	}
+	if order.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("Timestamp = %v, want transactTime", order.Timestamp)
+	}
+}
+
+func TestBinanceSpot_CreateOrder_RetryIdempotent(t *testing.T) {
+	var posts, lookups int32
+	var mu sync.Mutex
+	var postedID, lookedUpID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			// 交易所已接受订单，但响应晚于客户端超时
+			atomic.AddInt32(&posts, 1)
+			id := r.URL.Query().Get("newClientOrderId")
+			mu.Lock()
+			postedID = id
+			mu.Unlock()
+			time.Sleep(300 * time.Millisecond)
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"` + id + `","transactTime":1700000000000}`))
+		case http.MethodGet:
+			atomic.AddInt32(&lookups, 1)
+			id := r.URL.Query().Get("origClientOrderId")
+			mu.Lock()
+			lookedUpID = id
+			mu.Unlock()
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"` + id + `","price":"50000","origQty":"0.01","executedQty":"0","status":"NEW","type":"LIMIT","side":"BUY","time":1700000000000,"updateTime":1700000000000}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL, "timeout": 100 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	ctx := common.WithRetryPolicy(context.Background(), common.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond, RetryNonIdempotent: true})
+	order, err := ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.01", option.WithPrice("50000"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	// 等待超时的下单请求处理结束后再读取记录的ID
+	srv.Close()
+	mu.Lock()
+	defer mu.Unlock()
+	if n := atomic.LoadInt32(&posts); n != 1 {
+		t.Errorf("order submitted %d times, want 1", n)
+	}
+	if n := atomic.LoadInt32(&lookups); n != 1 {
+		t.Errorf("lookups = %d, want 1", n)
+	}
+	// 未指定客户端订单ID时自动生成，重试前按该ID查询
+	if postedID == "" || lookedUpID != postedID {
+		t.Errorf("posted client id %q, looked up %q", postedID, lookedUpID)
+	}
+	if order.OrderId != "28" || order.ClientOrderID != postedID || order.Symbol != "BTC/USDT" {
+		t.Errorf("unexpected order: %+v", order)
+	}
+}
+
+func TestBinanceSpot_CreateOrder_PostOnly(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":29,"clientOrderId":"maker-1","transactTime":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	if _, err := ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.001", option.WithPrice("50000"), option.WithPostOnly(true)); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	// 现货只做 Maker 使用 LIMIT_MAKER，不发送 timeInForce
+	if query.Get("type") != "LIMIT_MAKER" || query.Has("timeInForce") {
+		t.Errorf("type = %s, timeInForce = %q, want LIMIT_MAKER without timeInForce", query.Get("type"), query.Get("timeInForce"))
+	}
+
+	query = nil
+	if _, err := ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.001", option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only market order err = %v, want ErrInvalidOrder", err)
+	}
+	if query != nil {
+		t.Error("post-only market order should not be sent")
+	}
+}
+
+// TestBinanceSpot_FetchTicker_Decimal Binance 行情字段为按精度补零的字符串（如 0.00001000），解析后去掉多余的零且不丢失精度
+func TestBinanceSpot_FetchTicker_Decimal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/ticker/24hr" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","bidPrice":"0.00001230","askPrice":"0.00001240","lastPrice":"65000.00000001","openPrice":"64000.00000000","highPrice":"66000.00000000","lowPrice":"0.00000001","volume":"1234.56789012","quoteVolume":"80000000.12345678","closeTime":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+
+	exchangetest.CheckTickerDecimals(t, ticker, map[string]string{
+		"bid": "0.0000123", "ask": "0.0000124", "last": "65000.00000001", "open": "64000", "high": "66000",
+		"low": "0.00000001", "volume": "1234.56789012", "quote_volume": "80000000.12345678",
+	})
+}
+
+func TestBinanceSpot_FetchAggregatedTrades(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/aggTrades" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("startTime"); got != "1700000000000" {
+			t.Errorf("startTime = %q, want 1700000000000", got)
+		}
+		w.Write([]byte(`[
+			{"a":26129,"p":"65000.10","q":"0.015","f":27781,"l":27783,"T":1700000000100,"m":true,"M":true},
+			{"a":26130,"p":"65000.20","q":"0.5","f":27784,"l":27784,"T":1700000000200,"m":false,"M":true}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	aggs, err := ex.Spot().FetchAggregatedTrades(context.Background(), "BTC/USDT", time.UnixMilli(1700000000000), 2)
+	if err != nil {
+		t.Fatalf("FetchAggregatedTrades: %v", err)
+	}
+	if len(aggs) != 2 {
+		t.Fatalf("got %d agg trades, want 2", len(aggs))
+	}
+
+	first := aggs[0]
+	if first.ID != "26129" || first.FirstTradeID != "27781" || first.LastTradeID != "27783" || first.Count != 3 {
+		t.Errorf("first = %+v, want id 26129 covering trades 27781-27783", first)
+	}
+	if first.Side != "sell" || first.Symbol != "BTC/USDT" {
+		t.Errorf("first side/symbol = %s/%s, want sell/BTC/USDT", first.Side, first.Symbol)
+	}
+	if first.Price.String() != "65000.1" || first.Amount.String() != "0.015" {
+		t.Errorf("first price/amount = %s/%s", first.Price.String(), first.Amount.String())
+	}
+	if !first.Timestamp.Equal(time.UnixMilli(1700000000100)) {
+		t.Errorf("first timestamp = %v", first.Timestamp.Time)
+	}
+	if aggs[1].Side != "buy" {
+		t.Errorf("second side = %s, want buy", aggs[1].Side)
+	}
+}
+
+func TestBinance_UpdateCredentials_Concurrent(t *testing.T) {
+	secrets := map[string]string{"key-0": "secret-0", "key-1": "secret-1", "key-2": "secret-2"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 签名必须由请求头中 API Key 对应的密钥生成，新旧凭证混用时验签失败
+		secret, ok := secrets[r.Header.Get("X-MBX-APIKEY")]
+		var signature string
+		var signed []string
+		for _, pair := range strings.Split(r.URL.RawQuery, "&") {
+			if v, found := strings.CutPrefix(pair, "signature="); found {
+				signature = v
+				continue
+			}
+			signed = append(signed, pair)
+		}
+		if !ok || common.SignHMAC256(strings.Join(signed, "&"), secret) != signature {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":-1022,"msg":"Signature for this request is not valid."}`))
+			return
+		}
+		w.Write([]byte(`{"balances":[]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key-0", "secret-0", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if c := ex.(*Binance).client; c.APIKey != "key-0" || c.SecretKey != "secret-0" {
+		t.Errorf("client credentials = %q/%q, want key-0/secret-0", c.APIKey, c.SecretKey)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ctx.Err() == nil; i++ {
+			key := fmt.Sprintf("key-%d", i%3)
+			ex.UpdateCredentials(key, secrets[key], "")
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*20)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				if _, err := ex.Spot().FetchBalance(context.Background()); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	cancel()
+	<-done
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("FetchBalance during rotation: %v", err)
+	}
+}
+
+func TestBinanceSpot_FetchOHLCVs_ClosedCandlesOnly(t *testing.T) {
+	// 最后一根K线为当前分钟，仍在形成中
+	current := time.Now().Truncate(time.Minute)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/klines" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var rows []string
+		for i := 2; i >= 0; i-- {
+			ts := current.Add(-time.Duration(i) * time.Minute).UnixMilli()
+			rows = append(rows, fmt.Sprintf(`[%d,"100","101","99","100.5","10",%d,"1005",5,"5","502",""]`, ts, ts+59999))
+		}
+		w.Write([]byte("[" + strings.Join(rows, ",") + "]"))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ohlcvs, err := ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m", option.WithLimit(3))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 3 {
+		t.Fatalf("expected 3 candles without option, got %d", len(ohlcvs))
+	}
+
+	ohlcvs, err = ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m", option.WithLimit(3), option.WithClosedCandlesOnly())
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 2 {
+		t.Fatalf("expected 2 closed candles, got %d", len(ohlcvs))
+	}
+	if !ohlcvs[len(ohlcvs)-1].Timestamp.Before(current) {
+		t.Errorf("in-progress candle %s was not dropped", ohlcvs[len(ohlcvs)-1].Timestamp)
+	}
+
+	// 按服务器时间判定：本地时钟落后 10 分钟时，本地当前分钟的K线在服务器上已收盘
+	e.clock.SetOffset(10 * time.Minute)
+	ohlcvs, err = ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m", option.WithLimit(3), option.WithClosedCandlesOnly())
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 3 {
+		t.Errorf("expected 3 closed candles with server clock ahead, got %d", len(ohlcvs))
+	}
+
+	// 本地时钟超前 10 分钟时，这些K线在服务器上都未收盘
+	e.clock.SetOffset(-10 * time.Minute)
+	ohlcvs, err = ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m", option.WithLimit(3), option.WithClosedCandlesOnly())
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 0 {
+		t.Errorf("expected no closed candles with server clock behind, got %d", len(ohlcvs))
+	}
+}
+
+func TestBinanceSpot_FetchOHLCVs_Precision(t *testing.T) {
+	// 收盘价超出 float64 精度，应原样解析为 decimal
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[[1700000000000,"65000.1","65100.5","64900.000000000000000001","65000.123456789012345678","12.345678901234567891",1700000059999,"1005",5,"5","502",""]]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ohlcvs, err := ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m")
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Close.String() != "65000.123456789012345678" ||
+		ohlcvs[0].Low.String() != "64900.000000000000000001" || ohlcvs[0].Volume.String() != "12.345678901234567891" {
+		t.Errorf("ohlcvs = %+v, want high-precision values preserved", ohlcvs)
+	}
+}
+
+func TestBinanceSpot_FetchOHLCVRange(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(2500 * time.Minute)
+	var pages atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/klines" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		pages.Add(1)
+		q := r.URL.Query()
+		start, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+		if q.Get("limit") != "1000" {
+			t.Errorf("limit = %s, want 1000", q.Get("limit"))
+		}
+		// 每页从上一页最后一根K线开始返回，验证重叠K线去重
+		var rows []string
+		for ts := start - 60000; ts <= end; ts += 60000 {
+			rows = append(rows, fmt.Sprintf(`[%d,"100","101","99","100.5","10",%d,"1005",5,"5","502",""]`, ts, ts+59999))
+		}
+		w.Write([]byte("[" + strings.Join(rows, ",") + "]"))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ohlcvs, err := ex.Spot().FetchOHLCVRange(context.Background(), "BTC/USDT", "1m", since, until)
+	if err != nil {
+		t.Fatalf("FetchOHLCVRange: %v", err)
+	}
+	if n := pages.Load(); n != 3 {
+		t.Errorf("expected 3 page requests, got %d", n)
+	}
+	if len(ohlcvs) != 2500 {
+		t.Fatalf("expected 2500 candles, got %d", len(ohlcvs))
+	}
+	for i, c := range ohlcvs {
+		if want := since.Add(time.Duration(i) * time.Minute); !c.Timestamp.Equal(want) {
+			t.Fatalf("candle %d timestamp = %s, want %s", i, c.Timestamp, want)
+		}
+	}
+}
+
+func TestBinanceSpot_FetchTicker_TradeCount(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/ticker/24hr" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"symbol":"BTCUSDT","priceChange":"100","priceChangePercent":"0.2","weightedAvgPrice":"50012.34",
+			"prevClosePrice":"50000","lastPrice":"50100","lastQty":"0.01","bidPrice":"50099.9","bidQty":"1",
+			"askPrice":"50100.1","askQty":"2","openPrice":"50000","highPrice":"51000","lowPrice":"49000",
+			"volume":"1000","quoteVolume":"50012340","openTime":1699913600000,"closeTime":1700000000000,
+			"firstId":28385,"lastId":1028384,"count":1000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.TradeCount != 1000000 {
+		t.Errorf("TradeCount = %d, want 1000000", ticker.TradeCount)
+	}
+	if !ticker.VWAP.Equal(decimal.RequireFromString("50012.34")) {
+		t.Errorf("VWAP = %s, want 50012.34", ticker.VWAP.String())
+	}
+}
+
+func TestBinanceSpot_FetchTicker_ArrayResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 部分网关在传 symbol 时仍返回数组
+		w.Write([]byte(`[{"symbol":"ETHUSDT","lastPrice":"3000","bidPrice":"2999","askPrice":"3001","closeTime":1700000000000},
+			{"symbol":"BTCUSDT","lastPrice":"50000","bidPrice":"49999","askPrice":"50001","closeTime":1700000000000}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Last.String() != "50000" || ticker.Bid.String() != "49999" || ticker.Ask.String() != "50001" {
+		t.Errorf("unexpected ticker %s last=%s bid=%s ask=%s", ticker.Symbol, ticker.Last, ticker.Bid, ticker.Ask)
+	}
+}
+
+func TestBinanceSpot_FetchOrders(t *testing.T) {
+	var query map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/allOrders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = map[string]string{}
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		// 录制的 allOrders 响应
+		w.Write([]byte(`[
+			{"symbol":"BTCUSDT","orderId":28,"orderListId":-1,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP","price":"50000.00",
+			 "origQty":"0.10000000","executedQty":"0.04000000","cummulativeQuoteQty":"2000.00","status":"PARTIALLY_FILLED",
+			 "timeInForce":"GTC","type":"LIMIT","side":"BUY","stopPrice":"0.00","icebergQty":"0.00",
+			 "time":1700000000000,"updateTime":1700000005000,"isWorking":true,"origQuoteOrderQty":"0.00"},
+			{"symbol":"BTCUSDT","orderId":29,"orderListId":-1,"clientOrderId":"x-abc","price":"0.00",
+			 "origQty":"0.05000000","executedQty":"0.05000000","cummulativeQuoteQty":"2505.00","status":"FILLED",
+			 "timeInForce":"GTC","type":"MARKET","side":"SELL","stopPrice":"0.00","icebergQty":"0.00",
+			 "time":1700000010000,"updateTime":1700000010000,"isWorking":true,"origQuoteOrderQty":"0.00"}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	since := time.UnixMilli(1699990000000)
+	orders, err := e.spot.FetchOrders(context.Background(), "BTC/USDT", since, 50)
+	if err != nil {
+		t.Fatalf("FetchOrders: %v", err)
+	}
+	if query["symbol"] != "BTCUSDT" || query["startTime"] != "1699990000000" || query["limit"] != "50" {
+		t.Errorf("query = %v, want symbol=BTCUSDT startTime=1699990000000 limit=50", query)
+	}
+	if query["signature"] == "" || query["timestamp"] == "" {
+		t.Errorf("request not signed: %v", query)
+	}
+
+	if len(orders) != 2 {
+		t.Fatalf("got %d orders, want 2", len(orders))
+	}
+	first := orders[0]
+	if first.ID != "28" || first.Symbol != "BTC/USDT" || first.Status != model.OrderStatusOpen ||
+		first.Side != model.OrderSideBuy || first.Type != model.OrderTypeLimit {
+		t.Errorf("order[0] = %s %s %s %s %s", first.ID, first.Symbol, first.Status, first.Side, first.Type)
+	}
+	if !first.Filled.Equal(decimal.RequireFromString("0.04")) || !first.Remaining.Equal(decimal.RequireFromString("0.06")) {
+		t.Errorf("order[0] Filled/Remaining = %s/%s, want 0.04/0.06", first.Filled, first.Remaining)
+	}
+	second := orders[1]
+	if second.Status != model.OrderStatusFilled || second.Side != model.OrderSideSell || second.Type != model.OrderTypeMarket {
+		t.Errorf("order[1] = %s %s %s", second.Status, second.Side, second.Type)
+	}
+	if !second.Remaining.IsZero() || second.CreatedAt.UnixMilli() != 1700000010000 {
+		t.Errorf("order[1] Remaining = %s, CreatedAt = %d", second.Remaining, second.CreatedAt.UnixMilli())
+	}
+
+	// 未配置密钥时返回 ErrAuthenticationRequired
+	e.UpdateCredentials("", "", "")
+	if _, err := e.spot.FetchOrders(context.Background(), "BTC/USDT", time.Time{}, 0); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("FetchOrders without secret = %v, want ErrAuthenticationRequired", err)
+	}
+}
+
+func TestBinanceSpot_WatchTicker(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) != `{"method":"SUBSCRIBE","params":["btcusdt@ticker"],"id":1}` {
+			t.Errorf("unexpected subscribe message: %s", msg)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@ticker","data":{"e":"24hrTicker","E":1700000000123,
+			"s":"BTCUSDT","p":"100","P":"0.2","w":"50012.34","x":"50000","c":"50100","Q":"0.01","b":"50099.9","B":"1",
+			"a":"50100.1","A":"2","o":"50000","h":"51000","l":"49000","v":"1000","q":"50012340",
+			"O":1699913600000,"C":1700000000000,"F":28385,"L":1028384,"n":1000000}}`))
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", nil)
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	e.spotWS = common.NewWSManager(common.NewWSDialer("ws"+strings.TrimPrefix(srv.URL, "http"), ""), &binanceWSProtocol{})
+	defer e.spotWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch, err := ex.Spot().WatchTicker(ctx, "BTC/USDT")
+	if err != nil {
+		t.Fatalf("WatchTicker: %v", err)
+	}
+
+	ticker, ok := <-ch
+	if !ok {
+		t.Fatal("channel closed before ticker")
+	}
+	if ticker.Symbol != "BTC/USDT" {
+		t.Errorf("Symbol = %s, want BTC/USDT", ticker.Symbol)
+	}
+	// 大小写不同的字段（c/C、e/E 等）不能互相覆盖
+	if !ticker.Last.Equal(decimal.RequireFromString("50100")) {
+		t.Errorf("Last = %s, want 50100", ticker.Last.String())
+	}
+	if !ticker.Bid.Equal(decimal.RequireFromString("50099.9")) || !ticker.Ask.Equal(decimal.RequireFromString("50100.1")) {
+		t.Errorf("Bid/Ask = %s/%s, want 50099.9/50100.1", ticker.Bid.String(), ticker.Ask.String())
+	}
+	if ticker.TradeCount != 1000000 {
+		t.Errorf("TradeCount = %d, want 1000000", ticker.TradeCount)
+	}
+	if ticker.Timestamp.UnixMilli() != 1700000000123 {
+		t.Errorf("Timestamp = %d, want 1700000000123", ticker.Timestamp.UnixMilli())
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestBinanceSpot_Wallet(t *testing.T) {
+	var withdrawQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-MBX-APIKEY") != "key" || r.URL.Query().Get("signature") == "" {
+			t.Errorf("unsigned request: %s %s", r.Method, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/sapi/v1/capital/deposit/address":
+			if r.URL.Query().Get("coin") != "XRP" || r.URL.Query().Get("network") != "XRP" {
+				t.Errorf("unexpected deposit address query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"address":"rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh","coin":"XRP","tag":"108618262","url":""}`))
+		case "/sapi/v1/capital/withdraw/apply":
+			withdrawQuery = r.URL.Query()
+			w.Write([]byte(`{"id":"7213fea8e94b4a5593d507237e5a555b"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	addr, err := ex.Spot().FetchDepositAddress(ctx, "xrp", "xrp")
+	if err != nil {
+		t.Fatalf("FetchDepositAddress: %v", err)
+	}
+	if addr.Currency != "XRP" || addr.Address != "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh" || addr.Tag != "108618262" || addr.Network != "XRP" {
+		t.Errorf("unexpected deposit address: %+v", addr)
+	}
+
+	tx, err := ex.Spot().Withdraw(ctx, "xrp", "25.5", "rEb8TK3gBgk5auZkwc6sHnwrGVJH8DuaLh", "XRP",
+		map[string]interface{}{"tag": "108618262", "walletType": 1})
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if withdrawQuery.Get("coin") != "XRP" || withdrawQuery.Get("amount") != "25.5" || withdrawQuery.Get("network") != "XRP" ||
+		withdrawQuery.Get("addressTag") != "108618262" || withdrawQuery.Get("walletType") != "1" || withdrawQuery.Has("tag") {
+		t.Errorf("unexpected withdraw query: %v", withdrawQuery)
+	}
+	if tx.ID != "7213fea8e94b4a5593d507237e5a555b" || tx.Status != model.TransactionStatusPending || tx.Amount.String() != "25.5" {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	// 未配置凭证时返回认证错误
+	noAuth, _ := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if _, err := noAuth.Spot().FetchDepositAddress(ctx, "BTC", ""); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("err = %v, want ErrAuthenticationRequired", err)
+	}
+}
+
+func TestBinanceSpot_Transfer(t *testing.T) {
+	var gotType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sapi/v1/asset/transfer" || r.URL.Query().Get("signature") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotType = r.URL.Query().Get("type")
+		w.Write([]byte(`{"tranId":13526853623}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		from, to option.AccountType
+		wantType string
+	}{
+		{option.AccountSpot, option.AccountFutures, "MAIN_UMFUTURE"},
+		{option.AccountFutures, option.AccountSpot, "UMFUTURE_MAIN"},
+		{option.AccountFunding, option.AccountMargin, "FUNDING_MARGIN"},
+	}
+	for _, tt := range tests {
+		tx, err := ex.Spot().Transfer(ctx, "usdt", "100", tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("Transfer %s->%s: %v", tt.from, tt.to, err)
+		}
+		if gotType != tt.wantType {
+			t.Errorf("type = %s, want %s", gotType, tt.wantType)
+		}
+		if tx.ID != "13526853623" || tx.Currency != "USDT" || tx.Status != model.TransactionStatusOK {
+			t.Errorf("unexpected transaction: %+v", tx)
+		}
+	}
+
+	if _, err := ex.Spot().Transfer(ctx, "USDT", "100", option.AccountSpot, option.AccountSpot); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestBinanceSpot_SubAccounts(t *testing.T) {
+	var gotEmail string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("signature") == "" {
+			t.Errorf("unsigned request: %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/sapi/v1/sub-account/list":
+			if r.URL.Query().Get("limit") != "200" {
+				t.Errorf("limit = %s, want 200", r.URL.Query().Get("limit"))
+			}
+			w.Write([]byte(`{"subAccounts":[{"email":"alpha@example.com","isFreeze":false,"createTime":1544433328000,"isManagedSubAccount":false},{"email":"beta@example.com","isFreeze":true,"createTime":1544433329000}]}`))
+		case "/sapi/v3/sub-account/assets":
+			gotEmail = r.URL.Query().Get("email")
+			w.Write([]byte(`{"balances":[{"asset":"BTC","free":0.5,"locked":0.25,"freeze":0,"withdrawing":0},{"asset":"USDT","free":"100","locked":"0"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	accounts, err := ex.Spot().FetchSubAccounts(ctx)
+	if err != nil {
+		t.Fatalf("FetchSubAccounts: %v", err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("len(accounts) = %d, want 2", len(accounts))
+	}
+	if accounts[0].ID != "alpha@example.com" || accounts[0].Frozen || accounts[0].CreatedAt.UnixMilli() != 1544433328000 {
+		t.Errorf("unexpected account: %+v", accounts[0])
+	}
+	if accounts[1].ID != "beta@example.com" || !accounts[1].Frozen {
+		t.Errorf("unexpected account: %+v", accounts[1])
+	}
+
+	balances, err := ex.Spot().FetchBalanceFor(ctx, "beta@example.com")
+	if err != nil {
+		t.Fatalf("FetchBalanceFor: %v", err)
+	}
+	if gotEmail != "beta@example.com" {
+		t.Errorf("email = %q, want beta@example.com", gotEmail)
+	}
+	if len(balances) != 2 {
+		t.Fatalf("len(balances) = %d, want 2", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "BTC" || b.Available.String() != "0.5" || b.Locked.String() != "0.25" || b.Total.String() != "0.75" {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+
+	if _, err := ex.Spot().FetchBalanceFor(ctx, ""); err == nil {
+		t.Error("expected error for empty sub account id")
+	}
+}
+
+func TestBinanceSpot_Margin(t *testing.T) {
+	var borrowQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("signature") == "" {
+			t.Errorf("unsigned request: %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/sapi/v1/margin/next-hourly-interest-rate":
+			if r.URL.Query().Get("assets") != "BTC" || r.URL.Query().Get("isIsolated") != "FALSE" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`[{"asset":"BTC","nextHourlyInterestRate":"0.00000571"}]`))
+		case "/sapi/v1/margin/borrow-repay":
+			if r.Method != http.MethodPost {
+				t.Errorf("method = %s, want POST", r.Method)
+			}
+			borrowQuery = r.URL.Query()
+			w.Write([]byte(`{"tranId":100000001}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	rate, err := ex.Spot().FetchBorrowRate(ctx, "btc")
+	if err != nil {
+		t.Fatalf("FetchBorrowRate: %v", err)
+	}
+	if rate.Currency != "BTC" || rate.Rate.String() != "0.00000571" {
+		t.Errorf("unexpected rate: %+v", rate)
+	}
+
+	if err := ex.Spot().Borrow(ctx, "usdt", "100.50"); err != nil {
+		t.Fatalf("Borrow: %v", err)
+	}
+	if borrowQuery.Get("asset") != "USDT" || borrowQuery.Get("amount") != "100.5" || borrowQuery.Get("type") != "BORROW" || borrowQuery.Get("isIsolated") != "FALSE" {
+		t.Errorf("unexpected borrow query: %v", borrowQuery)
+	}
+	if err := ex.Spot().Repay(ctx, "USDT", "100.5"); err != nil {
+		t.Fatalf("Repay: %v", err)
+	}
+	if borrowQuery.Get("type") != "REPAY" {
+		t.Errorf("type = %s, want REPAY", borrowQuery.Get("type"))
+	}
+
+	if err := ex.Spot().Borrow(ctx, "USDT", "0"); err == nil {
+		t.Error("expected error for zero amount")
+	}
+}
+
+func TestBinanceSpot_FetchBalance_Futures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fapi/v2/balance" || r.URL.Query().Get("signature") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"accountAlias":"SgsR","asset":"USDT","balance":"122.60","crossWalletBalance":"122.60","crossUnPnl":"-2.60","availableBalance":"100.00","maxWithdrawAmount":"100.00","marginAvailable":true,"updateTime":1617939110373}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+
+	balances, err := ex.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFutures))
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if len(balances) != 1 {
+		t.Fatalf("len(balances) = %d, want 1", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "USDT" || b.Total.String() != "120" || b.Available.String() != "100" || b.Locked.String() != "20" {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+}
+
+func TestBinance_TimeSync(t *testing.T) {
+	// 服务器时间比本地快 1 小时
+	serverOffset := time.Hour
+	signed := make(chan int64, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/time":
+			fmt.Fprintf(w, `{"serverTime":%d}`, time.Now().Add(serverOffset).UnixMilli())
+		case "/api/v3/account":
+			var ts int64
+			fmt.Sscan(r.URL.Query().Get("timestamp"), &ts)
+			signed <- ts
+			w.Write([]byte(`{"balances":[],"updateTime":0}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL, "timeSync": true})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	defer ex.Drain(context.Background())
+
+	serverTime, err := ex.FetchTime(context.Background())
+	if err != nil {
+		t.Fatalf("FetchTime: %v", err)
+	}
+	if d := time.Until(serverTime) - serverOffset; d < -time.Second || d > time.Second {
+		t.Errorf("server time = %v, want ~now+1h", serverTime)
+	}
+
+	// 后台同步完成后，签名时间戳按服务器时间校正
+	b := ex.(*Binance)
+	deadline := time.Now().Add(2 * time.Second)
+	for b.clock.Offset() < serverOffset/2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := ex.Spot().FetchBalance(context.Background()); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if d := <-signed - time.Now().Add(serverOffset).UnixMilli(); d < -1000 || d > 1000 {
+		t.Errorf("signed timestamp off by %dms from server time", d)
+	}
+
+	// 偏移按实例独立，未启用同步的实例使用本地时间
+	other, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if offset := other.(*Binance).clock.Offset(); offset != 0 {
+		t.Errorf("other instance offset = %v, want 0", offset)
+	}
+}
+
+func TestBinance_RecvWindow(t *testing.T) {
+	var mu sync.Mutex
+	recvWindows := make(map[string]string)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 签名覆盖除 signature 外的原始查询串
+		query := r.URL.Query()
+		var signed []string
+		for _, pair := range strings.Split(r.URL.RawQuery, "&") {
+			if !strings.HasPrefix(pair, "signature=") {
+				signed = append(signed, pair)
+			}
+		}
+		if want := NewSigner("secret").Sign(strings.Join(signed, "&")); query.Get("signature") != want {
+			t.Errorf("%s: signature does not cover recvWindow", r.URL.Path)
+		}
+		mu.Lock()
+		recvWindows[r.URL.Path] = query.Get("recvWindow")
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v3/account":
+			w.Write([]byte(`{"balances":[],"updateTime":0}`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer srv.Close()
+
+	// 超过 Binance 上限时按 60000 发送
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL, "perpBaseURL": srv.URL, "recvWindow": 90000})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if _, err := ex.Spot().FetchBalance(context.Background()); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if _, err := ex.Perp().FetchPositions(context.Background()); err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	for _, path := range []string{"/api/v3/account", "/fapi/v2/positionRisk", "/dapi/v1/positionRisk"} {
+		if got := recvWindows[path]; got != "60000" {
+			t.Errorf("%s recvWindow = %q, want 60000", path, got)
+		}
+	}
+
+	// 未配置时不发送，使用交易所默认值
+	other, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if _, err := other.Spot().FetchBalance(context.Background()); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if got := recvWindows["/api/v3/account"]; got != "" {
+		t.Errorf("default recvWindow = %q, want none", got)
+	}
+}
+
+func TestBinanceSpot_WatchOHLCV(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/klines" {
+			// 收盘K线的 REST 快照
+			w.Write([]byte(`[[1700000040000,"100","102.5","99","101","12.5",1700000099999,"1262.5",30,"6","606",""]]`))
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) != `{"method":"SUBSCRIBE","params":["btcusdt@kline_1m"],"id":1}` {
+			t.Errorf("unexpected subscribe message: %s", msg)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`))
+		// 同一根K线的两次盘中更新和一次收盘推送
+		for _, frame := range []string{
+			`{"t":1700000040000,"T":1700000099999,"s":"BTCUSDT","i":"1m","f":1,"L":10,"o":"100","c":"100.5","h":"101","l":"99.5","v":"3","n":10,"x":false,"q":"301.5","V":"1","Q":"100.5","B":"0"}`,
+			`{"t":1700000040000,"T":1700000099999,"s":"BTCUSDT","i":"1m","f":1,"L":20,"o":"100","c":"102","h":"102.5","l":"99","v":"8","n":20,"x":false,"q":"808","V":"4","Q":"404","B":"0"}`,
+			`{"t":1700000040000,"T":1700000099999,"s":"BTCUSDT","i":"1m","f":1,"L":30,"o":"100","c":"101","h":"102.5","l":"99","v":"12.5","n":30,"x":true,"q":"1262.5","V":"6","Q":"606","B":"0"}`,
+		} {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@kline_1m","data":{"e":"kline","E":1700000060000,"s":"BTCUSDT","k":`+frame+`}}`))
+		}
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	e.spotWS = common.NewWSManager(common.NewWSDialer("ws"+strings.TrimPrefix(srv.URL, "http"), ""), &binanceWSProtocol{})
+	defer e.spotWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 不支持的周期与 FetchOHLCVs 一样返回 ErrNotSupported
+	if _, err := ex.Spot().WatchOHLCV(ctx, "BTC/USDT", "10s"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("WatchOHLCV(10s) = %v, want ErrNotSupported", err)
+	}
+	if _, err := ex.Spot().FetchOHLCVs(ctx, "BTC/USDT", "10s"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("FetchOHLCVs(10s) = %v, want ErrNotSupported", err)
+	}
+
+	ch, err := ex.Spot().WatchOHLCV(ctx, "BTC/USDT", "1m")
+	if err != nil {
+		t.Fatalf("WatchOHLCV: %v", err)
+	}
+
+	var candles []*model.OHLCV
+	for candle := range ch {
+		candles = append(candles, candle)
+		if candle.Closed {
+			break
+		}
+	}
+	if len(candles) != 3 {
+		t.Fatalf("got %d candles, want 3", len(candles))
+	}
+	if candles[0].Closed || candles[1].Closed {
+		t.Error("in-progress candles must not be closed")
+	}
+	if !candles[1].Close.Equal(decimal.RequireFromString("102")) {
+		t.Errorf("in-progress Close = %s, want 102", candles[1].Close.String())
+	}
+
+	snapshot, err := ex.Spot().FetchOHLCVs(ctx, "BTC/USDT", "1m", option.WithLimit(1))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	final, want := candles[2], snapshot[0]
+	if !final.Timestamp.Equal(want.Timestamp.Time) || !final.Open.Equal(want.Open.Decimal) || !final.High.Equal(want.High.Decimal) ||
+		!final.Low.Equal(want.Low.Decimal) || !final.Close.Equal(want.Close.Decimal) || !final.Volume.Equal(want.Volume.Decimal) {
+		t.Errorf("closed candle = %+v, want snapshot %+v", final, want)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestBinanceSpot_WatchOrders_UserDataStream(t *testing.T) {
+	var mu sync.Mutex
+	var created, refreshed []string
+	expire := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/userDataStream" {
+			if r.Header.Get("X-MBX-APIKEY") != "key" {
+				t.Errorf("missing API key header")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			switch r.Method {
+			case http.MethodPost:
+				listenKey := fmt.Sprintf("lk%d", len(created)+1)
+				created = append(created, listenKey)
+				w.Write([]byte(`{"listenKey":"` + listenKey + `"}`))
+			case http.MethodPut:
+				refreshed = append(refreshed, r.URL.Query().Get("listenKey"))
+				w.Write([]byte(`{}`))
+			}
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		switch r.URL.Path {
+		case "/ws/lk1":
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"outboundAccountPosition","E":1700000000101,"u":1700000000101,"B":[{"a":"USDT","f":"800","l":"200"},{"a":"BTC","f":"1","l":"0"}]}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"executionReport","E":1700000000100,"s":"BTCUSDT","c":"my1","S":"BUY","o":"LIMIT","f":"GTC","q":"2","p":"100","P":"0","F":"0","g":-1,"C":"","x":"NEW","X":"NEW","r":"NONE","i":42,"l":"0","z":"0","L":"0","n":"0","N":null,"T":1700000000100,"t":-1,"I":1,"w":true,"m":false,"M":false,"O":1700000000100,"Z":"0","Y":"0","Q":"0","W":1700000000100,"V":"NONE"}`))
+			// listenKey 失效后客户端重新创建 listenKey 并重连
+			<-expire
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"listenKeyExpired","E":1700000000200,"listenKey":"lk1"}`))
+		case "/ws/lk2":
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"executionReport","E":1700000000300,"s":"BTCUSDT","c":"cancel1","S":"BUY","o":"LIMIT","f":"GTC","q":"2","p":"100","P":"0","F":"0","g":-1,"C":"my1","x":"CANCELED","X":"CANCELED","r":"NONE","i":42,"l":"0","z":"1","L":"0","n":"0","N":null,"T":1700000000300,"t":-1,"I":2,"w":false,"m":false,"M":false,"O":1700000000100,"Z":"99","Y":"0","Q":"0","W":1700000000100,"V":"NONE","v":0}`))
+		default:
+			t.Errorf("unexpected websocket path %s", r.URL.Path)
+		}
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	e.spotUserWS = e.newUserDataWS(e.client.SpotClient, binanceSpotListenKeyPath, "ws"+strings.TrimPrefix(srv.URL, "http")+"/stream", 50*time.Millisecond)
+	defer e.spotUserWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 订单和余额共用同一用户数据流连接
+	balanceCtx, cancelBalance := context.WithCancel(ctx)
+	balances, err := ex.Spot().WatchBalance(balanceCtx)
+	if err != nil {
+		t.Fatalf("WatchBalance: %v", err)
+	}
+	orders, err := ex.Spot().WatchOrders(ctx)
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+
+	select {
+	case bals := <-balances:
+		if len(bals) != 2 || bals[0].Currency != "USDT" || bals[0].Total.String() != "1000" || bals[0].Locked.String() != "200" {
+			t.Errorf("unexpected balances: %+v", bals)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for balance")
+	}
+	cancelBalance()
+
+	receive := func() *model.SpotOrder {
+		t.Helper()
+		select {
+		case order := <-orders:
+			return order
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for order")
+		}
+		return nil
+	}
+
+	order := receive()
+	if order.ID != "42" || order.Symbol != "BTC/USDT" || order.Status != model.OrderStatusNew || order.ClientOrderID != "my1" {
+		t.Errorf("unexpected new order: %+v", order)
+	}
+
+	// 等待 listenKey 延期后再让其失效
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		n := len(refreshed)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("listenKey was never refreshed")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	close(expire)
+
+	// 撤单推送中 c 为撤单请求ID，原客户端订单ID取 C
+	order = receive()
+	if order.Status != model.OrderStatusCanceled || order.ClientOrderID != "my1" || !order.Average.Equal(decimal.RequireFromString("99")) {
+		t.Errorf("unexpected canceled order: %+v", order)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(created) != 2 || refreshed[0] != "lk1" {
+		t.Errorf("created=%v refreshed=%v, want listenKey re-created after expiry", created, refreshed)
+	}
+}
+
+func TestBinanceSpot_TrackOrder_UserDataStream(t *testing.T) {
+	var mu sync.Mutex
+	var listenKeys, orderFetches int
+	initialFetched := make(chan struct{})
+	// 第一次查询为订阅后的初始快照，第二次为断线重连后的补齐查询（断线期间成交到 4）
+	fetched := []string{
+		`{"symbol":"BTCUSDT","orderId":42,"orderListId":-1,"clientOrderId":"my1","price":"110","origQty":"5","executedQty":"0","cummulativeQuoteQty":"0","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","time":1700000000000,"updateTime":1700000000000,"isWorking":true}`,
+		`{"symbol":"BTCUSDT","orderId":42,"orderListId":-1,"clientOrderId":"my1","price":"110","origQty":"5","executedQty":"4","cummulativeQuoteQty":"412","status":"PARTIALLY_FILLED","timeInForce":"GTC","type":"LIMIT","side":"BUY","time":1700000000000,"updateTime":1700000000250,"isWorking":true}`,
+	}
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/userDataStream":
+			mu.Lock()
+			defer mu.Unlock()
+			if r.Method == http.MethodPost {
+				listenKeys++
+				w.Write([]byte(fmt.Sprintf(`{"listenKey":"lk%d"}`, listenKeys)))
+				return
+			}
+			w.Write([]byte(`{}`))
+			return
+		case "/api/v3/order":
+			if r.URL.Query().Get("orderId") != "42" {
+				t.Errorf("unexpected order query: %s", r.URL.RawQuery)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if orderFetches >= len(fetched) {
+				t.Errorf("unexpected REST order fetch #%d", orderFetches+1)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(fetched[orderFetches]))
+			if orderFetches++; orderFetches == 1 {
+				close(initialFetched)
+			}
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		report := func(orderID, status, filled, cost string) []byte {
+			return []byte(`{"e":"executionReport","E":1700000000100,"s":"BTCUSDT","c":"my1","S":"BUY","o":"LIMIT","f":"GTC","q":"5","p":"110","P":"0","F":"0","g":-1,"C":"","x":"TRADE","X":"` + status + `","r":"NONE","i":` + orderID + `,"l":"0","z":"` + filled + `","L":"0","n":"0","N":null,"T":1700000000100,"t":1,"I":1,"w":false,"m":false,"M":true,"O":1700000000000,"Z":"` + cost + `","Y":"0","Q":"0","W":1700000000000,"V":"NONE"}`)
+		}
+		switch r.URL.Path {
+		case "/ws/lk1":
+			<-initialFetched
+			conn.WriteMessage(websocket.TextMessage, report("7", "PARTIALLY_FILLED", "9", "900")) // 其他订单，忽略
+			conn.WriteMessage(websocket.TextMessage, report("42", "PARTIALLY_FILLED", "1", "100"))
+			conn.WriteMessage(websocket.TextMessage, report("42", "PARTIALLY_FILLED", "3", "306"))
+			// listenKey 失效后断线重连
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"e":"listenKeyExpired","E":1700000000200,"listenKey":"lk1"}`))
+		case "/ws/lk2":
+			conn.WriteMessage(websocket.TextMessage, report("42", "FILLED", "5", "520"))
+		default:
+			t.Errorf("unexpected websocket path %s", r.URL.Path)
+		}
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	e.spotUserWS = e.newUserDataWS(e.client.SpotClient, binanceSpotListenKeyPath, "ws"+strings.TrimPrefix(srv.URL, "http")+"/stream", time.Minute)
+	defer e.spotUserWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := ex.Spot().TrackOrder(ctx, "BTC/USDT", "42")
+	if err != nil {
+		t.Fatalf("TrackOrder: %v", err)
+	}
+
+	expected := []struct {
+		fillAmount, fillPrice, filled, vwap string
+		final                               bool
+	}{
+		{"1", "100", "1", "100", false},
+		{"2", "103", "3", "102", false},
+		{"1", "106", "4", "103", false}, // 重连后由 REST 查询补齐
+		{"1", "108", "5", "104", true},
+	}
+	for i, want := range expected {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("channel closed after %d events", i)
+			}
+			if event.OrderID != "42" || event.Symbol != "BTC/USDT" || event.FillAmount.String() != want.fillAmount || event.FillPrice.String() != want.fillPrice ||
+				event.Filled.String() != want.filled || event.VWAP.String() != want.vwap || event.Final != want.final {
+				t.Errorf("event %d = %+v, want fill %s@%s filled %s vwap %s final %v", i, event, want.fillAmount, want.fillPrice, want.filled, want.vwap, want.final)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+	}
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Fatalf("unexpected extra event: %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("channel not closed after terminal status")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if orderFetches != 2 {
+		t.Errorf("expected 2 REST order fetches (initial and after reconnect), got %d", orderFetches)
+	}
+}
+
+func TestBinance_Drain_ClosesStreams(t *testing.T) {
+	disconnected := make(chan string, 2)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/userDataStream":
+			w.Write([]byte(`{"listenKey":"lk1"}`))
+			return
+		case "/api/v3/klines":
+			w.Write([]byte(`[[1700000040000,"100","102.5","99","101","12.5",1700000099999,"1262.5",30,"6","606",""]]`))
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		// 读取到错误即客户端断开
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				disconnected <- r.URL.Path
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+	e.spotWS = common.NewWSManager(common.NewWSDialer(wsURL+"/stream", ""), &binanceWSProtocol{})
+	e.spotUserWS = e.newUserDataWS(e.client.SpotClient, binanceSpotListenKeyPath, wsURL+"/stream", time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	candles, err := ex.Spot().WatchOHLCV(ctx, "BTC/USDT", "1m")
+	if err != nil {
+		t.Fatalf("WatchOHLCV: %v", err)
+	}
+	orders, err := ex.Spot().WatchOrders(ctx)
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+	polled, err := ex.Spot().PollOHLCV(ctx, "BTC/USDT", "1m")
+	if err != nil {
+		t.Fatalf("PollOHLCV: %v", err)
+	}
+
+	if err := ex.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	// Drain 返回后推送和轮询订阅的通道都关闭，WebSocket 连接断开且不再重连
+	for name, closed := range map[string]func() bool{
+		"WatchOHLCV":  func() bool { _, ok := <-candles; return !ok },
+		"WatchOrders": func() bool { _, ok := <-orders; return !ok },
+		"PollOHLCV":   func() bool { _, ok := <-polled; return !ok },
+	} {
+		done := make(chan struct{})
+		go func() {
+			for !closed() {
+			}
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			t.Fatalf("%s channel not closed after Drain", name)
+		}
+	}
+	paths := map[string]bool{}
+	for len(paths) < 2 {
+		select {
+		case path := <-disconnected:
+			paths[path] = true
+		case <-ctx.Done():
+			t.Fatalf("websocket connections not closed after Drain, closed %v", paths)
+		}
+	}
+	if !paths["/stream"] || !paths["/ws/lk1"] {
+		t.Errorf("closed connections = %v, want public stream and user data stream", paths)
+	}
+	if _, err := e.spotUserWS.Subscribe(ctx, binanceExecutionReportEvent); !errors.Is(err, common.ErrWSClosed) {
+		t.Errorf("Subscribe after Drain = %v, want ErrWSClosed", err)
+	}
+}
+
+func TestBinanceSpot_LoadMarkets_CacheTTL(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/exchangeInfo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		calls.Add(1)
+		w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","quoteAsset":"USDT","status":"TRADING","baseAssetPrecision":8,"quotePrecision":8,"filters":[{"filterType":"PRICE_FILTER","minPrice":"0.01","maxPrice":"1000000.00","tickSize":"0.01"},{"filterType":"LOT_SIZE","minQty":"0.00001","maxQty":"9000.00","stepSize":"0.00001"}]}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL, "marketCacheTTL": time.Hour})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	// 有效期内重复加载不发送请求
+	for i := 0; i < 3; i++ {
+		if err := ex.Spot().LoadMarkets(ctx, false); err != nil {
+			t.Fatalf("LoadMarkets: %v", err)
+		}
+	}
+	if _, err := ex.Spot().FetchMarkets(ctx); err != nil {
+		t.Fatalf("FetchMarkets: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("exchangeInfo calls = %d, want 1", n)
+	}
+	if err := ex.Spot().LoadMarkets(ctx, true); err != nil {
+		t.Fatalf("LoadMarkets reload: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("exchangeInfo calls after reload = %d, want 2", n)
+	}
+
+	// 导出后在新实例中导入，无需请求交易所
+	data, err := ex.ExportMarkets()
+	if err != nil {
+		t.Fatalf("ExportMarkets: %v", err)
+	}
+	restored, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if err := restored.ImportMarkets(data); err != nil {
+		t.Fatalf("ImportMarkets: %v", err)
+	}
+	if err := restored.Spot().LoadMarkets(ctx, false); err != nil {
+		t.Fatalf("LoadMarkets after import: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("exchangeInfo calls after import = %d, want 2", n)
+	}
+	market, err := restored.Spot().GetMarket("BTCUSDT")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if market.Symbol != "BTC/USDT" || market.Precision.TickSize.String() != "0.01" || market.Limits.Amount.Min.String() != "0.00001" {
+		t.Errorf("unexpected imported market: %+v", market)
+	}
+}
+
+func TestBinanceSpot_FetchCurrencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sapi/v1/capital/config/getall" || r.Header.Get("X-MBX-APIKEY") != "key" || r.URL.Query().Get("signature") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"coin":"USDT","depositAllEnable":true,"withdrawAllEnable":true,"name":"TetherUS","free":"0","locked":"0","trading":true,"networkList":[
+				{"network":"BSC","coin":"USDT","name":"BNB Smart Chain (BEP20)","isDefault":false,"depositEnable":true,"withdrawEnable":true,"withdrawFee":"0.29","withdrawMin":"10","withdrawMax":"9999999"},
+				{"network":"ETH","coin":"USDT","name":"Ethereum (ERC20)","isDefault":true,"depositEnable":true,"withdrawEnable":false,"withdrawFee":"4.5","withdrawMin":"10","withdrawMax":"9999999"},
+				{"network":"TRX","coin":"USDT","name":"Tron (TRC20)","isDefault":false,"depositEnable":true,"withdrawEnable":true,"withdrawFee":"1","withdrawMin":"10","withdrawMax":"9999999"}]},
+			{"coin":"LUNC","depositAllEnable":false,"withdrawAllEnable":false,"name":"Terra Classic","networkList":[
+				{"network":"LUNC","coin":"LUNC","name":"Terra Classic","isDefault":true,"depositEnable":false,"withdrawEnable":false,"withdrawFee":"10000","withdrawMin":"20000"}]}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	currencies, err := ex.Spot().FetchCurrencies(ctx)
+	if err != nil {
+		t.Fatalf("FetchCurrencies: %v", err)
+	}
+	usdt := currencies["USDT"]
+	if usdt == nil || usdt.Name != "TetherUS" || len(usdt.Networks) != 3 {
+		t.Fatalf("unexpected USDT: %+v", usdt)
+	}
+	// 手续费取默认网络（ERC20）
+	if !usdt.DepositEnabled || !usdt.WithdrawEnabled || usdt.WithdrawFee.String() != "4.5" {
+		t.Errorf("USDT deposit=%v withdraw=%v fee=%s", usdt.DepositEnabled, usdt.WithdrawEnabled, usdt.WithdrawFee)
+	}
+	for i, want := range []struct {
+		network, fee string
+		withdraw     bool
+	}{{"BSC", "0.29", true}, {"ETH", "4.5", false}, {"TRX", "1", true}} {
+		n := usdt.Networks[i]
+		if n.Network != want.network || n.WithdrawFee.String() != want.fee || n.WithdrawEnabled != want.withdraw || n.WithdrawMin.String() != "10" {
+			t.Errorf("Networks[%d] = %+v", i, n)
+		}
+	}
+	if lunc := currencies["LUNC"]; lunc == nil || lunc.DepositEnabled || lunc.WithdrawEnabled {
+		t.Errorf("unexpected LUNC: %+v", lunc)
+	}
+
+	// 提币手续费按网络区分，代币标准匹配 Binance 的链标识
+	for _, tt := range []struct{ network, want string }{{"TRC20", "1"}, {"ERC20", "4.5"}, {"bsc", "0.29"}, {"", "4.5"}} {
+		fee, err := ex.Spot().FetchWithdrawalFee(ctx, "usdt", tt.network)
+		if err != nil || fee.String() != tt.want {
+			t.Errorf("FetchWithdrawalFee(USDT, %q) = %s, %v, want %s", tt.network, fee, err, tt.want)
+		}
+	}
+	if _, err := ex.Spot().FetchWithdrawalFee(ctx, "USDT", "SOL"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("unsupported network: err = %v, want ErrNotSupported", err)
+	}
+
+	noAuth, _ := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if _, err := noAuth.Spot().FetchCurrencies(ctx); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("err = %v, want ErrAuthenticationRequired", err)
+	}
+	if _, err := noAuth.Spot().FetchWithdrawalFee(ctx, "USDT", "TRC20"); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("err = %v, want ErrAuthenticationRequired", err)
+	}
+}
+
+func TestBinance_GetMarketByID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/exchangeInfo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","quoteAsset":"USDT","status":"TRADING","baseAssetPrecision":8,"quotePrecision":8,"filters":[]},{"symbol":"ETHBTC","baseAsset":"ETH","quoteAsset":"BTC","status":"TRADING","baseAssetPrecision":8,"quotePrecision":8,"filters":[]}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if err := ex.Spot().LoadMarkets(context.Background(), false); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+
+	market, err := ex.Spot().GetMarketByID("ETHBTC")
+	if err != nil {
+		t.Fatalf("GetMarketByID: %v", err)
+	}
+	if market.Symbol != "ETH/BTC" {
+		t.Errorf("Symbol = %s, want ETH/BTC", market.Symbol)
+	}
+	// 只匹配原始ID
+	if _, err := ex.Spot().GetMarketByID("ETH/BTC"); err == nil {
+		t.Error("expected error for normalized symbol")
+	}
+
+	// 现货和合约的原始ID相同，分别映射到各自的市场
+	e := ex.(*Binance)
+	perp := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[perp.Symbol] = perp
+	e.perpMarketsByID[perp.ID] = perp
+	if m, err := ex.Spot().GetMarketByID("BTCUSDT"); err != nil || m.Symbol != "BTC/USDT" {
+		t.Errorf("spot GetMarketByID = %v, %v", m, err)
+	}
+	if m, err := ex.Perp().GetMarketByID("BTCUSDT"); err != nil || m.Symbol != "BTC/USDT:USDT" {
+		t.Errorf("perp GetMarketByID = %v, %v", m, err)
+	}
+}
+
+func TestBinanceSpot_LoadMarkets_ReloadIndexes(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 第二次加载时 ETHBTC 已下架，新增 SOLUSDT
+		if calls.Add(1) == 1 {
+			w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","quoteAsset":"USDT","status":"TRADING","filters":[]},{"symbol":"ETHBTC","baseAsset":"ETH","quoteAsset":"BTC","status":"TRADING","filters":[]}]}`))
+			return
+		}
+		w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","quoteAsset":"USDT","status":"TRADING","filters":[]},{"symbol":"SOLUSDT","baseAsset":"SOL","quoteAsset":"USDT","status":"TRADING","filters":[]}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+	if err := ex.Spot().LoadMarkets(ctx, false); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+	if err := ex.Spot().LoadMarkets(ctx, true); err != nil {
+		t.Fatalf("LoadMarkets reload: %v", err)
+	}
+
+	e := ex.(*Binance)
+	if len(e.spotMarketsBySymbol) != 2 || len(e.spotMarketsByID) != 2 {
+		t.Fatalf("index sizes = %d/%d, want 2/2", len(e.spotMarketsBySymbol), len(e.spotMarketsByID))
+	}
+	for id, market := range e.spotMarketsByID {
+		if e.spotMarketsBySymbol[market.Symbol] != market {
+			t.Errorf("symbol index out of sync for %s", id)
+		}
+	}
+	if _, err := ex.Spot().GetMarketByID("ETHBTC"); err == nil {
+		t.Error("delisted market still found by ID")
+	}
+	if _, err := ex.Spot().GetMarket("ETH/BTC"); err == nil {
+		t.Error("delisted market still found by symbol")
+	}
+	if m, err := ex.Spot().GetMarketByID("SOLUSDT"); err != nil || m.Symbol != "SOL/USDT" {
+		t.Errorf("GetMarketByID(SOLUSDT) = %v, %v", m, err)
+	}
+}
+
+func TestBinance_FetchStatus(t *testing.T) {
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sapi/v1/system/status" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	ctx := context.Background()
+
+	body.Store(`{"status":0,"msg":"normal"}`)
+	status, err := ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusOK || status.Updated.IsZero() || status.Err != nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	body.Store(`{"status":1,"msg":"system maintenance"}`)
+	status, err = ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusMaintenance || status.Err != nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// 接口无法访问时视为维护中并附带错误
+	srv.Close()
+	status, err = ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusMaintenance || status.Err == nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestBinanceSpot_FetchOpenOrders(t *testing.T) {
+	var gotSymbols []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/openOrders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotSymbols = append(gotSymbols, r.URL.Query().Get("symbol"))
+		w.Write([]byte(`[
+			{"symbol":"BTCUSDT","orderId":28,"orderListId":-1,"clientOrderId":"a","price":"50000.00",
+			 "origQty":"0.10000000","executedQty":"0.00000000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY",
+			 "time":1700000000000,"updateTime":1700000000000,"isWorking":true},
+			{"symbol":"XYZUSDT","orderId":30,"orderListId":-1,"clientOrderId":"b","price":"1.00",
+			 "origQty":"5.00000000","executedQty":"0.00000000","status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"SELL",
+			 "time":1700000000000,"updateTime":1700000000000,"isWorking":true}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	// 未指定交易对时按返回的 ID 还原符号，未加载的交易对保留原始 ID
+	orders, err := e.spot.FetchOpenOrders(context.Background(), "")
+	if err != nil {
+		t.Fatalf("FetchOpenOrders: %v", err)
+	}
+	if len(orders) != 2 || orders[0].Symbol != "BTC/USDT" || orders[1].Symbol != "XYZUSDT" {
+		t.Fatalf("orders = %+v, want BTC/USDT and XYZUSDT", orders)
+	}
+
+	if _, err := e.spot.FetchOpenOrders(context.Background(), "BTC/USDT"); err != nil {
+		t.Fatalf("FetchOpenOrders symbol: %v", err)
+	}
+	if len(gotSymbols) != 2 || gotSymbols[0] != "" || gotSymbols[1] != "BTCUSDT" {
+		t.Errorf("symbol queries = %q, want [\"\" \"BTCUSDT\"]", gotSymbols)
+	}
+}
+
+func TestBinance_GetMarketSuggestion(t *testing.T) {
+	ex, err := NewBinance("", "", nil)
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	spot := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[spot.Symbol] = spot
+	e.spotMarketsByID[spot.ID] = spot
+	perp := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[perp.Symbol] = perp
+	e.perpMarketsByID[perp.ID] = perp
+
+	if symbols := ex.Symbols(); len(symbols) != 2 || symbols[0] != "BTC/USDT" || symbols[1] != "BTC/USDT:USDT" {
+		t.Errorf("Symbols = %v, want [BTC/USDT BTC/USDT:USDT]", symbols)
+	}
+
+	// 分隔符写错时提示标准化 symbol
+	_, err = ex.Spot().GetMarket("BTC-USDT")
+	if !errors.Is(err, common.ErrMarketNotFound) || !strings.Contains(err.Error(), "did you mean BTC/USDT?") {
+		t.Errorf("GetMarket(BTC-USDT) err = %v, want ErrMarketNotFound suggesting BTC/USDT", err)
+	}
+	if _, err := ex.Perp().GetMarket("BTC/USDT:USD"); !errors.Is(err, common.ErrMarketNotFound) || !strings.Contains(err.Error(), "did you mean BTC/USDT:USDT?") {
+		t.Errorf("perp GetMarket err = %v, want suggestion BTC/USDT:USDT", err)
+	}
+}
+
+func TestBinanceSpot_CreateOCOOrder(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v3/orderList/oco" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.Query()
+		// 录制的 orderList/oco 响应（newOrderRespType=FULL）
+		w.Write([]byte(`{"orderListId":1,"contingencyType":"OCO","listStatusType":"EXEC_STARTED","listOrderStatus":"EXECUTING",
+			"listClientOrderId":"oco-1","transactionTime":1710485608839,"symbol":"BTCUSDT",
+			"orders":[{"symbol":"BTCUSDT","orderId":10,"clientOrderId":"leg-stop"},{"symbol":"BTCUSDT","orderId":11,"clientOrderId":"leg-limit"}],
+			"orderReports":[
+				{"symbol":"BTCUSDT","orderId":10,"orderListId":1,"clientOrderId":"leg-stop","transactTime":1710485608839,"price":"47900.00",
+				 "origQty":"0.10000","executedQty":"0.00000","cummulativeQuoteQty":"0.00","status":"NEW","timeInForce":"GTC",
+				 "type":"STOP_LOSS_LIMIT","side":"SELL","stopPrice":"48000.00"},
+				{"symbol":"BTCUSDT","orderId":11,"orderListId":1,"clientOrderId":"leg-limit","transactTime":1710485608839,"price":"55000.00",
+				 "origQty":"0.10000","executedQty":"0.00000","cummulativeQuoteQty":"0.00","status":"NEW","timeInForce":"GTC",
+				 "type":"LIMIT_MAKER","side":"SELL"}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	list, err := ex.Spot().CreateOCOOrder(ctx, "BTC/USDT", option.Sell, "0.1", "55000", "48000", "47900", option.WithClientOrderID("oco-1"))
+	if err != nil {
+		t.Fatalf("CreateOCOOrder: %v", err)
+	}
+	want := map[string]string{
+		"symbol": "BTCUSDT", "side": "SELL", "quantity": "0.1", "listClientOrderId": "oco-1",
+		"aboveType": "LIMIT_MAKER", "abovePrice": "55000",
+		"belowType": "STOP_LOSS_LIMIT", "belowStopPrice": "48000", "belowPrice": "47900", "belowTimeInForce": "GTC",
+	}
+	for k, v := range want {
+		if query.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, query.Get(k), v)
+		}
+	}
+	if query.Get("signature") == "" {
+		t.Error("request not signed")
+	}
+
+	if list.ID != "1" || list.ClientID != "oco-1" || list.Type != "OCO" || list.Status != "EXECUTING" || list.Symbol != "BTC/USDT" {
+		t.Errorf("list = %+v", list)
+	}
+	if list.Timestamp.UnixMilli() != 1710485608839 || len(list.Orders) != 2 {
+		t.Fatalf("list timestamp/orders = %d/%d", list.Timestamp.UnixMilli(), len(list.Orders))
+	}
+	stop, limit := list.Orders[0], list.Orders[1]
+	if stop.ID != "10" || stop.Type != "STOP_LOSS_LIMIT" || stop.StopPrice.String() != "48000" || stop.Price.String() != "47900" || stop.Side != model.OrderSideSell {
+		t.Errorf("stop leg = %+v", stop)
+	}
+	if limit.ID != "11" || limit.Type != "LIMIT_MAKER" || limit.Price.String() != "55000" || limit.Amount.String() != "0.1" {
+		t.Errorf("limit leg = %+v", limit)
+	}
+
+	// 买入：止损单在上方，stopLimitPrice 为空时为 STOP_LOSS
+	if _, err := ex.Spot().CreateOCOOrder(ctx, "BTC/USDT", option.Buy, "0.1", "45000", "52000", ""); err != nil {
+		t.Fatalf("CreateOCOOrder buy: %v", err)
+	}
+	if query.Get("aboveType") != "STOP_LOSS" || query.Get("aboveStopPrice") != "52000" || query.Get("abovePrice") != "" ||
+		query.Get("belowType") != "LIMIT_MAKER" || query.Get("belowPrice") != "45000" {
+		t.Errorf("buy query = %v", query)
+	}
+
+	query = nil
+	if _, err := ex.Spot().CreateOCOOrder(ctx, "BTC/USDT", option.Sell, "0.1", "45000", "48000", ""); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("sell price below stop err = %v, want ErrInvalidOrder", err)
+	}
+	if query != nil {
+		t.Error("invalid oco order sent a request")
+	}
+}
+
+func TestBinanceSpot_FetchMyTradesRange(t *testing.T) {
+	since := time.UnixMilli(1700000000000)
+	until := since.Add(30 * time.Hour)
+	trade := func(id int64) string {
+		return fmt.Sprintf(`{"symbol":"BTCUSDT","id":%d,"orderId":%d,"price":"50000","qty":"0.01","quoteQty":"500","time":%d,"isBuyer":%t}`,
+			id, id/10, since.Add(time.Duration(id)*time.Second).UnixMilli(), id%2 == 0)
+	}
+
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v3/myTrades" || q.Get("symbol") != "BTCUSDT" || q.Get("signature") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		queries = append(queries, q)
+
+		// 第一页按时间窗口返回整页，第二页按 fromId 返回与第一页重叠的一条和一条晚于 until 的成交
+		var items []string
+		if q.Get("fromId") == "" {
+			for id := int64(1); id <= 1000; id++ {
+				items = append(items, trade(id))
+			}
+		} else {
+			items = append(items, trade(1000), trade(1001), trade(1002), trade(200000))
+		}
+		w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	trades, err := ex.Spot().FetchMyTradesRange(context.Background(), "BTC/USDT", since, until)
+	if err != nil {
+		t.Fatalf("FetchMyTradesRange: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d requests, want 2", len(queries))
+	}
+	// 第一个时间窗口最长 24 小时
+	if queries[0].Get("startTime") != "1700000000000" || queries[0].Get("endTime") != "1700086399999" || queries[0].Get("limit") != "1000" {
+		t.Errorf("first query = %v", queries[0])
+	}
+	if queries[1].Get("fromId") != "1001" || queries[1].Get("startTime") != "" {
+		t.Errorf("second query = %v, want fromId 1001 without time window", queries[1])
+	}
+	if len(trades) != 1002 {
+		t.Fatalf("got %d trades, want 1002", len(trades))
+	}
+	if trades[0].ID != "1" || trades[1001].ID != "1002" {
+		t.Errorf("trades range %s..%s, want 1..1002", trades[0].ID, trades[1001].ID)
+	}
+	if last := trades[1001]; last.Side != "buy" || last.OrderID != "100" || last.Cost.String() != "500" {
+		t.Errorf("trade = %+v", last)
+	}
+}
+
+func TestBinance_VerifyCredentials(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/api/v3/time":
+			w.Write([]byte(`{"serverTime":1700000000000}`))
+		case "/api/v3/account":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	ex, err := NewBinance("key", "bad-secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	if err := ex.Ping(ctx); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+
+	err = ex.VerifyCredentials(ctx)
+	var exErr *common.ExchangeError
+	if !errors.Is(err, common.ErrAuthenticationFailed) || !errors.As(err, &exErr) || exErr.Code != "-2015" {
+		t.Errorf("VerifyCredentials err = %v, want ErrAuthenticationFailed with code -2015", err)
+	}
+
+	// 未配置凭证时不发送请求
+	public, err := NewBinance("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	requests.Store(0)
+	if err := public.VerifyCredentials(ctx); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("without credentials err = %v, want ErrAuthenticationRequired", err)
+	}
+	if requests.Load() != 0 {
+		t.Errorf("sent %d requests without credentials, want 0", requests.Load())
+	}
+
+	// 服务器不可达时 Ping 返回错误
+	srv.Close()
+	if err := ex.Ping(ctx); err == nil {
+		t.Errorf("Ping after close: want error")
+	}
+}
+
+func TestBinance_NormalizeSymbol(t *testing.T) {
+	ex, err := NewBinance("", "", nil)
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	b := ex.(*Binance)
+
+	// 拼接格式的ID无法拆分，需要已加载的市场
+	if _, err := b.NormalizeSymbol("BTCUSDT"); !errors.Is(err, common.ErrMarketNotFound) {
+		t.Errorf("expected ErrMarketNotFound before loading markets, got %v", err)
+	}
+	if got, err := b.DenormalizeSymbol("BTC/USDT"); err != nil || got != "BTCUSDT" {
+		t.Errorf("DenormalizeSymbol fallback = %q, %v", got, err)
+	}
+
+	b.spotMarketsBySymbol, b.spotMarketsByID = common.IndexMarkets(model.Markets{{ID: "BTCUSDT", Symbol: "BTC/USDT"}})
+	b.perpMarketsBySymbol, b.perpMarketsByID = common.IndexMarkets(model.Markets{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT"},
+		{ID: "BTCUSD_PERP", Symbol: "BTC/USD:BTC"},
+	})
+	tests := []struct {
+		id, symbol string
+	}{
+		{"BTCUSDT", "BTC/USDT"}, // 现货和合约ID相同时返回现货 symbol
+		{"BTCUSD_PERP", "BTC/USD:BTC"},
+	}
+	for _, tt := range tests {
+		if got, err := b.NormalizeSymbol(tt.id); err != nil || got != tt.symbol {
+			t.Errorf("NormalizeSymbol(%q) = %q, %v, want %q", tt.id, got, err, tt.symbol)
+		}
+		if got, err := b.DenormalizeSymbol(tt.symbol); err != nil || got != tt.id {
+			t.Errorf("DenormalizeSymbol(%q) = %q, %v, want %q", tt.symbol, got, err, tt.id)
+		}
+	}
+	if got, err := b.DenormalizeSymbol("BTC/USDT:USDT"); err != nil || got != "BTCUSDT" {
+		t.Errorf("DenormalizeSymbol(contract) = %q, %v", got, err)
+	}
+}
@@ -1,6 +1,9 @@
 package binance
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/lemconn/exlink/common"
 )
 
@@ -10,8 +13,39 @@ const (
 	binanceSandboxURL     = "https://demo-api.binance.com"
 	binanceFapiBaseURL    = "https://fapi.binance.com"
 	binanceFapiSandboxURL = "https://demo-fapi.binance.com"
+	binanceDapiBaseURL    = "https://dapi.binance.com"
+	binanceDapiSandboxURL = "https://testnet.binancefuture.com"
+
+	// WebSocket 组合流地址
+	binanceSpotWSURL        = "wss://stream.binance.com:9443/stream"
+	binanceSpotWSSandboxURL = "wss://demo-stream.binance.com/stream"
+	binancePerpWSURL        = "wss://fstream.binance.com/stream"
+	binancePerpWSSandboxURL = "wss://fstream.binancefuture.com/stream"
+	binanceDapiWSURL        = "wss://dstream.binance.com/stream"
+	binanceDapiWSSandboxURL = "wss://dstream.binancefuture.com/stream"
+
+	// binanceSpotMaxDepthLimit 现货深度单次最大档位数
+	binanceSpotMaxDepthLimit = 5000
+
+	// binanceWSSnapshotLimit WatchOrderBook 初始化本地订单簿的快照档位数
+	binanceWSSnapshotLimit = 1000
+
+	// binanceOHLCVPageLimit FetchOHLCVRange 每页K线数
+	binanceOHLCVPageLimit = 1000
+
+	// binanceMaxRecvWindow 签名请求接收窗口上限（毫秒）
+	binanceMaxRecvWindow = 60000
 )
 
+// binancePerpDepthLimits 合约深度支持的档位数
+var binancePerpDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000}
+
+// binanceSpotTimeframes 现货K线支持的周期
+var binanceSpotTimeframes = []string{"1s", "1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "3d", "1w", "1M"}
+
+// binancePerpTimeframes 合约K线支持的周期（不支持 1s）
+var binancePerpTimeframes = binanceSpotTimeframes[1:]
+
 // Client Binance 客户端，包含现货和合约的 HTTP 客户端
 type Client struct {
 	// SpotClient 现货 API 客户端
@@ -20,10 +54,22 @@ type Client struct {
 	// PerpClient 永续合约 API 客户端
 	PerpClient *common.HTTPClient
 
-	// APIKey API 密钥
+	// DeliveryClient 币本位合约 API 客户端（dapi）
+	DeliveryClient *common.HTTPClient
+
+	// SpotWSURL 现货 WebSocket 地址
+	SpotWSURL string
+
+	// PerpWSURL 永续合约 WebSocket 地址
+	PerpWSURL string
+
+	// DeliveryWSURL 币本位合约 WebSocket 地址
+	DeliveryWSURL string
+
+	// APIKey API 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	APIKey string
 
-	// SecretKey 密钥
+	// SecretKey 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	SecretKey string
 
 	// Sandbox 是否为模拟盘
@@ -37,6 +83,7 @@ type Client struct {
 }
 
 // NewClient 创建 Binance 客户端
+// 请求签名使用 Binance 持有的当前凭证（见 credentials），以支持运行时轮换
 func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Client, error) {
 	baseURL := binanceBaseURL
 	sandbox := false
@@ -60,19 +107,84 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		baseURL = binanceSandboxURL
 	}
 
+	// perpBaseURL 来自 option.WithMarketBaseURL，fapiBaseURL 为自定义选项（优先）
 	fapiBaseURL := binanceFapiBaseURL
+	if v, ok := options["perpBaseURL"].(string); ok {
+		fapiBaseURL = v
+	}
+	if v, ok := options["fapiBaseURL"].(string); ok {
+		fapiBaseURL = v
+	}
 	if sandbox {
 		fapiBaseURL = binanceFapiSandboxURL
 	}
 
+	// 未单独设置 dapiBaseURL 时，自定义的 fapiBaseURL 同时用于币本位合约（测试网两者同域名）
+	dapiBaseURL := binanceDapiBaseURL
+	if v, ok := options["perpBaseURL"].(string); ok {
+		dapiBaseURL = v
+	}
+	if v, ok := options["fapiBaseURL"].(string); ok {
+		dapiBaseURL = v
+	}
+	if v, ok := options["dapiBaseURL"].(string); ok {
+		dapiBaseURL = v
+	}
+	if sandbox {
+		dapiBaseURL = binanceDapiSandboxURL
+	}
+
+	spotWSURL := binanceSpotWSURL
+	perpWSURL := binancePerpWSURL
+	dapiWSURL := binanceDapiWSURL
+	if sandbox {
+		spotWSURL = binanceSpotWSSandboxURL
+		perpWSURL = binancePerpWSSandboxURL
+		dapiWSURL = binanceDapiWSSandboxURL
+	}
+
+	// 现货和合约共享 IP 限额，共用同一个限流器
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
 	client := &Client{
-		SpotClient: common.NewHTTPClient(baseURL),
-		PerpClient: common.NewHTTPClient(fapiBaseURL),
-		APIKey:     apiKey,
-		SecretKey:  secretKey,
-		Sandbox:    sandbox,
-		ProxyURL:   proxyURL,
-		Debug:      debug,
+		SpotClient:     common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
+		PerpClient:     common.NewHTTPClient(fapiBaseURL, common.WithRateLimiter(limiter)),
+		DeliveryClient: common.NewHTTPClient(dapiBaseURL, common.WithRateLimiter(limiter)),
+		SpotWSURL:      spotWSURL,
+		PerpWSURL:      perpWSURL,
+		DeliveryWSURL:  dapiWSURL,
+		APIKey:         apiKey,
+		SecretKey:      secretKey,
+		Sandbox:        sandbox,
+		ProxyURL:       proxyURL,
+		Debug:          debug,
+	}
+
+	// 解析交易所错误码
+	client.SpotClient.SetErrorParser(parseBinanceError)
+	client.PerpClient.SetErrorParser(parseBinanceError)
+	client.DeliveryClient.SetErrorParser(parseBinanceError)
+	client.SpotClient.SetBodyChecker(checkBinanceError)
+	client.PerpClient.SetBodyChecker(checkBinanceError)
+	client.DeliveryClient.SetBodyChecker(checkBinanceError)
+
+	// 按接口权重限流
+	client.SpotClient.SetRequestWeigher(binanceRequestWeight)
+	client.PerpClient.SetRequestWeigher(binanceRequestWeight)
+	client.DeliveryClient.SetRequestWeigher(binanceRequestWeight)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.SpotClient.SetRetryPolicy(v)
+		client.PerpClient.SetRetryPolicy(v)
+		client.DeliveryClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.SpotClient.SetHTTPClient(v)
+		client.PerpClient.SetHTTPClient(v)
+		client.DeliveryClient.SetHTTPClient(v)
 	}
 
 	// 设置代理
@@ -83,18 +195,45 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		if err := client.PerpClient.SetProxy(proxyURL); err != nil {
 			return nil, err
 		}
+		if err := client.DeliveryClient.SetProxy(proxyURL); err != nil {
+			return nil, err
+		}
 	}
 
 	// 设置调试模式
 	if debug {
 		client.SpotClient.SetDebug(true)
 		client.PerpClient.SetDebug(true)
+		client.DeliveryClient.SetDebug(true)
 	}
 
-	// 设置请求头
-	if apiKey != "" {
-		client.SpotClient.SetHeader("X-MBX-APIKEY", apiKey)
-		client.PerpClient.SetHeader("X-MBX-APIKEY", apiKey)
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.SpotClient.SetTimeout(v)
+		client.PerpClient.SetTimeout(v)
+		client.DeliveryClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.SpotClient.SetCorrelationHeader(v)
+		client.PerpClient.SetCorrelationHeader(v)
+		client.DeliveryClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.SpotClient.SetLogger(v)
+		client.PerpClient.SetLogger(v)
+		client.DeliveryClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.SpotClient.OnRequest(v)
+		client.PerpClient.OnRequest(v)
+		client.DeliveryClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.SpotClient.OnResponse(v)
+		client.PerpClient.OnResponse(v)
+		client.DeliveryClient.OnResponse(v)
 	}
 
 	return client, nil
@@ -0,0 +1,69 @@
+package binance
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// binanceErrorCodes Binance 错误码到统一错误的映射（现货和合约共用）
+var binanceErrorCodes = map[string]error{
+	"-1003": common.ErrRateLimitExceeded,    // 请求权重超限
+	"-1015": common.ErrRateLimitExceeded,    // 下单频率超限
+	"-1013": common.ErrInvalidOrder,         // 不满足交易规则（数量、价格过滤器）
+	"-1111": common.ErrInvalidOrder,         // 精度超出限制
+	"-1116": common.ErrInvalidOrder,         // 订单类型不合法
+	"-2010": common.ErrInsufficientFunds,    // 下单被拒绝（余额不足）
+	"-2018": common.ErrInsufficientFunds,    // 余额不足
+	"-2019": common.ErrInsufficientFunds,    // 保证金不足
+	"-2011": common.ErrOrderNotFound,        // 撤单被拒绝（订单不存在）
+	"-2013": common.ErrOrderNotFound,        // 订单不存在
+	"-2021": common.ErrInvalidOrder,         // 条件单会立即触发
+	"-2022": common.ErrInvalidOrder,         // 只减仓订单被拒绝
+	"-4003": common.ErrInvalidOrder,         // 数量小于等于 0
+	"-4005": common.ErrInvalidOrder,         // 数量超过上限
+	"-4164": common.ErrInvalidOrder,         // 订单名义价值低于下限
+	"-1022": common.ErrAuthenticationFailed, // 签名无效
+	"-2014": common.ErrAuthenticationFailed, // API Key 格式错误
+	"-2015": common.ErrAuthenticationFailed, // API Key 无效、IP 不在白名单或权限不足
+}
+
+// binanceErrorBody Binance 错误响应体 {"code":-2010,"msg":"..."}（现货和合约格式相同）
+type binanceErrorBody struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// decodeBinanceError 识别顶层为错误对象的响应体，数组或其他结构返回 nil
+// 错误码为负数，部分合约接口成功时返回 {"code":200,"msg":"success"}，不视为错误
+func decodeBinanceError(body []byte) *common.ExchangeError {
+	var e binanceErrorBody
+	if err := json.Unmarshal(body, &e); err != nil || e.Code >= 0 {
+		return nil
+	}
+	return common.NewExchangeError("binance", strconv.Itoa(e.Code), e.Msg, binanceErrorCodes)
+}
+
+// parseBinanceError 解析 Binance 非 2xx 响应体 {"code":-2010,"msg":"..."}
+func parseBinanceError(httpErr *common.HTTPError) error {
+	e := decodeBinanceError([]byte(httpErr.Body))
+	if e == nil {
+		return nil
+	}
+	e.Cause = httpErr
+	return e
+}
+
+// checkBinanceError 检查 2xx 响应体是否为错误对象，避免错误被解析为空订单（orderId 为 0）
+func checkBinanceError(body []byte) error {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil
+	}
+	if e := decodeBinanceError(trimmed); e != nil {
+		return e
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+func TestParseBinanceError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"insufficient balance", 400, `{"code":-2010,"msg":"Account has insufficient balance for requested action."}`, common.ErrInsufficientFunds},
+		{"margin insufficient", 400, `{"code":-2019,"msg":"Margin is insufficient."}`, common.ErrInsufficientFunds},
+		{"rate limit", 429, `{"code":-1003,"msg":"Too many requests."}`, common.ErrRateLimitExceeded},
+		{"unknown order", 400, `{"code":-2011,"msg":"Unknown order sent."}`, common.ErrOrderNotFound},
+		{"lot size", 400, `{"code":-1013,"msg":"Filter failure: LOT_SIZE"}`, common.ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpErr := &common.HTTPError{StatusCode: tt.status, Body: tt.body}
+			err := parseBinanceError(httpErr)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want errors.Is %v", err, tt.want)
+			}
+			var exErr *common.ExchangeError
+			if !errors.As(err, &exErr) || exErr.Exchange != "binance" || !strings.HasPrefix(exErr.Code, "-") {
+				t.Errorf("err = %#v, want *ExchangeError with raw code", err)
+			}
+			var gotHTTP *common.HTTPError
+			if !errors.As(err, &gotHTTP) || gotHTTP.StatusCode != tt.status {
+				t.Errorf("err = %v, want wrapped *HTTPError %d", err, tt.status)
+			}
+		})
+	}
+
+	if err := parseBinanceError(&common.HTTPError{StatusCode: 502, Body: "Bad Gateway"}); err != nil {
+		t.Errorf("non-JSON body err = %v, want nil", err)
+	}
+}
+
+func TestBinancePerp_ExchangeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-2013,"msg":"Order does not exist."}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"fapiBaseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	e.perpMarketsBySymbol[market.Symbol] = market
+	e.perpMarketsByID[market.ID] = market
+
+	_, err = e.perp.FetchOrders(context.Background(), "BTC/USDT:USDT", time.Time{}, 10)
+	if !errors.Is(err, common.ErrOrderNotFound) {
+		t.Fatalf("err = %v, want ErrOrderNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "Order does not exist.") {
+		t.Errorf("err = %q, want original message", err.Error())
+	}
+}
+
+func TestCheckBinanceError(t *testing.T) {
+	if err := checkBinanceError([]byte(`{"code":-2019,"msg":"Margin is insufficient."}`)); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("error object err = %v, want ErrInsufficientFunds", err)
+	}
+	for _, body := range []string{
+		`{"code":200,"msg":"success"}`,
+		`{"symbol":"BTCUSDT","orderId":28}`,
+		`[{"code":-2019,"msg":"Margin is insufficient."}]`,
+		`[]`,
+		``,
+	} {
+		if err := checkBinanceError([]byte(body)); err != nil {
+			t.Errorf("checkBinanceError(%s) = %v, want nil", body, err)
+		}
+	}
+}
+
+func TestBinance_CreateOrderErrorBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+		msg    string
+	}{
+		// 2xx 状态返回的错误对象
+		{"insufficient balance", http.StatusOK, `{"code":-2019,"msg":"Margin is insufficient."}`, common.ErrInsufficientFunds, "Margin is insufficient."},
+		{"invalid quantity", http.StatusOK, `{"code":-4003,"msg":"Quantity less than or equal to zero."}`, common.ErrInvalidOrder, "Quantity less than or equal to zero."},
+		{"precision", http.StatusBadRequest, `{"code":-1111,"msg":"Precision is over the maximum defined for this asset."}`, common.ErrInvalidOrder, "Precision is over"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL, "fapiBaseURL": srv.URL})
+			if err != nil {
+				t.Fatalf("NewBinance: %v", err)
+			}
+			e := ex.(*Binance)
+			spotMarket := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+			e.spotMarketsBySymbol[spotMarket.Symbol] = spotMarket
+			e.spotMarketsByID[spotMarket.ID] = spotMarket
+			perpMarket := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+			e.perpMarketsBySymbol[perpMarket.Symbol] = perpMarket
+			e.perpMarketsByID[perpMarket.ID] = perpMarket
+
+			_, err = ex.Spot().CreateOrder(context.Background(), "BTC/USDT", option.Buy, "0.001")
+			if !errors.Is(err, tt.want) || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("spot err = %v, want %v", err, tt.want)
+			}
+			_, err = ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.01", option.OpenLong, option.Market)
+			if !errors.Is(err, tt.want) || !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("perp err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinanceSpot_CreateOrderMissingOrderID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"symbol":"BTCUSDT"}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBinance("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBinance: %v", err)
+	}
+	e := ex.(*Binance)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	if _, err := ex.Spot().CreateOrder(context.Background(), "BTC/USDT", option.Buy, "0.001"); err == nil || !strings.Contains(err.Error(), "missing orderId") {
+		t.Errorf("err = %v, want missing orderId", err)
+	}
+}
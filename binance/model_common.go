@@ -1,12 +1,34 @@
 package binance
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/types"
 )
 
+// decodeObjectOrArray 解析单个对象或对象数组格式的响应
+// Binance 行情接口传 symbol 时返回对象，不传时返回数组
+func decodeObjectOrArray[T any](data []byte) ([]T, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+
+	var item T
+	if err := json.Unmarshal(trimmed, &item); err != nil {
+		return nil, err
+	}
+	return []T{item}, nil
+}
+
 // binanceFilter Binance 过滤器（现货和合约共用）
 type binanceFilter struct {
 	FilterType  string          `json:"filterType"`
@@ -153,3 +175,138 @@ func (k *binanceKline) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// binanceAggTrade Binance 归集交易（现货和合约共用）
+type binanceAggTrade struct {
+	AggTradeID   int64             `json:"a"` // Aggregate tradeId
+	Price        types.ExDecimal   `json:"p"` // Price
+	Quantity     types.ExDecimal   `json:"q"` // Quantity
+	FirstTradeID int64             `json:"f"` // First tradeId
+	LastTradeID  int64             `json:"l"` // Last tradeId
+	Timestamp    types.ExTimestamp `json:"T"` // Timestamp
+	IsBuyerMaker bool              `json:"m"` // Was the buyer the maker?
+	IsBestMatch  bool              `json:"M"` // Was the trade the best price match?（需显式声明，否则 encoding/json 大小写不敏感会覆盖 m）
+}
+
+// toAggTrades 转换为统一的归集交易结构
+func toAggTrades(symbol string, items []binanceAggTrade) model.AggTrades {
+	aggs := make(model.AggTrades, 0, len(items))
+	for _, item := range items {
+		// 买方为 maker 时主动方为卖方
+		side := "buy"
+		if item.IsBuyerMaker {
+			side = "sell"
+		}
+		aggs = append(aggs, &model.AggTrade{
+			ID:           strconv.FormatInt(item.AggTradeID, 10),
+			Symbol:       symbol,
+			Side:         side,
+			Price:        item.Price,
+			Amount:       item.Quantity,
+			FirstTradeID: strconv.FormatInt(item.FirstTradeID, 10),
+			LastTradeID:  strconv.FormatInt(item.LastTradeID, 10),
+			Count:        int(item.LastTradeID - item.FirstTradeID + 1),
+			Timestamp:    item.Timestamp,
+		})
+	}
+	return aggs
+}
+
+// binanceOrderBookResponse Binance 深度响应（现货和合约共用）
+type binanceOrderBookResponse struct {
+	LastUpdateID int64               `json:"lastUpdateId"` // Last update ID
+	EventTime    types.ExTimestamp   `json:"E"`            // Message output time（仅合约返回）
+	TransactTime types.ExTimestamp   `json:"T"`            // Transaction time（仅合约返回）
+	Bids         [][]types.ExDecimal `json:"bids"`         // [price, quantity]
+	Asks         [][]types.ExDecimal `json:"asks"`         // [price, quantity]
+}
+
+// binanceWSTicker Binance 24 小时行情推送（现货和合约共用，合约推送不包含买一/卖一）
+// 字段名区分大小写（如 c/C、e/E），需全部声明以免 encoding/json 忽略大小写匹配到错误字段
+type binanceWSTicker struct {
+	EventType          string            `json:"e"` // 事件类型 24hrTicker
+	EventTime          types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	Symbol             string            `json:"s"` // 交易对
+	PriceChange        types.ExDecimal   `json:"p"` // 24小时价格变化
+	PriceChangePercent types.ExDecimal   `json:"P"` // 24小时价格变化百分比
+	WeightedAvgPrice   types.ExDecimal   `json:"w"` // 成交量加权均价
+	PrevClosePrice     types.ExDecimal   `json:"x"` // 前一日收盘价
+	LastPrice          types.ExDecimal   `json:"c"` // 最新价
+	LastQty            types.ExDecimal   `json:"Q"` // 最新成交量
+	BidPrice           types.ExDecimal   `json:"b"` // 买一价
+	BidQty             types.ExDecimal   `json:"B"` // 买一量
+	AskPrice           types.ExDecimal   `json:"a"` // 卖一价
+	AskQty             types.ExDecimal   `json:"A"` // 卖一量
+	OpenPrice          types.ExDecimal   `json:"o"` // 开盘价
+	HighPrice          types.ExDecimal   `json:"h"` // 最高价
+	LowPrice           types.ExDecimal   `json:"l"` // 最低价
+	Volume             types.ExDecimal   `json:"v"` // 成交量
+	QuoteVolume        types.ExDecimal   `json:"q"` // 成交额
+	OpenTime           types.ExTimestamp `json:"O"` // 统计开始时间
+	CloseTime          types.ExTimestamp `json:"C"` // 统计结束时间
+	FirstID            int64             `json:"F"` // 首笔成交ID
+	LastID             int64             `json:"L"` // 末笔成交ID
+	Count              int64             `json:"n"` // 成交笔数
+}
+
+// binanceWSDepthUpdate Binance 深度增量推送（现货和合约共用，pu 仅合约提供）
+type binanceWSDepthUpdate struct {
+	EventType       string              `json:"e"`  // 事件类型 depthUpdate
+	EventTime       types.ExTimestamp   `json:"E"`  // 事件时间（毫秒）
+	TransactionTime types.ExTimestamp   `json:"T"`  // 撮合时间（毫秒，仅合约）
+	Symbol          string              `json:"s"`  // 交易对
+	FirstUpdateID   int64               `json:"U"`  // 本次推送的第一个更新ID
+	FinalUpdateID   int64               `json:"u"`  // 本次推送的最后一个更新ID
+	PrevUpdateID    int64               `json:"pu"` // 上一次推送的最后一个更新ID（仅合约）
+	Bids            [][]types.ExDecimal `json:"b"`  // 买单变化 [价格, 数量]
+	Asks            [][]types.ExDecimal `json:"a"`  // 卖单变化 [价格, 数量]
+}
+
+// binanceTimeResponse Binance 服务器时间响应
+type binanceTimeResponse struct {
+	ServerTime types.ExTimestamp `json:"serverTime"` // 服务器时间（毫秒）
+}
+
+// binanceSystemStatusResponse Binance 系统状态响应
+type binanceSystemStatusResponse struct {
+	Status int    `json:"status"` // 0 正常，1 系统维护
+	Msg    string `json:"msg"`    // normal 或 system maintenance
+}
+
+// binanceWSKline Binance K线推送（现货和合约共用）
+type binanceWSKline struct {
+	EventType string            `json:"e"` // 事件类型 kline
+	EventTime types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	Symbol    string            `json:"s"` // 交易对
+	Kline     struct {
+		// 字段名区分大小写（如 t/T、v/V），需全部声明以免 encoding/json 忽略大小写匹配到错误字段
+		StartTime        types.ExTimestamp `json:"t"` // 开盘时间
+		CloseTime        types.ExTimestamp `json:"T"` // 收盘时间
+		Symbol           string            `json:"s"` // 交易对
+		Interval         string            `json:"i"` // 周期
+		FirstTradeID     int64             `json:"f"` // 首笔成交ID
+		LastTradeID      int64             `json:"L"` // 末笔成交ID
+		Open             types.ExDecimal   `json:"o"` // 开盘价
+		Close            types.ExDecimal   `json:"c"` // 收盘价
+		High             types.ExDecimal   `json:"h"` // 最高价
+		Low              types.ExDecimal   `json:"l"` // 最低价
+		Volume           types.ExDecimal   `json:"v"` // 成交量
+		TradeCount       int64             `json:"n"` // 成交笔数
+		Closed           bool              `json:"x"` // 是否已收盘
+		QuoteVolume      types.ExDecimal   `json:"q"` // 成交额
+		TakerBuyVolume   types.ExDecimal   `json:"V"` // 主动买入成交量
+		TakerBuyQuoteVol types.ExDecimal   `json:"Q"` // 主动买入成交额
+		Ignore           string            `json:"B"` // 忽略
+	} `json:"k"`
+}
+
+// binanceListenKeyResponse Binance 创建用户数据流响应
+type binanceListenKeyResponse struct {
+	ListenKey string `json:"listenKey"` // 用户数据流 listenKey
+}
+
+// binanceWSUserDataEvent Binance 用户数据流事件头
+type binanceWSUserDataEvent struct {
+	EventType string            `json:"e"` // 事件类型
+	EventTime types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+}
@@ -1,6 +1,8 @@
 package binance
 
 import (
+	"encoding/json"
+
 	"github.com/lemconn/exlink/types"
 )
 
@@ -23,3 +25,192 @@ type binancePerpTickerResponse struct {
 	LastId             int64             `json:"lastId"`
 	Count              int64             `json:"count"`
 }
+
+// binancePerpBookTickerResponse Binance 永续合约最优挂单响应
+// 永续合约 24hr 行情不包含买一/卖一价，需要通过 bookTicker 接口获取
+type binancePerpBookTickerResponse struct {
+	Symbol   string            `json:"symbol"`
+	BidPrice types.ExDecimal   `json:"bidPrice"`
+	BidQty   types.ExDecimal   `json:"bidQty"`
+	AskPrice types.ExDecimal   `json:"askPrice"`
+	AskQty   types.ExDecimal   `json:"askQty"`
+	Time     types.ExTimestamp `json:"time"`
+}
+
+// binancePerpUserTrade Binance 合约账户成交记录（/fapi/v1/userTrades、/dapi/v1/userTrades）
+type binancePerpUserTrade struct {
+	Symbol          string            `json:"symbol"`          // 交易对
+	ID              int64             `json:"id"`              // 成交ID
+	OrderID         int64             `json:"orderId"`         // 订单ID
+	Side            string            `json:"side"`            // 买卖方向
+	PositionSide    string            `json:"positionSide"`    // 持仓方向
+	Price           types.ExDecimal   `json:"price"`           // 成交价格
+	Qty             types.ExDecimal   `json:"qty"`             // 成交数量（币本位合约为张数）
+	QuoteQty        types.ExDecimal   `json:"quoteQty"`        // 成交金额（仅 U本位合约）
+	RealizedPnl     types.ExDecimal   `json:"realizedPnl"`     // 已实现盈亏
+	Commission      types.ExDecimal   `json:"commission"`      // 手续费
+	CommissionAsset string            `json:"commissionAsset"` // 手续费币种
+	Time            types.ExTimestamp `json:"time"`            // 成交时间
+	Maker           bool              `json:"maker"`           // 是否为 Maker
+}
+
+// binancePerpFetchOrderResponse Binance 永续合约订单响应（查询订单和历史订单共用）
+type binancePerpFetchOrderResponse struct {
+	OrderID       int64             `json:"orderId"`       // 订单ID（交易所唯一）
+	ClientOrderID string            `json:"clientOrderId"` // 客户端自定义订单ID
+	Symbol        string            `json:"symbol"`        // 交易对 / 合约标的
+	Price         types.ExDecimal   `json:"price"`         // 下单价格（市价单通常为0）
+	AvgPrice      types.ExDecimal   `json:"avgPrice"`      // 成交均价
+	OrigQty       types.ExDecimal   `json:"origQty"`       // 下单数量
+	ExecutedQty   types.ExDecimal   `json:"executedQty"`   // 实际成交数量
+	Status        string            `json:"status"`        // 订单状态
+	TimeInForce   string            `json:"timeInForce"`   // 订单有效方式
+	ReduceOnly    bool              `json:"reduceOnly"`    // 是否只减仓
+	Time          types.ExTimestamp `json:"time"`          // 创建时间（毫秒）
+	Type          string            `json:"type"`          // 订单类型
+	Side          string            `json:"side"`          // 订单方向
+	PositionSide  string            `json:"positionSide"`  // 单向持仓 BOTH，双向持仓 LONG / SHORT
+	UpdateTime    types.ExTimestamp `json:"updateTime"`    // 更新时间（毫秒）
+}
+
+// binancePerpWSBookTicker Binance 永续合约最优挂单推送
+type binancePerpWSBookTicker struct {
+	EventType       string            `json:"e"` // 事件类型 bookTicker
+	UpdateID        int64             `json:"u"` // 更新ID
+	EventTime       types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	TransactionTime types.ExTimestamp `json:"T"` // 撮合时间（毫秒）
+	Symbol          string            `json:"s"` // 交易对
+	BidPrice        types.ExDecimal   `json:"b"` // 买一价
+	BidQty          types.ExDecimal   `json:"B"` // 买一量
+	AskPrice        types.ExDecimal   `json:"a"` // 卖一价
+	AskQty          types.ExDecimal   `json:"A"` // 卖一量
+}
+
+// binancePerpPremiumIndexResponse Binance 永续合约标记价格及资金费率响应
+type binancePerpPremiumIndexResponse struct {
+	Symbol          string            `json:"symbol"`          // 交易对
+	MarkPrice       types.ExDecimal   `json:"markPrice"`       // 标记价格
+	IndexPrice      types.ExDecimal   `json:"indexPrice"`      // 指数价格
+	LastFundingRate types.ExDecimal   `json:"lastFundingRate"` // 当期资金费率
+	InterestRate    types.ExDecimal   `json:"interestRate"`    // 利率
+	NextFundingTime types.ExTimestamp `json:"nextFundingTime"` // 下次结算时间（毫秒）
+	Time            types.ExTimestamp `json:"time"`            // 更新时间（毫秒）
+}
+
+// binancePerpOpenInterestResponse Binance 合约持仓量（U 本位为基础币数量，币本位为张数）
+type binancePerpOpenInterestResponse struct {
+	Symbol       string            `json:"symbol"`       // 交易对
+	OpenInterest types.ExDecimal   `json:"openInterest"` // 持仓量
+	Time         types.ExTimestamp `json:"time"`         // 更新时间（毫秒）
+}
+
+// binancePerpFundingRateResponse Binance 永续合约历史资金费率
+type binancePerpFundingRateResponse struct {
+	Symbol      string            `json:"symbol"`      // 交易对
+	FundingRate types.ExDecimal   `json:"fundingRate"` // 资金费率
+	FundingTime types.ExTimestamp `json:"fundingTime"` // 结算时间（毫秒）
+	MarkPrice   types.ExDecimal   `json:"markPrice"`   // 结算时的标记价格
+}
+
+// binancePerpPositionResponse 持仓风险（/fapi/v2/positionRisk、/dapi/v1/positionRisk）
+type binancePerpPositionResponse struct {
+	Symbol           string            `json:"symbol"`
+	PositionAmt      types.ExDecimal   `json:"positionAmt"`
+	EntryPrice       types.ExDecimal   `json:"entryPrice"`
+	BreakEvenPrice   types.ExDecimal   `json:"breakEvenPrice"`
+	MarkPrice        types.ExDecimal   `json:"markPrice"`
+	UnRealizedProfit types.ExDecimal   `json:"unRealizedProfit"`
+	LiquidationPrice types.ExDecimal   `json:"liquidationPrice"`
+	Leverage         types.ExDecimal   `json:"leverage"`
+	MaxNotionalValue types.ExDecimal   `json:"maxNotionalValue"`
+	MarginType       string            `json:"marginType"`
+	IsolatedMargin   types.ExDecimal   `json:"isolatedMargin"`
+	IsAutoAddMargin  string            `json:"isAutoAddMargin"`
+	PositionSide     string            `json:"positionSide"`
+	Notional         types.ExDecimal   `json:"notional"`
+	IsolatedWallet   types.ExDecimal   `json:"isolatedWallet"`
+	UpdateTime       types.ExTimestamp `json:"updateTime"`
+	Isolated         bool              `json:"isolated"`
+	AdlQuantile      int               `json:"adlQuantile"`
+}
+
+// binancePerpBalanceItem U本位合约账户余额（/fapi/v2/balance）
+type binancePerpBalanceItem struct {
+	Asset              string            `json:"asset"`              // 币种
+	Balance            types.ExDecimal   `json:"balance"`            // 钱包余额
+	CrossWalletBalance types.ExDecimal   `json:"crossWalletBalance"` // 全仓钱包余额
+	CrossUnPnl         types.ExDecimal   `json:"crossUnPnl"`         // 全仓未实现盈亏
+	AvailableBalance   types.ExDecimal   `json:"availableBalance"`   // 可用余额（可用于下单的保证金）
+	MaxWithdrawAmount  types.ExDecimal   `json:"maxWithdrawAmount"`  // 最大可转出余额
+	UpdateTime         types.ExTimestamp `json:"updateTime"`         // 更新时间
+}
+
+// binancePerpCreateOrderResponse Binance 合约下单响应
+type binancePerpCreateOrderResponse struct {
+	OrderID       int64             `json:"orderId"`       // 系统订单号
+	ClientOrderID string            `json:"clientOrderId"` // 客户端订单ID
+	UpdateTime    types.ExTimestamp `json:"updateTime"`    // 更新时间（毫秒时间戳）
+}
+
+// binancePerpBatchOrderResponse Binance 合约批量下单响应中的单项（失败时为 {"code","msg"}）
+type binancePerpBatchOrderResponse struct {
+	binancePerpCreateOrderResponse
+	Code int    `json:"code"` // 错误码（成功时为 0）
+	Msg  string `json:"msg"`  // 错误信息
+}
+
+// binancePerpWSOrderUpdate Binance 合约订单更新推送（ORDER_TRADE_UPDATE）
+// 字段名区分大小写（如 x/X、l/L、ap/AP），需全部声明以免 encoding/json 忽略大小写匹配到错误字段
+type binancePerpWSOrderUpdate struct {
+	EventType       string            `json:"e"` // 事件类型 ORDER_TRADE_UPDATE
+	EventTime       types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	TransactionTime types.ExTimestamp `json:"T"` // 撮合时间（毫秒）
+	Order           struct {
+		Symbol            string            `json:"s"`  // 交易对
+		ClientOrderID     string            `json:"c"`  // 客户端订单ID
+		Side              string            `json:"S"`  // 订单方向
+		Type              string            `json:"o"`  // 订单类型
+		TimeInForce       string            `json:"f"`  // 有效方式
+		Quantity          types.ExDecimal   `json:"q"`  // 订单数量
+		Price             types.ExDecimal   `json:"p"`  // 订单价格
+		AvgPrice          types.ExDecimal   `json:"ap"` // 成交均价
+		StopPrice         types.ExDecimal   `json:"sp"` // 触发价格
+		ExecutionType     string            `json:"x"`  // 本次事件的执行类型
+		Status            string            `json:"X"`  // 订单当前状态
+		OrderID           int64             `json:"i"`  // 订单ID
+		LastFilledQty     types.ExDecimal   `json:"l"`  // 本次成交数量
+		FilledQty         types.ExDecimal   `json:"z"`  // 累计成交数量
+		LastFilledPrice   types.ExDecimal   `json:"L"`  // 本次成交价格
+		CommissionAsset   string            `json:"N"`  // 手续费币种
+		Commission        types.ExDecimal   `json:"n"`  // 手续费
+		TradeTime         types.ExTimestamp `json:"T"`  // 成交时间
+		TradeID           int64             `json:"t"`  // 成交ID
+		ReduceOnly        bool              `json:"R"`  // 是否只减仓
+		PositionSide      string            `json:"ps"` // 持仓方向
+		ActivationPrice   types.ExDecimal   `json:"AP"` // 追踪止损激活价格
+		RealizedProfit    types.ExDecimal   `json:"rp"` // 本次成交的实现盈亏
+		OriginalOrderType string            `json:"ot"` // 原始订单类型
+	} `json:"o"`
+}
+
+// binancePerpWSAccountUpdate Binance 合约账户更新推送（ACCOUNT_UPDATE，仅包含变化的币种和持仓）
+type binancePerpWSAccountUpdate struct {
+	EventType       string            `json:"e"` // 事件类型 ACCOUNT_UPDATE
+	EventTime       types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	TransactionTime types.ExTimestamp `json:"T"` // 撮合时间（毫秒）
+	Account         struct {
+		Reason   string `json:"m"` // 事件原因
+		Balances []struct {
+			Asset              string          `json:"a"`  // 币种
+			WalletBalance      types.ExDecimal `json:"wb"` // 钱包余额
+			CrossWalletBalance types.ExDecimal `json:"cw"` // 全仓钱包余额
+			BalanceChange      types.ExDecimal `json:"bc"` // 除盈亏和手续费外的余额变化
+		} `json:"B"`
+		Positions json.RawMessage `json:"P"` // 持仓变化（WatchBalance 不使用）
+	} `json:"a"`
+}
+
+// binancePerpPositionModeResponse Binance 合约持仓模式响应
+type binancePerpPositionModeResponse struct {
+	DualSidePosition bool `json:"dualSidePosition"` // true 为双向持仓，false 为单向持仓
+}
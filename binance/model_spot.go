@@ -67,10 +67,60 @@ type binanceSpotBalanceItem struct {
 
 // binanceSpotCreateOrderResponse Binance 现货订单响应
 type binanceSpotCreateOrderResponse struct {
-	Symbol        string            `json:"symbol"`
-	OrderID       int64             `json:"orderId"`
-	ClientOrderID string            `json:"clientOrderId"`
-	Time          types.ExTimestamp `json:"time"`
+	Symbol        string                 `json:"symbol"`
+	OrderID       int64                  `json:"orderId"`
+	ClientOrderID string                 `json:"clientOrderId"`
+	TransactTime  types.ExTimestamp      `json:"transactTime"`
+	Type          string                 `json:"type"`
+	Side          string                 `json:"side"`
+	Fills         []binanceSpotOrderFill `json:"fills"` // 成交明细（FULL 响应，市价单和立即成交的限价单）
+}
+
+// binanceSpotOrderFill Binance 现货下单响应中的单笔成交
+type binanceSpotOrderFill struct {
+	Price           types.ExDecimal `json:"price"`
+	Qty             types.ExDecimal `json:"qty"`
+	Commission      types.ExDecimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeID         int64           `json:"tradeId"`
+}
+
+// binanceSpotOrderListResponse Binance 现货订单组（OCO）下单响应
+type binanceSpotOrderListResponse struct {
+	OrderListID       int64             `json:"orderListId"`       // 订单组ID
+	ContingencyType   string            `json:"contingencyType"`   // 组合类型（OCO）
+	ListOrderStatus   string            `json:"listOrderStatus"`   // 订单组状态（EXECUTING 等）
+	ListClientOrderID string            `json:"listClientOrderId"` // 客户端订单组ID
+	TransactionTime   types.ExTimestamp `json:"transactionTime"`   // 创建时间
+	Symbol            string            `json:"symbol"`            // 交易对
+	Orders            []struct {
+		OrderID       int64  `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+	} `json:"orders"` // 子订单ID
+	OrderReports []struct {
+		OrderID       int64           `json:"orderId"`
+		ClientOrderID string          `json:"clientOrderId"`
+		Price         types.ExDecimal `json:"price"`
+		OrigQty       types.ExDecimal `json:"origQty"`
+		StopPrice     types.ExDecimal `json:"stopPrice"`
+		Type          string          `json:"type"`
+		Side          string          `json:"side"`
+	} `json:"orderReports"` // 子订单详情
+}
+
+// binanceSpotMyTrade Binance 现货账户成交记录（/api/v3/myTrades）
+type binanceSpotMyTrade struct {
+	Symbol          string            `json:"symbol"`          // 交易对
+	ID              int64             `json:"id"`              // 成交ID
+	OrderID         int64             `json:"orderId"`         // 订单ID
+	Price           types.ExDecimal   `json:"price"`           // 成交价格
+	Qty             types.ExDecimal   `json:"qty"`             // 成交数量
+	QuoteQty        types.ExDecimal   `json:"quoteQty"`        // 成交金额
+	Commission      types.ExDecimal   `json:"commission"`      // 手续费
+	CommissionAsset string            `json:"commissionAsset"` // 手续费币种
+	Time            types.ExTimestamp `json:"time"`            // 成交时间
+	IsBuyer         bool              `json:"isBuyer"`         // 是否为买方
+	IsMaker         bool              `json:"isMaker"`         // 是否为 Maker
 }
 
 // binanceSpotFetchOrderResponse Binance 现货查询订单响应
@@ -95,3 +145,155 @@ type binanceSpotFetchOrderResponse struct {
 	WorkingTime         types.ExTimestamp `json:"workingTime"`         // 工作时间
 	OrigQuoteOrderQty   types.ExDecimal   `json:"origQuoteOrderQty"`   // 原始报价订单数量
 }
+
+// binanceSpotConvertQuoteResponse Binance 闪兑询价响应
+type binanceSpotConvertQuoteResponse struct {
+	QuoteID        string            `json:"quoteId"`        // 询价ID
+	Ratio          types.ExDecimal   `json:"ratio"`          // 兑换比例（1 个 fromAsset 可兑换的 toAsset 数量）
+	InverseRatio   types.ExDecimal   `json:"inverseRatio"`   // 反向兑换比例
+	ValidTimestamp types.ExTimestamp `json:"validTimestamp"` // 报价有效期
+	ToAmount       types.ExDecimal   `json:"toAmount"`       // 买入数量
+	FromAmount     types.ExDecimal   `json:"fromAmount"`     // 卖出数量
+}
+
+// binanceSpotConvertAcceptResponse Binance 闪兑确认报价响应
+type binanceSpotConvertAcceptResponse struct {
+	OrderID     string            `json:"orderId"`     // 闪兑订单ID
+	CreateTime  types.ExTimestamp `json:"createTime"`  // 创建时间
+	OrderStatus string            `json:"orderStatus"` // 订单状态（PROCESS/ACCEPT_SUCCESS/SUCCESS/FAIL）
+}
+
+// binanceSpotAggTradesResponse Binance 现货归集交易响应
+type binanceSpotAggTradesResponse []binanceAggTrade
+
+// binanceSpotCurrencyResponse Binance 币种信息响应
+type binanceSpotCurrencyResponse []binanceSpotCurrency
+
+// binanceSpotCurrency Binance 币种信息
+type binanceSpotCurrency struct {
+	Coin        string                       `json:"coin"`        // 币种
+	Name        string                       `json:"name"`        // 币种名称
+	NetworkList []binanceSpotCurrencyNetwork `json:"networkList"` // 支持的网络
+}
+
+// binanceSpotCurrencyNetwork Binance 币种网络信息
+type binanceSpotCurrencyNetwork struct {
+	Network        string          `json:"network"`        // 网络，如 ETH、TRX、BSC
+	Name           string          `json:"name"`           // 网络名称
+	IsDefault      bool            `json:"isDefault"`      // 是否为默认网络
+	DepositEnable  bool            `json:"depositEnable"`  // 是否可充值
+	WithdrawEnable bool            `json:"withdrawEnable"` // 是否可提币
+	WithdrawFee    types.ExDecimal `json:"withdrawFee"`    // 提币手续费
+	WithdrawMin    types.ExDecimal `json:"withdrawMin"`    // 最小提币数量
+}
+
+// binanceSpotDepositAddressResponse Binance 充值地址响应
+type binanceSpotDepositAddressResponse struct {
+	Address string `json:"address"` // 充值地址
+	Coin    string `json:"coin"`    // 币种
+	Tag     string `json:"tag"`     // 地址标签
+	URL     string `json:"url"`     // 区块浏览器链接
+}
+
+// binanceSpotWithdrawResponse Binance 提币申请响应
+type binanceSpotWithdrawResponse struct {
+	ID string `json:"id"` // 提币ID
+}
+
+// binanceSpotTransferResponse Binance 万向划转、杠杆借还币响应
+type binanceSpotTransferResponse struct {
+	TranID int64 `json:"tranId"` // 划转ID
+}
+
+// binanceMarginInterestRateItem Binance 杠杆下一小时借币利率
+type binanceMarginInterestRateItem struct {
+	Asset                  string          `json:"asset"`                  // 币种
+	NextHourlyInterestRate types.ExDecimal `json:"nextHourlyInterestRate"` // 下一小时利率
+}
+
+// binanceSubAccountListResponse Binance 子账户列表响应
+type binanceSubAccountListResponse struct {
+	SubAccounts []struct {
+		Email      string            `json:"email"`      // 子账户邮箱
+		IsFreeze   bool              `json:"isFreeze"`   // 是否冻结
+		CreateTime types.ExTimestamp `json:"createTime"` // 创建时间
+	} `json:"subAccounts"`
+}
+
+// binanceSubAccountAssetsResponse Binance 子账户资产响应
+type binanceSubAccountAssetsResponse struct {
+	Balances []binanceSpotBalanceItem `json:"balances"`
+}
+
+// binanceSpotMarginAccountResponse Binance 全仓杠杆账户响应
+type binanceSpotMarginAccountResponse struct {
+	UserAssets []struct {
+		Asset    string          `json:"asset"`    // 币种
+		Free     types.ExDecimal `json:"free"`     // 可用
+		Locked   types.ExDecimal `json:"locked"`   // 冻结
+		Borrowed types.ExDecimal `json:"borrowed"` // 借款
+		Interest types.ExDecimal `json:"interest"` // 利息
+		NetAsset types.ExDecimal `json:"netAsset"` // 净资产
+	} `json:"userAssets"`
+}
+
+// binanceSpotFundingAssetItem Binance 资金账户余额
+type binanceSpotFundingAssetItem struct {
+	Asset       string          `json:"asset"`       // 币种
+	Free        types.ExDecimal `json:"free"`        // 可用
+	Locked      types.ExDecimal `json:"locked"`      // 锁定
+	Freeze      types.ExDecimal `json:"freeze"`      // 冻结
+	Withdrawing types.ExDecimal `json:"withdrawing"` // 提币中
+}
+
+// binanceSpotWSExecutionReport Binance 现货订单更新推送（executionReport）
+// 字段名区分大小写（如 c/C、x/X、z/Z），需全部声明以免 encoding/json 忽略大小写匹配到错误字段
+type binanceSpotWSExecutionReport struct {
+	EventType               string            `json:"e"` // 事件类型 executionReport
+	EventTime               types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	Symbol                  string            `json:"s"` // 交易对
+	ClientOrderID           string            `json:"c"` // 客户端订单ID（撤单时为撤单请求的客户端ID）
+	Side                    string            `json:"S"` // 订单方向
+	Type                    string            `json:"o"` // 订单类型
+	TimeInForce             string            `json:"f"` // 时间有效性
+	Quantity                types.ExDecimal   `json:"q"` // 订单数量
+	Price                   types.ExDecimal   `json:"p"` // 订单价格
+	StopPrice               types.ExDecimal   `json:"P"` // 止损价格
+	IcebergQty              types.ExDecimal   `json:"F"` // 冰山订单数量
+	OrderListID             int64             `json:"g"` // 订单列表ID
+	OrigClientOrderID       string            `json:"C"` // 原始客户端订单ID（撤单时有值）
+	ExecutionType           string            `json:"x"` // 本次事件的执行类型
+	Status                  string            `json:"X"` // 订单当前状态
+	RejectReason            string            `json:"r"` // 拒绝原因
+	OrderID                 int64             `json:"i"` // 订单ID
+	LastExecutedQty         types.ExDecimal   `json:"l"` // 本次成交数量
+	CumulativeFilledQty     types.ExDecimal   `json:"z"` // 累计成交数量
+	LastExecutedPrice       types.ExDecimal   `json:"L"` // 本次成交价格
+	Commission              types.ExDecimal   `json:"n"` // 手续费
+	CommissionAsset         *string           `json:"N"` // 手续费币种
+	TransactionTime         types.ExTimestamp `json:"T"` // 成交时间
+	TradeID                 int64             `json:"t"` // 成交ID
+	Ignore                  int64             `json:"I"` // 忽略
+	IsWorking               bool              `json:"w"` // 是否在订单簿上
+	IsMaker                 bool              `json:"m"` // 是否为挂单方
+	IgnoreM                 bool              `json:"M"` // 忽略
+	CreationTime            types.ExTimestamp `json:"O"` // 订单创建时间
+	CumulativeQuoteQty      types.ExDecimal   `json:"Z"` // 累计成交金额
+	LastQuoteQty            types.ExDecimal   `json:"Y"` // 本次成交金额
+	QuoteOrderQty           types.ExDecimal   `json:"Q"` // 报价订单数量
+	WorkingTime             types.ExTimestamp `json:"W"` // 进入订单簿时间
+	SelfTradePreventionMode string            `json:"V"` // 自成交保护模式
+	PreventedMatchID        int64             `json:"v"` // 自成交保护匹配ID
+}
+
+// binanceSpotWSAccountPosition Binance 现货账户余额推送（outboundAccountPosition，仅包含变化的币种）
+type binanceSpotWSAccountPosition struct {
+	EventType      string            `json:"e"` // 事件类型 outboundAccountPosition
+	EventTime      types.ExTimestamp `json:"E"` // 事件时间（毫秒）
+	LastUpdateTime types.ExTimestamp `json:"u"` // 账户最后更新时间
+	Balances       []struct {
+		Asset  string          `json:"a"` // 币种
+		Free   types.ExDecimal `json:"f"` // 可用
+		Locked types.ExDecimal `json:"l"` // 冻结
+	} `json:"B"`
+}
@@ -0,0 +1,114 @@
+package binance
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// binanceRequestWeight 返回 Binance 接口的请求权重（按官方文档的 IP 权重），未列出的接口权重为 1
+func binanceRequestWeight(method, path string, query url.Values) int {
+	// 币本位合约（dapi）除以下接口外与 fapi 权重一致
+	if strings.HasPrefix(path, "/dapi/") {
+		switch path {
+		case "/dapi/v1/allOrders", "/dapi/v1/userTrades":
+			return 20
+		case "/dapi/v1/positionRisk":
+			return 1
+		}
+		path = "/fapi/" + strings.TrimPrefix(path, "/dapi/")
+	}
+
+	switch path {
+	// 现货
+	case "/api/v3/exchangeInfo", "/api/v3/account", "/api/v3/allOrders", "/api/v3/myTrades":
+		return 20
+	case "/api/v3/ticker/24hr":
+		if query.Get("symbol") != "" {
+			return 2
+		}
+		if symbols := query.Get("symbols"); symbols != "" {
+			switch n := strings.Count(symbols, ",") + 1; {
+			case n <= 20:
+				return 2
+			case n <= 100:
+				return 40
+			}
+		}
+		return 80
+	case "/api/v3/depth":
+		switch limit := queryInt(query, "limit", 100); {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		default:
+			return 250
+		}
+	case "/api/v3/klines":
+		return 2
+	case "/api/v3/aggTrades":
+		return 4
+	case "/api/v3/order":
+		if method == http.MethodGet {
+			return 4
+		}
+		return 1
+
+	// 永续合约
+	case "/fapi/v1/ticker/24hr":
+		if query.Get("symbol") != "" {
+			return 1
+		}
+		return 40
+	case "/fapi/v1/ticker/bookTicker":
+		if query.Get("symbol") != "" {
+			return 2
+		}
+		return 5
+	case "/fapi/v1/depth":
+		switch limit := queryInt(query, "limit", 500); {
+		case limit <= 50:
+			return 2
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 10
+		default:
+			return 20
+		}
+	case "/fapi/v1/klines":
+		switch limit := queryInt(query, "limit", 500); {
+		case limit < 100:
+			return 1
+		case limit < 500:
+			return 2
+		case limit <= 1000:
+			return 5
+		default:
+			return 10
+		}
+	case "/fapi/v1/aggTrades":
+		return 20
+	case "/fapi/v1/premiumIndex":
+		if query.Get("symbol") != "" {
+			return 1
+		}
+		return 10
+	case "/fapi/v1/allOrders", "/fapi/v2/positionRisk", "/fapi/v1/userTrades":
+		return 5
+	}
+	return 1
+}
+
+// queryInt 读取整数查询参数，不存在或无法解析时返回 def
+func queryInt(query url.Values, key string, def int) int {
+	v, err := strconv.Atoi(query.Get(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
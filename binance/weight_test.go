@@ -0,0 +1,36 @@
+package binance
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestBinanceRequestWeight(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		query  string
+		want   int
+	}{
+		{http.MethodGet, "/api/v3/exchangeInfo", "", 20},
+		{http.MethodGet, "/api/v3/ticker/24hr", "symbol=BTCUSDT", 2},
+		{http.MethodGet, "/api/v3/ticker/24hr", "", 80},
+		{http.MethodGet, "/api/v3/depth", "symbol=BTCUSDT&limit=5000", 250},
+		{http.MethodGet, "/api/v3/order", "symbol=BTCUSDT", 4},
+		{http.MethodPost, "/api/v3/order", "symbol=BTCUSDT", 1},
+		{http.MethodGet, "/fapi/v1/depth", "symbol=BTCUSDT", 10},
+		{http.MethodGet, "/fapi/v1/klines", "symbol=BTCUSDT&limit=1500", 10},
+		{http.MethodGet, "/fapi/v1/ticker/24hr", "", 40},
+		{http.MethodPost, "/fapi/v1/leverage", "", 1},
+		{http.MethodGet, "/dapi/v1/depth", "symbol=BTCUSD_PERP&limit=1000", 20},
+		{http.MethodGet, "/dapi/v1/positionRisk", "pair=BTCUSD", 1},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		if got := binanceRequestWeight(tt.method, tt.path, query); got != tt.want {
+			t.Errorf("%s %s?%s weight = %d, want %d", tt.method, tt.path, tt.query, got, tt.want)
+		}
+	}
+}
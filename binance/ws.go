@@ -0,0 +1,431 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// binanceWSProtocol Binance 组合流订阅协议
+// 订阅消息形如 {"method":"SUBSCRIBE","params":["btcusdt@ticker"],"id":1}，推送消息形如 {"stream":"btcusdt@ticker","data":{...}}；
+// 服务端定时发送 ping 帧，由连接自动回复 pong，无需客户端心跳
+type binanceWSProtocol struct {
+	id atomic.Int64
+}
+
+// binanceWSRequest Binance WebSocket 订阅请求
+type binanceWSRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int64    `json:"id"`
+}
+
+// SubscribeMessage 构建订阅消息
+func (p *binanceWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(binanceWSRequest{Method: "SUBSCRIBE", Params: topics, ID: p.id.Add(1)})
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (p *binanceWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(binanceWSRequest{Method: "UNSUBSCRIBE", Params: topics, ID: p.id.Add(1)})
+}
+
+// Route 组合流推送消息的 stream 字段即为订阅主题，订阅响应没有 stream 字段
+func (p *binanceWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Stream string `json:"stream"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Stream == "" {
+		return "", false
+	}
+	return m.Stream, true
+}
+
+// binanceTickerTopic 返回 24 小时行情流的主题，如 btcusdt@ticker
+func binanceTickerTopic(marketID string) string {
+	return strings.ToLower(marketID) + "@ticker"
+}
+
+// parseBinanceWSTicker 返回解析 24 小时行情推送的函数，symbol 为标准化格式
+func parseBinanceWSTicker(symbol string) common.WSParser[*model.Ticker] {
+	return func(msg []byte) (*model.Ticker, bool) {
+		var m struct {
+			Data binanceWSTicker `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &m); err != nil || m.Data.EventType != "24hrTicker" {
+			return nil, false
+		}
+
+		data := m.Data
+		ticker := &model.Ticker{
+			Symbol:    symbol,
+			Timestamp: data.EventTime,
+		}
+		ticker.Bid = data.BidPrice
+		ticker.Ask = data.AskPrice
+		ticker.Last = data.LastPrice
+		ticker.Open = data.OpenPrice
+		ticker.High = data.HighPrice
+		ticker.Low = data.LowPrice
+		ticker.Volume = data.Volume
+		ticker.QuoteVolume = data.QuoteVolume
+		ticker.VWAP = data.WeightedAvgPrice
+		ticker.TradeCount = data.Count
+		return ticker, true
+	}
+}
+
+// binanceKlineTopic 返回K线流的主题，如 btcusdt@kline_1m
+func binanceKlineTopic(marketID, interval string) string {
+	return strings.ToLower(marketID) + "@kline_" + interval
+}
+
+// parseBinanceWSKline 解析K线推送，收盘前每次更新推送当前K线，x 为 true 时为收盘K线
+func parseBinanceWSKline(msg []byte) (*model.OHLCV, bool) {
+	var m struct {
+		Data binanceWSKline `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Data.EventType != "kline" {
+		return nil, false
+	}
+
+	k := m.Data.Kline
+	return &model.OHLCV{
+		Timestamp: k.StartTime,
+		Open:      k.Open,
+		High:      k.High,
+		Low:       k.Low,
+		Close:     k.Close,
+		Volume:    k.Volume,
+		Closed:    k.Closed,
+	}, true
+}
+
+// binanceBookTickerTopic 返回最优挂单流的主题，如 btcusdt@bookTicker
+func binanceBookTickerTopic(marketID string) string {
+	return strings.ToLower(marketID) + "@bookTicker"
+}
+
+// parseBinancePerpWSBookTicker 解析合约最优挂单推送
+func parseBinancePerpWSBookTicker(msg []byte) (*binancePerpWSBookTicker, bool) {
+	var m struct {
+		Data binancePerpWSBookTicker `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Data.EventType != "bookTicker" {
+		return nil, false
+	}
+	return &m.Data, true
+}
+
+// binanceDepthTopic 返回 100ms 深度增量流的主题，如 btcusdt@depth@100ms
+func binanceDepthTopic(marketID string) string {
+	return strings.ToLower(marketID) + "@depth@100ms"
+}
+
+// parseBinanceWSDepth 解析深度增量推送
+func parseBinanceWSDepth(msg []byte) (*common.OrderBookDelta, bool) {
+	var m struct {
+		Data binanceWSDepthUpdate `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Data.EventType != "depthUpdate" {
+		return nil, false
+	}
+
+	data := m.Data
+	return &common.OrderBookDelta{
+		FirstUpdateID: data.FirstUpdateID,
+		FinalUpdateID: data.FinalUpdateID,
+		PrevUpdateID:  data.PrevUpdateID,
+		Bids:          common.ParseOrderBookLevels(data.Bids, 0),
+		Asks:          common.ParseOrderBookLevels(data.Asks, 0),
+		Timestamp:     data.EventTime,
+	}, true
+}
+
+// 用户数据流事件类型，同时作为用户数据流的订阅主题
+const (
+	binanceExecutionReportEvent   = "executionReport"         // 现货订单更新
+	binanceAccountPositionEvent   = "outboundAccountPosition" // 现货余额更新
+	binancePerpOrderUpdateEvent   = "ORDER_TRADE_UPDATE"      // 合约订单更新
+	binancePerpAccountUpdateEvent = "ACCOUNT_UPDATE"          // 合约余额和持仓更新
+	binanceListenKeyExpiredEvent  = "listenKeyExpired"        // listenKey 过期
+)
+
+const (
+	// binanceSpotListenKeyPath 现货用户数据流 listenKey 接口
+	binanceSpotListenKeyPath = "/api/v3/userDataStream"
+	// binancePerpListenKeyPath U本位合约用户数据流 listenKey 接口
+	binancePerpListenKeyPath = "/fapi/v1/listenKey"
+)
+
+// binanceListenKeyKeepalive listenKey 延期间隔（listenKey 60 分钟未延期即失效）
+const binanceListenKeyKeepalive = 30 * time.Minute
+
+// binanceUserDataProtocol Binance 用户数据流协议
+// 连接地址 /ws/<listenKey> 建立后即推送该账户的全部事件，无需订阅；主题为事件类型（e 字段）
+type binanceUserDataProtocol struct {
+	id atomic.Int64
+}
+
+// SubscribeMessage 用户数据流无需订阅
+func (p *binanceUserDataProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return nil, nil
+}
+
+// UnsubscribeMessage 用户数据流无需取消订阅
+func (p *binanceUserDataProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return nil, nil
+}
+
+// Route 推送消息的事件类型即为主题，LIST_SUBSCRIPTIONS 心跳响应没有 e 字段
+func (p *binanceUserDataProtocol) Route(msg []byte) (string, bool) {
+	var m binanceWSUserDataEvent
+	if err := json.Unmarshal(msg, &m); err != nil || m.EventType == "" {
+		return "", false
+	}
+	return m.EventType, true
+}
+
+// PingMessage 用户数据流推送稀疏，以 LIST_SUBSCRIPTIONS 请求作为心跳
+func (p *binanceUserDataProtocol) PingMessage() []byte {
+	msg, _ := json.Marshal(binanceWSRequest{Method: "LIST_SUBSCRIPTIONS", Params: []string{}, ID: p.id.Add(1)})
+	return msg
+}
+
+// newUserDataWS 创建用户数据流订阅管理器，client 和 path 为创建 listenKey 的 REST 客户端和接口，wsURL 为组合流地址
+// 每次建立连接（包括断线重连）时通过 REST 获取 listenKey，连接期间每 keepaliveInterval 延期一次
+func (b *Binance) newUserDataWS(client *common.HTTPClient, path, wsURL string, keepaliveInterval time.Duration) *common.WSManager {
+	baseURL := strings.TrimSuffix(wsURL, "/stream")
+	dial := func(ctx context.Context) (common.WSConn, error) {
+		listenKey, err := b.createListenKey(ctx, client, path)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := common.NewWSDialer(baseURL+"/ws/"+listenKey, b.client.ProxyURL)(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		userConn := &binanceUserDataConn{WSConn: conn, done: make(chan struct{})}
+		go userConn.keepalive(keepaliveInterval, func() error {
+			return b.keepaliveListenKey(context.Background(), client, path, listenKey)
+		})
+		return userConn, nil
+	}
+	return common.NewWSManager(dial, &binanceUserDataProtocol{})
+}
+
+// createListenKey 创建用户数据流 listenKey（已存在有效的 listenKey 时返回同一个并延期）
+func (b *Binance) createListenKey(ctx context.Context, client *common.HTTPClient, path string) (string, error) {
+	creds := b.credentials()
+	if creds.apiKey == "" {
+		return "", common.ErrAuthenticationRequired
+	}
+
+	resp, err := client.RequestWithHeaders(ctx, http.MethodPost, path, nil, nil, creds.headers())
+	if err != nil {
+		return "", fmt.Errorf("create listen key: %w", err)
+	}
+
+	var result binanceListenKeyResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("unmarshal listen key: %w", err)
+	}
+	if result.ListenKey == "" {
+		return "", fmt.Errorf("create listen key: empty listen key")
+	}
+	return result.ListenKey, nil
+}
+
+// keepaliveListenKey 延长 listenKey 有效期
+func (b *Binance) keepaliveListenKey(ctx context.Context, client *common.HTTPClient, path, listenKey string) error {
+	_, err := client.RequestWithHeaders(ctx, http.MethodPut, path, map[string]interface{}{"listenKey": listenKey}, nil, b.credentials().headers())
+	if err != nil {
+		return fmt.Errorf("keepalive listen key: %w", err)
+	}
+	return nil
+}
+
+// binanceUserDataConn 用户数据流连接
+// 定期延长 listenKey 有效期，延期失败或收到 listenKeyExpired 事件时返回读取错误，由 WSManager 断开重连（重连时重新获取 listenKey）
+type binanceUserDataConn struct {
+	common.WSConn
+	done    chan struct{}
+	once    sync.Once
+	expired atomic.Bool
+}
+
+// ReadMessage 读取一条消息，listenKey 已失效时返回错误
+func (c *binanceUserDataConn) ReadMessage() ([]byte, error) {
+	if c.expired.Load() {
+		return nil, fmt.Errorf("listen key expired")
+	}
+	msg, err := c.WSConn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var m binanceWSUserDataEvent
+	if json.Unmarshal(msg, &m) == nil && m.EventType == binanceListenKeyExpiredEvent {
+		return nil, fmt.Errorf("listen key expired")
+	}
+	return msg, nil
+}
+
+// Close 停止延期并关闭连接
+func (c *binanceUserDataConn) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return c.WSConn.Close()
+}
+
+// keepalive 定期延期 listenKey 直到连接关闭，延期失败时标记失效并关闭底层连接以触发重连
+func (c *binanceUserDataConn) keepalive(interval time.Duration, extend func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if err := extend(); err != nil {
+				c.expired.Store(true)
+				c.WSConn.Close()
+				return
+			}
+		}
+	}
+}
+
+// symbolResolver 将交易所市场ID转换为标准化交易对，市场信息未加载时原样返回
+type symbolResolver func(marketID string) string
+
+// parseBinanceWSExecutionReport 返回解析现货订单更新推送的函数，每次订单状态变化（新建、成交、撤销等）推送一次
+func parseBinanceWSExecutionReport(symbolOf symbolResolver) common.WSParser[*model.SpotOrder] {
+	return func(msg []byte) (*model.SpotOrder, bool) {
+		var data binanceSpotWSExecutionReport
+		if err := json.Unmarshal(msg, &data); err != nil || data.EventType != binanceExecutionReportEvent {
+			return nil, false
+		}
+
+		// 撤单事件的 c 为撤单请求的客户端ID，原订单的客户端ID在 C 中
+		clientOrderID := data.ClientOrderID
+		if data.OrigClientOrderID != "" {
+			clientOrderID = data.OrigClientOrderID
+		}
+		order := toBinanceSpotOrder(symbolOf(data.Symbol), &binanceSpotFetchOrderResponse{
+			Symbol:              data.Symbol,
+			OrderID:             data.OrderID,
+			OrderListID:         data.OrderListID,
+			ClientOrderID:       clientOrderID,
+			Price:               data.Price,
+			OrigQty:             data.Quantity,
+			ExecutedQty:         data.CumulativeFilledQty,
+			CummulativeQuoteQty: data.CumulativeQuoteQty,
+			Status:              data.Status,
+			TimeInForce:         data.TimeInForce,
+			Type:                data.Type,
+			Side:                data.Side,
+			StopPrice:           data.StopPrice,
+			Time:                data.CreationTime,
+			UpdateTime:          data.TransactionTime,
+		})
+		// 推送包含累计成交金额，可计算实际成交均价
+		if data.CumulativeFilledQty.IsPositive() {
+			order.Average = types.ExDecimal{Decimal: data.CumulativeQuoteQty.Div(data.CumulativeFilledQty.Decimal)}
+		}
+		return order, true
+	}
+}
+
+// parseBinanceWSAccountPosition 解析现货余额推送，只包含本次变化的币种
+func parseBinanceWSAccountPosition(msg []byte) (model.Balances, bool) {
+	var data binanceSpotWSAccountPosition
+	if err := json.Unmarshal(msg, &data); err != nil || data.EventType != binanceAccountPositionEvent {
+		return nil, false
+	}
+
+	balances := make(model.Balances, 0, len(data.Balances))
+	for _, bal := range data.Balances {
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.Free,
+			Locked:    bal.Locked,
+			Total:     types.ExDecimal{Decimal: bal.Free.Add(bal.Locked.Decimal)},
+			UpdatedAt: data.LastUpdateTime,
+		})
+	}
+	return balances, true
+}
+
+// parseBinancePerpWSOrderUpdate 返回解析合约订单更新推送的函数，每次订单状态变化推送一次
+func parseBinancePerpWSOrderUpdate(symbolOf symbolResolver) common.WSParser[*model.PerpOrder] {
+	return func(msg []byte) (*model.PerpOrder, bool) {
+		var data binancePerpWSOrderUpdate
+		if err := json.Unmarshal(msg, &data); err != nil || data.EventType != binancePerpOrderUpdateEvent {
+			return nil, false
+		}
+
+		o := data.Order
+		return toBinancePerpOrder(symbolOf(o.Symbol), &binancePerpFetchOrderResponse{
+			OrderID:       o.OrderID,
+			ClientOrderID: o.ClientOrderID,
+			Symbol:        o.Symbol,
+			Price:         o.Price,
+			AvgPrice:      o.AvgPrice,
+			OrigQty:       o.Quantity,
+			ExecutedQty:   o.FilledQty,
+			Status:        o.Status,
+			TimeInForce:   o.TimeInForce,
+			ReduceOnly:    o.ReduceOnly,
+			Type:          o.Type,
+			Side:          o.Side,
+			PositionSide:  o.PositionSide,
+			UpdateTime:    data.TransactionTime,
+		}), true
+	}
+}
+
+// parseBinancePerpWSAccountUpdate 解析合约余额推送，只包含本次变化的币种
+// 推送不含可用余额，以全仓钱包余额作为可用余额，钱包余额作为总余额
+func parseBinancePerpWSAccountUpdate(msg []byte) (model.Balances, bool) {
+	var data binancePerpWSAccountUpdate
+	if err := json.Unmarshal(msg, &data); err != nil || data.EventType != binancePerpAccountUpdateEvent {
+		return nil, false
+	}
+	if len(data.Account.Balances) == 0 {
+		return nil, false
+	}
+
+	balances := make(model.Balances, 0, len(data.Account.Balances))
+	for _, bal := range data.Account.Balances {
+		balances = append(balances, &model.Balance{
+			Currency:  bal.Asset,
+			Available: bal.CrossWalletBalance,
+			Locked:    types.ExDecimal{Decimal: bal.WalletBalance.Sub(bal.CrossWalletBalance.Decimal)},
+			Total:     bal.WalletBalance,
+			UpdatedAt: data.TransactionTime,
+		})
+	}
+	return balances, true
+}
+
+// binanceWSItems 将单条推送解析器包装为返回切片的解析器（TrackOrder 订阅订单推送时使用）
+func binanceWSItems[T any](parse common.WSParser[T]) common.WSParser[[]T] {
+	return func(msg []byte) ([]T, bool) {
+		v, ok := parse(msg)
+		if !ok {
+			return nil, false
+		}
+		return []T{v}, true
+	}
+}
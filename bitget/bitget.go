@@ -0,0 +1,333 @@
+package bitget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+// Bitget Bitget 交易所实现
+type Bitget struct {
+	client              *Client
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
+	spot                *BitgetSpot
+	perp                *BitgetPerp
+	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
+	spotMarketsByID     map[string]*model.Market // 现货市场信息（原始格式索引）
+	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
+	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
+	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+}
+
+// NewBitget 创建 Bitget 交易所实例，options["password"] 为创建 API Key 时设置的 passphrase
+func NewBitget(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+	passphrase := ""
+	if v, ok := options["password"].(string); ok {
+		passphrase = v
+	}
+
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
+	bitget := &Bitget{
+		client:              client,
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		perpMarketsBySymbol: make(map[string]*model.Market),
+		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		bitget.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(bitget.lifecycle)
+
+	bitget.UpdateCredentials(apiKey, secretKey, passphrase)
+
+	// 初始化现货和合约实现
+	bitget.spot = NewBitgetSpot(bitget)
+	bitget.perp = NewBitgetPerp(bitget)
+
+	if v, ok := options["timeSync"].(bool); ok && v {
+		bitget.clock.Start(common.TimeSyncInterval, bitget.FetchTime)
+	}
+
+	return bitget, nil
+}
+
+// Spot 返回现货交易接口
+func (b *Bitget) Spot() exchange.SpotExchange {
+	return b.spot
+}
+
+// Perp 返回永续合约交易接口（USDT 本位永续合约）
+func (b *Bitget) Perp() exchange.PerpExchange {
+	return b.perp
+}
+
+// Name 返回交易所名称
+func (b *Bitget) Name() string {
+	return bitgetName
+}
+
+// Has 返回 Bitget 支持的功能
+func (b *Bitget) Has() model.Capabilities {
+	return bitgetCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (b *Bitget) Symbols() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketSymbols(b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (b *Bitget) NormalizeSymbol(nativeID string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, b.spotMarketsByID, b.perpMarketsByID, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (b *Bitget) DenormalizeSymbol(symbol string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, b.spotMarketsBySymbol, b.perpMarketsBySymbol, nil)
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (b *Bitget) ExportMarkets() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarshalMarkets(bitgetName, b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (b *Bitget) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(bitgetName, data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		b.spotMarketsBySymbol, b.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		b.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		b.perpMarketsBySymbol, b.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		b.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后已建立的轮询订阅通道随之关闭
+func (b *Bitget) Drain(ctx context.Context) error {
+	b.clock.Stop()
+	return b.lifecycle.Drain(ctx, b.spot, b.perp)
+}
+
+// FetchTime 获取交易所服务器时间
+func (b *Bitget) FetchTime(ctx context.Context) (time.Time, error) {
+	var result bitgetTimeResponse
+	if err := b.publicGet(ctx, "/api/v2/public/time", nil, &result); err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+	return result.ServerTime.Time, nil
+}
+
+// FetchStatus 获取系统状态，Bitget 没有系统状态接口，服务器时间接口可访问时视为正常
+func (b *Bitget) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	if _, err := b.FetchTime(ctx); err != nil {
+		return common.UnreachableStatus(ctx, err)
+	}
+	return common.OKStatus(), nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (b *Bitget) Ping(ctx context.Context) error {
+	return common.Ping(ctx, b.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (b *Bitget) VerifyCredentials(ctx context.Context) error {
+	creds := b.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "" && creds.passphrase != "", b.spot.FetchBalance)
+}
+
+// bitgetCapabilities Bitget 支持的功能
+var bitgetCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported: true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:       true,
+		WatchPositions:  true, // 轮询持仓
+		FetchMarkPrice:  true,
+		FetchIndexPrice: true,
+		SetLeverage:     true,
+		SetLeverageSide: true,
+		SetMarginMode:   true,
+		SetPositionMode: true,
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey     string
+	secretKey  string
+	passphrase string
+	signer     *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// password 为 API Key 的 passphrase
+func (b *Bitget) UpdateCredentials(apiKey, secretKey, password string) {
+	b.creds.Store(&credentials{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		passphrase: password,
+		signer:     NewSigner(secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (b *Bitget) credentials() *credentials {
+	return b.creds.Load()
+}
+
+// publicGet 请求公共接口并解析 data
+func (b *Bitget) publicGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := b.client.HTTPClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	return parseBitgetResponse(resp, result)
+}
+
+// signAndRequest 签名并发送请求（Bitget v2 API），GET 签名包含查询字符串，POST 签名包含 JSON 请求体
+func (b *Bitget) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}, result interface{}) error {
+	creds := b.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	requestPath := path
+	if query := common.BuildQueryString(params); query != "" {
+		requestPath += "?" + query
+	}
+	bodyStr := ""
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		bodyStr = string(bodyBytes)
+	}
+
+	// 签名（使用同一个 timestamp 确保签名和请求头一致）
+	timestamp := b.clock.Timestamp()
+	headers := map[string]string{
+		"ACCESS-KEY":        creds.apiKey,
+		"ACCESS-SIGN":       creds.signer.SignRequest(timestamp, method, requestPath, bodyStr),
+		"ACCESS-TIMESTAMP":  strconv.FormatInt(timestamp, 10),
+		"ACCESS-PASSPHRASE": creds.passphrase,
+		"locale":            "en-US",
+	}
+
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
+	resp, err := b.client.HTTPClient.RequestWithHeaders(ctx, method, path, params, reqBody, headers)
+	if err != nil {
+		return err
+	}
+	return parseBitgetResponse(resp, result)
+}
+
+// fetchOHLCVs 获取K线（现货和合约共用），按开盘时间升序返回
+// 设置 since 时保留从 since 开始的 limit 根，否则保留最近的 limit 根；until 包含该时刻开盘的K线
+func (b *Bitget) fetchOHLCVs(ctx context.Context, path string, params map[string]interface{}, limit int, opts *option.ExchangeArgsOptions) (model.OHLCVs, error) {
+	if limit <= 0 || limit > bitgetOHLCVPageLimit {
+		limit = bitgetOHLCVPageLimit
+	}
+	params["limit"] = limit
+	since, hasSince := option.GetTime(opts.Since)
+	if hasSince {
+		params["startTime"] = since.UnixMilli()
+	}
+	until, hasUntil := option.GetTime(opts.Until)
+	if hasUntil {
+		params["endTime"] = until.UnixMilli()
+	}
+
+	var klines []bitgetKline
+	if err := b.publicGet(ctx, path, params, &klines); err != nil {
+		return nil, fmt.Errorf("fetch ohlcv: %w", err)
+	}
+
+	ohlcvs := make(model.OHLCVs, 0, len(klines))
+	for _, item := range klines {
+		if (hasSince && item.Time.Before(since)) || (hasUntil && item.Time.After(until)) {
+			continue
+		}
+		ohlcvs = append(ohlcvs, &model.OHLCV{
+			Timestamp: item.Time,
+			Open:      item.Open,
+			High:      item.High,
+			Low:       item.Low,
+			Close:     item.Close,
+			Volume:    item.Volume,
+		})
+	}
+	if len(ohlcvs) > limit {
+		if hasSince {
+			ohlcvs = ohlcvs[:limit]
+		} else {
+			ohlcvs = ohlcvs[len(ohlcvs)-limit:]
+		}
+	}
+	return ohlcvs, nil
+}
+
+// parseBitgetResponse 解析响应外层结构，code 不为 00000 时返回交易所错误
+func parseBitgetResponse(resp []byte, result interface{}) error {
+	var envelope bitgetResponse
+	if err := json.Unmarshal(resp, &envelope); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	if envelope.Code != bitgetSuccessCode {
+		return newBitgetError(envelope.Code, envelope.Msg)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("unmarshal data: %w", err)
+	}
+	return nil
+}
+
+var _ exchange.Exchange = (*Bitget)(nil)
@@ -0,0 +1,713 @@
+package bitget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// bitgetMarginCoin USDT 本位合约的保证金币种
+const bitgetMarginCoin = "USDT"
+
+// BitgetPerp Bitget 永续合约实现（USDT 本位，productType 为 USDT-FUTURES）
+type BitgetPerp struct {
+	bitget       *Bitget
+	positionMode common.PositionMode // 账户持仓模式（SetPositionMode 设置后缓存）
+}
+
+// NewBitgetPerp 创建 Bitget 永续合约实例
+func NewBitgetPerp(b *Bitget) *BitgetPerp {
+	return &BitgetPerp{bitget: b}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载 USDT 本位永续合约市场（/api/v2/mix/market/contracts），跳过交割合约
+func (p *BitgetPerp) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	p.bitget.mu.RLock()
+	if !reload && len(p.bitget.perpMarketsBySymbol) > 0 && !p.bitget.marketCache.Expired(model.MarketTypeSwap) {
+		p.bitget.mu.RUnlock()
+		return nil
+	}
+	p.bitget.mu.RUnlock()
+
+	var data []bitgetContract
+	params := map[string]interface{}{"productType": bitgetProductTypeUSDTFutures}
+	if err := p.bitget.publicGet(ctx, "/api/v2/mix/market/contracts", params, &data); err != nil {
+		return fmt.Errorf("fetch swap markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data))
+	for _, item := range data {
+		if item.SymbolType != "perpetual" {
+			continue
+		}
+
+		// 转换为标准化格式 BTC/USDT:USDT
+		market := &model.Market{
+			ID:       item.Symbol,
+			Symbol:   common.NormalizeContractSymbol(item.BaseCoin, item.QuoteCoin, bitgetMarginCoin),
+			Base:     common.NormalizeCurrency(item.BaseCoin),
+			Quote:    common.NormalizeCurrency(item.QuoteCoin),
+			Settle:   bitgetMarginCoin,
+			Type:     model.MarketTypeSwap,
+			Active:   item.SymbolStatus == "normal",
+			Contract: true,
+			Linear:   true,
+		}
+
+		market.Precision.Amount = item.VolumePlace
+		market.Precision.Price = item.PricePlace
+		market.Precision.StepSize = item.SizeMultiplier
+		market.Precision.TickSize = types.ExDecimal{Decimal: decimal.New(item.PriceEndStep, -int32(item.PricePlace))}
+		market.Limits.Amount.Min = item.MinTradeNum
+		market.Limits.Cost.Min = item.MinTradeUSDT
+		market.Limits.Leverage.Min = item.MinLever
+		market.Limits.Leverage.Max = item.MaxLever
+
+		markets = append(markets, market)
+	}
+
+	p.bitget.mu.Lock()
+	p.bitget.perpMarketsBySymbol, p.bitget.perpMarketsByID = common.IndexMarkets(markets)
+	p.bitget.marketCache.Touch(model.MarketTypeSwap)
+	p.bitget.mu.Unlock()
+
+	return nil
+}
+
+func (p *BitgetPerp) FetchMarkets(ctx context.Context, opts ...option.ArgsOption) (model.Markets, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 确保市场已加载
+	if err := p.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		market, err := p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		return model.Markets{market}, nil
+	}
+
+	p.bitget.mu.RLock()
+	defer p.bitget.mu.RUnlock()
+
+	markets := make(model.Markets, 0, len(p.bitget.perpMarketsBySymbol))
+	for _, market := range p.bitget.perpMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (p *BitgetPerp) GetMarket(symbol string) (*model.Market, error) {
+	p.bitget.mu.RLock()
+	defer p.bitget.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := p.bitget.perpMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := p.bitget.perpMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, p.bitget.perpMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *BitgetPerp) GetMarketByID(id string) (*model.Market, error) {
+	p.bitget.mu.RLock()
+	defer p.bitget.mu.RUnlock()
+
+	if market, ok := p.bitget.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.bitget.perpMarketsByID)
+}
+
+func (p *BitgetPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (p *BitgetPerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+func (p *BitgetPerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+func (p *BitgetPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
+}
+
+// FetchTicker 获取行情（/api/v2/mix/market/ticker），包含标记价格和指数价格
+func (p *BitgetPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []bitgetPerpTicker
+	params := map[string]interface{}{"symbol": market.ID, "productType": bitgetProductType(market)}
+	if err := p.bitget.publicGet(ctx, "/api/v2/mix/market/ticker", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("ticker not found")
+	}
+	return data[0].toTicker(market.Symbol), nil
+}
+
+// FetchTickers 获取 USDT 本位合约行情（/api/v2/mix/market/tickers），设置 option.WithSymbol 时只返回该合约，跳过未加载的合约
+func (p *BitgetPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (model.Tickers, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		ticker, err := p.FetchTicker(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return model.Tickers{ticker}, nil
+	}
+
+	var data []bitgetPerpTicker
+	params := map[string]interface{}{"productType": bitgetProductTypeUSDTFutures}
+	if err := p.bitget.publicGet(ctx, "/api/v2/mix/market/tickers", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(model.Tickers, 0, len(data))
+	for _, item := range data {
+		market, err := p.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+		tickers = append(tickers, item.toTicker(market.Symbol))
+	}
+
+	return tickers, nil
+}
+
+// FetchOrderBook 获取订单簿深度（/api/v2/mix/market/merge-depth），档位为 1/5/15/50/max，取不小于 limit 的最小档位
+func (p *BitgetPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{"symbol": market.ID, "productType": bitgetProductType(market)}
+	if limit > 0 {
+		params["limit"] = "max"
+		for _, depth := range []int{1, 5, 15, 50} {
+			if limit <= depth {
+				params["limit"] = strconv.Itoa(depth)
+				break
+			}
+		}
+	}
+
+	var data bitgetOrderBook
+	if err := p.bitget.publicGet(ctx, "/api/v2/mix/market/merge-depth", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+	return data.toOrderBook(market.Symbol, limit), nil
+}
+
+func (p *BitgetPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (p *BitgetPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/api/v2/mix/market/candles），按开盘时间升序返回，单次最多 1000 根
+func (p *BitgetPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	granularity, err := common.CheckTimeframe(timeframe, bitgetPerpTimeframe(timeframe), bitgetPerpTimeframes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol":      market.ID,
+		"productType": bitgetProductType(market),
+		"granularity": granularity,
+	}
+	ohlcvs, err := p.bitget.fetchOHLCVs(ctx, "/api/v2/mix/market/candles", params, limit, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, p.bitget.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (p *BitgetPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, bitgetOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, limit, option.WithSince(start), option.WithUntil(end))
+	})
+}
+
+func (p *BitgetPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (p *BitgetPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.bitget.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, 2)
+	})
+}
+
+func (p *BitgetPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+func (p *BitgetPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	return nil, notSupported("fetch funding rate")
+}
+
+func (p *BitgetPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	return nil, notSupported("fetch funding rate history")
+}
+
+func (p *BitgetPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	return nil, notSupported("fetch open interest")
+}
+
+// FetchMarkPrice 获取标记价格，合约行情接口同时返回标记价格和指数价格
+func (p *BitgetPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return p.FetchTicker(ctx, symbol)
+}
+
+// FetchIndexPrice 获取指数价格（取自合约行情）
+func (p *BitgetPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.FetchTicker(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
+// ========== 账户信息 ==========
+
+// FetchPositions 获取 USDT 本位合约持仓（/api/v2/mix/position/all-position），设置 option.WithSymbol 时查询单个合约（single-position）
+func (p *BitgetPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	path := "/api/v2/mix/position/all-position"
+	params := map[string]interface{}{
+		"productType": bitgetProductTypeUSDTFutures,
+		"marginCoin":  bitgetMarginCoin,
+	}
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		market, err := p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		path = "/api/v2/mix/position/single-position"
+		params["symbol"] = market.ID
+		params["productType"] = bitgetProductType(market)
+	}
+
+	var data []bitgetPosition
+	if err := p.bitget.signAndRequest(ctx, http.MethodGet, path, params, nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch positions: %w", err)
+	}
+
+	positions := make(model.Positions, 0, len(data))
+	for _, item := range data {
+		if item.Total.IsZero() {
+			continue
+		}
+		market, err := p.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+
+		side := string(types.PositionSideLong)
+		if item.HoldSide == "short" {
+			side = string(types.PositionSideShort)
+		}
+		marginMode := model.MarginModeCross
+		if item.MarginMode == "isolated" {
+			marginMode = model.MarginModeIsolated
+		}
+
+		positions = append(positions, &model.Position{
+			Symbol:                market.Symbol,
+			Side:                  side,
+			Amount:                item.Total,
+			EntryPrice:            item.OpenPriceAvg,
+			MarkPrice:             item.MarkPrice,
+			LiquidationPrice:      item.LiquidationPrice,
+			UnrealizedPnl:         item.UnrealizedPL,
+			RealizedPnl:           item.AchievedProfits,
+			Leverage:              item.Leverage,
+			Margin:                item.MarginSize,
+			MarginMode:            marginMode,
+			MaintenanceMarginRate: item.KeepMarginRate,
+			Timestamp:             item.UTime,
+		})
+	}
+
+	return positions, nil
+}
+
+func (p *BitgetPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.bitget.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollPositions(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	})
+}
+
+func (p *BitgetPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/api/v2/mix/order/place-order），数量为基础货币数量
+// 未设置 option.WithMarginType 时为全仓；双向持仓时 side 为持仓方向，tradeSide 区分开平仓，单向持仓时平仓单带 reduceOnly；不支持条件单和跟踪止损
+func (p *BitgetPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+	if option.StringPresent(argsOpts.TrailingCallbackRate) {
+		return nil, notSupported("trailing stop order")
+	}
+
+	isLimit := orderType == option.Limit
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	marginMode := "crossed"
+	if argsOpts.MarginType != nil && *argsOpts.MarginType == option.ISOLATED {
+		marginMode = "isolated"
+	}
+	clientOid := common.GenerateClientOrderID(bitgetName, orderSide.ToSide())
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		clientOid = *argsOpts.ClientOrderID
+	}
+	body := map[string]interface{}{
+		"symbol":      market.ID,
+		"productType": bitgetProductType(market),
+		"marginMode":  marginMode,
+		"marginCoin":  bitgetMarginCoin,
+		"size":        size,
+		"orderType":   "market",
+		"clientOid":   clientOid,
+	}
+	if isLimit {
+		price, ok := option.GetDecimalFromString(argsOpts.Price)
+		if !ok || price.IsZero() {
+			return nil, fmt.Errorf("limit order requires price")
+		}
+		priceStr, err := common.PriceToPrecision(market, price.String())
+		if err != nil {
+			return nil, err
+		}
+		body["orderType"] = "limit"
+		body["price"] = priceStr
+		body["force"] = bitgetForce(postOnly, argsOpts.TimeInForce)
+	}
+
+	if p.positionMode.Hedged(argsOpts.HedgeMode) {
+		// 双向持仓：开多/平多 side=buy，开空/平空 side=sell
+		body["side"] = "sell"
+		if orderSide.ToPositionSide() == "LONG" {
+			body["side"] = "buy"
+		}
+		body["tradeSide"] = "open"
+		if orderSide.ToReduceOnly() {
+			body["tradeSide"] = "close"
+		}
+	} else {
+		body["side"] = strings.ToLower(orderSide.ToSide())
+		if common.ReduceOnly(orderSide, argsOpts) {
+			body["reduceOnly"] = "YES"
+		}
+	}
+
+	var result bitgetOrderResponse
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/order/place-order", nil, body, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	order := &model.NewOrder{
+		OrderId:       result.OrderID,
+		ClientOrderID: clientOid,
+		Symbol:        symbol,
+		Timestamp:     types.ExTimestamp{Time: time.Now()},
+	}
+	p.bitget.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (p *BitgetPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.PerpOrderRequest) (*model.NewOrder, error) {
+		return p.CreateOrder(ctx, r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+	})
+}
+
+// ClosePosition 以只减仓市价单平掉持仓，未指定 option.WithMarginType 时使用持仓的保证金模式
+func (p *BitgetPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	position, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if argsOpts.MarginType == nil {
+		marginType := option.CROSSED
+		if position.MarginMode == model.MarginModeIsolated {
+			marginType = option.ISOLATED
+		}
+		opts = append(opts[:len(opts):len(opts)], option.WithMarginType(marginType))
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
+}
+
+// CancelOrder 撤销订单（/api/v2/mix/order/cancel-order），orderId 为空时按 option.WithClientOrderID 撤销
+func (p *BitgetPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	body, err := bitgetCancelBody(market, orderId, argsOpts)
+	if err != nil {
+		return err
+	}
+	body["marginCoin"] = bitgetMarginCoin
+
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/order/cancel-order", nil, body, nil); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	p.bitget.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+func (p *BitgetPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+func (p *BitgetPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return nil, notSupported("fetch order")
+}
+
+func (p *BitgetPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return nil, notSupported("fetch order by client id")
+}
+
+func (p *BitgetPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (p *BitgetPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (p *BitgetPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 合约特有功能 ==========
+
+// SetLeverage 设置杠杆（/api/v2/mix/account/set-leverage），多空使用相同杠杆
+func (p *BitgetPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"symbol":      market.ID,
+		"productType": bitgetProductType(market),
+		"marginCoin":  bitgetMarginCoin,
+		"leverage":    strconv.Itoa(leverage),
+	}
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/account/set-leverage", nil, body, nil); err != nil {
+		return fmt.Errorf("set leverage: %w", err)
+	}
+	return nil
+}
+
+// SetLeverageSide 设置多头或空头杠杆（holdSide），仅逐仓双向持仓模式下两个方向可以不同
+func (p *BitgetPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	if err := common.CheckPositionSide(side); err != nil {
+		return err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"symbol":      market.ID,
+		"productType": bitgetProductType(market),
+		"marginCoin":  bitgetMarginCoin,
+		"leverage":    strconv.Itoa(leverage),
+		"holdSide":    side.Lower(),
+	}
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/account/set-leverage", nil, body, nil); err != nil {
+		return fmt.Errorf("set leverage: %w", err)
+	}
+	return nil
+}
+
+// SetMarginType 设置保证金模式（/api/v2/mix/account/set-margin-mode），有持仓或挂单时交易所会拒绝
+func (p *BitgetPerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+
+	var marginMode string
+	switch marginType {
+	case option.ISOLATED:
+		marginMode = "isolated"
+	case option.CROSSED:
+		marginMode = "crossed"
+	default:
+		return fmt.Errorf("margin type not supported")
+	}
+
+	body := map[string]interface{}{
+		"symbol":      market.ID,
+		"productType": bitgetProductType(market),
+		"marginCoin":  bitgetMarginCoin,
+		"marginMode":  marginMode,
+	}
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/account/set-margin-mode", nil, body, nil); err != nil {
+		return fmt.Errorf("set margin type: %w", err)
+	}
+	return nil
+}
+
+// SetPositionMode 设置 USDT 本位合约持仓模式（/api/v2/mix/account/set-position-mode，hedge_mode 为双向持仓，one_way_mode 为单向持仓）
+func (p *BitgetPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	posMode := "one_way_mode"
+	if hedged {
+		posMode = "hedge_mode"
+	}
+	body := map[string]interface{}{
+		"productType": bitgetProductTypeUSDTFutures,
+		"posMode":     posMode,
+	}
+	if err := p.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/mix/account/set-position-mode", nil, body, nil); err != nil {
+		return fmt.Errorf("set position mode: %w", err)
+	}
+
+	p.positionMode.Set(hedged)
+	return nil
+}
+
+// GetPositionMode 返回 SetPositionMode 设置的持仓模式，未设置时返回 common.ErrNotSupported
+func (p *BitgetPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	if hedged, ok := p.positionMode.Get(); ok {
+		return hedged, nil
+	}
+	return false, notSupported("get position mode")
+}
+
+var _ exchange.PerpExchange = (*BitgetPerp)(nil)
@@ -0,0 +1,526 @@
+package bitget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// BitgetSpot Bitget 现货实现
+type BitgetSpot struct {
+	bitget *Bitget
+}
+
+// NewBitgetSpot 创建 Bitget 现货实例
+func NewBitgetSpot(b *Bitget) *BitgetSpot {
+	return &BitgetSpot{bitget: b}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载现货市场（/api/v2/spot/public/symbols）
+func (s *BitgetSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	s.bitget.mu.RLock()
+	if !reload && len(s.bitget.spotMarketsBySymbol) > 0 && !s.bitget.marketCache.Expired(model.MarketTypeSpot) {
+		s.bitget.mu.RUnlock()
+		return nil
+	}
+	s.bitget.mu.RUnlock()
+
+	var data []bitgetSpotSymbol
+	if err := s.bitget.publicGet(ctx, "/api/v2/spot/public/symbols", nil, &data); err != nil {
+		return fmt.Errorf("fetch spot markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data))
+	for _, item := range data {
+		symbol := common.NormalizeSymbol(item.BaseCoin, item.QuoteCoin)
+		base, quote, _ := strings.Cut(symbol, "/")
+		market := &model.Market{
+			ID:     item.Symbol, // Bitget 原始格式 (BTCUSDT)
+			Symbol: symbol,      // 标准化格式 (BTC/USDT)
+			Base:   base,
+			Quote:  quote,
+			Type:   model.MarketTypeSpot,
+			Active: item.Status == "online",
+		}
+
+		market.Precision.Amount = item.QuantityPrecision
+		market.Precision.Price = item.PricePrecision
+		market.Precision.StepSize = types.ExDecimal{Decimal: decimal.New(1, -int32(item.QuantityPrecision))}
+		market.Precision.TickSize = types.ExDecimal{Decimal: decimal.New(1, -int32(item.PricePrecision))}
+		market.Limits.Amount.Min = item.MinTradeAmount
+		market.Limits.Amount.Max = item.MaxTradeAmount
+		market.Limits.Cost.Min = item.MinTradeUSDT
+
+		markets = append(markets, market)
+	}
+
+	s.bitget.mu.Lock()
+	s.bitget.spotMarketsBySymbol, s.bitget.spotMarketsByID = common.IndexMarkets(markets)
+	s.bitget.marketCache.Touch(model.MarketTypeSpot)
+	s.bitget.mu.Unlock()
+
+	return nil
+}
+
+// FetchMarkets 获取现货市场列表
+func (s *BitgetSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	// 确保市场已加载
+	if err := s.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.GetMarkets()
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (s *BitgetSpot) GetMarket(symbol string) (*model.Market, error) {
+	s.bitget.mu.RLock()
+	defer s.bitget.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := s.bitget.spotMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := s.bitget.spotMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, s.bitget.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *BitgetSpot) GetMarketByID(id string) (*model.Market, error) {
+	s.bitget.mu.RLock()
+	defer s.bitget.mu.RUnlock()
+
+	if market, ok := s.bitget.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, s.bitget.spotMarketsByID)
+}
+
+// GetMarkets 从内存中获取所有现货市场信息
+func (s *BitgetSpot) GetMarkets() ([]*model.Market, error) {
+	s.bitget.mu.RLock()
+	defer s.bitget.mu.RUnlock()
+
+	markets := make([]*model.Market, 0, len(s.bitget.spotMarketsBySymbol))
+	for _, market := range s.bitget.spotMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+func (s *BitgetSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *BitgetSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *BitgetSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取行情（/api/v1/market/orderbook/level1），该接口只返回最优挂单和最新成交，24小时统计字段为 0
+// FetchTicker 获取行情（/api/v2/spot/market/tickers）
+func (s *BitgetSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []bitgetSpotTicker
+	if err := s.bitget.publicGet(ctx, "/api/v2/spot/market/tickers", map[string]interface{}{"symbol": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("ticker not found")
+	}
+	return data[0].toTicker(market.Symbol), nil
+}
+
+// FetchTickers 获取全部现货行情，跳过未加载的交易对
+func (s *BitgetSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	var data []bitgetSpotTicker
+	if err := s.bitget.publicGet(ctx, "/api/v2/spot/market/tickers", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(map[string]*model.Ticker, len(data))
+	for _, item := range data {
+		market, err := s.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+		tickers[market.Symbol] = item.toTicker(market.Symbol)
+	}
+
+	return tickers, nil
+}
+
+func (s *BitgetSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取订单簿深度（/api/v2/spot/market/orderbook），Bitget 不返回更新ID，Nonce 为 0
+func (s *BitgetSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{"symbol": market.ID, "type": "step0"}
+	if limit = common.ClampOrderBookLimit(limit, bitgetMaxDepthLimit); limit > 0 {
+		params["limit"] = limit
+	}
+
+	var data bitgetOrderBook
+	if err := s.bitget.publicGet(ctx, "/api/v2/spot/market/orderbook", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+	return data.toOrderBook(market.Symbol, limit), nil
+}
+
+func (s *BitgetSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (s *BitgetSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/api/v1/market/candles），Bitget 按开盘时间倒序返回，最多 1500 根，结果转换为升序
+// 设置 since 时保留从 since 开始的 limit 根，否则保留最近的 limit 根
+// FetchOHLCVs 获取K线（/api/v2/spot/market/candles），按开盘时间升序返回，单次最多 1000 根
+func (s *BitgetSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	granularity, err := common.CheckTimeframe(timeframe, bitgetSpotTimeframe(timeframe), bitgetSpotTimeframes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol":      market.ID,
+		"granularity": granularity,
+	}
+	limit := 0
+	if argsOpts.Limit != nil {
+		limit = *argsOpts.Limit
+	}
+	ohlcvs, err := s.bitget.fetchOHLCVs(ctx, "/api/v2/spot/market/candles", params, limit, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.bitget.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *BitgetSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, bitgetOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *BitgetSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (s *BitgetSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.bitget.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *BitgetSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// FetchBalance 获取余额，默认现货账户（/api/v2/spot/account/assets），option.AccountFutures 为 USDT 本位合约账户（/api/v2/mix/account/accounts）
+// 现货 Locked 为挂单冻结和其他业务锁定之和；合约 Total 为账户权益，Locked 为 Total - Available
+func (s *BitgetSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	var balances model.Balances
+	switch accountType := option.GetAccountType(argsOpts.AccountType); accountType {
+	case option.AccountSpot:
+		var data []bitgetSpotAsset
+		if err := s.bitget.signAndRequest(ctx, http.MethodGet, "/api/v2/spot/account/assets", nil, nil, &data); err != nil {
+			return nil, fmt.Errorf("fetch balance: %w", err)
+		}
+		for _, item := range data {
+			locked := item.Frozen.Add(item.Locked.Decimal)
+			balances = append(balances, &model.Balance{
+				Currency:  common.NormalizeCurrency(item.Coin),
+				Available: item.Available,
+				Locked:    types.ExDecimal{Decimal: locked},
+				Total:     types.ExDecimal{Decimal: item.Available.Add(locked)},
+				UpdatedAt: item.UTime,
+			})
+		}
+	case option.AccountFutures:
+		var data []bitgetPerpAccount
+		params := map[string]interface{}{"productType": bitgetProductTypeUSDTFutures}
+		if err := s.bitget.signAndRequest(ctx, http.MethodGet, "/api/v2/mix/account/accounts", params, nil, &data); err != nil {
+			return nil, fmt.Errorf("fetch balance: %w", err)
+		}
+		for _, item := range data {
+			balances = append(balances, &model.Balance{
+				Currency:  common.NormalizeCurrency(item.MarginCoin),
+				Available: item.Available,
+				Locked:    types.ExDecimal{Decimal: item.AccountEquity.Sub(item.Available.Decimal)},
+				Total:     item.AccountEquity,
+				UpdatedAt: types.ExTimestamp{Time: time.Now()}, // Bitget 合约账户接口没有返回更新时间
+			})
+		}
+	default:
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+func (s *BitgetSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/api/v2/spot/trade/place-order），设置 WithPrice 时为限价单，否则为市价单
+// Bitget 市价买单的 size 为计价货币金额，按最新价换算；未设置 option.WithClientOrderID 时自动生成 clientOid；不支持条件单
+func (s *BitgetSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+
+	isLimit := option.StringPresent(argsOpts.Price)
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	sideStr := strings.ToLower(string(side))
+	clientOid := common.GenerateClientOrderID(bitgetName, sideStr)
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		clientOid = *argsOpts.ClientOrderID
+	}
+	body := map[string]interface{}{
+		"clientOid": clientOid,
+		"symbol":    market.ID,
+		"side":      sideStr,
+		"orderType": "market",
+		"force":     bitgetForce(postOnly, argsOpts.TimeInForce),
+		"size":      size,
+	}
+	if isLimit {
+		price, err := common.PriceToPrecision(market, *argsOpts.Price)
+		if err != nil {
+			return nil, err
+		}
+		body["orderType"] = "limit"
+		body["price"] = price
+	} else if side == option.Buy {
+		// 市价买单：按最新价换算为计价货币金额
+		ticker, err := s.FetchTicker(ctx, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("fetch ticker for market buy: %w", err)
+		}
+		if ticker.Last.IsZero() {
+			return nil, fmt.Errorf("invalid ticker price")
+		}
+		quantity, _ := decimal.NewFromString(size)
+		body["size"] = quantity.Mul(ticker.Last.Decimal).Truncate(int32(market.Precision.Price)).String()
+	}
+
+	var result bitgetOrderResponse
+	if err := s.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/spot/trade/place-order", nil, body, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	order := &model.NewOrder{
+		OrderId:       result.OrderID,
+		ClientOrderID: clientOid,
+		Symbol:        symbol,
+		Timestamp:     types.ExTimestamp{Time: time.Now()},
+	}
+	s.bitget.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *BitgetSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *BitgetSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// CancelOrder 撤销订单（/api/v2/spot/trade/cancel-order），orderId 为空时按 option.WithClientOrderID 撤销
+func (s *BitgetSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	body, err := bitgetCancelBody(market, orderId, argsOpts)
+	if err != nil {
+		return err
+	}
+
+	if err := s.bitget.signAndRequest(ctx, http.MethodPost, "/api/v2/spot/trade/cancel-order", nil, body, nil); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	s.bitget.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *BitgetSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+func (s *BitgetSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order")
+}
+
+func (s *BitgetSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order by client id")
+}
+
+func (s *BitgetSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (s *BitgetSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (s *BitgetSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+func (s *BitgetSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+func (s *BitgetSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *BitgetSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *BitgetSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+func (s *BitgetSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+func (s *BitgetSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+func (s *BitgetSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+func (s *BitgetSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+func (s *BitgetSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+func (s *BitgetSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+func (s *BitgetSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+var _ exchange.SpotExchange = (*BitgetSpot)(nil)
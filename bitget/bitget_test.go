@@ -0,0 +1,382 @@
+package bitget
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+const testSpotSymbols = `{"code":"00000","msg":"success","requestTime":1700000000000,"data":[
+	{"symbol":"BTCUSDT","baseCoin":"BTC","quoteCoin":"USDT","minTradeAmount":"0","maxTradeAmount":"10000000000",
+		"pricePrecision":"2","quantityPrecision":"6","minTradeUSDT":"1","status":"online"},
+	{"symbol":"ETHUSDT","baseCoin":"ETH","quoteCoin":"USDT","minTradeAmount":"0","maxTradeAmount":"10000000000",
+		"pricePrecision":"2","quantityPrecision":"4","minTradeUSDT":"1","status":"halt"}
+]}`
+
+const testContracts = `{"code":"00000","msg":"success","requestTime":1700000000000,"data":[
+	{"symbol":"BTCUSDT","baseCoin":"BTC","quoteCoin":"USDT","minTradeNum":"0.001","priceEndStep":"1","pricePlace":"1",
+		"volumePlace":"3","sizeMultiplier":"0.001","minTradeUSDT":"5","symbolType":"perpetual","symbolStatus":"normal"},
+	{"symbol":"BTCUSDT_241227","baseCoin":"BTC","quoteCoin":"USDT","minTradeNum":"0.001","priceEndStep":"1","pricePlace":"1",
+		"volumePlace":"3","sizeMultiplier":"0.001","minTradeUSDT":"5","symbolType":"delivery","symbolStatus":"normal"}
+]}`
+
+// newTestBitget 创建请求指向 handler 的 Bitget 实例，并加载现货和合约市场
+func newTestBitget(t *testing.T, handler http.HandlerFunc) *Bitget {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/spot/public/symbols":
+			w.Write([]byte(testSpotSymbols))
+		case "/api/v2/mix/market/contracts":
+			if r.URL.Query().Get("productType") != "USDT-FUTURES" {
+				t.Errorf("contracts productType = %s", r.URL.Query().Get("productType"))
+			}
+			w.Write([]byte(testContracts))
+		default:
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ex, err := NewBitget("key", testSecret, map[string]interface{}{"baseURL": srv.URL, "password": "my-passphrase"})
+	if err != nil {
+		t.Fatalf("NewBitget: %v", err)
+	}
+	b := ex.(*Bitget)
+	if err := b.Spot().LoadMarkets(context.Background(), false); err != nil {
+		t.Fatalf("spot LoadMarkets: %v", err)
+	}
+	if err := b.Perp().LoadMarkets(context.Background(), false); err != nil {
+		t.Fatalf("perp LoadMarkets: %v", err)
+	}
+	return b
+}
+
+// checkSignature 校验签名覆盖实际发送的路径、查询字符串和请求体
+func checkSignature(t *testing.T, r *http.Request, body []byte) {
+	t.Helper()
+	ts, err := strconv.ParseInt(r.Header.Get("ACCESS-TIMESTAMP"), 10, 64)
+	if err != nil {
+		t.Fatalf("ACCESS-TIMESTAMP = %q", r.Header.Get("ACCESS-TIMESTAMP"))
+	}
+	requestPath := r.URL.Path
+	if r.URL.RawQuery != "" {
+		requestPath += "?" + r.URL.RawQuery
+	}
+	if want := NewSigner(testSecret).SignRequest(ts, r.Method, requestPath, string(body)); r.Header.Get("ACCESS-SIGN") != want {
+		t.Errorf("ACCESS-SIGN = %q, want %q", r.Header.Get("ACCESS-SIGN"), want)
+	}
+	if r.Header.Get("ACCESS-KEY") != "key" || r.Header.Get("ACCESS-PASSPHRASE") != "my-passphrase" {
+		t.Errorf("headers = %v", r.Header)
+	}
+}
+
+func TestBitget_LoadMarkets(t *testing.T) {
+	b := newTestBitget(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	spot, err := b.Spot().GetMarket("BTC/USDT")
+	if err != nil {
+		t.Fatalf("spot GetMarket: %v", err)
+	}
+	if spot.ID != "BTCUSDT" || spot.Type != model.MarketTypeSpot || !spot.Active ||
+		!spot.Precision.StepSize.Equal(decimal.RequireFromString("0.000001")) || !spot.Precision.TickSize.Equal(decimal.RequireFromString("0.01")) {
+		t.Errorf("spot market = %+v", spot)
+	}
+	if eth, err := b.Spot().GetMarket("ETH/USDT"); err != nil || eth.Active {
+		t.Errorf("halted spot market = %+v, %v", eth, err)
+	}
+
+	perp, err := b.Perp().GetMarket("BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("perp GetMarket: %v", err)
+	}
+	if perp.ID != "BTCUSDT" || perp.Type != model.MarketTypeSwap || !perp.Linear || perp.Settle != "USDT" ||
+		!perp.Precision.StepSize.Equal(decimal.RequireFromString("0.001")) || !perp.Precision.TickSize.Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("perp market = %+v", perp)
+	}
+	if byID, err := b.Perp().GetMarketByID("BTCUSDT"); err != nil || byID != perp {
+		t.Errorf("perp GetMarketByID = %v, %v", byID, err)
+	}
+	if markets, _ := b.Perp().FetchMarkets(context.Background()); len(markets) != 1 {
+		t.Errorf("got %d perp markets, want 1 (delivery contract skipped)", len(markets))
+	}
+
+	if got := bitgetProductType(spot); got != "SPOT" {
+		t.Errorf("spot productType = %s", got)
+	}
+	if got := bitgetProductType(perp); got != "USDT-FUTURES" {
+		t.Errorf("perp productType = %s", got)
+	}
+}
+
+func TestBitget_SymbolRouting(t *testing.T) {
+	b := newTestBitget(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/v2/spot/market/tickers":
+			if q.Get("symbol") != "BTCUSDT" || q.Get("productType") != "" {
+				t.Errorf("spot ticker query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[{"symbol":"BTCUSDT","lastPr":"37000.5","bidPr":"37000.4","askPr":"37000.6",
+				"high24h":"37500","low24h":"36000","open":"36500","baseVolume":"1000","quoteVolume":"37000000","ts":"1700000000000"}]}`))
+		case "/api/v2/mix/market/ticker":
+			if q.Get("symbol") != "BTCUSDT" || q.Get("productType") != "USDT-FUTURES" {
+				t.Errorf("perp ticker query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[{"symbol":"BTCUSDT","lastPr":"37010","bidPr":"37009.9","askPr":"37010.1",
+				"markPrice":"37011","indexPrice":"37005","ts":"1700000000000"}]}`))
+		case "/api/v2/mix/market/candles":
+			if q.Get("productType") != "USDT-FUTURES" || q.Get("granularity") != "1H" || q.Get("startTime") != "1699999200000" {
+				t.Errorf("perp candles query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[
+				["1699999200000","37000","37100","36990","37050","10","370000"],
+				["1700002800000","37050","37200","37000","37150","12","444000"]
+			]}`))
+		case "/api/v2/spot/market/candles":
+			if q.Get("granularity") != "1day" || q.Get("productType") != "" {
+				t.Errorf("spot candles query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[["1699920000000","36000","37500","35900","37000","100","3650000"]]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	spotTicker, err := b.Spot().FetchTicker(ctx, "BTC/USDT")
+	if err != nil {
+		t.Fatalf("spot FetchTicker: %v", err)
+	}
+	if spotTicker.Symbol != "BTC/USDT" || spotTicker.Last.String() != "37000.5" || spotTicker.Volume.String() != "1000" {
+		t.Errorf("spot ticker = %+v", spotTicker)
+	}
+
+	perpTicker, err := b.Perp().FetchTicker(ctx, "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("perp FetchTicker: %v", err)
+	}
+	if perpTicker.Symbol != "BTC/USDT:USDT" || perpTicker.Last.String() != "37010" || perpTicker.MarkPrice.String() != "37011" {
+		t.Errorf("perp ticker = %+v", perpTicker)
+	}
+	if index, err := b.Perp().FetchIndexPrice(ctx, "BTC/USDT:USDT"); err != nil || index.String() != "37005" {
+		t.Errorf("FetchIndexPrice = %s, %v", index, err)
+	}
+
+	ohlcvs, err := b.Perp().FetchOHLCVs(ctx, "BTC/USDT:USDT", "1h", 1, option.WithSince(time.UnixMilli(1699999200000)))
+	if err != nil {
+		t.Fatalf("perp FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Timestamp.UnixMilli() != 1699999200000 || ohlcvs[0].High.String() != "37100" || ohlcvs[0].Close.String() != "37050" {
+		t.Errorf("perp ohlcvs = %v", ohlcvs)
+	}
+	if _, err := b.Spot().FetchOHLCVs(ctx, "BTC/USDT", "1d"); err != nil {
+		t.Fatalf("spot FetchOHLCVs: %v", err)
+	}
+	if _, err := b.Spot().FetchOHLCVs(ctx, "BTC/USDT", "2h"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("unsupported spot timeframe: err = %v, want ErrNotSupported", err)
+	}
+
+	if _, err := b.Perp().FetchTicker(ctx, "BTC/USDT"); err == nil {
+		t.Error("perp FetchTicker with spot symbol: want error")
+	}
+}
+
+func TestBitgetPerp_Orders(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestBitget(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		checkSignature(t, r, raw)
+		body = nil
+		json.Unmarshal(raw, &body)
+
+		switch r.URL.Path {
+		case "/api/v2/mix/order/place-order":
+			if body["size"] == "1000" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":"40762","msg":"The order amount exceeds the balance","requestTime":1700000000000}`))
+				return
+			}
+			w.Write([]byte(`{"code":"00000","data":{"orderId":"1001","clientOid":"` + body["clientOid"].(string) + `"}}`))
+		case "/api/v2/mix/order/cancel-order":
+			w.Write([]byte(`{"code":"00000","data":{"orderId":"1001","clientOid":"my-order-1"}}`))
+		case "/api/v2/mix/account/set-leverage", "/api/v2/mix/account/set-position-mode":
+			w.Write([]byte(`{"code":"00000","data":{}}`))
+		case "/api/v2/mix/position/single-position":
+			if r.URL.Query().Get("symbol") != "BTCUSDT" || r.URL.Query().Get("productType") != "USDT-FUTURES" {
+				t.Errorf("position query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[{"symbol":"BTCUSDT","marginCoin":"USDT","holdSide":"short","total":"0.02",
+				"openPriceAvg":"37000","markPrice":"36900","liquidationPrice":"45000","unrealizedPL":"2","achievedProfits":"0",
+				"leverage":"10","marginSize":"74","marginMode":"isolated","uTime":"1700000000000"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	order, err := b.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.0105", option.OpenLong, option.Limit, option.WithPrice("37000.05"), option.WithClientOrderID("my-order-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "1001" || order.ClientOrderID != "my-order-1" {
+		t.Errorf("order = %+v", order)
+	}
+	if body["symbol"] != "BTCUSDT" || body["productType"] != "USDT-FUTURES" || body["marginMode"] != "crossed" || body["marginCoin"] != "USDT" ||
+		body["side"] != "buy" || body["size"] != "0.01" || body["price"] != "37000" || body["force"] != "gtc" || body["tradeSide"] != nil || body["reduceOnly"] != nil {
+		t.Errorf("open long body = %v", body)
+	}
+
+	if _, err := b.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "1000", option.OpenLong, option.Market); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("insufficient funds: err = %v, want ErrInsufficientFunds", err)
+	}
+
+	// 单向持仓平空：买入并只减仓，保证金模式取自持仓
+	if _, err := b.Perp().ClosePosition(ctx, "BTC/USDT:USDT"); err != nil {
+		t.Fatalf("ClosePosition: %v", err)
+	}
+	if body["side"] != "buy" || body["size"] != "0.02" || body["reduceOnly"] != "YES" || body["marginMode"] != "isolated" || body["orderType"] != "market" {
+		t.Errorf("close short body = %v", body)
+	}
+
+	// 双向持仓：side 为持仓方向，tradeSide 区分开平仓
+	if err := b.Perp().SetPositionMode(ctx, true); err != nil {
+		t.Fatalf("SetPositionMode: %v", err)
+	}
+	if body["posMode"] != "hedge_mode" {
+		t.Errorf("position mode body = %v", body)
+	}
+	if _, err := b.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseShort, option.Market); err != nil {
+		t.Fatalf("hedged CreateOrder: %v", err)
+	}
+	if body["side"] != "sell" || body["tradeSide"] != "close" || body["reduceOnly"] != nil {
+		t.Errorf("hedged close short body = %v", body)
+	}
+	if hedged, err := b.Perp().GetPositionMode(ctx); err != nil || !hedged {
+		t.Errorf("GetPositionMode = %v, %v", hedged, err)
+	}
+
+	if err := b.Perp().SetLeverage(ctx, "BTC/USDT:USDT", 20); err != nil {
+		t.Fatalf("SetLeverage: %v", err)
+	}
+	if body["leverage"] != "20" || body["productType"] != "USDT-FUTURES" || body["marginCoin"] != "USDT" {
+		t.Errorf("leverage body = %v", body)
+	}
+
+	if err := b.Perp().CancelOrder(ctx, "BTC/USDT:USDT", "", option.WithClientOrderID("my-order-1")); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if body["clientOid"] != "my-order-1" || body["productType"] != "USDT-FUTURES" || body["orderId"] != nil {
+		t.Errorf("cancel body = %v", body)
+	}
+}
+
+func TestBitgetPerp_FetchPositions(t *testing.T) {
+	b := newTestBitget(t, func(w http.ResponseWriter, r *http.Request) {
+		checkSignature(t, r, nil)
+		if r.URL.Path != "/api/v2/mix/position/all-position" || r.URL.Query().Get("productType") != "USDT-FUTURES" || r.URL.Query().Get("marginCoin") != "USDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+		w.Write([]byte(`{"code":"00000","data":[
+			{"symbol":"BTCUSDT","marginCoin":"USDT","holdSide":"long","total":"0.5","openPriceAvg":"36000","markPrice":"37000",
+				"liquidationPrice":"30000","unrealizedPL":"500","achievedProfits":"12","leverage":"20","marginSize":"900",
+				"marginMode":"crossed","keepMarginRate":"0.004","uTime":"1700000000000"},
+			{"symbol":"BTCUSDT","marginCoin":"USDT","holdSide":"short","total":"0","marginMode":"crossed"},
+			{"symbol":"DOGEUSDT","marginCoin":"USDT","holdSide":"long","total":"100","marginMode":"crossed"}
+		]}`))
+	})
+
+	positions, err := b.Perp().FetchPositions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("got %d positions, want 1 (empty and unknown positions skipped)", len(positions))
+	}
+	p := positions[0]
+	if p.Symbol != "BTC/USDT:USDT" || p.Side != string(types.PositionSideLong) || p.Amount.String() != "0.5" || p.EntryPrice.String() != "36000" ||
+		p.UnrealizedPnl.String() != "500" || p.Leverage.String() != "20" || p.MarginMode != model.MarginModeCross {
+		t.Errorf("position = %+v", p)
+	}
+}
+
+func TestBitgetSpot_CreateOrderBalance(t *testing.T) {
+	var body map[string]interface{}
+	b := newTestBitget(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/spot/market/tickers" {
+			w.Write([]byte(`{"code":"00000","data":[{"symbol":"BTCUSDT","lastPr":"37000.5","ts":"1700000000000"}]}`))
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		checkSignature(t, r, raw)
+		body = nil
+		json.Unmarshal(raw, &body)
+
+		switch r.URL.Path {
+		case "/api/v2/spot/trade/place-order":
+			w.Write([]byte(`{"code":"00000","data":{"orderId":"2001","clientOid":"x"}}`))
+		case "/api/v2/spot/account/assets":
+			w.Write([]byte(`{"code":"00000","data":[
+				{"coin":"USDT","available":"900","frozen":"100","locked":"0","uTime":"1700000000000"},
+				{"coin":"BTC","available":"1","frozen":"0.25","locked":"0.25","uTime":"1700000000000"}
+			]}`))
+		case "/api/v2/mix/account/accounts":
+			if r.URL.Query().Get("productType") != "USDT-FUTURES" {
+				t.Errorf("futures balance query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"00000","data":[{"marginCoin":"USDT","available":"800","accountEquity":"1000","unrealizedPL":"5"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	if _, err := b.Spot().CreateOrder(ctx, "BTC/USDT", option.Sell, "0.5", option.WithPrice("37000.123"), option.WithPostOnly(true)); err != nil {
+		t.Fatalf("limit CreateOrder: %v", err)
+	}
+	if body["symbol"] != "BTCUSDT" || body["side"] != "sell" || body["orderType"] != "limit" || body["price"] != "37000.12" ||
+		body["size"] != "0.5" || body["force"] != "post_only" || body["productType"] != nil {
+		t.Errorf("limit order body = %v", body)
+	}
+
+	if _, err := b.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.01"); err != nil {
+		t.Fatalf("market buy CreateOrder: %v", err)
+	}
+	if body["orderType"] != "market" || body["size"] != "370" {
+		t.Errorf("market buy body = %v, want quote size 370", body)
+	}
+
+	balances, err := b.Spot().FetchBalance(ctx)
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if len(balances) != 2 || balances[0].Currency != "BTC" || balances[0].Locked.String() != "0.5" || balances[0].Total.String() != "1.5" ||
+		balances[1].Currency != "USDT" || balances[1].Total.String() != "1000" {
+		t.Errorf("spot balances = %+v %+v", balances[0], balances[1])
+	}
+
+	futures, err := b.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFutures))
+	if err != nil {
+		t.Fatalf("futures FetchBalance: %v", err)
+	}
+	if len(futures) != 1 || futures[0].Available.String() != "800" || futures[0].Locked.String() != "200" || futures[0].Total.String() != "1000" {
+		t.Errorf("futures balances = %+v", futures)
+	}
+}
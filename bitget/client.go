@@ -0,0 +1,116 @@
+package bitget
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+)
+
+const (
+	bitgetName    = "bitget"
+	bitgetBaseURL = "https://api.bitget.com"
+
+	// bitgetSuccessCode 成功响应的 code
+	bitgetSuccessCode = "00000"
+
+	// bitgetOHLCVPageLimit 单次返回的最大K线数
+	bitgetOHLCVPageLimit = 1000
+
+	// bitgetMaxDepthLimit 订单簿最大档位数
+	bitgetMaxDepthLimit = 150
+
+	// bitgetProductTypeSpot、bitgetProductTypeUSDTFutures 产品类型（现货、USDT 本位合约）
+	bitgetProductTypeSpot        = "SPOT"
+	bitgetProductTypeUSDTFutures = "USDT-FUTURES"
+)
+
+// bitgetSpotTimeframes 现货K线支持的周期
+var bitgetSpotTimeframes = []string{"1min", "3min", "5min", "15min", "30min", "1h", "4h", "6h", "12h", "1day", "3day", "1week", "1M"}
+
+// bitgetPerpTimeframes 合约K线支持的周期
+var bitgetPerpTimeframes = []string{"1m", "3m", "5m", "15m", "30m", "1H", "2H", "4H", "6H", "12H", "1D", "3D", "1W", "1M"}
+
+// Client Bitget 客户端
+type Client struct {
+	// HTTPClient HTTP 客户端
+	HTTPClient *common.HTTPClient
+
+	// ProxyURL 代理地址
+	ProxyURL string
+
+	// Debug 是否启用调试模式
+	Debug bool
+}
+
+// NewClient 创建 Bitget 客户端
+// API 凭证由 Bitget 持有（见 credentials），以支持运行时轮换；Bitget 模拟盘使用独立的产品类型和交易对（如 SUSDT-FUTURES），暂不支持，忽略 sandbox 选项
+func NewClient(options map[string]interface{}) (*Client, error) {
+	baseURL, err := common.SharedBaseURL(options, bitgetBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL := ""
+	debug := false
+
+	if v, ok := options["proxy"].(string); ok {
+		proxyURL = v
+	}
+	if v, ok := options["debug"].(bool); ok {
+		debug = v
+	}
+
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
+	client := &Client{
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
+		ProxyURL:   proxyURL,
+		Debug:      debug,
+	}
+
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseBitgetError)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
+	// 设置代理
+	if proxyURL != "" {
+		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	// 设置调试模式
+	if debug {
+		client.HTTPClient.SetDebug(true)
+	}
+
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
+	}
+
+	return client, nil
+}
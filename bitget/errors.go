@@ -0,0 +1,36 @@
+package bitget
+
+import (
+	"encoding/json"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// bitgetErrorCodes Bitget 错误码到统一错误的映射
+var bitgetErrorCodes = map[string]error{
+	"43012": common.ErrInsufficientFunds,    // 余额不足
+	"40762": common.ErrInsufficientFunds,    // 下单数量超过可用余额
+	"40768": common.ErrOrderNotFound,        // 订单不存在
+	"43001": common.ErrOrderNotFound,        // 订单不存在
+	"43025": common.ErrOrderNotFound,        // 计划委托不存在
+	"429":   common.ErrRateLimitExceeded,    // 请求频率超限
+	"40006": common.ErrAuthenticationFailed, // API Key 无效
+	"40009": common.ErrAuthenticationFailed, // 签名错误
+	"40012": common.ErrAuthenticationFailed, // API Key 或 Passphrase 错误
+}
+
+// newBitgetError 根据 code/msg 创建交易所错误
+func newBitgetError(code, msg string) *common.ExchangeError {
+	return common.NewExchangeError(bitgetName, code, msg, bitgetErrorCodes)
+}
+
+// parseBitgetError 解析 Bitget 非 2xx 响应体 {"code":"43012","msg":"...","requestTime":...}
+func parseBitgetError(httpErr *common.HTTPError) error {
+	var body bitgetResponse
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Code == "" || body.Code == bitgetSuccessCode {
+		return nil
+	}
+	e := newBitgetError(body.Code, body.Msg)
+	e.Cause = httpErr
+	return e
+}
@@ -0,0 +1,203 @@
+package bitget
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// bitgetResponse Bitget 响应外层结构，code 为 00000 表示成功
+type bitgetResponse struct {
+	Code        string            `json:"code"`
+	Msg         string            `json:"msg"`
+	RequestTime types.ExTimestamp `json:"requestTime"`
+	Data        json.RawMessage   `json:"data"`
+}
+
+// bitgetTimeResponse Bitget 服务器时间（/api/v2/public/time）
+type bitgetTimeResponse struct {
+	ServerTime types.ExTimestamp `json:"serverTime"`
+}
+
+// bitgetSpotSymbol Bitget 现货交易对（/api/v2/spot/public/symbols）
+type bitgetSpotSymbol struct {
+	Symbol            string          `json:"symbol"` // 交易对，如 BTCUSDT
+	BaseCoin          string          `json:"baseCoin"`
+	QuoteCoin         string          `json:"quoteCoin"`
+	MinTradeAmount    types.ExDecimal `json:"minTradeAmount"`           // 最小下单量
+	MaxTradeAmount    types.ExDecimal `json:"maxTradeAmount"`           // 最大下单量
+	PricePrecision    int             `json:"pricePrecision,string"`    // 价格小数位
+	QuantityPrecision int             `json:"quantityPrecision,string"` // 数量小数位
+	MinTradeUSDT      types.ExDecimal `json:"minTradeUSDT"`             // 最小下单金额（USDT）
+	Status            string          `json:"status"`                   // online/gray/offline/halt
+}
+
+// bitgetContract Bitget 合约（/api/v2/mix/market/contracts）
+type bitgetContract struct {
+	Symbol         string          `json:"symbol"` // 合约，如 BTCUSDT
+	BaseCoin       string          `json:"baseCoin"`
+	QuoteCoin      string          `json:"quoteCoin"`
+	MinTradeNum    types.ExDecimal `json:"minTradeNum"`         // 最小下单量
+	PriceEndStep   int64           `json:"priceEndStep,string"` // 价格步长末位（步长 = priceEndStep * 10^-pricePlace）
+	PricePlace     int             `json:"pricePlace,string"`   // 价格小数位
+	VolumePlace    int             `json:"volumePlace,string"`  // 数量小数位
+	SizeMultiplier types.ExDecimal `json:"sizeMultiplier"`      // 数量步长
+	MinTradeUSDT   types.ExDecimal `json:"minTradeUSDT"`        // 最小下单金额（USDT）
+	MinLever       types.ExDecimal `json:"minLever"`            // 最小杠杆倍数
+	MaxLever       types.ExDecimal `json:"maxLever"`            // 最大杠杆倍数
+	SymbolType     string          `json:"symbolType"`          // perpetual/delivery
+	SymbolStatus   string          `json:"symbolStatus"`        // normal/maintain/limit_open/restrictedAPI/off
+}
+
+// bitgetSpotTicker Bitget 现货行情（/api/v2/spot/market/tickers）
+type bitgetSpotTicker struct {
+	Symbol      string            `json:"symbol"`
+	High24h     types.ExDecimal   `json:"high24h"`
+	Low24h      types.ExDecimal   `json:"low24h"`
+	Open        types.ExDecimal   `json:"open"`
+	LastPr      types.ExDecimal   `json:"lastPr"`
+	BidPr       types.ExDecimal   `json:"bidPr"`
+	AskPr       types.ExDecimal   `json:"askPr"`
+	BaseVolume  types.ExDecimal   `json:"baseVolume"`  // 24小时成交量（基础货币）
+	QuoteVolume types.ExDecimal   `json:"quoteVolume"` // 24小时成交额（计价货币）
+	Ts          types.ExTimestamp `json:"ts"`
+}
+
+// toTicker 转换为统一行情
+func (t *bitgetSpotTicker) toTicker(symbol string) *model.Ticker {
+	return &model.Ticker{
+		Symbol:      symbol,
+		Bid:         t.BidPr,
+		Ask:         t.AskPr,
+		Last:        t.LastPr,
+		Open:        t.Open,
+		High:        t.High24h,
+		Low:         t.Low24h,
+		Volume:      t.BaseVolume,
+		QuoteVolume: t.QuoteVolume,
+		Timestamp:   t.Ts,
+	}
+}
+
+// bitgetPerpTicker Bitget 合约行情（/api/v2/mix/market/ticker、tickers）
+type bitgetPerpTicker struct {
+	Symbol      string            `json:"symbol"`
+	LastPr      types.ExDecimal   `json:"lastPr"`
+	BidPr       types.ExDecimal   `json:"bidPr"`
+	AskPr       types.ExDecimal   `json:"askPr"`
+	High24h     types.ExDecimal   `json:"high24h"`
+	Low24h      types.ExDecimal   `json:"low24h"`
+	Open24h     types.ExDecimal   `json:"open24h"`
+	BaseVolume  types.ExDecimal   `json:"baseVolume"`
+	QuoteVolume types.ExDecimal   `json:"quoteVolume"`
+	IndexPrice  types.ExDecimal   `json:"indexPrice"`
+	MarkPrice   types.ExDecimal   `json:"markPrice"`
+	Ts          types.ExTimestamp `json:"ts"`
+}
+
+// toTicker 转换为统一行情，包含标记价格和指数价格
+func (t *bitgetPerpTicker) toTicker(symbol string) *model.Ticker {
+	return &model.Ticker{
+		Symbol:      symbol,
+		Bid:         t.BidPr,
+		Ask:         t.AskPr,
+		Last:        t.LastPr,
+		Open:        t.Open24h,
+		High:        t.High24h,
+		Low:         t.Low24h,
+		Volume:      t.BaseVolume,
+		QuoteVolume: t.QuoteVolume,
+		MarkPrice:   t.MarkPrice,
+		IndexPrice:  t.IndexPrice,
+		Timestamp:   t.Ts,
+	}
+}
+
+// bitgetOrderBook Bitget 深度（现货 /api/v2/spot/market/orderbook、合约 /api/v2/mix/market/merge-depth）
+type bitgetOrderBook struct {
+	Asks [][]types.ExDecimal `json:"asks"`
+	Bids [][]types.ExDecimal `json:"bids"`
+	Ts   types.ExTimestamp   `json:"ts"`
+}
+
+// toOrderBook 转换为统一订单簿，保留每侧前 limit 档（limit <= 0 时保留全部）
+func (b *bitgetOrderBook) toOrderBook(symbol string, limit int) *model.OrderBook {
+	return &model.OrderBook{
+		Symbol:    symbol,
+		Bids:      common.ParseOrderBookLevels(b.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(b.Asks, limit),
+		Timestamp: b.Ts,
+	}
+}
+
+// bitgetKline Bitget K线 [ts(毫秒), open, high, low, close, baseVolume, quoteVolume, ...]
+type bitgetKline struct {
+	Time   types.ExTimestamp
+	Open   types.ExDecimal
+	High   types.ExDecimal
+	Low    types.ExDecimal
+	Close  types.ExDecimal
+	Volume types.ExDecimal
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化，解析数组格式
+func (k *bitgetKline) UnmarshalJSON(data []byte) error {
+	var arr []json.RawMessage
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	if len(arr) < 6 {
+		return fmt.Errorf("invalid kline array length: %d", len(arr))
+	}
+	fields := []json.Unmarshaler{&k.Time, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume}
+	for i, field := range fields {
+		if err := field.UnmarshalJSON(arr[i]); err != nil {
+			return fmt.Errorf("parse kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// bitgetSpotAsset Bitget 现货资产（/api/v2/spot/account/assets）
+type bitgetSpotAsset struct {
+	Coin      string            `json:"coin"`
+	Available types.ExDecimal   `json:"available"`
+	Frozen    types.ExDecimal   `json:"frozen"` // 挂单冻结
+	Locked    types.ExDecimal   `json:"locked"` // 其他业务锁定
+	UTime     types.ExTimestamp `json:"uTime"`
+}
+
+// bitgetPerpAccount Bitget 合约账户（/api/v2/mix/account/accounts）
+type bitgetPerpAccount struct {
+	MarginCoin    string          `json:"marginCoin"`
+	Available     types.ExDecimal `json:"available"`
+	AccountEquity types.ExDecimal `json:"accountEquity"` // 账户权益（包含未实现盈亏）
+	UnrealizedPL  types.ExDecimal `json:"unrealizedPL"`
+}
+
+// bitgetPosition Bitget 合约持仓（/api/v2/mix/position/all-position、single-position）
+type bitgetPosition struct {
+	Symbol           string            `json:"symbol"`
+	MarginCoin       string            `json:"marginCoin"`
+	HoldSide         string            `json:"holdSide"` // long/short
+	Total            types.ExDecimal   `json:"total"`
+	OpenPriceAvg     types.ExDecimal   `json:"openPriceAvg"`
+	MarkPrice        types.ExDecimal   `json:"markPrice"`
+	LiquidationPrice types.ExDecimal   `json:"liquidationPrice"`
+	UnrealizedPL     types.ExDecimal   `json:"unrealizedPL"`
+	AchievedProfits  types.ExDecimal   `json:"achievedProfits"`
+	Leverage         types.ExDecimal   `json:"leverage"`
+	MarginSize       types.ExDecimal   `json:"marginSize"`
+	MarginMode       string            `json:"marginMode"` // isolated/crossed
+	KeepMarginRate   types.ExDecimal   `json:"keepMarginRate"`
+	UTime            types.ExTimestamp `json:"uTime"`
+}
+
+// bitgetOrderResponse Bitget 下单/撤单响应
+type bitgetOrderResponse struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+}
@@ -0,0 +1,29 @@
+package bitget
+
+import (
+	"strconv"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// Signer Bitget 签名工具
+type Signer struct {
+	secretKey string
+}
+
+// NewSigner 创建签名工具
+func NewSigner(secretKey string) *Signer {
+	return &Signer{
+		secretKey: secretKey,
+	}
+}
+
+// SignRequest 对请求进行签名（Bitget v2 API）
+// timestamp: 毫秒时间戳（与 ACCESS-TIMESTAMP 一致）
+// method: GET, POST（大写）
+// requestPath: API 路径，有查询参数时包含 ?queryString（如 /api/v2/mix/position/all-position?productType=USDT-FUTURES）
+// body: 请求体（POST 时使用）
+// Bitget 签名格式: base64(HMAC-SHA256(timestamp + method + requestPath + body, secretKey))
+func (s *Signer) SignRequest(timestamp int64, method, requestPath, body string) string {
+	return common.SignHMAC256Base64(strconv.FormatInt(timestamp, 10)+method+requestPath+body, s.secretKey)
+}
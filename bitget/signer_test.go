@@ -0,0 +1,34 @@
+package bitget
+
+import "testing"
+
+const testSecret = "bg-secret-2f9d6c1a"
+
+func TestSigner_SignRequest(t *testing.T) {
+	signer := NewSigner(testSecret)
+
+	tests := []struct {
+		name, method, requestPath, body, want string
+	}{
+		{
+			name:        "get with query",
+			method:      "GET",
+			requestPath: "/api/v2/mix/position/all-position?marginCoin=USDT&productType=USDT-FUTURES",
+			want:        "MwguuABMd8bBWx94RSTyNjeLCe+Ut3+VRg99Gxc5k14=",
+		},
+		{
+			name:        "post with body",
+			method:      "POST",
+			requestPath: "/api/v2/mix/order/place-order",
+			body:        `{"clientOid":"my-order-1","marginCoin":"USDT","marginMode":"crossed","orderType":"market","productType":"USDT-FUTURES","side":"buy","size":"0.01","symbol":"BTCUSDT"}`,
+			want:        "NgCbl/gBAIsHtPgm3zjx1xvAL7Eh62hUo4P4+kUzZaw=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signer.SignRequest(1700000000000, tt.method, tt.requestPath, tt.body); got != tt.want {
+				t.Errorf("SignRequest = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package bitget
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+// bitgetSpotTimeframe 转换为 Bitget 现货K线周期（如 1m -> 1min、1d -> 1day），不支持的周期原样返回
+func bitgetSpotTimeframe(timeframe string) string {
+	normalized := common.NormalizeTimeframe(timeframe)
+	switch {
+	case strings.HasSuffix(normalized, "m"):
+		return strings.TrimSuffix(normalized, "m") + "min"
+	case strings.HasSuffix(normalized, "d"):
+		return strings.TrimSuffix(normalized, "d") + "day"
+	case normalized == "1w":
+		return "1week"
+	default:
+		return normalized
+	}
+}
+
+// bitgetPerpTimeframe 转换为 Bitget 合约K线周期（小时、天、周为大写，如 1h -> 1H、1d -> 1D），不支持的周期原样返回
+func bitgetPerpTimeframe(timeframe string) string {
+	normalized := common.NormalizeTimeframe(timeframe)
+	if strings.HasSuffix(normalized, "m") {
+		return normalized
+	}
+	return strings.ToUpper(normalized)
+}
+
+// bitgetProductType 返回市场对应的 Bitget 产品类型，现货为 SPOT，USDT 结算的永续合约为 USDT-FUTURES
+func bitgetProductType(market *model.Market) string {
+	if market.Type == model.MarketTypeSpot {
+		return bitgetProductTypeSpot
+	}
+	return bitgetProductTypeUSDTFutures
+}
+
+// bitgetForce 返回订单有效期 force（gtc/ioc/fok/post_only），只做 Maker 时为 post_only，未设置时为 gtc
+func bitgetForce(postOnly bool, tif *option.TimeInForce) string {
+	switch {
+	case postOnly:
+		return "post_only"
+	case tif != nil && (tif.IsIOC() || tif.IsFOK()):
+		return tif.Lower()
+	default:
+		return "gtc"
+	}
+}
+
+// bitgetCancelBody 构建撤单请求体，合约市场附带 productType；orderId 为空时按 option.WithClientOrderID 撤销
+func bitgetCancelBody(market *model.Market, orderId string, opts *option.ExchangeArgsOptions) (map[string]interface{}, error) {
+	body := map[string]interface{}{"symbol": market.ID}
+	if market.Type != model.MarketTypeSpot {
+		body["productType"] = bitgetProductType(market)
+	}
+	switch {
+	case orderId != "":
+		body["orderId"] = orderId
+	case option.StringPresent(opts.ClientOrderID):
+		body["clientOid"] = *opts.ClientOrderID
+	default:
+		return nil, fmt.Errorf("cancel order: order id or client order id is required: %w", common.ErrInvalidOrder)
+	}
+	return body, nil
+}
+
+// notSupported Bitget 适配器不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for bitget", operation, common.ErrNotSupported)
+}
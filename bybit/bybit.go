@@ -1,16 +1,27 @@
 package bybit
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
 	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // Bybit Bybit 交易所实现
 type Bybit struct {
 	client              *Client
-	signer              *Signer
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
 	spot                *BybitSpot
 	perp                *BybitPerp
 	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
@@ -18,6 +29,14 @@ type Bybit struct {
 	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
 	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
 	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	recvWindow          string                   // 签名请求接收窗口（毫秒），签名和 X-BAPI-RECV-WINDOW 请求头共用
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+	spotWS              *common.WSManager        // 现货公共 WebSocket 订阅
+	perpWS              *common.WSManager        // 合约公共 WebSocket 订阅
+	inverseWS           *common.WSManager        // 币本位合约公共 WebSocket 订阅
+	privateWS           *common.WSManager        // 私有 WebSocket 订阅（订单、钱包，现货和合约共用）
 }
 
 // NewBybit 创建 Bybit 交易所实例
@@ -27,22 +46,54 @@ func NewBybit(apiKey, secretKey string, options map[string]interface{}) (exchang
 		return nil, err
 	}
 
-	signer := NewSigner(secretKey)
-	signer.SetAPIKey(apiKey) // Bybit v5 签名需要 API Key
-
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
 	bybit := &Bybit{
 		client:              client,
-		signer:              signer,
 		spotMarketsBySymbol: make(map[string]*model.Market),
 		spotMarketsByID:     make(map[string]*model.Market),
 		perpMarketsBySymbol: make(map[string]*model.Market),
 		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		recvWindow:          strconv.Itoa(bybitDefaultRecvWindow),
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		bybit.lifecycle.SetCancelOrders(v)
+	}
+	if v, ok := options["recvWindow"].(int); ok && v > 0 {
+		bybit.recvWindow = strconv.Itoa(v)
+	}
+	client.HTTPClient.SetLifecycle(bybit.lifecycle)
+
+	bybit.UpdateCredentials(apiKey, secretKey, "")
+
+	// 公共 WebSocket 按需建立连接，所有订阅复用同一连接
+	bybit.spotWS = common.NewWSManager(common.NewWSDialer(bybitSpotWSURL, client.ProxyURL), bybitWSProtocol{})
+	bybit.perpWS = common.NewWSManager(common.NewWSDialer(bybitPerpWSURL, client.ProxyURL), bybitWSProtocol{})
+	bybit.inverseWS = common.NewWSManager(common.NewWSDialer(bybitInverseWSURL, client.ProxyURL), bybitWSProtocol{})
+
+	// 私有 WebSocket 每次建立连接（包括断线重连）后先鉴权再订阅
+	privateWSURL := bybitPrivateWSURL
+	if client.Sandbox {
+		privateWSURL = bybitPrivateWSSandboxURL
+	}
+	bybit.privateWS = common.NewWSManager(common.NewAuthWSDialer(common.NewWSDialer(privateWSURL, client.ProxyURL), bybit.wsLogin), bybitPrivateWSProtocol{})
+	if v, ok := options["streamStateHandler"].(common.WSStateHandler); ok {
+		bybit.spotWS.SetStateHandler(bybitName, "spot", v)
+		bybit.perpWS.SetStateHandler(bybitName, "perp", v)
+		bybit.inverseWS.SetStateHandler(bybitName, "inverse", v)
+		bybit.privateWS.SetStateHandler(bybitName, "private", v)
 	}
 
 	// 初始化现货和合约实现
 	bybit.spot = NewBybitSpot(bybit)
 	bybit.perp = NewBybitPerp(bybit)
 
+	if v, ok := options["timeSync"].(bool); ok && v {
+		bybit.clock.Start(common.TimeSyncInterval, bybit.FetchTime)
+	}
+
 	return bybit, nil
 }
 
@@ -60,3 +111,349 @@ func (b *Bybit) Perp() exchange.PerpExchange {
 func (b *Bybit) Name() string {
 	return bybitName
 }
+
+// Has 返回 Bybit 支持的功能
+func (b *Bybit) Has() model.Capabilities {
+	return bybitCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (b *Bybit) Symbols() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketSymbols(b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (b *Bybit) NormalizeSymbol(nativeID string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, b.spotMarketsByID, b.perpMarketsByID, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (b *Bybit) DenormalizeSymbol(symbol string) (string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, b.spotMarketsBySymbol, b.perpMarketsBySymbol, common.ToBybitSymbol)
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (b *Bybit) ExportMarkets() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return common.MarshalMarkets(bybitName, b.spotMarketsBySymbol, b.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (b *Bybit) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(bybitName, data)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		b.spotMarketsBySymbol, b.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		b.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		b.perpMarketsBySymbol, b.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		b.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后关闭 WebSocket 连接，已建立的推送和轮询订阅通道随之关闭
+func (b *Bybit) Drain(ctx context.Context) error {
+	b.clock.Stop()
+	err := b.lifecycle.Drain(ctx, b.spot, b.perp)
+	return errors.Join(err, common.CloseWSManagers(b.spotWS, b.perpWS, b.inverseWS, b.privateWS))
+}
+
+// FetchTime 获取交易所服务器时间
+func (b *Bybit) FetchTime(ctx context.Context) (time.Time, error) {
+	resp, err := b.client.HTTPClient.Get(ctx, "/v5/market/time", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+
+	var result bybitTimeResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal time: %w", err)
+	}
+	if result.RetCode != 0 {
+		return time.Time{}, newBybitError(result.RetCode, result.RetMsg)
+	}
+	return result.Time.Time, nil
+}
+
+// FetchStatus 获取系统状态（/v5/system/status），存在进行中的维护时为维护中，接口无法访问时视为维护中
+func (b *Bybit) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	resp, err := b.client.HTTPClient.Get(ctx, "/v5/system/status", nil)
+	if err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+
+	var result bybitSystemStatusResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("unmarshal system status: %w", err))
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	status := common.OKStatus()
+	for _, item := range result.Result.List {
+		if item.State != "ongoing" {
+			continue
+		}
+		status.Status = model.ExchangeStatusMaintenance
+		status.Updated = item.Begin
+		status.ETA = item.End
+		status.URL = item.Href
+		break
+	}
+	return status, nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (b *Bybit) Ping(ctx context.Context) error {
+	return common.Ping(ctx, b.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (b *Bybit) VerifyCredentials(ctx context.Context) error {
+	creds := b.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", b.spot.FetchBalance)
+}
+
+// bybitCapabilities Bybit 支持的功能
+var bybitCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported:             true,
+		CreateStopOrder:       true,
+		EditOrder:             true,
+		FetchOrder:            true,
+		FetchOrderByClientID:  true,
+		TrackOrder:            true,
+		FetchAggregatedTrades: true,
+		FetchMyTrades:         true,
+		WatchTicker:           true,
+		WatchOrderBook:        true,
+		WatchOHLCV:            true,
+		WatchOrders:           true,
+		WatchBalance:          true,
+		CreateConversion:      true,
+		FetchCurrencies:       true,
+		FetchDepositAddress:   true,
+		Withdraw:              true,
+		Transfer:              true,
+		FetchSubAccounts:      true,
+		Margin:                true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:               true,
+		CreateStopOrder:         true,
+		CreateTrailingStopOrder: true,
+		EditOrder:               true,
+		FetchOrder:              true,
+		FetchOrderByClientID:    true,
+		TrackOrder:              true,
+		FetchAggregatedTrades:   true,
+		FetchMyTrades:           true,
+		WatchTicker:             true,
+		WatchOrderBook:          true,
+		WatchOHLCV:              true,
+		WatchOrders:             true,
+		WatchBalance:            true,
+		WatchPositions:          true,
+		FetchFundingRate:        true,
+		FetchFundingRateHistory: true,
+		FetchOpenInterest:       true,
+		FetchMarkPrice:          true,
+		FetchIndexPrice:         true,
+		SetLeverage:             true,
+		SetLeverageSide:         true,
+		SetMarginMode:           true,
+		SetPositionMode:         true,
+		GetPositionMode:         true, // 由持仓推断，没有持仓时使用 SetPositionMode 设置的模式
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// Bybit 不需要 password，忽略该参数
+func (b *Bybit) UpdateCredentials(apiKey, secretKey, password string) {
+	signer := NewSigner(secretKey)
+	signer.SetAPIKey(apiKey) // Bybit v5 签名需要 API Key
+	b.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    signer,
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (b *Bybit) credentials() *credentials {
+	return b.creds.Load()
+}
+
+// bybitOrderParams 已构建好的下单参数（现货和合约共用，单笔下单和批量下单共用）
+type bybitOrderParams struct {
+	symbol        string
+	category      string
+	body          map[string]interface{}
+	clientOrderID string
+	stopPrice     decimal.Decimal
+	strict        bool
+}
+
+// toNewOrder 将下单结果转换为 NewOrder，开启严格校验时检查客户端订单ID
+func (params *bybitOrderParams) toNewOrder(orderID, orderLinkID string, timestamp types.ExTimestamp) (*model.NewOrder, error) {
+	order := &model.NewOrder{
+		Symbol:        params.symbol,
+		OrderId:       orderID,
+		ClientOrderID: orderLinkID,
+		Timestamp:     timestamp,
+		TriggerPrice:  types.ExDecimal{Decimal: params.stopPrice},
+	}
+
+	if params.strict {
+		if err := common.CheckClientOrderID(params.clientOrderID, order.ClientOrderID); err != nil {
+			return order, err
+		}
+	}
+
+	return order, nil
+}
+
+// bybitSignFunc 签名并发送请求（现货和合约的 signAndRequest）
+type bybitSignFunc func(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error)
+
+// submitBybitBatchOrders 提交同一产品类型的一批订单（POST /v5/order/create-batch）
+// result.list 与 retExtInfo.list 按请求顺序一一对应，后者为每个订单的错误码
+func submitBybitBatchOrders(ctx context.Context, sign bybitSignFunc, category string, batch []*bybitOrderParams) ([]*model.NewOrder, []error, error) {
+	request := make([]map[string]interface{}, len(batch))
+	for i, params := range batch {
+		item := make(map[string]interface{}, len(params.body))
+		for k, v := range params.body {
+			if k != "category" {
+				item[k] = v
+			}
+		}
+		request[i] = item
+	}
+
+	resp, err := sign(ctx, "POST", "/v5/order/create-batch", nil, map[string]interface{}{
+		"category": category,
+		"request":  request,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create orders: %w", err)
+	}
+
+	var respData bybitBatchOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal batch orders: %w", err)
+	}
+	if respData.RetCode != 0 {
+		return nil, nil, newBybitError(respData.RetCode, respData.RetMsg)
+	}
+
+	orders := make([]*model.NewOrder, len(batch))
+	errs := make([]error, len(batch))
+	for i, params := range batch {
+		if i < len(respData.RetExtInfo.List) && respData.RetExtInfo.List[i].Code != 0 {
+			errs[i] = newBybitError(respData.RetExtInfo.List[i].Code, respData.RetExtInfo.List[i].Msg)
+			continue
+		}
+		if i >= len(respData.Result.List) || respData.Result.List[i].OrderID == "" {
+			errs[i] = fmt.Errorf("create orders: empty order in response")
+			continue
+		}
+		result := respData.Result.List[i]
+		orders[i], errs[i] = params.toNewOrder(result.OrderID, result.OrderLinkID, respData.Time)
+	}
+	return orders, errs, nil
+}
+
+// bybitExecutionWindow 成交明细单次查询的最大时间跨度
+const bybitExecutionWindow = 7 * 24 * time.Hour
+
+// fetchBybitMyTradesRange 通过 /v5/execution/list 查询账户成交记录
+// startTime 与 endTime 间隔不能超过 7 天，按窗口依次查询，窗口内按 nextPageCursor 翻页
+// 游标为 "<窗口起点毫秒>|<nextPageCursor>"
+func fetchBybitMyTradesRange(ctx context.Context, sign bybitSignFunc, category string, market *model.Market, since, until time.Time) ([]*model.Trade, error) {
+	return common.FetchTradesRange(ctx, since, until, func(ctx context.Context, since, until time.Time, cursor string) ([]*model.Trade, string, error) {
+		start, pageCursor := since, ""
+		if cursor != "" {
+			ms, rest, _ := strings.Cut(cursor, "|")
+			v, err := strconv.ParseInt(ms, 10, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid trade cursor: %s", cursor)
+			}
+			start, pageCursor = time.UnixMilli(v), rest
+		}
+		end := start.Add(bybitExecutionWindow)
+		if end.After(until) {
+			end = until
+		}
+
+		params := map[string]interface{}{
+			"category":  category,
+			"symbol":    market.ID,
+			"startTime": start.UnixMilli(),
+			"endTime":   end.UnixMilli() - 1,
+			"limit":     100,
+		}
+		if pageCursor != "" {
+			params["cursor"] = pageCursor
+		}
+
+		resp, err := sign(ctx, "GET", "/v5/execution/list", params, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("fetch my trades: %w", err)
+		}
+
+		var respData bybitExecutionResponse
+		if err := json.Unmarshal(resp, &respData); err != nil {
+			return nil, "", fmt.Errorf("unmarshal my trades: %w", err)
+		}
+		if respData.RetCode != 0 {
+			return nil, "", newBybitError(respData.RetCode, respData.RetMsg)
+		}
+
+		trades := make([]*model.Trade, 0, len(respData.Result.List))
+		for _, item := range respData.Result.List {
+			trades = append(trades, &model.Trade{
+				ID:        item.ExecID,
+				OrderID:   item.OrderID,
+				Symbol:    market.Symbol,
+				Side:      strings.ToLower(item.Side),
+				Amount:    item.ExecQty.Decimal,
+				Price:     item.ExecPrice.Decimal,
+				Cost:      item.ExecValue.Decimal,
+				Timestamp: item.ExecTime.Time,
+			})
+		}
+
+		startMs := strconv.FormatInt(start.UnixMilli(), 10)
+		if next := respData.Result.NextPageCursor; next != "" {
+			return trades, startMs + "|" + next, nil
+		}
+		if !end.Before(until) {
+			return trades, "", nil
+		}
+		return trades, strconv.FormatInt(end.UnixMilli(), 10) + "|", nil
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -18,7 +19,8 @@ import (
 
 // BybitPerp Bybit 永续合约实现
 type BybitPerp struct {
-	bybit *Bybit
+	bybit        *Bybit
+	positionMode common.PositionMode // USDT 永续合约持仓模式（币本位永续只支持单向持仓）
 }
 
 // NewBybitPerp 创建 Bybit 永续合约实例
@@ -31,20 +33,41 @@ func NewBybitPerp(b *Bybit) *BybitPerp {
 // ========== PerpExchange 接口实现 ==========
 
 func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	p.bybit.mu.RLock()
-	if !reload && len(p.bybit.perpMarketsBySymbol) > 0 {
+	if !reload && len(p.bybit.perpMarketsBySymbol) > 0 && !p.bybit.marketCache.Expired(model.MarketTypeSwap) {
 		p.bybit.mu.RUnlock()
 		return nil
 	}
 	p.bybit.mu.RUnlock()
 
-	// 获取永续合约市场信息
+	// 获取永续合约市场信息（U本位 linear 和币本位 inverse 分别查询）
+	markets := make([]*model.Market, 0)
+	for _, category := range bybitPerpCategories {
+		categoryMarkets, err := p.fetchMarkets(ctx, category)
+		if err != nil {
+			return err
+		}
+		markets = append(markets, categoryMarkets...)
+	}
+
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
+	p.bybit.mu.Lock()
+	p.bybit.perpMarketsBySymbol, p.bybit.perpMarketsByID = bySymbol, byID
+	p.bybit.marketCache.Touch(model.MarketTypeSwap)
+	p.bybit.mu.Unlock()
+
+	return nil
+}
+
+// fetchMarkets 获取指定产品分类（linear/inverse）的永续合约市场
+func (p *BybitPerp) fetchMarkets(ctx context.Context, category string) ([]*model.Market, error) {
 	req := types.NewExValues()
-	req.SetQuery("category", "linear")
+	req.SetQuery("category", category)
 	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/instruments-info", req.ToQueryMap())
 	if err != nil {
-		return fmt.Errorf("fetch swap markets: %w", err)
+		return nil, fmt.Errorf("fetch swap markets: %w", err)
 	}
 
 	var respData struct {
@@ -61,6 +84,7 @@ func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
 				LotSizeFilter struct {
 					BasePrecision  types.ExDecimal `json:"basePrecision"`
 					QuotePrecision types.ExDecimal `json:"quotePrecision"`
+					QtyStep        types.ExDecimal `json:"qtyStep"`
 					MinOrderQty    types.ExDecimal `json:"minOrderQty"`
 					MaxOrderQty    types.ExDecimal `json:"maxOrderQty"`
 					MinOrderAmt    types.ExDecimal `json:"minOrderAmt"`
@@ -69,15 +93,19 @@ func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
 				PriceFilter struct {
 					TickSize types.ExDecimal `json:"tickSize"`
 				} `json:"priceFilter"`
+				LeverageFilter struct {
+					MinLeverage types.ExDecimal `json:"minLeverage"`
+					MaxLeverage types.ExDecimal `json:"maxLeverage"`
+				} `json:"leverageFilter"`
 			} `json:"list"`
 		} `json:"result"`
 	}
 	if err := json.Unmarshal(resp, &respData); err != nil {
-		return fmt.Errorf("unmarshal swap markets: %w", err)
+		return nil, fmt.Errorf("unmarshal swap markets: %w", err)
 	}
 
 	if respData.RetCode != 0 {
-		return fmt.Errorf("bybit api error: %s", respData.RetMsg)
+		return nil, newBybitError(respData.RetCode, respData.RetMsg)
 	}
 
 	markets := make([]*model.Market, 0)
@@ -90,8 +118,11 @@ func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
 			continue
 		}
 
-		// Bybit linear 合约的 settle 通常是 quoteCoin
+		// Bybit linear 合约的 settle 通常是 quoteCoin，inverse 合约以 baseCoin 结算
 		settle := s.QuoteCoin
+		if s.ContractType == "InversePerpetual" {
+			settle = s.BaseCoin
+		}
 
 		// 转换为标准化格式 BTC/USDT:USDT
 		normalizedSymbol := common.NormalizeContractSymbol(s.BaseCoin, s.QuoteCoin, settle)
@@ -110,19 +141,31 @@ func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
 		// U本位永续合约
 		if s.ContractType == "LinearPerpetual" {
 			market.Linear = true
+			market.ContractValue = bybitLinearContractValue
 		}
 
-		// 币本位永续合约
+		// 币本位永续合约，数量单位为 USD，每张合约面值 1 USD
 		if s.ContractType == "InversePerpetual" {
 			market.Inverse = true
+			market.ContractValue = bybitInverseContractValue
 		}
 
-		// 解析精度
+		// 解析精度（合约使用 qtyStep 作为数量步长）
+		market.Precision.StepSize = s.LotSizeFilter.QtyStep
+		if market.Precision.StepSize.IsZero() {
+			market.Precision.StepSize = s.LotSizeFilter.BasePrecision
+		}
+		market.Precision.TickSize = s.PriceFilter.TickSize
 		basePrecision := s.LotSizeFilter.BasePrecision.InexactFloat64()
 		tickSize := s.PriceFilter.TickSize.InexactFloat64()
 		quotePrecision := s.LotSizeFilter.QuotePrecision.InexactFloat64()
 
-		market.Precision.Amount = getPrecisionDigits(basePrecision)
+		// 合约不返回 basePrecision，数量精度按数量步长计算
+		if qtyStep := market.Precision.StepSize.InexactFloat64(); qtyStep > 0 {
+			market.Precision.Amount = getPrecisionDigits(qtyStep)
+		} else {
+			market.Precision.Amount = getPrecisionDigits(basePrecision)
+		}
 		if tickSize > 0 {
 			market.Precision.Price = getPrecisionDigits(tickSize)
 		} else if quotePrecision > 0 {
@@ -134,23 +177,13 @@ func (p *BybitPerp) LoadMarkets(ctx context.Context, reload bool) error {
 		market.Limits.Amount.Max = s.LotSizeFilter.MaxOrderQty
 		market.Limits.Cost.Min = s.LotSizeFilter.MinOrderAmt
 		market.Limits.Cost.Max = s.LotSizeFilter.MaxOrderAmt
+		market.Limits.Leverage.Min = s.LeverageFilter.MinLeverage
+		market.Limits.Leverage.Max = s.LeverageFilter.MaxLeverage
 
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
-	p.bybit.mu.Lock()
-	if p.bybit.perpMarketsBySymbol == nil {
-		p.bybit.perpMarketsBySymbol = make(map[string]*model.Market)
-		p.bybit.perpMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		p.bybit.perpMarketsBySymbol[market.Symbol] = market
-		p.bybit.perpMarketsByID[market.ID] = market
-	}
-	p.bybit.mu.Unlock()
-
-	return nil
+	return markets, nil
 }
 
 func (p *BybitPerp) FetchMarkets(ctx context.Context, opts ...option.ArgsOption) (model.Markets, error) {
@@ -197,7 +230,50 @@ func (p *BybitPerp) GetMarket(symbol string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", symbol)
+	return nil, common.MarketNotFound(symbol, p.bybit.perpMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *BybitPerp) GetMarketByID(id string) (*model.Market, error) {
+	p.bybit.mu.RLock()
+	defer p.bybit.mu.RUnlock()
+
+	if market, ok := p.bybit.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.bybit.perpMarketsByID)
+}
+
+func (p *BybitPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (p *BybitPerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+func (p *BybitPerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+func (p *BybitPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
 }
 
 func (p *BybitPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
@@ -219,7 +295,7 @@ func (p *BybitPerp) FetchTicker(ctx context.Context, symbol string) (*model.Tick
 
 	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/tickers", map[string]interface{}{
 		"symbol":   bybitSymbol,
-		"category": "linear",
+		"category": bybitPerpCategory(market),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch ticker: %w", err)
@@ -232,7 +308,7 @@ func (p *BybitPerp) FetchTicker(ctx context.Context, symbol string) (*model.Tick
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	if len(result.Result.List) == 0 {
@@ -253,6 +329,8 @@ func (p *BybitPerp) FetchTicker(ctx context.Context, symbol string) (*model.Tick
 	ticker.Low = item.LowPrice24h
 	ticker.Volume = item.Volume24h
 	ticker.QuoteVolume = item.Turnover24h
+	ticker.MarkPrice = item.MarkPrice
+	ticker.IndexPrice = item.IndexPrice
 	ticker.Timestamp = result.Time
 
 	return ticker, nil
@@ -265,6 +343,8 @@ func (p *BybitPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 		opt(argsOpts)
 	}
 
+	// 未指定交易对时分别查询 U本位和币本位合约
+	categories := bybitPerpCategories
 	var querySymbol string
 	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
 		market, err := p.GetMarket(symbol)
@@ -272,10 +352,24 @@ func (p *BybitPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 			return nil, err
 		}
 		querySymbol = market.ID
+		categories = []string{bybitPerpCategory(market)}
+	}
+
+	// 多个分类并发查询
+	tickers, err := common.FetchConcurrently(ctx, categories, func(ctx context.Context, category string) ([]*model.Ticker, error) {
+		return p.fetchTickers(ctx, category, querySymbol)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return tickers, nil
+}
+
+// fetchTickers 获取单个分类（linear 或 inverse）的行情，querySymbol 为空时获取该分类全部交易对
+func (p *BybitPerp) fetchTickers(ctx context.Context, category, querySymbol string) (model.Tickers, error) {
 	req := types.NewExValues()
-	req.SetQuery("category", "linear")
+	req.SetQuery("category", category)
 	if querySymbol != "" {
 		req.SetQuery("symbol", querySymbol)
 	}
@@ -331,7 +425,7 @@ func (p *BybitPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 	}
 
 	if respData.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", respData.RetMsg)
+		return nil, newBybitError(respData.RetCode, respData.RetMsg)
 	}
 
 	tickers := make(model.Tickers, 0, len(respData.Result.List))
@@ -353,6 +447,8 @@ func (p *BybitPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 		ticker.Low = item.LowPrice24h
 		ticker.Volume = item.Volume24h
 		ticker.QuoteVolume = item.Turnover24h
+		ticker.MarkPrice = item.MarkPrice
+		ticker.IndexPrice = item.IndexPrice
 		ticker.Timestamp = respData.Time
 		tickers = append(tickers, ticker)
 	}
@@ -360,6 +456,89 @@ func (p *BybitPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 	return tickers, nil
 }
 
+func (p *BybitPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
+	if limit = common.ClampOrderBookLimit(limit, bybitPerpMaxDepthLimit); limit > 0 {
+		req.SetQuery("limit", limit)
+	}
+
+	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/orderbook", req.ToQueryMap())
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var result bybitOrderBookResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(result.Result.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(result.Result.Asks, limit),
+		Nonce:     result.Result.UpdateID,
+		Timestamp: result.Result.Timestamp,
+	}, nil
+}
+
+func (p *BybitPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.wsManager(market), "tickers."+market.ID, parseBybitWSTicker(market.Symbol))
+}
+
+func (p *BybitPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  p.wsManager(market),
+		Topic:    bybitOrderBookTopic(market.ID, depth, bybitPerpWSDepthLevels),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequenceNone,
+		Parse:    parseBybitWSOrderBook,
+	})
+}
+
+func (p *BybitPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.BybitTimeframe(timeframe), bybitTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.wsManager(market), bybitKlineTopic(market.ID, interval), parseBybitWSKline)
+}
+
 func (p *BybitPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -371,14 +550,21 @@ func (p *BybitPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe st
 	if err != nil {
 		return nil, err
 	}
+	interval, err := common.CheckTimeframe(timeframe, common.BybitTimeframe(timeframe), bybitTimeframes)
+	if err != nil {
+		return nil, err
+	}
 	req := types.NewExValues()
-	req.SetQuery("category", "linear")
+	req.SetQuery("category", bybitPerpCategory(market))
 	req.SetQuery("symbol", market.ID)
-	req.SetQuery("interval", common.BybitTimeframe(timeframe))
+	req.SetQuery("interval", interval)
 	req.SetQuery("limit", limit)
 	if since, ok := option.GetTime(argsOpts.Since); ok {
 		req.SetQuery("start", since.UnixMilli())
 	}
+	if until, ok := option.GetTime(argsOpts.Until); ok {
+		req.SetQuery("end", until.UnixMilli())
+	}
 
 	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/kline", req.ToQueryMap())
 	if err != nil {
@@ -401,7 +587,7 @@ func (p *BybitPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe st
 	}
 
 	if respData.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", respData.RetMsg)
+		return nil, newBybitError(respData.RetCode, respData.RetMsg)
 	}
 
 	ohlcvs := make(model.OHLCVs, 0, len(respData.Result.List))
@@ -430,237 +616,721 @@ func (p *BybitPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe st
 		ohlcvs = append(ohlcvs, ohlcv)
 	}
 
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, p.bybit.clock.Now())
+	}
 	return ohlcvs, nil
 }
 
-func (p *BybitPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
-	argsOpts := &option.ExchangeArgsOptions{}
-	for _, opt := range opts {
-		opt(argsOpts)
+func (p *BybitPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, bybitOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, limit, option.WithSince(start), option.WithUntil(end))
+	})
+}
+
+func (p *BybitPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
 	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, 2)
+	})
+}
 
-	var querySymbol string
-	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
-		market, err := p.GetMarket(symbol)
-		if err != nil {
-			return nil, err
-		}
-		querySymbol = market.ID
+func (p *BybitPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
 	}
+
 	req := types.NewExValues()
-	req.SetQuery("category", "linear")
-	if querySymbol != "" {
-		req.SetQuery("symbol", querySymbol)
-	} else {
-		req.SetQuery("settleCoin", "USDT")
-	}
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
+	req.SetQuery("limit", bybitPerpMaxTradesLimit)
 
-	resp, err := p.signAndRequest(ctx, "GET", "/v5/position/list", req.ToQueryMap(), nil)
+	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/recent-trade", req.ToQueryMap())
 	if err != nil {
-		return nil, fmt.Errorf("fetch positions: %w", err)
+		return nil, fmt.Errorf("fetch trades: %w", err)
 	}
 
-	var respData struct {
-		RetCode int    `json:"retCode"`
-		RetMsg  string `json:"retMsg"`
-		Result  struct {
-			Category string `json:"category"`
-			List     []struct {
-				Symbol                 string            `json:"symbol"`
-				Leverage               types.ExDecimal   `json:"leverage"`
-				AutoAddMargin          int               `json:"autoAddMargin"`
-				AvgPrice               types.ExDecimal   `json:"avgPrice"`
-				LiqPrice               types.ExDecimal   `json:"liqPrice"`
-				RiskLimitValue         types.ExDecimal   `json:"riskLimitValue"`
-				TakeProfit             types.ExDecimal   `json:"takeProfit"`
-				PositionValue          types.ExDecimal   `json:"positionValue"`
-				IsReduceOnly           bool              `json:"isReduceOnly"`
-				PositionIMByMp         types.ExDecimal   `json:"positionIMByMp"`
-				TpslMode               string            `json:"tpslMode"`
-				RiskId                 int               `json:"riskId"`
-				TrailingStop           types.ExDecimal   `json:"trailingStop"`
-				UnrealisedPnl          types.ExDecimal   `json:"unrealisedPnl"`
-				MarkPrice              types.ExDecimal   `json:"markPrice"`
-				AdlRankIndicator       int               `json:"adlRankIndicator"`
-				CumRealisedPnl         types.ExDecimal   `json:"cumRealisedPnl"`
-				PositionMM             types.ExDecimal   `json:"positionMM"`
-				CreatedTime            types.ExTimestamp `json:"createdTime"`
-				PositionIdx            int               `json:"positionIdx"`
-				PositionIM             types.ExDecimal   `json:"positionIM"`
-				PositionMMByMp         types.ExDecimal   `json:"positionMMByMp"`
-				Seq                    int64             `json:"seq"`
-				UpdatedTime            types.ExTimestamp `json:"updatedTime"`
-				Side                   string            `json:"side"`
-				BustPrice              types.ExDecimal   `json:"bustPrice"`
-				PositionBalance        types.ExDecimal   `json:"positionBalance"`
-				LeverageSysUpdatedTime types.ExTimestamp `json:"leverageSysUpdatedTime"`
-				CurRealisedPnl         types.ExDecimal   `json:"curRealisedPnl"`
-				Size                   types.ExDecimal   `json:"size"`
-				PositionStatus         string            `json:"positionStatus"`
-				MmrSysUpdatedTime      types.ExTimestamp `json:"mmrSysUpdatedTime"`
-				StopLoss               types.ExDecimal   `json:"stopLoss"`
-				TradeMode              int               `json:"tradeMode"`
-				SessionAvgPrice        types.ExDecimal   `json:"sessionAvgPrice"`
-			} `json:"list"`
-		} `json:"result"`
-		Time types.ExTimestamp `json:"time"`
-	}
-	if err := json.Unmarshal(resp, &respData); err != nil {
-		return nil, fmt.Errorf("unmarshal positions: %w", err)
+	var respData bybitTradesResponse
+	if err = json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
 	}
 
 	if respData.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", respData.RetMsg)
+		return nil, newBybitError(respData.RetCode, respData.RetMsg)
 	}
 
-	positions := make([]*model.Position, 0)
-	for _, item := range respData.Result.List {
-		if item.Size.IsZero() {
-			continue
-		}
+	return common.AggregateTrades(respData.toTrades(market.Symbol), since, limit), nil
+}
 
-		market, err := p.GetMarket(item.Symbol)
-		if err != nil {
-			continue
-		}
+func (p *BybitPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
 
-		var side string
-		if strings.ToUpper(item.Side) == "BUY" {
-			side = string(types.PositionSideLong)
-		} else {
-			side = string(types.PositionSideShort)
-		}
+	req := types.NewExValues()
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
 
-		position := &model.Position{
-			Symbol:           market.Symbol,
-			Side:             side,
-			Amount:           item.Size,
-			EntryPrice:       item.AvgPrice,
-			MarkPrice:        item.MarkPrice,
-			UnrealizedPnl:    item.UnrealisedPnl,
-			LiquidationPrice: item.LiqPrice,
-			RealizedPnl:      item.CumRealisedPnl,
-			Leverage:         item.Leverage,
-			Margin:           item.PositionIM,
-			Percentage:       types.ExDecimal{},
-			Timestamp:        item.UpdatedTime,
-		}
+	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/tickers", req.ToQueryMap())
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate: %w", err)
+	}
 
-		positions = append(positions, position)
+	var result bybitPerpTickerResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate: %w", err)
 	}
 
-	return positions, nil
-}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
 
-func (p *BybitPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	// 解析选项
-	argsOpts := &option.ExchangeArgsOptions{}
-	for _, opt := range opts {
-		opt(argsOpts)
+	if len(result.Result.List) == 0 {
+		return nil, fmt.Errorf("ticker not found: %s", symbol)
 	}
 
-	// 获取市场信息
+	item := result.Result.List[0]
+	return &model.FundingRate{
+		Symbol:          market.Symbol,
+		Rate:            item.FundingRate,
+		Timestamp:       result.Time,
+		NextFundingTime: item.NextFundingTime,
+	}, nil
+}
+
+func (p *BybitPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
 	market, err := p.GetMarket(symbol)
 	if err != nil {
 		return nil, err
 	}
 
 	req := types.NewExValues()
-	req.SetBody("category", "linear")
-	req.SetBody("symbol", market.ID)
-
-	// 设置限价单价格
-	if orderType == option.Limit {
-		price, ok := option.GetDecimalFromString(argsOpts.Price)
-		if !ok || price.IsZero() {
-			return nil, fmt.Errorf("limit order requires price")
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
+	if limit > 0 {
+		req.SetQuery("limit", limit)
+	}
+	if !since.IsZero() {
+		// startTime 须与 endTime 同时传入，接口返回时间窗口内最新的记录；
+		// 窗口按最短结算周期（1 小时）计算，保证返回的记录从 since 开始
+		window := bybitFundingHistoryLimit
+		if limit > 0 && limit < window {
+			window = limit
 		}
-		req.SetBody("price", price.String())
-		// Limit 单默认使用 GTC
-		req.SetBody("timeInForce", option.GTC.Upper())
+		end := since.Add(time.Duration(window) * time.Hour)
+		if now := time.Now(); end.After(now) {
+			end = now
+		}
+		req.SetQuery("startTime", since.UnixMilli())
+		req.SetQuery("endTime", end.UnixMilli())
 	}
 
-	if argsOpts.TimeInForce != nil {
-		req.SetBody("timeInForce", argsOpts.TimeInForce.Upper())
+	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/funding/history", req.ToQueryMap())
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate history: %w", err)
 	}
 
-	// 设置数量
-	if quantity, ok := option.GetDecimalFromString(&amount); ok {
-		req.SetBody("qty", quantity.String())
-	} else {
-		return nil, fmt.Errorf("amount is required and must be a valid decimal")
+	var result bybitFundingHistoryResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate history: %w", err)
 	}
 
-	// Bybit API requires "Buy" or "Sell" (capitalized)
-	sideStr := orderSide.ToSide()
-	if len(sideStr) > 0 {
-		sideStr = strings.ToUpper(sideStr[:1]) + strings.ToLower(sideStr[1:])
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
-	req.SetBody("side", sideStr)
-	req.SetBody("orderType", orderType.Capitalize())
-	req.SetBody("reduceOnly", orderSide.ToReduceOnly())
 
-	if hedgeMode, ok := option.GetBool(argsOpts.HedgeMode); hedgeMode && ok {
-		// 双向持仓模式
-		// 开多/平多: positionIdx=1
-		// 开空/平空: positionIdx=2
-		if orderSide.ToPositionSide() == "LONG" {
-			req.SetBody("positionIdx", 1)
-		} else {
-			req.SetBody("positionIdx", 2)
-		}
-	} else {
-		req.SetBody("positionIdx", 0)
+	// 接口按时间倒序返回
+	list := result.Result.List
+	rates := make(model.FundingRates, 0, len(list))
+	for i := len(list) - 1; i >= 0; i-- {
+		rates = append(rates, &model.FundingRate{
+			Symbol:    market.Symbol,
+			Rate:      list[i].FundingRate,
+			Timestamp: list[i].FundingRateTimestamp,
+		})
 	}
+	return rates, nil
+}
 
-	if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
-		req.SetBody("orderLinkId", clientOrderId)
-	} else {
-		// 生成订单 ID
-		generatedID := common.GenerateClientOrderID(p.bybit.Name(), orderSide.ToSide())
-		req.SetBody("orderLinkId", generatedID)
+// FetchOpenInterest 获取持仓量（取自行情的 openInterest），反向合约持仓量为张数，价值按每张面值换算为美元
+func (p *BybitPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := p.signAndRequest(ctx, "POST", "/v5/order/create", nil, req.ToBodyMap())
+	req := types.NewExValues()
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
+
+	resp, err := p.bybit.client.HTTPClient.Get(ctx, "/v5/market/tickers", req.ToQueryMap())
 	if err != nil {
-		return nil, fmt.Errorf("create order: %w", err)
+		return nil, fmt.Errorf("fetch open interest: %w", err)
 	}
 
-	var respData struct {
-		RetCode int    `json:"retCode"` // 返回码，0 表示成功
-		RetMsg  string `json:"retMsg"`  // 返回消息
-		Result  struct {
-			OrderID     string `json:"orderId"`     // 系统订单号
-			OrderLinkID string `json:"orderLinkId"` // 客户端订单ID
-		} `json:"result"`                                     // 订单结果
-		RetExtInfo map[string]interface{} `json:"retExtInfo"` // 扩展信息
-		Time       types.ExTimestamp      `json:"time"`       // 时间戳（毫秒）
-	}
-	if err := json.Unmarshal(resp, &respData); err != nil {
-		return nil, fmt.Errorf("unmarshal order: %w", err)
+	var result bybitPerpTickerResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal open interest: %w", err)
 	}
 
-	if respData.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", respData.RetMsg)
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
-	// 构建 NewOrder 对象
-	perpOrder := &model.NewOrder{
-		Symbol:        symbol,
-		OrderId:       respData.Result.OrderID,
-		ClientOrderID: respData.Result.OrderLinkID,
-		Timestamp:     respData.Time,
+	if len(result.Result.List) == 0 {
+		return nil, fmt.Errorf("ticker not found: %s", symbol)
 	}
 
-	return perpOrder, nil
+	item := result.Result.List[0]
+	value := item.OpenInterestValue
+	if market.Inverse {
+		// 反向合约的 openInterestValue 以币计价，改为按面值计算美元价值
+		contractValue, err := decimal.NewFromString(market.ContractValue)
+		if err != nil {
+			contractValue = decimal.NewFromInt(1)
+		}
+		value = types.ExDecimal{Decimal: item.OpenInterest.Mul(contractValue)}
+	}
+	return &model.OpenInterest{
+		Symbol:             market.Symbol,
+		OpenInterestAmount: item.OpenInterest,
+		OpenInterestValue:  value,
+		Timestamp:          result.Time,
+	}, nil
 }
 
-func (p *BybitPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
-	// 解析参数
-	argsOpts := &option.ExchangeArgsOptions{}
-	for _, opt := range opts {
-		opt(argsOpts)
-	}
+// FetchMarkPrice 获取标记价格和指数价格，Bybit 合约行情中包含 markPrice、indexPrice，同时返回最新成交价等字段
+func (p *BybitPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return p.FetchTicker(ctx, symbol)
+}
+
+// FetchIndexPrice 获取指数价格（合约行情中的 indexPrice）
+func (p *BybitPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.FetchTicker(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
+// wsManager 返回合约对应的公共 WebSocket 订阅，币本位合约使用 inverse 地址
+func (p *BybitPerp) wsManager(market *model.Market) *common.WSManager {
+	if market.Inverse {
+		return p.bybit.inverseWS
+	}
+	return p.bybit.perpWS
+}
+
+// bybitPerpCategory 返回合约的产品分类，币本位合约为 inverse
+func bybitPerpCategory(market *model.Market) string {
+	if market.Inverse {
+		return "inverse"
+	}
+	return "linear"
+}
+
+func (p *BybitPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 未指定交易对时分别查询 U本位（USDT 结算）和币本位合约持仓
+	var queries []map[string]interface{}
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		market, err := p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, map[string]interface{}{"category": bybitPerpCategory(market), "symbol": market.ID})
+	} else {
+		queries = append(queries,
+			map[string]interface{}{"category": "linear", "settleCoin": "USDT"},
+			map[string]interface{}{"category": "inverse"},
+		)
+	}
+
+	// 每页最多 200 条，按 nextPageCursor 翻页直到取完
+	positions := make([]*model.Position, 0)
+	for _, query := range queries {
+		query["limit"] = 200
+		for {
+			resp, err := p.signAndRequest(ctx, "GET", "/v5/position/list", query, nil)
+			if err != nil {
+				return nil, fmt.Errorf("fetch positions: %w", err)
+			}
+
+			var respData struct {
+				RetCode int    `json:"retCode"`
+				RetMsg  string `json:"retMsg"`
+				Result  struct {
+					Category string `json:"category"`
+					List     []struct {
+						Symbol                 string            `json:"symbol"`
+						Leverage               types.ExDecimal   `json:"leverage"`
+						AutoAddMargin          int               `json:"autoAddMargin"`
+						AvgPrice               types.ExDecimal   `json:"avgPrice"`
+						LiqPrice               types.ExDecimal   `json:"liqPrice"`
+						RiskLimitValue         types.ExDecimal   `json:"riskLimitValue"`
+						TakeProfit             types.ExDecimal   `json:"takeProfit"`
+						PositionValue          types.ExDecimal   `json:"positionValue"`
+						IsReduceOnly           bool              `json:"isReduceOnly"`
+						PositionIMByMp         types.ExDecimal   `json:"positionIMByMp"`
+						TpslMode               string            `json:"tpslMode"`
+						RiskId                 int               `json:"riskId"`
+						TrailingStop           types.ExDecimal   `json:"trailingStop"`
+						UnrealisedPnl          types.ExDecimal   `json:"unrealisedPnl"`
+						MarkPrice              types.ExDecimal   `json:"markPrice"`
+						AdlRankIndicator       int               `json:"adlRankIndicator"`
+						CumRealisedPnl         types.ExDecimal   `json:"cumRealisedPnl"`
+						PositionMM             types.ExDecimal   `json:"positionMM"`
+						CreatedTime            types.ExTimestamp `json:"createdTime"`
+						PositionIdx            int               `json:"positionIdx"`
+						PositionIM             types.ExDecimal   `json:"positionIM"`
+						PositionMMByMp         types.ExDecimal   `json:"positionMMByMp"`
+						Seq                    int64             `json:"seq"`
+						UpdatedTime            types.ExTimestamp `json:"updatedTime"`
+						Side                   string            `json:"side"`
+						BustPrice              types.ExDecimal   `json:"bustPrice"`
+						PositionBalance        types.ExDecimal   `json:"positionBalance"`
+						LeverageSysUpdatedTime types.ExTimestamp `json:"leverageSysUpdatedTime"`
+						CurRealisedPnl         types.ExDecimal   `json:"curRealisedPnl"`
+						Size                   types.ExDecimal   `json:"size"`
+						PositionStatus         string            `json:"positionStatus"`
+						MmrSysUpdatedTime      types.ExTimestamp `json:"mmrSysUpdatedTime"`
+						StopLoss               types.ExDecimal   `json:"stopLoss"`
+						TradeMode              int               `json:"tradeMode"`
+						SessionAvgPrice        types.ExDecimal   `json:"sessionAvgPrice"`
+					} `json:"list"`
+					NextPageCursor string `json:"nextPageCursor"`
+				} `json:"result"`
+				Time types.ExTimestamp `json:"time"`
+			}
+			if err := json.Unmarshal(resp, &respData); err != nil {
+				return nil, fmt.Errorf("unmarshal positions: %w", err)
+			}
+
+			if respData.RetCode != 0 {
+				return nil, newBybitError(respData.RetCode, respData.RetMsg)
+			}
+
+			for _, item := range respData.Result.List {
+				if item.Size.IsZero() {
+					continue
+				}
+
+				// 市场信息未加载时使用原始ID
+				symbol := item.Symbol
+				if market, err := p.GetMarket(item.Symbol); err == nil {
+					symbol = market.Symbol
+				}
+
+				var side string
+				if strings.ToUpper(item.Side) == "BUY" {
+					side = string(types.PositionSideLong)
+				} else {
+					side = string(types.PositionSideShort)
+				}
+
+				position := &model.Position{
+					Symbol:           symbol,
+					Side:             side,
+					Amount:           item.Size,
+					EntryPrice:       item.AvgPrice,
+					MarkPrice:        item.MarkPrice,
+					UnrealizedPnl:    item.UnrealisedPnl,
+					LiquidationPrice: item.LiqPrice,
+					RealizedPnl:      item.CumRealisedPnl,
+					Leverage:         item.Leverage,
+					Margin:           item.PositionIM,
+					Percentage:       types.ExDecimal{},
+					Timestamp:        item.UpdatedTime,
+				}
+
+				positions = append(positions, position)
+			}
+
+			cursor := respData.Result.NextPageCursor
+			if cursor == "" || cursor == query["cursor"] {
+				break
+			}
+			query["cursor"] = cursor
+		}
+	}
+
+	return positions, nil
+}
+
+// WatchPositions 轮询持仓并推送持仓变化，同时订阅私有频道 order，强平、自动减仓导致的减仓/平仓事件填充 Reason
+// 订单订阅失败时仍推送持仓变化，但不填充 Reason
+func (p *BybitPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := p.WatchOrders(ctx)
+	if err != nil {
+		orders = nil
+	}
+	return common.PollPositionsWithOrders(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	}, orders)
+}
+
+// WatchBalance 通过私有频道 wallet 订阅统一账户余额，每次推送账户全部币种的余额
+func (p *BybitPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.bybit.privateWS, bybitWSWalletTopic, parseBybitWSWallet)
+}
+
+// WatchOrders 通过私有频道 order 订阅 U本位和币本位合约订单更新，订单每次状态变化推送一次
+func (p *BybitPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, p.bybit.privateWS, bybitWSOrderTopic, p.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 order 的合约订单推送（WatchOrders 和 TrackOrder 共用）
+func (p *BybitPerp) parseWSOrders() common.WSParser[[]*model.PerpOrder] {
+	return parseBybitWSOrders(bybitPerpCategories, func(raw json.RawMessage) (*model.PerpOrder, bool) {
+		var item bybitPerpOrderItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		symbol := item.Symbol
+		if market, err := p.GetMarket(item.Symbol); err == nil {
+			symbol = market.Symbol
+		}
+		return toBybitPerpOrder(symbol, &item), true
+	})
+}
+
+// CreateOrder 创建订单，设置 option.WithTrailingStop 时为持仓设置跟踪止损（见 setTrailingStop）
+func (p *BybitPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	if option.StringPresent(argsOpts.TrailingCallbackRate) {
+		return p.setTrailingStop(ctx, symbol, orderSide, orderType, argsOpts)
+	}
+
+	order, err := common.CreateOrderIdempotent(ctx, p.bybit.client.HTTPClient, p.bybit.Name(), orderSide.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return p.createOrder(ctx, symbol, amount, orderSide, orderType, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.PerpOrder, error) {
+		return p.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		p.bybit.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+func (p *BybitPerp) createOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	params, err := p.buildOrderParams(symbol, amount, orderSide, orderType, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/v5/order/create", nil, params.body)
+	if err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	var respData bybitPerpCreateOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal order: %w", err)
+	}
+
+	if respData.RetCode != 0 {
+		return nil, newBybitError(respData.RetCode, respData.RetMsg)
+	}
+
+	return params.toNewOrder(respData.Result.OrderID, respData.Result.OrderLinkID, respData.Time)
+}
+
+// 跟踪止损回调比例范围（百分比）
+var (
+	bybitMinCallbackRate = decimal.NewFromFloat(0.1)
+	bybitMaxCallbackRate = decimal.NewFromInt(100)
+)
+
+// setTrailingStop Bybit 没有跟踪止损订单类型，通过 /v5/position/trading-stop 为持仓设置 trailingStop
+// trailingStop 为价格距离，按激活价（未设置时为标记价格）乘以回调比例换算；作用于整个持仓，只接受平仓方向的市价单
+// 返回的 NewOrder 没有订单ID
+func (p *BybitPerp) setTrailingStop(ctx context.Context, symbol string, orderSide option.PerpOrderSide, orderType option.OrderType, argsOpts *option.ExchangeArgsOptions) (*model.NewOrder, error) {
+	callbackRate, activationPrice, _, err := common.ParseTrailingStop(argsOpts, bybitMinCallbackRate, bybitMaxCallbackRate)
+	if err != nil {
+		return nil, err
+	}
+	if orderType != option.Market {
+		return nil, fmt.Errorf("trailing stop requires a market order: %w", common.ErrInvalidOrder)
+	}
+	if !orderSide.ToReduceOnly() {
+		return nil, fmt.Errorf("bybit trailing stop applies to an open position, use CloseLong or CloseShort: %w", common.ErrInvalidOrder)
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	refPrice := activationPrice
+	if !refPrice.IsPositive() {
+		ticker, err := p.FetchMarkPrice(ctx, symbol)
+		if err != nil {
+			return nil, fmt.Errorf("fetch mark price: %w", err)
+		}
+		refPrice = ticker.MarkPrice.Decimal
+	}
+	distance, err := common.PriceToPrecision(market, refPrice.Mul(callbackRate).Div(decimal.NewFromInt(100)).String())
+	if err != nil {
+		return nil, err
+	}
+	if d, _ := decimal.NewFromString(distance); !d.IsPositive() {
+		return nil, fmt.Errorf("trailing stop distance rounds to zero at price %s: %w", refPrice, common.ErrInvalidOrder)
+	}
+
+	req := types.NewExValues()
+	req.SetBody("category", bybitPerpCategory(market))
+	req.SetBody("symbol", market.ID)
+	req.SetBody("tpslMode", "Full")
+	req.SetBody("trailingStop", distance)
+	if activationPrice.IsPositive() {
+		activePrice, err := common.PriceToPrecision(market, activationPrice.String())
+		if err != nil {
+			return nil, err
+		}
+		req.SetBody("activePrice", activePrice)
+	}
+
+	hedged := p.positionMode.Hedged(argsOpts.HedgeMode)
+	if market.Inverse {
+		hedged, _ = option.GetBool(argsOpts.HedgeMode)
+	}
+	switch {
+	case !hedged:
+		req.SetBody("positionIdx", 0)
+	case orderSide.ToPositionSide() == "LONG":
+		req.SetBody("positionIdx", 1)
+	default:
+		req.SetBody("positionIdx", 2)
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/v5/position/trading-stop", nil, req.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("set trailing stop: %w", err)
+	}
+
+	var respData bybitPerpTradingStopResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal trading stop: %w", err)
+	}
+	if respData.RetCode != 0 {
+		return nil, fmt.Errorf("set trailing stop: %w", newBybitError(respData.RetCode, respData.RetMsg))
+	}
+
+	return &model.NewOrder{
+		Symbol:       symbol,
+		Timestamp:    respData.Time,
+		CallbackRate: types.ExDecimal{Decimal: callbackRate},
+	}, nil
+}
+
+// buildOrderParams 校验并构建下单参数（单笔下单和批量下单共用）
+func (p *BybitPerp) buildOrderParams(symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*bybitOrderParams, error) {
+	// 解析选项
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 获取市场信息
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetBody("category", bybitPerpCategory(market))
+	req.SetBody("symbol", market.ID)
+
+	// 设置限价单价格
+	if orderType == option.Limit {
+		price, ok := option.GetDecimalFromString(argsOpts.Price)
+		if !ok || price.IsZero() {
+			return nil, fmt.Errorf("limit order requires price")
+		}
+		priceStr, err := common.PriceToPrecision(market, price.String())
+		if err != nil {
+			return nil, err
+		}
+		req.SetBody("price", priceStr)
+		// Limit 单默认使用 GTC
+		req.SetBody("timeInForce", option.GTC.Upper())
+	}
+
+	// 市价单固定为 IOC，不发送 timeInForce
+	if err := common.CheckTimeInForce(argsOpts, orderType == option.Limit, false); err != nil {
+		return nil, err
+	}
+	if orderType == option.Limit && argsOpts.TimeInForce != nil {
+		req.SetBody("timeInForce", argsOpts.TimeInForce.Upper())
+	}
+
+	// 只做 Maker 的限价单使用 PostOnly
+	postOnly, err := common.ParsePostOnly(argsOpts, orderType == option.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if postOnly {
+		req.SetBody("timeInForce", "PostOnly")
+	}
+
+	// 设置数量（向下对齐到数量步长）
+	quantity, ok := option.GetDecimalFromString(&amount)
+	if !ok {
+		return nil, fmt.Errorf("amount is required and must be a valid decimal")
+	}
+	qty, err := common.AmountToPrecision(market, quantity.String())
+	if err != nil {
+		return nil, err
+	}
+	req.SetBody("qty", qty)
+
+	// Bybit API requires "Buy" or "Sell" (capitalized)
+	sideStr := orderSide.ToSide()
+	if len(sideStr) > 0 {
+		sideStr = strings.ToUpper(sideStr[:1]) + strings.ToLower(sideStr[1:])
+	}
+	req.SetBody("side", sideStr)
+	req.SetBody("orderType", orderType.Capitalize())
+	req.SetBody("reduceOnly", common.ReduceOnly(orderSide, argsOpts))
+
+	// 跟踪止损作用于持仓，只能通过 CreateOrder 单独设置
+	if option.StringPresent(argsOpts.TrailingCallbackRate) {
+		return nil, fmt.Errorf("trailing stop in batch orders: %w", common.ErrNotSupported)
+	}
+
+	// 设置触发价时为条件单，triggerDirection 1 表示价格上涨到触发价时触发，2 表示下跌到触发价时触发
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if isStop {
+		req.SetBody("triggerPrice", stopPrice.String())
+		if triggerType.TriggersOnRise(orderSide.ToSide()) {
+			req.SetBody("triggerDirection", 1)
+		} else {
+			req.SetBody("triggerDirection", 2)
+		}
+	}
+
+	hedged := p.positionMode.Hedged(argsOpts.HedgeMode)
+	if market.Inverse {
+		hedged, _ = option.GetBool(argsOpts.HedgeMode)
+	}
+	if hedged {
+		// 双向持仓模式，由 positionIdx 区分多空仓位，平仓单同时带 reduceOnly
+		// 开多/平多: positionIdx=1
+		// 开空/平空: positionIdx=2
+		if orderSide.ToPositionSide() == "LONG" {
+			req.SetBody("positionIdx", 1)
+		} else {
+			req.SetBody("positionIdx", 2)
+		}
+	} else {
+		req.SetBody("positionIdx", 0)
+	}
+
+	clientOrderID, ok := option.GetString(argsOpts.ClientOrderID)
+	if !ok {
+		// 生成订单 ID
+		clientOrderID = common.GenerateClientOrderID(p.bybit.Name(), orderSide.ToSide())
+	}
+	req.SetBody("orderLinkId", clientOrderID)
+
+	strict, ok := option.GetBool(argsOpts.StrictClientID)
+	return &bybitOrderParams{
+		symbol:        symbol,
+		category:      bybitPerpCategory(market),
+		body:          req.ToBodyMap(),
+		clientOrderID: clientOrderID,
+		stopPrice:     stopPrice,
+		strict:        ok && strict,
+	}, nil
+}
+
+// CreateOrders 批量创建订单（POST /v5/order/create-batch，每批最多 20 个）
+// 不同产品类型（linear/inverse）分别提交；参数校验失败的订单不提交，其错误写入对应位置
+func (p *BybitPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
+
+	var items []common.BatchItem[*bybitOrderParams]
+	for i, r := range requests {
+		params, err := p.buildOrderParams(r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, common.BatchItem[*bybitOrderParams]{Index: i, Group: params.category, Params: params})
+	}
+
+	err := common.SubmitBatches(ctx, items, 20, orders, errs, func(ctx context.Context, category string, batch []*bybitOrderParams) ([]*model.NewOrder, []error, error) {
+		return submitBybitBatchOrders(ctx, p.signAndRequest, category, batch)
+	})
+	for _, order := range orders {
+		if order != nil {
+			p.bybit.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: order.Symbol, OrderID: order.OrderId})
+		}
+	}
+	return orders, errs, err
+}
+
+// ClosePosition 以只减仓市价单平掉持仓，数量与持仓单位一致（USDT 合约为币数量，反向合约为张数）
+func (p *BybitPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	_, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
+}
+
+func (p *BybitPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if err := p.cancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	p.bybit.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+func (p *BybitPerp) cancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	// 解析参数
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
 
 	// 获取市场信息
 	market, err := p.GetMarket(symbol)
@@ -669,7 +1339,7 @@ func (p *BybitPerp) CancelOrder(ctx context.Context, symbol string, orderId stri
 	}
 
 	req := types.NewExValues()
-	req.SetBody("category", "linear")
+	req.SetBody("category", bybitPerpCategory(market))
 	req.SetBody("symbol", market.ID)
 
 	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
@@ -686,6 +1356,11 @@ func (p *BybitPerp) CancelOrder(ctx context.Context, symbol string, orderId stri
 		return err
 	}
 
+	// 部分撤单接口成功时返回空响应体
+	if common.IsEmptyBody(resp) {
+		return nil
+	}
+
 	var respData struct {
 		RetCode int    `json:"retCode"`
 		RetMsg  string `json:"retMsg"`
@@ -695,13 +1370,17 @@ func (p *BybitPerp) CancelOrder(ctx context.Context, symbol string, orderId stri
 	}
 
 	if respData.RetCode != 0 {
-		return fmt.Errorf("cancel order fail: %d %s", respData.RetCode, respData.RetMsg)
+		return fmt.Errorf("cancel order fail: %w", newBybitError(respData.RetCode, respData.RetMsg))
 	}
 
 	return nil
 }
 
-func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+func (p *BybitPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -715,9 +1394,55 @@ func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId strin
 	}
 
 	req := types.NewExValues()
-	req.SetQuery("category", "linear")
-	req.SetQuery("symbol", market.ID)
+	req.SetBody("category", bybitPerpCategory(market))
+	req.SetBody("symbol", market.ID)
 
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		req.SetBody("orderId", orderId)
+	} else if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		req.SetBody("orderLinkId", clientOrderId)
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+	if newAmount != "" {
+		req.SetBody("qty", newAmount)
+	}
+	if newPrice != "" {
+		req.SetBody("price", newPrice)
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/v5/order/amend", nil, req.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var respData bybitAmendOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	if respData.RetCode != 0 {
+		return nil, fmt.Errorf("edit order fail: %w", newBybitError(respData.RetCode, respData.RetMsg))
+	}
+
+	// 改单接口只返回订单ID，查询最新订单
+	return p.FetchOrder(ctx, symbol, respData.Result.OrderID, opts...)
+}
+
+// FetchOrder 查询订单，symbol 为空时仅按订单ID查询（见 fetchOrderWithoutSymbol）
+func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	// 解析参数
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	req := types.NewExValues()
 	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
 	if orderId != "" {
 		req.SetQuery("orderId", orderId)
@@ -726,6 +1451,17 @@ func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId strin
 	} else {
 		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
+	if symbol == "" {
+		return p.fetchOrderWithoutSymbol(ctx, req)
+	}
+
+	// 获取市场信息
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	req.SetQuery("category", bybitPerpCategory(market))
+	req.SetQuery("symbol", market.ID)
 
 	// First try to fetch from open orders (realtime)
 	resp, err := p.signAndRequest(ctx, "GET", "/v5/order/realtime", req.ToQueryMap(), nil)
@@ -737,23 +1473,7 @@ func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId strin
 		RetCode int    `json:"retCode"`
 		RetMsg  string `json:"retMsg"`
 		Result  struct {
-			List []struct {
-				OrderID     string            `json:"orderId"`     // 订单ID
-				OrderLinkID string            `json:"orderLinkId"` // 客户端自定义订单ID
-				Symbol      string            `json:"symbol"`      // 交易对 / 合约标的
-				Price       types.ExDecimal   `json:"price"`       // 下单价格
-				AvgPrice    types.ExDecimal   `json:"avgPrice"`    // 成交均价
-				Qty         types.ExDecimal   `json:"qty"`         // 下单数量
-				CumExecQty  types.ExDecimal   `json:"cumExecQty"`  // 实际成交数量
-				OrderStatus string            `json:"orderStatus"` // 订单状态
-				TimeInForce string            `json:"timeInForce"` // 订单有效方式
-				ReduceOnly  bool              `json:"reduceOnly"`  // 是否只减仓
-				OrderType   string            `json:"orderType"`   // 订单类型
-				Side        string            `json:"side"`        // 订单方向
-				PositionIdx int               `json:"positionIdx"` // 单向持仓 positionIdx 等于 0，双向持仓 开多/平多 → positionIdx 等于 1，开空/平空 → positionIdx 等于 2
-				CreatedTime types.ExTimestamp `json:"createdTime"` // 创建时间（毫秒）
-				UpdatedTime types.ExTimestamp `json:"updatedTime"` // 更新时间（毫秒）
-			} `json:"list"`
+			List []bybitPerpOrderItem `json:"list"`
 		} `json:"result"`
 	}
 
@@ -762,16 +1482,63 @@ func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId strin
 	}
 
 	if respData.RetCode != 0 {
-		return nil, fmt.Errorf("fetch order: %d %s", respData.RetCode, respData.RetMsg)
+		return nil, fmt.Errorf("fetch order: %w", newBybitError(respData.RetCode, respData.RetMsg))
 	}
 
 	if len(respData.Result.List) == 0 {
-		return nil, fmt.Errorf("order not found")
+		return nil, common.ErrOrderNotFound
+	}
+
+	return toBybitPerpOrder(symbol, &respData.Result.List[0]), nil
+}
+
+// fetchOrderWithoutSymbol 按订单ID查询未指定交易对的订单
+// /v5/order/history 的 linear、inverse 分类不要求 symbol，依次查询，订单的 symbol 由交易所返回的市场ID换算
+// 历史订单接口有延迟，刚提交的订单可能暂时查不到
+func (p *BybitPerp) fetchOrderWithoutSymbol(ctx context.Context, req *types.ExValues) (*model.PerpOrder, error) {
+	for _, category := range []string{"linear", "inverse"} {
+		req.SetQuery("category", category)
+		resp, err := p.signAndRequest(ctx, "GET", "/v5/order/history", req.ToQueryMap(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetch order: %w", err)
+		}
+
+		var respData struct {
+			RetCode int    `json:"retCode"`
+			RetMsg  string `json:"retMsg"`
+			Result  struct {
+				List []bybitPerpOrderItem `json:"list"`
+			} `json:"result"`
+		}
+		if err := json.Unmarshal(resp, &respData); err != nil {
+			return nil, fmt.Errorf("fetch order: %w", err)
+		}
+		if respData.RetCode != 0 {
+			return nil, fmt.Errorf("fetch order: %w", newBybitError(respData.RetCode, respData.RetMsg))
+		}
+		if len(respData.Result.List) == 0 {
+			continue
+		}
+
+		item := &respData.Result.List[0]
+		symbol := item.Symbol
+		if market, err := p.GetMarketByID(item.Symbol); err == nil {
+			symbol = market.Symbol
+		}
+		return toBybitPerpOrder(symbol, item), nil
 	}
+	return nil, common.ErrOrderNotFound
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (p *BybitPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
 
-	originOrder := respData.Result.List[0]
+// toBybitPerpOrder 将 Bybit 订单转换为 model.PerpOrder（查询订单和私有频道推送共用）
+func toBybitPerpOrder(symbol string, item *bybitPerpOrderItem) *model.PerpOrder {
 	var positionSide string
-	switch originOrder.PositionIdx {
+	switch item.PositionIdx {
 	case 1:
 		positionSide = "LONG"
 	case 2:
@@ -780,25 +1547,57 @@ func (p *BybitPerp) FetchOrder(ctx context.Context, symbol string, orderId strin
 		positionSide = "NET"
 	}
 
-	order := &model.PerpOrder{
-		ID:               originOrder.OrderID,
-		ClientID:         originOrder.OrderLinkID,
-		Type:             originOrder.OrderType,
-		Side:             originOrder.Side,
+	return &model.PerpOrder{
+		ID:               item.OrderID,
+		ClientID:         item.OrderLinkID,
+		Type:             item.OrderType,
+		Side:             item.Side,
 		PositionSide:     positionSide,
 		Symbol:           symbol,
-		Price:            originOrder.Price,
-		AvgPrice:         originOrder.AvgPrice,
-		Quantity:         originOrder.Qty,
-		ExecutedQuantity: originOrder.CumExecQty,
-		Status:           originOrder.OrderStatus,
-		TimeInForce:      originOrder.TimeInForce,
-		ReduceOnly:       originOrder.ReduceOnly,
-		CreateTime:       originOrder.CreatedTime,
-		UpdateTime:       originOrder.UpdatedTime,
+		Price:            item.Price,
+		AvgPrice:         item.AvgPrice,
+		Quantity:         item.Qty,
+		ExecutedQuantity: item.CumExecQty,
+		Status:           item.OrderStatus,
+		TimeInForce:      item.TimeInForce,
+		ReduceOnly:       item.ReduceOnly,
+		CreateTime:       item.CreatedTime,
+		UpdateTime:       item.UpdatedTime,
+		Reason:           bybitPerpOrderReason(item.CreateType),
 	}
+}
 
-	return order, nil
+// bybitPerpOrderReason 根据订单创建类型识别强平（CreateByLiq/CreateByTakeOver_PassThrough）和自动减仓（CreateByAdl_PassThrough）订单
+func bybitPerpOrderReason(createType string) string {
+	switch createType {
+	case "CreateByLiq", "CreateByTakeOver_PassThrough":
+		return model.PositionReasonLiquidation
+	case "CreateByAdl_PassThrough":
+		return model.PositionReasonADL
+	default:
+		return ""
+	}
+}
+
+// FetchMyTradesRange 查询账户成交记录，按 7 天时间窗口和 nextPageCursor 翻页
+func (p *BybitPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return fetchBybitMyTradesRange(ctx, p.signAndRequest, bybitPerpCategory(market), market, since, until)
+}
+
+func (p *BybitPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := p.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.PerpOrder]) (<-chan []*model.PerpOrder, error) {
+		return common.WatchTopicResync(ctx, p.bybit.privateWS, bybitWSOrderTopic, p.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.PerpOrder, error) {
+		return p.FetchOrder(ctx, symbol, orderId)
+	}, common.PerpOrderFillSnapshot)
 }
 
 func (p *BybitPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
@@ -812,12 +1611,12 @@ func (p *BybitPerp) SetLeverage(ctx context.Context, symbol string, leverage int
 		return err
 	}
 
-	if leverage < 1 || leverage > 125 {
-		return fmt.Errorf("leverage must be between 1 and 125")
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
 	}
 
 	req := types.NewExValues()
-	req.SetBody("category", "linear")
+	req.SetBody("category", bybitPerpCategory(market))
 	req.SetBody("symbol", market.ID)
 	req.SetBody("buyLeverage", leverage)
 	req.SetBody("sellLeverage", leverage)
@@ -836,12 +1635,84 @@ func (p *BybitPerp) SetLeverage(ctx context.Context, symbol string, leverage int
 	}
 
 	if respData.RetCode != 0 {
-		return fmt.Errorf("set leverage fail: %d %s", respData.RetCode, respData.RetMsg)
+		return fmt.Errorf("set leverage fail: %w", newBybitError(respData.RetCode, respData.RetMsg))
 	}
 
 	return nil
 }
 
+// SetLeverageSide 单独设置多头（buyLeverage）或空头（sellLeverage）杠杆
+// 接口要求同时提交两个方向，另一方向沿用 /v5/position/list 返回的当前杠杆（positionIdx 1 为多头、2 为空头，单向持仓为 0）
+func (p *BybitPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	if err := common.CheckPositionSide(side); err != nil {
+		return err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
+	}
+
+	resp, err := p.signAndRequest(ctx, "GET", "/v5/position/list", map[string]interface{}{
+		"category": bybitPerpCategory(market),
+		"symbol":   market.ID,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("set leverage: %w", err)
+	}
+
+	var positions bybitPerpPositionLeverageResponse
+	if err := json.Unmarshal(resp, &positions); err != nil {
+		return fmt.Errorf("unmarshal positions: %w", err)
+	}
+	if positions.RetCode != 0 {
+		return fmt.Errorf("set leverage: %w", newBybitError(positions.RetCode, positions.RetMsg))
+	}
+
+	otherIdx := 2
+	if side.IsShort() {
+		otherIdx = 1
+	}
+	var other types.ExDecimal
+	for _, item := range positions.Result.List {
+		if item.PositionIdx == otherIdx || (item.PositionIdx == 0 && other.IsZero()) {
+			other = item.Leverage
+		}
+	}
+	if other.IsZero() {
+		return fmt.Errorf("set leverage: current leverage of %s not found", market.Symbol)
+	}
+
+	buyLeverage, sellLeverage := strconv.Itoa(leverage), other.String()
+	if side.IsShort() {
+		buyLeverage, sellLeverage = sellLeverage, buyLeverage
+	}
+
+	resp, err = p.signAndRequest(ctx, "POST", "/v5/position/set-leverage", nil, map[string]interface{}{
+		"category":     bybitPerpCategory(market),
+		"symbol":       market.ID,
+		"buyLeverage":  buyLeverage,
+		"sellLeverage": sellLeverage,
+	})
+	if err != nil {
+		return fmt.Errorf("set leverage: %w", err)
+	}
+
+	var respData struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return fmt.Errorf("unmarshal set leverage: %w", err)
+	}
+	if respData.RetCode != 0 {
+		return fmt.Errorf("set leverage: %w", newBybitError(respData.RetCode, respData.RetMsg))
+	}
+	return nil
+}
+
 func (p *BybitPerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -878,7 +1749,7 @@ func (p *BybitPerp) SetMarginType(ctx context.Context, symbol string, marginType
 	}
 
 	if respData.RetCode != 0 {
-		return fmt.Errorf("set margin type fail: %d %s", respData.RetCode, respData.RetMsg)
+		return fmt.Errorf("set margin type fail: %w", newBybitError(respData.RetCode, respData.RetMsg))
 	}
 
 	return nil
@@ -888,26 +1759,100 @@ func (p *BybitPerp) SetMarginType(ctx context.Context, symbol string, marginType
 
 // signAndRequest 签名并发送请求（Bybit v5 API）
 func (p *BybitPerp) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if p.bybit.client.SecretKey == "" {
+	creds := p.bybit.credentials()
+	if creds.secretKey == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
-	signature, timestamp := p.bybit.signer.SignRequest(method, params, body)
-	recvWindow := "5000"
+	signature, timestamp := creds.signer.SignRequest(method, params, body, p.bybit.clock.Timestamp(), p.bybit.recvWindow)
 
-	// 设置请求头
-	p.bybit.client.HTTPClient.SetHeader("X-BAPI-API-KEY", p.bybit.client.APIKey)
-	p.bybit.client.HTTPClient.SetHeader("X-BAPI-TIMESTAMP", timestamp)
-	p.bybit.client.HTTPClient.SetHeader("X-BAPI-RECV-WINDOW", recvWindow)
-	p.bybit.client.HTTPClient.SetHeader("X-BAPI-SIGN", signature)
-	p.bybit.client.HTTPClient.SetHeader("Content-Type", "application/json")
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"X-BAPI-API-KEY":     creds.apiKey,
+		"X-BAPI-TIMESTAMP":   timestamp,
+		"X-BAPI-RECV-WINDOW": p.bybit.recvWindow,
+		"X-BAPI-SIGN":        signature,
+		"Content-Type":       "application/json",
+	}
 
 	// 发送请求
 	if method == "GET" || method == "DELETE" {
-		return p.bybit.client.HTTPClient.Get(ctx, path, params)
+		return p.bybit.client.HTTPClient.RequestWithHeaders(ctx, http.MethodGet, path, params, nil, headers)
 	} else {
-		return p.bybit.client.HTTPClient.Post(ctx, path, body)
+		return p.bybit.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, nil, body, headers)
+	}
+}
+
+// SetPositionMode 设置 USDT 永续合约持仓模式（POST /v5/position/switch-mode，mode 3 为双向持仓，0 为单向持仓）
+func (p *BybitPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	mode := 0
+	if hedged {
+		mode = 3
+	}
+	body := map[string]interface{}{
+		"category": "linear",
+		"coin":     "USDT",
+		"mode":     mode,
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/v5/position/switch-mode", nil, body)
+	if err != nil {
+		return fmt.Errorf("set position mode: %w", err)
+	}
+
+	var respData struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return fmt.Errorf("unmarshal position mode: %w", err)
+	}
+	// 110025 表示已经是目标模式
+	if respData.RetCode != 0 && respData.RetCode != 110025 {
+		return fmt.Errorf("set position mode: %w", newBybitError(respData.RetCode, respData.RetMsg))
+	}
+
+	p.positionMode.Set(hedged)
+	return nil
+}
+
+// GetPositionMode 查询 USDT 永续合约持仓模式
+// Bybit 没有查询持仓模式的接口，由持仓的 positionIdx 推断；没有持仓时返回 SetPositionMode 设置的模式，都没有时返回 common.ErrNotSupported
+func (p *BybitPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	query := map[string]interface{}{
+		"category":   "linear",
+		"settleCoin": "USDT",
+	}
+	resp, err := p.signAndRequest(ctx, "GET", "/v5/position/list", query, nil)
+	if err != nil {
+		return false, fmt.Errorf("get position mode: %w", err)
+	}
+
+	var respData struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+		Result  struct {
+			List []struct {
+				PositionIdx int `json:"positionIdx"` // 0 单向持仓，1/2 双向持仓的多/空仓位
+			} `json:"list"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return false, fmt.Errorf("unmarshal position mode: %w", err)
+	}
+	if respData.RetCode != 0 {
+		return false, fmt.Errorf("get position mode: %w", newBybitError(respData.RetCode, respData.RetMsg))
+	}
+
+	if len(respData.Result.List) > 0 {
+		hedged := respData.Result.List[0].PositionIdx != 0
+		p.positionMode.Set(hedged)
+		return hedged, nil
+	}
+	if hedged, ok := p.positionMode.Get(); ok {
+		return hedged, nil
 	}
+	return false, fmt.Errorf("get position mode: %w: no open positions to infer the mode from", common.ErrNotSupported)
 }
 
 var _ exchange.PerpExchange = (*BybitPerp)(nil)
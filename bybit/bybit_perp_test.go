@@ -2,10 +2,21 @@ package bybit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -194,3 +205,1128 @@ func TestBybitPerp_FetchTickers(t *testing.T) {
 		t.Logf("First ticker: %s, Last=%s", ticker.Symbol, ticker.Last.String())
 	}
 }
+
+func TestBybitPerp_FetchTicker_ServerTimestamp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/tickers" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+			{"symbol":"BTCUSDT","lastPrice":"50000","bid1Price":"49999.5","ask1Price":"50000.5","prevPrice24h":"49000",
+			 "highPrice24h":"51000","lowPrice24h":"48000","volume24h":"1000","turnover24h":"50000000"}
+		]},"time":1700000000456}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	ticker, err := ex.Perp().FetchTicker(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if got := ticker.Timestamp.UnixMilli(); got != 1700000000456 {
+		t.Errorf("Timestamp = %d, want server time 1700000000456", got)
+	}
+}
+
+// newBybitCategoryTickersServer 模拟按 category 返回行情的接口，每个请求延迟 delay，记录最大并发请求数
+func newBybitCategoryTickersServer(tb testing.TB, delay time.Duration, maxInFlight *atomic.Int32) (*httptest.Server, *Bybit) {
+	tb.Helper()
+	var inFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			cur := maxInFlight.Load()
+			if n <= cur || maxInFlight.CompareAndSwap(cur, n) {
+				break
+			}
+		}
+		time.Sleep(delay)
+
+		switch r.URL.Query().Get("category") {
+		case "linear":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+				{"symbol":"BTCUSDT","lastPrice":"50000","bid1Price":"49999.5","ask1Price":"50000.5"}]},"time":1700000000456}`))
+		case "inverse":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"inverse","list":[
+				{"symbol":"BTCUSD","lastPrice":"50010","bid1Price":"50009.5","ask1Price":"50010.5"}]},"time":1700000000456}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		srv.Close()
+		tb.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+	return srv, b
+}
+
+func TestBybitPerp_FetchTickers_Concurrent(t *testing.T) {
+	var maxInFlight atomic.Int32
+	srv, ex := newBybitCategoryTickersServer(t, 50*time.Millisecond, &maxInFlight)
+	defer srv.Close()
+
+	tickers, err := ex.Perp().FetchTickers(context.Background())
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	if got := maxInFlight.Load(); got != 2 {
+		t.Errorf("max in-flight requests = %d, want 2 (linear and inverse concurrently)", got)
+	}
+
+	// 结果按分类顺序合并（先 U本位后币本位）
+	if len(tickers) != 2 || tickers[0].Symbol != "BTC/USDT:USDT" || tickers[1].Symbol != "BTC/USD:BTC" {
+		t.Fatalf("unexpected tickers: %+v", tickers)
+	}
+	if tickers[1].Last.String() != "50010" {
+		t.Errorf("inverse Last = %s, want 50010", tickers[1].Last)
+	}
+}
+
+func BenchmarkBybitPerp_FetchTickers(b *testing.B) {
+	var maxInFlight atomic.Int32
+	srv, ex := newBybitCategoryTickersServer(b, 5*time.Millisecond, &maxInFlight)
+	defer srv.Close()
+
+	ctx := context.Background()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ex.Perp().FetchTickers(ctx); err != nil {
+			b.Fatalf("FetchTickers: %v", err)
+		}
+	}
+}
+
+func TestBybitPerp_FetchOrderBook_Inverse(t *testing.T) {
+	var gotCategory, gotLimit string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/orderbook" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotCategory = r.URL.Query().Get("category")
+		gotLimit = r.URL.Query().Get("limit")
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"s":"BTCUSD",
+			"b":[["50000","100"],["49999.5","200"]],"a":[["50000.5","150"]],
+			"ts":1700000000321,"u":18521288,"seq":7961638724},"time":1700000000456}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	book, err := ex.Perp().FetchOrderBook(context.Background(), "BTC/USD:BTC", 1000)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+	if gotCategory != "inverse" {
+		t.Errorf("category = %q, want inverse", gotCategory)
+	}
+	if gotLimit != "500" {
+		t.Errorf("limit = %q, want clamped 500", gotLimit)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 1 {
+		t.Fatalf("got %d bids / %d asks, want 2/1", len(book.Bids), len(book.Asks))
+	}
+	if book.Bids[1].Price.String() != "49999.5" || book.Bids[1].Amount.String() != "200" {
+		t.Errorf("bid[1] = %s@%s, want 200@49999.5", book.Bids[1].Amount, book.Bids[1].Price)
+	}
+	if book.Nonce != 18521288 {
+		t.Errorf("Nonce = %d, want 18521288", book.Nonce)
+	}
+	if got := book.Timestamp.UnixMilli(); got != 1700000000321 {
+		t.Errorf("Timestamp = %d, want 1700000000321", got)
+	}
+}
+
+func TestBybitPerp_InverseMarket(t *testing.T) {
+	var tickerCategory, orderCategory string
+	var positionCategories []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/instruments-info":
+			switch r.URL.Query().Get("category") {
+			case "linear":
+				w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[{"symbol":"BTCUSDT","contractType":"LinearPerpetual",
+					"status":"Trading","baseCoin":"BTC","quoteCoin":"USDT","lotSizeFilter":{"qtyStep":"0.001","minOrderQty":"0.001"},
+					"priceFilter":{"tickSize":"0.1"}}]}}`))
+			case "inverse":
+				w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[
+					{"symbol":"BTCUSD","contractType":"InversePerpetual","status":"Trading","baseCoin":"BTC","quoteCoin":"USD",
+					"lotSizeFilter":{"qtyStep":"1","minOrderQty":"1"},"priceFilter":{"tickSize":"0.5"}},
+					{"symbol":"BTCUSDZ25","contractType":"InverseFutures","status":"Trading","baseCoin":"BTC","quoteCoin":"USD"}]}}`))
+			default:
+				t.Errorf("unexpected category %q", r.URL.Query().Get("category"))
+			}
+		case "/v5/market/tickers":
+			tickerCategory = r.URL.Query().Get("category")
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"inverse","list":[
+				{"symbol":"BTCUSD","lastPrice":"50000","bid1Price":"49999.5","ask1Price":"50000.5"}]},"time":1700000000456}`))
+		case "/v5/order/create":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			orderCategory, _ = body["category"].(string)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1","orderLinkId":"inv-1"},"time":1700000000456}`))
+		case "/v5/position/list":
+			positionCategories = append(positionCategories, r.URL.Query().Get("category"))
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[]},"time":1700000000456}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	if err := ex.Perp().LoadMarkets(context.Background(), true); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+
+	market, err := ex.Perp().GetMarket("BTC/USD:BTC")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if market.ID != "BTCUSD" || !market.Inverse || market.Linear || market.Settle != "BTC" || market.ContractValue != "1" {
+		t.Errorf("unexpected inverse market: %+v", market)
+	}
+	if linear, err := ex.Perp().GetMarket("BTC/USDT:USDT"); err != nil || !linear.Linear {
+		t.Errorf("linear market = %+v, %v", linear, err)
+	}
+	if _, err := ex.Perp().GetMarket("BTCUSDZ25"); err == nil {
+		t.Error("inverse futures should be skipped")
+	}
+
+	if _, err := ex.Perp().FetchTicker(context.Background(), "BTC/USD:BTC"); err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if tickerCategory != "inverse" {
+		t.Errorf("ticker category = %q, want inverse", tickerCategory)
+	}
+
+	if _, err := ex.Perp().CreateOrder(context.Background(), "BTC/USD:BTC", "100", option.OpenLong, option.Market); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if orderCategory != "inverse" {
+		t.Errorf("order category = %q, want inverse", orderCategory)
+	}
+
+	if _, err := ex.Perp().FetchPositions(context.Background(), option.WithSymbol("BTC/USD:BTC")); err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if _, err := ex.Perp().FetchPositions(context.Background()); err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positionCategories) != 3 || positionCategories[0] != "inverse" ||
+		positionCategories[1] != "linear" || positionCategories[2] != "inverse" {
+		t.Errorf("position categories = %v, want [inverse linear inverse]", positionCategories)
+	}
+}
+
+func TestBybitPerp_FetchPositions_AllSymbols(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/position/list" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		queries = append(queries, q.Get("category")+"/"+q.Get("settleCoin")+"/"+q.Get("limit")+"/"+q.Get("cursor"))
+		switch {
+		case q.Get("category") == "linear" && q.Get("cursor") == "":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","nextPageCursor":"page2","list":[
+				{"symbol":"BTCUSDT","side":"Buy","size":"0.5","avgPrice":"60000","markPrice":"61000","positionIdx":0},
+				{"symbol":"ETHUSDT","side":"Sell","size":"2","avgPrice":"3000","markPrice":"2900","positionIdx":0},
+				{"symbol":"SOLUSDT","side":"","size":"0","positionIdx":0}]},"time":1700000000000}`))
+		case q.Get("category") == "linear":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","nextPageCursor":"","list":[
+				{"symbol":"XRPUSDT","side":"Buy","size":"100","avgPrice":"0.5","positionIdx":0}]},"time":1700000000000}`))
+		default:
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"inverse","nextPageCursor":"","list":[
+				{"symbol":"BTCUSD","side":"Sell","size":"300","avgPrice":"59000","positionIdx":0}]},"time":1700000000000}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "ETHUSDT", Symbol: "ETH/USDT:USDT", Base: "ETH", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+
+	positions, err := ex.Perp().FetchPositions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	want := []struct{ symbol, side, amount string }{
+		{"BTC/USDT:USDT", "long", "0.5"},
+		{"ETH/USDT:USDT", "short", "2"},
+		{"XRPUSDT", "long", "100"}, // 市场信息未加载时使用原始ID
+		{"BTC/USD:BTC", "short", "300"},
+	}
+	if len(positions) != len(want) {
+		t.Fatalf("positions = %d, want %d", len(positions), len(want))
+	}
+	for i, w := range want {
+		p := positions[i]
+		if p.Symbol != w.symbol || p.Side != w.side || p.Amount.String() != w.amount {
+			t.Errorf("position %d = %s/%s/%s, want %s/%s/%s", i, p.Symbol, p.Side, p.Amount, w.symbol, w.side, w.amount)
+		}
+	}
+	wantQueries := []string{"linear/USDT/200/", "linear/USDT/200/page2", "inverse//200/"}
+	if !slices.Equal(queries, wantQueries) {
+		t.Errorf("queries = %v, want %v", queries, wantQueries)
+	}
+}
+
+func TestParseBybitWSTicker_Delta(t *testing.T) {
+	parse := parseBybitWSTicker("BTC/USDT:USDT")
+
+	// 收到全量数据前的增量无法合并，跳过
+	if _, ok := parse([]byte(`{"topic":"tickers.BTCUSDT","type":"delta","ts":1700000000000,"data":{"lastPrice":"50000"}}`)); ok {
+		t.Error("expected delta before snapshot to be skipped")
+	}
+
+	ticker, ok := parse([]byte(`{"topic":"tickers.BTCUSDT","type":"snapshot","ts":1700000000000,"data":{"symbol":"BTCUSDT",
+		"lastPrice":"50000","bid1Price":"49999.5","ask1Price":"50000.5","prevPrice24h":"49000","highPrice24h":"51000",
+		"lowPrice24h":"48000","volume24h":"1000","turnover24h":"50000000"}}`))
+	if !ok {
+		t.Fatal("expected snapshot to be parsed")
+	}
+	if !ticker.Last.Equal(decimal.RequireFromString("50000")) || !ticker.Bid.Equal(decimal.RequireFromString("49999.5")) {
+		t.Errorf("snapshot Last/Bid = %s/%s", ticker.Last.String(), ticker.Bid.String())
+	}
+
+	// 增量只包含变化的字段，其余字段沿用全量数据
+	ticker, ok = parse([]byte(`{"topic":"tickers.BTCUSDT","type":"delta","ts":1700000001000,"data":{"symbol":"BTCUSDT",
+		"lastPrice":"50100","ask1Price":"50100.5"}}`))
+	if !ok {
+		t.Fatal("expected delta to be parsed")
+	}
+	if !ticker.Last.Equal(decimal.RequireFromString("50100")) {
+		t.Errorf("Last = %s, want 50100", ticker.Last.String())
+	}
+	if !ticker.Ask.Equal(decimal.RequireFromString("50100.5")) {
+		t.Errorf("Ask = %s, want 50100.5", ticker.Ask.String())
+	}
+	if !ticker.Bid.Equal(decimal.RequireFromString("49999.5")) || !ticker.High.Equal(decimal.RequireFromString("51000")) {
+		t.Errorf("expected unchanged fields to be kept, got Bid %s High %s", ticker.Bid.String(), ticker.High.String())
+	}
+	if ticker.Timestamp.UnixMilli() != 1700000001000 {
+		t.Errorf("Timestamp = %d, want 1700000001000", ticker.Timestamp.UnixMilli())
+	}
+}
+
+func TestBybitPerp_FetchOpenInterest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/tickers" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("category") {
+		case "linear":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+				{"symbol":"BTCUSDT","lastPrice":"50000","markPrice":"50001","openInterest":"51483.374","openInterestValue":"2574220182.37"}
+			]},"time":1700000000456}`))
+		case "inverse":
+			// 反向合约 openInterest 为张数（1 USD/张），openInterestValue 以币计价
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"inverse","list":[
+				{"symbol":"BTCUSD","lastPrice":"50000","markPrice":"50001","openInterest":"470916437","openInterestValue":"9418.15"}
+			]},"time":1700000000789}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true, ContractValue: "1"},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+	ctx := context.Background()
+
+	oi, err := ex.Perp().FetchOpenInterest(ctx, "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchOpenInterest: %v", err)
+	}
+	if oi.OpenInterestAmount.String() != "51483.374" || oi.OpenInterestValue.String() != "2574220182.37" || oi.Timestamp.UnixMilli() != 1700000000456 {
+		t.Errorf("unexpected linear open interest: amount=%s value=%s ts=%d", oi.OpenInterestAmount, oi.OpenInterestValue, oi.Timestamp.UnixMilli())
+	}
+
+	oi, err = ex.Perp().FetchOpenInterest(ctx, "BTC/USD:BTC")
+	if err != nil {
+		t.Fatalf("FetchOpenInterest inverse: %v", err)
+	}
+	if oi.OpenInterestAmount.String() != "470916437" || oi.OpenInterestValue.String() != "470916437" {
+		t.Errorf("unexpected inverse open interest: amount=%s value=%s", oi.OpenInterestAmount, oi.OpenInterestValue)
+	}
+
+	if _, err := ex.Perp().FetchOpenInterest(ctx, "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestBybitPerp_CreateOrders_MixedResults(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/order/create-batch" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		category, _ := body["category"].(string)
+		bodies[category] = body
+		switch category {
+		case "linear":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[{"orderId":"1","orderLinkId":"l-1"},{"orderId":"","orderLinkId":""}]},"retExtInfo":{"list":[{"code":0,"msg":"OK"},{"code":110007,"msg":"ab not enough for new order"}]},"time":1700000000000}`))
+		default:
+			w.Write([]byte(`{"retCode":10003,"retMsg":"API key is invalid.","result":{},"retExtInfo":{},"time":1700000000000}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+
+	orders, errs, err := ex.Perp().CreateOrders(context.Background(), []option.PerpOrderRequest{
+		{Symbol: "BTC/USDT:USDT", Amount: "0.01", Side: option.OpenLong, Type: option.Limit, Opts: []option.ArgsOption{option.WithPrice("30000"), option.WithClientOrderID("l-1")}},
+		{Symbol: "BTC/USD:BTC", Amount: "100", Side: option.OpenLong, Type: option.Market},
+		{Symbol: "BTC/USDT:USDT", Amount: "1000", Side: option.OpenShort, Type: option.Market},
+	})
+	if err == nil {
+		t.Fatal("expected batch-level error for inverse batch")
+	}
+	if len(orders) != 3 || len(errs) != 3 {
+		t.Fatalf("len(orders) = %d, len(errs) = %d, want 3", len(orders), len(errs))
+	}
+
+	linear := bodies["linear"]
+	if linear == nil {
+		t.Fatal("linear batch not submitted")
+	}
+	if request, _ := linear["request"].([]interface{}); len(request) != 2 {
+		t.Errorf("linear request = %v, want 2 orders", linear["request"])
+	} else if first, _ := request[0].(map[string]interface{}); first["price"] != "30000" || first["category"] != nil {
+		t.Errorf("unexpected first order: %v", first)
+	}
+
+	if errs[0] != nil || orders[0] == nil || orders[0].OrderId != "1" || orders[0].ClientOrderID != "l-1" {
+		t.Errorf("order 0 = %+v, %v", orders[0], errs[0])
+	}
+	if !errors.Is(errs[1], err) || orders[1] != nil {
+		t.Errorf("order 1 = %+v, %v, want batch error %v", orders[1], errs[1], err)
+	}
+	if !errors.Is(errs[2], common.ErrInsufficientFunds) || orders[2] != nil {
+		t.Errorf("order 2 = %+v, %v, want ErrInsufficientFunds", orders[2], errs[2])
+	}
+}
+
+func TestParseBybitWSKline(t *testing.T) {
+	candle, ok := parseBybitWSKline([]byte(`{"topic":"kline.1.BTCUSDT","type":"snapshot","ts":1700000050000,"data":[{"start":1700000040000,"end":1700000099999,
+		"interval":"1","open":"100","close":"100.5","high":"101","low":"99.5","volume":"12","turnover":"1206","confirm":false,"timestamp":1700000050000}]}`))
+	if !ok || candle.Closed {
+		t.Fatalf("in-progress candle = %+v, %v", candle, ok)
+	}
+
+	candle, ok = parseBybitWSKline([]byte(`{"topic":"kline.1.BTCUSDT","type":"snapshot","ts":1700000100000,"data":[{"start":1700000040000,"end":1700000099999,
+		"interval":"1","open":"100","close":"101","high":"102.5","low":"99","volume":"30","turnover":"3022.5","confirm":true,"timestamp":1700000100000}]}`))
+	if !ok || !candle.Closed {
+		t.Fatalf("closed candle = %+v, %v", candle, ok)
+	}
+	if candle.Timestamp.UnixMilli() != 1700000040000 || !candle.High.Equal(decimal.RequireFromString("102.5")) ||
+		!candle.Close.Equal(decimal.RequireFromString("101")) || !candle.Volume.Equal(decimal.RequireFromString("30")) {
+		t.Errorf("unexpected closed candle: %+v", candle)
+	}
+	if bybitKlineTopic("BTCUSDT", common.BybitTimeframe("1h")) != "kline.60.BTCUSDT" {
+		t.Errorf("topic = %s", bybitKlineTopic("BTCUSDT", common.BybitTimeframe("1h")))
+	}
+}
+
+func TestBybitPerp_WatchOrders_Relogin(t *testing.T) {
+	var conns atomic.Int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		n := conns.Add(1)
+
+		// 每次连接先鉴权再订阅
+		var auth struct {
+			Op   string        `json:"op"`
+			Args []interface{} `json:"args"`
+		}
+		if err := conn.ReadJSON(&auth); err != nil {
+			return
+		}
+		if auth.Op != "auth" || len(auth.Args) != 3 || auth.Args[0] != "key" {
+			t.Errorf("unexpected auth message: %+v", auth)
+		}
+		expires := int64(auth.Args[1].(float64))
+		if auth.Args[2] != NewSigner("secret").SignWSAuth(expires) {
+			t.Errorf("invalid auth signature")
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"success":true,"ret_msg":"","op":"auth","conn_id":"c1"}`))
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) != `{"op":"subscribe","args":["order"]}` {
+			t.Errorf("unexpected subscribe message: %s", msg)
+		}
+
+		if n == 1 {
+			// 现货订单被过滤，合约订单逐条推送；之后断开连接
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"topic":"order","creationTime":1700000000100,"data":[
+				{"category":"spot","symbol":"BTCUSDT","orderId":"s1","orderStatus":"New"},
+				{"category":"linear","symbol":"BTCUSDT","orderId":"p1","orderLinkId":"l1","side":"Buy","orderType":"Limit","price":"50000","qty":"0.01","cumExecQty":"0","avgPrice":"","orderStatus":"New","timeInForce":"GTC","reduceOnly":false,"positionIdx":1,"createdTime":"1700000000000","updatedTime":"1700000000100"},
+				{"category":"inverse","symbol":"BTCUSD","orderId":"p2","side":"Sell","orderType":"Market","price":"0","qty":"100","cumExecQty":"100","avgPrice":"50010","orderStatus":"Filled","positionIdx":0,"createdTime":"1700000000000","updatedTime":"1700000000100"}
+			]}`))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"topic":"order","creationTime":1700000000200,"data":[{"category":"linear","symbol":"BTCUSDT","orderId":"p1","orderStatus":"Cancelled","qty":"0.01","cumExecQty":"0","positionIdx":1,"createdTime":"1700000000000","updatedTime":"1700000000200"}]}`))
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+	b.privateWS = common.NewWSManager(common.NewAuthWSDialer(common.NewWSDialer("ws"+strings.TrimPrefix(srv.URL, "http"), ""), b.wsLogin), bybitPrivateWSProtocol{})
+	defer b.privateWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	orders, err := ex.Perp().WatchOrders(ctx)
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+
+	var got []*model.PerpOrder
+	for len(got) < 3 {
+		select {
+		case order := <-orders:
+			got = append(got, order)
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for orders, got %d", len(got))
+		}
+	}
+	if got[0].ID != "p1" || got[0].Symbol != "BTC/USDT:USDT" || got[0].PositionSide != "LONG" || got[0].ClientID != "l1" {
+		t.Errorf("unexpected linear order: %+v", got[0])
+	}
+	if got[1].ID != "p2" || got[1].Symbol != "BTC/USD:BTC" || got[1].Status != "Filled" {
+		t.Errorf("unexpected inverse order: %+v", got[1])
+	}
+	// 断线重连后重新鉴权并继续推送
+	if got[2].ID != "p1" || got[2].Status != "Cancelled" {
+		t.Errorf("unexpected order after reconnect: %+v", got[2])
+	}
+	if n := conns.Load(); n != 2 {
+		t.Errorf("got %d connections, want 2", n)
+	}
+}
+
+func TestParseBybitWSWallet(t *testing.T) {
+	topic, ok := bybitPrivateWSProtocol{}.Route([]byte(`{"id":"1","topic":"wallet","creationTime":1700000000000,"data":[]}`))
+	if !ok || topic != bybitWSWalletTopic {
+		t.Fatalf("Route = %q, %v", topic, ok)
+	}
+
+	balances, ok := parseBybitWSWallet([]byte(`{"id":"1","topic":"wallet","creationTime":1700000000000,"data":[{"accountType":"UNIFIED","coin":[
+		{"coin":"USDT","equity":"1000","totalOrderIM":"100","totalPositionIM":"50","totalPositionMM":"10","locked":"0"}
+	]}]}`))
+	if !ok || len(balances) != 1 {
+		t.Fatalf("parseBybitWSWallet = %v, %v", balances, ok)
+	}
+	bal := balances[0]
+	if bal.Currency != "USDT" || bal.Total.String() != "1000" || bal.Locked.String() != "150" || bal.Available.String() != "850" {
+		t.Errorf("unexpected balance: %+v", bal)
+	}
+	if bal.UpdatedAt.UnixMilli() != 1700000000000 {
+		t.Errorf("UpdatedAt = %d", bal.UpdatedAt.UnixMilli())
+	}
+}
+
+func TestBybitPerp_FetchMarkPrice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/tickers" || r.URL.Query().Get("category") != "linear" || r.URL.Query().Get("symbol") != "BTCUSDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+			{"symbol":"BTCUSDT","lastPrice":"16597.00","indexPrice":"16598.54","markPrice":"16596.00","prevPrice24h":"16464.50","highPrice24h":"30912.50","lowPrice24h":"15700.00","volume24h":"49337318","turnover24h":"2352.94950046","bid1Price":"16596.00","ask1Price":"16597.50"}
+		]},"time":1672376496682}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	ticker, err := ex.Perp().FetchMarkPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchMarkPrice: %v", err)
+	}
+	if ticker.MarkPrice.String() != "16596" || ticker.IndexPrice.String() != "16598.54" || ticker.Last.String() != "16597" {
+		t.Errorf("mark/index/last = %s/%s/%s, want 16596/16598.54/16597", ticker.MarkPrice, ticker.IndexPrice, ticker.Last)
+	}
+
+	index, err := ex.Perp().FetchIndexPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil || index.String() != "16598.54" {
+		t.Errorf("FetchIndexPrice = %s, %v", index, err)
+	}
+
+	if _, err := ex.Perp().FetchIndexPrice(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestBybitPerp_FetchOHLCVs_Precision(t *testing.T) {
+	// 收盘价超出 float64 精度，应原样解析为 decimal
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","symbol":"BTCUSDT","list":[
+			["1700000000000","65000.1","65100.5","64900.000000000000000001","65000.123456789012345678","12.345678901234567891","802500"]]},"time":1700000000001}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	ohlcvs, err := ex.Perp().FetchOHLCVs(context.Background(), "BTC/USDT:USDT", "1m", 1)
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Close.String() != "65000.123456789012345678" ||
+		ohlcvs[0].Low.String() != "64900.000000000000000001" || ohlcvs[0].Volume.String() != "12.345678901234567891" {
+		t.Errorf("ohlcvs = %+v, want high-precision values preserved", ohlcvs)
+	}
+}
+
+func TestBybitPerp_CreateOrder_ReduceOnlyPostOnly(t *testing.T) {
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": "http://127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	params, err := b.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.OpenShort, option.Limit, option.WithPrice("50000"), option.WithPostOnly(true), option.WithReduceOnly(true))
+	if err != nil {
+		t.Fatalf("buildOrderParams: %v", err)
+	}
+	if params.body["timeInForce"] != "PostOnly" || params.body["reduceOnly"] != "true" {
+		t.Errorf("timeInForce/reduceOnly = %v/%v, want PostOnly/true", params.body["timeInForce"], params.body["reduceOnly"])
+	}
+
+	if _, err := b.perp.buildOrderParams("BTC/USDT:USDT", "0.01", option.OpenShort, option.Market, option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only market order err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestBybitPerp_SetMarginType(t *testing.T) {
+	var bodies []map[string]interface{}
+	var retCode atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v5/account/set-margin-mode" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+		if code := retCode.Load(); code != 0 {
+			w.Write([]byte(`{"retCode":3400045,"retMsg":"Set margin mode failed","result":{}}`))
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"reasons":[]}}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	if err := ex.Perp().SetMarginType(ctx, "BTC/USDT:USDT", option.ISOLATED); err != nil {
+		t.Fatalf("SetMarginType isolated: %v", err)
+	}
+	if err := ex.Perp().SetMarginType(ctx, "BTC/USDT:USDT", option.CROSSED); err != nil {
+		t.Fatalf("SetMarginType cross: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0]["setMarginMode"] != "ISOLATED_MARGIN" || bodies[1]["setMarginMode"] != "REGULAR_MARGIN" {
+		t.Errorf("request bodies = %v, want ISOLATED_MARGIN then REGULAR_MARGIN", bodies)
+	}
+
+	if err := ex.Perp().SetMarginType(ctx, "BTC/USDT:USDT", option.MarginType("PORTFOLIO")); err == nil {
+		t.Error("expected error for unsupported margin type")
+	}
+	if len(bodies) != 2 {
+		t.Errorf("unsupported margin type sent a request")
+	}
+
+	retCode.Store(3400045)
+	if err := ex.Perp().SetMarginType(ctx, "BTC/USDT:USDT", option.ISOLATED); err == nil || !strings.Contains(err.Error(), "Set margin mode failed") {
+		t.Errorf("retCode error = %v, want API error", err)
+	}
+}
+
+func TestBybitPerp_CreateOrder_TrailingStop(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/tickers":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+				{"symbol":"BTCUSDT","lastPrice":"16597.00","indexPrice":"16598.54","markPrice":"16596.00"}]},"time":1672376496682}`))
+		case "/v5/position/trading-stop":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			bodies = append(bodies, body)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{},"time":1672376496700}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	market.Precision.Price = 2
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	// 未设置激活价时按标记价格换算价格距离：16596 * 1% = 165.96
+	order, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market, option.WithTrailingStop("1", ""))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.CallbackRate.String() != "1" || order.OrderId != "" {
+		t.Errorf("order CallbackRate/OrderId = %s/%q, want 1 and no order ID", order.CallbackRate, order.OrderId)
+	}
+	// 设置激活价时按激活价换算：20000 * 0.5% = 100
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseLong, option.Market, option.WithTrailingStop("0.5", "20000")); err != nil {
+		t.Fatalf("CreateOrder with activation price: %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("trading-stop requests = %d, want 2", len(bodies))
+	}
+	if bodies[0]["trailingStop"] != "165.96" || bodies[0]["tpslMode"] != "Full" || bodies[0]["category"] != "linear" {
+		t.Errorf("first request = %v, want trailingStop 165.96", bodies[0])
+	}
+	if _, ok := bodies[0]["activePrice"]; ok {
+		t.Errorf("unexpected activePrice without activation price: %v", bodies[0])
+	}
+	if bodies[1]["trailingStop"] != "100" || bodies[1]["activePrice"] != "20000" {
+		t.Errorf("second request = %v, want trailingStop 100 and activePrice 20000", bodies[1])
+	}
+
+	// 开仓方向和限价单不发送请求
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.OpenLong, option.Market, option.WithTrailingStop("1", "")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("open side err = %v, want ErrInvalidOrder", err)
+	}
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.01", option.CloseLong, option.Limit,
+		option.WithPrice("17000"), option.WithTrailingStop("1", "")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("limit order err = %v, want ErrInvalidOrder", err)
+	}
+	if len(bodies) != 2 {
+		t.Errorf("invalid trailing stops sent %d extra requests", len(bodies)-2)
+	}
+}
+
+func TestBybitPerp_LotSizeOrderQuantity(t *testing.T) {
+	var quantities []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/instruments-info":
+			if r.URL.Query().Get("category") != "linear" {
+				w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[]}}`))
+				return
+			}
+			// 合约不返回 basePrecision
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[{"symbol":"BTCUSDT","contractType":"LinearPerpetual",
+				"status":"Trading","baseCoin":"BTC","quoteCoin":"USDT","lotSizeFilter":{"qtyStep":"0.001","minOrderQty":"0.001","maxOrderQty":"1190"},
+				"priceFilter":{"tickSize":"0.10"}}]}}`))
+		case "/v5/order/create":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			qty, _ := body["qty"].(string)
+			quantities = append(quantities, qty)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1","orderLinkId":"a"},"time":1700000000456}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+	if err := ex.Perp().LoadMarkets(ctx, true); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+	market, err := ex.Perp().GetMarket("BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if market.Precision.Amount != 3 || market.ContractValue != "1" {
+		t.Errorf("amount precision/contract value = %d/%s, want 3/1", market.Precision.Amount, market.ContractValue)
+	}
+
+	for _, amount := range []string{"0.001", "0.0019"} {
+		if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", amount, option.OpenLong, option.Market); err != nil {
+			t.Fatalf("CreateOrder %s: %v", amount, err)
+		}
+	}
+	if len(quantities) != 2 || quantities[0] != "0.001" || quantities[1] != "0.001" {
+		t.Errorf("quantities = %v, want [0.001 0.001]", quantities)
+	}
+}
+
+func TestBybitPerp_FetchMyTradesRange(t *testing.T) {
+	since := time.UnixMilli(1700000000000)
+	until := since.Add(8 * 24 * time.Hour)
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v5/execution/list" || q.Get("category") != "linear" || q.Get("symbol") != "BTCUSDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests = append(requests, q.Get("startTime")+"/"+q.Get("endTime")+"/"+q.Get("cursor"))
+		switch {
+		case q.Get("startTime") == "1700000000000" && q.Get("cursor") == "":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"page2","list":[
+				{"symbol":"BTCUSDT","execId":"e3","orderId":"o2","side":"Sell","execQty":"0.2","execPrice":"61000","execValue":"12200","execTime":"1700000300000"},
+				{"symbol":"BTCUSDT","execId":"e2","orderId":"o1","side":"Buy","execQty":"0.1","execPrice":"60500","execValue":"6050","execTime":"1700000200000"}]}}`))
+		case q.Get("startTime") == "1700000000000":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"","list":[
+				{"symbol":"BTCUSDT","execId":"e2","orderId":"o1","side":"Buy","execQty":"0.1","execPrice":"60500","execValue":"6050","execTime":"1700000200000"},
+				{"symbol":"BTCUSDT","execId":"e1","orderId":"o1","side":"Buy","execQty":"0.1","execPrice":"60000","execValue":"6000","execTime":"1700000100000"}]}}`))
+		default:
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"nextPageCursor":"","list":[
+				{"symbol":"BTCUSDT","execId":"e4","orderId":"o3","side":"Buy","execQty":"0.3","execPrice":"62000","execValue":"18600","execTime":"1700650000000"}]}}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+
+	trades, err := ex.Perp().FetchMyTradesRange(context.Background(), "BTC/USDT:USDT", since, until)
+	if err != nil {
+		t.Fatalf("FetchMyTradesRange: %v", err)
+	}
+
+	// 第一个 7 天窗口按游标翻两页，随后前进到第二个窗口
+	wantRequests := []string{
+		"1700000000000/1700604799999/",
+		"1700000000000/1700604799999/page2",
+		"1700604800000/1700691199999/",
+	}
+	if !slices.Equal(requests, wantRequests) {
+		t.Errorf("requests = %v, want %v", requests, wantRequests)
+	}
+	var ids []string
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	if want := []string{"e1", "e2", "e3", "e4"}; !slices.Equal(ids, want) {
+		t.Fatalf("trade IDs = %v, want %v", ids, want)
+	}
+	if trades[2].Side != "sell" || trades[2].Cost.String() != "12200" || trades[2].Symbol != "BTC/USDT:USDT" {
+		t.Errorf("trade = %+v", trades[2])
+	}
+}
+
+func TestBybitPerp_SetLeverageSide(t *testing.T) {
+	var body map[string]interface{}
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/v5/position/list":
+			if q := r.URL.Query(); q.Get("category") != "linear" || q.Get("symbol") != "BTCUSDT" {
+				t.Errorf("position query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[
+				{"symbol":"BTCUSDT","positionIdx":1,"leverage":"10","size":"0"},
+				{"symbol":"BTCUSDT","positionIdx":2,"leverage":"5","size":"0"}]}}`))
+		case "/v5/position/set-leverage":
+			body = nil
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true}
+	market.Limits.Leverage.Min = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	market.Limits.Leverage.Max = types.ExDecimal{Decimal: decimal.NewFromInt(50)}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	// 设置空头杠杆时多头沿用当前的 10 倍
+	if err := ex.Perp().SetLeverageSide(ctx, "BTC/USDT:USDT", types.PositionSideShort, 20); err != nil {
+		t.Fatalf("SetLeverageSide short: %v", err)
+	}
+	if body["buyLeverage"] != "10" || body["sellLeverage"] != "20" || body["category"] != "linear" || body["symbol"] != "BTCUSDT" {
+		t.Errorf("short body = %v, want buyLeverage 10 sellLeverage 20", body)
+	}
+
+	if err := ex.Perp().SetLeverageSide(ctx, "BTC/USDT:USDT", types.PositionSideLong, 25); err != nil {
+		t.Fatalf("SetLeverageSide long: %v", err)
+	}
+	if body["buyLeverage"] != "25" || body["sellLeverage"] != "5" {
+		t.Errorf("long body = %v, want buyLeverage 25 sellLeverage 5", body)
+	}
+
+	// 超出最大杠杆时不发送请求
+	requests = 0
+	if err := ex.Perp().SetLeverageSide(ctx, "BTC/USDT:USDT", types.PositionSideLong, 75); !errors.Is(err, common.ErrLeverageExceeded) {
+		t.Errorf("over limit: err = %v, want ErrLeverageExceeded", err)
+	}
+	if err := ex.Perp().SetLeverage(ctx, "BTC/USDT:USDT", 75); !errors.Is(err, common.ErrLeverageExceeded) {
+		t.Errorf("SetLeverage over limit: err = %v, want ErrLeverageExceeded", err)
+	}
+	if requests != 0 {
+		t.Errorf("sent %d requests for over-limit leverage, want 0", requests)
+	}
+}
+
+func TestBybitPerp_FetchOrder_WithoutSymbol(t *testing.T) {
+	var categories []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/order/history" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Has("symbol") || q.Get("orderId") == "" {
+			t.Errorf("query = %s, want orderId without symbol", r.URL.RawQuery)
+		}
+		categories = append(categories, q.Get("category"))
+		if q.Get("category") == "linear" || q.Get("orderId") != "inv-1" {
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[]},"retExtInfo":{},"time":1700000000000}`))
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"inverse","list":[{"orderId":"inv-1","orderLinkId":"c-1","symbol":"BTCUSD","side":"Buy","orderType":"Limit","price":"30000","qty":"100","cumExecQty":"40","orderStatus":"PartiallyFilled","timeInForce":"GTC","positionIdx":0,"createdTime":"1700000000000","updatedTime":"1700000000000"}]},"retExtInfo":{},"time":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	for _, market := range []*model.Market{
+		{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Linear: true},
+		{ID: "BTCUSD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC", Inverse: true},
+	} {
+		b.perpMarketsBySymbol[market.Symbol] = market
+		b.perpMarketsByID[market.ID] = market
+	}
+
+	// 按订单ID查询，linear 未找到后查询 inverse，symbol 由市场ID换算
+	order, err := ex.Perp().FetchOrder(context.Background(), "", "inv-1")
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	if order.ID != "inv-1" || order.Symbol != "BTC/USD:BTC" || order.ExecutedQuantity.String() != "40" {
+		t.Errorf("order = %+v", order)
+	}
+	if !slices.Equal(categories, []string{"linear", "inverse"}) {
+		t.Errorf("categories = %v, want linear then inverse", categories)
+	}
+
+	if _, err := ex.Perp().FetchOrder(context.Background(), "", "missing"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("missing order: err = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestBybitPerp_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/order/amend":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			edits = append(edits, body)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"p1","orderLinkId":""},"retExtInfo":{},"time":1700000000000}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/order/realtime":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[{"orderId":"p1","symbol":"BTCUSDT","side":"Sell","orderType":"Limit","price":"50000.5","qty":"0.012","cumExecQty":"0","orderStatus":"New","timeInForce":"GTC","positionIdx":0,"createdTime":"1700000000000","updatedTime":"1700000000100"}]},"retExtInfo":{},"time":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, Linear: true}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.5")}
+	b.perpMarketsBySymbol[market.Symbol] = market
+	b.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	if _, err := ex.Perp().EditOrder(ctx, "BTC/USDT:USDT", "p1", "0.0129", "50000.9"); err != nil {
+		t.Fatalf("EditOrder: %v", err)
+	}
+	if e := edits[0]; e["category"] != "linear" || e["symbol"] != "BTCUSDT" || e["orderId"] != "p1" || e["qty"] != "0.012" || e["price"] != "50000.5" {
+		t.Errorf("edit body = %v, want qty 0.012 price 50000.5", e)
+	}
+
+	// 只改价格时不发送数量
+	if _, err := ex.Perp().EditOrder(ctx, "BTC/USDT:USDT", "p1", "", "51000"); err != nil {
+		t.Fatalf("EditOrder price: %v", err)
+	}
+	if e := edits[1]; e["price"] != "51000" || e["qty"] != nil {
+		t.Errorf("price edit body = %v, want price only", e)
+	}
+}
+
+func TestBybitPerp_OrderReason(t *testing.T) {
+	tests := map[string]string{
+		"CreateByLiq":                  model.PositionReasonLiquidation,
+		"CreateByTakeOver_PassThrough": model.PositionReasonLiquidation,
+		"CreateByAdl_PassThrough":      model.PositionReasonADL,
+		"CreateByUser":                 "",
+	}
+	for createType, want := range tests {
+		var item bybitPerpOrderItem
+		if err := json.Unmarshal([]byte(`{"orderId":"1","symbol":"BTCUSDT","orderStatus":"Filled","createType":"`+createType+`"}`), &item); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if got := toBybitPerpOrder("BTC/USDT:USDT", &item).Reason; got != want {
+			t.Errorf("%s: Reason = %q, want %q", createType, got, want)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
+	"net/http"
 	"strings"
 	"time"
 
@@ -46,10 +46,35 @@ func (s *BybitSpot) GetMarket(symbol string) (*model.Market, error) {
 	return s.market.GetMarket(symbol)
 }
 
+func (s *BybitSpot) GetMarketByID(id string) (*model.Market, error) {
+	return s.market.GetMarketByID(id)
+}
+
 func (s *BybitSpot) GetMarkets() ([]*model.Market, error) {
 	return s.market.GetMarkets()
 }
 
+func (s *BybitSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *BybitSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *BybitSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
 func (s *BybitSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
 	return s.market.FetchTicker(ctx, symbol)
 }
@@ -58,6 +83,65 @@ func (s *BybitSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker,
 	return s.market.FetchTickers(ctx)
 }
 
+func (s *BybitSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+func (s *BybitSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return s.market.FetchOrderBook(ctx, symbol, limit)
+}
+
+func (s *BybitSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.bybit.spotWS, "tickers."+market.ID, parseBybitWSTicker(market.Symbol))
+}
+
+func (s *BybitSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  s.bybit.spotWS,
+		Topic:    bybitOrderBookTopic(market.ID, depth, bybitSpotWSDepthLevels),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequenceNone,
+		Parse:    parseBybitWSOrderBook,
+	})
+}
+
+func (s *BybitSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.BybitTimeframe(timeframe), bybitTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.bybit.spotWS, bybitKlineTopic(market.ID, interval), parseBybitWSKline)
+}
+
 func (s *BybitSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -71,25 +155,189 @@ func (s *BybitSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe st
 	if argsOpts.Since != nil {
 		since = *argsOpts.Since
 	}
-	return s.market.FetchOHLCVs(ctx, symbol, timeframe, since, limit)
+	until, _ := option.GetTime(argsOpts.Until)
+	ohlcvs, err := s.market.FetchOHLCVs(ctx, symbol, timeframe, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.bybit.clock.Now())
+	}
+	return ohlcvs, nil
 }
 
-func (s *BybitSpot) FetchBalance(ctx context.Context) (model.Balances, error) {
-	return s.order.FetchBalance(ctx)
+func (s *BybitSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, bybitOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *BybitSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *BybitSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return s.market.FetchAggregatedTrades(ctx, symbol, since, limit)
+}
+
+func (s *BybitSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	return s.order.FetchBalance(ctx, opts...)
+}
+
+// WatchBalance 通过私有频道 wallet 订阅统一账户余额，每次推送账户全部币种的余额
+func (s *BybitSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.bybit.privateWS, bybitWSWalletTopic, parseBybitWSWallet)
 }
 
 func (s *BybitSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	order, err := common.CreateOrderIdempotent(ctx, s.bybit.client.HTTPClient, s.bybit.Name(), side.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.SpotOrder, error) {
+		return s.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		s.bybit.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+func (s *BybitSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders, errs, err := s.order.CreateOrders(ctx, requests)
+	for _, order := range orders {
+		if order != nil {
+			s.bybit.lifecycle.AddOrder(common.TrackedOrder{Symbol: order.Symbol, OrderID: order.OrderId})
+		}
+	}
+	return orders, errs, err
+}
+
+// CreateOCOOrder Bybit v5 没有原生现货 OCO 接口（两个独立条件单无法保证互相撤销，不做模拟）
+func (s *BybitSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, fmt.Errorf("create oco order: %w: Bybit has no native spot OCO order API", common.ErrNotSupported)
 }
 
 func (s *BybitSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
-	return s.order.CancelOrder(ctx, symbol, orderId, opts...)
+	if err := s.order.CancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	s.bybit.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *BybitSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return s.order.EditOrder(ctx, symbol, orderId, newAmount, newPrice, opts...)
 }
 
 func (s *BybitSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
 	return s.order.FetchOrder(ctx, symbol, orderId, opts...)
 }
 
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *BybitSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 查询账户成交记录，按 7 天时间窗口和 nextPageCursor 翻页
+func (s *BybitSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return fetchBybitMyTradesRange(ctx, s.order.signAndRequest, "spot", market, since, until)
+}
+
+func (s *BybitSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.SpotOrder]) (<-chan []*model.SpotOrder, error) {
+		return common.WatchTopicResync(ctx, s.bybit.privateWS, bybitWSOrderTopic, s.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.SpotOrder, error) {
+		return s.FetchOrder(ctx, symbol, orderId)
+	}, common.SpotOrderFillSnapshot)
+}
+
+// WatchOrders 通过私有频道 order 订阅现货订单更新，订单每次状态变化推送一次
+func (s *BybitSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	ctx, err := s.bybit.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, s.bybit.privateWS, bybitWSOrderTopic, s.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 order 的现货订单推送（WatchOrders 和 TrackOrder 共用）
+func (s *BybitSpot) parseWSOrders() common.WSParser[[]*model.SpotOrder] {
+	return parseBybitWSOrders([]string{"spot"}, func(raw json.RawMessage) (*model.SpotOrder, bool) {
+		var item bybitSpotFetchOrderItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		symbol := item.Symbol
+		if market, err := s.GetMarket(item.Symbol); err == nil {
+			symbol = market.Symbol
+		}
+		return s.order.parseOrder(item, symbol), true
+	})
+}
+
+func (s *BybitSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return s.order.CreateConversion(ctx, from, to, amount)
+}
+
+func (s *BybitSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return s.order.FetchCurrencies(ctx)
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *BybitSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *BybitSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return s.order.FetchDepositAddress(ctx, currency, network)
+}
+
+func (s *BybitSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return s.order.Withdraw(ctx, currency, amount, address, network, params)
+}
+
+func (s *BybitSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return s.order.Transfer(ctx, currency, amount, fromAccount, toAccount)
+}
+
+func (s *BybitSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return s.order.FetchBorrowRate(ctx, currency)
+}
+
+func (s *BybitSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "borrow", "/v5/account/borrow", currency, amount)
+}
+
+func (s *BybitSpot) Repay(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "repay", "/v5/account/repay", currency, amount)
+}
+
+func (s *BybitSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return s.order.FetchSubAccounts(ctx)
+}
+
+func (s *BybitSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return s.order.FetchBalanceFor(ctx, subAccountID)
+}
+
 var _ exchange.SpotExchange = (*BybitSpot)(nil)
 
 // ========== 内部实现 ==========
@@ -99,9 +347,9 @@ type bybitSpotMarket struct {
 }
 
 func (m *bybitSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	m.bybit.mu.RLock()
-	if !reload && len(m.bybit.spotMarketsBySymbol) > 0 {
+	if !reload && len(m.bybit.spotMarketsBySymbol) > 0 && !m.bybit.marketCache.Expired(model.MarketTypeSpot) {
 		m.bybit.mu.RUnlock()
 		return nil
 	}
@@ -121,7 +369,7 @@ func (m *bybitSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 	}
 
 	if result.RetCode != 0 {
-		return fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	markets := make([]*model.Market, 0)
@@ -142,7 +390,9 @@ func (m *bybitSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 			Active: s.Status == "Trading",
 		}
 
-		// 解析精度
+		// 解析精度（basePrecision 即数量步长）
+		market.Precision.StepSize = s.LotSizeFilter.BasePrecision
+		market.Precision.TickSize = s.PriceFilter.TickSize
 		basePrecision := s.LotSizeFilter.BasePrecision.InexactFloat64()
 		tickSize := s.PriceFilter.TickSize.InexactFloat64()
 		quotePrecision := s.LotSizeFilter.QuotePrecision.InexactFloat64()
@@ -164,16 +414,11 @@ func (m *bybitSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	m.bybit.mu.Lock()
-	if m.bybit.spotMarketsBySymbol == nil {
-		m.bybit.spotMarketsBySymbol = make(map[string]*model.Market)
-		m.bybit.spotMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		m.bybit.spotMarketsBySymbol[market.Symbol] = market
-		m.bybit.spotMarketsByID[market.ID] = market
-	}
+	m.bybit.spotMarketsBySymbol, m.bybit.spotMarketsByID = bySymbol, byID
+	m.bybit.marketCache.Touch(model.MarketTypeSpot)
 	m.bybit.mu.Unlock()
 
 	return nil
@@ -209,7 +454,18 @@ func (m *bybitSpotMarket) GetMarket(key string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", key)
+	return nil, common.MarketNotFound(key, m.bybit.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (m *bybitSpotMarket) GetMarketByID(id string) (*model.Market, error) {
+	m.bybit.mu.RLock()
+	defer m.bybit.mu.RUnlock()
+
+	if market, ok := m.bybit.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, m.bybit.spotMarketsByID)
 }
 
 func (m *bybitSpotMarket) GetMarkets() ([]*model.Market, error) {
@@ -256,7 +512,7 @@ func (m *bybitSpotMarket) FetchTicker(ctx context.Context, symbol string) (*mode
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	if len(result.Result.List) == 0 {
@@ -297,7 +553,7 @@ func (m *bybitSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.T
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	tickers := make(map[string]*model.Ticker)
@@ -326,7 +582,44 @@ func (m *bybitSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.T
 	return tickers, nil
 }
 
-func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since time.Time, limit int) (model.OHLCVs, error) {
+func (m *bybitSpotMarket) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"category": "spot",
+		"symbol":   market.ID,
+	}
+	if limit = common.ClampOrderBookLimit(limit, bybitSpotMaxDepthLimit); limit > 0 {
+		params["limit"] = limit
+	}
+
+	resp, err := m.bybit.client.HTTPClient.Get(ctx, "/v5/market/orderbook", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var result bybitOrderBookResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(result.Result.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(result.Result.Asks, limit),
+		Nonce:     result.Result.UpdateID,
+		Timestamp: result.Result.Timestamp,
+	}, nil
+}
+
+func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since, until time.Time, limit int) (model.OHLCVs, error) {
 	// 获取市场信息
 	market, err := m.GetMarket(symbol)
 	if err != nil {
@@ -334,7 +627,10 @@ func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefr
 	}
 
 	// 标准化时间框架
-	normalizedTimeframe := common.BybitTimeframe(timeframe)
+	normalizedTimeframe, err := common.CheckTimeframe(timeframe, common.BybitTimeframe(timeframe), bybitTimeframes)
+	if err != nil {
+		return nil, err
+	}
 
 	params := map[string]interface{}{
 		"symbol":   market.ID,
@@ -345,6 +641,9 @@ func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefr
 	if !since.IsZero() {
 		params["start"] = since.UnixMilli()
 	}
+	if !until.IsZero() {
+		params["end"] = until.UnixMilli()
+	}
 
 	resp, err := m.bybit.client.HTTPClient.Get(ctx, "/v5/market/kline", params)
 	if err != nil {
@@ -357,7 +656,7 @@ func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefr
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	ohlcvs := make(model.OHLCVs, 0, len(result.Result.List))
@@ -376,38 +675,83 @@ func (m *bybitSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefr
 	return ohlcvs, nil
 }
 
+// FetchAggregatedTrades 获取归集交易（Bybit 无原生接口，由最近逐笔成交归集）
+func (m *bybitSpotMarket) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.bybit.client.HTTPClient.Get(ctx, "/v5/market/recent-trade", map[string]interface{}{
+		"category": "spot",
+		"symbol":   market.ID,
+		"limit":    bybitSpotMaxTradesLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch trades: %w", err)
+	}
+
+	var result bybitTradesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return common.AggregateTrades(result.toTrades(market.Symbol), since, limit), nil
+}
+
 type bybitSpotOrder struct {
 	bybit *Bybit
 }
 
 // signAndRequest 签名并发送请求（Bybit v5 API）
 func (o *bybitSpotOrder) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if o.bybit.client.SecretKey == "" {
+	creds := o.bybit.credentials()
+	if creds.secretKey == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
-	signature, timestamp := o.bybit.signer.SignRequest(method, params, body)
-	recvWindow := "5000"
+	signature, timestamp := creds.signer.SignRequest(method, params, body, o.bybit.clock.Timestamp(), o.bybit.recvWindow)
 
-	// 设置请求头
-	o.bybit.client.HTTPClient.SetHeader("X-BAPI-API-KEY", o.bybit.client.APIKey)
-	o.bybit.client.HTTPClient.SetHeader("X-BAPI-TIMESTAMP", timestamp)
-	o.bybit.client.HTTPClient.SetHeader("X-BAPI-RECV-WINDOW", recvWindow)
-	o.bybit.client.HTTPClient.SetHeader("X-BAPI-SIGN", signature)
-	o.bybit.client.HTTPClient.SetHeader("Content-Type", "application/json")
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"X-BAPI-API-KEY":     creds.apiKey,
+		"X-BAPI-TIMESTAMP":   timestamp,
+		"X-BAPI-RECV-WINDOW": o.bybit.recvWindow,
+		"X-BAPI-SIGN":        signature,
+		"Content-Type":       "application/json",
+	}
 
 	// 发送请求
 	if method == "GET" || method == "DELETE" {
-		return o.bybit.client.HTTPClient.Get(ctx, path, params)
+		return o.bybit.client.HTTPClient.RequestWithHeaders(ctx, http.MethodGet, path, params, nil, headers)
 	} else {
-		return o.bybit.client.HTTPClient.Post(ctx, path, body)
+		return o.bybit.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, nil, body, headers)
 	}
 }
 
-func (o *bybitSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error) {
+// FetchBalance 获取余额，按 option.WithAccountType 选择账户（统一账户下现货、杠杆和合约均为 UNIFIED）
+func (o *bybitSpotOrder) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	accountType := option.GetAccountType(argsOpts.AccountType)
+	bybitAccountType, ok := bybitAccountTypes[accountType]
+	if !ok {
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+	if bybitAccountType == "FUND" {
+		return o.fetchFundingBalance(ctx)
+	}
+
 	// Bybit v5 统一账户余额
 	resp, err := o.signAndRequest(ctx, "GET", "/v5/account/wallet-balance", map[string]interface{}{
-		"accountType": "UNIFIED",
+		"accountType": bybitAccountType,
 	}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("fetch balance: %w", err)
@@ -419,33 +763,100 @@ func (o *bybitSpotOrder) FetchBalance(ctx context.Context) (model.Balances, erro
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
-	}
-
-	balances := make(model.Balances, 0)
-	if len(result.Result.List) > 0 {
-		for _, coin := range result.Result.List[0].Coin {
-			// 锁定的金额 = totalOrderIM + totalPositionIM + totalPositionMM + locked
-			locked := coin.TotalOrderIM.Add(coin.TotalPositionIM.Decimal).
-				Add(coin.TotalPositionMM.Decimal).
-				Add(coin.Locked.Decimal)
-			// 可用的余额 = equity - (totalOrderIM + totalPositionIM + totalPositionMM + locked)
-			available := coin.Equity.Sub(locked)
-			balance := &model.Balance{
-				Currency:  coin.Coin,
-				Total:     coin.Equity,
-				Locked:    types.ExDecimal{Decimal: locked},
-				Available: types.ExDecimal{Decimal: available},
-				UpdatedAt: result.Time,
-			}
-			balances = append(balances, balance)
-		}
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	if len(result.Result.List) == 0 {
+		return model.Balances{}, nil
+	}
+	return toBybitBalances(result.Result.List[0].Coin, result.Time), nil
+}
+
+// toBybitBalances 将统一账户币种余额转换为 model.Balances（查询余额和私有频道 wallet 推送共用）
+func toBybitBalances(coins []bybitSpotBalanceCoin, updatedAt types.ExTimestamp) model.Balances {
+	balances := make(model.Balances, 0, len(coins))
+	for _, coin := range coins {
+		// 锁定的金额 = totalOrderIM + totalPositionIM + locked（维持保证金已包含在初始保证金中，不重复计算）
+		locked := coin.TotalOrderIM.Add(coin.TotalPositionIM.Decimal).
+			Add(coin.Locked.Decimal)
+		// 可用的余额 = equity - (totalOrderIM + totalPositionIM + locked)
+		available := coin.Equity.Sub(locked)
+		balances = append(balances, &model.Balance{
+			Currency:  coin.Coin,
+			Total:     coin.Equity,
+			Locked:    types.ExDecimal{Decimal: locked},
+			Available: types.ExDecimal{Decimal: available},
+			UpdatedAt: updatedAt,
+		})
+	}
+	return balances
+}
+
+// fetchFundingBalance 获取资金账户余额（/v5/asset/transfer/query-account-coins-balance）
+func (o *bybitSpotOrder) fetchFundingBalance(ctx context.Context) (model.Balances, error) {
+	balances, err := o.fetchCoinsBalance(ctx, map[string]interface{}{
+		"accountType": "FUND",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding balance: %w", err)
+	}
+	return balances, nil
+}
+
+// fetchCoinsBalance 查询账户全部币种的钱包余额和可划转余额（/v5/asset/transfer/query-account-coins-balance），params 中的 memberId 可指定子账户
+func (o *bybitSpotOrder) fetchCoinsBalance(ctx context.Context, params map[string]interface{}) (model.Balances, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/v5/asset/transfer/query-account-coins-balance", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result bybitSpotFundingBalanceResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal coins balance: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	balances := make(model.Balances, 0, len(result.Result.Balance))
+	for _, coin := range result.Result.Balance {
+		balances = append(balances, &model.Balance{
+			Currency:  coin.Coin,
+			Total:     coin.WalletBalance,
+			Available: coin.TransferBalance,
+			Locked:    types.ExDecimal{Decimal: coin.WalletBalance.Sub(coin.TransferBalance.Decimal)},
+			UpdatedAt: result.Time,
+		})
 	}
 
 	return balances, nil
 }
 
 func (o *bybitSpotOrder) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	params, err := o.buildOrderParams(ctx, symbol, side, amount, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/order/create", nil, params.body)
+	if err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	var result bybitSpotCreateOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return params.toNewOrder(result.Result.OrderID, result.Result.OrderLinkID, result.Time)
+}
+
+// buildOrderParams 校验并构建下单参数（单笔下单和批量下单共用）
+func (o *bybitSpotOrder) buildOrderParams(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*bybitOrderParams, error) {
 	// 解析选项
 	options := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -489,6 +900,23 @@ func (o *bybitSpotOrder) CreateOrder(ctx context.Context, symbol string, side op
 		"side":     sideStr,
 	}
 
+	// 设置触发价时为条件单（orderFilter=StopOrder），现货按触发价判断触发方向
+	stopPrice, _, isStop, err := common.ParseStopOrder(options)
+	if err != nil {
+		return nil, err
+	}
+	if isStop {
+		reqBody["orderFilter"] = "StopOrder"
+		reqBody["triggerPrice"] = stopPrice.String()
+	}
+	if err := common.CheckTimeInForce(options, orderType == model.OrderTypeLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(options, orderType == model.OrderTypeLimit)
+	if err != nil {
+		return nil, err
+	}
+
 	// 现货市价买单特殊处理
 	if orderType == model.OrderTypeMarket && side == option.Buy {
 		// Calculate cost: amount * price (use current ask price if price not provided)
@@ -504,6 +932,9 @@ func (o *bybitSpotOrder) CreateOrder(ctx context.Context, symbol string, side op
 				return nil, fmt.Errorf("invalid price: %w", err)
 			}
 			costDecimal = amountDecimal.Mul(priceDecimal)
+		} else if isStop {
+			// 条件单按触发价估算成交额
+			costDecimal = amountDecimal.Mul(stopPrice)
 		} else {
 			// Fetch current price to calculate cost
 			ticker, err := o.bybit.spot.market.FetchTicker(ctx, symbol)
@@ -522,31 +953,25 @@ func (o *bybitSpotOrder) CreateOrder(ctx context.Context, symbol string, side op
 		reqBody["qty"] = costDecimal.StringFixed(int32(precision))
 		reqBody["orderType"] = "Market"
 	} else {
-		// 其他订单类型
-		precision := market.Precision.Amount
-		if precision <= 0 {
-			precision = 8
-		}
-		amountFloat, err := strconv.ParseFloat(amount, 64)
+		// 其他订单类型，数量和价格向下对齐到市场的步长
+		qty, err := common.AmountToPrecision(market, amount)
 		if err != nil {
-			return nil, fmt.Errorf("invalid amount: %w", err)
+			return nil, err
 		}
-		reqBody["qty"] = strconv.FormatFloat(amountFloat, 'f', precision, 64)
+		reqBody["qty"] = qty
 
 		if orderType == model.OrderTypeLimit {
 			reqBody["orderType"] = "Limit"
-			priceFloat, err := strconv.ParseFloat(priceStr, 64)
+			price, err := common.PriceToPrecision(market, priceStr)
 			if err != nil {
-				return nil, fmt.Errorf("invalid price: %w", err)
+				return nil, err
 			}
-			pricePrecision := market.Precision.Price
-			if pricePrecision <= 0 {
-				pricePrecision = 8
-			}
-			reqBody["price"] = strconv.FormatFloat(priceFloat, 'f', pricePrecision, 64)
+			reqBody["price"] = price
 
-			// 处理 timeInForce
-			if options.TimeInForce != nil {
+			// 处理 timeInForce，只做 Maker 使用 PostOnly
+			if postOnly {
+				reqBody["timeInForce"] = "PostOnly"
+			} else if options.TimeInForce != nil {
 				reqBody["timeInForce"] = strings.ToUpper(string(*options.TimeInForce))
 			} else {
 				reqBody["timeInForce"] = "GTC"
@@ -557,34 +982,43 @@ func (o *bybitSpotOrder) CreateOrder(ctx context.Context, symbol string, side op
 	}
 
 	// 客户端订单ID
+	clientOrderID := common.GenerateClientOrderID(o.bybit.Name(), side.ToSide())
 	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
-		reqBody["orderLinkId"] = *options.ClientOrderID
-	} else {
-		reqBody["orderLinkId"] = common.GenerateClientOrderID(o.bybit.Name(), side.ToSide())
-	}
-
-	resp, err := o.signAndRequest(ctx, "POST", "/v5/order/create", nil, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("create order: %w", err)
-	}
-
-	var result bybitSpotCreateOrderResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("unmarshal order: %w", err)
-	}
+		clientOrderID = *options.ClientOrderID
+	}
+	reqBody["orderLinkId"] = clientOrderID
+
+	strict, ok := option.GetBool(options.StrictClientID)
+	return &bybitOrderParams{
+		symbol:        symbol,
+		category:      "spot",
+		body:          reqBody,
+		clientOrderID: clientOrderID,
+		stopPrice:     stopPrice,
+		strict:        ok && strict,
+	}, nil
+}
 
-	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
-	}
+// CreateOrders 批量创建订单（POST /v5/order/create-batch，每批最多 10 个）
+// 参数校验失败的订单不提交，其错误写入对应位置
+func (o *bybitSpotOrder) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
 
-	order := &model.NewOrder{
-		OrderId:       result.Result.OrderID,
-		ClientOrderID: result.Result.OrderLinkID,
-		Symbol:        symbol,
-		Timestamp:     result.Time,
+	var items []common.BatchItem[*bybitOrderParams]
+	for i, r := range requests {
+		params, err := o.buildOrderParams(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, common.BatchItem[*bybitOrderParams]{Index: i, Group: params.category, Params: params})
 	}
 
-	return order, nil
+	err := common.SubmitBatches(ctx, items, 10, orders, errs, func(ctx context.Context, category string, batch []*bybitOrderParams) ([]*model.NewOrder, []error, error) {
+		return submitBybitBatchOrders(ctx, o.signAndRequest, category, batch)
+	})
+	return orders, errs, err
 }
 
 func (o *bybitSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
@@ -612,14 +1046,95 @@ func (o *bybitSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId
 	reqBody := map[string]interface{}{
 		"category": "spot",
 		"symbol":   bybitSymbol,
-		"orderId":  orderId,
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		reqBody["orderLinkId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		reqBody["orderId"] = orderId
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		reqBody["orderLinkId"] = clientOrderID
+	} else {
+		return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/order/cancel", nil, reqBody)
+	if err != nil {
+		return err
 	}
 
-	_, err = o.signAndRequest(ctx, "POST", "/v5/order/cancel", nil, reqBody)
-	return err
+	// 部分撤单接口成功时返回空响应体
+	if common.IsEmptyBody(resp) {
+		return nil
+	}
+
+	var result struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("unmarshal cancel order: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return nil
+}
+
+// EditOrder 修改挂单的数量和/或价格，修改成功后查询并返回最新订单
+func (o *bybitSpotOrder) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	market, err := o.bybit.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	reqBody := map[string]interface{}{
+		"category": "spot",
+		"symbol":   market.ID,
+	}
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		reqBody["orderId"] = orderId
+	} else if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		reqBody["orderLinkId"] = clientOrderId
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+	if newAmount != "" {
+		reqBody["qty"] = newAmount
+	}
+	if newPrice != "" {
+		reqBody["price"] = newPrice
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/order/amend", nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var result bybitAmendOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return o.FetchOrder(ctx, symbol, result.Result.OrderID, opts...)
 }
 
 // parseOrder 解析订单数据
@@ -681,35 +1196,40 @@ func (o *bybitSpotOrder) parseOrder(item bybitSpotFetchOrderItem, symbol string)
 	return order
 }
 
+// FetchOrder 查询订单，symbol 为空时仅按订单ID查询，订单的 symbol 由交易所返回的市场ID换算
 func (o *bybitSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
-	// 获取市场信息
-	market, err := o.bybit.spot.market.GetMarket(symbol)
-	if err != nil {
-		return nil, err
-	}
-
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
 		opt(argsOpts)
 	}
 
-	// 获取交易所格式的 symbol ID
-	bybitSymbol := market.ID
-	if bybitSymbol == "" {
-		var err error
-		bybitSymbol, err = ToBybitSymbol(symbol, false)
+	params := map[string]interface{}{
+		"category": "spot",
+	}
+	if symbol != "" {
+		// 获取市场信息
+		market, err := o.bybit.spot.market.GetMarket(symbol)
 		if err != nil {
-			return nil, fmt.Errorf("get market ID: %w", err)
+			return nil, err
 		}
-	}
 
-	params := map[string]interface{}{
-		"category": "spot",
-		"symbol":   bybitSymbol,
-		"orderId":  orderId,
+		// 获取交易所格式的 symbol ID
+		bybitSymbol := market.ID
+		if bybitSymbol == "" {
+			bybitSymbol, err = ToBybitSymbol(symbol, false)
+			if err != nil {
+				return nil, fmt.Errorf("get market ID: %w", err)
+			}
+		}
+		params["symbol"] = bybitSymbol
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		params["orderLinkId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		params["orderId"] = orderId
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		params["orderLinkId"] = clientOrderID
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
 	// First try to fetch from open orders (realtime)
@@ -719,8 +1239,8 @@ func (o *bybitSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId
 
 		if err := json.Unmarshal(resp, &realtimeResult); err == nil && realtimeResult.RetCode == 0 {
 			for _, item := range realtimeResult.Result.List {
-				if item.OrderID == orderId {
-					return o.parseOrder(item, symbol), nil
+				if item.OrderID == orderId || orderId == "" && item.OrderLinkID == params["orderLinkId"] {
+					return o.parseOrder(item, o.orderSymbol(symbol, item.Symbol)), nil
 				}
 			}
 		}
@@ -738,19 +1258,374 @@ func (o *bybitSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId
 	}
 
 	if result.RetCode != 0 {
-		return nil, fmt.Errorf("bybit api error: %s", result.RetMsg)
+		return nil, newBybitError(result.RetCode, result.RetMsg)
 	}
 
 	if len(result.Result.List) == 0 {
-		return nil, fmt.Errorf("order not found")
+		return nil, common.ErrOrderNotFound
 	}
 
-	// Find the order by ID
+	// Find the order by ID (or by orderLinkId when orderId is empty)
 	for _, item := range result.Result.List {
-		if item.OrderID == orderId {
-			return o.parseOrder(item, symbol), nil
+		if item.OrderID == orderId || orderId == "" && item.OrderLinkID == params["orderLinkId"] {
+			return o.parseOrder(item, o.orderSymbol(symbol, item.Symbol)), nil
 		}
 	}
 
-	return nil, fmt.Errorf("order not found")
+	return nil, common.ErrOrderNotFound
+}
+
+// orderSymbol 返回订单的标准化 symbol：查询时指定了 symbol 则直接使用，否则按交易所市场ID换算（市场未加载时返回原始ID）
+func (o *bybitSpotOrder) orderSymbol(symbol, marketID string) string {
+	if symbol != "" {
+		return symbol
+	}
+	if market, err := o.bybit.spot.market.GetMarketByID(marketID); err == nil {
+		return market.Symbol
+	}
+	return marketID
+}
+
+// CreateConversion 闪兑（先通过 quote-apply 询价，再通过 convert-execute 确认报价）
+func (o *bybitSpotOrder) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	if _, err := decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	// 询价
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/asset/exchange/quote-apply", nil, map[string]interface{}{
+		"accountType":   "eb_convert_uta",
+		"fromCoin":      from,
+		"toCoin":        to,
+		"requestCoin":   from,
+		"requestAmount": amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("apply convert quote: %w", err)
+	}
+
+	var quoteResult bybitSpotConvertQuoteResponse
+	if err := json.Unmarshal(resp, &quoteResult); err != nil {
+		return nil, fmt.Errorf("unmarshal convert quote: %w", err)
+	}
+	if quoteResult.RetCode != 0 {
+		return nil, newBybitError(quoteResult.RetCode, quoteResult.RetMsg)
+	}
+	quote := quoteResult.Result
+
+	// 确认报价
+	resp, err = o.signAndRequest(ctx, "POST", "/v5/asset/exchange/convert-execute", nil, map[string]interface{}{
+		"quoteTxId": quote.QuoteTxID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("execute convert: %w", err)
+	}
+
+	var executeResult bybitSpotConvertExecuteResponse
+	if err := json.Unmarshal(resp, &executeResult); err != nil {
+		return nil, fmt.Errorf("unmarshal convert execute: %w", err)
+	}
+	if executeResult.RetCode != 0 {
+		return nil, newBybitError(executeResult.RetCode, executeResult.RetMsg)
+	}
+
+	return &model.Conversion{
+		ID:         executeResult.Result.QuoteTxID,
+		QuoteID:    quote.QuoteTxID,
+		From:       from,
+		To:         to,
+		FromAmount: quote.FromAmount,
+		ToAmount:   quote.ToAmount,
+		Rate:       quote.ExchangeRate,
+		Status:     executeResult.Result.ExchangeStatus,
+		Timestamp:  executeResult.Time,
+	}, nil
+}
+
+// FetchCurrencies 获取全部币种的充提状态及支持的网络（/v5/asset/coin/query-info）
+// 网络标识为链名称（如 ETH），Name 为链类型（如 ERC20）
+func (o *bybitSpotOrder) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/v5/asset/coin/query-info", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch currencies: %w", err)
+	}
+
+	var result bybitSpotCoinInfoResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal currencies: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	currencies := make(map[string]*model.Currency, len(result.Result.Rows))
+	for _, row := range result.Result.Rows {
+		networks := make([]model.CurrencyNetwork, 0, len(row.Chains))
+		for _, chain := range row.Chains {
+			networks = append(networks, model.CurrencyNetwork{
+				Network:         chain.Chain,
+				Name:            chain.ChainType,
+				DepositEnabled:  chain.ChainDeposit == "1",
+				WithdrawEnabled: chain.ChainWithdraw == "1",
+				WithdrawFee:     chain.WithdrawFee,
+				WithdrawMin:     chain.WithdrawMin,
+			})
+		}
+		currencies[row.Coin] = common.NewCurrency(row.Coin, row.Name, networks)
+	}
+	return currencies, nil
+}
+
+// FetchDepositAddress 获取充值地址（/v5/asset/deposit/query-address），network 为空时使用第一条链
+func (o *bybitSpotOrder) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	currency = strings.ToUpper(currency)
+
+	params := map[string]interface{}{"coin": currency}
+	if network != "" {
+		params["chainType"] = network
+	}
+	resp, err := o.signAndRequest(ctx, "GET", "/v5/asset/deposit/query-address", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch deposit address: %w", err)
+	}
+
+	var result bybitSpotDepositAddressResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal deposit address: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	for _, chain := range result.Result.Chains {
+		if network != "" && !strings.EqualFold(chain.Chain, network) && !strings.EqualFold(chain.ChainType, network) {
+			continue
+		}
+		return &model.DepositAddress{
+			Currency: result.Result.Coin,
+			Address:  chain.AddressDeposit,
+			Tag:      chain.TagDeposit,
+			Network:  chain.Chain,
+		}, nil
+	}
+	return nil, fmt.Errorf("deposit address not found: %s %s", currency, network)
+}
+
+// Withdraw 提币（/v5/asset/withdraw）
+func (o *bybitSpotOrder) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	currency = strings.ToUpper(currency)
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	tag, body := common.SplitWithdrawParams(params)
+	if tag != "" {
+		body["tag"] = tag
+	}
+	body["coin"] = currency
+	body["address"] = address
+	body["amount"] = amount
+	if network != "" {
+		body["chain"] = network
+	}
+	body["timestamp"] = time.Now().UnixMilli()
+
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/asset/withdraw", nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("withdraw: %w", err)
+	}
+
+	var result bybitSpotWithdrawResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal withdraw: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return &model.Transaction{
+		ID:        result.Result.ID,
+		Currency:  currency,
+		Amount:    types.ExDecimal{Decimal: amountDecimal},
+		Address:   address,
+		Tag:       tag,
+		Network:   network,
+		Status:    model.TransactionStatusPending,
+		Timestamp: result.Time,
+	}, nil
+}
+
+// bybitAccountTypes 划转的账户类型（统一账户下现货、杠杆和合约共用 UNIFIED）
+var bybitAccountTypes = map[option.AccountType]string{
+	option.AccountSpot:    "UNIFIED",
+	option.AccountFutures: "UNIFIED",
+	option.AccountMargin:  "UNIFIED",
+	option.AccountFunding: "FUND",
+}
+
+// Transfer 账户间资金划转（/v5/asset/transfer/inter-transfer）
+func (o *bybitSpotOrder) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	from, okFrom := bybitAccountTypes[fromAccount]
+	to, okTo := bybitAccountTypes[toAccount]
+	if !okFrom || !okTo || from == to {
+		return nil, common.UnsupportedTransferError(bybitName, fromAccount, toAccount)
+	}
+
+	currency = strings.ToUpper(currency)
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/v5/asset/transfer/inter-transfer", nil, map[string]interface{}{
+		"transferId":      common.NewUUID(),
+		"coin":            currency,
+		"amount":          amount,
+		"fromAccountType": from,
+		"toAccountType":   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	var result bybitSpotTransferResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	return &model.Transaction{
+		ID:        result.Result.TransferID,
+		Currency:  currency,
+		Amount:    types.ExDecimal{Decimal: amountDecimal},
+		Status:    toBybitTransferStatus(result.Result.Status),
+		Timestamp: result.Time,
+	}, nil
+}
+
+// FetchBorrowRate 获取统一账户借币利率（/v5/account/collateral-info），不可借的币种返回 common.ErrNotSupported
+func (o *bybitSpotOrder) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	currency = strings.ToUpper(currency)
+	resp, err := o.signAndRequest(ctx, "GET", "/v5/account/collateral-info", map[string]interface{}{
+		"currency": currency,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch borrow rate: %w", err)
+	}
+
+	var result bybitCollateralInfoResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal borrow rate: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+	for _, item := range result.Result.List {
+		if !strings.EqualFold(item.Currency, currency) {
+			continue
+		}
+		if !item.Borrowable {
+			return nil, fmt.Errorf("fetch borrow rate: %w: %s is not borrowable", common.ErrNotSupported, currency)
+		}
+		return &model.BorrowRate{
+			Currency:  currency,
+			Rate:      item.HourlyBorrowRate,
+			Timestamp: result.Time,
+		}, nil
+	}
+	return nil, fmt.Errorf("fetch borrow rate: no rate returned for %s", currency)
+}
+
+// borrowRepay 统一账户手动借币（/v5/account/borrow）或还币（/v5/account/repay）
+func (o *bybitSpotOrder) borrowRepay(ctx context.Context, op, path, currency, amount string) error {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if !amountDecimal.IsPositive() {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", path, nil, map[string]interface{}{
+		"coin":   strings.ToUpper(currency),
+		"amount": amountDecimal.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var result bybitBorrowRepayResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", op, err)
+	}
+	if result.RetCode != 0 {
+		return newBybitError(result.RetCode, result.RetMsg)
+	}
+	return nil
+}
+
+// FetchSubAccounts 获取子账户列表（/v5/user/query-sub-members），子账户以 UID 标识
+func (o *bybitSpotOrder) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/v5/user/query-sub-members", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sub accounts: %w", err)
+	}
+
+	var result bybitSubMembersResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal sub accounts: %w", err)
+	}
+	if result.RetCode != 0 {
+		return nil, newBybitError(result.RetCode, result.RetMsg)
+	}
+
+	accounts := make([]*model.SubAccount, 0, len(result.Result.SubMembers))
+	for _, member := range result.Result.SubMembers {
+		name := member.Username
+		if name == "" {
+			name = member.UID
+		}
+		accounts = append(accounts, &model.SubAccount{
+			ID:     member.UID,
+			Name:   name,
+			Frozen: member.Status == bybitSubMemberFrozen,
+		})
+	}
+	return accounts, nil
+}
+
+// FetchBalanceFor 获取子账户统一交易账户余额（/v5/asset/transfer/query-account-coins-balance），subAccountID 为子账户 UID
+// 可用余额为可划转余额
+func (o *bybitSpotOrder) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	if subAccountID == "" {
+		return nil, fmt.Errorf("fetch balance for: sub account id is required")
+	}
+
+	balances, err := o.fetchCoinsBalance(ctx, map[string]interface{}{
+		"memberId":    subAccountID,
+		"accountType": "UNIFIED",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch balance for: %w", err)
+	}
+	return balances, nil
+}
+
+// toBybitTransferStatus 将 Bybit 划转状态转换为统一状态
+func toBybitTransferStatus(status string) string {
+	switch status {
+	case "SUCCESS":
+		return model.TransactionStatusOK
+	case "FAILED":
+		return model.TransactionStatusFailed
+	default:
+		return model.TransactionStatusPending
+	}
 }
@@ -2,12 +2,22 @@ package bybit
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
 	"github.com/shopspring/decimal"
 )
@@ -243,3 +253,593 @@ func TestBybitSpot_FetchTickers(t *testing.T) {
 		}
 	}
 }
+
+// TestBybitSpot_FetchTicker_Decimal Bybit 行情字段为字符串，订单簿一侧为空时 bid1Price/ask1Price 为空字符串，解析为 0
+func TestBybitSpot_FetchTicker_Decimal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/tickers" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"BTCUSDT","bid1Price":"","ask1Price":"65000.87654321","lastPrice":"65000.00000001","prevPrice24h":"64000","highPrice24h":"66000","lowPrice24h":"63000","volume24h":"1234.56789012345678","turnover24h":"80246913.58024691"}]},"time":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	e := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+
+	exchangetest.CheckTickerDecimals(t, ticker, map[string]string{
+		"bid": "0", "ask": "65000.87654321", "last": "65000.00000001", "open": "64000",
+		"volume": "1234.56789012345678", "quote_volume": "80246913.58024691",
+	})
+}
+
+func TestBybitSpot_Wallet(t *testing.T) {
+	var withdrawBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-BAPI-API-KEY") != "key" || r.Header.Get("X-BAPI-SIGN") == "" {
+			t.Errorf("missing auth headers for %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/v5/asset/deposit/query-address":
+			if r.URL.Query().Get("chainType") != "TRX" {
+				t.Errorf("chainType = %q, want TRX", r.URL.Query().Get("chainType"))
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"coin":"USDT","chains":[
+				{"chainType":"TRC20","addressDeposit":"TXyz","tagDeposit":"","chain":"TRX"}]}}`))
+		case "/v5/asset/withdraw":
+			if err := json.NewDecoder(r.Body).Decode(&withdrawBody); err != nil {
+				t.Errorf("decode withdraw body: %v", err)
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"id":"10195"},"time":1700000000456}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	addr, err := ex.Spot().FetchDepositAddress(ctx, "usdt", "TRX")
+	if err != nil {
+		t.Fatalf("FetchDepositAddress: %v", err)
+	}
+	if addr.Currency != "USDT" || addr.Address != "TXyz" || addr.Network != "TRX" {
+		t.Errorf("unexpected deposit address: %+v", addr)
+	}
+
+	tx, err := ex.Spot().Withdraw(ctx, "usdt", "50", "TXyz", "TRX", map[string]interface{}{"accountType": "FUND"})
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if withdrawBody["coin"] != "USDT" || withdrawBody["chain"] != "TRX" || withdrawBody["amount"] != "50" ||
+		withdrawBody["accountType"] != "FUND" || withdrawBody["timestamp"] == nil {
+		t.Errorf("unexpected withdraw body: %v", withdrawBody)
+	}
+	if tx.ID != "10195" || tx.Status != model.TransactionStatusPending {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+}
+
+func TestBybitSpot_Transfer(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/asset/transfer/inter-transfer" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode transfer body: %v", err)
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"transferId":"` + body["transferId"].(string) + `","status":"SUCCESS"},"time":1700000000456}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	tx, err := ex.Spot().Transfer(ctx, "usdt", "20", option.AccountSpot, option.AccountFunding)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if body["fromAccountType"] != "UNIFIED" || body["toAccountType"] != "FUND" || body["coin"] != "USDT" || body["amount"] != "20" {
+		t.Errorf("unexpected transfer body: %v", body)
+	}
+	if tx.ID == "" || tx.ID != body["transferId"] || tx.Status != model.TransactionStatusOK {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	// 统一账户下现货和合约共用 UNIFIED 账户
+	if _, err := ex.Spot().Transfer(ctx, "USDT", "20", option.AccountSpot, option.AccountFutures); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestBybitSpot_FetchBalance_Funding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/asset/transfer/query-account-coins-balance" || r.URL.Query().Get("accountType") != "FUND" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"accountType":"FUND","bizType":1,"balance":[{"coin":"USDT","walletBalance":"150","transferBalance":"120","bonus":""}]},"time":1700000000456}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+
+	balances, err := ex.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFunding))
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if len(balances) != 1 {
+		t.Fatalf("len(balances) = %d, want 1", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "USDT" || b.Total.String() != "150" || b.Available.String() != "120" || b.Locked.String() != "30" {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+}
+
+func TestBybitSpot_SubAccounts(t *testing.T) {
+	var gotQuery url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/user/query-sub-members":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"subMembers":[{"uid":"53888000","username":"desk-a","memberType":1,"status":1,"accountMode":5,"remark":""},{"uid":"53888001","username":"desk-b","memberType":1,"status":4,"accountMode":5,"remark":""}]},"time":1700000000000}`))
+		case "/v5/asset/transfer/query-account-coins-balance":
+			gotQuery = r.URL.Query()
+			w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"accountType":"UNIFIED","memberId":"53888001","balance":[{"coin":"USDT","walletBalance":"500","transferBalance":"450","bonus":""}]},"time":1700000000456}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	accounts, err := ex.Spot().FetchSubAccounts(ctx)
+	if err != nil {
+		t.Fatalf("FetchSubAccounts: %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].ID != "53888000" || accounts[0].Name != "desk-a" || accounts[0].Frozen || !accounts[1].Frozen {
+		t.Fatalf("unexpected accounts: %+v %+v", accounts[0], accounts[1])
+	}
+
+	balances, err := ex.Spot().FetchBalanceFor(ctx, "53888001")
+	if err != nil {
+		t.Fatalf("FetchBalanceFor: %v", err)
+	}
+	if gotQuery.Get("memberId") != "53888001" || gotQuery.Get("accountType") != "UNIFIED" {
+		t.Errorf("query = %v, want memberId=53888001 accountType=UNIFIED", gotQuery)
+	}
+	if len(balances) != 1 || balances[0].Total.String() != "500" || balances[0].Available.String() != "450" {
+		t.Errorf("unexpected balances: %+v", balances)
+	}
+}
+
+func TestBybitSpot_Margin(t *testing.T) {
+	var gotPath string
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/account/collateral-info":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"list":[{"currency":"BTC","hourlyBorrowRate":"0.0000015021220000","borrowable":true,"marginCollateral":true}]},"time":1700000000000}`))
+		case "/v5/account/borrow", "/v5/account/repay":
+			gotPath = r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"coin":"BTC","amount":"0.1"},"time":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	rate, err := ex.Spot().FetchBorrowRate(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("FetchBorrowRate: %v", err)
+	}
+	if rate.Rate.String() != "0.000001502122" || rate.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("unexpected rate: %+v", rate)
+	}
+
+	if err := ex.Spot().Borrow(ctx, "btc", "0.1"); err != nil {
+		t.Fatalf("Borrow: %v", err)
+	}
+	if gotPath != "/v5/account/borrow" || body["coin"] != "BTC" || body["amount"] != "0.1" {
+		t.Errorf("unexpected borrow request: %s %v", gotPath, body)
+	}
+	if err := ex.Spot().Repay(ctx, "BTC", "0.1"); err != nil {
+		t.Fatalf("Repay: %v", err)
+	}
+	if gotPath != "/v5/account/repay" {
+		t.Errorf("path = %s, want /v5/account/repay", gotPath)
+	}
+}
+
+func TestBybitSpot_CreateOrder_DecimalPrecision(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/order/create" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1","orderLinkId":"my-order"},"retExtInfo":{},"time":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "SHIBUSDT", Symbol: "SHIB/USDT", Base: "SHIB", Quote: "USDT"}
+	market.Precision.Amount = 8
+	market.Precision.Price = 8
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	// float64 只有约 16 位有效数字，1000000000.00000001 经 float64 格式化后为 1000000000.00000000
+	_, err = ex.Spot().CreateOrder(context.Background(), "SHIB/USDT", option.Sell, "1000000000.00000001",
+		option.WithPrice("0.00002345"), option.WithClientOrderID("my-order"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if body["qty"] != "1000000000.00000001" {
+		t.Errorf("qty = %v, want 1000000000.00000001", body["qty"])
+	}
+	if body["price"] != "0.00002345" {
+		t.Errorf("price = %v, want 0.00002345", body["price"])
+	}
+}
+
+func TestBybitSpot_FetchOrderByClientID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/order/create":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["orderLinkId"] != "my-order" {
+				t.Errorf("orderLinkId = %v, want my-order", body["orderLinkId"])
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1","orderLinkId":"my-order"},"retExtInfo":{},"time":1700000000000}`))
+		case "/v5/order/realtime":
+			// 按客户端订单ID查询时不发送空的 orderId
+			if q := r.URL.Query(); q.Get("orderLinkId") != "my-order" || q.Has("orderId") {
+				t.Errorf("query = %s, want orderLinkId only", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"orderId":"1","orderLinkId":"my-order","symbol":"BTCUSDT","side":"Buy","orderType":"Limit","price":"30000","qty":"0.01","orderStatus":"New","timeInForce":"GTC","createdTime":"1700000000000","updatedTime":"1700000000000"}]},"retExtInfo":{},"time":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	created, err := ex.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.01", option.WithPrice("30000"), option.WithClientOrderID("my-order"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	order, err := ex.Spot().FetchOrderByClientID(ctx, "BTC/USDT", created.ClientOrderID)
+	if err != nil {
+		t.Fatalf("FetchOrderByClientID: %v", err)
+	}
+	if order.ID != created.OrderId || order.ClientOrderID != "my-order" {
+		t.Errorf("order = %+v, want ID %s and client ID my-order", order, created.OrderId)
+	}
+}
+
+func TestBybit_TimeSync(t *testing.T) {
+	// 服务器时间比本地慢 30 秒（超出默认 5 秒接收窗口）
+	serverOffset := -30 * time.Second
+	var signed string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/time":
+			now := time.Now().Add(serverOffset)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"timeSecond":"` + strconv.FormatInt(now.Unix(), 10) + `","timeNano":"` + strconv.FormatInt(now.UnixNano(), 10) + `"},"retExtInfo":{},"time":` + strconv.FormatInt(now.UnixMilli(), 10) + `}`))
+		case "/v5/asset/transfer/query-account-coins-balance":
+			signed = r.Header.Get("X-BAPI-TIMESTAMP")
+			w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"accountType":"FUND","balance":[]},"time":1700000000456}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	if err := b.clock.Sync(context.Background(), ex.FetchTime); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if _, err := ex.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFunding)); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	ts, err := strconv.ParseInt(signed, 10, 64)
+	if err != nil {
+		t.Fatalf("invalid X-BAPI-TIMESTAMP %q", signed)
+	}
+	if d := ts - time.Now().Add(serverOffset).UnixMilli(); d < -1000 || d > 1000 {
+		t.Errorf("signed timestamp off by %dms from server time", d)
+	}
+}
+
+func TestBybit_RecvWindow(t *testing.T) {
+	var header, timestamp, signature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-BAPI-RECV-WINDOW")
+		timestamp = r.Header.Get("X-BAPI-TIMESTAMP")
+		signature = r.Header.Get("X-BAPI-SIGN")
+		w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"accountType":"FUND","balance":[]},"time":1700000000456}`))
+	}))
+	defer srv.Close()
+
+	for _, tt := range []struct {
+		options map[string]interface{}
+		want    string
+	}{
+		{map[string]interface{}{"baseURL": srv.URL}, "5000"},
+		{map[string]interface{}{"baseURL": srv.URL, "recvWindow": 20000}, "20000"},
+	} {
+		ex, err := NewBybit("key", "secret", tt.options)
+		if err != nil {
+			t.Fatalf("NewBybit: %v", err)
+		}
+		if _, err := ex.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFunding)); err != nil {
+			t.Fatalf("FetchBalance: %v", err)
+		}
+		if header != tt.want {
+			t.Errorf("X-BAPI-RECV-WINDOW = %q, want %q", header, tt.want)
+		}
+		// 签名包含接收窗口
+		ts, _ := strconv.ParseInt(timestamp, 10, 64)
+		signer := NewSigner("secret")
+		signer.SetAPIKey("key")
+		if want, _ := signer.SignRequest(http.MethodGet, map[string]interface{}{"accountType": "FUND"}, nil, ts, tt.want); signature != want {
+			t.Errorf("signature does not match recvWindow %s", tt.want)
+		}
+	}
+}
+
+func TestBybitSpot_FetchCurrencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/asset/coin/query-info" || r.Header.Get("X-BAPI-SIGN") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"success","result":{"rows":[
+			{"name":"USDT","coin":"USDT","remainAmount":"150000","chains":[
+				{"chainType":"ERC20","confirmation":"12","withdrawFee":"4","depositMin":"0","withdrawMin":"10","chain":"ETH","chainDeposit":"1","chainWithdraw":"1","minAccuracy":"4","withdrawPercentageFee":"0"},
+				{"chainType":"TRC20","confirmation":"20","withdrawFee":"1","depositMin":"0","withdrawMin":"10","chain":"TRX","chainDeposit":"1","chainWithdraw":"1","minAccuracy":"4","withdrawPercentageFee":"0"},
+				{"chainType":"BEP20(BSC)","confirmation":"15","withdrawFee":"0.3","depositMin":"0","withdrawMin":"10","chain":"BSC","chainDeposit":"0","chainWithdraw":"1","minAccuracy":"4","withdrawPercentageFee":"0"}]}
+		]},"retExtInfo":{},"time":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+
+	currencies, err := ex.Spot().FetchCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrencies: %v", err)
+	}
+	usdt := currencies["USDT"]
+	if usdt == nil || len(usdt.Networks) != 3 {
+		t.Fatalf("unexpected USDT: %+v", usdt)
+	}
+	// 没有默认网络时取第一个网络的手续费
+	if !usdt.DepositEnabled || !usdt.WithdrawEnabled || usdt.WithdrawFee.String() != "4" {
+		t.Errorf("USDT deposit=%v withdraw=%v fee=%s", usdt.DepositEnabled, usdt.WithdrawEnabled, usdt.WithdrawFee)
+	}
+	trx, bsc := usdt.Networks[1], usdt.Networks[2]
+	if trx.Network != "TRX" || trx.Name != "TRC20" || trx.WithdrawFee.String() != "1" || trx.WithdrawMin.String() != "10" {
+		t.Errorf("unexpected TRC20 network: %+v", trx)
+	}
+	if bsc.Network != "BSC" || bsc.DepositEnabled || !bsc.WithdrawEnabled {
+		t.Errorf("unexpected BEP20 network: %+v", bsc)
+	}
+}
+
+func TestBybit_FetchStatus(t *testing.T) {
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/system/status" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	body.Store(`{"retCode":0,"retMsg":"OK","result":{"list":[]},"retExtInfo":{},"time":1700000000000}`)
+	status, err := ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusOK || status.Updated.IsZero() {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	body.Store(`{"retCode":0,"retMsg":"OK","result":{"list":[
+		{"id":"4d95b2a0-587f-11ef-a2c8-2b9bd2f2e4b2","title":"System maintenance","state":"ongoing","begin":"1700000000000","end":"1700007200000","href":"https://announcements.bybit.com/maintenance","serviceTypes":[2,3,4,5],"product":[1,2],"uidSuffix":[],"maintainType":1,"env":1}]},"retExtInfo":{},"time":1700000100000}`)
+	status, err = ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusMaintenance || status.ETA.UnixMilli() != 1700007200000 || status.URL != "https://announcements.bybit.com/maintenance" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestBybit_VerifyCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/market/time":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"timeSecond":"1700000000","timeNano":"1700000000000000000"},"time":1700000000000}`))
+		case "/v5/account/wallet-balance":
+			// Bybit 对无效的 API Key 返回 401 且响应体为空
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := ex.Ping(ctx); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+	err = ex.VerifyCredentials(ctx)
+	var httpErr *common.HTTPError
+	if !errors.Is(err, common.ErrAuthenticationFailed) || !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("VerifyCredentials err = %v, want ErrAuthenticationFailed wrapping HTTP 401", err)
+	}
+}
+
+func TestBybitSpot_FetchOrder_WithoutSymbol(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/order/realtime" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if q := r.URL.Query(); q.Has("symbol") || q.Get("category") != "spot" || q.Get("orderId") != "1" {
+			t.Errorf("query = %s, want spot orderId without symbol", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"orderId":"1","orderLinkId":"my-order","symbol":"ETHUSDT","side":"Sell","orderType":"Limit","price":"2000","qty":"0.5","orderStatus":"New","timeInForce":"GTC","createdTime":"1700000000000","updatedTime":"1700000000000"}]},"retExtInfo":{},"time":1700000000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "ETHUSDT", Symbol: "ETH/USDT", Base: "ETH", Quote: "USDT"}
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	order, err := ex.Spot().FetchOrder(context.Background(), "", "1")
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	if order.ID != "1" || order.Symbol != "ETH/USDT" || order.ClientOrderID != "my-order" {
+		t.Errorf("order = %+v", order)
+	}
+}
+
+func TestBybitSpot_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v5/order/amend":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			edits = append(edits, body)
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"orderId":"1","orderLinkId":"my-order"},"retExtInfo":{},"time":1700000000000}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v5/order/realtime":
+			w.Write([]byte(`{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"orderId":"1","orderLinkId":"my-order","symbol":"BTCUSDT","side":"Buy","orderType":"Limit","price":"30000","qty":"0.02","orderStatus":"New","timeInForce":"GTC","createdTime":"1700000000000","updatedTime":"1700000000100"}]},"retExtInfo":{},"time":1700000000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewBybit("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewBybit: %v", err)
+	}
+	b := ex.(*Bybit)
+	market := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	market.Precision.Amount = 4
+	market.Precision.Price = 2
+	b.spotMarketsBySymbol[market.Symbol] = market
+	b.spotMarketsByID[market.ID] = market
+
+	// 按客户端订单ID只改数量，数量按精度截断
+	order, err := ex.Spot().EditOrder(context.Background(), "BTC/USDT", "", "0.020009", "", option.WithClientOrderID("my-order"))
+	if err != nil {
+		t.Fatalf("EditOrder: %v", err)
+	}
+	if order.ID != "1" {
+		t.Errorf("unexpected order: %+v", order)
+	}
+	if e := edits[0]; e["category"] != "spot" || e["symbol"] != "BTCUSDT" || e["orderLinkId"] != "my-order" || e["orderId"] != nil ||
+		e["qty"] != "0.02" || e["price"] != nil {
+		t.Errorf("edit body = %v, want orderLinkId my-order qty 0.02 only", e)
+	}
+}
@@ -1,6 +1,9 @@
 package bybit
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/lemconn/exlink/common"
 )
 
@@ -8,17 +11,60 @@ const (
 	bybitName       = "bybit"
 	bybitBaseURL    = "https://api.bybit.com"
 	bybitSandboxURL = "https://api-demo.bybit.com"
+
+	// 公共 WebSocket 地址（模拟盘的公共行情与实盘共用，币本位合约使用独立地址）
+	bybitSpotWSURL    = "wss://stream.bybit.com/v5/public/spot"
+	bybitPerpWSURL    = "wss://stream.bybit.com/v5/public/linear"
+	bybitInverseWSURL = "wss://stream.bybit.com/v5/public/inverse"
+
+	// 私有 WebSocket 地址（订单、钱包推送，现货和合约共用）
+	bybitPrivateWSURL        = "wss://stream.bybit.com/v5/private"
+	bybitPrivateWSSandboxURL = "wss://stream-demo.bybit.com/v5/private"
+
+	// 逐笔成交单次最大返回条数
+	bybitSpotMaxTradesLimit = 60
+	bybitPerpMaxTradesLimit = 1000
+
+	// 深度单次最大档位数
+	bybitSpotMaxDepthLimit = 200
+	bybitPerpMaxDepthLimit = 500
+
+	// 历史资金费率单次最大返回条数
+	bybitFundingHistoryLimit = 200
+
+	// FetchOHLCVRange 每页K线数
+	bybitOHLCVPageLimit = 1000
+
+	// 签名请求默认接收窗口（毫秒）
+	bybitDefaultRecvWindow = 5000
+
+	// 币本位合约每张面值（USD）
+	bybitInverseContractValue = "1"
+	// U本位合约每张面值（币），按币数量下单
+	bybitLinearContractValue = "1"
+)
+
+// bybitPerpCategories 永续合约产品分类：U本位和币本位
+var bybitPerpCategories = []string{"linear", "inverse"}
+
+// WebSocket 深度推送可订阅的档位数
+var (
+	bybitSpotWSDepthLevels = []int{1, 50, 200}
+	bybitPerpWSDepthLevels = []int{1, 50, 200, 500}
 )
 
+// bybitTimeframes K线支持的周期（现货和合约共用）
+var bybitTimeframes = []string{"1", "3", "5", "15", "30", "60", "120", "240", "360", "720", "D", "W", "M"}
+
 // Client Bybit 客户端
 type Client struct {
 	// HTTPClient HTTP 客户端（Bybit 使用统一的 API）
 	HTTPClient *common.HTTPClient
 
-	// APIKey API 密钥
+	// APIKey API 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	APIKey string
 
-	// SecretKey 密钥
+	// SecretKey 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	SecretKey string
 
 	// Sandbox 是否为模拟盘
@@ -32,15 +78,16 @@ type Client struct {
 }
 
 // NewClient 创建 Bybit 客户端
+// 请求签名使用 Bybit 持有的当前凭证（见 credentials），以支持运行时轮换
 func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Client, error) {
-	baseURL := bybitBaseURL
+	baseURL, err := common.SharedBaseURL(options, bybitBaseURL)
+	if err != nil {
+		return nil, err
+	}
 	sandbox := false
 	proxyURL := ""
 	debug := false
 
-	if v, ok := options["baseURL"].(string); ok {
-		baseURL = v
-	}
 	if v, ok := options["sandbox"].(bool); ok {
 		sandbox = v
 	}
@@ -55,8 +102,10 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		baseURL = bybitSandboxURL
 	}
 
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
 	client := &Client{
-		HTTPClient: common.NewHTTPClient(baseURL),
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
 		APIKey:     apiKey,
 		SecretKey:  secretKey,
 		Sandbox:    sandbox,
@@ -64,6 +113,19 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		Debug:      debug,
 	}
 
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseBybitError)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
 	// 设置代理
 	if proxyURL != "" {
 		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
@@ -76,9 +138,23 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		client.HTTPClient.SetDebug(true)
 	}
 
-	// 设置请求头
-	if apiKey != "" {
-		client.HTTPClient.SetHeader("X-BAPI-API-KEY", apiKey)
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
 	}
 
 	return client, nil
@@ -0,0 +1,51 @@
+package bybit
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// bybitErrorCodes Bybit 错误码（retCode）到统一错误的映射（现货和合约共用）
+var bybitErrorCodes = map[string]error{
+	"10006":  common.ErrRateLimitExceeded,    // 请求频率超限
+	"10018":  common.ErrRateLimitExceeded,    // IP 请求频率超限
+	"110004": common.ErrInsufficientFunds,    // 钱包余额不足
+	"110007": common.ErrInsufficientFunds,    // 可用余额不足
+	"110012": common.ErrInsufficientFunds,    // 可用余额不足
+	"110044": common.ErrInsufficientFunds,    // 可用保证金不足
+	"170131": common.ErrInsufficientFunds,    // 现货余额不足
+	"110001": common.ErrOrderNotFound,        // 订单不存在
+	"170213": common.ErrOrderNotFound,        // 现货订单不存在
+	"110003": common.ErrInvalidOrder,         // 价格超出允许范围
+	"110017": common.ErrInvalidOrder,         // 只减仓订单会增加仓位
+	"110094": common.ErrInvalidOrder,         // 订单名义价值低于下限
+	"170136": common.ErrInvalidOrder,         // 数量超过上限
+	"170137": common.ErrInvalidOrder,         // 数量精度超出限制
+	"170140": common.ErrInvalidOrder,         // 订单金额低于下限
+	"10003":  common.ErrAuthenticationFailed, // API Key 无效
+	"10004":  common.ErrAuthenticationFailed, // 签名错误
+	"10005":  common.ErrAuthenticationFailed, // 权限不足
+	"10010":  common.ErrAuthenticationFailed, // IP 不在白名单
+	"33004":  common.ErrAuthenticationFailed, // API Key 已过期
+}
+
+// newBybitError 根据 retCode/retMsg 创建交易所错误
+func newBybitError(retCode int, retMsg string) error {
+	return common.NewExchangeError("bybit", strconv.Itoa(retCode), retMsg, bybitErrorCodes)
+}
+
+// parseBybitError 解析 Bybit 非 2xx 响应体 {"retCode":10006,"retMsg":"..."}
+func parseBybitError(httpErr *common.HTTPError) error {
+	var body struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+	}
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.RetCode == 0 {
+		return nil
+	}
+	e := common.NewExchangeError("bybit", strconv.Itoa(body.RetCode), body.RetMsg, bybitErrorCodes)
+	e.Cause = httpErr
+	return e
+}
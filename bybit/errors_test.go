@@ -0,0 +1,47 @@
+package bybit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+)
+
+func TestNewBybitError(t *testing.T) {
+	tests := []struct {
+		name    string
+		retCode int
+		retMsg  string
+		want    error
+	}{
+		{"insufficient balance", 110007, "ab not enough for new order", common.ErrInsufficientFunds},
+		{"spot insufficient balance", 170131, "Insufficient balance.", common.ErrInsufficientFunds},
+		{"rate limit", 10006, "Too many visits!", common.ErrRateLimitExceeded},
+		{"order not found", 110001, "order not exists or too late to cancel", common.ErrOrderNotFound},
+		{"min notional", 170140, "Order value exceeded lower limit.", common.ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("cancel order fail: %w", newBybitError(tt.retCode, tt.retMsg))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want errors.Is %v", err, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.retMsg) {
+				t.Errorf("err = %q, want original retMsg", err.Error())
+			}
+		})
+	}
+}
+
+func TestParseBybitError(t *testing.T) {
+	err := parseBybitError(&common.HTTPError{StatusCode: 403, Body: `{"retCode":10006,"retMsg":"Too many visits!"}`})
+	if !errors.Is(err, common.ErrRateLimitExceeded) {
+		t.Errorf("err = %v, want ErrRateLimitExceeded", err)
+	}
+	if err := parseBybitError(&common.HTTPError{StatusCode: 403, Body: "access denied"}); err != nil {
+		t.Errorf("non-JSON body err = %v, want nil", err)
+	}
+}
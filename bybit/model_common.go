@@ -3,7 +3,9 @@ package bybit
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/types"
 )
 
@@ -147,3 +149,128 @@ func (k *bybitKline) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// bybitTrade Bybit 逐笔成交（现货和合约共用）
+type bybitTrade struct {
+	ExecID string            `json:"execId"`
+	Symbol string            `json:"symbol"`
+	Price  types.ExDecimal   `json:"price"`
+	Size   types.ExDecimal   `json:"size"`
+	Side   string            `json:"side"`
+	Time   types.ExTimestamp `json:"time"`
+}
+
+// bybitTradesResponse Bybit 逐笔成交响应
+type bybitTradesResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Category string       `json:"category"`
+		List     []bybitTrade `json:"list"`
+	} `json:"result"`
+}
+
+// toTrades 转换为统一的成交结构
+func (r bybitTradesResponse) toTrades(symbol string) []*model.Trade {
+	trades := make([]*model.Trade, 0, len(r.Result.List))
+	for _, item := range r.Result.List {
+		trades = append(trades, &model.Trade{
+			ID:        item.ExecID,
+			Symbol:    symbol,
+			Side:      strings.ToLower(item.Side),
+			Amount:    item.Size.Decimal,
+			Price:     item.Price.Decimal,
+			Timestamp: item.Time.Time,
+		})
+	}
+	return trades
+}
+
+// bybitOrderBookResponse Bybit 深度响应（现货和合约共用）
+type bybitOrderBookResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Symbol    string              `json:"s"`   // 交易对
+		Bids      [][]types.ExDecimal `json:"b"`   // 买盘 [price, size]
+		Asks      [][]types.ExDecimal `json:"a"`   // 卖盘 [price, size]
+		Timestamp types.ExTimestamp   `json:"ts"`  // 撮合引擎生成数据的时间（毫秒）
+		UpdateID  int64               `json:"u"`   // 更新ID
+		Seq       int64               `json:"seq"` // 撮合序列号
+	} `json:"result"`
+	Time types.ExTimestamp `json:"time"`
+}
+
+// bybitAmendOrderResponse Bybit 改单响应（现货和合约共用）
+type bybitAmendOrderResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		OrderID     string `json:"orderId"`     // 系统订单号
+		OrderLinkID string `json:"orderLinkId"` // 客户端订单ID
+	} `json:"result"`
+}
+
+// bybitTimeResponse Bybit 服务器时间响应
+type bybitTimeResponse struct {
+	RetCode int               `json:"retCode"`
+	RetMsg  string            `json:"retMsg"`
+	Time    types.ExTimestamp `json:"time"` // 服务器时间（毫秒）
+}
+
+// bybitSystemStatusResponse Bybit 系统维护状态响应
+type bybitSystemStatusResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []bybitSystemStatus `json:"list"`
+	} `json:"result"`
+}
+
+// bybitSystemStatus Bybit 系统维护事件
+type bybitSystemStatus struct {
+	ID    string            `json:"id"`    // 维护事件ID
+	Title string            `json:"title"` // 标题
+	State string            `json:"state"` // 状态：scheduled/ongoing/completed/canceled
+	Begin types.ExTimestamp `json:"begin"` // 开始时间（毫秒）
+	End   types.ExTimestamp `json:"end"`   // 结束时间（毫秒）
+	Href  string            `json:"href"`  // 公告链接
+}
+
+// bybitBatchOrderResponse Bybit 批量下单响应（现货和合约共用）
+type bybitBatchOrderResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			OrderID     string `json:"orderId"`
+			OrderLinkID string `json:"orderLinkId"`
+		} `json:"list"`
+	} `json:"result"`
+	RetExtInfo struct {
+		List []struct {
+			Code int    `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"list"`
+	} `json:"retExtInfo"`
+	Time types.ExTimestamp `json:"time"`
+}
+
+// bybitExecutionResponse Bybit 成交明细响应（/v5/execution/list，现货和合约共用）
+type bybitExecutionResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			Symbol    string            `json:"symbol"`
+			ExecID    string            `json:"execId"`    // 成交ID
+			OrderID   string            `json:"orderId"`   // 订单ID
+			Side      string            `json:"side"`      // Buy/Sell
+			ExecQty   types.ExDecimal   `json:"execQty"`   // 成交数量
+			ExecPrice types.ExDecimal   `json:"execPrice"` // 成交价格
+			ExecValue types.ExDecimal   `json:"execValue"` // 成交金额
+			ExecTime  types.ExTimestamp `json:"execTime"`  // 成交时间（毫秒）
+		} `json:"list"`
+		NextPageCursor string `json:"nextPageCursor"`
+	} `json:"result"`
+}
@@ -15,3 +15,69 @@ type bybitPerpTickerResponse struct {
 	RetExtInfo map[string]interface{} `json:"retExtInfo"`
 	Time       types.ExTimestamp      `json:"time"`
 }
+
+// bybitFundingHistoryResponse Bybit 历史资金费率响应
+type bybitFundingHistoryResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Category string `json:"category"`
+		List     []struct {
+			Symbol               string            `json:"symbol"`
+			FundingRate          types.ExDecimal   `json:"fundingRate"`
+			FundingRateTimestamp types.ExTimestamp `json:"fundingRateTimestamp"`
+		} `json:"list"`
+	} `json:"result"`
+}
+
+// bybitPerpCreateOrderResponse Bybit 合约创建订单响应
+type bybitPerpCreateOrderResponse struct {
+	RetCode int    `json:"retCode"` // 返回码，0 表示成功
+	RetMsg  string `json:"retMsg"`  // 返回消息
+	Result  struct {
+		OrderID     string `json:"orderId"`     // 系统订单号
+		OrderLinkID string `json:"orderLinkId"` // 客户端订单ID
+	} `json:"result"` // 订单结果
+	RetExtInfo map[string]interface{} `json:"retExtInfo"` // 扩展信息
+	Time       types.ExTimestamp      `json:"time"`       // 时间戳（毫秒）
+}
+
+// bybitPerpTradingStopResponse Bybit 设置持仓止盈止损/跟踪止损响应
+type bybitPerpTradingStopResponse struct {
+	RetCode int               `json:"retCode"` // 返回码，0 表示成功
+	RetMsg  string            `json:"retMsg"`  // 返回消息
+	Time    types.ExTimestamp `json:"time"`    // 时间戳（毫秒）
+}
+
+// bybitPerpPositionLeverageResponse Bybit 持仓列表响应（仅解析各方向杠杆）
+type bybitPerpPositionLeverageResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			PositionIdx int             `json:"positionIdx"` // 0 单向持仓，1 双向持仓多头，2 双向持仓空头
+			Leverage    types.ExDecimal `json:"leverage"`    // 当前杠杆
+		} `json:"list"`
+	} `json:"result"`
+}
+
+// bybitPerpOrderItem Bybit 合约订单详情（查询订单和私有频道 order 推送共用）
+type bybitPerpOrderItem struct {
+	Category    string            `json:"category"`    // 产品类型（仅推送包含）
+	OrderID     string            `json:"orderId"`     // 订单ID
+	OrderLinkID string            `json:"orderLinkId"` // 客户端自定义订单ID
+	Symbol      string            `json:"symbol"`      // 交易对 / 合约标的
+	Price       types.ExDecimal   `json:"price"`       // 下单价格
+	AvgPrice    types.ExDecimal   `json:"avgPrice"`    // 成交均价
+	Qty         types.ExDecimal   `json:"qty"`         // 下单数量
+	CumExecQty  types.ExDecimal   `json:"cumExecQty"`  // 实际成交数量
+	OrderStatus string            `json:"orderStatus"` // 订单状态
+	TimeInForce string            `json:"timeInForce"` // 订单有效方式
+	ReduceOnly  bool              `json:"reduceOnly"`  // 是否只减仓
+	OrderType   string            `json:"orderType"`   // 订单类型
+	Side        string            `json:"side"`        // 订单方向
+	PositionIdx int               `json:"positionIdx"` // 单向持仓 positionIdx 等于 0，双向持仓 开多/平多 → positionIdx 等于 1，开空/平空 → positionIdx 等于 2
+	CreatedTime types.ExTimestamp `json:"createdTime"` // 创建时间（毫秒）
+	UpdatedTime types.ExTimestamp `json:"updatedTime"` // 更新时间（毫秒）
+	CreateType  string            `json:"createType"`  // 订单创建类型（CreateByUser/CreateByLiq/CreateByAdl_PassThrough 等）
+}
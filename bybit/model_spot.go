@@ -119,3 +119,156 @@ type bybitSpotFetchOrderItem struct {
 	Qty            types.ExDecimal   `json:"qty"`            // 订单数量
 	LeavesValue    types.ExDecimal   `json:"leavesValue"`    // 剩余价值
 }
+
+// bybitSpotConvertQuoteResponse Bybit 闪兑询价响应
+type bybitSpotConvertQuoteResponse struct {
+	RetCode int                         `json:"retCode"`
+	RetMsg  string                      `json:"retMsg"`
+	Result  bybitSpotConvertQuoteResult `json:"result"`
+	Time    types.ExTimestamp           `json:"time"`
+}
+
+// bybitSpotConvertQuoteResult Bybit 闪兑询价结果
+type bybitSpotConvertQuoteResult struct {
+	QuoteTxID    string            `json:"quoteTxId"`    // 询价交易ID
+	ExchangeRate types.ExDecimal   `json:"exchangeRate"` // 兑换汇率
+	FromCoin     string            `json:"fromCoin"`     // 卖出币种
+	FromCoinType string            `json:"fromCoinType"` // 卖出币种类型
+	ToCoin       string            `json:"toCoin"`       // 买入币种
+	ToCoinType   string            `json:"toCoinType"`   // 买入币种类型
+	FromAmount   types.ExDecimal   `json:"fromAmount"`   // 卖出数量
+	ToAmount     types.ExDecimal   `json:"toAmount"`     // 买入数量
+	ExpiredTime  types.ExTimestamp `json:"expiredTime"`  // 报价过期时间
+	RequestID    string            `json:"requestId"`    // 请求ID
+}
+
+// bybitSpotConvertExecuteResponse Bybit 闪兑确认响应
+type bybitSpotConvertExecuteResponse struct {
+	RetCode int                           `json:"retCode"`
+	RetMsg  string                        `json:"retMsg"`
+	Result  bybitSpotConvertExecuteResult `json:"result"`
+	Time    types.ExTimestamp             `json:"time"`
+}
+
+// bybitSpotConvertExecuteResult Bybit 闪兑确认结果
+type bybitSpotConvertExecuteResult struct {
+	QuoteTxID      string `json:"quoteTxId"`      // 询价交易ID
+	ExchangeStatus string `json:"exchangeStatus"` // 闪兑状态（init/processing/success/failure）
+}
+
+// bybitSpotCoinInfoResponse Bybit 币种信息响应
+type bybitSpotCoinInfoResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Rows []bybitSpotCoinInfo `json:"rows"`
+	} `json:"result"`
+}
+
+// bybitSpotCoinInfo Bybit 币种信息
+type bybitSpotCoinInfo struct {
+	Name   string               `json:"name"`   // 币种名称
+	Coin   string               `json:"coin"`   // 币种
+	Chains []bybitSpotCoinChain `json:"chains"` // 支持的链
+}
+
+// bybitSpotCoinChain Bybit 币种单条链信息
+type bybitSpotCoinChain struct {
+	Chain         string          `json:"chain"`         // 链名称，如 ETH、TRX
+	ChainType     string          `json:"chainType"`     // 链类型，如 ERC20、TRC20
+	ChainDeposit  string          `json:"chainDeposit"`  // 是否可充值（1 可充值）
+	ChainWithdraw string          `json:"chainWithdraw"` // 是否可提币（1 可提币）
+	WithdrawFee   types.ExDecimal `json:"withdrawFee"`   // 提币手续费
+	WithdrawMin   types.ExDecimal `json:"withdrawMin"`   // 最小提币数量
+}
+
+// bybitSpotDepositAddressResponse Bybit 充值地址响应
+type bybitSpotDepositAddressResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		Coin   string                         `json:"coin"`
+		Chains []bybitSpotDepositAddressChain `json:"chains"`
+	} `json:"result"`
+}
+
+// bybitSpotDepositAddressChain Bybit 单条链的充值地址
+type bybitSpotDepositAddressChain struct {
+	ChainType      string `json:"chainType"`      // 链类型
+	AddressDeposit string `json:"addressDeposit"` // 充值地址
+	TagDeposit     string `json:"tagDeposit"`     // 地址标签
+	Chain          string `json:"chain"`          // 链名称
+}
+
+// bybitSpotWithdrawResponse Bybit 提币响应
+type bybitSpotWithdrawResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		ID string `json:"id"` // 提币ID
+	} `json:"result"`
+	Time types.ExTimestamp `json:"time"`
+}
+
+// bybitCollateralInfoResponse Bybit 统一账户抵押与借币信息响应
+type bybitCollateralInfoResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			Currency         string          `json:"currency"`         // 币种
+			HourlyBorrowRate types.ExDecimal `json:"hourlyBorrowRate"` // 每小时借币利率
+			Borrowable       bool            `json:"borrowable"`       // 是否可借
+		} `json:"list"`
+	} `json:"result"`
+	Time types.ExTimestamp `json:"time"`
+}
+
+// bybitBorrowRepayResponse Bybit 手动借币还币响应
+type bybitBorrowRepayResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+}
+
+// bybitSubMembersResponse Bybit 子账户列表响应
+type bybitSubMembersResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		SubMembers []struct {
+			UID      string `json:"uid"`      // 子账户 UID
+			Username string `json:"username"` // 子账户用户名
+			Status   int    `json:"status"`   // 状态：1 正常，2 禁止登录，4 冻结
+			Remark   string `json:"remark"`   // 备注
+		} `json:"subMembers"`
+	} `json:"result"`
+}
+
+// bybitSubMemberFrozen 子账户冻结状态
+const bybitSubMemberFrozen = 4
+
+// bybitSpotTransferResponse Bybit 账户划转响应
+type bybitSpotTransferResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		TransferID string `json:"transferId"` // 划转ID
+		Status     string `json:"status"`     // 划转状态（SUCCESS/PENDING/FAILED/STATUS_UNKNOWN）
+	} `json:"result"`
+	Time types.ExTimestamp `json:"time"`
+}
+
+// bybitSpotFundingBalanceResponse Bybit 资金账户余额响应
+type bybitSpotFundingBalanceResponse struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		AccountType string `json:"accountType"`
+		Balance     []struct {
+			Coin            string          `json:"coin"`            // 币种
+			WalletBalance   types.ExDecimal `json:"walletBalance"`   // 钱包余额
+			TransferBalance types.ExDecimal `json:"transferBalance"` // 可划转余额
+		} `json:"balance"`
+	} `json:"result"`
+	Time types.ExTimestamp `json:"time"`
+}
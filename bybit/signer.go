@@ -29,9 +29,10 @@ func (s *Signer) SetAPIKey(apiKey string) {
 // method: GET, POST, DELETE
 // params: 查询参数
 // body: 请求体（POST 时使用）
-func (s *Signer) SignRequest(method string, params map[string]interface{}, body map[string]interface{}) (signature, timestamp string) {
-	timestamp = strconv.FormatInt(common.GetTimestamp(), 10)
-	recvWindow := "5000" // 默认接收窗口
+// timestampMs: 签名时间戳（毫秒）
+// recvWindow: 接收窗口（毫秒），需与 X-BAPI-RECV-WINDOW 请求头一致
+func (s *Signer) SignRequest(method string, params map[string]interface{}, body map[string]interface{}, timestampMs int64, recvWindow string) (signature, timestamp string) {
+	timestamp = strconv.FormatInt(timestampMs, 10)
 
 	// 构建查询字符串
 	queryString := ""
@@ -68,3 +69,8 @@ func (s *Signer) Sign(queryString string) string {
 func BuildQueryString(params map[string]interface{}) string {
 	return common.BuildQueryString(params)
 }
+
+// SignWSAuth 私有 WebSocket 鉴权签名：HMAC_SHA256("GET/realtime" + expires)
+func (s *Signer) SignWSAuth(expires int64) string {
+	return common.SignHMAC256("GET/realtime"+strconv.FormatInt(expires, 10), s.secretKey)
+}
@@ -0,0 +1,275 @@
+package bybit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// bybitWSProtocol Bybit V5 公共频道订阅协议
+// 订阅消息形如 {"op":"subscribe","args":["tickers.BTCUSDT"]}，推送消息形如 {"topic":"tickers.BTCUSDT","type":"snapshot","data":{...}}；
+// 服务端要求每 20 秒发送一次 {"op":"ping"}，否则断开连接
+type bybitWSProtocol struct{}
+
+// bybitWSRequest Bybit WebSocket 订阅请求
+type bybitWSRequest struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args"`
+}
+
+// SubscribeMessage 构建订阅消息
+func (bybitWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(bybitWSRequest{Op: "subscribe", Args: topics})
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (bybitWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(bybitWSRequest{Op: "unsubscribe", Args: topics})
+}
+
+// Route 推送消息的 topic 字段即为订阅主题，订阅响应和 pong 没有 topic 字段
+func (bybitWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Topic == "" {
+		return "", false
+	}
+	return m.Topic, true
+}
+
+// PingMessage 心跳消息
+func (bybitWSProtocol) PingMessage() []byte {
+	msg, _ := json.Marshal(bybitWSRequest{Op: "ping", Args: []string{}})
+	return msg
+}
+
+// bybitWSMessage Bybit WebSocket 推送消息
+type bybitWSMessage struct {
+	Topic string                     `json:"topic"`
+	Type  string                     `json:"type"` // snapshot 全量 / delta 增量
+	Ts    types.ExTimestamp          `json:"ts"`
+	Data  map[string]json.RawMessage `json:"data"`
+}
+
+// parseBybitWSTicker 返回解析行情推送的函数，symbol 为标准化格式
+// 合约行情先推送 snapshot，之后只推送变化字段的 delta，需合并到最近一次的全量数据上；
+// 现货行情每次都是 snapshot，且不包含买一/卖一价
+func parseBybitWSTicker(symbol string) common.WSParser[*model.Ticker] {
+	var state map[string]json.RawMessage
+	return func(msg []byte) (*model.Ticker, bool) {
+		var m bybitWSMessage
+		if err := json.Unmarshal(msg, &m); err != nil || m.Data == nil {
+			return nil, false
+		}
+
+		if m.Type == "delta" {
+			// 尚未收到全量数据时无法合并增量
+			if state == nil {
+				return nil, false
+			}
+			for k, v := range m.Data {
+				state[k] = v
+			}
+		} else {
+			state = m.Data
+		}
+
+		raw, err := json.Marshal(state)
+		if err != nil {
+			return nil, false
+		}
+		var item bybitTickerItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+
+		ticker := &model.Ticker{
+			Symbol:    symbol,
+			Timestamp: m.Ts,
+		}
+		ticker.Bid = item.Bid1Price
+		ticker.Ask = item.Ask1Price
+		ticker.Last = item.LastPrice
+		ticker.Open = item.PrevPrice24h
+		ticker.High = item.HighPrice24h
+		ticker.Low = item.LowPrice24h
+		ticker.Volume = item.Volume24h
+		ticker.QuoteVolume = item.Turnover24h
+		return ticker, true
+	}
+}
+
+// bybitWSOrderBook Bybit 深度推送数据
+type bybitWSOrderBook struct {
+	Symbol   string              `json:"s"`   // 交易对
+	Bids     [][]types.ExDecimal `json:"b"`   // 买单 [价格, 数量]
+	Asks     [][]types.ExDecimal `json:"a"`   // 卖单 [价格, 数量]
+	UpdateID int64               `json:"u"`   // 更新ID
+	Seq      int64               `json:"seq"` // 撮合序号
+}
+
+// bybitOrderBookTopic 返回深度推送主题，档位数取不小于 depth 的最小可订阅档位（depth <= 0 时取最大档位）
+func bybitOrderBookTopic(marketID string, depth int, allowed []int) string {
+	level := allowed[len(allowed)-1]
+	if depth > 0 {
+		level = common.RoundOrderBookLimit(depth, allowed)
+	}
+	return fmt.Sprintf("orderbook.%d.%s", level, marketID)
+}
+
+// parseBybitWSOrderBook 解析深度推送，u 为 1 时表示服务重启后的全量推送
+func parseBybitWSOrderBook(msg []byte) (*common.OrderBookDelta, bool) {
+	var m struct {
+		Type string            `json:"type"`
+		Ts   types.ExTimestamp `json:"ts"`
+		Data bybitWSOrderBook  `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Type == "" {
+		return nil, false
+	}
+
+	return &common.OrderBookDelta{
+		Snapshot:      m.Type == "snapshot" || m.Data.UpdateID == 1,
+		FinalUpdateID: m.Data.UpdateID,
+		Bids:          common.ParseOrderBookLevels(m.Data.Bids, 0),
+		Asks:          common.ParseOrderBookLevels(m.Data.Asks, 0),
+		Timestamp:     m.Ts,
+	}, true
+}
+
+// bybitWSKline Bybit K线推送数据
+type bybitWSKline struct {
+	Start    types.ExTimestamp `json:"start"`    // 开盘时间（毫秒）
+	End      types.ExTimestamp `json:"end"`      // 收盘时间（毫秒）
+	Interval string            `json:"interval"` // 周期
+	Open     types.ExDecimal   `json:"open"`     // 开盘价
+	Close    types.ExDecimal   `json:"close"`    // 收盘价
+	High     types.ExDecimal   `json:"high"`     // 最高价
+	Low      types.ExDecimal   `json:"low"`      // 最低价
+	Volume   types.ExDecimal   `json:"volume"`   // 成交量
+	Turnover types.ExDecimal   `json:"turnover"` // 成交额
+	Confirm  bool              `json:"confirm"`  // 是否已收盘
+}
+
+// bybitKlineTopic 返回K线推送主题，如 kline.1.BTCUSDT
+func bybitKlineTopic(marketID, interval string) string {
+	return "kline." + interval + "." + marketID
+}
+
+// parseBybitWSKline 解析K线推送，data 为K线数组，取最后一根（最新）K线
+func parseBybitWSKline(msg []byte) (*model.OHLCV, bool) {
+	var m struct {
+		Data []bybitWSKline `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+		return nil, false
+	}
+
+	k := m.Data[len(m.Data)-1]
+	return &model.OHLCV{
+		Timestamp: k.Start,
+		Open:      k.Open,
+		High:      k.High,
+		Low:       k.Low,
+		Close:     k.Close,
+		Volume:    k.Volume,
+		Closed:    k.Confirm,
+	}, true
+}
+
+const (
+	// bybitWSOrderTopic 私有频道订单主题（包含现货和合约全部分类）
+	bybitWSOrderTopic = "order"
+	// bybitWSWalletTopic 私有频道钱包主题（统一账户余额）
+	bybitWSWalletTopic = "wallet"
+	// bybitWSAuthExpiry 私有频道鉴权签名有效期
+	bybitWSAuthExpiry = 10 * time.Second
+)
+
+// bybitPrivateWSProtocol Bybit V5 私有频道协议，订阅、推送与心跳格式同公共频道
+type bybitPrivateWSProtocol struct {
+	bybitWSProtocol
+}
+
+// bybitWSAuthRequest 私有频道鉴权请求，args 为 [apiKey, expires, signature]
+type bybitWSAuthRequest struct {
+	Op   string        `json:"op"`
+	Args []interface{} `json:"args"`
+}
+
+// wsLogin 私有频道鉴权，每次建立连接（包括断线重连）后调用
+func (b *Bybit) wsLogin(ctx context.Context, conn common.WSConn) error {
+	creds := b.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	expires := b.clock.Now().Add(bybitWSAuthExpiry).UnixMilli()
+	msg, err := json.Marshal(bybitWSAuthRequest{
+		Op:   "auth",
+		Args: []interface{}{creds.apiKey, expires, creds.signer.SignWSAuth(expires)},
+	})
+	if err != nil {
+		return err
+	}
+
+	return common.WSLoginRequest(conn, msg, func(resp []byte) (bool, error) {
+		var m struct {
+			Op      string `json:"op"`
+			Success bool   `json:"success"`
+			RetMsg  string `json:"ret_msg"`
+		}
+		if err := json.Unmarshal(resp, &m); err != nil || m.Op != "auth" {
+			return false, nil
+		}
+		if !m.Success {
+			return true, fmt.Errorf("auth failed: %s", m.RetMsg)
+		}
+		return true, nil
+	})
+}
+
+// parseBybitWSOrders 返回解析私有频道订单推送的函数，只推送 categories 中的订单，一条推送可能包含多个订单
+func parseBybitWSOrders[T any](categories []string, convert func(raw json.RawMessage) (T, bool)) common.WSParser[[]T] {
+	return func(msg []byte) ([]T, bool) {
+		var m struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return nil, false
+		}
+
+		var orders []T
+		for _, raw := range m.Data {
+			var item struct {
+				Category string `json:"category"`
+			}
+			if err := json.Unmarshal(raw, &item); err != nil || !slices.Contains(categories, item.Category) {
+				continue
+			}
+			if order, ok := convert(raw); ok {
+				orders = append(orders, order)
+			}
+		}
+		return orders, len(orders) > 0
+	}
+}
+
+// parseBybitWSWallet 解析私有频道钱包推送，每次推送包含账户全部币种的余额快照
+func parseBybitWSWallet(msg []byte) (model.Balances, bool) {
+	var m struct {
+		CreationTime types.ExTimestamp         `json:"creationTime"`
+		Data         []bybitSpotBalanceAccount `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+		return nil, false
+	}
+	return toBybitBalances(m.Data[0].Coin, m.CreationTime), true
+}
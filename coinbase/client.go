@@ -0,0 +1,120 @@
+package coinbase
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+)
+
+const (
+	coinbaseName    = "coinbase"
+	coinbaseBaseURL = "https://api.coinbase.com"
+
+	// coinbaseOHLCVPageLimit 单次返回的最大K线数
+	coinbaseOHLCVPageLimit = 350
+
+	// coinbaseAccountsPageLimit 账户列表单页最大条数
+	coinbaseAccountsPageLimit = 250
+
+	// coinbaseJWTExpiry JWT 有效期（Coinbase 要求不超过 2 分钟）
+	coinbaseJWTExpiry = 2 * time.Minute
+)
+
+// coinbaseTimeframes K线支持的周期
+var coinbaseTimeframes = []string{"ONE_MINUTE", "FIVE_MINUTE", "FIFTEEN_MINUTE", "THIRTY_MINUTE", "ONE_HOUR", "TWO_HOUR", "FOUR_HOUR", "SIX_HOUR", "ONE_DAY"}
+
+// Client Coinbase 客户端
+type Client struct {
+	// HTTPClient HTTP 客户端
+	HTTPClient *common.HTTPClient
+
+	// Host API 域名（JWT 的 uri 声明使用）
+	Host string
+
+	// ProxyURL 代理地址
+	ProxyURL string
+
+	// Debug 是否启用调试模式
+	Debug bool
+}
+
+// NewClient 创建 Coinbase 客户端
+// API 凭证由 Coinbase 持有（见 credentials），以支持运行时轮换；Advanced Trade 沙盒只返回固定的模拟数据，忽略 sandbox 选项
+func NewClient(options map[string]interface{}) (*Client, error) {
+	baseURL := coinbaseBaseURL
+	proxyURL := ""
+	debug := false
+
+	if v, ok := options["baseURL"].(string); ok {
+		baseURL = v
+	}
+	if v, ok := options["proxy"].(string); ok {
+		proxyURL = v
+	}
+	if v, ok := options["debug"].(bool); ok {
+		debug = v
+	}
+
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+
+	client := &Client{
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
+		Host:       parsedURL.Host,
+		ProxyURL:   proxyURL,
+		Debug:      debug,
+	}
+
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseCoinbaseError)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
+	// 设置代理
+	if proxyURL != "" {
+		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	// 设置调试模式
+	if debug {
+		client.HTTPClient.SetDebug(true)
+	}
+
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
+	}
+
+	return client, nil
+}
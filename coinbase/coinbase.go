@@ -0,0 +1,243 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+)
+
+// Coinbase Coinbase Advanced Trade 交易所实现
+type Coinbase struct {
+	client              *Client
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
+	spot                *CoinbaseSpot
+	perp                *common.UnsupportedPerp  // 永续合约占位实现（未接入）
+	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
+	spotMarketsByID     map[string]*model.Market // 现货市场信息（原始格式索引）
+	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+}
+
+// NewCoinbase 创建 Coinbase 交易所实例
+// apiKey/secretKey 为 CDP API Key 名称和 PEM 格式 EC 私钥时使用 JWT 认证，否则按旧版 API Key 使用 HMAC 签名
+func NewCoinbase(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
+	coinbase := &Coinbase{
+		client:              client,
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		coinbase.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(coinbase.lifecycle)
+
+	coinbase.UpdateCredentials(apiKey, secretKey, "")
+
+	// 初始化现货和合约实现
+	coinbase.spot = NewCoinbaseSpot(coinbase)
+	coinbase.perp = common.NewUnsupportedPerp(coinbaseName)
+
+	if v, ok := options["timeSync"].(bool); ok && v {
+		coinbase.clock.Start(common.TimeSyncInterval, coinbase.FetchTime)
+	}
+
+	return coinbase, nil
+}
+
+// Spot 返回现货交易接口
+func (c *Coinbase) Spot() exchange.SpotExchange {
+	return c.spot
+}
+
+// Perp 返回永续合约交易接口（Coinbase 永续合约暂不支持）
+func (c *Coinbase) Perp() exchange.PerpExchange {
+	return c.perp
+}
+
+// Name 返回交易所名称
+func (c *Coinbase) Name() string {
+	return coinbaseName
+}
+
+// Has 返回 Coinbase 支持的功能
+func (c *Coinbase) Has() model.Capabilities {
+	return coinbaseCapabilities
+}
+
+// Symbols 返回已加载的现货市场的标准化 symbol，按字母排序（未接入合约）
+func (c *Coinbase) Symbols() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return common.MarketSymbols(c.spotMarketsBySymbol, nil)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (c *Coinbase) NormalizeSymbol(nativeID string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, c.spotMarketsByID, nil, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (c *Coinbase) DenormalizeSymbol(symbol string) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, c.spotMarketsBySymbol, nil, nil)
+}
+
+// ExportMarkets 导出已加载的现货市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (c *Coinbase) ExportMarkets() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return common.MarshalMarkets(coinbaseName, c.spotMarketsBySymbol, nil)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换已加载的现货市场（导出时为空则保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (c *Coinbase) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(coinbaseName, data)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		c.spotMarketsBySymbol, c.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		c.marketCache.Touch(model.MarketTypeSpot)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后已建立的轮询订阅通道随之关闭
+func (c *Coinbase) Drain(ctx context.Context) error {
+	c.clock.Stop()
+	return c.lifecycle.Drain(ctx, c.spot, c.perp)
+}
+
+// FetchTime 获取交易所服务器时间（/api/v3/brokerage/time）
+func (c *Coinbase) FetchTime(ctx context.Context) (time.Time, error) {
+	var result coinbaseTimeResponse
+	if err := c.publicGet(ctx, "/api/v3/brokerage/time", nil, &result); err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+	return result.EpochMillis.Time, nil
+}
+
+// FetchStatus 获取系统状态，Advanced Trade 没有系统状态接口，服务器时间接口可访问即视为正常
+func (c *Coinbase) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	if _, err := c.FetchTime(ctx); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+	return common.OKStatus(), nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (c *Coinbase) Ping(ctx context.Context) error {
+	return common.Ping(ctx, c.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (c *Coinbase) VerifyCredentials(ctx context.Context) error {
+	creds := c.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", c.spot.FetchBalance)
+}
+
+// coinbaseCapabilities Coinbase 支持的功能
+var coinbaseCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported: true,
+	},
+	Perp: model.MarketCapabilities{},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// Coinbase 不需要 password，忽略该参数
+func (c *Coinbase) UpdateCredentials(apiKey, secretKey, password string) {
+	c.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(apiKey, secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (c *Coinbase) credentials() *credentials {
+	return c.creds.Load()
+}
+
+// publicGet 请求公共接口并解析响应
+func (c *Coinbase) publicGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := c.client.HTTPClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// signAndRequest 签名并发送请求，签名覆盖 timestamp + method + path + body（不包含查询字符串）
+func (c *Coinbase) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}, result interface{}) error {
+	creds := c.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	bodyStr := ""
+	var reqBody interface{}
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		bodyStr = string(bodyBytes)
+		reqBody = body
+	}
+
+	headers, err := creds.signer.Headers(method, c.client.Host, path, bodyStr, c.clock.Now())
+	if err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+
+	resp, err := c.client.HTTPClient.RequestWithHeaders(ctx, method, path, params, reqBody, headers)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+var _ exchange.Exchange = (*Coinbase)(nil)
@@ -0,0 +1,583 @@
+package coinbase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// CoinbaseSpot Coinbase 现货实现
+type CoinbaseSpot struct {
+	coinbase *Coinbase
+}
+
+// NewCoinbaseSpot 创建 Coinbase 现货实例
+func NewCoinbaseSpot(c *Coinbase) *CoinbaseSpot {
+	return &CoinbaseSpot{coinbase: c}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载现货市场（/api/v3/brokerage/market/products，与 /api/v3/brokerage/products 返回相同但无需签名）
+// product_id 形如 BTC-USD，直接由 base/quote 转换为 BTC/USD；只保留 SPOT 类型
+func (s *CoinbaseSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	s.coinbase.mu.RLock()
+	if !reload && len(s.coinbase.spotMarketsBySymbol) > 0 && !s.coinbase.marketCache.Expired(model.MarketTypeSpot) {
+		s.coinbase.mu.RUnlock()
+		return nil
+	}
+	s.coinbase.mu.RUnlock()
+
+	var data coinbaseProducts
+	if err := s.coinbase.publicGet(ctx, "/api/v3/brokerage/market/products", map[string]interface{}{"product_type": "SPOT"}, &data); err != nil {
+		return fmt.Errorf("fetch spot markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data.Products))
+	for _, item := range data.Products {
+		if item.ProductType != "SPOT" {
+			continue
+		}
+		market := toMarket(item)
+		markets = append(markets, market)
+	}
+
+	s.coinbase.mu.Lock()
+	s.coinbase.spotMarketsBySymbol, s.coinbase.spotMarketsByID = common.IndexMarkets(markets)
+	s.coinbase.marketCache.Touch(model.MarketTypeSpot)
+	s.coinbase.mu.Unlock()
+
+	return nil
+}
+
+// toMarket 转换为标准化市场信息，暂停交易、只允许撤单或非 online 状态的交易对视为不活跃
+func toMarket(item coinbaseProduct) *model.Market {
+	symbol := common.NormalizeSymbol(item.BaseCurrencyID, item.QuoteCurrencyID)
+	base, quote, _ := strings.Cut(symbol, "/")
+	market := &model.Market{
+		ID:     item.ProductID, // Coinbase 原始格式 (BTC-USD)
+		Symbol: symbol,         // 标准化格式 (BTC/USD)
+		Base:   base,
+		Quote:  quote,
+		Type:   model.MarketTypeSpot,
+		Active: item.Status == "online" && !item.TradingDisabled && !item.IsDisabled && !item.CancelOnly,
+	}
+
+	market.Precision.Amount = decimalPlaces(item.BaseIncrement)
+	market.Precision.Price = decimalPlaces(item.PriceIncrement)
+	market.Precision.StepSize = item.BaseIncrement
+	market.Precision.TickSize = item.PriceIncrement
+	market.Limits.Amount.Min = item.BaseMinSize
+	market.Limits.Amount.Max = item.BaseMaxSize
+	market.Limits.Cost.Min = item.QuoteMinSize
+	market.Limits.Cost.Max = item.QuoteMaxSize
+	return market
+}
+
+// FetchMarkets 获取现货市场列表
+func (s *CoinbaseSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	// 确保市场已加载
+	if err := s.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.GetMarkets()
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (s *CoinbaseSpot) GetMarket(symbol string) (*model.Market, error) {
+	s.coinbase.mu.RLock()
+	defer s.coinbase.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := s.coinbase.spotMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := s.coinbase.spotMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, s.coinbase.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *CoinbaseSpot) GetMarketByID(id string) (*model.Market, error) {
+	s.coinbase.mu.RLock()
+	defer s.coinbase.mu.RUnlock()
+
+	if market, ok := s.coinbase.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, s.coinbase.spotMarketsByID)
+}
+
+// GetMarkets 从内存中获取所有现货市场信息
+func (s *CoinbaseSpot) GetMarkets() ([]*model.Market, error) {
+	s.coinbase.mu.RLock()
+	defer s.coinbase.mu.RUnlock()
+
+	markets := make([]*model.Market, 0, len(s.coinbase.spotMarketsBySymbol))
+	for _, market := range s.coinbase.spotMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+func (s *CoinbaseSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *CoinbaseSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *CoinbaseSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取行情（/api/v3/brokerage/market/products/{product_id}/ticker），取最优挂单和最近一笔成交，24小时统计字段为 0
+func (s *CoinbaseSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data coinbaseMarketTrades
+	path := "/api/v3/brokerage/market/products/" + market.ID + "/ticker"
+	if err := s.coinbase.publicGet(ctx, path, map[string]interface{}{"limit": 1}, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+
+	ticker := &model.Ticker{
+		Symbol:    market.Symbol,
+		Bid:       data.BestBid,
+		Ask:       data.BestAsk,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}
+	if len(data.Trades) > 0 {
+		ticker.Last = data.Trades[0].Price
+		ticker.Timestamp = data.Trades[0].Time
+	}
+	return ticker, nil
+}
+
+// FetchTickers 获取全部现货行情（/api/v3/brokerage/market/products），只有最新价和24小时成交量，跳过未加载的交易对
+func (s *CoinbaseSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	var data coinbaseProducts
+	if err := s.coinbase.publicGet(ctx, "/api/v3/brokerage/market/products", map[string]interface{}{"product_type": "SPOT"}, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	now := types.ExTimestamp{Time: time.Now()} // 产品列表没有返回行情时间
+	tickers := make(map[string]*model.Ticker, len(data.Products))
+	for _, item := range data.Products {
+		market, err := s.GetMarketByID(item.ProductID)
+		if err != nil {
+			continue
+		}
+		tickers[market.Symbol] = &model.Ticker{
+			Symbol:    market.Symbol,
+			Last:      item.Price,
+			Volume:    item.Volume24h,
+			Timestamp: now,
+		}
+	}
+
+	return tickers, nil
+}
+
+func (s *CoinbaseSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+func (s *CoinbaseSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return nil, notSupported("fetch order book")
+}
+
+func (s *CoinbaseSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (s *CoinbaseSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/api/v3/brokerage/market/products/{product_id}/candles），Coinbase 按开盘时间倒序返回，最多 350 根，结果转换为升序
+// 接口要求 start 和 end：未设置 since 时取最近的 limit 根，未设置 until 时截止到当前时间
+func (s *CoinbaseSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	granularity, err := common.CheckTimeframe(timeframe, coinbaseTimeframe(timeframe), coinbaseTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := common.ParseTimeframe(timeframe)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := coinbaseOHLCVPageLimit
+	if argsOpts.Limit != nil && *argsOpts.Limit > 0 && *argsOpts.Limit < limit {
+		limit = *argsOpts.Limit
+	}
+	since, hasSince := option.GetTime(argsOpts.Since)
+	until, hasUntil := option.GetTime(argsOpts.Until)
+	switch {
+	case hasSince && !hasUntil:
+		until = since.Add(duration * time.Duration(limit))
+		if now := time.Now(); until.After(now) {
+			until = now
+		}
+	case !hasSince:
+		if !hasUntil {
+			until = time.Now()
+		}
+		since = until.Add(-duration * time.Duration(limit-1))
+	}
+
+	params := map[string]interface{}{
+		"granularity": granularity,
+		"start":       since.Unix(),
+		"end":         until.Unix(),
+		"limit":       limit,
+	}
+	var data coinbaseCandles
+	path := "/api/v3/brokerage/market/products/" + market.ID + "/candles"
+	if err := s.coinbase.publicGet(ctx, path, params, &data); err != nil {
+		return nil, fmt.Errorf("fetch ohlcv: %w", err)
+	}
+
+	ohlcvs := make(model.OHLCVs, 0, len(data.Candles))
+	for _, item := range data.Candles {
+		ohlcvs = append(ohlcvs, &model.OHLCV{
+			Timestamp: item.Start,
+			Open:      item.Open,
+			High:      item.High,
+			Low:       item.Low,
+			Close:     item.Close,
+			Volume:    item.Volume,
+		})
+	}
+	slices.Reverse(ohlcvs)
+
+	if len(ohlcvs) > limit {
+		if hasSince {
+			ohlcvs = ohlcvs[:limit]
+		} else {
+			ohlcvs = ohlcvs[len(ohlcvs)-limit:]
+		}
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.coinbase.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *CoinbaseSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, coinbaseOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *CoinbaseSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (s *CoinbaseSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.coinbase.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *CoinbaseSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// FetchBalance 获取余额（/api/v3/brokerage/accounts，按 cursor 翻页），只支持现货账户
+func (s *CoinbaseSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	if accountType := option.GetAccountType(argsOpts.AccountType); accountType != option.AccountSpot {
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+
+	balances := make(model.Balances, 0)
+	params := map[string]interface{}{"limit": coinbaseAccountsPageLimit}
+	for {
+		var data coinbaseAccounts
+		if err := s.coinbase.signAndRequest(ctx, http.MethodGet, "/api/v3/brokerage/accounts", params, nil, &data); err != nil {
+			return nil, fmt.Errorf("fetch balance: %w", err)
+		}
+		for _, item := range data.Accounts {
+			available, hold := item.AvailableBalance.Value, item.Hold.Value
+			balances = append(balances, &model.Balance{
+				Currency:  common.NormalizeCurrency(item.Currency),
+				Available: available,
+				Locked:    hold,
+				Total:     types.ExDecimal{Decimal: available.Add(hold.Decimal)},
+				UpdatedAt: types.ExTimestamp{Time: time.Now()}, // Coinbase 账户接口没有返回更新时间
+			})
+		}
+		if !data.HasNext || data.Cursor == "" {
+			break
+		}
+		params["cursor"] = data.Cursor
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+func (s *CoinbaseSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/api/v3/brokerage/orders），设置 WithPrice 时为限价单，否则为市价单（数量为基础货币数量）
+// 限价单按有效期使用 limit_limit_gtc、sor_limit_ioc 或 limit_limit_fok，GTX 为 post_only 的 GTC 单
+// 未设置 option.WithClientOrderID 时自动生成 client_order_id；不支持条件单
+func (s *CoinbaseSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+
+	isLimit := option.StringPresent(argsOpts.Price)
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	clientOrderID := common.GenerateClientOrderID(coinbaseName, strings.ToLower(string(side)))
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		clientOrderID = *argsOpts.ClientOrderID
+	}
+
+	configType := "market_market_ioc"
+	config := map[string]interface{}{"base_size": size}
+	if isLimit {
+		price, err := common.PriceToPrecision(market, *argsOpts.Price)
+		if err != nil {
+			return nil, err
+		}
+		config["limit_price"] = price
+		switch {
+		case argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsIOC():
+			configType = "sor_limit_ioc"
+		case argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsFOK():
+			configType = "limit_limit_fok"
+		default:
+			configType = "limit_limit_gtc"
+			config["post_only"] = postOnly
+		}
+	}
+	body := map[string]interface{}{
+		"client_order_id":     clientOrderID,
+		"product_id":          market.ID,
+		"side":                strings.ToUpper(string(side)),
+		"order_configuration": map[string]interface{}{configType: config},
+	}
+
+	var result coinbaseCreateOrderResponse
+	if err := s.coinbase.signAndRequest(ctx, http.MethodPost, "/api/v3/brokerage/orders", nil, body, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("create order: %w", createOrderError(&result))
+	}
+
+	order := &model.NewOrder{
+		OrderId:       result.SuccessResponse.OrderID,
+		ClientOrderID: clientOrderID,
+		Symbol:        symbol,
+		Timestamp:     types.ExTimestamp{Time: time.Now()},
+	}
+	s.coinbase.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// createOrderError 转换下单失败响应，优先使用具体的失败原因作为错误码
+func createOrderError(result *coinbaseCreateOrderResponse) error {
+	resp := result.ErrorResponse
+	code := resp.Error
+	for _, reason := range []string{resp.NewOrderFailureReason, resp.PreviewFailureReason} {
+		if reason != "" && reason != "UNKNOWN_FAILURE_REASON" {
+			code = reason
+			break
+		}
+	}
+	msg := resp.Message
+	if resp.ErrorDetails != "" {
+		msg += ": " + resp.ErrorDetails
+	}
+	return newCoinbaseError(code, msg)
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *CoinbaseSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *CoinbaseSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// CancelOrder 撤销订单（/api/v3/brokerage/orders/batch_cancel），Coinbase 只能按订单ID撤单
+func (s *CoinbaseSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if orderId == "" {
+		return fmt.Errorf("cancel order: order id is required: %w", common.ErrInvalidOrder)
+	}
+
+	var result coinbaseCancelOrdersResponse
+	body := map[string]interface{}{"order_ids": []string{orderId}}
+	if err := s.coinbase.signAndRequest(ctx, http.MethodPost, "/api/v3/brokerage/orders/batch_cancel", nil, body, &result); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return fmt.Errorf("cancel order: empty response")
+	}
+	if r := result.Results[0]; !r.Success {
+		return fmt.Errorf("cancel order: %w", newCoinbaseError(r.FailureReason, "cancel order "+orderId+" failed"))
+	}
+	s.coinbase.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *CoinbaseSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+func (s *CoinbaseSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order")
+}
+
+func (s *CoinbaseSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order by client id")
+}
+
+func (s *CoinbaseSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (s *CoinbaseSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (s *CoinbaseSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+func (s *CoinbaseSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+func (s *CoinbaseSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *CoinbaseSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *CoinbaseSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+func (s *CoinbaseSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+func (s *CoinbaseSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+func (s *CoinbaseSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+func (s *CoinbaseSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+func (s *CoinbaseSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+func (s *CoinbaseSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+func (s *CoinbaseSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+var _ exchange.SpotExchange = (*CoinbaseSpot)(nil)
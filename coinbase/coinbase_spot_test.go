@@ -0,0 +1,382 @@
+package coinbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+const testProducts = `{"products":[
+	{"product_id":"BTC-USD","price":"37000.5","volume_24h":"1234.5","base_increment":"0.00000001","price_increment":"0.01",
+		"quote_increment":"0.01","base_min_size":"0.00000001","base_max_size":"3400","quote_min_size":"1","quote_max_size":"150000000",
+		"base_currency_id":"BTC","quote_currency_id":"USD","status":"online","trading_disabled":false,"is_disabled":false,
+		"cancel_only":false,"product_type":"SPOT"},
+	{"product_id":"ETH-BTC","price":"0.052","volume_24h":"800","base_increment":"0.00000001","price_increment":"0.00001",
+		"quote_increment":"0.00001","base_min_size":"0.00022","base_max_size":"2400","quote_min_size":"0.00001","quote_max_size":"100",
+		"base_currency_id":"ETH","quote_currency_id":"BTC","status":"online","trading_disabled":false,"is_disabled":false,
+		"cancel_only":true,"product_type":"SPOT"},
+	{"product_id":"BIT-28JUL23-CDE","price":"29000","base_increment":"1","price_increment":"5",
+		"base_currency_id":"","quote_currency_id":"USD","status":"online","product_type":"FUTURE"}
+],"num_products":3}`
+
+// newTestCoinbase 创建请求指向 handler 的 Coinbase 实例，产品列表接口返回 testProducts
+func newTestCoinbase(t *testing.T, handler http.HandlerFunc) *Coinbase {
+	t.Helper()
+	return exchangetest.New[*Coinbase](t, exchangetest.Venue{
+		New:         NewCoinbase,
+		SecretKey:   testSecret,
+		MarketsPath: "/api/v3/brokerage/market/products",
+		Markets:     testProducts,
+	}, handler)
+}
+
+// checkSignature 校验请求头中的签名覆盖实际发送的路径（不含查询字符串）和请求体
+func checkSignature(t *testing.T, r *http.Request, body []byte) {
+	t.Helper()
+	ts, err := strconv.ParseInt(r.Header.Get("CB-ACCESS-TIMESTAMP"), 10, 64)
+	if err != nil {
+		t.Fatalf("CB-ACCESS-TIMESTAMP = %q", r.Header.Get("CB-ACCESS-TIMESTAMP"))
+	}
+	signer := NewSigner("key", testSecret)
+	if want := signer.SignRequest(ts, r.Method, r.URL.Path, string(body)); r.Header.Get("CB-ACCESS-SIGN") != want {
+		t.Errorf("CB-ACCESS-SIGN = %q, want %q", r.Header.Get("CB-ACCESS-SIGN"), want)
+	}
+	if r.Header.Get("CB-ACCESS-KEY") != "key" {
+		t.Errorf("headers = %v", r.Header)
+	}
+}
+
+func TestCoinbaseSpot_LoadMarkets(t *testing.T) {
+	c := newTestCoinbase(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	tests := []struct {
+		symbol, id    string
+		active        bool
+		amountPrec    int
+		tick, minCost string
+	}{
+		{"BTC/USD", "BTC-USD", true, 8, "0.01", "1"},
+		{"ETH/BTC", "ETH-BTC", false, 8, "0.00001", "0.00001"},
+	}
+	for _, tt := range tests {
+		market, err := c.Spot().GetMarket(tt.symbol)
+		if err != nil {
+			t.Errorf("GetMarket(%s): %v", tt.symbol, err)
+			continue
+		}
+		if market.ID != tt.id || market.Active != tt.active || market.Precision.Amount != tt.amountPrec ||
+			!market.Precision.TickSize.Equal(decimal.RequireFromString(tt.tick)) ||
+			!market.Limits.Cost.Min.Equal(decimal.RequireFromString(tt.minCost)) {
+			t.Errorf("market %s = %+v", tt.symbol, market)
+		}
+		if byID, err := c.Spot().GetMarketByID(tt.id); err != nil || byID != market {
+			t.Errorf("GetMarketByID(%s) = %v, %v", tt.id, byID, err)
+		}
+	}
+
+	// 期货产品不加载为现货市场
+	if symbols := c.Symbols(); len(symbols) != 2 {
+		t.Errorf("Symbols = %v, want only spot products", symbols)
+	}
+}
+
+func TestCoinbaseSpot_FetchTickerOHLCV(t *testing.T) {
+	c := newTestCoinbase(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/brokerage/market/products/BTC-USD/ticker":
+			w.Write([]byte(`{"trades":[{"trade_id":"1","product_id":"BTC-USD","price":"37000.5","size":"0.01",
+				"time":"2023-11-14T22:13:20.123Z","side":"BUY"}],"best_bid":"37000.4","best_ask":"37000.6"}`))
+		case "/api/v3/brokerage/market/products/BTC-USD/candles":
+			q := r.URL.Query()
+			if q.Get("granularity") != "ONE_HOUR" || q.Get("start") != "1699995600" || q.Get("end") != "1700002800" {
+				t.Errorf("candles query = %s", r.URL.RawQuery)
+			}
+			// Coinbase 按开盘时间倒序返回
+			w.Write([]byte(`{"candles":[
+				{"start":"1700002800","low":"37050","high":"37200","open":"37100","close":"37150","volume":"12.5"},
+				{"start":"1699999200","low":"36990","high":"37120","open":"37000","close":"37100","volume":"10"},
+				{"start":"1699995600","low":"36880","high":"37010","open":"36900","close":"37000","volume":"8"}
+			]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ticker, err := c.Spot().FetchTicker(ctx, "BTC/USD")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Symbol != "BTC/USD" || ticker.Bid.String() != "37000.4" || ticker.Ask.String() != "37000.6" ||
+		ticker.Last.String() != "37000.5" || ticker.Timestamp.UnixMilli() != 1700000000123 {
+		t.Errorf("ticker = %+v", ticker)
+	}
+
+	ohlcvs, err := c.Spot().FetchOHLCVs(ctx, "BTC/USD", "1h", option.WithSince(time.Unix(1699995600, 0)), option.WithLimit(2))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 2 || ohlcvs[0].Timestamp.Unix() != 1699995600 || ohlcvs[1].Timestamp.Unix() != 1699999200 ||
+		ohlcvs[0].Close.String() != "37000" || ohlcvs[0].High.String() != "37010" || ohlcvs[0].Volume.String() != "8" {
+		t.Errorf("ohlcvs = %v, want the first two candles in ascending order", ohlcvs)
+	}
+
+	if _, err := c.Spot().FetchOHLCVs(ctx, "BTC/USD", "1s"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("unsupported timeframe: err = %v, want ErrNotSupported", err)
+	}
+
+	tickers, err := c.Spot().FetchTickers(ctx)
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	if len(tickers) != 2 || tickers["BTC/USD"].Last.String() != "37000.5" || tickers["ETH/BTC"].Volume.String() != "800" {
+		t.Errorf("tickers = %v", tickers)
+	}
+}
+
+func TestCoinbaseSpot_CreateOrder(t *testing.T) {
+	var body map[string]interface{}
+	c := newTestCoinbase(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		checkSignature(t, r, raw)
+		body = nil
+		json.Unmarshal(raw, &body)
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/brokerage/orders":
+			if body["client_order_id"] == "too-big" {
+				w.Write([]byte(`{"success":false,"failure_reason":"UNKNOWN_FAILURE_REASON","error_response":{"error":"INSUFFICIENT_FUND",
+					"message":"Insufficient balance in source account","error_details":"","preview_failure_reason":"PREVIEW_INSUFFICIENT_FUND"}}`))
+				return
+			}
+			w.Write([]byte(`{"success":true,"success_response":{"order_id":"11111-00000-000000","product_id":"BTC-USD","side":"BUY",
+				"client_order_id":"` + body["client_order_id"].(string) + `"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v3/brokerage/orders/batch_cancel":
+			ids := body["order_ids"].([]interface{})
+			if ids[0] == "missing" {
+				w.Write([]byte(`{"results":[{"success":false,"failure_reason":"UNKNOWN_CANCEL_ORDER","order_id":"missing"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[{"success":true,"failure_reason":"UNKNOWN_CANCEL_FAILURE_REASON","order_id":"11111-00000-000000"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	order, err := c.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "0.01", option.WithPrice("37000.004"), option.WithPostOnly(true), option.WithClientOrderID("my-order-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "11111-00000-000000" || order.ClientOrderID != "my-order-1" {
+		t.Errorf("order = %+v", order)
+	}
+	config, _ := body["order_configuration"].(map[string]interface{})["limit_limit_gtc"].(map[string]interface{})
+	if body["product_id"] != "BTC-USD" || body["side"] != "BUY" || body["client_order_id"] != "my-order-1" ||
+		config["base_size"] != "0.01" || config["limit_price"] != "37000" || config["post_only"] != true {
+		t.Errorf("order body = %v", body)
+	}
+
+	if _, err := c.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "0.01", option.WithPrice("37000"), option.WithTimeInForce(option.IOC)); err != nil {
+		t.Fatalf("IOC CreateOrder: %v", err)
+	}
+	if _, ok := body["order_configuration"].(map[string]interface{})["sor_limit_ioc"]; !ok {
+		t.Errorf("IOC order body = %v", body)
+	}
+
+	order, err = c.Spot().CreateOrder(ctx, "BTC/USD", option.Sell, "0.5")
+	if err != nil {
+		t.Fatalf("market CreateOrder: %v", err)
+	}
+	config, _ = body["order_configuration"].(map[string]interface{})["market_market_ioc"].(map[string]interface{})
+	if body["side"] != "SELL" || config["base_size"] != "0.5" || order.ClientOrderID == "" || body["client_order_id"] != order.ClientOrderID {
+		t.Errorf("market order body = %v, order = %+v", body, order)
+	}
+
+	if _, err := c.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "1000", option.WithClientOrderID("too-big")); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("insufficient funds: err = %v, want ErrInsufficientFunds", err)
+	}
+
+	if err := c.Spot().CancelOrder(ctx, "BTC/USD", "11111-00000-000000"); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if err := c.Spot().CancelOrder(ctx, "BTC/USD", "missing"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("cancel missing order: err = %v, want ErrOrderNotFound", err)
+	}
+	if err := c.Spot().CancelOrder(ctx, "BTC/USD", "", option.WithClientOrderID("my-order-1")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("cancel by client id: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+// TestCoinbaseSpot_OrderConfiguration 每种订单只发送一种 order_configuration，字段与配置类型对应
+func TestCoinbaseSpot_OrderConfiguration(t *testing.T) {
+	var body map[string]interface{}
+	c := newTestCoinbase(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = nil
+		json.Unmarshal(raw, &body)
+		w.Write([]byte(`{"success":true,"success_response":{"order_id":"11111-00000-000000","product_id":"BTC-USD"}}`))
+	})
+
+	tests := []struct {
+		name       string
+		opts       []option.ArgsOption
+		configType string
+		config     map[string]interface{}
+	}{
+		{"market", nil, "market_market_ioc", map[string]interface{}{"base_size": "0.01"}},
+		{"market ioc", []option.ArgsOption{option.WithTimeInForce(option.IOC)}, "market_market_ioc", map[string]interface{}{"base_size": "0.01"}},
+		{"limit gtc", []option.ArgsOption{option.WithPrice("37000")}, "limit_limit_gtc",
+			map[string]interface{}{"base_size": "0.01", "limit_price": "37000", "post_only": false}},
+		{"limit gtx", []option.ArgsOption{option.WithPrice("37000"), option.WithTimeInForce(option.GTX)}, "limit_limit_gtc",
+			map[string]interface{}{"base_size": "0.01", "limit_price": "37000", "post_only": true}},
+		{"limit ioc", []option.ArgsOption{option.WithPrice("37000"), option.WithTimeInForce(option.IOC)}, "sor_limit_ioc",
+			map[string]interface{}{"base_size": "0.01", "limit_price": "37000"}},
+		{"limit fok", []option.ArgsOption{option.WithPrice("37000"), option.WithTimeInForce(option.FOK)}, "limit_limit_fok",
+			map[string]interface{}{"base_size": "0.01", "limit_price": "37000"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Spot().CreateOrder(context.Background(), "BTC/USD", option.Buy, "0.01", tt.opts...); err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+			configs, _ := body["order_configuration"].(map[string]interface{})
+			config, _ := configs[tt.configType].(map[string]interface{})
+			if len(configs) != 1 || len(config) != len(tt.config) {
+				t.Fatalf("order_configuration = %v, want only %s %v", configs, tt.configType, tt.config)
+			}
+			for key, want := range tt.config {
+				if config[key] != want {
+					t.Errorf("%s.%s = %v, want %v", tt.configType, key, config[key], want)
+				}
+			}
+		})
+	}
+
+	// 市价 FOK 和市价只做 maker 没有对应的配置类型
+	if _, err := c.Spot().CreateOrder(context.Background(), "BTC/USD", option.Buy, "0.01", option.WithTimeInForce(option.FOK)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("market FOK: err = %v, want ErrInvalidOrder", err)
+	}
+	if _, err := c.Spot().CreateOrder(context.Background(), "BTC/USD", option.Buy, "0.01", option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("market post only: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+// TestCoinbase_JWTAuth CDP API Key 的每个请求签发新的 JWT，uri 声明为请求方法、API 域名和不含查询字符串的路径
+func TestCoinbase_JWTAuth(t *testing.T) {
+	key, pemKey := testECKey(t)
+	var claims []map[string]interface{}
+	var nonces []interface{}
+	var host string
+	c := exchangetest.New[*Coinbase](t, exchangetest.Venue{
+		New:         NewCoinbase,
+		SecretKey:   pemKey,
+		MarketsPath: "/api/v3/brokerage/market/products",
+		Markets:     testProducts,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("CB-ACCESS-SIGN") != "" {
+			t.Errorf("legacy signature sent with a CDP key: %v", r.Header)
+		}
+		header, c := verifyTestJWT(t, &key.PublicKey, r.Header.Get("Authorization"))
+		host = r.Host
+		claims = append(claims, c)
+		nonces = append(nonces, header["nonce"])
+
+		switch r.URL.Path {
+		case "/api/v3/brokerage/accounts":
+			w.Write([]byte(`{"accounts":[],"has_next":false,"cursor":""}`))
+		case "/api/v3/brokerage/orders":
+			w.Write([]byte(`{"success":true,"success_response":{"order_id":"11111-00000-000000","product_id":"BTC-USD"}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	if _, err := c.Spot().FetchBalance(ctx); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if _, err := c.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "0.01"); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if len(claims) != 2 {
+		t.Fatalf("got %d requests, want 2", len(claims))
+	}
+	for i, uri := range []string{"GET " + host + "/api/v3/brokerage/accounts", "POST " + host + "/api/v3/brokerage/orders"} {
+		nbf, _ := claims[i]["nbf"].(float64)
+		exp, _ := claims[i]["exp"].(float64)
+		if claims[i]["uri"] != uri || claims[i]["sub"] != "key" || claims[i]["iss"] != "cdp" || exp-nbf != 120 {
+			t.Errorf("claims[%d] = %v, want uri %q", i, claims[i], uri)
+		}
+	}
+	if nonces[0] == nonces[1] {
+		t.Errorf("nonce reused: %v", nonces[0])
+	}
+}
+
+func TestCoinbaseSpot_FetchBalance(t *testing.T) {
+	var pages int
+	c := newTestCoinbase(t, func(w http.ResponseWriter, r *http.Request) {
+		checkSignature(t, r, nil)
+		if r.URL.Path != "/api/v3/brokerage/accounts" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		pages++
+		if r.URL.Query().Get("cursor") == "page-2" {
+			w.Write([]byte(`{"accounts":[{"uuid":"2","currency":"BTC","available_balance":{"value":"1","currency":"BTC"},
+				"hold":{"value":"0.5","currency":"BTC"},"active":true}],"has_next":false,"cursor":""}`))
+			return
+		}
+		w.Write([]byte(`{"accounts":[{"uuid":"1","currency":"USD","available_balance":{"value":"900.25","currency":"USD"},
+			"hold":{"value":"100","currency":"USD"},"active":true}],"has_next":true,"cursor":"page-2"}`))
+	})
+	ctx := context.Background()
+
+	balances, err := c.Spot().FetchBalance(ctx)
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	want := []struct{ currency, available, locked, total string }{
+		{"BTC", "1", "0.5", "1.5"},
+		{"USD", "900.25", "100", "1000.25"},
+	}
+	if pages != 2 || len(balances) != len(want) {
+		t.Fatalf("got %d balances over %d pages, want %d over 2", len(balances), pages, len(want))
+	}
+	for i, w := range want {
+		b := balances[i]
+		if b.Currency != w.currency || b.Available.String() != w.available || b.Locked.String() != w.locked || b.Total.String() != w.total {
+			t.Errorf("balance %d = %+v, want %v", i, b, w)
+		}
+	}
+
+	if _, err := c.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFutures)); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("futures balance: err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestCoinbase_AuthenticationRequired(t *testing.T) {
+	ex, err := NewCoinbase("", "", nil)
+	if err != nil {
+		t.Fatalf("NewCoinbase: %v", err)
+	}
+	if _, err := ex.Spot().FetchBalance(context.Background()); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("err = %v, want ErrAuthenticationRequired", err)
+	}
+}
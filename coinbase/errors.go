@@ -0,0 +1,42 @@
+package coinbase
+
+import (
+	"encoding/json"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// coinbaseErrorCodes Coinbase 错误码（error 字段或下单失败原因）到统一错误的映射
+var coinbaseErrorCodes = map[string]error{
+	"UNAUTHENTICATED":                     common.ErrAuthenticationFailed, // 签名无效或 API Key 不存在
+	"PERMISSION_DENIED":                   common.ErrAuthenticationFailed, // 权限不足
+	"RESOURCE_EXHAUSTED":                  common.ErrRateLimitExceeded,    // 请求频率超限
+	"INSUFFICIENT_FUND":                   common.ErrInsufficientFunds,    // 余额不足
+	"INVALID_LIMIT_PRICE_POST_ONLY":       common.ErrInvalidOrder,         // 只做 maker 订单会立即成交
+	"INVALID_PRICE_PRECISION":             common.ErrInvalidOrder,         // 价格精度错误
+	"INVALID_SIZE_PRECISION":              common.ErrInvalidOrder,         // 数量精度错误
+	"ORDER_ENTRY_DISABLED":                common.ErrInvalidOrder,         // 交易对暂停下单
+	"UNKNOWN_CANCEL_ORDER":                common.ErrOrderNotFound,        // 撤单时订单不存在
+	"UNSUPPORTED_ORDER_CONFIGURATION":     common.ErrInvalidOrder,         // 不支持的订单类型
+	"PREVIEW_INSUFFICIENT_FUND":           common.ErrInsufficientFunds,    // 预检余额不足
+	"PREVIEW_INVALID_BASE_SIZE_TOO_SMALL": common.ErrInvalidOrder,         // 数量低于下限
+}
+
+// newCoinbaseError 根据错误码和信息创建交易所错误
+func newCoinbaseError(code, msg string) *common.ExchangeError {
+	return common.NewExchangeError(coinbaseName, code, msg, coinbaseErrorCodes)
+}
+
+// parseCoinbaseError 解析 Coinbase 非 2xx 响应体 {"error":"UNAUTHENTICATED","message":"..."}
+func parseCoinbaseError(httpErr *common.HTTPError) error {
+	var body struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Error == "" {
+		return nil
+	}
+	e := newCoinbaseError(body.Error, body.Message)
+	e.Cause = httpErr
+	return e
+}
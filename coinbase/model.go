@@ -0,0 +1,112 @@
+package coinbase
+
+import (
+	"github.com/lemconn/exlink/types"
+)
+
+// coinbaseProducts Coinbase 交易对列表（/api/v3/brokerage/market/products）
+type coinbaseProducts struct {
+	Products    []coinbaseProduct `json:"products"`
+	NumProducts int               `json:"num_products"`
+}
+
+// coinbaseProduct Coinbase 交易对信息，product_id 形如 BTC-USD
+type coinbaseProduct struct {
+	ProductID       string          `json:"product_id"`        // 交易对，如 BTC-USD
+	Price           types.ExDecimal `json:"price"`             // 最新成交价
+	Volume24h       types.ExDecimal `json:"volume_24h"`        // 24小时成交量（基础货币）
+	BaseIncrement   types.ExDecimal `json:"base_increment"`    // 数量步长
+	PriceIncrement  types.ExDecimal `json:"price_increment"`   // 价格步长
+	QuoteIncrement  types.ExDecimal `json:"quote_increment"`   // 金额步长
+	BaseMinSize     types.ExDecimal `json:"base_min_size"`     // 最小下单量
+	BaseMaxSize     types.ExDecimal `json:"base_max_size"`     // 最大下单量
+	QuoteMinSize    types.ExDecimal `json:"quote_min_size"`    // 最小下单金额
+	QuoteMaxSize    types.ExDecimal `json:"quote_max_size"`    // 最大下单金额
+	BaseCurrencyID  string          `json:"base_currency_id"`  // 基础货币
+	QuoteCurrencyID string          `json:"quote_currency_id"` // 计价货币
+	Status          string          `json:"status"`            // 状态：online、offline 等
+	TradingDisabled bool            `json:"trading_disabled"`  // 是否暂停交易
+	IsDisabled      bool            `json:"is_disabled"`       // 是否下线
+	CancelOnly      bool            `json:"cancel_only"`       // 是否只允许撤单
+	ProductType     string          `json:"product_type"`      // 类型：SPOT、FUTURE
+}
+
+// coinbaseMarketTrades Coinbase 最近成交和最优挂单（/api/v3/brokerage/market/products/{product_id}/ticker）
+type coinbaseMarketTrades struct {
+	Trades []struct {
+		TradeID   string            `json:"trade_id"`
+		ProductID string            `json:"product_id"`
+		Price     types.ExDecimal   `json:"price"`
+		Size      types.ExDecimal   `json:"size"`
+		Time      types.ExTimestamp `json:"time"`
+		Side      string            `json:"side"`
+	} `json:"trades"`
+	BestBid types.ExDecimal `json:"best_bid"`
+	BestAsk types.ExDecimal `json:"best_ask"`
+}
+
+// coinbaseCandles Coinbase K线（/api/v3/brokerage/market/products/{product_id}/candles），按开盘时间倒序返回
+type coinbaseCandles struct {
+	Candles []struct {
+		Start  types.ExTimestamp `json:"start"` // 开盘时间（秒级时间戳字符串）
+		Low    types.ExDecimal   `json:"low"`
+		High   types.ExDecimal   `json:"high"`
+		Open   types.ExDecimal   `json:"open"`
+		Close  types.ExDecimal   `json:"close"`
+		Volume types.ExDecimal   `json:"volume"`
+	} `json:"candles"`
+}
+
+// coinbaseAmount Coinbase 金额 {"value":"1.23","currency":"BTC"}
+type coinbaseAmount struct {
+	Value    types.ExDecimal `json:"value"`
+	Currency string          `json:"currency"`
+}
+
+// coinbaseAccounts Coinbase 账户列表（/api/v3/brokerage/accounts），has_next 为 true 时用 cursor 翻页
+type coinbaseAccounts struct {
+	Accounts []struct {
+		UUID             string         `json:"uuid"`
+		Name             string         `json:"name"`
+		Currency         string         `json:"currency"`
+		AvailableBalance coinbaseAmount `json:"available_balance"` // 可用余额
+		Hold             coinbaseAmount `json:"hold"`              // 冻结余额
+		Active           bool           `json:"active"`
+	} `json:"accounts"`
+	HasNext bool   `json:"has_next"`
+	Cursor  string `json:"cursor"`
+}
+
+// coinbaseCreateOrderResponse Coinbase 下单响应（/api/v3/brokerage/orders），success 为 false 时看 error_response
+type coinbaseCreateOrderResponse struct {
+	Success         bool `json:"success"`
+	SuccessResponse struct {
+		OrderID       string `json:"order_id"`
+		ProductID     string `json:"product_id"`
+		Side          string `json:"side"`
+		ClientOrderID string `json:"client_order_id"`
+	} `json:"success_response"`
+	ErrorResponse struct {
+		Error                 string `json:"error"`
+		Message               string `json:"message"`
+		ErrorDetails          string `json:"error_details"`
+		PreviewFailureReason  string `json:"preview_failure_reason"`
+		NewOrderFailureReason string `json:"new_order_failure_reason"`
+	} `json:"error_response"`
+}
+
+// coinbaseCancelOrdersResponse Coinbase 撤单响应（/api/v3/brokerage/orders/batch_cancel），每个订单单独返回结果
+type coinbaseCancelOrdersResponse struct {
+	Results []struct {
+		Success       bool   `json:"success"`
+		FailureReason string `json:"failure_reason"`
+		OrderID       string `json:"order_id"`
+	} `json:"results"`
+}
+
+// coinbaseTimeResponse Coinbase 服务器时间（/api/v3/brokerage/time）
+type coinbaseTimeResponse struct {
+	ISO          string            `json:"iso"`
+	EpochSeconds string            `json:"epochSeconds"`
+	EpochMillis  types.ExTimestamp `json:"epochMillis"`
+}
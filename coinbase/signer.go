@@ -0,0 +1,83 @@
+package coinbase
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// Signer Coinbase 签名工具
+// secretKey 为 PEM 格式 EC 私钥时（CDP API Key）使用 JWT（ES256），否则使用旧版 API Key 的 HMAC 签名
+type Signer struct {
+	apiKey    string
+	secretKey string
+	key       *ecdsa.PrivateKey // CDP API Key 私钥，为 nil 时使用 HMAC
+	keyErr    error             // 私钥解析失败的错误，签名时返回
+}
+
+// NewSigner 创建签名工具，apiKey 为 CDP API Key 名称（organizations/{org_id}/apiKeys/{key_id}）或旧版 API Key
+func NewSigner(apiKey, secretKey string) *Signer {
+	s := &Signer{apiKey: apiKey, secretKey: secretKey}
+	if isPEMKey(secretKey) {
+		s.key, s.keyErr = common.ParseECPrivateKey(secretKey)
+	}
+	return s
+}
+
+// isPEMKey secretKey 是否为 PEM 格式私钥
+func isPEMKey(secretKey string) bool {
+	return strings.Contains(secretKey, "-----BEGIN")
+}
+
+// SignRequest 对请求进行 HMAC 签名（旧版 API Key）
+// timestamp: 秒级时间戳（与 CB-ACCESS-TIMESTAMP 一致）
+// path: API 路径，不包含查询字符串
+// Coinbase 签名格式: hex(HMAC-SHA256(timestamp + method + path + body, secretKey))
+func (s *Signer) SignRequest(timestamp int64, method, path, body string) string {
+	return common.SignHMAC256(strconv.FormatInt(timestamp, 10)+method+path+body, s.secretKey)
+}
+
+// JWT 签发请求用的 JWT（CDP API Key），uri 为 "METHOD host/path"（不包含查询字符串）
+func (s *Signer) JWT(method, host, path string, now time.Time) (string, error) {
+	if s.keyErr != nil {
+		return "", s.keyErr
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	header := map[string]interface{}{
+		"kid":   s.apiKey,
+		"nonce": hex.EncodeToString(nonce),
+	}
+	claims := map[string]interface{}{
+		"sub": s.apiKey,
+		"iss": "cdp",
+		"nbf": now.Unix(),
+		"exp": now.Add(coinbaseJWTExpiry).Unix(),
+		"uri": method + " " + host + path,
+	}
+	return common.SignJWTES256(s.key, header, claims)
+}
+
+// Headers 返回签名请求需要的请求头，CDP API Key 使用 Authorization: Bearer，旧版 API Key 使用 CB-ACCESS-*
+func (s *Signer) Headers(method, host, path, body string, now time.Time) (map[string]string, error) {
+	if s.key != nil || s.keyErr != nil {
+		token, err := s.JWT(method, host, path, now)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"Authorization": "Bearer " + token}, nil
+	}
+	timestamp := now.Unix()
+	return map[string]string{
+		"CB-ACCESS-KEY":       s.apiKey,
+		"CB-ACCESS-SIGN":      s.SignRequest(timestamp, method, path, body),
+		"CB-ACCESS-TIMESTAMP": strconv.FormatInt(timestamp, 10),
+	}, nil
+}
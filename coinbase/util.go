@@ -0,0 +1,49 @@
+package coinbase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/types"
+)
+
+// coinbaseTimeframe 转换为 Coinbase K线周期（如 1m -> ONE_MINUTE、1h -> ONE_HOUR），不支持的周期原样返回
+func coinbaseTimeframe(timeframe string) string {
+	switch common.NormalizeTimeframe(timeframe) {
+	case "1m":
+		return "ONE_MINUTE"
+	case "5m":
+		return "FIVE_MINUTE"
+	case "15m":
+		return "FIFTEEN_MINUTE"
+	case "30m":
+		return "THIRTY_MINUTE"
+	case "1h":
+		return "ONE_HOUR"
+	case "2h":
+		return "TWO_HOUR"
+	case "4h":
+		return "FOUR_HOUR"
+	case "6h":
+		return "SIX_HOUR"
+	case "1d":
+		return "ONE_DAY"
+	default:
+		return timeframe
+	}
+}
+
+// decimalPlaces 返回步长的小数位数（如 0.0001 -> 4）
+func decimalPlaces(step types.ExDecimal) int {
+	_, frac, ok := strings.Cut(step.String(), ".")
+	if !ok {
+		return 0
+	}
+	return len(strings.TrimRight(frac, "0"))
+}
+
+// notSupported Coinbase 适配器不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for coinbase", operation, common.ErrNotSupported)
+}
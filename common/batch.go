@@ -0,0 +1,87 @@
+package common
+
+import (
+	"context"
+
+	"github.com/lemconn/exlink/model"
+)
+
+// BatchItem 批量下单中已构建好请求参数的订单
+type BatchItem[P any] struct {
+	// Index 在原始请求中的下标
+	Index int
+	// Group 分组键，同一批次的订单必须属于同一组（如同一产品类型或接口）
+	Group string
+	// Params 交易所请求参数
+	Params P
+}
+
+// BatchSubmitFunc 提交同一组的一批订单，返回与 params 一一对应的结果和错误；整批失败时返回 error
+type BatchSubmitFunc[P any] func(ctx context.Context, group string, params []P) ([]*model.NewOrder, []error, error)
+
+// SubmitBatches 将订单按分组和 size 分批提交，结果和错误写入 orders、errs 中对应下标
+// 某一批整体失败时，该批每个订单的错误均为该错误，其余批次继续提交；返回第一个整批失败的错误
+func SubmitBatches[P any](ctx context.Context, items []BatchItem[P], size int, orders []*model.NewOrder, errs []error, submit BatchSubmitFunc[P]) error {
+	// 按分组保持原始顺序
+	var groups []string
+	byGroup := make(map[string][]BatchItem[P])
+	for _, item := range items {
+		if _, ok := byGroup[item.Group]; !ok {
+			groups = append(groups, item.Group)
+		}
+		byGroup[item.Group] = append(byGroup[item.Group], item)
+	}
+
+	var firstErr error
+	for _, group := range groups {
+		grouped := byGroup[group]
+		for start := 0; start < len(grouped); start += size {
+			end := start + size
+			if end > len(grouped) {
+				end = len(grouped)
+			}
+			batch := grouped[start:end]
+
+			params := make([]P, len(batch))
+			for i, item := range batch {
+				params[i] = item.Params
+			}
+
+			results, itemErrs, err := submit(ctx, group, params)
+			if err == nil {
+				err = ctx.Err()
+			}
+			for i, item := range batch {
+				switch {
+				case i < len(itemErrs) && itemErrs[i] != nil:
+					errs[item.Index] = itemErrs[i]
+				case i < len(results) && results[i] != nil:
+					orders[item.Index] = results[i]
+				case err != nil:
+					errs[item.Index] = err
+				}
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// CreateOrdersSequential 逐个提交订单（交易所没有批量下单接口时使用），返回与请求一一对应的结果和错误
+// ctx 取消后不再提交剩余订单，其错误为 ctx.Err()，并作为第三个返回值返回
+func CreateOrdersSequential[R any](ctx context.Context, requests []R, create func(ctx context.Context, req R) (*model.NewOrder, error)) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
+	for i, req := range requests {
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(requests); j++ {
+				errs[j] = err
+			}
+			return orders, errs, err
+		}
+		orders[i], errs[i] = create(ctx, req)
+	}
+	return orders, errs, nil
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestSubmitBatches(t *testing.T) {
+	// 下标 2 参数校验失败，不参与提交
+	var items []BatchItem[int]
+	for i := 0; i < 6; i++ {
+		if i == 2 {
+			continue
+		}
+		group := "a"
+		if i%2 == 1 {
+			group = "b"
+		}
+		items = append(items, BatchItem[int]{Index: i, Group: group, Params: i})
+	}
+
+	orders := make([]*model.NewOrder, 6)
+	errs := make([]error, 6)
+	errs[2] = ErrInvalidOrder
+
+	errBatch := errors.New("network error")
+	var calls []string
+	err := SubmitBatches(context.Background(), items, 2, orders, errs, func(ctx context.Context, group string, params []int) ([]*model.NewOrder, []error, error) {
+		calls = append(calls, group+":"+strconv.Itoa(len(params)))
+		if group == "b" {
+			return nil, nil, errBatch
+		}
+		results := make([]*model.NewOrder, len(params))
+		itemErrs := make([]error, len(params))
+		for i, p := range params {
+			if p == 4 {
+				itemErrs[i] = ErrInsufficientFunds
+				continue
+			}
+			results[i] = &model.NewOrder{OrderId: strconv.Itoa(p)}
+		}
+		return results, itemErrs, nil
+	})
+
+	if !errors.Is(err, errBatch) {
+		t.Errorf("err = %v, want %v", err, errBatch)
+	}
+	// a: 0,4 一批；b: 1,3 一批，5 一批
+	if want := []string{"a:2", "b:2", "b:1"}; len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] || calls[2] != want[2] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if orders[0] == nil || orders[0].OrderId != "0" || errs[0] != nil {
+		t.Errorf("index 0 = %+v, %v", orders[0], errs[0])
+	}
+	if !errors.Is(errs[2], ErrInvalidOrder) {
+		t.Errorf("index 2 err = %v, want ErrInvalidOrder kept", errs[2])
+	}
+	if !errors.Is(errs[4], ErrInsufficientFunds) || orders[4] != nil {
+		t.Errorf("index 4 = %+v, %v", orders[4], errs[4])
+	}
+	for _, i := range []int{1, 3, 5} {
+		if !errors.Is(errs[i], errBatch) || orders[i] != nil {
+			t.Errorf("index %d = %+v, %v, want batch error", i, orders[i], errs[i])
+		}
+	}
+}
+
+func TestCreateOrdersSequential_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orders, errs, err := CreateOrdersSequential(ctx, []string{"1", "2", "3"}, func(ctx context.Context, id string) (*model.NewOrder, error) {
+		if id == "2" {
+			cancel()
+			return nil, ErrInvalidOrder
+		}
+		return &model.NewOrder{OrderId: id}, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if orders[0] == nil || errs[0] != nil {
+		t.Errorf("index 0 = %+v, %v", orders[0], errs[0])
+	}
+	if !errors.Is(errs[1], ErrInvalidOrder) {
+		t.Errorf("index 1 err = %v, want ErrInvalidOrder", errs[1])
+	}
+	if orders[2] != nil || !errors.Is(errs[2], context.Canceled) {
+		t.Errorf("index 2 = %+v, %v, want not submitted", orders[2], errs[2])
+	}
+}
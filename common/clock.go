@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TimeSyncInterval 服务器时间同步周期
+const TimeSyncInterval = 10 * time.Minute
+
+// Clock 交易所服务器时钟：记录服务器时间与本地时间的偏移，签名时间戳按偏移校正
+// 每个交易所实例持有独立的 Clock，零值可用（偏移为 0，即本地时间）
+type Clock struct {
+	offset atomic.Int64 // 服务器时间 - 本地时间（纳秒）
+	mu     sync.Mutex
+	cancel context.CancelFunc // 后台同步的取消函数
+}
+
+// Now 返回按偏移校正后的当前时间
+func (c *Clock) Now() time.Time {
+	return time.Now().Add(c.Offset())
+}
+
+// Offset 返回服务器时间与本地时间的偏移
+func (c *Clock) Offset() time.Duration {
+	return time.Duration(c.offset.Load())
+}
+
+// SetOffset 设置服务器时间与本地时间的偏移
+func (c *Clock) SetOffset(offset time.Duration) {
+	c.offset.Store(int64(offset))
+}
+
+// Timestamp 获取校正后的时间戳（毫秒），对应 GetTimestamp
+func (c *Clock) Timestamp() int64 {
+	return c.Now().UnixMilli()
+}
+
+// TimestampSeconds 获取校正后的时间戳（秒），对应 GetTimestampSeconds
+func (c *Clock) TimestampSeconds() int64 {
+	return c.Now().Unix()
+}
+
+// ISO8601Timestamp 获取校正后的 ISO8601 格式时间戳，对应 GetISO8601Timestamp
+func (c *Clock) ISO8601Timestamp() string {
+	return c.Now().UTC().Format(time.RFC3339)
+}
+
+// Sync 通过 fetch 获取服务器时间并更新偏移，网络延迟按请求往返时间的一半估算
+func (c *Clock) Sync(ctx context.Context, fetch func(ctx context.Context) (time.Time, error)) error {
+	start := time.Now()
+	serverTime, err := fetch(ctx)
+	if err != nil {
+		return err
+	}
+	end := time.Now()
+	local := start.Add(end.Sub(start) / 2)
+	c.SetOffset(serverTime.Sub(local))
+	return nil
+}
+
+// Start 在后台立即同步一次，之后每 interval 同步一次，直到调用 Stop；重复调用时先停止之前的同步
+// 同步失败时保留上一次的偏移
+func (c *Clock) Start(interval time.Duration, fetch func(ctx context.Context) (time.Time, error)) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			syncCtx, syncCancel := context.WithTimeout(ctx, interval)
+			_ = c.Sync(syncCtx, fetch)
+			syncCancel()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止后台同步，未启动时无操作
+func (c *Clock) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClock_Sync(t *testing.T) {
+	var clock Clock
+	if clock.Offset() != 0 {
+		t.Fatalf("zero clock offset = %v, want 0", clock.Offset())
+	}
+
+	// 服务器时间比本地快 5 秒
+	err := clock.Sync(context.Background(), func(ctx context.Context) (time.Time, error) {
+		return time.Now().Add(5 * time.Second), nil
+	})
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if d := clock.Offset() - 5*time.Second; d < -100*time.Millisecond || d > 100*time.Millisecond {
+		t.Errorf("offset = %v, want ~5s", clock.Offset())
+	}
+	if d := clock.Timestamp() - time.Now().Add(5*time.Second).UnixMilli(); d < -100 || d > 100 {
+		t.Errorf("timestamp off by %dms", d)
+	}
+
+	// 同步失败时保留上一次的偏移
+	offset := clock.Offset()
+	if err := clock.Sync(context.Background(), func(ctx context.Context) (time.Time, error) {
+		return time.Time{}, errors.New("boom")
+	}); err == nil {
+		t.Error("expected sync error")
+	}
+	if clock.Offset() != offset {
+		t.Errorf("offset changed after failed sync: %v -> %v", offset, clock.Offset())
+	}
+}
+
+func TestClock_StartStop(t *testing.T) {
+	var clock Clock
+	synced := make(chan struct{}, 1)
+	clock.Start(time.Hour, func(ctx context.Context) (time.Time, error) {
+		select {
+		case synced <- struct{}{}:
+		default:
+		}
+		return time.Now().Add(-time.Minute), nil
+	})
+	defer clock.Stop()
+
+	select {
+	case <-synced:
+	case <-time.After(time.Second):
+		t.Fatal("clock did not sync on start")
+	}
+	deadline := time.Now().Add(time.Second)
+	for clock.Offset() > -30*time.Second && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if clock.Offset() > -30*time.Second {
+		t.Errorf("offset = %v, want ~-1m", clock.Offset())
+	}
+}
@@ -0,0 +1,20 @@
+package common
+
+import "context"
+
+// correlationIDKey context 中关联ID的键
+type correlationIDKey struct{}
+
+// WithCorrelationID 返回携带关联ID的 context，用于将交易所请求与上游请求关联（日志/链路追踪）
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext 从 context 中读取关联ID
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
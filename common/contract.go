@@ -0,0 +1,44 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/shopspring/decimal"
+)
+
+// 币本位（反向）合约以张为下单和持仓单位，每张面值为 market.ContractValue 美元
+// （Bybit 1 USD，Binance dapi BTC 100 USD / 其他 10 USD，OKX 见 ctVal）。
+// 下单数量 amount 和持仓数量均为张数，与币数量的换算依赖价格：
+//   张数 = 币数量 × 价格 / 面值，币数量 = 张数 × 面值 / 价格
+
+// inverseContractValue 返回币本位合约每张面值，未知时按 1 处理
+func inverseContractValue(market *model.Market) decimal.Decimal {
+	value, err := decimal.NewFromString(market.ContractValue)
+	if err != nil || !value.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+	return value
+}
+
+// InverseContractsFromBase 将币数量按价格换算为币本位合约张数（向下取整，避免超出预期仓位）
+func InverseContractsFromBase(market *model.Market, amount, price decimal.Decimal) (decimal.Decimal, error) {
+	if !market.Inverse {
+		return decimal.Zero, fmt.Errorf("market %s is not an inverse contract", market.Symbol)
+	}
+	if !price.IsPositive() {
+		return decimal.Zero, fmt.Errorf("invalid price %s", price.String())
+	}
+	return amount.Mul(price).DivRound(inverseContractValue(market), 16).Floor(), nil
+}
+
+// InverseBaseFromContracts 将币本位合约张数按价格换算为币数量
+func InverseBaseFromContracts(market *model.Market, contracts, price decimal.Decimal) (decimal.Decimal, error) {
+	if !market.Inverse {
+		return decimal.Zero, fmt.Errorf("market %s is not an inverse contract", market.Symbol)
+	}
+	if !price.IsPositive() {
+		return decimal.Zero, fmt.Errorf("invalid price %s", price.String())
+	}
+	return contracts.Mul(inverseContractValue(market)).DivRound(price, 16), nil
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/shopspring/decimal"
+)
+
+func TestInverseContractConversion(t *testing.T) {
+	market := &model.Market{Symbol: "BTC/USD:BTC", Inverse: true, ContractValue: "100"}
+	price := decimal.RequireFromString("50000")
+
+	// 0.01 BTC × 50000 / 100 = 5 张
+	contracts, err := InverseContractsFromBase(market, decimal.RequireFromString("0.01"), price)
+	if err != nil {
+		t.Fatalf("InverseContractsFromBase: %v", err)
+	}
+	if contracts.String() != "5" {
+		t.Errorf("contracts = %s, want 5", contracts)
+	}
+
+	// 不足一张的部分向下取整
+	contracts, _ = InverseContractsFromBase(market, decimal.RequireFromString("0.0119"), price)
+	if contracts.String() != "5" {
+		t.Errorf("contracts = %s, want floored 5", contracts)
+	}
+
+	amount, err := InverseBaseFromContracts(market, decimal.NewFromInt(5), price)
+	if err != nil {
+		t.Fatalf("InverseBaseFromContracts: %v", err)
+	}
+	if amount.String() != "0.01" {
+		t.Errorf("amount = %s, want 0.01", amount)
+	}
+
+	if _, err := InverseContractsFromBase(market, decimal.NewFromInt(1), decimal.Zero); err == nil {
+		t.Error("expected error for zero price")
+	}
+	if _, err := InverseContractsFromBase(&model.Market{Symbol: "BTC/USDT:USDT", Linear: true}, decimal.NewFromInt(1), price); err == nil {
+		t.Error("expected error for linear market")
+	}
+}
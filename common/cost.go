@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// CostEstimateSource EstimateSpotOrderCost 需要的市场和行情查询（现货接口的子集）
+type CostEstimateSource interface {
+	GetMarket(symbol string) (*model.Market, error)
+	FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error)
+}
+
+// EstimateSpotOrderCost 下单前估算现货订单成本并检查市场限制，price 为空时为市价单，按当前行情估算
+// 手续费率来自 option.WithFeeRate，未设置时手续费为 0
+func EstimateSpotOrderCost(ctx context.Context, source CostEstimateSource, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	market, err := source.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	feeRate := decimal.Zero
+	if option.StringPresent(argsOpts.FeeRate) {
+		if feeRate, err = decimal.NewFromString(*argsOpts.FeeRate); err != nil || feeRate.IsNegative() {
+			return nil, fmt.Errorf("invalid fee rate %q", *argsOpts.FeeRate)
+		}
+	}
+
+	var ticker *model.Ticker
+	if price == "" {
+		if ticker, err = source.FetchTicker(ctx, symbol); err != nil {
+			return nil, fmt.Errorf("estimate order cost: %w", err)
+		}
+	}
+	return EstimateOrderCost(market, side, amount, price, ticker, feeRate)
+}
+
+// EstimateOrderCost 按市场精度对齐数量和价格，计算成交金额和手续费，并检查最小/最大数量和金额限制
+// price 为空时为市价单：买单取卖一价、卖单取买一价，缺失时取最新价
+// 不满足限制时不返回错误，Valid 为 false 并在 Violations 中说明原因
+func EstimateOrderCost(market *model.Market, side option.SpotOrderSide, amount, price string, ticker *model.Ticker, feeRate decimal.Decimal) (*model.CostEstimate, error) {
+	if side != option.Buy && side != option.Sell {
+		return nil, fmt.Errorf("%w: invalid side %q", ErrInvalidOrder, side)
+	}
+	amountValue, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	if market.Precision.StepSize.IsPositive() || market.Precision.Amount > 0 {
+		amountValue = market.SnapAmount(amountValue)
+	}
+
+	estimate := &model.CostEstimate{
+		Symbol:   market.Symbol,
+		Side:     model.OrderSide(strings.ToLower(string(side))),
+		Amount:   types.ExDecimal{Decimal: amountValue},
+		FeeRate:  types.ExDecimal{Decimal: feeRate},
+		Currency: market.Quote,
+		Market:   price == "",
+	}
+
+	priceValue, err := orderCostPrice(market, side, price, ticker)
+	if err != nil {
+		return nil, err
+	}
+	cost := amountValue.Mul(priceValue)
+	estimate.Price = types.ExDecimal{Decimal: priceValue}
+	estimate.Cost = types.ExDecimal{Decimal: cost}
+	estimate.Fee = types.ExDecimal{Decimal: cost.Mul(feeRate)}
+
+	limits := market.Limits
+	if !amountValue.IsPositive() {
+		estimate.Violations = append(estimate.Violations, fmt.Sprintf("amount %s is below the lot size", amount))
+	} else if limits.Amount.Min.IsPositive() && amountValue.LessThan(limits.Amount.Min.Decimal) {
+		estimate.Violations = append(estimate.Violations, fmt.Sprintf("amount %s is below the minimum %s", amountValue, limits.Amount.Min))
+	}
+	if limits.Amount.Max.IsPositive() && amountValue.GreaterThan(limits.Amount.Max.Decimal) {
+		estimate.Violations = append(estimate.Violations, fmt.Sprintf("amount %s is above the maximum %s", amountValue, limits.Amount.Max))
+	}
+	if limits.Cost.Min.IsPositive() && cost.LessThan(limits.Cost.Min.Decimal) {
+		estimate.Violations = append(estimate.Violations, fmt.Sprintf("cost %s is below the minimum %s", cost, limits.Cost.Min))
+	}
+	if limits.Cost.Max.IsPositive() && cost.GreaterThan(limits.Cost.Max.Decimal) {
+		estimate.Violations = append(estimate.Violations, fmt.Sprintf("cost %s is above the maximum %s", cost, limits.Cost.Max))
+	}
+	estimate.Valid = len(estimate.Violations) == 0
+	return estimate, nil
+}
+
+// orderCostPrice 返回估算使用的价格：限价单按价格步长对齐，市价单取对手价（缺失时取最新价）
+func orderCostPrice(market *model.Market, side option.SpotOrderSide, price string, ticker *model.Ticker) (decimal.Decimal, error) {
+	if price != "" {
+		snapped, err := PriceToPrecision(market, price)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return decimal.RequireFromString(snapped), nil
+	}
+
+	if ticker == nil {
+		return decimal.Zero, fmt.Errorf("estimate market order cost for %s: ticker required", market.Symbol)
+	}
+	quote := ticker.Ask.Decimal
+	if side == option.Sell {
+		quote = ticker.Bid.Decimal
+	}
+	if !quote.IsPositive() {
+		quote = ticker.Last.Decimal
+	}
+	if !quote.IsPositive() {
+		return decimal.Zero, fmt.Errorf("estimate market order cost for %s: no price in ticker", market.Symbol)
+	}
+	return quote, nil
+}
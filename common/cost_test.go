@@ -0,0 +1,97 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// costTestMarket 最小成交额 10 USDT、数量步长 0.001 的现货市场
+func costTestMarket() *model.Market {
+	market := &model.Market{ID: "ETHUSDT", Symbol: "ETH/USDT", Base: "ETH", Quote: "USDT", Type: model.MarketTypeSpot, Active: true}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.01")}
+	market.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Limits.Cost.Min = types.ExDecimal{Decimal: decimal.NewFromInt(10)}
+	return market
+}
+
+type costTestSource struct {
+	market *model.Market
+	ticker *model.Ticker
+}
+
+func (s costTestSource) GetMarket(symbol string) (*model.Market, error) {
+	if symbol != s.market.Symbol {
+		return nil, MarketNotFound(symbol, nil)
+	}
+	return s.market, nil
+}
+
+func (s costTestSource) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return s.ticker, nil
+}
+
+func TestEstimateOrderCost(t *testing.T) {
+	source := costTestSource{
+		market: costTestMarket(),
+		ticker: &model.Ticker{
+			Bid:  types.ExDecimal{Decimal: decimal.RequireFromString("1999.5")},
+			Ask:  types.ExDecimal{Decimal: decimal.RequireFromString("2000.5")},
+			Last: types.ExDecimal{Decimal: decimal.NewFromInt(2000)},
+		},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		side          option.SpotOrderSide
+		amount, price string
+		wantAmount    string
+		wantPrice     string
+		wantCost      string
+		wantFee       string
+		valid         bool
+	}{
+		{"limit above min notional", option.Buy, "0.0109", "2000.009", "0.01", "2000", "20", "0.02", true},
+		{"limit below min notional", option.Buy, "0.004", "2000", "0.004", "2000", "8", "0.008", false},
+		{"market buy uses ask", option.Buy, "0.005", "", "0.005", "2000.5", "10.0025", "0.0100025", true},
+		{"market sell uses bid", option.Sell, "0.005", "", "0.005", "1999.5", "9.9975", "0.0099975", false},
+		{"below lot size", option.Sell, "0.0004", "2000", "0", "2000", "0", "0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, err := EstimateSpotOrderCost(ctx, source, "ETH/USDT", tt.side, tt.amount, tt.price, option.WithFeeRate("0.001"))
+			if err != nil {
+				t.Fatalf("EstimateSpotOrderCost: %v", err)
+			}
+			if estimate.Amount.String() != tt.wantAmount || estimate.Price.String() != tt.wantPrice ||
+				estimate.Cost.String() != tt.wantCost || estimate.Fee.String() != tt.wantFee || estimate.Currency != "USDT" {
+				t.Errorf("estimate = %+v", estimate)
+			}
+			if estimate.Valid != tt.valid || estimate.Valid != (len(estimate.Violations) == 0) || estimate.Market != (tt.price == "") {
+				t.Errorf("valid = %v, violations = %v, want valid %v", estimate.Valid, estimate.Violations, tt.valid)
+			}
+		})
+	}
+
+	estimate, err := EstimateSpotOrderCost(ctx, source, "ETH/USDT", option.Buy, "0.004", "2000")
+	if err != nil {
+		t.Fatalf("EstimateSpotOrderCost: %v", err)
+	}
+	if !estimate.Fee.IsZero() || len(estimate.Violations) != 1 || estimate.Violations[0] != "cost 8 is below the minimum 10" {
+		t.Errorf("without fee rate: fee = %s, violations = %v", estimate.Fee, estimate.Violations)
+	}
+
+	if _, err := EstimateSpotOrderCost(ctx, source, "ETH/USDT", option.Buy, "1", "2000", option.WithFeeRate("-0.1")); err == nil {
+		t.Error("expected error for negative fee rate")
+	}
+	if _, err := EstimateSpotOrderCost(ctx, source, "BTC/USDT", option.Buy, "1", "2000"); !errors.Is(err, ErrMarketNotFound) {
+		t.Errorf("unknown market: err = %v, want ErrMarketNotFound", err)
+	}
+}
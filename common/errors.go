@@ -0,0 +1,132 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrAuthenticationRequired 需要认证的接口未配置 API 凭证
+var ErrAuthenticationRequired = errors.New("authentication required")
+
+// ErrAuthenticationFailed API 凭证无效（API Key 不存在或已过期、签名错误、权限不足或 IP 不在白名单）
+var ErrAuthenticationFailed = errors.New("authentication failed")
+
+// ErrNotSupported 交易所 API 不支持该操作
+var ErrNotSupported = errors.New("not supported by exchange")
+
+// ErrInsufficientFunds 余额或保证金不足
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrRateLimitExceeded 超出交易所请求频率限制
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+// ErrInvalidOrder 订单参数不合法（精度、数量、价格等不满足交易所规则）
+var ErrInvalidOrder = errors.New("invalid order")
+
+// ErrOrderNotFound 订单不存在（或已完成无法操作）
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrMarketNotFound 市场不存在（symbol 拼写错误或市场未加载）
+var ErrMarketNotFound = errors.New("market not found")
+
+// ErrPositionNotFound 交易对没有持仓（ClosePosition 无可平仓的持仓）
+var ErrPositionNotFound = errors.New("position not found")
+
+// ErrLeverageExceeded 杠杆倍数超出交易对允许的最大杠杆
+var ErrLeverageExceeded = errors.New("leverage exceeds maximum")
+
+// ErrSymbolRequired 交易所接口需要 symbol（如不支持仅按订单ID查询订单的交易所未传 symbol）
+var ErrSymbolRequired = errors.New("symbol required")
+
+// ExchangeError 交易所返回的业务错误，保留原始错误码和错误信息
+// 已知错误码映射为统一错误（ErrInsufficientFunds 等），可通过 errors.Is 判断
+type ExchangeError struct {
+	// Exchange 交易所名称
+	Exchange string
+	// Code 交易所原始错误码
+	Code string
+	// Message 交易所原始错误信息
+	Message string
+	// Err 映射的统一错误，未知错误码时为 nil
+	Err error
+	// Cause 底层错误（如 *HTTPError），业务层返回的错误为 nil
+	Cause error
+}
+
+// NewExchangeError 创建交易所错误，codes 为交易所错误码到统一错误的映射表
+func NewExchangeError(exchange, code, message string, codes map[string]error) *ExchangeError {
+	return &ExchangeError{
+		Exchange: exchange,
+		Code:     code,
+		Message:  message,
+		Err:      codes[code],
+	}
+}
+
+// Error 实现 error 接口
+func (e *ExchangeError) Error() string {
+	return fmt.Sprintf("%s api error: %s (code: %s)", e.Exchange, e.Message, e.Code)
+}
+
+// Unwrap 返回统一错误和底层错误
+func (e *ExchangeError) Unwrap() []error {
+	errs := make([]error, 0, 2)
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	return errs
+}
+
+// HTTPError 非 2xx 响应错误
+type HTTPError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Body 响应体
+	Body string
+	// RetryAfter 响应头 Retry-After 指定的等待时间（未返回时为 0）
+	RetryAfter time.Duration
+}
+
+// Error 实现 error 接口
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("http error %d: %s", e.StatusCode, e.Body)
+}
+
+// Is 429/418 状态码视为 ErrRateLimitExceeded，401 状态码视为 ErrAuthenticationFailed
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrRateLimitExceeded:
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusTeapot
+	case ErrAuthenticationFailed:
+		return e.StatusCode == http.StatusUnauthorized
+	}
+	return false
+}
+
+// RetryExhaustedError 重试次数耗尽错误，包装最后一次失败的错误
+// 可通过 errors.Is/As 获取底层错误
+type RetryExhaustedError struct {
+	// Attempts 实际尝试次数（含首次请求）
+	Attempts int
+	// Elapsed 从首次尝试到放弃的总耗时
+	Elapsed time.Duration
+	// StatusCode 最后一次失败的 HTTP 状态码（非 HTTP 错误时为 0）
+	StatusCode int
+	// Err 最后一次失败的错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *RetryExhaustedError) Error() string {
+	return fmt.Sprintf("retries exhausted after %d attempts in %s: %v", e.Attempts, e.Elapsed, e.Err)
+}
+
+// Unwrap 返回最后一次失败的错误
+func (e *RetryExhaustedError) Unwrap() error {
+	return e.Err
+}
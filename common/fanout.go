@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// FetchConcurrently 并发调用 fetch 获取每个 key（如合约分类、API 前缀）的结果，按 keys 顺序合并返回
+// 各 key 的请求互不中断，失败的错误全部收集后合并返回（此时不返回部分结果）
+func FetchConcurrently[T any](ctx context.Context, keys []string, fetch func(ctx context.Context, key string) ([]T, error)) ([]T, error) {
+	if len(keys) == 1 {
+		return fetch(ctx, keys[0])
+	}
+
+	results := make([][]T, len(keys))
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key string) {
+			defer wg.Done()
+			results[i], errs[i] = fetch(ctx, key)
+		}(i, key)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	merged := make([]T, 0)
+	for _, items := range results {
+		merged = append(merged, items...)
+	}
+	return merged, nil
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFetchConcurrently(t *testing.T) {
+	ctx := context.Background()
+	fetch := func(ctx context.Context, key string) ([]string, error) {
+		switch key {
+		case "bad":
+			return nil, errors.New("bad category")
+		case "worse":
+			return nil, errors.New("worse category")
+		}
+		return []string{key + "-1", key + "-2"}, nil
+	}
+
+	// 结果按 keys 顺序合并
+	got, err := FetchConcurrently(ctx, []string{"linear", "inverse"}, fetch)
+	if err != nil {
+		t.Fatalf("FetchConcurrently: %v", err)
+	}
+	if want := []string{"linear-1", "linear-2", "inverse-1", "inverse-2"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// 失败的错误全部收集
+	_, err = FetchConcurrently(ctx, []string{"linear", "bad", "worse"}, fetch)
+	if err == nil || err.Error() != "bad category\nworse category" {
+		t.Errorf("err = %v, want both category errors", err)
+	}
+
+	// 无结果时返回空切片
+	got, err = FetchConcurrently(ctx, nil, fetch)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("got %v, %v, want empty slice", got, err)
+	}
+}
@@ -8,56 +8,188 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultHTTPTimeout 默认请求超时（包括连接、发送请求和读取响应）
+const DefaultHTTPTimeout = 30 * time.Second
+
+// RequestHook 请求发送前的回调，可通过 CorrelationIDFromContext 读取关联ID
+type RequestHook = func(ctx context.Context, method, path string, params map[string]interface{})
+
+// ResponseHook 请求结束后的回调，status 为 HTTP 状态码（未收到响应时为 0），latency 为发送请求到读完响应的耗时
+// err 为本次请求的错误（包括非 2xx 响应解析出的交易所错误），配置重试时每次尝试各回调一次
+type ResponseHook = func(ctx context.Context, method, path string, status int, latency time.Duration, err error)
+
+// RequestWeigher 计算请求权重，path 不含查询参数，query 合并了 path 中的查询参数和 params
+type RequestWeigher = func(method, path string, query url.Values) int
+
+// ErrorParser 解析非 2xx 响应中的交易所错误，无法解析时返回 nil（保留原始 *HTTPError）
+type ErrorParser = func(httpErr *HTTPError) error
+
+// BodyChecker 检查 2xx 响应体中的交易所错误（部分交易所以 2xx 状态返回错误对象），无错误时返回 nil
+type BodyChecker = func(body []byte) error
+
 // HTTPClient HTTP客户端
 type HTTPClient struct {
-	client  *http.Client
-	baseURL string
-	headers map[string]string
-	proxy   string
-	debug   bool
+	client            *http.Client
+	baseURL           string
+	headers           map[string]string
+	headersMu         sync.RWMutex      // 保护 headers 的读写锁
+	transport         http.RoundTripper // 通过 SetTransport/SetHTTPClient 设置的底层传输，代理在其上生效
+	proxy             string
+	debug             bool
+	correlationHeader string
+	onRequest         RequestHook
+	onResponse        ResponseHook
+	logger            Logger
+	errorParser       ErrorParser
+	bodyChecker       BodyChecker
+	rateLimiter       *RateLimiter
+	retryPolicy       *RetryPolicy
+	weigher           RequestWeigher
+	lifecycle         *Lifecycle
+}
+
+// HTTPClientOption HTTP客户端配置选项
+type HTTPClientOption func(*HTTPClient)
+
+// WithRateLimit 设置限流：每 interval 最多消耗 weight 权重，超出时阻塞等待
+func WithRateLimit(weight int, interval time.Duration) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.rateLimiter = NewRateLimiter(weight, interval, RateLimitWait)
+	}
+}
+
+// WithRateLimiter 设置限流器，多个客户端传入同一个限流器时共享限额，为 nil 时不限流
+func WithRateLimiter(limiter *RateLimiter) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.rateLimiter = limiter
+	}
 }
 
 // NewHTTPClient 创建HTTP客户端
-func NewHTTPClient(baseURL string) *HTTPClient {
-	return &HTTPClient{
+func NewHTTPClient(baseURL string, opts ...HTTPClientOption) *HTTPClient {
+	c := &HTTPClient{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultHTTPTimeout,
 		},
 		baseURL: baseURL,
 		headers: make(map[string]string),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-// SetProxy 设置代理
+// SharedBaseURL 返回现货和合约共用同一 API 域名的交易所地址
+// options["baseURL"]（现货）或 options["perpBaseURL"]（合约）设置时使用该地址，都未设置时使用 defaultURL；两者都设置且不同时返回错误
+func SharedBaseURL(options map[string]interface{}, defaultURL string) (string, error) {
+	spot, _ := options["baseURL"].(string)
+	perp, _ := options["perpBaseURL"].(string)
+	switch {
+	case spot != "" && perp != "" && spot != perp:
+		return "", fmt.Errorf("spot base URL %s and perp base URL %s differ, but spot and perp share one API host", spot, perp)
+	case spot != "":
+		return spot, nil
+	case perp != "":
+		return perp, nil
+	default:
+		return defaultURL, nil
+	}
+}
+
+// SetProxy 设置代理，为空时清除代理
+// 已通过 SetTransport 设置 *http.Transport 时在其副本上设置代理；其他类型的 RoundTripper 无法设置代理，返回错误
 func (c *HTTPClient) SetProxy(proxyURL string) error {
 	if proxyURL == "" {
-		c.client.Transport = nil
+		c.client.Transport = c.transport
 		c.proxy = ""
 		return nil
 	}
 
+	proxy, err := ParseProxyURL(proxyURL)
+	if err != nil {
+		return err
+	}
+
+	transport, err := c.proxyTransport(proxy)
+	if err != nil {
+		return err
+	}
+	c.client.Transport = transport
+	c.proxy = proxyURL
+	return nil
+}
+
+// ParseProxyURL 解析代理地址，支持 http、https、socks5 和 socks5h（由代理解析域名）
+// REST 请求和 WebSocket 连接使用同一代理地址
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
 	proxy, err := url.Parse(proxyURL)
 	if err != nil {
-		return fmt.Errorf("invalid proxy URL: %w", err)
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: unsupported scheme %q (want http, https, socks5 or socks5h)", proxyURL, proxy.Scheme)
 	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", proxyURL)
+	}
+	return proxy, nil
+}
 
-	transport := &http.Transport{
-		Proxy: http.ProxyURL(proxy),
+// proxyTransport 返回在底层传输上设置代理后的 Transport
+func (c *HTTPClient) proxyTransport(proxy *url.URL) (http.RoundTripper, error) {
+	switch base := c.transport.(type) {
+	case nil:
+		return &http.Transport{Proxy: http.ProxyURL(proxy)}, nil
+	case *http.Transport:
+		transport := base.Clone()
+		transport.Proxy = http.ProxyURL(proxy)
+		return transport, nil
+	default:
+		return nil, fmt.Errorf("proxy cannot be applied to custom transport %T", base)
 	}
+}
 
-	if c.client.Transport != nil {
-		// 保留现有的Transport设置
-		if existingTransport, ok := c.client.Transport.(*http.Transport); ok {
-			transport.TLSClientConfig = existingTransport.TLSClientConfig
+// SetTransport 设置底层 RoundTripper（如录制回放测试的 recorder），为 nil 时恢复默认传输
+// 已设置代理时：rt 为 *http.Transport 则在其副本上设置代理；其他类型的 rt 自行处理请求，代理被清除
+func (c *HTTPClient) SetTransport(rt http.RoundTripper) {
+	c.transport = rt
+	if c.proxy != "" {
+		if proxy, err := url.Parse(c.proxy); err == nil {
+			if transport, err := c.proxyTransport(proxy); err == nil {
+				c.client.Transport = transport
+				return
+			}
 		}
+		c.proxy = ""
 	}
+	c.client.Transport = rt
+}
 
-	c.client.Transport = transport
-	c.proxy = proxyURL
-	return nil
+// SetHTTPClient 使用 client 的传输、Cookie 和重定向设置替换底层客户端
+// client 本身不会被修改；client.Timeout 为 0 时保留当前超时，之后的 SetProxy、SetTimeout 仍然生效
+func (c *HTTPClient) SetHTTPClient(client *http.Client) {
+	if client == nil {
+		return
+	}
+	timeout := c.client.Timeout
+	if client.Timeout > 0 {
+		timeout = client.Timeout
+	}
+	c.client = &http.Client{
+		Jar:           client.Jar,
+		CheckRedirect: client.CheckRedirect,
+		Timeout:       timeout,
+	}
+	c.SetTransport(client.Transport)
 }
 
 // GetProxy 获取当前代理设置
@@ -67,10 +199,13 @@ func (c *HTTPClient) GetProxy() string {
 
 // SetHeader 设置请求头
 func (c *HTTPClient) SetHeader(key, value string) {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
 	c.headers[key] = value
 }
 
-// SetTimeout 设置超时时间
+// SetTimeout 设置单次请求超时时间（包括连接、发送请求和读取响应），为 0 时不限制
+// ctx 的截止时间和取消同样会中止进行中的请求，以先到者为准
 func (c *HTTPClient) SetTimeout(timeout time.Duration) {
 	c.client.Timeout = timeout
 }
@@ -80,6 +215,72 @@ func (c *HTTPClient) SetDebug(debug bool) {
 	c.debug = debug
 }
 
+// SetCorrelationHeader 设置关联ID请求头名称，设置后 context 中的关联ID会通过该请求头发送
+// 请求头不参与签名，为空时不发送
+func (c *HTTPClient) SetCorrelationHeader(header string) {
+	c.correlationHeader = header
+}
+
+// OnRequest 设置请求发送前的回调
+func (c *HTTPClient) OnRequest(hook RequestHook) {
+	c.onRequest = hook
+}
+
+// OnResponse 设置请求结束后的回调
+func (c *HTTPClient) OnResponse(hook ResponseHook) {
+	c.onResponse = hook
+}
+
+// SetLogger 设置请求和响应诊断日志的输出（请求头中的 API Key、签名已隐藏），设置后优先于 SetDebug 的标准输出
+func (c *HTTPClient) SetLogger(logger Logger) {
+	c.logger = logger
+}
+
+// log 返回诊断日志输出，未设置 Logger 也未启用调试时返回 nil
+func (c *HTTPClient) log() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	if c.debug {
+		return stdoutLogger{}
+	}
+	return nil
+}
+
+// SetErrorParser 设置非 2xx 响应的错误解析函数
+func (c *HTTPClient) SetErrorParser(parser ErrorParser) {
+	c.errorParser = parser
+}
+
+// SetBodyChecker 设置 2xx 响应体的错误检查函数
+func (c *HTTPClient) SetBodyChecker(checker BodyChecker) {
+	c.bodyChecker = checker
+}
+
+// SetRetryPolicy 设置默认重试策略，默认只重试 GET 请求的 429/5xx 响应和网络错误
+// 单次调用可通过 WithRetryPolicy 覆盖
+func (c *HTTPClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = &policy
+}
+
+// RetryPolicy 返回默认重试策略，未设置时返回 false
+func (c *HTTPClient) RetryPolicy() (RetryPolicy, bool) {
+	if c.retryPolicy == nil {
+		return RetryPolicy{}, false
+	}
+	return *c.retryPolicy, true
+}
+
+// SetRequestWeigher 设置请求权重计算函数，未设置时每个请求权重为 1
+func (c *HTTPClient) SetRequestWeigher(weigher RequestWeigher) {
+	c.weigher = weigher
+}
+
+// SetLifecycle 设置生命周期管理器，用于跟踪进行中的请求
+func (c *HTTPClient) SetLifecycle(lifecycle *Lifecycle) {
+	c.lifecycle = lifecycle
+}
+
 // Get 发送GET请求
 func (c *HTTPClient) Get(ctx context.Context, path string, params map[string]interface{}) ([]byte, error) {
 	return c.Request(ctx, http.MethodGet, path, params, nil)
@@ -96,7 +297,50 @@ func (c *HTTPClient) Delete(ctx context.Context, path string, params map[string]
 }
 
 // Request 发送HTTP请求
+// 2xx 响应体为空（如 204 No Content）时视为成功，返回空响应体，调用方可通过 IsEmptyBody 判断
 func (c *HTTPClient) Request(ctx context.Context, method, path string, params map[string]interface{}, body interface{}) ([]byte, error) {
+	return c.RequestWithHeaders(ctx, method, path, params, body, nil)
+}
+
+// RequestWithHeaders 发送带请求级请求头的HTTP请求，headers 覆盖同名的客户端请求头
+// 签名相关的请求头（API Key、签名、时间戳）应通过该方法按请求传入，避免并发请求互相覆盖
+// 配置了重试策略时按策略重试，重试次数耗尽时返回 *RetryExhaustedError
+func (c *HTTPClient) RequestWithHeaders(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, headers map[string]string) ([]byte, error) {
+	if c.lifecycle != nil {
+		defer c.lifecycle.Begin()()
+	}
+
+	policy, ok := RetryPolicyFromContext(ctx)
+	if !ok && c.retryPolicy != nil {
+		policy, ok = *c.retryPolicy, true
+	}
+	if !ok || policy.MaxAttempts <= 1 || (method != http.MethodGet && !policy.RetryNonIdempotent) {
+		return c.do(ctx, method, path, params, body, headers)
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = IsTransientError
+	}
+
+	var resp []byte
+	err := Retry(ctx, policy, func(ctx context.Context) error {
+		var err error
+		resp, err = c.do(ctx, method, path, params, body, headers)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// do 发送一次HTTP请求
+func (c *HTTPClient) do(ctx context.Context, method, path string, params map[string]interface{}, body interface{}, headers map[string]string) (_ []byte, err error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx, c.requestWeight(method, path, params)); err != nil {
+			return nil, err
+		}
+	}
+
 	url := c.baseURL + path
 
 	// 构建查询参数 - 使用 BuildQueryString 确保与签名时一致（排序和URL编码）
@@ -124,38 +368,61 @@ func (c *HTTPClient) Request(ctx context.Context, method, path string, params ma
 	}
 
 	// 设置请求头
+	c.headersMu.RLock()
+	reqHeaders := make(map[string]string, len(c.headers)+len(headers))
 	for k, v := range c.headers {
+		reqHeaders[k] = v
+	}
+	c.headersMu.RUnlock()
+	for k, v := range headers {
+		reqHeaders[k] = v
+	}
+	for k, v := range reqHeaders {
 		req.Header.Set(k, v)
 	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if id, ok := CorrelationIDFromContext(ctx); ok && c.correlationHeader != "" {
+		req.Header.Set(c.correlationHeader, id)
+	}
 
-	// 调试输出：请求信息
-	if c.debug {
-		fmt.Printf("[DEBUG] Request:\n")
-		fmt.Printf("  Method: %s\n", method)
-		fmt.Printf("  URL: %s\n", url)
-		headersJSON, _ := json.Marshal(c.headers)
-		fmt.Printf("  Headers: %s\n", string(headersJSON))
+	if c.onRequest != nil {
+		c.onRequest(ctx, method, path, params)
+	}
+	var start time.Time
+	var status int
+	if c.onResponse != nil {
+		defer func() {
+			c.onResponse(ctx, method, path, status, time.Since(start), err)
+		}()
+	}
+
+	// 诊断日志：请求信息（隐藏 API Key 和签名）
+	log := c.log()
+	if log != nil {
+		headersJSON, _ := json.Marshal(RedactHeaders(reqHeaders))
+		var bodyBytes []byte
 		if body != nil {
-			bodyBytes, _ := json.Marshal(body)
-			fmt.Printf("  Body: %s\n", string(bodyBytes))
+			bodyBytes, _ = json.Marshal(body)
 		}
-		fmt.Println()
+		log.Debugf("request %s %s headers=%s body=%s", method, RedactURL(url), headersJSON, bodyBytes)
 	}
 
 	// 发送请求
+	start = time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
+		if log != nil {
+			log.Errorf("request %s %s failed: %v", method, path, err)
+		}
 		return nil, fmt.Errorf("send request: %w", err)
 	}
+	status = resp.StatusCode
 	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			// Log error but don't fail the request
-			if c.debug {
-				fmt.Printf("Warning: failed to close response body: %v\n", closeErr)
-			}
+		if closeErr := resp.Body.Close(); closeErr != nil && log != nil {
+			// 关闭失败不影响请求结果
+			log.Errorf("close response body: %v", closeErr)
 		}
 	}()
 
@@ -165,18 +432,67 @@ func (c *HTTPClient) Request(ctx context.Context, method, path string, params ma
 		return nil, fmt.Errorf("read response: %w", err)
 	}
 
-	// 调试输出：响应信息
-	if c.debug {
-		fmt.Printf("[DEBUG] Response:\n")
-		fmt.Printf("  Status: %d %s\n", resp.StatusCode, resp.Status)
-		fmt.Printf("  Body: %s\n", string(respBody))
-		fmt.Println()
+	// 诊断日志：响应信息
+	if log != nil {
+		log.Debugf("response %s %s status=%d latency=%s body=%s", method, path, resp.StatusCode, time.Since(start), respBody)
 	}
 
 	// 检查状态码
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("http error %d: %s", resp.StatusCode, string(respBody))
+		httpErr := &HTTPError{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+		if c.errorParser != nil {
+			if err := c.errorParser(httpErr); err != nil {
+				return nil, err
+			}
+		}
+		return nil, httpErr
+	}
+	if c.bodyChecker != nil {
+		if err := c.bodyChecker(respBody); err != nil {
+			return nil, err
+		}
 	}
 
 	return respBody, nil
 }
+
+// parseRetryAfter 解析 Retry-After 响应头（秒数或 HTTP 日期），无法解析时返回 0
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// requestWeight 计算请求权重
+func (c *HTTPClient) requestWeight(method, path string, params map[string]interface{}) int {
+	if c.weigher == nil {
+		return 1
+	}
+	query := url.Values{}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		query, _ = url.ParseQuery(path[i+1:])
+		path = path[:i]
+	}
+	for k, v := range params {
+		query.Set(k, fmt.Sprint(v))
+	}
+	return c.weigher(method, path, query)
+}
+
+// IsEmptyBody 判断响应体是否为空（忽略空白字符）
+func IsEmptyBody(body []byte) bool {
+	return len(bytes.TrimSpace(body)) == 0
+}
@@ -0,0 +1,198 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+// ErrDraining 交易所实例正在关闭，不再接受新的订阅
+var ErrDraining = errors.New("exchange is draining")
+
+// TrackedOrder 通过本实例创建、尚未确认结束的订单
+type TrackedOrder struct {
+	// Perp 是否为永续合约订单
+	Perp bool
+	// Symbol 交易对
+	Symbol string
+	// OrderID 订单ID
+	OrderID string
+}
+
+// SpotOrderCanceler 现货查询和撤单接口
+type SpotOrderCanceler interface {
+	FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error)
+	CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error
+}
+
+// PerpOrderCanceler 永续合约查询和撤单接口
+type PerpOrderCanceler interface {
+	FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error)
+	CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error
+}
+
+// Lifecycle 跟踪交易所实例进行中的请求和新建的订单，用于优雅关闭
+type Lifecycle struct {
+	mu       sync.Mutex
+	inflight int
+	idle     chan struct{} // 没有进行中的请求时处于关闭状态
+	draining bool
+	stopped  chan struct{} // Drain 结束时关闭，结束所有订阅
+	// cancelOrders 为 true 时记录新建订单，Drain 时撤销仍未结束的订单
+	cancelOrders bool
+	orders       map[string]TrackedOrder
+}
+
+// NewLifecycle 创建生命周期管理器
+func NewLifecycle() *Lifecycle {
+	idle := make(chan struct{})
+	close(idle)
+	return &Lifecycle{
+		idle:    idle,
+		stopped: make(chan struct{}),
+		orders:  make(map[string]TrackedOrder),
+	}
+}
+
+// SetCancelOrders 设置 Drain 时是否撤销通过本实例创建且仍未结束的订单（默认关闭，关闭时不记录订单）
+func (l *Lifecycle) SetCancelOrders(cancel bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cancelOrders = cancel
+}
+
+// Begin 标记一个请求开始，返回的函数在请求结束时调用
+func (l *Lifecycle) Begin() (done func()) {
+	l.mu.Lock()
+	if l.inflight == 0 {
+		l.idle = make(chan struct{})
+	}
+	l.inflight++
+	l.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.inflight--
+			if l.inflight == 0 {
+				close(l.idle)
+			}
+			l.mu.Unlock()
+		})
+	}
+}
+
+// Accept 检查是否接受新的订阅，关闭中返回 ErrDraining
+// 返回的 ctx 在 Drain 结束时取消，订阅应使用该 ctx，使轮询和推送在关闭后随之结束
+func (l *Lifecycle) Accept(ctx context.Context) (context.Context, error) {
+	l.mu.Lock()
+	draining, stopped := l.draining, l.stopped
+	l.mu.Unlock()
+	if draining {
+		return nil, ErrDraining
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, nil
+}
+
+// AddOrder 记录新建的订单（未启用撤单时忽略）
+func (l *Lifecycle) AddOrder(order TrackedOrder) {
+	if order.OrderID == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.cancelOrders {
+		return
+	}
+	l.orders[trackedOrderKey(order.Perp, order.OrderID)] = order
+}
+
+// RemoveOrder 移除已结束（撤销或成交）的订单
+func (l *Lifecycle) RemoveOrder(perp bool, orderID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.orders, trackedOrderKey(perp, orderID))
+}
+
+// Orders 返回尚未确认结束的订单
+func (l *Lifecycle) Orders() []TrackedOrder {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	orders := make([]TrackedOrder, 0, len(l.orders))
+	for _, order := range l.orders {
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// Drain 停止接受新的订阅，并等待进行中的请求完成（最长到 ctx 截止）
+// 启用撤单时，随后撤销通过本实例创建且仍未结束的订单，撤单失败的错误合并返回；
+// 返回前取消所有通过 Accept 建立的订阅
+func (l *Lifecycle) Drain(ctx context.Context, spot SpotOrderCanceler, perp PerpOrderCanceler) error {
+	l.mu.Lock()
+	alreadyDraining := l.draining
+	l.draining = true
+	idle := l.idle
+	cancelOrders := l.cancelOrders
+	l.mu.Unlock()
+	if !alreadyDraining {
+		defer close(l.stopped)
+	}
+
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		return fmt.Errorf("wait in-flight requests: %w", ctx.Err())
+	}
+
+	if !cancelOrders {
+		return nil
+	}
+
+	var errs []error
+	for _, order := range l.Orders() {
+		if err := cancelTrackedOrder(ctx, order, spot, perp); err != nil {
+			errs = append(errs, fmt.Errorf("cancel order %s (%s): %w", order.OrderID, order.Symbol, err))
+			continue
+		}
+		l.RemoveOrder(order.Perp, order.OrderID)
+	}
+	return errors.Join(errs...)
+}
+
+// cancelTrackedOrder 撤销单个订单，订单已处于终态时跳过
+func cancelTrackedOrder(ctx context.Context, order TrackedOrder, spot SpotOrderCanceler, perp PerpOrderCanceler) error {
+	if order.Perp {
+		if o, err := perp.FetchOrder(ctx, order.Symbol, order.OrderID); err == nil && IsTerminalOrderStatus(o.Status) {
+			return nil
+		}
+		return perp.CancelOrder(ctx, order.Symbol, order.OrderID)
+	}
+
+	if o, err := spot.FetchOrder(ctx, order.Symbol, order.OrderID); err == nil && IsTerminalOrderStatus(string(o.Status)) {
+		return nil
+	}
+	return spot.CancelOrder(ctx, order.Symbol, order.OrderID)
+}
+
+// trackedOrderKey 订单唯一键（现货和合约订单ID可能重复）
+func trackedOrderKey(perp bool, orderID string) string {
+	if perp {
+		return "perp:" + orderID
+	}
+	return "spot:" + orderID
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+func TestLifecycle_DrainWaitsInflight(t *testing.T) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	lifecycle := NewLifecycle()
+	client := NewHTTPClient(srv.URL)
+	client.SetLifecycle(lifecycle)
+
+	reqDone := make(chan error, 1)
+	go func() {
+		_, err := client.Get(context.Background(), "/slow", nil)
+		reqDone <- err
+	}()
+	<-received
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- lifecycle.Drain(context.Background(), nil, nil)
+	}()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before in-flight request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// 关闭中不再接受新的订阅
+	if _, err := lifecycle.Accept(context.Background()); !errors.Is(err, ErrDraining) {
+		t.Errorf("Accept = %v, want ErrDraining", err)
+	}
+
+	close(release)
+	if err := <-reqDone; err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	select {
+	case err := <-drained:
+		if err != nil {
+			t.Fatalf("Drain: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Drain did not return after in-flight request finished")
+	}
+}
+
+func TestLifecycle_DrainTimeout(t *testing.T) {
+	lifecycle := NewLifecycle()
+	done := lifecycle.Begin()
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := lifecycle.Drain(ctx, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Drain = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+type testSpotCanceler struct {
+	status   map[string]model.OrderStatus
+	canceled []string
+	onCancel func()
+}
+
+func (c *testSpotCanceler) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return &model.SpotOrder{ID: orderId, Symbol: symbol, Status: c.status[orderId]}, nil
+}
+
+func (c *testSpotCanceler) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if c.onCancel != nil {
+		c.onCancel()
+	}
+	c.canceled = append(c.canceled, orderId)
+	return nil
+}
+
+type testPerpCanceler struct {
+	status   map[string]string
+	canceled []string
+	err      error
+}
+
+func (c *testPerpCanceler) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return &model.PerpOrder{ID: orderId, Symbol: symbol, Status: c.status[orderId]}, nil
+}
+
+func (c *testPerpCanceler) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	c.canceled = append(c.canceled, orderId)
+	return c.err
+}
+
+func TestLifecycle_DrainStopsSubscriptions(t *testing.T) {
+	lifecycle := NewLifecycle()
+	parent, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, err := lifecycle.Accept(parent)
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	// 撤单期间订阅仍然有效，Drain 返回前取消
+	lifecycle.SetCancelOrders(true)
+	lifecycle.AddOrder(TrackedOrder{Symbol: "BTC/USDT", OrderID: "1"})
+	spot := &testSpotCanceler{status: map[string]model.OrderStatus{"1": model.OrderStatusNew}}
+	spot.onCancel = func() {
+		if ctx.Err() != nil {
+			t.Error("subscription canceled before the order cancel pass")
+		}
+	}
+	if err := lifecycle.Drain(context.Background(), spot, nil); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscription context not canceled after Drain")
+	}
+	if parent.Err() != nil {
+		t.Error("Drain canceled the caller's context")
+	}
+}
+
+func TestLifecycle_DrainCancelsOrders(t *testing.T) {
+	// 未启用撤单时不记录订单
+	lifecycle := NewLifecycle()
+	lifecycle.AddOrder(TrackedOrder{Symbol: "BTC/USDT", OrderID: "1"})
+	if n := len(lifecycle.Orders()); n != 0 {
+		t.Fatalf("tracked %d orders without cancelOrders, want 0", n)
+	}
+
+	lifecycle.SetCancelOrders(true)
+	lifecycle.AddOrder(TrackedOrder{Symbol: "BTC/USDT", OrderID: "1"})
+	lifecycle.AddOrder(TrackedOrder{Symbol: "BTC/USDT", OrderID: "2"})
+	lifecycle.AddOrder(TrackedOrder{Symbol: "ETH/USDT", OrderID: "3"})
+	lifecycle.AddOrder(TrackedOrder{Perp: true, Symbol: "BTC/USDT:USDT", OrderID: "1"})
+	lifecycle.AddOrder(TrackedOrder{Perp: true, Symbol: "BTC/USDT:USDT", OrderID: "4"})
+	lifecycle.RemoveOrder(false, "3") // 用户已自行撤单
+
+	spot := &testSpotCanceler{status: map[string]model.OrderStatus{
+		"1": model.OrderStatusNew,
+		"2": model.OrderStatusFilled,
+	}}
+	perp := &testPerpCanceler{status: map[string]string{
+		"1": "PARTIALLY_FILLED",
+		"4": "canceled",
+	}}
+
+	if err := lifecycle.Drain(context.Background(), spot, perp); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if len(spot.canceled) != 1 || spot.canceled[0] != "1" {
+		t.Errorf("spot canceled = %v, want [1]", spot.canceled)
+	}
+	if len(perp.canceled) != 1 || perp.canceled[0] != "1" {
+		t.Errorf("perp canceled = %v, want [1]", perp.canceled)
+	}
+	if n := len(lifecycle.Orders()); n != 0 {
+		t.Errorf("tracked %d orders after Drain, want 0", n)
+	}
+
+	// 撤单失败的订单保留，错误合并返回
+	lifecycle = NewLifecycle()
+	lifecycle.SetCancelOrders(true)
+	lifecycle.AddOrder(TrackedOrder{Perp: true, Symbol: "BTC/USDT:USDT", OrderID: "5"})
+	perp = &testPerpCanceler{err: errors.New("rejected")}
+	if err := lifecycle.Drain(context.Background(), spot, perp); err == nil {
+		t.Fatal("Drain error = nil, want cancel error")
+	}
+	if n := len(lifecycle.Orders()); n != 1 {
+		t.Errorf("tracked %d orders after failed cancel, want 1", n)
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lemconn/exlink/option"
+)
+
+// Logger 分级日志接口（同 option.Logger），HTTP 请求和响应的诊断信息通过 Logger 输出
+type Logger = option.Logger
+
+// NopLogger 丢弃全部日志（默认）
+type NopLogger struct{}
+
+// Debugf 丢弃调试日志
+func (NopLogger) Debugf(format string, args ...interface{}) {}
+
+// Infof 丢弃信息日志
+func (NopLogger) Infof(format string, args ...interface{}) {}
+
+// Errorf 丢弃错误日志
+func (NopLogger) Errorf(format string, args ...interface{}) {}
+
+// stdoutLogger 启用 WithDebug 且未设置 Logger 时使用，输出到标准输出
+type stdoutLogger struct{}
+
+func (stdoutLogger) Debugf(format string, args ...interface{}) {
+	fmt.Printf("[DEBUG] "+format+"\n", args...)
+}
+
+func (stdoutLogger) Infof(format string, args ...interface{}) {
+	fmt.Printf("[INFO] "+format+"\n", args...)
+}
+
+func (stdoutLogger) Errorf(format string, args ...interface{}) {
+	fmt.Printf("[ERROR] "+format+"\n", args...)
+}
+
+// redacted 日志中替换敏感值的占位符
+const redacted = "***"
+
+// sensitiveHeaderParts 请求头名称（大写）包含这些词时隐藏其值，覆盖各交易所的 API Key、签名和口令请求头
+var sensitiveHeaderParts = []string{"KEY", "SIGN", "PASSPHRASE", "SECRET", "TOKEN", "AUTHORIZATION"}
+
+// sensitiveQueryPattern 查询串中的签名参数（Binance、MEXC 的 signature 等）
+var sensitiveQueryPattern = regexp.MustCompile(`(?i)([?&](?:signature|sign|api_?key)=)[^&]*`)
+
+// RedactHeaders 返回隐藏 API Key、签名和口令后的请求头副本，用于日志
+func RedactHeaders(headers map[string]string) map[string]string {
+	out := make(map[string]string, len(headers))
+	for k, v := range headers {
+		out[k] = v
+		name := strings.ToUpper(k)
+		for _, part := range sensitiveHeaderParts {
+			if strings.Contains(name, part) {
+				out[k] = redacted
+				break
+			}
+		}
+	}
+	return out
+}
+
+// RedactURL 隐藏 URL 查询串中的签名参数，用于日志
+func RedactURL(rawURL string) string {
+	return sensitiveQueryPattern.ReplaceAllString(rawURL, "${1}"+redacted)
+}
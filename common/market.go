@@ -0,0 +1,209 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
+// MarketCache 记录现货、合约市场信息的加载时间，判断缓存是否过期（并发安全）
+// TTL 为 0 时加载后一直有效，需要刷新时调用 LoadMarkets(ctx, true)
+type MarketCache struct {
+	ttl      time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	loadedAt map[model.MarketType]time.Time
+}
+
+// NewMarketCache 创建市场信息缓存，ttl <= 0 表示不过期
+func NewMarketCache(ttl time.Duration) *MarketCache {
+	return &MarketCache{
+		ttl:      ttl,
+		now:      time.Now,
+		loadedAt: make(map[model.MarketType]time.Time),
+	}
+}
+
+// Touch 记录该类型市场信息刚完成加载
+func (c *MarketCache) Touch(marketType model.MarketType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt[marketType] = c.now()
+}
+
+// Expired 返回该类型市场信息是否已超过 TTL；未设置 TTL 或未记录加载时间时不过期
+func (c *MarketCache) Expired(marketType model.MarketType) bool {
+	if c.ttl <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	loadedAt, ok := c.loadedAt[marketType]
+	return ok && c.now().Sub(loadedAt) >= c.ttl
+}
+
+// MarketSnapshot ExportMarkets 导出的市场信息
+type MarketSnapshot struct {
+	Exchange string        `json:"exchange"`
+	Spot     model.Markets `json:"spot"`
+	Perp     model.Markets `json:"perp"`
+}
+
+// MarshalMarkets 将现货、合约市场信息（按标准化 symbol 索引）序列化为 JSON，市场按 symbol 排序
+func MarshalMarkets(exchange string, spot, perp map[string]*model.Market) ([]byte, error) {
+	return json.Marshal(&MarketSnapshot{
+		Exchange: exchange,
+		Spot:     sortedMarkets(spot),
+		Perp:     sortedMarkets(perp),
+	})
+}
+
+// UnmarshalMarkets 解析 MarshalMarkets 导出的市场信息，交易所名称不一致时返回错误
+func UnmarshalMarkets(exchange string, data []byte) (*MarketSnapshot, error) {
+	var snapshot MarketSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("unmarshal markets: %w", err)
+	}
+	if snapshot.Exchange != exchange {
+		return nil, fmt.Errorf("import markets: snapshot is for %q, not %q", snapshot.Exchange, exchange)
+	}
+	return &snapshot, nil
+}
+
+// IndexMarkets 按标准化 symbol 和原始 ID 建立市场索引
+func IndexMarkets(markets model.Markets) (bySymbol, byID map[string]*model.Market) {
+	bySymbol = make(map[string]*model.Market, len(markets))
+	byID = make(map[string]*model.Market, len(markets))
+	for _, market := range markets {
+		if market == nil {
+			continue
+		}
+		bySymbol[market.Symbol] = market
+		byID[market.ID] = market
+	}
+	return bySymbol, byID
+}
+
+// sortedMarkets 返回按 symbol 排序的市场列表
+func sortedMarkets(markets map[string]*model.Market) model.Markets {
+	list := make(model.Markets, 0, len(markets))
+	for _, market := range markets {
+		list = append(list, market)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Symbol < list[j].Symbol
+	})
+	return list
+}
+
+// MarketSymbols 返回现货、合约市场的标准化 symbol，按字母排序
+func MarketSymbols(spot, perp map[string]*model.Market) []string {
+	symbols := make([]string, 0, len(spot)+len(perp))
+	for symbol := range spot {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range perp {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// SymbolByMarketID 将交易所原始市场ID转换为标准化 symbol
+// 先查现货市场再查合约市场（如 Binance 现货和 U 本位合约同为 BTCUSDT 时返回 BTC/USDT）；
+// 均未找到时由 parse 按交易所格式解析，parse 为 nil 时返回 ErrMarketNotFound
+func SymbolByMarketID(id string, spotByID, perpByID map[string]*model.Market, parse func(id string) (string, error)) (string, error) {
+	if market, ok := spotByID[id]; ok {
+		return market.Symbol, nil
+	}
+	if market, ok := perpByID[id]; ok {
+		return market.Symbol, nil
+	}
+	if parse != nil {
+		return parse(id)
+	}
+	return "", MarketNotFound(id, mergeMarkets(spotByID, perpByID))
+}
+
+// MarketIDBySymbol 将标准化 symbol 转换为交易所原始市场ID
+// 带 :结算货币 的合约 symbol 查合约市场，其他查现货市场；未找到时由 format 按交易所格式转换，format 为 nil 时返回 ErrMarketNotFound
+func MarketIDBySymbol(symbol string, spotBySymbol, perpBySymbol map[string]*model.Market, format func(symbol string) (string, error)) (string, error) {
+	markets := spotBySymbol
+	if strings.Contains(symbol, ":") {
+		markets = perpBySymbol
+	}
+	if market, ok := markets[symbol]; ok {
+		return market.ID, nil
+	}
+	if format != nil {
+		return format(symbol)
+	}
+	return "", MarketNotFound(symbol, markets)
+}
+
+// mergeMarkets 合并两个市场索引（用于错误提示）
+func mergeMarkets(a, b map[string]*model.Market) map[string]*model.Market {
+	merged := make(map[string]*model.Market, len(a)+len(b))
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
+
+// MarketNotFound 返回包装 ErrMarketNotFound 的错误，markets 中有相近的 key 时在错误信息中给出建议
+// 如 BTC-USDT 提示 did you mean BTC/USDT?
+func MarketNotFound(key string, markets map[string]*model.Market) error {
+	if suggestion := closestMarketKey(key, markets); suggestion != "" {
+		return fmt.Errorf("%w: %s (did you mean %s?)", ErrMarketNotFound, key, suggestion)
+	}
+	return fmt.Errorf("%w: %s", ErrMarketNotFound, key)
+}
+
+// closestMarketKey 返回与 key 编辑距离最小的市场 key（不区分大小写），距离超过 key 长度的三分之一（至少 1）时返回空
+func closestMarketKey(key string, markets map[string]*model.Market) string {
+	target := strings.ToUpper(key)
+	maxDistance := len([]rune(target)) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	best, bestDistance := "", maxDistance+1
+	for candidate := range markets {
+		distance := levenshtein(target, strings.ToUpper(candidate))
+		// 距离相同时取字母序较小的，保证结果稳定
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// levenshtein 计算两个字符串的编辑距离（按 rune）
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestMarketCache_Expired(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewMarketCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	// 未记录加载时间时不过期（市场为空时由调用方判断需要加载）
+	if cache.Expired(model.MarketTypeSpot) {
+		t.Error("Expired before Touch")
+	}
+	cache.Touch(model.MarketTypeSpot)
+	now = now.Add(59 * time.Second)
+	if cache.Expired(model.MarketTypeSpot) {
+		t.Error("Expired within TTL")
+	}
+	now = now.Add(time.Second)
+	if !cache.Expired(model.MarketTypeSpot) {
+		t.Error("not Expired after TTL")
+	}
+	if cache.Expired(model.MarketTypeSwap) {
+		t.Error("perp Expired without Touch")
+	}
+
+	noTTL := NewMarketCache(0)
+	noTTL.Touch(model.MarketTypeSpot)
+	noTTL.now = func() time.Time { return now.Add(24 * time.Hour) }
+	if noTTL.Expired(model.MarketTypeSpot) {
+		t.Error("Expired with zero TTL")
+	}
+}
+
+func TestMarshalMarkets_RoundTrip(t *testing.T) {
+	btc := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT", Type: model.MarketTypeSpot, Active: true}
+	eth := &model.Market{ID: "ETHUSDT", Symbol: "ETH/USDT", Base: "ETH", Quote: "USDT", Type: model.MarketTypeSpot, Active: true}
+	perp := &model.Market{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Type: model.MarketTypeSwap, Contract: true, Linear: true}
+
+	data, err := MarshalMarkets("binance", map[string]*model.Market{eth.Symbol: eth, btc.Symbol: btc}, map[string]*model.Market{perp.Symbol: perp})
+	if err != nil {
+		t.Fatalf("MarshalMarkets: %v", err)
+	}
+
+	snapshot, err := UnmarshalMarkets("binance", data)
+	if err != nil {
+		t.Fatalf("UnmarshalMarkets: %v", err)
+	}
+	if len(snapshot.Spot) != 2 || snapshot.Spot[0].Symbol != "BTC/USDT" || snapshot.Spot[1].Symbol != "ETH/USDT" {
+		t.Fatalf("unexpected spot markets: %+v", snapshot.Spot)
+	}
+	if len(snapshot.Perp) != 1 || !snapshot.Perp[0].Contract || snapshot.Perp[0].Settle != "USDT" {
+		t.Fatalf("unexpected perp markets: %+v", snapshot.Perp)
+	}
+
+	bySymbol, byID := IndexMarkets(snapshot.Spot)
+	if bySymbol["ETH/USDT"] != byID["ETHUSDT"] {
+		t.Error("symbol and ID index point to different markets")
+	}
+
+	if _, err := UnmarshalMarkets("okx", data); err == nil {
+		t.Error("expected error importing another exchange's markets")
+	}
+}
+
+func TestMarketNotFound_Suggestion(t *testing.T) {
+	markets := map[string]*model.Market{
+		"BTC/USDT":      {Symbol: "BTC/USDT"},
+		"ETH/USDT":      {Symbol: "ETH/USDT"},
+		"BTC/USDT:USDT": {Symbol: "BTC/USDT:USDT"},
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"BTC-USDT", "market not found: BTC-USDT (did you mean BTC/USDT?)"},
+		{"btc/usdt", "market not found: btc/usdt (did you mean BTC/USDT?)"},
+		{"BTC/USDT:USD", "market not found: BTC/USDT:USD (did you mean BTC/USDT:USDT?)"},
+		{"DOGE/BTC", "market not found: DOGE/BTC"},
+	}
+	for _, tt := range tests {
+		err := MarketNotFound(tt.key, markets)
+		if !errors.Is(err, ErrMarketNotFound) {
+			t.Errorf("%s: err = %v, want ErrMarketNotFound", tt.key, err)
+		}
+		if err.Error() != tt.want {
+			t.Errorf("%s: err = %q, want %q", tt.key, err.Error(), tt.want)
+		}
+	}
+
+	symbols := MarketSymbols(map[string]*model.Market{"ETH/USDT": nil, "BTC/USDT": nil}, map[string]*model.Market{"BTC/USDT:USDT": nil})
+	if fmt.Sprint(symbols) != "[BTC/USDT BTC/USDT:USDT ETH/USDT]" {
+		t.Errorf("MarketSymbols = %v", symbols)
+	}
+}
+
+// benchmarkMarkets 生成 n 个市场及对应的原始ID列表（模拟批量行情按原始ID查找市场）
+func benchmarkMarkets(n int) (model.Markets, []string) {
+	markets := make(model.Markets, 0, n)
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("COIN%dUSDT", i)
+		markets = append(markets, &model.Market{ID: id, Symbol: fmt.Sprintf("COIN%d/USDT", i)})
+		ids = append(ids, id)
+	}
+	return markets, ids
+}
+
+func BenchmarkMarketLookupByID_Index(b *testing.B) {
+	markets, ids := benchmarkMarkets(2000)
+	_, byID := IndexMarkets(markets)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if byID[id] == nil {
+				b.Fatalf("market not found: %s", id)
+			}
+		}
+	}
+}
+
+func BenchmarkMarketLookupByID_Scan(b *testing.B) {
+	markets, ids := benchmarkMarkets(2000)
+	bySymbol, _ := IndexMarkets(markets)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			var found *model.Market
+			for _, market := range bySymbol {
+				if market.ID == id {
+					found = market
+					break
+				}
+			}
+			if found == nil {
+				b.Fatalf("market not found: %s", id)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
+// DropUnclosedOHLCVs 丢弃仍在形成中的K线（开盘时间 + 周期晚于 now），返回已收盘K线
+// now 为判定基准时间，适配器传入按服务器时间偏移校正的 Clock.Now()，避免本地时钟偏差误判临界K线
+func DropUnclosedOHLCVs(candles model.OHLCVs, timeframe string, now time.Time) (model.OHLCVs, error) {
+	period, err := ParseTimeframe(timeframe)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make(model.OHLCVs, 0, len(candles))
+	for _, c := range candles {
+		if c == nil || c.Timestamp.Add(period).After(now) {
+			continue
+		}
+		closed = append(closed, c)
+	}
+	return closed, nil
+}
+
+// OHLCVPageFetcher 获取开盘时间在 [start, end] 内的一页K线，最多 limit 根
+type OHLCVPageFetcher func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error)
+
+// FetchOHLCVRange 分页获取开盘时间在 [since, until) 内的K线，返回按开盘时间升序、去重后的连续序列
+// 每页窗口为 limit 根K线，游标每页前进 limit * 周期；某一页无数据或到达 until 时停止，until 为零值时截止到当前时间
+func FetchOHLCVRange(ctx context.Context, timeframe string, since, until time.Time, limit int, fetch OHLCVPageFetcher) (model.OHLCVs, error) {
+	period, err := ParseTimeframe(timeframe)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	}
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	seen := make(map[int64]bool)
+	var result model.OHLCVs
+	for cursor := since; cursor.Before(until); cursor = cursor.Add(time.Duration(limit) * period) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := cursor.Add(time.Duration(limit-1) * period)
+		if last := until.Add(-time.Millisecond); end.After(last) {
+			end = last
+		}
+		page, err := fetch(ctx, cursor, end, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for _, c := range page {
+			if c == nil || c.Timestamp.Before(since) || !c.Timestamp.Before(until) {
+				continue
+			}
+			ts := c.Timestamp.UnixMilli()
+			if seen[ts] {
+				continue
+			}
+			seen[ts] = true
+			result = append(result, c)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp.Time)
+	})
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestDropUnclosedOHLCVs(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	candles := model.OHLCVs{
+		newTestOHLCV(now.Add(-3*time.Hour).Truncate(time.Hour), 1),
+		newTestOHLCV(now.Add(-2*time.Hour).Truncate(time.Hour), 2),
+		newTestOHLCV(now.Add(-time.Hour).Truncate(time.Hour), 3),
+		newTestOHLCV(now.Truncate(time.Hour), 4), // 10:00 开盘，11:00 收盘，仍在形成中
+	}
+
+	closed, err := DropUnclosedOHLCVs(candles, "1h", now)
+	if err != nil {
+		t.Fatalf("DropUnclosedOHLCVs: %v", err)
+	}
+	if len(closed) != 3 {
+		t.Fatalf("expected 3 closed candles, got %d", len(closed))
+	}
+	if !closed[2].Close.Equal(candles[2].Close.Decimal) {
+		t.Errorf("expected last closed candle close %s, got %s", candles[2].Close, closed[2].Close)
+	}
+
+	// 恰好到达收盘时间的K线视为已收盘
+	closed, err = DropUnclosedOHLCVs(candles, "1h", now.Truncate(time.Hour).Add(time.Hour))
+	if err != nil {
+		t.Fatalf("DropUnclosedOHLCVs: %v", err)
+	}
+	if len(closed) != 4 {
+		t.Errorf("expected 4 closed candles, got %d", len(closed))
+	}
+
+	if _, err := DropUnclosedOHLCVs(candles, "1x", now); err == nil {
+		t.Error("expected error for invalid timeframe")
+	}
+}
+
+func TestFetchOHLCVRange_StopsOnEmptyPage(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := since.Add(4 * time.Hour) // 交易所只有 5 根K线
+	calls := 0
+	fetch := func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		calls++
+		var page model.OHLCVs
+		// 每页额外返回上一页的最后一根K线，验证去重
+		for ts := start.Add(-time.Hour); !ts.After(end) && !ts.After(last); ts = ts.Add(time.Hour) {
+			if ts.Before(since) {
+				continue
+			}
+			page = append(page, newTestOHLCV(ts, int64(ts.Hour())))
+		}
+		return page, nil
+	}
+
+	candles, err := FetchOHLCVRange(context.Background(), "1h", since, since.Add(24*time.Hour), 2, fetch)
+	if err != nil {
+		t.Fatalf("FetchOHLCVRange: %v", err)
+	}
+	if len(candles) != 5 {
+		t.Fatalf("expected 5 candles, got %d", len(candles))
+	}
+	for i, c := range candles {
+		if want := since.Add(time.Duration(i) * time.Hour); !c.Timestamp.Equal(want) {
+			t.Errorf("candle %d timestamp = %s, want %s", i, c.Timestamp, want)
+		}
+	}
+	// 3 页有数据，第 4 页为空后停止
+	if calls != 4 {
+		t.Errorf("expected 4 page requests, got %d", calls)
+	}
+
+	if _, err := FetchOHLCVRange(context.Background(), "1x", since, time.Time{}, 2, fetch); err == nil {
+		t.Error("expected error for invalid timeframe")
+	}
+}
@@ -0,0 +1,396 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// orderPollInterval 订单成交默认轮询间隔
+const orderPollInterval = time.Second
+
+// terminalOrderStatuses 各交易所订单终态（统一转为小写并去除下划线后比较）
+var terminalOrderStatuses = map[string]bool{
+	"filled":                  true, // 通用
+	"closed":                  true, // 统一格式
+	"canceled":                true, // Binance/OKX/统一格式
+	"cancelled":               true, // Bybit
+	"expired":                 true, // Binance
+	"expiredinmatch":          true, // Binance
+	"rejected":                true, // Binance/Bybit
+	"finished":                true, // Gate
+	"partiallyfilledcanceled": true, // Bybit
+	"deactivated":             true, // Bybit
+	"mmpcanceled":             true, // OKX
+	"fullyexecuted":           true, // Kraken 合约
+}
+
+// IsTerminalOrderStatus 判断交易所订单状态是否为终态（不会再有新成交）
+func IsTerminalOrderStatus(status string) bool {
+	normalized := strings.ReplaceAll(strings.ToLower(status), "_", "")
+	return terminalOrderStatuses[normalized]
+}
+
+// RequireSymbol 交易所接口必须指定交易对时校验 symbol 非空，为空时返回匹配 ErrSymbolRequired 的错误
+func RequireSymbol(operation, symbol string) error {
+	if symbol == "" {
+		return fmt.Errorf("%s: %w", operation, ErrSymbolRequired)
+	}
+	return nil
+}
+
+// ValidateEditOrder 校验改单参数：newAmount 和 newPrice 为空表示不修改，但至少提供一个，提供的值必须为正数
+func ValidateEditOrder(newAmount, newPrice string) error {
+	if newAmount == "" && newPrice == "" {
+		return fmt.Errorf("either new amount or new price must be provided")
+	}
+	if newAmount != "" {
+		if v, err := decimal.NewFromString(newAmount); err != nil || !v.IsPositive() {
+			return fmt.Errorf("invalid new amount: %s", newAmount)
+		}
+	}
+	if newPrice != "" {
+		if v, err := decimal.NewFromString(newPrice); err != nil || !v.IsPositive() {
+			return fmt.Errorf("invalid new price: %s", newPrice)
+		}
+	}
+	return nil
+}
+
+// EditOrderToPrecision 将改单的新数量和新价格分别按 AmountToPrecision、PriceToPrecision 对齐到市场精度，为空的值保持为空（不修改）
+func EditOrderToPrecision(market *model.Market, newAmount, newPrice string) (amount, price string, err error) {
+	if newAmount != "" {
+		if amount, err = AmountToPrecision(market, newAmount); err != nil {
+			return "", "", err
+		}
+	}
+	if newPrice != "" {
+		if price, err = PriceToPrecision(market, newPrice); err != nil {
+			return "", "", err
+		}
+	}
+	return amount, price, nil
+}
+
+// ValidateOCOOrder 校验 OCO 订单价格：price、stopPrice 必须为正数，stopLimitPrice 为空或正数；
+// 卖出时限价 price 须高于触发价 stopPrice（止盈在上、止损在下），买入时须低于触发价，否则返回 ErrInvalidOrder
+func ValidateOCOOrder(side option.SpotOrderSide, price, stopPrice, stopLimitPrice string) error {
+	limit, err := decimal.NewFromString(price)
+	if err != nil || !limit.IsPositive() {
+		return fmt.Errorf("%w: invalid oco price: %s", ErrInvalidOrder, price)
+	}
+	trigger, err := decimal.NewFromString(stopPrice)
+	if err != nil || !trigger.IsPositive() {
+		return fmt.Errorf("%w: invalid oco stop price: %s", ErrInvalidOrder, stopPrice)
+	}
+	if stopLimitPrice != "" {
+		if v, err := decimal.NewFromString(stopLimitPrice); err != nil || !v.IsPositive() {
+			return fmt.Errorf("%w: invalid oco stop limit price: %s", ErrInvalidOrder, stopLimitPrice)
+		}
+	}
+
+	switch side {
+	case option.Sell:
+		if !limit.GreaterThan(trigger) {
+			return fmt.Errorf("%w: oco sell price %s must be above stop price %s", ErrInvalidOrder, price, stopPrice)
+		}
+	case option.Buy:
+		if !limit.LessThan(trigger) {
+			return fmt.Errorf("%w: oco buy price %s must be below stop price %s", ErrInvalidOrder, price, stopPrice)
+		}
+	default:
+		return fmt.Errorf("%w: invalid oco side: %s", ErrInvalidOrder, side)
+	}
+	return nil
+}
+
+// ParseStopOrder 解析条件单参数，未设置触发价时 ok 为 false；触发价须为正数，触发类型默认止损
+func ParseStopOrder(opts *option.ExchangeArgsOptions) (stopPrice decimal.Decimal, triggerType option.TriggerType, ok bool, err error) {
+	if !option.StringPresent(opts.StopPrice) {
+		if opts.TriggerType != nil {
+			return decimal.Zero, "", false, fmt.Errorf("trigger type requires stop price")
+		}
+		return decimal.Zero, "", false, nil
+	}
+	stopPrice, err = decimal.NewFromString(*opts.StopPrice)
+	if err != nil || !stopPrice.IsPositive() {
+		return decimal.Zero, "", false, fmt.Errorf("invalid stop price: %s", *opts.StopPrice)
+	}
+
+	triggerType = option.StopLoss
+	if opts.TriggerType != nil {
+		triggerType = *opts.TriggerType
+	}
+	if !triggerType.IsStopLoss() && !triggerType.IsTakeProfit() {
+		return decimal.Zero, "", false, fmt.Errorf("invalid trigger type: %s", triggerType)
+	}
+	return stopPrice, triggerType, true, nil
+}
+
+// ParseTrailingStop 解析跟踪止损选项，回调比例（百分比）需在 [minRate, maxRate] 内
+// 与触发价同时指定时返回 ErrInvalidOrder
+func ParseTrailingStop(opts *option.ExchangeArgsOptions, minRate, maxRate decimal.Decimal) (callbackRate, activationPrice decimal.Decimal, ok bool, err error) {
+	if !option.StringPresent(opts.TrailingCallbackRate) {
+		return decimal.Zero, decimal.Zero, false, nil
+	}
+	callbackRate, err = decimal.NewFromString(*opts.TrailingCallbackRate)
+	if err != nil || !callbackRate.IsPositive() {
+		return decimal.Zero, decimal.Zero, false, fmt.Errorf("invalid trailing callback rate: %s: %w", *opts.TrailingCallbackRate, ErrInvalidOrder)
+	}
+	if callbackRate.LessThan(minRate) || callbackRate.GreaterThan(maxRate) {
+		return decimal.Zero, decimal.Zero, false, fmt.Errorf("trailing callback rate %s%% out of range [%s%%, %s%%]: %w",
+			callbackRate, minRate, maxRate, ErrInvalidOrder)
+	}
+	if option.StringPresent(opts.StopPrice) {
+		return decimal.Zero, decimal.Zero, false, fmt.Errorf("trailing stop conflicts with stop price: %w", ErrInvalidOrder)
+	}
+	if option.StringPresent(opts.TrailingActivationPrice) {
+		activationPrice, err = decimal.NewFromString(*opts.TrailingActivationPrice)
+		if err != nil || !activationPrice.IsPositive() {
+			return decimal.Zero, decimal.Zero, false, fmt.Errorf("invalid trailing activation price: %s: %w", *opts.TrailingActivationPrice, ErrInvalidOrder)
+		}
+	}
+	return callbackRate, activationPrice, true, nil
+}
+
+// ParsePostOnly 解析只做 Maker 选项（WithPostOnly(true) 或 WithTimeInForce(option.GTX)），isLimit 为是否限价单
+// 市价单或同时指定 IOC/FOK 时返回 ErrInvalidOrder
+func ParsePostOnly(opts *option.ExchangeArgsOptions, isLimit bool) (bool, error) {
+	postOnly, _ := option.GetBool(opts.PostOnly)
+	if opts.TimeInForce != nil && opts.TimeInForce.IsGTX() {
+		postOnly = true
+	}
+	if !postOnly {
+		return false, nil
+	}
+	if !isLimit {
+		return false, fmt.Errorf("post-only requires a limit order: %w", ErrInvalidOrder)
+	}
+	if opts.TimeInForce != nil && !opts.TimeInForce.IsGTC() && !opts.TimeInForce.IsGTX() {
+		return false, fmt.Errorf("post-only conflicts with time in force %s: %w", *opts.TimeInForce, ErrInvalidOrder)
+	}
+	return true, nil
+}
+
+// CheckTimeInForce 校验订单有效期与订单类型的组合，isLimit 为是否限价单，marketFOK 为交易所是否支持市价 FOK
+// 市价单忽略 GTC、接受 IOC；GTX 仅限价单可用；未知取值返回 ErrInvalidOrder
+func CheckTimeInForce(opts *option.ExchangeArgsOptions, isLimit, marketFOK bool) error {
+	if opts.TimeInForce == nil {
+		return nil
+	}
+	switch tif := *opts.TimeInForce; {
+	case tif.IsGTC(), tif.IsIOC():
+		return nil
+	case tif.IsFOK():
+		if isLimit || marketFOK {
+			return nil
+		}
+		return fmt.Errorf("time in force FOK is not supported for market orders: %w", ErrInvalidOrder)
+	case tif.IsGTX():
+		if isLimit {
+			return nil
+		}
+		return fmt.Errorf("time in force GTX requires a limit order: %w", ErrInvalidOrder)
+	default:
+		return fmt.Errorf("unsupported time in force %s: %w", tif, ErrInvalidOrder)
+	}
+}
+
+// ReduceOnly 返回合约订单是否只减仓：设置了 option.WithReduceOnly 时以其为准，否则平仓单只减仓
+func ReduceOnly(orderSide option.PerpOrderSide, opts *option.ExchangeArgsOptions) bool {
+	if reduceOnly, ok := option.GetBool(opts.ReduceOnly); ok {
+		return reduceOnly
+	}
+	return orderSide.ToReduceOnly()
+}
+
+// OrderFillSnapshot 订单成交快照
+type OrderFillSnapshot struct {
+	// OrderID 交易所订单ID，用于从订单推送中筛选被跟踪的订单
+	OrderID string
+	// Filled 累计成交数量
+	Filled decimal.Decimal
+	// Average 累计成交均价
+	Average decimal.Decimal
+	// Status 交易所返回的订单状态
+	Status string
+}
+
+// SpotOrderFillSnapshot 由现货订单构建成交快照，成交额可用时以成交额/成交数量计算均价
+func SpotOrderFillSnapshot(order *model.SpotOrder) *OrderFillSnapshot {
+	average := order.Average.Decimal
+	if order.Cost.IsPositive() && order.Filled.IsPositive() {
+		average = order.Cost.DivRound(order.Filled.Decimal, 16)
+	}
+	return &OrderFillSnapshot{OrderID: order.ID, Filled: order.Filled.Decimal, Average: average, Status: string(order.Status)}
+}
+
+// PerpOrderFillSnapshot 由合约订单构建成交快照
+func PerpOrderFillSnapshot(order *model.PerpOrder) *OrderFillSnapshot {
+	return &OrderFillSnapshot{OrderID: order.ID, Filled: order.ExecutedQuantity.Decimal, Average: order.AvgPrice.Decimal, Status: order.Status}
+}
+
+// OrderFillFetcher 查询订单当前成交快照
+type OrderFillFetcher func(ctx context.Context) (*OrderFillSnapshot, error)
+
+// OrderStreamSubscriber 订阅私有订单推送（每条消息可含多个订单），resync 需在断线重新订阅后调用（通常传给 WatchTopicResync）
+type OrderStreamSubscriber[T any] func(ctx context.Context, resync WSResync[[]T]) (<-chan []T, error)
+
+// TrackOrderFills 通过私有 WebSocket 订单推送跟踪订单成交，推送增量成交事件，订单进入终态后推送 Final 事件并关闭通道
+// 先订阅推送再用 fetch 查询一次订单作为初始快照，避免订阅前已发生的成交被遗漏；之后只处理 ID 为 orderID 的推送，
+// 仅在断线重新订阅后再用 fetch 补齐断线期间遗漏的成交。首次订阅或查询失败时直接返回错误，通道在 ctx 取消后关闭。
+func TrackOrderFills[T any](ctx context.Context, orderID, symbol string, subscribe OrderStreamSubscriber[T], fetch func(ctx context.Context) (T, error), snapshot func(T) *OrderFillSnapshot) (<-chan *model.OrderFillEvent, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	updates, err := subscribe(ctx, func(ctx context.Context) ([]T, bool) {
+		order, err := fetch(ctx)
+		if err != nil {
+			return nil, false
+		}
+		return []T{order}, true
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	order, err := fetch(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ch := make(chan *model.OrderFillEvent)
+	t := &orderFillTracker{orderID: orderID, symbol: symbol}
+
+	go func() {
+		defer close(ch)
+		defer cancel()
+
+		pending := []T{order}
+		for {
+			for _, order := range pending {
+				s := snapshot(order)
+				if s.OrderID != orderID {
+					continue
+				}
+				event := t.update(s, time.Now())
+				if event == nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+					return
+				}
+				if event.Final {
+					return
+				}
+			}
+
+			var ok bool
+			if pending, ok = <-updates; !ok {
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// PollOrderFills 轮询订单并推送增量成交事件，用于没有私有订单推送的交易所，事件规则同 TrackOrderFills
+// 首次请求失败时直接返回错误，之后单次请求失败会在下个周期重试，通道在 ctx 取消后关闭。
+func PollOrderFills(ctx context.Context, orderID, symbol string, interval time.Duration, fetch OrderFillFetcher) (<-chan *model.OrderFillEvent, error) {
+	if interval <= 0 {
+		interval = orderPollInterval
+	}
+
+	snapshot, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *model.OrderFillEvent)
+	t := &orderFillTracker{orderID: orderID, symbol: symbol}
+
+	go func() {
+		defer close(ch)
+
+		for {
+			if snapshot != nil {
+				if event := t.update(snapshot, time.Now()); event != nil {
+					select {
+					case ch <- event:
+					case <-ctx.Done():
+						return
+					}
+					if event.Final {
+						return
+					}
+				}
+			}
+
+			timer := time.NewTimer(interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			// 单次请求失败时跳过本周期，下个周期重试
+			if snapshot, err = fetch(ctx); err != nil {
+				snapshot = nil
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// orderFillTracker 记录累计成交数量和成交额，用于计算增量成交和 VWAP
+type orderFillTracker struct {
+	orderID string
+	symbol  string
+	filled  decimal.Decimal
+	cost    decimal.Decimal
+}
+
+// update 处理一次成交快照，无新成交且未进入终态时返回 nil
+func (t *orderFillTracker) update(s *OrderFillSnapshot, now time.Time) *model.OrderFillEvent {
+	final := IsTerminalOrderStatus(s.Status)
+
+	var fillAmount, fillPrice decimal.Decimal
+	if s.Filled.GreaterThan(t.filled) {
+		cost := s.Filled.Mul(s.Average)
+		fillAmount = s.Filled.Sub(t.filled)
+		fillPrice = cost.Sub(t.cost).DivRound(fillAmount, 16)
+		t.filled = s.Filled
+		t.cost = cost
+	} else if !final {
+		return nil
+	}
+
+	var vwap decimal.Decimal
+	if t.filled.IsPositive() {
+		vwap = t.cost.DivRound(t.filled, 16)
+	}
+
+	return &model.OrderFillEvent{
+		OrderID:    t.orderID,
+		Symbol:     t.symbol,
+		FillAmount: types.ExDecimal{Decimal: fillAmount},
+		FillPrice:  types.ExDecimal{Decimal: fillPrice},
+		Filled:     types.ExDecimal{Decimal: t.filled},
+		VWAP:       types.ExDecimal{Decimal: vwap},
+		Status:     s.Status,
+		Final:      final,
+		Timestamp:  types.ExTimestamp{Time: now},
+	}
+}
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+// OrderRetryPolicy 返回下单请求生效的重试策略（context 中的策略优先于客户端默认策略）
+// 只有 MaxAttempts > 1 且允许重试非 GET 请求（RetryNonIdempotent）时返回 true
+func (c *HTTPClient) OrderRetryPolicy(ctx context.Context) (RetryPolicy, bool) {
+	policy, ok := RetryPolicyFromContext(ctx)
+	if !ok && c.retryPolicy != nil {
+		policy, ok = *c.retryPolicy, true
+	}
+	return policy, ok && policy.MaxAttempts > 1 && policy.RetryNonIdempotent
+}
+
+// OrderCreator 提交一次订单，opts 中已包含客户端订单ID
+type OrderCreator func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error)
+
+// LookedUpOrder 按客户端订单ID查询到的订单（*model.SpotOrder 或 *model.PerpOrder）
+type LookedUpOrder interface {
+	NewOrder() *model.NewOrder
+}
+
+// CreateOrderIdempotent 带幂等保护的下单重试，各交易所 CreateOrder 共用
+// 未启用下单重试（见 OrderRetryPolicy）时直接提交一次；启用时未指定客户端订单ID则按 exchange、side 自动生成，
+// 提交遇到结果未知的错误（网络错误、超时、429/5xx）后，重试前先按客户端订单ID查询：订单已存在则返回该订单，不存在才重新提交，
+// 查询失败时不再提交，返回提交错误，避免重复下单
+// lookup 按客户端订单ID查询订单，订单不存在时返回的错误须匹配 ErrOrderNotFound；条件单和跟踪止损可能无法按客户端订单ID查询，不重试
+func CreateOrderIdempotent[T LookedUpOrder](ctx context.Context, client *HTTPClient, exchange, side string, opts []option.ArgsOption, create OrderCreator, lookup func(ctx context.Context, clientOrderID string) (T, error)) (*model.NewOrder, error) {
+	policy, ok := client.OrderRetryPolicy(ctx)
+	if !ok {
+		return create(ctx, opts)
+	}
+	// 单次提交不再由 HTTPClient 重试，查询等 GET 请求仍按原策略重试
+	once := policy
+	once.RetryNonIdempotent = false
+	onceCtx := WithRetryPolicy(ctx, once)
+
+	args := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if option.StringPresent(args.StopPrice) || option.StringPresent(args.TrailingCallbackRate) {
+		return create(onceCtx, opts)
+	}
+	clientOrderID := ""
+	if args.ClientOrderID != nil {
+		clientOrderID = *args.ClientOrderID
+	}
+	if clientOrderID == "" {
+		clientOrderID = GenerateClientOrderID(exchange, side)
+		opts = append(opts[:len(opts):len(opts)], option.WithClientOrderID(clientOrderID))
+	}
+
+	var order *model.NewOrder
+	var submitErr error
+	submitted, stop := false, false
+	retry := RetryPolicy{
+		MaxAttempts: policy.MaxAttempts,
+		Backoff:     policy.Backoff,
+		MaxBackoff:  policy.MaxBackoff,
+		Retryable: func(err error) bool {
+			return !stop && orderOutcomeUnknown(ctx, err)
+		},
+	}
+	err := Retry(ctx, retry, func(ctx context.Context) error {
+		if submitted {
+			existing, err := lookup(onceCtx, clientOrderID)
+			if err == nil {
+				order = existing.NewOrder()
+				return nil
+			}
+			if !errors.Is(err, ErrOrderNotFound) {
+				stop = true
+				return fmt.Errorf("%w (order %s may have been accepted, lookup failed: %v)", submitErr, clientOrderID, err)
+			}
+		}
+		submitted = true
+		order, submitErr = create(onceCtx, opts)
+		return submitErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// orderOutcomeUnknown 判断下单失败后订单是否可能已被交易所接受：网络错误（含客户端超时，IsTransientError 不重试超时）或 429/5xx
+// 调用方 ctx 已结束时不再重试
+func orderOutcomeUnknown(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	return IsTransientError(err) || errors.As(err, &netErr)
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+func TestCreateOrderIdempotent(t *testing.T) {
+	client := NewHTTPClient("http://localhost")
+	unavailable := &HTTPError{StatusCode: http.StatusServiceUnavailable}
+	retryCtx := WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 3, RetryNonIdempotent: true})
+
+	// create 依次返回 results 中的错误，全部用完后成功
+	run := func(ctx context.Context, opts []option.ArgsOption, results []error, lookupErr error) (*model.NewOrder, int, int, error) {
+		creates, lookups := 0, 0
+		order, err := CreateOrderIdempotent(ctx, client, "binance", "buy", opts,
+			func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+				creates++
+				if policy, _ := RetryPolicyFromContext(ctx); policy.RetryNonIdempotent {
+					t.Error("single submit must not be retried by HTTPClient")
+				}
+				if creates <= len(results) {
+					return nil, results[creates-1]
+				}
+				args := &option.ExchangeArgsOptions{}
+				for _, opt := range opts {
+					opt(args)
+				}
+				return &model.NewOrder{OrderId: "new", ClientOrderID: *args.ClientOrderID}, nil
+			},
+			func(ctx context.Context, clientOrderID string) (*model.SpotOrder, error) {
+				lookups++
+				if lookupErr != nil {
+					return nil, lookupErr
+				}
+				return &model.SpotOrder{ID: "existing", ClientOrderID: clientOrderID}, nil
+			})
+		return order, creates, lookups, err
+	}
+
+	// 提交失败后订单已存在：返回查到的订单，不重复提交
+	order, creates, lookups, err := run(retryCtx, []option.ArgsOption{option.WithClientOrderID("my-id")}, []error{unavailable}, nil)
+	if err != nil || order.OrderId != "existing" || order.ClientOrderID != "my-id" || creates != 1 || lookups != 1 {
+		t.Errorf("existing: order=%+v creates=%d lookups=%d err=%v", order, creates, lookups, err)
+	}
+
+	// 订单不存在：重新提交，自动生成的客户端订单ID保持不变
+	order, creates, lookups, err = run(retryCtx, nil, []error{unavailable, unavailable}, fmt.Errorf("fetch order: %w", ErrOrderNotFound))
+	if err != nil || order.OrderId != "new" || !strings.HasPrefix(order.ClientOrderID, "ELBINB") || creates != 3 || lookups != 2 {
+		t.Errorf("not found: order=%+v creates=%d lookups=%d err=%v", order, creates, lookups, err)
+	}
+
+	// 查询失败：不再提交，返回提交错误
+	_, creates, lookups, err = run(retryCtx, nil, []error{unavailable}, errors.New("lookup down"))
+	if !errors.Is(err, unavailable) || creates != 1 || lookups != 1 {
+		t.Errorf("lookup failed: creates=%d lookups=%d err=%v", creates, lookups, err)
+	}
+
+	// 明确被拒绝的订单不重试
+	_, creates, lookups, err = run(retryCtx, nil, []error{ErrInsufficientFunds}, nil)
+	if !errors.Is(err, ErrInsufficientFunds) || creates != 1 || lookups != 0 {
+		t.Errorf("rejected: creates=%d lookups=%d err=%v", creates, lookups, err)
+	}
+
+	// 条件单不重试
+	_, creates, _, err = run(retryCtx, []option.ArgsOption{option.WithStopPrice("100")}, []error{unavailable}, nil)
+	if !errors.Is(err, unavailable) || creates != 1 {
+		t.Errorf("stop order: creates=%d err=%v", creates, err)
+	}
+
+	// 未启用下单重试时只提交一次
+	_, creates, lookups, err = run(context.Background(), nil, []error{unavailable}, nil)
+	if !errors.Is(err, unavailable) || creates != 1 || lookups != 0 {
+		t.Errorf("retry disabled: creates=%d lookups=%d err=%v", creates, lookups, err)
+	}
+}
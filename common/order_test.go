@@ -0,0 +1,472 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestTrackOrderFills(t *testing.T) {
+	conns := make(chan *mockWSConn, 2)
+	m := NewWSManager(func(ctx context.Context) (WSConn, error) {
+		conn := newMockWSConn()
+		conns <- conn
+		return conn, nil
+	}, testWSProtocol{})
+	defer m.Close()
+
+	// 第一次查询为订阅后的初始快照，第二次为断线重连后的补齐查询
+	fetched := []*OrderFillSnapshot{
+		{OrderID: "1", Filled: decimal.Zero, Status: "NEW"},
+		{OrderID: "1", Filled: decimal.RequireFromString("4"), Average: decimal.RequireFromString("103"), Status: "PARTIALLY_FILLED"},
+	}
+	var mu sync.Mutex
+	calls := 0
+	fetch := func(ctx context.Context) (*OrderFillSnapshot, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls > len(fetched) {
+			return nil, errors.New("unexpected fetch")
+		}
+		return fetched[calls-1], nil
+	}
+	subscribe := func(ctx context.Context, resync WSResync[[]*OrderFillSnapshot]) (<-chan []*OrderFillSnapshot, error) {
+		return WatchTopicResync(ctx, m, "orders", func(msg []byte) ([]*OrderFillSnapshot, bool) {
+			var v struct {
+				Data []struct {
+					ID      string          `json:"id"`
+					Filled  decimal.Decimal `json:"filled"`
+					Average decimal.Decimal `json:"avg"`
+					Status  string          `json:"status"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(msg, &v); err != nil {
+				return nil, false
+			}
+			snapshots := make([]*OrderFillSnapshot, 0, len(v.Data))
+			for _, o := range v.Data {
+				snapshots = append(snapshots, &OrderFillSnapshot{OrderID: o.ID, Filled: o.Filled, Average: o.Average, Status: o.Status})
+			}
+			return snapshots, true
+		}, resync)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ch, err := TrackOrderFills(ctx, "1", "BTC/USDT", subscribe, fetch, func(s *OrderFillSnapshot) *OrderFillSnapshot { return s })
+	if err != nil {
+		t.Fatalf("TrackOrderFills: %v", err)
+	}
+
+	expected := []struct {
+		fillAmount, fillPrice, filled, vwap string
+		final                               bool
+	}{
+		{"1", "100", "1", "100", false},
+		{"2", "103", "3", "102", false}, // (306 - 100) / 2
+		{"1", "106", "4", "103", false}, // 断线期间的成交，由重连后的查询补齐 (412 - 306) / 1
+		{"1", "108", "5", "104", true},  // (520 - 412) / 1
+	}
+	var got int
+	receive := func() {
+		t.Helper()
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %d events", got)
+			}
+			want := expected[got]
+			if event.OrderID != "1" || event.FillAmount.String() != want.fillAmount || event.FillPrice.String() != want.fillPrice {
+				t.Errorf("event %d: expected fill %s@%s, got %+v", got, want.fillAmount, want.fillPrice, event)
+			}
+			if event.Filled.String() != want.filled || event.VWAP.String() != want.vwap {
+				t.Errorf("event %d: expected filled %s vwap %s, got %s vwap %s", got, want.filled, want.vwap, event.Filled, event.VWAP)
+			}
+			if event.Final != want.final {
+				t.Errorf("event %d: expected final %v, got %v", got, want.final, event.Final)
+			}
+			got++
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for event %d", got)
+		}
+	}
+
+	first := <-conns
+	first.incoming <- []byte(`{"topic":"orders","data":[{"id":"2","filled":"7","avg":"90","status":"PARTIALLY_FILLED"},{"id":"1","filled":"1","avg":"100","status":"PARTIALLY_FILLED"}]}`)
+	receive()
+	first.incoming <- []byte(`{"topic":"orders","data":[{"id":"1","filled":"1","avg":"100","status":"PARTIALLY_FILLED"}]}`) // 无新成交，不推送
+	first.incoming <- []byte(`{"topic":"orders","data":[{"id":"1","filled":"3","avg":"102","status":"PARTIALLY_FILLED"}]}`)
+	receive()
+
+	// 断线重连后通过查询补齐遗漏的成交，之后继续消费推送
+	first.Close()
+	receive()
+	second := <-conns
+	second.incoming <- []byte(`{"topic":"orders","data":[{"id":"1","filled":"5","avg":"104","status":"FILLED"}]}`)
+	receive()
+
+	select {
+	case event, ok := <-ch:
+		if ok {
+			t.Fatalf("unexpected extra event: %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("channel not closed after terminal status")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 2 {
+		t.Errorf("expected 2 REST fetches (initial and resync), got %d", calls)
+	}
+}
+
+func TestTrackOrderFills_FilledBeforeSubscribe(t *testing.T) {
+	m := NewWSManager(func(ctx context.Context) (WSConn, error) {
+		return newMockWSConn(), nil
+	}, testWSProtocol{})
+	defer m.Close()
+
+	subscribe := func(ctx context.Context, resync WSResync[[]*OrderFillSnapshot]) (<-chan []*OrderFillSnapshot, error) {
+		return WatchTopicResync(ctx, m, "orders", func(msg []byte) ([]*OrderFillSnapshot, bool) { return nil, false }, resync)
+	}
+	fetch := func(ctx context.Context) (*OrderFillSnapshot, error) {
+		return &OrderFillSnapshot{OrderID: "1", Filled: decimal.RequireFromString("2"), Average: decimal.RequireFromString("50"), Status: "FILLED"}, nil
+	}
+
+	ch, err := TrackOrderFills(context.Background(), "1", "BTC/USDT", subscribe, fetch, func(s *OrderFillSnapshot) *OrderFillSnapshot { return s })
+	if err != nil {
+		t.Fatalf("TrackOrderFills: %v", err)
+	}
+	event, ok := <-ch
+	if !ok || !event.Final || event.Filled.String() != "2" || event.VWAP.String() != "50" {
+		t.Fatalf("expected a final event from the initial fetch, got %+v", event)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after final event")
+	}
+	// 通道关闭后取消订阅
+	deadline := time.Now().Add(2 * time.Second)
+	for m.Topics() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m.Topics() != 0 {
+		t.Errorf("expected topic to be unsubscribed, got %d topics", m.Topics())
+	}
+}
+
+func TestPollOrderFills(t *testing.T) {
+	snapshots := []*OrderFillSnapshot{
+		{Filled: decimal.Zero, Status: "NEW"},
+		{Filled: decimal.RequireFromString("1"), Average: decimal.RequireFromString("100"), Status: "PARTIALLY_FILLED"},
+		{Filled: decimal.RequireFromString("1"), Average: decimal.RequireFromString("100"), Status: "PARTIALLY_FILLED"}, // 无新成交，不推送
+		{Filled: decimal.RequireFromString("3"), Average: decimal.RequireFromString("102"), Status: "PARTIALLY_FILLED"},
+		{Filled: decimal.RequireFromString("4"), Average: decimal.RequireFromString("103"), Status: "FILLED"},
+	}
+	calls := 0
+	fetch := func(ctx context.Context) (*OrderFillSnapshot, error) {
+		s := snapshots[len(snapshots)-1]
+		if calls < len(snapshots) {
+			s = snapshots[calls]
+		}
+		calls++
+		return s, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := PollOrderFills(ctx, "123", "BTC/USDT", 10*time.Millisecond, fetch)
+	if err != nil {
+		t.Fatalf("PollOrderFills: %v", err)
+	}
+
+	expected := []struct {
+		fillAmount, fillPrice, filled, vwap string
+		final                               bool
+	}{
+		{"1", "100", "1", "100", false},
+		{"2", "103", "3", "102", false}, // (306 - 100) / 2
+		{"1", "106", "4", "103", true},  // (412 - 306) / 1
+	}
+
+	var got int
+	for event := range ch {
+		if got >= len(expected) {
+			t.Fatalf("unexpected extra event: %+v", event)
+		}
+		want := expected[got]
+		if event.FillAmount.String() != want.fillAmount || event.FillPrice.String() != want.fillPrice {
+			t.Errorf("event %d: expected fill %s@%s, got %s@%s", got, want.fillAmount, want.fillPrice, event.FillAmount, event.FillPrice)
+		}
+		if event.Filled.String() != want.filled || event.VWAP.String() != want.vwap {
+			t.Errorf("event %d: expected filled %s vwap %s, got %s vwap %s", got, want.filled, want.vwap, event.Filled, event.VWAP)
+		}
+		if event.Final != want.final {
+			t.Errorf("event %d: expected final %v, got %v", got, want.final, event.Final)
+		}
+		got++
+	}
+
+	if got != len(expected) {
+		t.Fatalf("expected %d events before channel close, got %d", len(expected), got)
+	}
+	if ctx.Err() != nil {
+		t.Fatal("channel closed by timeout instead of terminal status")
+	}
+}
+
+func TestPollOrderFills_CanceledWithoutFill(t *testing.T) {
+	fetch := func(ctx context.Context) (*OrderFillSnapshot, error) {
+		return &OrderFillSnapshot{Status: "canceled"}, nil
+	}
+
+	ch, err := PollOrderFills(context.Background(), "1", "BTC/USDT", time.Millisecond, fetch)
+	if err != nil {
+		t.Fatalf("PollOrderFills: %v", err)
+	}
+
+	event, ok := <-ch
+	if !ok || !event.Final || !event.FillAmount.IsZero() {
+		t.Fatalf("expected a final event without fill, got %+v", event)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after final event")
+	}
+}
+
+func TestIsTerminalOrderStatus(t *testing.T) {
+	for _, status := range []string{"FILLED", "Cancelled", "canceled", "finished", "PartiallyFilledCanceled", "mmp_canceled", "EXPIRED"} {
+		if !IsTerminalOrderStatus(status) {
+			t.Errorf("expected %s to be terminal", status)
+		}
+	}
+	for _, status := range []string{"NEW", "PARTIALLY_FILLED", "PartiallyFilled", "live", "open"} {
+		if IsTerminalOrderStatus(status) {
+			t.Errorf("expected %s to be non-terminal", status)
+		}
+	}
+}
+
+func TestSpotOrderFillSnapshot(t *testing.T) {
+	order := &model.SpotOrder{
+		Filled:  types.ExDecimal{Decimal: decimal.RequireFromString("2")},
+		Cost:    types.ExDecimal{Decimal: decimal.RequireFromString("201")},
+		Average: types.ExDecimal{Decimal: decimal.RequireFromString("99")}, // 订单价格，非成交均价
+		Status:  model.OrderStatusOpen,
+	}
+
+	snapshot := SpotOrderFillSnapshot(order)
+	if snapshot.Average.String() != "100.5" {
+		t.Errorf("expected average 100.5 from cost, got %s", snapshot.Average)
+	}
+	if snapshot.Status != "open" {
+		t.Errorf("expected status open, got %s", snapshot.Status)
+	}
+}
+
+func TestValidateEditOrder(t *testing.T) {
+	tests := []struct {
+		amount, price string
+		wantErr       bool
+	}{
+		{"1", "100", false},
+		{"1", "", false},
+		{"", "100", false},
+		{"", "", true},
+		{"0", "", true},
+		{"", "-1", true},
+		{"abc", "100", true},
+	}
+	for _, tt := range tests {
+		err := ValidateEditOrder(tt.amount, tt.price)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateEditOrder(%q, %q) error = %v, wantErr %v", tt.amount, tt.price, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseStopOrder(t *testing.T) {
+	parse := func(opts ...option.ArgsOption) (decimal.Decimal, option.TriggerType, bool, error) {
+		argsOpts := &option.ExchangeArgsOptions{}
+		for _, opt := range opts {
+			opt(argsOpts)
+		}
+		return ParseStopOrder(argsOpts)
+	}
+
+	if _, _, ok, err := parse(); ok || err != nil {
+		t.Errorf("no stop price: ok = %v, err = %v, want plain order", ok, err)
+	}
+	if _, _, _, err := parse(option.WithTriggerType(option.TakeProfit)); err == nil {
+		t.Error("trigger type without stop price: want error")
+	}
+	if _, _, _, err := parse(option.WithStopPrice("-1")); err == nil {
+		t.Error("negative stop price: want error")
+	}
+	if _, _, _, err := parse(option.WithStopPrice("100"), option.WithTriggerType("TRAILING")); err == nil {
+		t.Error("unknown trigger type: want error")
+	}
+
+	price, triggerType, ok, err := parse(option.WithStopPrice("100.5"))
+	if err != nil || !ok || price.String() != "100.5" || triggerType != option.StopLoss {
+		t.Errorf("got %s %s %v %v, want 100.5 STOP_LOSS", price, triggerType, ok, err)
+	}
+	if _, triggerType, _, _ = parse(option.WithStopPrice("100"), option.WithTriggerType(option.TakeProfit)); triggerType != option.TakeProfit {
+		t.Errorf("trigger type = %s, want TAKE_PROFIT", triggerType)
+	}
+}
+
+func TestParsePostOnly_ReduceOnly(t *testing.T) {
+	args := func(opts ...option.ArgsOption) *option.ExchangeArgsOptions {
+		argsOpts := &option.ExchangeArgsOptions{}
+		for _, opt := range opts {
+			opt(argsOpts)
+		}
+		return argsOpts
+	}
+
+	if postOnly, err := ParsePostOnly(args(), true); postOnly || err != nil {
+		t.Errorf("unset: postOnly = %v, err = %v", postOnly, err)
+	}
+	if postOnly, err := ParsePostOnly(args(option.WithPostOnly(true), option.WithTimeInForce(option.GTC)), true); !postOnly || err != nil {
+		t.Errorf("limit GTC: postOnly = %v, err = %v, want true", postOnly, err)
+	}
+	if _, err := ParsePostOnly(args(option.WithPostOnly(true)), false); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("market order: err = %v, want ErrInvalidOrder", err)
+	}
+	if _, err := ParsePostOnly(args(option.WithPostOnly(true), option.WithTimeInForce(option.IOC)), true); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("IOC: err = %v, want ErrInvalidOrder", err)
+	}
+	if postOnly, err := ParsePostOnly(args(option.WithPostOnly(false)), false); postOnly || err != nil {
+		t.Errorf("disabled on market order: postOnly = %v, err = %v", postOnly, err)
+	}
+
+	// 未设置时由下单方向推断，设置后以选项为准
+	if ReduceOnly(option.OpenLong, args()) || !ReduceOnly(option.CloseShort, args()) {
+		t.Error("default reduce-only should follow order side")
+	}
+	if !ReduceOnly(option.OpenLong, args(option.WithReduceOnly(true))) || ReduceOnly(option.CloseLong, args(option.WithReduceOnly(false))) {
+		t.Error("WithReduceOnly should override order side")
+	}
+}
+
+func TestCheckTimeInForce(t *testing.T) {
+	tests := []struct {
+		tif       option.TimeInForce
+		isLimit   bool
+		marketFOK bool
+		wantErr   bool
+	}{
+		{option.GTC, true, false, false},
+		{option.IOC, true, false, false},
+		{option.FOK, true, false, false},
+		{option.GTX, true, false, false},
+		{option.GTC, false, false, false},
+		{option.IOC, false, false, false},
+		{option.FOK, false, false, true},
+		{option.FOK, false, true, false},
+		{option.GTX, false, true, true},
+		{"DAY", true, false, true},
+	}
+	for _, tt := range tests {
+		err := CheckTimeInForce(&option.ExchangeArgsOptions{TimeInForce: &tt.tif}, tt.isLimit, tt.marketFOK)
+		if tt.wantErr != (err != nil) || (err != nil && !errors.Is(err, ErrInvalidOrder)) {
+			t.Errorf("CheckTimeInForce(%s, limit=%v, marketFOK=%v) = %v, wantErr %v", tt.tif, tt.isLimit, tt.marketFOK, err, tt.wantErr)
+		}
+	}
+	if err := CheckTimeInForce(&option.ExchangeArgsOptions{}, false, false); err != nil {
+		t.Errorf("unset: %v", err)
+	}
+
+	// GTX 等同于只做 Maker
+	gtx := option.GTX
+	if postOnly, err := ParsePostOnly(&option.ExchangeArgsOptions{TimeInForce: &gtx}, true); !postOnly || err != nil {
+		t.Errorf("GTX: postOnly = %v, err = %v, want true", postOnly, err)
+	}
+}
+
+func TestTriggerType_TriggersOnRise(t *testing.T) {
+	tests := []struct {
+		triggerType option.TriggerType
+		side        string
+		want        bool
+	}{
+		{option.StopLoss, "BUY", true},
+		{option.StopLoss, "SELL", false},
+		{option.TakeProfit, "BUY", false},
+		{option.TakeProfit, "sell", true},
+	}
+	for _, tt := range tests {
+		if got := tt.triggerType.TriggersOnRise(tt.side); got != tt.want {
+			t.Errorf("%s.TriggersOnRise(%s) = %v, want %v", tt.triggerType, tt.side, got, tt.want)
+		}
+	}
+}
+
+func TestValidateOCOOrder(t *testing.T) {
+	tests := []struct {
+		side                             option.SpotOrderSide
+		price, stopPrice, stopLimitPrice string
+		ok                               bool
+	}{
+		{option.Sell, "55000", "48000", "47900", true},
+		{option.Sell, "55000", "48000", "", true},
+		{option.Buy, "45000", "52000", "52100", true},
+		{option.Sell, "45000", "48000", "", false},
+		{option.Buy, "55000", "52000", "", false},
+		{option.Sell, "55000", "55000", "", false},
+		{option.Sell, "", "48000", "", false},
+		{option.Sell, "55000", "-1", "", false},
+		{option.Sell, "55000", "48000", "abc", false},
+	}
+	for _, tt := range tests {
+		err := ValidateOCOOrder(tt.side, tt.price, tt.stopPrice, tt.stopLimitPrice)
+		if tt.ok && err != nil {
+			t.Errorf("%s %s/%s/%s: unexpected error %v", tt.side, tt.price, tt.stopPrice, tt.stopLimitPrice, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("%s %s/%s/%s: err = %v, want ErrInvalidOrder", tt.side, tt.price, tt.stopPrice, tt.stopLimitPrice, err)
+		}
+	}
+}
+
+func TestParseTrailingStop(t *testing.T) {
+	minRate, maxRate := decimal.NewFromFloat(0.1), decimal.NewFromInt(5)
+	parse := func(opts ...option.ArgsOption) (decimal.Decimal, decimal.Decimal, bool, error) {
+		argsOpts := &option.ExchangeArgsOptions{}
+		for _, opt := range opts {
+			opt(argsOpts)
+		}
+		return ParseTrailingStop(argsOpts, minRate, maxRate)
+	}
+
+	if _, _, ok, err := parse(); ok || err != nil {
+		t.Errorf("no trailing stop: ok/err = %v/%v, want false/nil", ok, err)
+	}
+	rate, activation, ok, err := parse(option.WithTrailingStop("1.5", "50000"))
+	if err != nil || !ok || rate.String() != "1.5" || activation.String() != "50000" {
+		t.Errorf("ParseTrailingStop = %s/%s/%v/%v, want 1.5/50000/true/nil", rate, activation, ok, err)
+	}
+	invalid := [][]option.ArgsOption{
+		{option.WithTrailingStop("0.05", "")},
+		{option.WithTrailingStop("5.1", "")},
+		{option.WithTrailingStop("abc", "")},
+		{option.WithTrailingStop("1", "-1")},
+		{option.WithTrailingStop("1", ""), option.WithStopPrice("48000")},
+	}
+	for _, opts := range invalid {
+		if _, _, _, err := parse(opts...); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("err = %v, want ErrInvalidOrder", err)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// ClampOrderBookLimit 将深度档位数限制在 [1, max] 范围内，limit <= 0 时返回 0（使用交易所默认值）
+func ClampOrderBookLimit(limit, max int) int {
+	if limit <= 0 {
+		return 0
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
+
+// RoundOrderBookLimit 将深度档位数调整为交易所支持的档位（allowed 升序）
+// 取不小于 limit 的最小档位，超过最大档位时取最大档位；limit <= 0 时返回 0（使用交易所默认值）
+func RoundOrderBookLimit(limit int, allowed []int) int {
+	if limit <= 0 || len(allowed) == 0 {
+		return 0
+	}
+	for _, v := range allowed {
+		if v >= limit {
+			return v
+		}
+	}
+	return allowed[len(allowed)-1]
+}
+
+// ParseOrderBookLevels 将 [价格, 数量, ...] 格式的深度档位转换为订单簿条目
+// limit > 0 时只保留前 limit 档（交易所按档位向上取整返回时截断多余的档位）
+func ParseOrderBookLevels(levels [][]types.ExDecimal, limit int) []model.OrderBookEntry {
+	if limit > 0 && len(levels) > limit {
+		levels = levels[:limit]
+	}
+	entries := make([]model.OrderBookEntry, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		entries = append(entries, model.OrderBookEntry{
+			Price:  level[0].Decimal,
+			Amount: level[1].Decimal,
+		})
+	}
+	return entries
+}
+
+// ErrOrderBookGap 增量推送序号不连续，需要重新同步订单簿
+var ErrOrderBookGap = errors.New("order book sequence gap")
+
+// OrderBookSequence 增量推送的序号校验方式
+type OrderBookSequence int
+
+const (
+	// OrderBookSequenceNone 不校验序号，依赖交易所按顺序推送（Bybit）
+	OrderBookSequenceNone OrderBookSequence = iota
+	// OrderBookSequenceRange 增量的 FirstUpdateID 须不大于本地序号 +1（Binance 现货、Gate）
+	OrderBookSequenceRange
+	// OrderBookSequencePrev 增量的 PrevUpdateID 须等于本地序号（Binance 合约、OKX）
+	OrderBookSequencePrev
+)
+
+// OrderBookDelta 订单簿推送（全量或增量），数量为 0 的档位表示删除该价格
+type OrderBookDelta struct {
+	// Snapshot 是否为全量推送，全量推送替换本地订单簿
+	Snapshot bool
+	// FirstUpdateID 本次推送的第一个更新ID（Binance/Gate U）
+	FirstUpdateID int64
+	// FinalUpdateID 本次推送的最后一个更新ID（Binance/Gate u、Bybit u、OKX seqId）
+	FinalUpdateID int64
+	// PrevUpdateID 上一次推送的最后一个更新ID（Binance 合约 pu、OKX prevSeqId）
+	PrevUpdateID int64
+	// Bids 买单变化
+	Bids []model.OrderBookEntry
+	// Asks 卖单变化
+	Asks []model.OrderBookEntry
+	// Timestamp 推送时间
+	Timestamp types.ExTimestamp
+}
+
+// LocalOrderBook 本地维护的订单簿，由快照初始化并按序应用增量推送
+type LocalOrderBook struct {
+	symbol    string
+	sequence  OrderBookSequence
+	bids      map[string]model.OrderBookEntry // 价格字符串 -> 档位
+	asks      map[string]model.OrderBookEntry
+	nonce     int64
+	timestamp types.ExTimestamp
+	ready     bool // 已加载快照
+	fresh     bool // 刚加载 REST 快照，尚未应用增量
+}
+
+// NewLocalOrderBook 创建本地订单簿，加载快照前不接受增量推送
+func NewLocalOrderBook(symbol string, sequence OrderBookSequence) *LocalOrderBook {
+	return &LocalOrderBook{symbol: symbol, sequence: sequence}
+}
+
+// Ready 是否已加载快照
+func (b *LocalOrderBook) Ready() bool {
+	return b.ready
+}
+
+// Invalidate 丢弃本地数据，等待重新加载快照
+func (b *LocalOrderBook) Invalidate() {
+	b.ready = false
+	b.bids, b.asks = nil, nil
+}
+
+// Reset 使用 REST 快照初始化本地订单簿，快照的 Nonce 为其包含的最后一个更新ID
+// 之后第一条增量须覆盖 Nonce+1：早于快照的增量被丢弃，晚于快照的增量视为序号缺口
+func (b *LocalOrderBook) Reset(snapshot *model.OrderBook) {
+	b.load(snapshot.Bids, snapshot.Asks, snapshot.Nonce, snapshot.Timestamp)
+	b.fresh = true
+}
+
+// Apply 应用一条推送，返回是否更新了本地订单簿
+// 未加载快照或增量早于本地序号时忽略该推送；序号不连续时返回 ErrOrderBookGap，需要重新加载快照
+func (b *LocalOrderBook) Apply(delta *OrderBookDelta) (bool, error) {
+	if delta.Snapshot {
+		b.load(delta.Bids, delta.Asks, delta.FinalUpdateID, delta.Timestamp)
+		return true, nil
+	}
+	if !b.ready {
+		return false, nil
+	}
+
+	switch {
+	case b.fresh || b.sequence == OrderBookSequenceRange:
+		// 快照已包含的增量直接丢弃；快照之后的第一条增量须从 Nonce+1 或更早开始
+		if delta.FinalUpdateID < b.nonce || (!b.fresh && delta.FinalUpdateID == b.nonce) {
+			return false, nil
+		}
+		if delta.FirstUpdateID > b.nonce+1 {
+			return false, fmt.Errorf("%w: expected update %d, got %d-%d", ErrOrderBookGap, b.nonce+1, delta.FirstUpdateID, delta.FinalUpdateID)
+		}
+	case b.sequence == OrderBookSequencePrev:
+		if delta.PrevUpdateID != b.nonce {
+			return false, fmt.Errorf("%w: expected previous update %d, got %d", ErrOrderBookGap, b.nonce, delta.PrevUpdateID)
+		}
+	}
+
+	applyOrderBookLevels(b.bids, delta.Bids)
+	applyOrderBookLevels(b.asks, delta.Asks)
+	if delta.FinalUpdateID != 0 {
+		b.nonce = delta.FinalUpdateID
+	}
+	b.timestamp = delta.Timestamp
+	b.fresh = false
+	return true, nil
+}
+
+// Top 返回前 depth 档的订单簿（depth <= 0 时返回全部档位），买单价格从高到低，卖单价格从低到高
+func (b *LocalOrderBook) Top(depth int) *model.OrderBook {
+	bids := sortedOrderBookLevels(b.bids, func(a, c decimal.Decimal) bool { return a.GreaterThan(c) }, depth)
+	asks := sortedOrderBookLevels(b.asks, func(a, c decimal.Decimal) bool { return a.LessThan(c) }, depth)
+	return &model.OrderBook{
+		Symbol:    b.symbol,
+		Bids:      bids,
+		Asks:      asks,
+		Nonce:     b.nonce,
+		Timestamp: b.timestamp,
+	}
+}
+
+// load 用全量档位替换本地订单簿
+func (b *LocalOrderBook) load(bids, asks []model.OrderBookEntry, nonce int64, timestamp types.ExTimestamp) {
+	b.bids = make(map[string]model.OrderBookEntry, len(bids))
+	b.asks = make(map[string]model.OrderBookEntry, len(asks))
+	applyOrderBookLevels(b.bids, bids)
+	applyOrderBookLevels(b.asks, asks)
+	b.nonce = nonce
+	b.timestamp = timestamp
+	b.ready = true
+	b.fresh = false
+}
+
+// applyOrderBookLevels 按价格更新档位，数量为 0 时删除该价格
+func applyOrderBookLevels(book map[string]model.OrderBookEntry, levels []model.OrderBookEntry) {
+	for _, level := range levels {
+		key := level.Price.String()
+		if level.Amount.IsZero() {
+			delete(book, key)
+		} else {
+			book[key] = level
+		}
+	}
+}
+
+// sortedOrderBookLevels 按价格排序档位，depth > 0 时只保留前 depth 档
+func sortedOrderBookLevels(book map[string]model.OrderBookEntry, less func(a, b decimal.Decimal) bool, depth int) []model.OrderBookEntry {
+	entries := make([]model.OrderBookEntry, 0, len(book))
+	for _, level := range book {
+		entries = append(entries, level)
+	}
+	sort.Slice(entries, func(i, j int) bool { return less(entries[i].Price, entries[j].Price) })
+	if depth > 0 && len(entries) > depth {
+		entries = entries[:depth]
+	}
+	return entries
+}
+
+// OrderBookWatcher WatchOrderBook 的订阅配置
+type OrderBookWatcher struct {
+	// Manager WebSocket 订阅管理器
+	Manager *WSManager
+	// Topic 深度推送主题
+	Topic string
+	// Symbol 标准化交易对
+	Symbol string
+	// Depth 推送的档位数，<= 0 时推送本地维护的全部档位
+	Depth int
+	// Sequence 增量推送的序号校验方式
+	Sequence OrderBookSequence
+	// Parse 解析深度推送
+	Parse WSParser[*OrderBookDelta]
+	// FetchSnapshot 获取 REST 快照；为 nil 时全量数据由推送提供，每次订阅（包括序号缺口后的重新订阅）
+	// 都通过 WSManager.SubscribeRefresh 重新发送订阅消息，主题已被其他订阅者持有时也能收到全量推送
+	FetchSnapshot func(ctx context.Context) (*model.OrderBook, error)
+}
+
+// WatchOrderBook 订阅深度推送并维护本地订单簿，每次应用推送后发送前 Depth 档的完整订单簿
+// 订阅后再获取 REST 快照，获取快照期间的推送由订阅缓冲暂存，之后按序号丢弃早于快照的增量；
+// 序号不连续时重新同步。首次订阅或获取快照失败时直接返回错误；之后连接断开时按指数退避重连并重新同步，
+// 通道在 ctx 取消后关闭并取消订阅。
+func WatchOrderBook(ctx context.Context, w OrderBookWatcher) (<-chan *model.OrderBook, error) {
+	book := NewLocalOrderBook(w.Symbol, w.Sequence)
+
+	sub, err := w.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if w.FetchSnapshot != nil {
+		snapshot, err := w.FetchSnapshot(ctx)
+		if err != nil {
+			_ = sub.Unsubscribe()
+			return nil, fmt.Errorf("fetch order book snapshot: %w", err)
+		}
+		book.Reset(snapshot)
+	}
+
+	ch := make(chan *model.OrderBook)
+	go func() {
+		defer close(ch)
+
+		delay := wsReconnectMinDelay
+		for {
+			resync, ok := forwardOrderBook(ctx, sub, w, book, ch, &delay)
+			if !ok {
+				_ = sub.Unsubscribe()
+				return
+			}
+
+			book.Invalidate()
+			if resync && w.FetchSnapshot != nil {
+				// 订阅仍然有效，重新获取快照即可；失败时重新订阅
+				if snapshot, err := w.FetchSnapshot(ctx); err == nil {
+					book.Reset(snapshot)
+					continue
+				}
+			}
+			if resync {
+				// 重新订阅以获取新的全量推送
+				_ = sub.Unsubscribe()
+			}
+
+			// 按指数退避重新订阅并获取快照
+			for {
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				if delay *= 2; delay > wsReconnectMaxDelay {
+					delay = wsReconnectMaxDelay
+				}
+				if sub, err = w.subscribe(ctx); err != nil {
+					if errors.Is(err, ErrWSClosed) {
+						return
+					}
+					continue
+				}
+				if w.FetchSnapshot == nil {
+					break
+				}
+				snapshot, err := w.FetchSnapshot(ctx)
+				if err == nil {
+					book.Reset(snapshot)
+					break
+				}
+				_ = sub.Unsubscribe()
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// subscribe 订阅深度主题，全量数据由推送提供时每次都重新发送订阅消息以触发全量推送
+func (w OrderBookWatcher) subscribe(ctx context.Context) (*WSSubscription, error) {
+	if w.FetchSnapshot == nil {
+		return w.Manager.SubscribeRefresh(ctx, w.Topic)
+	}
+	return w.Manager.Subscribe(ctx, w.Topic)
+}
+
+// forwardOrderBook 应用推送并发送订单簿，直到需要重新同步（resync 为 true）、连接断开或 ctx 取消（ok 为 false）
+func forwardOrderBook(ctx context.Context, sub *WSSubscription, w OrderBookWatcher, book *LocalOrderBook, ch chan<- *model.OrderBook, delay *time.Duration) (resync bool, ok bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, false
+		case msg, open := <-sub.C:
+			if !open {
+				return false, true
+			}
+			*delay = wsReconnectMinDelay
+
+			delta, parsed := w.Parse(msg)
+			if !parsed {
+				continue
+			}
+			applied, err := book.Apply(delta)
+			if err != nil {
+				return true, true
+			}
+			if !applied {
+				continue
+			}
+			select {
+			case ch <- book.Top(w.Depth):
+			case <-ctx.Done():
+				return false, false
+			}
+		}
+	}
+}
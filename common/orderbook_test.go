@@ -0,0 +1,412 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrderBookLimit(t *testing.T) {
+	for _, tt := range []struct {
+		limit, max, want int
+	}{
+		{0, 100, 0},
+		{-1, 100, 0},
+		{50, 100, 50},
+		{500, 100, 100},
+	} {
+		if got := ClampOrderBookLimit(tt.limit, tt.max); got != tt.want {
+			t.Errorf("ClampOrderBookLimit(%d, %d) = %d, want %d", tt.limit, tt.max, got, tt.want)
+		}
+	}
+
+	allowed := []int{5, 10, 20, 50, 100, 500, 1000}
+	for _, tt := range []struct {
+		limit, want int
+	}{
+		{0, 0},
+		{1, 5},
+		{5, 5},
+		{30, 50},
+		{5000, 1000},
+	} {
+		if got := RoundOrderBookLimit(tt.limit, allowed); got != tt.want {
+			t.Errorf("RoundOrderBookLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrderBookLevels(t *testing.T) {
+	d := func(s string) types.ExDecimal {
+		return types.ExDecimal{Decimal: decimal.RequireFromString(s)}
+	}
+	levels := [][]types.ExDecimal{
+		{d("100"), d("1"), d("0"), d("3")}, // OKX 格式带额外字段
+		{d("99"), d("2")},
+		{d("98")}, // 格式错误的档位跳过
+		{d("97"), d("4")},
+	}
+
+	entries := ParseOrderBookLevels(levels, 0)
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].Price.String() != "100" || entries[0].Amount.String() != "1" {
+		t.Errorf("entry[0] = %s@%s, want 1@100", entries[0].Amount, entries[0].Price)
+	}
+
+	if entries := ParseOrderBookLevels(levels, 2); len(entries) != 2 {
+		t.Errorf("got %d entries with limit 2, want 2", len(entries))
+	}
+}
+
+// levels 将 "价格:数量" 列表转换为订单簿条目
+func levels(specs ...string) []model.OrderBookEntry {
+	entries := make([]model.OrderBookEntry, 0, len(specs))
+	for _, spec := range specs {
+		price, amount, _ := strings.Cut(spec, ":")
+		entries = append(entries, model.OrderBookEntry{
+			Price:  decimal.RequireFromString(price),
+			Amount: decimal.RequireFromString(amount),
+		})
+	}
+	return entries
+}
+
+// bookString 将订单簿格式化为 "bids|asks" 便于比较
+func bookString(book *model.OrderBook) string {
+	format := func(entries []model.OrderBookEntry) string {
+		parts := make([]string, 0, len(entries))
+		for _, e := range entries {
+			parts = append(parts, e.Price.String()+":"+e.Amount.String())
+		}
+		return strings.Join(parts, ",")
+	}
+	return format(book.Bids) + "|" + format(book.Asks)
+}
+
+func TestLocalOrderBook_RangeSequence(t *testing.T) {
+	// 录制的 Binance 现货快照（lastUpdateId=100）和之后的增量推送
+	book := NewLocalOrderBook("BTC/USDT", OrderBookSequenceRange)
+	if applied, _ := book.Apply(&OrderBookDelta{FirstUpdateID: 99, FinalUpdateID: 101, Bids: levels("100:1")}); applied {
+		t.Fatal("expected delta before snapshot to be ignored")
+	}
+	book.Reset(&model.OrderBook{
+		Nonce: 100,
+		Bids:  levels("100:1", "99:2", "98:3"),
+		Asks:  levels("101:1", "102:2", "103:3"),
+	})
+
+	steps := []struct {
+		delta   OrderBookDelta
+		applied bool
+		want    string
+	}{
+		// 快照已包含的增量丢弃
+		{OrderBookDelta{FirstUpdateID: 95, FinalUpdateID: 99, Bids: levels("100:9")}, false, ""},
+		// 第一条增量跨越 lastUpdateId+1
+		{OrderBookDelta{FirstUpdateID: 99, FinalUpdateID: 102, Bids: levels("100:1.5", "98:0"), Asks: levels("101:0")},
+			true, "100:1.5,99:2|102:2,103:3"},
+		// 连续增量：新增档位并保持排序
+		{OrderBookDelta{FirstUpdateID: 103, FinalUpdateID: 105, Bids: levels("100.5:4"), Asks: levels("101.5:1")},
+			true, "100.5:4,100:1.5,99:2|101.5:1,102:2,103:3"},
+		// 重复推送丢弃
+		{OrderBookDelta{FirstUpdateID: 104, FinalUpdateID: 105, Bids: levels("100.5:0")}, false, ""},
+		// 删除不存在的档位不影响订单簿
+		{OrderBookDelta{FirstUpdateID: 106, FinalUpdateID: 106, Asks: levels("200:0")},
+			true, "100.5:4,100:1.5,99:2|101.5:1,102:2,103:3"},
+	}
+	for i, step := range steps {
+		applied, err := book.Apply(&step.delta)
+		if err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if applied != step.applied {
+			t.Fatalf("step %d: applied = %v, want %v", i, applied, step.applied)
+		}
+		if applied {
+			if got := bookString(book.Top(0)); got != step.want {
+				t.Errorf("step %d: book = %s, want %s", i, got, step.want)
+			}
+		}
+	}
+
+	if got := bookString(book.Top(2)); got != "100.5:4,100:1.5|101.5:1,102:2" {
+		t.Errorf("Top(2) = %s", got)
+	}
+	if nonce := book.Top(0).Nonce; nonce != 106 {
+		t.Errorf("Nonce = %d, want 106", nonce)
+	}
+
+	// 缺少 107 的增量
+	if _, err := book.Apply(&OrderBookDelta{FirstUpdateID: 108, FinalUpdateID: 110}); !errors.Is(err, ErrOrderBookGap) {
+		t.Errorf("expected ErrOrderBookGap, got %v", err)
+	}
+}
+
+func TestLocalOrderBook_SnapshotGap(t *testing.T) {
+	book := NewLocalOrderBook("BTC/USDT", OrderBookSequenceRange)
+	book.Reset(&model.OrderBook{Nonce: 100, Bids: levels("100:1"), Asks: levels("101:1")})
+
+	// 快照之后第一条增量从 105 开始，说明中间的推送丢失
+	if _, err := book.Apply(&OrderBookDelta{FirstUpdateID: 105, FinalUpdateID: 110}); !errors.Is(err, ErrOrderBookGap) {
+		t.Errorf("expected ErrOrderBookGap, got %v", err)
+	}
+}
+
+func TestLocalOrderBook_PrevSequence(t *testing.T) {
+	// 录制的 Binance 合约快照（lastUpdateId=1000），增量通过 pu 与上一条衔接
+	book := NewLocalOrderBook("BTC/USDT:USDT", OrderBookSequencePrev)
+	book.Reset(&model.OrderBook{Nonce: 1000, Bids: levels("50000:1"), Asks: levels("50001:1")})
+
+	deltas := []OrderBookDelta{
+		{FirstUpdateID: 990, FinalUpdateID: 998, PrevUpdateID: 989, Bids: levels("50000:5")}, // 早于快照，丢弃
+		{FirstUpdateID: 999, FinalUpdateID: 1003, PrevUpdateID: 998, Bids: levels("50000:2")},
+		{FirstUpdateID: 1010, FinalUpdateID: 1012, PrevUpdateID: 1003, Asks: levels("50001:0", "50002:3")},
+	}
+	for i := range deltas {
+		if _, err := book.Apply(&deltas[i]); err != nil {
+			t.Fatalf("delta %d: %v", i, err)
+		}
+	}
+	if got := bookString(book.Top(0)); got != "50000:2|50002:3" {
+		t.Errorf("book = %s, want 50000:2|50002:3", got)
+	}
+
+	if _, err := book.Apply(&OrderBookDelta{FirstUpdateID: 1020, FinalUpdateID: 1021, PrevUpdateID: 1015}); !errors.Is(err, ErrOrderBookGap) {
+		t.Errorf("expected ErrOrderBookGap, got %v", err)
+	}
+}
+
+func TestLocalOrderBook_PushSnapshot(t *testing.T) {
+	book := NewLocalOrderBook("BTC-USDT", OrderBookSequencePrev)
+	if _, err := book.Apply(&OrderBookDelta{Snapshot: true, FinalUpdateID: 10, Bids: levels("1:1"), Asks: levels("2:1")}); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if _, err := book.Apply(&OrderBookDelta{FinalUpdateID: 11, PrevUpdateID: 10, Bids: levels("1:0", "0.9:2")}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	// 新的全量推送替换本地订单簿
+	if _, err := book.Apply(&OrderBookDelta{Snapshot: true, FinalUpdateID: 20, Bids: levels("1.1:1"), Asks: levels("1.2:1")}); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if got := bookString(book.Top(0)); got != "1.1:1|1.2:1" {
+		t.Errorf("book = %s, want 1.1:1|1.2:1", got)
+	}
+}
+
+// testDepthMessage 测试用的深度推送，形如 {"topic":"depth","U":1,"u":2,"b":[["100","1"]],"a":[]}
+type testDepthMessage struct {
+	U    int64      `json:"U"`
+	Last int64      `json:"u"`
+	Bids [][]string `json:"b"`
+	Asks [][]string `json:"a"`
+}
+
+func parseTestDepth(msg []byte) (*OrderBookDelta, bool) {
+	var m testDepthMessage
+	if err := json.Unmarshal(msg, &m); err != nil || m.Last == 0 {
+		return nil, false
+	}
+	toEntries := func(raw [][]string) []model.OrderBookEntry {
+		specs := make([]string, 0, len(raw))
+		for _, level := range raw {
+			specs = append(specs, level[0]+":"+level[1])
+		}
+		return levels(specs...)
+	}
+	return &OrderBookDelta{FirstUpdateID: m.U, FinalUpdateID: m.Last, Bids: toEntries(m.Bids), Asks: toEntries(m.Asks)}, true
+}
+
+func depthMessage(first, last int64, bids, asks string) []byte {
+	return []byte(fmt.Sprintf(`{"topic":"depth","U":%d,"u":%d,"b":%s,"a":%s}`, first, last, bids, asks))
+}
+
+func TestWatchOrderBook_Resync(t *testing.T) {
+	conn := newMockWSConn()
+	m := NewWSManager(func(ctx context.Context) (WSConn, error) { return conn, nil }, testWSProtocol{})
+	defer m.Close()
+
+	// 订阅后的推送先于快照到达，由订阅缓冲暂存
+	conn.incoming <- depthMessage(98, 100, `[["100","9"]]`, `[]`)
+	conn.incoming <- depthMessage(101, 102, `[["100","2"]]`, `[]`)
+
+	var fetches atomic.Int32
+	snapshots := []*model.OrderBook{
+		{Nonce: 100, Bids: levels("100:1", "99:1"), Asks: levels("101:1")},
+		{Nonce: 200, Bids: levels("100:5"), Asks: levels("101:5")},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ch, err := WatchOrderBook(ctx, OrderBookWatcher{
+		Manager:  m,
+		Topic:    "depth",
+		Symbol:   "BTC/USDT",
+		Depth:    1,
+		Sequence: OrderBookSequenceRange,
+		Parse:    parseTestDepth,
+		FetchSnapshot: func(ctx context.Context) (*model.OrderBook, error) {
+			return snapshots[fetches.Add(1)-1], nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WatchOrderBook: %v", err)
+	}
+
+	receive := func() string {
+		t.Helper()
+		select {
+		case book, ok := <-ch:
+			if !ok {
+				t.Fatal("channel closed")
+			}
+			if book.Symbol != "BTC/USDT" {
+				t.Errorf("Symbol = %s", book.Symbol)
+			}
+			return bookString(book)
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for order book")
+		}
+		return ""
+	}
+
+	// 98-100 与快照重叠（u >= lastUpdateId），直接应用；101-102 连续
+	if got := receive(); got != "100:9|101:1" {
+		t.Errorf("book = %s, want 100:9|101:1", got)
+	}
+	if got := receive(); got != "100:2|101:1" {
+		t.Errorf("book = %s, want 100:2|101:1", got)
+	}
+
+	// 缺少 103 的增量，重新获取快照
+	conn.incoming <- depthMessage(104, 105, `[["100","3"]]`, `[]`)
+	conn.incoming <- depthMessage(199, 201, `[]`, `[["101","4"]]`)
+	if got := receive(); got != "100:5|101:4" {
+		t.Errorf("book after resync = %s, want 100:5|101:4", got)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected 2 snapshot fetches, got %d", n)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+// pushSnapshotConn 模拟全量数据只在订阅后推送一次的交易所（Bybit、OKX）：每收到一条订阅消息推送一次新的全量深度
+type pushSnapshotConn struct {
+	*mockWSConn
+	snapshots atomic.Int64
+}
+
+func (c *pushSnapshotConn) WriteMessage(data []byte) error {
+	c.mockWSConn.WriteMessage(data)
+	if strings.Contains(string(data), `"op":"subscribe"`) {
+		n := c.snapshots.Add(1)
+		c.incoming <- []byte(fmt.Sprintf(`{"topic":"books","snapshot":true,"seq":%d,"b":[["100","%d"]],"a":[["101","1"]]}`, n*100, n))
+	}
+	return nil
+}
+
+// parsePushDepth 解析 {"topic":"books","snapshot":...,"seq":...,"prev":...,"b":[...],"a":[...]} 格式的深度推送
+func parsePushDepth(msg []byte) (*OrderBookDelta, bool) {
+	var m struct {
+		Snapshot bool       `json:"snapshot"`
+		Seq      int64      `json:"seq"`
+		Prev     int64      `json:"prev"`
+		Bids     [][]string `json:"b"`
+		Asks     [][]string `json:"a"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return nil, false
+	}
+	toEntries := func(raw [][]string) []model.OrderBookEntry {
+		specs := make([]string, 0, len(raw))
+		for _, level := range raw {
+			specs = append(specs, level[0]+":"+level[1])
+		}
+		return levels(specs...)
+	}
+	return &OrderBookDelta{Snapshot: m.Snapshot, FinalUpdateID: m.Seq, PrevUpdateID: m.Prev, Bids: toEntries(m.Bids), Asks: toEntries(m.Asks)}, true
+}
+
+func TestWatchOrderBook_SharedTopicPushSnapshot(t *testing.T) {
+	conn := &pushSnapshotConn{mockWSConn: newMockWSConn()}
+	m := NewWSManager(func(ctx context.Context) (WSConn, error) { return conn, nil }, testWSProtocol{})
+	defer m.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	watch := func() <-chan *model.OrderBook {
+		t.Helper()
+		ch, err := WatchOrderBook(ctx, OrderBookWatcher{
+			Manager:  m,
+			Topic:    "books",
+			Symbol:   "BTC/USDT",
+			Sequence: OrderBookSequencePrev,
+			Parse:    parsePushDepth,
+		})
+		if err != nil {
+			t.Fatalf("WatchOrderBook: %v", err)
+		}
+		return ch
+	}
+	// receiveUntil 读取订单簿直到出现 want，返回读取到的最后一个订单簿
+	receiveUntil := func(name string, ch <-chan *model.OrderBook, want func(string) bool) string {
+		t.Helper()
+		for {
+			select {
+			case book, ok := <-ch:
+				if !ok {
+					t.Fatalf("%s: channel closed", name)
+				}
+				if got := bookString(book); want(got) {
+					return got
+				}
+			case <-ctx.Done():
+				t.Fatalf("%s: timeout waiting for order book", name)
+			}
+		}
+	}
+	is := func(s string) func(string) bool { return func(got string) bool { return got == s } }
+
+	first := watch()
+	receiveUntil("first", first, is("100:1|101:1"))
+
+	// 同一主题的第二个订阅者重新发送订阅消息以获取全量推送，第一个订阅者同样收到
+	second := watch()
+	receiveUntil("second", second, is("100:2|101:1"))
+	receiveUntil("first", first, is("100:2|101:1"))
+	if frames := conn.frames(); len(frames) != 3 || !strings.Contains(frames[1], `"unsubscribe"`) || !strings.Contains(frames[2], `"subscribe"`) {
+		t.Errorf("frames = %v, want subscribe, unsubscribe, subscribe", frames)
+	}
+
+	conn.incoming <- []byte(`{"topic":"books","seq":201,"prev":200,"b":[["99","1"]],"a":[]}`)
+	receiveUntil("first", first, is("100:2,99:1|101:1"))
+	receiveUntil("second", second, is("100:2,99:1|101:1"))
+
+	// 序号缺口：两个订阅者都重新订阅并重新收到全量推送
+	conn.incoming <- []byte(`{"topic":"books","seq":300,"prev":250,"b":[["98","1"]],"a":[]}`)
+	resynced := func(got string) bool {
+		var n int
+		_, err := fmt.Sscanf(got, "100:%d|101:1", &n)
+		return err == nil && n >= 3
+	}
+	receiveUntil("first", first, resynced)
+	receiveUntil("second", second, resynced)
+
+	cancel()
+	for range first {
+	}
+	for range second {
+	}
+}
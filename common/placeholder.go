@@ -0,0 +1,205 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// UnsupportedPerp 永续合约占位实现：交易所未接入永续合约时由 Perp() 返回，所有操作返回包装 ErrNotSupported 的错误
+// 实现 exchange.Placeholder，调用方可通过类型断言识别
+type UnsupportedPerp struct {
+	exchange string
+}
+
+// NewUnsupportedPerp 创建 exchange 交易所的永续合约占位实现
+func NewUnsupportedPerp(exchange string) *UnsupportedPerp {
+	return &UnsupportedPerp{exchange: exchange}
+}
+
+// NotSupported 实现 exchange.Placeholder，返回说明永续合约未接入的错误
+func (p *UnsupportedPerp) NotSupported() error {
+	return p.notSupported("perp")
+}
+
+// notSupported 返回 operation 未接入的错误
+func (p *UnsupportedPerp) notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for %s", operation, ErrNotSupported, p.exchange)
+}
+
+// ========== 市场数据 ==========
+
+func (p *UnsupportedPerp) LoadMarkets(ctx context.Context, reload bool) error {
+	return p.notSupported("load perp markets")
+}
+
+func (p *UnsupportedPerp) FetchMarkets(ctx context.Context, opts ...option.ArgsOption) (model.Markets, error) {
+	return nil, p.notSupported("fetch perp markets")
+}
+
+func (p *UnsupportedPerp) GetMarket(symbol string) (*model.Market, error) {
+	return nil, p.notSupported("get perp market")
+}
+
+func (p *UnsupportedPerp) GetMarketByID(id string) (*model.Market, error) {
+	return nil, p.notSupported("get perp market")
+}
+
+func (p *UnsupportedPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	return "", p.notSupported("amount to precision")
+}
+
+func (p *UnsupportedPerp) PriceToPrecision(symbol, price string) (string, error) {
+	return "", p.notSupported("price to precision")
+}
+
+func (p *UnsupportedPerp) AmountToContracts(symbol, amount string) (string, error) {
+	return "", p.notSupported("amount to contracts")
+}
+
+func (p *UnsupportedPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	return "", p.notSupported("contracts to amount")
+}
+
+func (p *UnsupportedPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return nil, p.notSupported("fetch perp ticker")
+}
+
+func (p *UnsupportedPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (model.Tickers, error) {
+	return nil, p.notSupported("fetch perp tickers")
+}
+
+func (p *UnsupportedPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return nil, p.notSupported("fetch perp order book")
+}
+
+func (p *UnsupportedPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, p.notSupported("watch perp ticker")
+}
+
+func (p *UnsupportedPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, p.notSupported("watch perp order book")
+}
+
+func (p *UnsupportedPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	return nil, p.notSupported("fetch perp ohlcv")
+}
+
+func (p *UnsupportedPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return nil, p.notSupported("fetch perp ohlcv")
+}
+
+func (p *UnsupportedPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, p.notSupported("watch perp ohlcv")
+}
+
+func (p *UnsupportedPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, p.notSupported("poll perp ohlcv")
+}
+
+func (p *UnsupportedPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, p.notSupported("fetch perp aggregated trades")
+}
+
+func (p *UnsupportedPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	return nil, p.notSupported("fetch funding rate")
+}
+
+func (p *UnsupportedPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	return nil, p.notSupported("fetch funding rate history")
+}
+
+func (p *UnsupportedPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	return nil, p.notSupported("fetch open interest")
+}
+
+func (p *UnsupportedPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return nil, p.notSupported("fetch mark price")
+}
+
+func (p *UnsupportedPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	return decimal.Zero, p.notSupported("fetch index price")
+}
+
+// ========== 账户信息 ==========
+
+func (p *UnsupportedPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	return nil, p.notSupported("fetch positions")
+}
+
+func (p *UnsupportedPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	return nil, p.notSupported("watch positions")
+}
+
+func (p *UnsupportedPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, p.notSupported("watch perp balance")
+}
+
+// ========== 订单操作 ==========
+
+func (p *UnsupportedPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	return nil, p.notSupported("create perp order")
+}
+
+func (p *UnsupportedPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	return nil, nil, p.notSupported("create perp orders")
+}
+
+func (p *UnsupportedPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	return nil, p.notSupported("close position")
+}
+
+func (p *UnsupportedPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	return p.notSupported("cancel perp order")
+}
+
+func (p *UnsupportedPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return nil, p.notSupported("edit perp order")
+}
+
+func (p *UnsupportedPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return nil, p.notSupported("fetch perp order")
+}
+
+func (p *UnsupportedPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return nil, p.notSupported("fetch order by client id")
+}
+
+func (p *UnsupportedPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, p.notSupported("fetch my trades")
+}
+
+func (p *UnsupportedPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, p.notSupported("track perp order")
+}
+
+func (p *UnsupportedPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	return nil, p.notSupported("watch perp orders")
+}
+
+// ========== 合约特有功能 ==========
+
+func (p *UnsupportedPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
+	return p.notSupported("set leverage")
+}
+
+func (p *UnsupportedPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	return p.notSupported("set leverage side")
+}
+
+func (p *UnsupportedPerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
+	return p.notSupported("set margin type")
+}
+
+func (p *UnsupportedPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	return p.notSupported("set position mode")
+}
+
+func (p *UnsupportedPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	return false, p.notSupported("get position mode")
+}
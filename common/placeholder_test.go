@@ -0,0 +1,22 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnsupportedPerp(t *testing.T) {
+	p := NewUnsupportedPerp("kucoin")
+
+	if err := p.NotSupported(); !errors.Is(err, ErrNotSupported) || !strings.Contains(err.Error(), "kucoin") {
+		t.Errorf("NotSupported = %v", err)
+	}
+	if err := p.LoadMarkets(context.Background(), false); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("LoadMarkets = %v, want ErrNotSupported", err)
+	}
+	if _, err := p.CreateOrder(context.Background(), "BTC/USDT:USDT", "1", "", ""); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("CreateOrder = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
+const (
+	// pollMinInterval 最小轮询间隔
+	pollMinInterval = time.Second
+	// pollMaxInterval 最大轮询间隔
+	pollMaxInterval = 5 * time.Second
+	// pollCloseDelay 越过K线边界后的额外等待，给交易所留出生成新K线的时间
+	pollCloseDelay = 200 * time.Millisecond
+)
+
+// OHLCVFetcher 获取最新K线（至少包含当前K线，通常为最近两根）
+type OHLCVFetcher func(ctx context.Context) (model.OHLCVs, error)
+
+// PollOHLCV 轮询最新K线并推送更新
+// 轮询间隔按 timeframe 自动计算（interval <= 0 时），并对齐到K线边界以便及时发现收盘；
+// 同一根K线内容未变化时不重复推送，收盘K线推送时 Closed 为 true。
+// 首次请求失败时直接返回错误；之后单次请求失败会在下个周期重试，通道在 ctx 取消后关闭。
+func PollOHLCV(ctx context.Context, timeframe string, interval time.Duration, fetch OHLCVFetcher) (<-chan *model.OHLCV, error) {
+	period, err := ParseTimeframe(timeframe)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = period / 12
+		if interval < pollMinInterval {
+			interval = pollMinInterval
+		}
+		if interval > pollMaxInterval {
+			interval = pollMaxInterval
+		}
+	}
+
+	// 首次请求同步执行，以便及时返回交易对或时间框架不支持等错误
+	candles, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *model.OHLCV)
+	p := &ohlcvPoller{period: period, out: ch}
+
+	go func() {
+		defer close(ch)
+
+		for {
+			if !p.handle(ctx, candles, time.Now()) {
+				return
+			}
+
+			timer := time.NewTimer(p.nextWait(time.Now(), interval))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			// 单次请求失败时跳过本周期，下个周期重试
+			if candles, err = fetch(ctx); err != nil {
+				candles = nil
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// ohlcvPoller 记录已推送的K线状态，用于去重和收盘检测
+type ohlcvPoller struct {
+	period  time.Duration
+	out     chan<- *model.OHLCV
+	current *model.OHLCV // 最近一次推送的未收盘K线
+	closed  time.Time    // 最近一根已收盘K线的时间戳
+	started bool
+}
+
+// handle 处理一次轮询结果，ctx 取消时返回 false
+func (p *ohlcvPoller) handle(ctx context.Context, candles model.OHLCVs, now time.Time) bool {
+	if len(candles) == 0 {
+		return true
+	}
+
+	sorted := make(model.OHLCVs, 0, len(candles))
+	for _, c := range candles {
+		if c != nil {
+			sorted = append(sorted, c)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp.Time)
+	})
+
+	// 首次轮询只推送最新一根，不回放历史K线
+	if !p.started {
+		p.started = true
+		if len(sorted) > 1 {
+			p.closed = sorted[len(sorted)-2].Timestamp.Time
+		}
+		sorted = sorted[len(sorted)-1:]
+	}
+
+	for i, c := range sorted {
+		ts := c.Timestamp.Time
+		if !p.closed.IsZero() && !ts.After(p.closed) {
+			continue
+		}
+
+		// 出现更新的K线，上一根未收盘K线视为已收盘
+		if p.current != nil && ts.After(p.current.Timestamp.Time) {
+			last := *p.current
+			last.Closed = true
+			if !p.emit(ctx, &last) {
+				return false
+			}
+			p.closed = last.Timestamp.Time
+			p.current = nil
+		}
+
+		// 同批次中存在更新的K线，或K线时间已结束，均视为已收盘
+		candle := *c
+		candle.Closed = i < len(sorted)-1 || !ts.Add(p.period).After(now)
+
+		if candle.Closed {
+			if !p.emit(ctx, &candle) {
+				return false
+			}
+			p.closed = ts
+			p.current = nil
+			continue
+		}
+
+		if p.current != nil && sameOHLCV(p.current, &candle) {
+			continue
+		}
+		if !p.emit(ctx, &candle) {
+			return false
+		}
+		p.current = &candle
+	}
+
+	return true
+}
+
+// emit 推送K线，ctx 取消时返回 false
+func (p *ohlcvPoller) emit(ctx context.Context, candle *model.OHLCV) bool {
+	out := *candle
+	select {
+	case p.out <- &out:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// nextWait 计算下次轮询等待时间，若K线边界早于下个周期则对齐到边界
+func (p *ohlcvPoller) nextWait(now time.Time, interval time.Duration) time.Duration {
+	if p.current == nil {
+		return interval
+	}
+
+	boundary := p.current.Timestamp.Add(p.period).Add(pollCloseDelay)
+	if wait := boundary.Sub(now); wait > 0 && wait < interval {
+		return wait
+	}
+	return interval
+}
+
+// sameOHLCV 判断两根K线内容是否一致
+func sameOHLCV(a, b *model.OHLCV) bool {
+	return a.Timestamp.Equal(b.Timestamp.Time) &&
+		a.Open.Equal(b.Open.Decimal) &&
+		a.High.Equal(b.High.Decimal) &&
+		a.Low.Equal(b.Low.Decimal) &&
+		a.Close.Equal(b.Close.Decimal) &&
+		a.Volume.Equal(b.Volume.Decimal)
+}
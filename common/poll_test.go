@@ -0,0 +1,134 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func newTestOHLCV(ts time.Time, closePrice int64) *model.OHLCV {
+	price := types.ExDecimal{Decimal: decimal.NewFromInt(closePrice)}
+	return &model.OHLCV{
+		Timestamp: types.ExTimestamp{Time: ts},
+		Open:      price,
+		High:      price,
+		Low:       price,
+		Close:     price,
+		Volume:    types.ExDecimal{Decimal: decimal.NewFromInt(1)},
+	}
+}
+
+func TestPollOHLCV(t *testing.T) {
+	// 使用未来时间，保证K线不会因本地时钟而被判定为收盘
+	t0 := time.Now().Add(time.Hour).Truncate(time.Minute)
+	t1 := t0.Add(time.Minute)
+
+	responses := []model.OHLCVs{
+		{newTestOHLCV(t0.Add(-time.Minute), 90), newTestOHLCV(t0, 100)},
+		{newTestOHLCV(t0.Add(-time.Minute), 90), newTestOHLCV(t0, 100)}, // 未变化，不推送
+		{newTestOHLCV(t0.Add(-time.Minute), 90), newTestOHLCV(t0, 101)},
+		{newTestOHLCV(t0, 102), newTestOHLCV(t1, 200)}, // 新K线出现，上一根收盘
+		{newTestOHLCV(t0, 102), newTestOHLCV(t1, 200)},
+	}
+
+	var mu sync.Mutex
+	calls := 0
+	fetch := func(ctx context.Context) (model.OHLCVs, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		resp := responses[calls]
+		if calls < len(responses)-1 {
+			calls++
+		}
+		return resp, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := PollOHLCV(ctx, "1m", 5*time.Millisecond, fetch)
+	if err != nil {
+		t.Fatalf("PollOHLCV: %v", err)
+	}
+
+	expected := []struct {
+		ts     time.Time
+		close  int64
+		closed bool
+	}{
+		{t0, 100, false},
+		{t0, 101, false},
+		{t0, 102, true},
+		{t1, 200, false},
+	}
+
+	for i, want := range expected {
+		select {
+		case got := <-ch:
+			if !got.Timestamp.Equal(want.ts) || got.Close.IntPart() != want.close || got.Closed != want.closed {
+				t.Fatalf("candle %d = {%s %s closed=%v}, want {%s %d closed=%v}",
+					i, got.Timestamp.Time, got.Close.String(), got.Closed, want.ts, want.close, want.closed)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for candle %d", i)
+		}
+	}
+
+	// 后续轮询内容不变，不应再有推送
+	select {
+	case got := <-ch:
+		t.Fatalf("unexpected candle: {%s %s closed=%v}", got.Timestamp.Time, got.Close.String(), got.Closed)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel to be closed after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestPollOHLCV_ClosedByClock(t *testing.T) {
+	// 当前K线时间已过，下一次轮询应直接推送收盘K线
+	t0 := time.Now().Add(-90 * time.Second).Truncate(time.Minute)
+	p := &ohlcvPoller{period: time.Minute}
+	out := make(chan *model.OHLCV, 4)
+	p.out = out
+
+	p.handle(context.Background(), model.OHLCVs{newTestOHLCV(t0, 100)}, t0.Add(30*time.Second))
+	p.handle(context.Background(), model.OHLCVs{newTestOHLCV(t0, 105)}, t0.Add(61*time.Second))
+	p.handle(context.Background(), model.OHLCVs{newTestOHLCV(t0, 105)}, t0.Add(62*time.Second))
+
+	if len(out) != 2 {
+		t.Fatalf("got %d candles, want 2", len(out))
+	}
+	if first := <-out; first.Closed {
+		t.Error("first candle should be open")
+	}
+	if second := <-out; !second.Closed || second.Close.IntPart() != 105 {
+		t.Errorf("second candle = {%s closed=%v}, want {105 closed=true}", second.Close.String(), second.Closed)
+	}
+}
+
+func TestPollOHLCV_InitialError(t *testing.T) {
+	if _, err := PollOHLCV(context.Background(), "1x", 0, nil); err == nil {
+		t.Error("expected error for invalid timeframe")
+	}
+
+	fetch := func(ctx context.Context) (model.OHLCVs, error) {
+		return nil, fmt.Errorf("market not found: FOO/BAR")
+	}
+	if _, err := PollOHLCV(context.Background(), "1m", 0, fetch); err == nil {
+		t.Error("expected error from initial fetch")
+	}
+}
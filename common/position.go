@@ -0,0 +1,301 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// positionPollInterval 持仓默认轮询间隔
+const positionPollInterval = 2 * time.Second
+
+// PositionsFetcher 获取当前全部持仓
+type PositionsFetcher func(ctx context.Context) (model.Positions, error)
+
+// PositionTracker 记录上一次持仓快照，并计算新快照相对它的变化
+type PositionTracker struct {
+	positions map[string]*model.Position
+}
+
+// NewPositionTracker 创建持仓跟踪器
+func NewPositionTracker() *PositionTracker {
+	return &PositionTracker{positions: make(map[string]*model.Position)}
+}
+
+// Update 以新快照替换上一次快照，返回发生变化的持仓（按 symbol、side 排序）
+// 快照中数量为 0 或缺失的持仓视为已平仓；数量与未实现盈亏均未变化的持仓不返回
+func (t *PositionTracker) Update(positions model.Positions) []*model.PositionUpdate {
+	next := make(map[string]*model.Position, len(positions))
+	for _, p := range positions {
+		if p == nil || p.Amount.IsZero() {
+			continue
+		}
+		next[positionKey(p)] = p
+	}
+
+	var updates []*model.PositionUpdate
+	for key, cur := range next {
+		if update := diffPosition(t.positions[key], cur); update != nil {
+			updates = append(updates, update)
+		}
+	}
+	for key, prev := range t.positions {
+		if _, ok := next[key]; ok {
+			continue
+		}
+		closed := *prev
+		closed.Amount = types.ExDecimal{}
+		closed.UnrealizedPnl = types.ExDecimal{}
+		updates = append(updates, diffPosition(prev, &closed))
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		a, b := updates[i].Position, updates[j].Position
+		if a.Symbol != b.Symbol {
+			return a.Symbol < b.Symbol
+		}
+		return a.Side < b.Side
+	})
+
+	t.positions = next
+	return updates
+}
+
+// snapshot 返回上一次快照中的持仓
+func (t *PositionTracker) snapshot() model.Positions {
+	positions := make(model.Positions, 0, len(t.positions))
+	for _, p := range t.positions {
+		positions = append(positions, p)
+	}
+	return positions
+}
+
+// diffPosition 计算单个持仓的变化，无变化时返回 nil
+func diffPosition(prev, cur *model.Position) *model.PositionUpdate {
+	var prevAmount, prevPnl decimal.Decimal
+	if prev != nil {
+		prevAmount = prev.Amount.Abs()
+		prevPnl = prev.UnrealizedPnl.Decimal
+	}
+	curAmount := cur.Amount.Abs()
+
+	amountChange := curAmount.Sub(prevAmount)
+	pnlChange := cur.UnrealizedPnl.Sub(prevPnl)
+
+	var action string
+	switch {
+	case prev == nil:
+		action = model.PositionActionOpened
+	case curAmount.IsZero():
+		action = model.PositionActionClosed
+	case amountChange.IsPositive():
+		action = model.PositionActionIncreased
+	case amountChange.IsNegative():
+		action = model.PositionActionReduced
+	case pnlChange.IsZero():
+		return nil
+	default:
+		action = model.PositionActionUpdated
+	}
+
+	return &model.PositionUpdate{
+		Position:     cur,
+		Previous:     prev,
+		Action:       action,
+		AmountChange: types.ExDecimal{Decimal: amountChange},
+		PnlChange:    types.ExDecimal{Decimal: pnlChange},
+	}
+}
+
+// positionReasonTTL 强平/自动减仓订单原因的有效期
+const positionReasonTTL = time.Minute
+
+// positionReason 等待匹配持仓变化的订单原因
+type positionReason struct {
+	reason  string
+	expires time.Time
+}
+
+// attachPositionReason 为减仓、平仓事件填充该交易对尚未过期的订单原因，匹配后移除该原因
+func attachPositionReason(reasons map[string]positionReason, update *model.PositionUpdate) {
+	symbol := update.Position.Symbol
+	r, ok := reasons[symbol]
+	if !ok {
+		return
+	}
+	if time.Now().After(r.expires) {
+		delete(reasons, symbol)
+		return
+	}
+	if update.Action == model.PositionActionReduced || update.Action == model.PositionActionClosed {
+		update.Reason = r.reason
+		delete(reasons, symbol)
+	}
+}
+
+// positionKey 持仓唯一键（双向持仓时同一交易对存在多空两个持仓）
+func positionKey(p *model.Position) string {
+	return p.Symbol + "|" + p.Side
+}
+
+// PollPositions 轮询持仓并推送持仓变化
+// 首次轮询的已有持仓以 opened 推送；之后仅推送发生变化的持仓。
+// 首次请求失败时直接返回错误；之后单次请求失败会在下个周期重试，通道在 ctx 取消后关闭。
+func PollPositions(ctx context.Context, interval time.Duration, fetch PositionsFetcher) (<-chan *model.PositionUpdate, error) {
+	return PollPositionsWithOrders(ctx, interval, fetch, nil)
+}
+
+// PollPositionsWithOrders 同 PollPositions，并通过 orders（私有订单推送）确定持仓变化原因：
+// 收到 Reason 非空的强平/自动减仓订单后立即重新查询持仓，该交易对随后一次减仓或平仓事件带上订单的 Reason。
+// 订单原因超过 positionReasonTTL 未匹配到持仓减少时丢弃；orders 为 nil 或关闭后退化为 PollPositions
+func PollPositionsWithOrders(ctx context.Context, interval time.Duration, fetch PositionsFetcher, orders <-chan *model.PerpOrder) (<-chan *model.PositionUpdate, error) {
+	if interval <= 0 {
+		interval = positionPollInterval
+	}
+
+	positions, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *model.PositionUpdate)
+	tracker := NewPositionTracker()
+	reasons := make(map[string]positionReason)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			for _, update := range tracker.Update(positions) {
+				attachPositionReason(reasons, update)
+				select {
+				case ch <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			timer := time.NewTimer(interval)
+		wait:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+					break wait
+				case order, ok := <-orders:
+					if !ok {
+						orders = nil
+						continue
+					}
+					if order == nil || order.Reason == "" {
+						continue
+					}
+					reasons[order.Symbol] = positionReason{reason: order.Reason, expires: time.Now().Add(positionReasonTTL)}
+					timer.Stop()
+					break wait
+				}
+			}
+
+			// 单次请求失败时保留上一次快照，避免误判为平仓
+			next, err := fetch(ctx)
+			if err != nil {
+				positions = tracker.snapshot()
+				continue
+			}
+			positions = next
+		}
+	}()
+
+	return ch, nil
+}
+
+// PositionMode 缓存账户持仓模式（单向/双向），SetPositionMode、GetPositionMode 成功后更新，零值表示未知
+// 下单时未通过 option.WithHedgeMode 指定持仓模式则使用缓存的模式，未知时按单向持仓处理
+type PositionMode struct {
+	hedged atomic.Pointer[bool]
+}
+
+// Set 记录账户持仓模式
+func (m *PositionMode) Set(hedged bool) {
+	m.hedged.Store(&hedged)
+}
+
+// Get 返回缓存的持仓模式，未知时 ok 为 false
+func (m *PositionMode) Get() (hedged bool, ok bool) {
+	if v := m.hedged.Load(); v != nil {
+		return *v, true
+	}
+	return false, false
+}
+
+// Hedged 返回下单使用的持仓模式，override 为 option.WithHedgeMode 指定的值（优先于缓存）
+func (m *PositionMode) Hedged(override *bool) bool {
+	if override != nil {
+		return *override
+	}
+	hedged, _ := m.Get()
+	return hedged
+}
+
+// ClosePositionOrder 计算平仓单：方向为持仓的反向（多头 CloseLong，空头 CloseShort），数量为持仓数量（与持仓单位相同）乘以 option.WithClosePercent 指定的比例（默认 100%）
+// positions 中没有 symbol 的持仓时返回 ErrPositionNotFound；双向持仓同时持有多空时无法确定方向，返回 ErrInvalidOrder
+func ClosePositionOrder(positions model.Positions, symbol string, opts *option.ExchangeArgsOptions) (*model.Position, option.PerpOrderSide, decimal.Decimal, error) {
+	percent := decimal.NewFromInt(100)
+	if option.StringPresent(opts.ClosePercent) {
+		v, err := decimal.NewFromString(*opts.ClosePercent)
+		if err != nil || !v.IsPositive() || v.GreaterThan(percent) {
+			return nil, "", decimal.Zero, fmt.Errorf("%w: close percent must be in (0, 100], got %q", ErrInvalidOrder, *opts.ClosePercent)
+		}
+		percent = v
+	}
+
+	var position *model.Position
+	for _, p := range positions {
+		if p == nil || p.Symbol != symbol || p.Amount.IsZero() {
+			continue
+		}
+		if position != nil {
+			return nil, "", decimal.Zero, fmt.Errorf("%w: both long and short positions are open for %s, close them with CreateOrder", ErrInvalidOrder, symbol)
+		}
+		position = p
+	}
+	if position == nil {
+		return nil, "", decimal.Zero, fmt.Errorf("%w: %s", ErrPositionNotFound, symbol)
+	}
+
+	side := option.CloseLong
+	if position.Side == string(model.PositionSideShort) {
+		side = option.CloseShort
+	}
+	amount := position.Amount.Abs().Mul(percent).Div(decimal.NewFromInt(100))
+	return position, side, amount, nil
+}
+
+// CheckLeverage 校验杠杆倍数：至少为 1（市场有最小杠杆时不低于最小杠杆），市场最大杠杆已知时超出返回 ErrLeverageExceeded
+func CheckLeverage(market *model.Market, leverage int) error {
+	lev := decimal.NewFromInt(int64(leverage))
+	if leverage < 1 || (market.Limits.Leverage.Min.IsPositive() && lev.LessThan(market.Limits.Leverage.Min.Decimal)) {
+		return fmt.Errorf("leverage %d is below the minimum for %s", leverage, market.Symbol)
+	}
+	if max := market.Limits.Leverage.Max; max.IsPositive() && lev.GreaterThan(max.Decimal) {
+		return fmt.Errorf("%w: %d > %s for %s", ErrLeverageExceeded, leverage, max.String(), market.Symbol)
+	}
+	return nil
+}
+
+// CheckPositionSide 校验持仓方向为 long 或 short
+func CheckPositionSide(side types.PositionSide) error {
+	if !side.IsLong() && !side.IsShort() {
+		return fmt.Errorf("invalid position side: %q", side)
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func newTestPosition(symbol, side, amount, pnl string) *model.Position {
+	return &model.Position{
+		Symbol:        symbol,
+		Side:          side,
+		Amount:        types.ExDecimal{Decimal: decimal.RequireFromString(amount)},
+		UnrealizedPnl: types.ExDecimal{Decimal: decimal.RequireFromString(pnl)},
+	}
+}
+
+func TestPositionTracker_Update(t *testing.T) {
+	tracker := NewPositionTracker()
+
+	steps := []struct {
+		positions model.Positions
+		actions   []string
+		amounts   []string
+	}{
+		{model.Positions{newTestPosition("BTC/USDT:USDT", "long", "1", "0")}, []string{model.PositionActionOpened}, []string{"1"}},
+		{model.Positions{newTestPosition("BTC/USDT:USDT", "long", "1", "0")}, nil, nil}, // 无变化，不推送
+		{model.Positions{newTestPosition("BTC/USDT:USDT", "long", "1", "5")}, []string{model.PositionActionUpdated}, []string{"0"}},
+		{model.Positions{newTestPosition("BTC/USDT:USDT", "long", "3", "8")}, []string{model.PositionActionIncreased}, []string{"2"}},
+		{model.Positions{
+			newTestPosition("BTC/USDT:USDT", "long", "2", "6"),
+			newTestPosition("ETH/USDT:USDT", "short", "-5", "0"),
+		}, []string{model.PositionActionReduced, model.PositionActionOpened}, []string{"-1", "5"}},
+		{model.Positions{
+			newTestPosition("BTC/USDT:USDT", "long", "0", "0"),
+		}, []string{model.PositionActionClosed, model.PositionActionClosed}, []string{"-2", "-5"}},
+	}
+
+	for i, step := range steps {
+		updates := tracker.Update(step.positions)
+		if len(updates) != len(step.actions) {
+			t.Fatalf("step %d: expected %d updates, got %d", i, len(step.actions), len(updates))
+		}
+		for j, u := range updates {
+			if u.Action != step.actions[j] {
+				t.Errorf("step %d[%d]: expected action %s, got %s", i, j, step.actions[j], u.Action)
+			}
+			if u.AmountChange.String() != step.amounts[j] {
+				t.Errorf("step %d[%d]: expected amount change %s, got %s", i, j, step.amounts[j], u.AmountChange.String())
+			}
+		}
+	}
+}
+
+func TestPositionTracker_PnlChange(t *testing.T) {
+	tracker := NewPositionTracker()
+	tracker.Update(model.Positions{newTestPosition("BTC/USDT:USDT", "long", "1", "10")})
+
+	updates := tracker.Update(model.Positions{newTestPosition("BTC/USDT:USDT", "long", "1.5", "4.5")})
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(updates))
+	}
+	if updates[0].PnlChange.String() != "-5.5" {
+		t.Errorf("expected pnl change -5.5, got %s", updates[0].PnlChange.String())
+	}
+	if updates[0].Previous == nil || updates[0].Previous.Amount.String() != "1" {
+		t.Errorf("expected previous position amount 1")
+	}
+}
+
+func TestPollPositions(t *testing.T) {
+	responses := []model.Positions{
+		{newTestPosition("BTC/USDT:USDT", "long", "1", "0")},
+		{newTestPosition("BTC/USDT:USDT", "long", "2", "0")},
+		{},
+	}
+	calls := 0
+	fetch := func(ctx context.Context) (model.Positions, error) {
+		resp := responses[len(responses)-1]
+		if calls < len(responses) {
+			resp = responses[calls]
+		}
+		calls++
+		return resp, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ch, err := PollPositions(ctx, 10*time.Millisecond, fetch)
+	if err != nil {
+		t.Fatalf("PollPositions: %v", err)
+	}
+
+	expected := []string{model.PositionActionOpened, model.PositionActionIncreased, model.PositionActionClosed}
+	for i, action := range expected {
+		select {
+		case u := <-ch:
+			if u.Action != action {
+				t.Errorf("update %d: expected %s, got %s", i, action, u.Action)
+			}
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for update %d", i)
+		}
+	}
+}
+
+func TestPollPositionsWithOrders(t *testing.T) {
+	responses := []model.Positions{
+		{newTestPosition("BTC/USDT:USDT", "long", "2", "0")},
+		{newTestPosition("BTC/USDT:USDT", "long", "1", "0")},
+		{},
+	}
+	calls := 0
+	fetch := func(ctx context.Context) (model.Positions, error) {
+		resp := responses[len(responses)-1]
+		if calls < len(responses) {
+			resp = responses[calls]
+		}
+		calls++
+		return resp, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 轮询间隔足够长，持仓只在收到系统订单后重新查询
+	orders := make(chan *model.PerpOrder)
+	ch, err := PollPositionsWithOrders(ctx, time.Hour, fetch, orders)
+	if err != nil {
+		t.Fatalf("PollPositionsWithOrders: %v", err)
+	}
+
+	next := func() *model.PositionUpdate {
+		select {
+		case u := <-ch:
+			return u
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for update")
+			return nil
+		}
+	}
+
+	if u := next(); u.Action != model.PositionActionOpened || u.Reason != "" {
+		t.Fatalf("expected opened without reason, got %s %q", u.Action, u.Reason)
+	}
+
+	orders <- &model.PerpOrder{Symbol: "BTC/USDT:USDT", Reason: model.PositionReasonLiquidation}
+	if u := next(); u.Action != model.PositionActionReduced || u.Reason != model.PositionReasonLiquidation {
+		t.Fatalf("expected reduced by liquidation, got %s %q", u.Action, u.Reason)
+	}
+
+	// 普通订单不触发查询；其他交易对的原因不影响 BTC 的平仓事件
+	orders <- &model.PerpOrder{Symbol: "BTC/USDT:USDT"}
+	orders <- &model.PerpOrder{Symbol: "ETH/USDT:USDT", Reason: model.PositionReasonADL}
+	if u := next(); u.Action != model.PositionActionClosed || u.Reason != "" {
+		t.Fatalf("expected closed without reason, got %s %q", u.Action, u.Reason)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 fetches, got %d", calls)
+	}
+}
+
+func TestPositionMode_Hedged(t *testing.T) {
+	var m PositionMode
+	if _, ok := m.Get(); ok {
+		t.Error("zero value should be unknown")
+	}
+	if m.Hedged(nil) {
+		t.Error("unknown mode should default to one-way")
+	}
+
+	m.Set(true)
+	if hedged, ok := m.Get(); !ok || !hedged {
+		t.Errorf("Get = %v, %v", hedged, ok)
+	}
+	if !m.Hedged(nil) {
+		t.Error("cached hedge mode not used")
+	}
+	// option.WithHedgeMode 优先于缓存
+	oneWay := false
+	if m.Hedged(&oneWay) {
+		t.Error("override should take precedence over cached mode")
+	}
+}
+
+func TestClosePositionOrder(t *testing.T) {
+	positions := model.Positions{
+		newTestPosition("BTC/USDT:USDT", "long", "2", "0"),
+		newTestPosition("ETH/USDT:USDT", "short", "-5", "0"),
+		newTestPosition("SOL/USDT:USDT", "long", "0", "0"),
+	}
+
+	tests := []struct {
+		symbol  string
+		percent string
+		side    option.PerpOrderSide
+		amount  string
+		err     error
+	}{
+		{"BTC/USDT:USDT", "", option.CloseLong, "2", nil},
+		{"BTC/USDT:USDT", "25", option.CloseLong, "0.5", nil},
+		{"ETH/USDT:USDT", "", option.CloseShort, "5", nil},
+		{"SOL/USDT:USDT", "", "", "", ErrPositionNotFound}, // 数量为 0 视为无持仓
+		{"XRP/USDT:USDT", "", "", "", ErrPositionNotFound},
+		{"BTC/USDT:USDT", "0", "", "", ErrInvalidOrder},
+		{"BTC/USDT:USDT", "150", "", "", ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		opts := &option.ExchangeArgsOptions{}
+		if tt.percent != "" {
+			option.WithClosePercent(tt.percent)(opts)
+		}
+		_, side, amount, err := ClosePositionOrder(positions, tt.symbol, opts)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s %s: err = %v, want %v", tt.symbol, tt.percent, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || side != tt.side || !amount.Equal(decimal.RequireFromString(tt.amount)) {
+			t.Errorf("%s %s = %s %s, %v, want %s %s", tt.symbol, tt.percent, side, amount, err, tt.side, tt.amount)
+		}
+	}
+
+	// 双向持仓同时持有多空时无法确定平仓方向
+	hedged := append(positions, newTestPosition("BTC/USDT:USDT", "short", "-1", "0"))
+	if _, _, _, err := ClosePositionOrder(hedged, "BTC/USDT:USDT", &option.ExchangeArgsOptions{}); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("both sides: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestCheckLeverage(t *testing.T) {
+	market := &model.Market{Symbol: "BTC/USDT:USDT"}
+	market.Limits.Leverage.Min = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	market.Limits.Leverage.Max = types.ExDecimal{Decimal: decimal.NewFromInt(50)}
+	unknown := &model.Market{Symbol: "ETH/USDT:USDT"}
+
+	tests := []struct {
+		market   *model.Market
+		leverage int
+		exceeded bool
+		ok       bool
+	}{
+		{market, 1, false, true},
+		{market, 50, false, true},
+		{market, 51, true, false},
+		{market, 0, false, false},
+		{unknown, 200, false, true}, // 最大杠杆未知时不校验上限
+		{unknown, -1, false, false},
+	}
+	for _, tt := range tests {
+		err := CheckLeverage(tt.market, tt.leverage)
+		if (err == nil) != tt.ok || errors.Is(err, ErrLeverageExceeded) != tt.exceeded {
+			t.Errorf("%s x%d: err = %v", tt.market.Symbol, tt.leverage, err)
+		}
+	}
+
+	if err := CheckPositionSide("both"); err == nil {
+		t.Errorf("invalid side: want error")
+	}
+}
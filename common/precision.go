@@ -0,0 +1,87 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/shopspring/decimal"
+)
+
+// AmountToPrecision 将数量向下对齐到市场的数量步长（步长未知时按数量精度截断），返回交易所可接受的数量字符串
+// 步长和精度均未知时不做对齐；对齐后为零或低于市场最小下单量时返回 ErrInvalidOrder
+func AmountToPrecision(market *model.Market, amount string) (string, error) {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	snapped := value
+	if market.Precision.StepSize.IsPositive() || market.Precision.Amount > 0 {
+		snapped = market.SnapAmount(value)
+	}
+	if !snapped.IsPositive() {
+		return "", fmt.Errorf("%w: amount %s is below the lot size of %s", ErrInvalidOrder, amount, market.Symbol)
+	}
+	if min := market.Limits.Amount.Min; min.IsPositive() && snapped.LessThan(min.Decimal) {
+		return "", fmt.Errorf("%w: amount %s is below the minimum %s of %s", ErrInvalidOrder, snapped.String(), min.String(), market.Symbol)
+	}
+	return snapped.String(), nil
+}
+
+// PriceToPrecision 将价格向下对齐到市场的价格步长（步长未知时按价格精度截断），返回交易所可接受的价格字符串
+// 步长和精度均未知时不做对齐；对齐后为零时返回 ErrInvalidOrder
+func PriceToPrecision(market *model.Market, price string) (string, error) {
+	value, err := decimal.NewFromString(price)
+	if err != nil {
+		return "", fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	snapped := value
+	if market.Precision.TickSize.IsPositive() || market.Precision.Price > 0 {
+		snapped = market.SnapPrice(value)
+	}
+	if !snapped.IsPositive() {
+		return "", fmt.Errorf("%w: price %s is below the tick size of %s", ErrInvalidOrder, price, market.Symbol)
+	}
+	return snapped.String(), nil
+}
+
+// contractValue 返回线性合约每张合约对应的币数量（ContractValue），未知时按 1 处理
+func contractValue(market *model.Market) (decimal.Decimal, error) {
+	if !market.Contract {
+		return decimal.Zero, fmt.Errorf("%s is not a contract market", market.Symbol)
+	}
+	if market.Inverse {
+		return decimal.Zero, fmt.Errorf("%s is an inverse contract: contract value is in %s, converting to %s needs a price", market.Symbol, market.Quote, market.Base)
+	}
+	value, err := decimal.NewFromString(market.ContractValue)
+	if err != nil || !value.IsPositive() {
+		return decimal.NewFromInt(1), nil
+	}
+	return value, nil
+}
+
+// AmountToContracts 将币数量换算为合约张数（币数量 / ContractValue），再按 AmountToPrecision 向下对齐到张数步长
+// 只适用于线性合约；低于最小下单张数时返回 ErrInvalidOrder
+func AmountToContracts(market *model.Market, amount string) (string, error) {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return "", fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	cv, err := contractValue(market)
+	if err != nil {
+		return "", err
+	}
+	return AmountToPrecision(market, value.DivRound(cv, 16).String())
+}
+
+// ContractsToAmount 将合约张数换算为币数量（张数 × ContractValue），只适用于线性合约
+func ContractsToAmount(market *model.Market, contracts string) (string, error) {
+	value, err := decimal.NewFromString(contracts)
+	if err != nil {
+		return "", fmt.Errorf("invalid contracts %q: %w", contracts, err)
+	}
+	cv, err := contractValue(market)
+	if err != nil {
+		return "", err
+	}
+	return value.Mul(cv).String(), nil
+}
@@ -0,0 +1,150 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestAmountPriceToPrecision_Step(t *testing.T) {
+	market := &model.Market{Symbol: "BTC/USDT"}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.01")}
+	market.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.RequireFromString("0.002")}
+
+	tests := []struct {
+		name string
+		fn   func(*model.Market, string) (string, error)
+		in   string
+		want string
+	}{
+		{"price on tick", PriceToPrecision, "0.12", "0.12"},
+		{"price just below next tick", PriceToPrecision, "0.129999999", "0.12"},
+		{"price with extra digits", PriceToPrecision, "0.123456789", "0.12"},
+		{"amount on step", AmountToPrecision, "0.002", "0.002"},
+		{"amount just below next step", AmountToPrecision, "0.0029999", "0.002"},
+		{"amount trailing zeros", AmountToPrecision, "1.2300000", "1.23"},
+	}
+	for _, tt := range tests {
+		got, err := tt.fn(market, tt.in)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// 对齐后低于最小下单量或为零时报错
+	for _, amount := range []string{"0.0019", "0.0009"} {
+		if _, err := AmountToPrecision(market, amount); !errors.Is(err, ErrInvalidOrder) {
+			t.Errorf("AmountToPrecision(%s) err = %v, want ErrInvalidOrder", amount, err)
+		}
+	}
+	if _, err := PriceToPrecision(market, "0.009"); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("PriceToPrecision err = %v, want ErrInvalidOrder", err)
+	}
+	if _, err := AmountToPrecision(market, "abc"); err == nil {
+		t.Error("expected error for invalid amount")
+	}
+}
+
+func TestAmountPriceToPrecision_ZeroPrecision(t *testing.T) {
+	// 按张下单的合约（如 Gate）数量精度为 0，步长为 1 张
+	market := &model.Market{Symbol: "BTC/USDT:USDT", Contract: true}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	market.Precision.Price = 1
+
+	amount, err := AmountToPrecision(market, "35.9")
+	if err != nil {
+		t.Fatalf("AmountToPrecision: %v", err)
+	}
+	if amount != "35" {
+		t.Errorf("amount = %s, want 35", amount)
+	}
+	if _, err := AmountToPrecision(market, "0.9"); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("err = %v, want ErrInvalidOrder", err)
+	}
+
+	price, err := PriceToPrecision(market, "65000.99")
+	if err != nil {
+		t.Fatalf("PriceToPrecision: %v", err)
+	}
+	if price != "65000.9" {
+		t.Errorf("price = %s, want 65000.9", price)
+	}
+
+	// 步长和精度均未知时原样返回
+	unknown := &model.Market{Symbol: "SHIB/USDT"}
+	if got, err := AmountToPrecision(unknown, "0.12345678"); err != nil || got != "0.12345678" {
+		t.Errorf("AmountToPrecision = %s, %v; want 0.12345678", got, err)
+	}
+	if got, err := PriceToPrecision(unknown, "0.00001234"); err != nil || got != "0.00001234" {
+		t.Errorf("PriceToPrecision = %s, %v; want 0.00001234", got, err)
+	}
+}
+
+func TestAmountToContracts(t *testing.T) {
+	// OKX BTC-USDT-SWAP：每张 0.01 BTC，张数步长 0.01
+	okx := &model.Market{Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Contract: true, Linear: true, ContractValue: "0.01"}
+	okx.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.01")}
+	okx.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.RequireFromString("0.01")}
+
+	// Gate BTC_USDT：每张 0.0001 BTC，按整张下单
+	gate := &model.Market{Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Contract: true, Linear: true, ContractValue: "0.0001"}
+	gate.Precision.StepSize = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	gate.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+
+	// Binance/Bybit 线性合约：按币下单，面值为 1
+	linear := &model.Market{Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Contract: true, Linear: true, ContractValue: "1"}
+	linear.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	linear.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+
+	tests := []struct {
+		name      string
+		market    *model.Market
+		amount    string
+		contracts string
+		back      string
+	}{
+		{"okx", okx, "0.5", "50", "0.5"},
+		{"okx rounds down to lot", okx, "0.12345", "12.34", "0.1234"},
+		{"gate", gate, "0.5", "5000", "0.5"},
+		{"gate whole contracts", gate, "0.00019", "1", "0.0001"},
+		{"linear", linear, "0.0123", "0.012", "0.012"},
+	}
+	for _, tt := range tests {
+		contracts, err := AmountToContracts(tt.market, tt.amount)
+		if err != nil {
+			t.Fatalf("%s: AmountToContracts: %v", tt.name, err)
+		}
+		if contracts != tt.contracts {
+			t.Errorf("%s: contracts = %s, want %s", tt.name, contracts, tt.contracts)
+		}
+		amount, err := ContractsToAmount(tt.market, contracts)
+		if err != nil {
+			t.Fatalf("%s: ContractsToAmount: %v", tt.name, err)
+		}
+		if amount != tt.back {
+			t.Errorf("%s: amount = %s, want %s", tt.name, amount, tt.back)
+		}
+	}
+
+	// 不足一张（最小下单张数）时报错
+	if _, err := AmountToContracts(gate, "0.00005"); !errors.Is(err, ErrInvalidOrder) {
+		t.Errorf("gate below min err = %v, want ErrInvalidOrder", err)
+	}
+
+	// 币本位合约面值以美元计价，现货没有合约面值
+	inverse := &model.Market{Symbol: "BTC/USD:BTC", Contract: true, Inverse: true, ContractValue: "100"}
+	if _, err := AmountToContracts(inverse, "1"); err == nil {
+		t.Error("expected error for inverse contract")
+	}
+	spot := &model.Market{Symbol: "BTC/USDT"}
+	if _, err := ContractsToAmount(spot, "1"); err == nil {
+		t.Error("expected error for spot market")
+	}
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitMode 令牌不足时的处理方式
+type RateLimitMode int
+
+const (
+	// RateLimitWait 阻塞等待令牌补足（默认）
+	RateLimitWait RateLimitMode = iota
+	// RateLimitReject 立即返回 ErrRateLimitExceeded
+	RateLimitReject
+)
+
+// RateLimiter 令牌桶限流器，按请求权重扣减令牌
+// 同一个 RateLimiter 可由多个 HTTPClient 共用（如共享 IP 限额的现货和合约客户端）
+type RateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	perToken time.Duration // 补充一个令牌所需时间
+	last     time.Time
+	mode     RateLimitMode
+}
+
+// NewRateLimiter 创建限流器，每 interval 最多消耗 weight 权重
+func NewRateLimiter(weight int, interval time.Duration, mode RateLimitMode) *RateLimiter {
+	if weight < 1 {
+		weight = 1
+	}
+	return &RateLimiter{
+		capacity: float64(weight),
+		tokens:   float64(weight),
+		perToken: interval / time.Duration(weight),
+		last:     time.Now(),
+		mode:     mode,
+	}
+}
+
+// Wait 扣减 weight 个令牌，令牌不足时按模式等待或返回 ErrRateLimitExceeded
+// 等待期间 ctx 取消时返回 ctx.Err()；weight 超过桶容量时按桶容量扣减
+func (l *RateLimiter) Wait(ctx context.Context, weight int) error {
+	need := float64(weight)
+	if need < 1 {
+		need = 1
+	}
+	if need > l.capacity {
+		need = l.capacity
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	if l.tokens >= need {
+		l.tokens -= need
+		l.mu.Unlock()
+		return nil
+	}
+	if l.mode == RateLimitReject {
+		l.mu.Unlock()
+		return ErrRateLimitExceeded
+	}
+	// 预先扣减令牌（允许为负），后续请求按顺序排队
+	delay := time.Duration((need - l.tokens) * float64(l.perToken))
+	l.tokens -= need
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens += need
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refill 按经过的时间补充令牌，调用方需持有锁
+func (l *RateLimiter) refill(now time.Time) {
+	if l.perToken > 0 {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.perToken)
+	} else {
+		l.tokens = l.capacity
+	}
+	if l.tokens > l.capacity {
+		l.tokens = l.capacity
+	}
+	l.last = now
+}
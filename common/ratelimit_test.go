@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_RateLimitPacing(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// 每 100ms 2 个权重：前 2 个请求立即发送，之后每 50ms 放行 1 个
+	client := NewHTTPClient(srv.URL, WithRateLimit(2, 100*time.Millisecond))
+
+	const n = 6
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		if _, err := client.Get(context.Background(), "/ping", nil); err != nil {
+			t.Fatalf("Get #%d: %v", i, err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if hits != n {
+		t.Errorf("server hits = %d, want %d", hits, n)
+	}
+	if want := 180 * time.Millisecond; elapsed < want {
+		t.Errorf("%d requests took %s, want >= %s", n, elapsed, want)
+	}
+	if elapsed > time.Second {
+		t.Errorf("%d requests took %s, limiter is too slow", n, elapsed)
+	}
+}
+
+func TestHTTPClient_RateLimitRejectAndWeight(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// 两个客户端共用一个限流器
+	limiter := NewRateLimiter(10, time.Minute, RateLimitReject)
+	spot := NewHTTPClient(srv.URL, WithRateLimiter(limiter))
+	perp := NewHTTPClient(srv.URL, WithRateLimiter(limiter))
+	spot.SetRequestWeigher(func(method, path string, query url.Values) int {
+		if path == "/heavy" && query.Get("limit") == "1000" {
+			return 8
+		}
+		return 1
+	})
+
+	ctx := context.Background()
+	if _, err := spot.Get(ctx, "/heavy?limit=1000", nil); err != nil {
+		t.Fatalf("heavy request: %v", err)
+	}
+	if _, err := perp.Get(ctx, "/light", nil); err != nil {
+		t.Fatalf("light request: %v", err)
+	}
+	if _, err := spot.Get(ctx, "/heavy", map[string]interface{}{"limit": 1000}); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("err = %v, want ErrRateLimitExceeded", err)
+	}
+	if _, err := perp.Get(ctx, "/light", nil); err != nil {
+		t.Fatalf("light request: %v", err)
+	}
+	if _, err := perp.Get(ctx, "/light", nil); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("err = %v, want ErrRateLimitExceeded after shared limit used up", err)
+	}
+	if hits != 3 {
+		t.Errorf("server hits = %d, want 3 (rejected requests are not sent)", hits)
+	}
+}
+
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Hour, RateLimitWait)
+	if err := limiter.Wait(context.Background(), 1); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数（含首次请求），小于 1 时按 1 处理
+	MaxAttempts int
+	// Backoff 首次重试前的等待时间，之后每次翻倍
+	Backoff time.Duration
+	// MaxBackoff 单次等待时间上限，为 0 时不限制
+	MaxBackoff time.Duration
+	// Retryable 判断错误是否可重试，为 nil 时所有错误均重试（HTTPClient 中为 nil 时使用 IsTransientError）
+	Retryable func(err error) bool
+	// RetryNonIdempotent HTTPClient 是否重试非 GET 请求（如下单），默认只重试 GET，避免重复下单
+	// 各交易所 CreateOrder 启用后重试前先按客户端订单ID查询已提交的订单（见 CreateOrderIdempotent）
+	RetryNonIdempotent bool
+}
+
+// retryPolicyKey context 中重试策略的键
+type retryPolicyKey struct{}
+
+// WithRetryPolicy 返回携带重试策略的 context，覆盖 HTTPClient 的默认重试策略（仅对本次调用生效）
+// 例如 RetryPolicy{MaxAttempts: 1} 关闭重试，RetryNonIdempotent 为 true 时允许重试下单
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// RetryPolicyFromContext 从 context 中读取重试策略
+func RetryPolicyFromContext(ctx context.Context) (RetryPolicy, bool) {
+	if ctx == nil {
+		return RetryPolicy{}, false
+	}
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy, ok
+}
+
+// IsTransientError 判断是否为临时错误：HTTP 429/5xx 或网络错误（不含 context 取消和超时）
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Retry 按策略执行 fn，直到成功、遇到不可重试的错误或尝试次数耗尽
+// 尝试次数耗尽时返回 *RetryExhaustedError；等待期间 ctx 取消时返回 ctx.Err()
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	start := time.Now()
+	backoff := policy.Backoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return err
+		}
+		if attempt >= maxAttempts {
+			exhausted := &RetryExhaustedError{
+				Attempts: attempt,
+				Elapsed:  time.Since(start),
+				Err:      err,
+			}
+			var httpErr *HTTPError
+			if errors.As(err, &httpErr) {
+				exhausted.StatusCode = httpErr.StatusCode
+			}
+			return exhausted
+		}
+
+		// 交易所返回 Retry-After 时至少等待该时长
+		wait := backoff
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.RetryAfter > wait {
+			wait = httpErr.RetryAfter
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
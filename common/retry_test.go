@@ -0,0 +1,180 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry_Exhausted(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"msg":"unavailable"}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.URL)
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	err := Retry(context.Background(), policy, func(ctx context.Context) error {
+		_, err := client.Get(ctx, "/ping", nil)
+		return err
+	})
+
+	var exhausted *RetryExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("expected RetryExhaustedError, got %v", err)
+	}
+	if exhausted.Attempts != 3 || calls != 3 {
+		t.Errorf("expected 3 attempts, got %d (server calls %d)", exhausted.Attempts, calls)
+	}
+	if exhausted.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", exhausted.StatusCode)
+	}
+	if exhausted.Elapsed <= 0 {
+		t.Errorf("expected positive elapsed time, got %s", exhausted.Elapsed)
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected to unwrap to HTTPError 503, got %v", err)
+	}
+}
+
+func TestRetry_SucceedsAndStopsOnNonRetryable(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	calls := 0
+	err := Retry(context.Background(), RetryPolicy{MaxAttempts: 5}, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("expected success on 3rd attempt, got err=%v calls=%d", err, calls)
+	}
+
+	calls = 0
+	policy := RetryPolicy{MaxAttempts: 5, Retryable: func(err error) bool { return !errors.Is(err, errFatal) }}
+	err = Retry(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return errFatal
+	})
+	if !errors.Is(err, errFatal) || calls != 1 {
+		t.Errorf("expected non-retryable error after 1 attempt, got err=%v calls=%d", err, calls)
+	}
+	var exhausted *RetryExhaustedError
+	if errors.As(err, &exhausted) {
+		t.Error("non-retryable error should not be reported as exhausted")
+	}
+}
+
+func TestHTTPClient_RetryOn429(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"code":-1003,"msg":"Too many requests."}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.URL)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	start := time.Now()
+	resp, err := client.Get(context.Background(), "/ping", nil)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(resp) != `{"ok":true}` || calls != 2 {
+		t.Errorf("resp = %s after %d calls, want ok after 2 calls", resp, calls)
+	}
+	// Retry-After 优先于更短的退避时间
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want to honor Retry-After of 1s", elapsed)
+	}
+}
+
+func TestHTTPClient_RetryOnlyIdempotent(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := NewHTTPClient(srv.URL)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+
+	// 下单等 POST 请求默认不重试
+	_, err := client.Post(context.Background(), "/api/v3/order", map[string]string{"side": "BUY"})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Fatalf("err = %v after %d calls, want single 503", err, calls)
+	}
+
+	// 单次调用显式开启
+	calls = 0
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, RetryNonIdempotent: true})
+	if _, err := client.Post(ctx, "/api/v3/order", map[string]string{"side": "BUY"}); err != nil || calls != 2 {
+		t.Fatalf("err = %v after %d calls, want success after retry", err, calls)
+	}
+
+	// 4xx 业务错误不重试
+	calls = 0
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	if _, err := client.Get(context.Background(), "/ping", nil); err == nil || calls != 1 {
+		t.Errorf("err = %v after %d calls, want single 400", err, calls)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{&HTTPError{StatusCode: http.StatusBadGateway}, true},
+		{&HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{&ExchangeError{Code: "-1003", Cause: &HTTPError{StatusCode: http.StatusTooManyRequests}}, true},
+		{context.Canceled, false},
+		{errors.New("decode response"), false},
+	}
+	for _, tt := range tests {
+		if got := IsTransientError(tt.err); got != tt.want {
+			t.Errorf("IsTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %s, want 3s", got)
+	}
+	if got := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); got <= 50*time.Second {
+		t.Errorf("parseRetryAfter(date) = %s, want about 1m", got)
+	}
+	if got := parseRetryAfter("soon"); got != 0 {
+		t.Errorf("parseRetryAfter(soon) = %s, want 0", got)
+	}
+}
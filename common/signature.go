@@ -1,11 +1,17 @@
 package common
 
 import (
+	"crypto/ecdsa"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"net/url"
 	"sort"
@@ -65,7 +71,7 @@ func BuildQueryString(params map[string]interface{}) string {
 	return strings.Join(parts, "&")
 }
 
-// GetTimestamp 获取时间戳（毫秒）
+// GetTimestamp 获取本地时间戳（毫秒），签名请求使用交易所实例的 Clock 以按服务器时间校正
 func GetTimestamp() int64 {
 	return time.Now().UnixMilli()
 }
@@ -92,3 +98,55 @@ func HashSHA512(data string) string {
 	hash := sha512.Sum512([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
+
+// ParseECPrivateKey 解析 PEM 格式的 EC 私钥（SEC 1 "EC PRIVATE KEY" 或 PKCS#8 "PRIVATE KEY"）
+// 配置文件中的私钥常以字面量 \n 换行，解析前还原为换行符
+func ParseECPrivateKey(pemKey string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(pemKey, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("parse ec private key: no PEM block found")
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse ec private key: %w", err)
+	}
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parse ec private key: unexpected key type %T", parsed)
+	}
+	return key, nil
+}
+
+// SignJWTES256 使用 ES256（ECDSA P-256 + SHA-256）签发 JWT，header 中的 alg 和 typ 由本函数设置
+// 签名为 JWS 要求的 r||s 定长编码（各 32 字节），而不是 ASN.1 DER
+func SignJWTES256(key *ecdsa.PrivateKey, header, claims map[string]interface{}) (string, error) {
+	if key.Curve.Params().BitSize != 256 {
+		return "", fmt.Errorf("sign jwt: ES256 requires a P-256 key, got %s", key.Curve.Params().Name)
+	}
+	fullHeader := map[string]interface{}{"alg": "ES256", "typ": "JWT"}
+	for k, v := range header {
+		fullHeader[k] = v
+	}
+	headerJSON, err := json.Marshal(fullHeader)
+	if err != nil {
+		return "", fmt.Errorf("marshal jwt header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("marshal jwt claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign jwt: %w", err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+)
+
+// OKStatus 返回查询时间为当前时间的正常状态
+func OKStatus() *model.ExchangeStatus {
+	return &model.ExchangeStatus{
+		Status:  model.ExchangeStatusOK,
+		Updated: types.ExTimestamp{Time: time.Now()},
+	}
+}
+
+// UnreachableStatus 状态接口无法访问（请求失败或返回非预期内容，如维护页面）时视为维护中，并附带错误
+// ctx 已取消或超时时返回 ctx 的错误，不视为维护
+func UnreachableStatus(ctx context.Context, err error) (*model.ExchangeStatus, error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return &model.ExchangeStatus{
+		Status:  model.ExchangeStatusMaintenance,
+		Updated: types.ExTimestamp{Time: time.Now()},
+		Err:     err,
+	}, nil
+}
+
+// Ping 通过服务器时间接口（无需签名）检查交易所是否可达
+func Ping(ctx context.Context, fetchTime func(ctx context.Context) (time.Time, error)) error {
+	if _, err := fetchTime(ctx); err != nil {
+		return fmt.Errorf("ping: %w", err)
+	}
+	return nil
+}
+
+// VerifyCredentials 通过一次签名请求（查询现货余额）校验 API 凭证，configured 为 false 时不发送请求并返回 ErrAuthenticationRequired
+// 凭证无效时（401 响应或交易所的凭证错误码）返回的错误匹配 ErrAuthenticationFailed
+func VerifyCredentials(ctx context.Context, configured bool, fetchBalance func(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error)) error {
+	if !configured {
+		return fmt.Errorf("verify credentials: %w", ErrAuthenticationRequired)
+	}
+	if _, err := fetchBalance(ctx); err != nil {
+		return fmt.Errorf("verify credentials: %w", err)
+	}
+	return nil
+}
@@ -5,16 +5,21 @@ import (
 	"strings"
 )
 
+// NormalizeCurrency 标准化币种代码为大写（交易所专有代码由各适配器自行转换，如 Kraken 的 XBT）
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(code)
+}
+
 // NormalizeSymbol 标准化交易对格式为 BASE/QUOTE (如 BTC/USDT)
 func NormalizeSymbol(base, quote string) string {
-	return strings.ToUpper(base) + "/" + strings.ToUpper(quote)
+	return NormalizeCurrency(base) + "/" + NormalizeCurrency(quote)
 }
 
 // NormalizeContractSymbol 标准化合约交易对格式 BASE/QUOTE:SETTLE (如 BTC/USDT:USDT)
 func NormalizeContractSymbol(base, quote, settle string) string {
 	// 对于合约市场，总是包含结算货币
 	if settle != "" {
-		return strings.ToUpper(base) + "/" + strings.ToUpper(quote) + ":" + strings.ToUpper(settle)
+		return NormalizeSymbol(base, quote) + ":" + NormalizeCurrency(settle)
 	}
 	return NormalizeSymbol(base, quote)
 }
@@ -77,6 +82,35 @@ func ToOKXSymbol(symbol string) (string, error) {
 	return base + "-" + quote, nil
 }
 
+// FromOKXSymbol 由 OKX 产品ID解析标准化 symbol（ToOKXSymbol 的逆转换）
+// 现货: BTC-USDT -> BTC/USDT
+// 合约: BTC-USDT-SWAP -> BTC/USDT:USDT，币本位 BTC-USD-SWAP -> BTC/USD:BTC
+func FromOKXSymbol(instID string) (string, error) {
+	parts := strings.Split(strings.ToUpper(instID), "-")
+	switch {
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return NormalizeSymbol(parts[0], parts[1]), nil
+	case len(parts) == 3 && parts[2] == "SWAP" && parts[0] != "" && parts[1] != "":
+		settle := parts[1]
+		if settle == "USD" {
+			settle = parts[0]
+		}
+		return NormalizeContractSymbol(parts[0], parts[1], settle), nil
+	default:
+		return "", fmt.Errorf("invalid OKX instrument ID: %s", instID)
+	}
+}
+
+// FromGateSymbol 由 Gate 交易对解析标准化现货 symbol（现货和合约ID相同，无法区分时按现货处理）
+// BTC_USDT -> BTC/USDT
+func FromGateSymbol(pair string) (string, error) {
+	base, quote, ok := strings.Cut(pair, "_")
+	if !ok || base == "" || quote == "" || strings.Contains(quote, "_") {
+		return "", fmt.Errorf("invalid Gate currency pair: %s", pair)
+	}
+	return NormalizeSymbol(base, quote), nil
+}
+
 // ToGateSymbol 转换为Gate格式
 // 现货: BTC/USDT -> BTC_USDT
 // 合约: BTC/USDT:USDT -> BTC_USDT
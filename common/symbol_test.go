@@ -0,0 +1,77 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestNormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		base, quote, settle string
+		want                string
+	}{
+		{"btc", "usdt", "", "BTC/USDT"},
+		{"XBT", "USD", "", "XBT/USD"}, // 交易所专有代码不在通用层转换
+		{"eth", "usdt", "usdt", "ETH/USDT:USDT"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeContractSymbol(tt.base, tt.quote, tt.settle); got != tt.want {
+			t.Errorf("NormalizeContractSymbol(%q, %q, %q) = %q, want %q", tt.base, tt.quote, tt.settle, got, tt.want)
+		}
+	}
+}
+
+func TestSymbolByMarketID_RoundTrip(t *testing.T) {
+	// Binance 现货和 U 本位合约ID相同
+	spotBySymbol, spotByID := IndexMarkets(model.Markets{{ID: "BTCUSDT", Symbol: "BTC/USDT"}})
+	perpBySymbol, perpByID := IndexMarkets(model.Markets{{ID: "BTCUSDT", Symbol: "BTC/USDT:USDT"}})
+
+	if got, err := SymbolByMarketID("BTCUSDT", spotByID, perpByID, nil); err != nil || got != "BTC/USDT" {
+		t.Errorf("SymbolByMarketID = %q, %v, want BTC/USDT", got, err)
+	}
+	if got, err := MarketIDBySymbol("BTC/USDT", spotBySymbol, perpBySymbol, nil); err != nil || got != "BTCUSDT" {
+		t.Errorf("MarketIDBySymbol = %q, %v, want BTCUSDT", got, err)
+	}
+	if got, err := MarketIDBySymbol("BTC/USDT:USDT", spotBySymbol, perpBySymbol, nil); err != nil || got != "BTCUSDT" {
+		t.Errorf("MarketIDBySymbol(contract) = %q, %v", got, err)
+	}
+
+	// 未加载的市场按交易所格式转换，无转换函数时返回 ErrMarketNotFound
+	if _, err := SymbolByMarketID("ETHUSDT", spotByID, perpByID, nil); !errors.Is(err, ErrMarketNotFound) {
+		t.Errorf("expected ErrMarketNotFound, got %v", err)
+	}
+	if _, err := MarketIDBySymbol("ETH/USDT:USDT", spotBySymbol, perpBySymbol, nil); !errors.Is(err, ErrMarketNotFound) {
+		t.Errorf("expected ErrMarketNotFound, got %v", err)
+	}
+	if got, err := MarketIDBySymbol("ETH/USDT", spotBySymbol, perpBySymbol, ToBinanceSymbol); err != nil || got != "ETHUSDT" {
+		t.Errorf("MarketIDBySymbol fallback = %q, %v", got, err)
+	}
+}
+
+func TestFromOKXSymbol(t *testing.T) {
+	tests := []struct {
+		id, symbol string
+	}{
+		{"BTC-USDT", "BTC/USDT"},
+		{"BTC-USDT-SWAP", "BTC/USDT:USDT"},
+		{"BTC-USD-SWAP", "BTC/USD:BTC"},
+	}
+	for _, tt := range tests {
+		if got, err := FromOKXSymbol(tt.id); err != nil || got != tt.symbol {
+			t.Errorf("FromOKXSymbol(%q) = %q, %v, want %q", tt.id, got, err, tt.symbol)
+		}
+		if got, err := ToOKXSymbol(tt.symbol); err != nil || got != tt.id {
+			t.Errorf("ToOKXSymbol(%q) = %q, %v, want %q", tt.symbol, got, err, tt.id)
+		}
+	}
+	for _, id := range []string{"BTC", "BTC-USD-240329", "-USDT"} {
+		if _, err := FromOKXSymbol(id); err == nil {
+			t.Errorf("FromOKXSymbol(%q): expected error", id)
+		}
+	}
+	if got, err := FromGateSymbol("BTC_USDT"); err != nil || got != "BTC/USDT" {
+		t.Errorf("FromGateSymbol = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,22 @@
+package common
+
+import "github.com/lemconn/exlink/model"
+
+// OrderTickers 按 symbols 顺序排列行情，结果与 symbols 一一对应，缺失的交易对为 nil
+// resolve 用于将交易所格式的 symbol 转换为标准化格式，可为 nil
+func OrderTickers(tickers map[string]*model.Ticker, symbols []string, resolve func(symbol string) (*model.Market, error)) []*model.Ticker {
+	ordered := make([]*model.Ticker, len(symbols))
+	for i, symbol := range symbols {
+		if ticker, ok := tickers[symbol]; ok {
+			ordered[i] = ticker
+			continue
+		}
+		if resolve == nil {
+			continue
+		}
+		if market, err := resolve(symbol); err == nil {
+			ordered[i] = tickers[market.Symbol]
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestOrderTickers(t *testing.T) {
+	tickers := map[string]*model.Ticker{
+		"BTC/USDT": {Symbol: "BTC/USDT"},
+		"ETH/USDT": {Symbol: "ETH/USDT"},
+		"SOL/USDT": {Symbol: "SOL/USDT"},
+	}
+	markets := map[string]*model.Market{
+		"ETHUSDT": {ID: "ETHUSDT", Symbol: "ETH/USDT"},
+	}
+	resolve := func(symbol string) (*model.Market, error) {
+		if m, ok := markets[symbol]; ok {
+			return m, nil
+		}
+		return nil, fmt.Errorf("market not found: %s", symbol)
+	}
+
+	symbols := []string{"SOL/USDT", "DOGE/USDT", "ETHUSDT", "BTC/USDT"}
+	got := OrderTickers(tickers, symbols, resolve)
+
+	if len(got) != len(symbols) {
+		t.Fatalf("got %d tickers, want %d", len(got), len(symbols))
+	}
+
+	want := []string{"SOL/USDT", "", "ETH/USDT", "BTC/USDT"}
+	for i, w := range want {
+		if w == "" {
+			if got[i] != nil {
+				t.Errorf("tickers[%d] = %s, want nil for missing %s", i, got[i].Symbol, symbols[i])
+			}
+			continue
+		}
+		if got[i] == nil || got[i].Symbol != w {
+			t.Errorf("tickers[%d] = %v, want %s", i, got[i], w)
+		}
+	}
+}
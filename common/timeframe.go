@@ -1,6 +1,12 @@
 package common
 
-import "strings"
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // TimeframeMap 时间框架映射表
 var TimeframeMap = map[string]string{
@@ -124,3 +130,54 @@ func GateTimeframe(timeframe string) string {
 		return normalized
 	}
 }
+
+// CheckTimeframe 校验转换后的交易所周期 interval 是否在交易所支持的周期列表 supported 中
+// 不支持时返回包装 ErrNotSupported 的错误，FetchOHLCVs 和 WatchOHLCV 共用，避免不支持的周期发到交易所
+func CheckTimeframe(timeframe, interval string, supported []string) (string, error) {
+	if !slices.Contains(supported, interval) {
+		return "", fmt.Errorf("timeframe %s: %w", timeframe, ErrNotSupported)
+	}
+	return interval, nil
+}
+
+// ParseTimeframe 将标准时间框架（如 1m、4h、1d、1w、1M）转换为时长
+// 支持的单位：s 秒、m 分钟、h 小时、d 天、w 周、M 月（月按 30 天近似，与交易所月线实际跨度可能不同）
+func ParseTimeframe(timeframe string) (time.Duration, error) {
+	if len(timeframe) < 2 {
+		return 0, fmt.Errorf("invalid timeframe %q", timeframe)
+	}
+
+	n, err := strconv.Atoi(timeframe[:len(timeframe)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid timeframe %q: count must be a positive integer", timeframe)
+	}
+
+	var unit time.Duration
+	switch u := timeframe[len(timeframe)-1]; u {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	case 'M':
+		unit = 30 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid timeframe %q: unknown unit %q", timeframe, u)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// TimeframeToMillis 将时间框架转换为毫秒数（规则同 ParseTimeframe）
+func TimeframeToMillis(timeframe string) (int64, error) {
+	d, err := ParseTimeframe(timeframe)
+	if err != nil {
+		return 0, err
+	}
+	return d.Milliseconds(), nil
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeframe(t *testing.T) {
+	tests := []struct {
+		timeframe string
+		want      time.Duration
+	}{
+		{"1s", time.Second},
+		{"10s", 10 * time.Second},
+		{"1m", time.Minute},
+		{"3m", 3 * time.Minute},
+		{"15m", 15 * time.Minute},
+		{"1h", time.Hour},
+		{"4h", 4 * time.Hour},
+		{"1d", 24 * time.Hour},
+		{"3d", 3 * 24 * time.Hour},
+		{"1w", 7 * 24 * time.Hour},
+		{"1M", 30 * 24 * time.Hour},
+		{"3M", 90 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimeframe(tt.timeframe)
+		if err != nil {
+			t.Fatalf("ParseTimeframe(%s): %v", tt.timeframe, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeframe(%s) = %s, want %s", tt.timeframe, got, tt.want)
+		}
+		millis, err := TimeframeToMillis(tt.timeframe)
+		if err != nil {
+			t.Fatalf("TimeframeToMillis(%s): %v", tt.timeframe, err)
+		}
+		if millis != tt.want.Milliseconds() {
+			t.Errorf("TimeframeToMillis(%s) = %d, want %d", tt.timeframe, millis, tt.want.Milliseconds())
+		}
+	}
+
+	// 未知单位、非正数量、格式错误均返回错误
+	for _, timeframe := range []string{"", "m", "1x", "1H", "0m", "-1h", "1.5h", "h1"} {
+		if _, err := ParseTimeframe(timeframe); err == nil {
+			t.Errorf("ParseTimeframe(%q) expected error", timeframe)
+		}
+		if _, err := TimeframeToMillis(timeframe); err == nil {
+			t.Errorf("TimeframeToMillis(%q) expected error", timeframe)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// AggTradeWindow 模拟归集时同一条归集交易覆盖的最大时间跨度
+const AggTradeWindow = 100 * time.Millisecond
+
+// AggregateTrades 将逐笔成交按价格/方向/时间窗口归集（用于不支持原生归集交易的交易所）
+// 相邻且价格、方向相同，并与首笔成交间隔不超过 AggTradeWindow 的成交合并为一条
+// since 非零时忽略更早的成交并保留最早的 limit 条，否则保留最新的 limit 条；limit <= 0 表示不限制
+func AggregateTrades(trades []*model.Trade, since time.Time, limit int) model.AggTrades {
+	sorted := make([]*model.Trade, 0, len(trades))
+	for _, trade := range trades {
+		if trade == nil || (!since.IsZero() && trade.Timestamp.Before(since)) {
+			continue
+		}
+		sorted = append(sorted, trade)
+	}
+	// 交易所通常按时间倒序返回
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	aggs := make(model.AggTrades, 0, len(sorted))
+	var last *model.AggTrade
+	for _, trade := range sorted {
+		if last != nil && last.Side == trade.Side && last.Price.Equal(trade.Price) &&
+			trade.Timestamp.Sub(last.Timestamp.Time) <= AggTradeWindow {
+			last.Amount = types.ExDecimal{Decimal: last.Amount.Add(trade.Amount)}
+			last.LastTradeID = trade.ID
+			last.Count++
+			continue
+		}
+		last = &model.AggTrade{
+			ID:           trade.ID,
+			Symbol:       trade.Symbol,
+			Side:         trade.Side,
+			Price:        types.ExDecimal{Decimal: trade.Price},
+			Amount:       types.ExDecimal{Decimal: trade.Amount},
+			FirstTradeID: trade.ID,
+			LastTradeID:  trade.ID,
+			Count:        1,
+			Timestamp:    types.ExTimestamp{Time: trade.Timestamp},
+		}
+		aggs = append(aggs, last)
+	}
+
+	if limit > 0 && len(aggs) > limit {
+		if since.IsZero() {
+			return aggs[len(aggs)-limit:]
+		}
+		return aggs[:limit]
+	}
+	return aggs
+}
+
+// TradePageFetcher 按游标获取成交时间在 [since, until) 内的一页成交记录，返回下一页游标（为空表示没有更多数据）
+// 游标的含义由交易所决定（如 Binance fromId、OKX after、Bybit cursor），第一页为空字符串
+type TradePageFetcher func(ctx context.Context, since, until time.Time, cursor string) (trades []*model.Trade, next string, err error)
+
+// FetchTradesRange 按游标翻页获取成交时间在 [since, until) 内的成交记录，按成交ID去重后按时间升序返回
+// fetch 返回空游标或重复游标时停止（空页不代表结束，交易所可能按时间窗口翻页）；since 不能为零值，until 为零值时截止到当前时间
+func FetchTradesRange(ctx context.Context, since, until time.Time, fetch TradePageFetcher) ([]*model.Trade, error) {
+	if since.IsZero() {
+		return nil, fmt.Errorf("fetch trades range: since is required")
+	}
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	result := make([]*model.Trade, 0)
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		visited[cursor] = true
+
+		page, next, err := fetch(ctx, since, until, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, trade := range page {
+			if trade == nil || trade.Timestamp.Before(since) || !trade.Timestamp.Before(until) || seen[trade.ID] {
+				continue
+			}
+			seen[trade.ID] = true
+			result = append(result, trade)
+		}
+
+		if next == "" || visited[next] {
+			break
+		}
+		cursor = next
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result, nil
+}
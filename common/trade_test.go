@@ -0,0 +1,111 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/shopspring/decimal"
+)
+
+func TestAggregateTrades(t *testing.T) {
+	base := time.UnixMilli(1700000000000)
+	trade := func(id, side, price, amount string, offset time.Duration) *model.Trade {
+		return &model.Trade{
+			ID:        id,
+			Symbol:    "BTC/USDT",
+			Side:      side,
+			Price:     decimal.RequireFromString(price),
+			Amount:    decimal.RequireFromString(amount),
+			Timestamp: base.Add(offset),
+		}
+	}
+
+	// 按交易所习惯倒序给出
+	trades := []*model.Trade{
+		trade("6", "buy", "100", "1", 500*time.Millisecond),
+		trade("5", "buy", "100", "1", 300*time.Millisecond),
+		trade("4", "sell", "100", "2", 60*time.Millisecond),
+		trade("3", "buy", "101", "1", 50*time.Millisecond),
+		trade("2", "buy", "100", "0.5", 20*time.Millisecond),
+		trade("1", "buy", "100", "0.25", 0),
+	}
+
+	aggs := AggregateTrades(trades, time.Time{}, 0)
+
+	want := []struct {
+		first, last string
+		side        string
+		amount      string
+		count       int
+	}{
+		{"1", "2", "buy", "0.75", 2},
+		{"3", "3", "buy", "1", 1},
+		{"4", "4", "sell", "2", 1},
+		{"5", "5", "buy", "1", 1}, // 与前一笔同价同向但超出时间窗口
+		{"6", "6", "buy", "1", 1},
+	}
+	if len(aggs) != len(want) {
+		t.Fatalf("got %d agg trades, want %d", len(aggs), len(want))
+	}
+	for i, w := range want {
+		got := aggs[i]
+		if got.FirstTradeID != w.first || got.LastTradeID != w.last || got.Side != w.side || got.Count != w.count {
+			t.Errorf("agg[%d] = %+v, want %+v", i, got, w)
+		}
+		if !got.Amount.Equal(decimal.RequireFromString(w.amount)) {
+			t.Errorf("agg[%d] amount = %s, want %s", i, got.Amount.String(), w.amount)
+		}
+	}
+	if !aggs[0].Timestamp.Equal(base) {
+		t.Errorf("agg[0] timestamp = %v, want %v", aggs[0].Timestamp.Time, base)
+	}
+
+	// 未指定 since 时保留最新的 limit 条
+	latest := AggregateTrades(trades, time.Time{}, 2)
+	if len(latest) != 2 || latest[0].FirstTradeID != "5" || latest[1].FirstTradeID != "6" {
+		t.Errorf("latest = %+v, want trades 5 and 6", latest)
+	}
+
+	// 指定 since 时过滤更早的成交并保留最早的 limit 条
+	fromSince := AggregateTrades(trades, base.Add(50*time.Millisecond), 2)
+	if len(fromSince) != 2 || fromSince[0].FirstTradeID != "3" || fromSince[1].FirstTradeID != "4" {
+		t.Errorf("fromSince = %+v, want trades 3 and 4", fromSince)
+	}
+}
+
+func TestFetchTradesRange(t *testing.T) {
+	base := time.UnixMilli(1700000000000)
+	trade := func(id string, offset time.Duration) *model.Trade {
+		return &model.Trade{ID: id, Symbol: "BTC/USDT", Timestamp: base.Add(offset)}
+	}
+	// 两页游标，第二页与第一页重叠一条，第三页为空但游标重复
+	pages := map[string]struct {
+		trades []*model.Trade
+		next   string
+	}{
+		"":   {[]*model.Trade{trade("3", 3*time.Second), trade("2", 2*time.Second), trade("0", -time.Second)}, "c2"},
+		"c2": {[]*model.Trade{trade("2", 2*time.Second), trade("4", 4*time.Second), trade("9", time.Hour)}, "c3"},
+		"c3": {nil, "c2"},
+	}
+	var cursors []string
+	trades, err := FetchTradesRange(context.Background(), base, base.Add(time.Minute), func(ctx context.Context, since, until time.Time, cursor string) ([]*model.Trade, string, error) {
+		cursors = append(cursors, cursor)
+		return pages[cursor].trades, pages[cursor].next, nil
+	})
+	if err != nil {
+		t.Fatalf("FetchTradesRange: %v", err)
+	}
+
+	var ids []string
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	if len(ids) != 3 || ids[0] != "2" || ids[1] != "3" || ids[2] != "4" {
+		t.Errorf("trade IDs = %v, want [2 3 4]", ids)
+	}
+	if len(cursors) != 3 {
+		t.Errorf("cursors = %q, want 3 pages", cursors)
+	}
+}
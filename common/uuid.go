@@ -2,6 +2,7 @@ package common
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -67,3 +68,23 @@ func GenerateClientOrderID(exchange string, side string) string {
 
 	return orderID
 }
+
+// ErrClientOrderIDMismatch 交易所回传的客户端订单ID与请求不一致
+var ErrClientOrderIDMismatch = errors.New("client order id mismatch")
+
+// CheckClientOrderID 校验交易所回传的客户端订单ID（用于 option.WithStrictClientID）
+func CheckClientOrderID(requested, returned string) error {
+	if requested == returned {
+		return nil
+	}
+	return fmt.Errorf("%w: sent %q, got %q", ErrClientOrderIDMismatch, requested, returned)
+}
+
+// NewUUID 生成随机 UUID（v4），用于要求 UUID 格式的请求ID（如 Bybit 划转ID）
+func NewUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
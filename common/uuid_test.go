@@ -1,6 +1,7 @@
 package common
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/lemconn/exlink/option"
@@ -47,3 +48,14 @@ func TestGenerateClientOrderID(t *testing.T) {
 		uniqueIDs[id] = true
 	}
 }
+
+func TestNewUUID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := NewUUID(), NewUUID()
+	if !pattern.MatchString(a) {
+		t.Errorf("NewUUID() = %s, want v4 UUID", a)
+	}
+	if a == b {
+		t.Errorf("NewUUID() returned duplicate %s", a)
+	}
+}
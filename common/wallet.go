@@ -0,0 +1,101 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+// SplitWithdrawParams 从提币参数中取出地址标签（params["tag"]），返回标签和不含 tag 的参数副本
+func SplitWithdrawParams(params map[string]interface{}) (string, map[string]interface{}) {
+	rest := make(map[string]interface{}, len(params))
+	tag := ""
+	for k, v := range params {
+		if k == "tag" {
+			if v != nil {
+				tag = fmt.Sprint(v)
+			}
+			continue
+		}
+		rest[k] = v
+	}
+	return tag, rest
+}
+
+// UnsupportedTransferError 返回账户组合不支持划转的错误（匹配 ErrNotSupported）
+func UnsupportedTransferError(exchange string, from, to option.AccountType) error {
+	return fmt.Errorf("transfer from %s to %s: %w: %s", from, to, ErrNotSupported, exchange)
+}
+
+// NewCurrency 根据网络列表创建币种信息，汇总充提状态和默认网络的提币手续费
+func NewCurrency(code, name string, networks []model.CurrencyNetwork) *model.Currency {
+	currency := &model.Currency{Code: code, Name: name, Networks: networks}
+	for i, network := range networks {
+		currency.DepositEnabled = currency.DepositEnabled || network.DepositEnabled
+		currency.WithdrawEnabled = currency.WithdrawEnabled || network.WithdrawEnabled
+		if i == 0 || network.Default {
+			currency.WithdrawFee = network.WithdrawFee
+		}
+	}
+	return currency
+}
+
+// tokenStandardNetworks 代币标准到链标识的对应关系（如 TRC20 -> TRX），交易所按链标识命名网络时用于匹配
+var tokenStandardNetworks = map[string]string{
+	"ERC20": "ETH",
+	"TRC20": "TRX",
+	"BEP20": "BSC",
+	"SPL":   "SOL",
+}
+
+// FindCurrencyNetwork 查找币种的充提网络，network 为空时返回默认网络（没有默认网络时取第一个网络）
+// 依次按网络标识、网络名称（不区分大小写）匹配，代币标准（如 TRC20）匹配对应链标识（TRX）
+func FindCurrencyNetwork(currency *model.Currency, network string) (model.CurrencyNetwork, bool) {
+	if len(currency.Networks) == 0 {
+		return model.CurrencyNetwork{}, false
+	}
+	if network == "" {
+		for _, n := range currency.Networks {
+			if n.Default {
+				return n, true
+			}
+		}
+		return currency.Networks[0], true
+	}
+
+	candidates := []string{network}
+	if chain, ok := tokenStandardNetworks[strings.ToUpper(network)]; ok {
+		candidates = append(candidates, chain)
+	}
+	for _, candidate := range candidates {
+		for _, n := range currency.Networks {
+			if strings.EqualFold(n.Network, candidate) || strings.EqualFold(n.Name, candidate) {
+				return n, true
+			}
+		}
+	}
+	return model.CurrencyNetwork{}, false
+}
+
+// WithdrawalFee 根据 FetchCurrencies 返回的网络信息获取提币手续费（币种数量），network 为空时使用默认网络
+// 币种不存在或不支持该网络时返回 ErrNotSupported
+func WithdrawalFee(ctx context.Context, fetchCurrencies func(context.Context) (map[string]*model.Currency, error), currency, network string) (decimal.Decimal, error) {
+	currencies, err := fetchCurrencies(ctx)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("fetch withdrawal fee: %w", err)
+	}
+	code := strings.ToUpper(currency)
+	c, ok := currencies[code]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("fetch withdrawal fee: currency %s: %w", code, ErrNotSupported)
+	}
+	n, ok := FindCurrencyNetwork(c, network)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("fetch withdrawal fee: %s on network %s: %w", code, network, ErrNotSupported)
+	}
+	return n.WithdrawFee.Decimal, nil
+}
@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/lemconn/exlink/model"
+)
+
+func TestFindCurrencyNetwork(t *testing.T) {
+	usdt := &model.Currency{Code: "USDT", Networks: []model.CurrencyNetwork{
+		{Network: "ETH", Name: "Ethereum (ERC20)"},
+		{Network: "TRC20", Name: "USDT-TRC20", Default: true},
+		{Network: "BSC", Name: "BNB Smart Chain (BEP20)"},
+	}}
+
+	tests := []struct {
+		network, want string
+		ok            bool
+	}{
+		{"", "TRC20", true},
+		{"eth", "ETH", true},
+		{"ERC20", "ETH", true},
+		{"USDT-TRC20", "TRC20", true},
+		{"BEP20", "BSC", true},
+		{"SOL", "", false},
+	}
+	for _, tt := range tests {
+		n, ok := FindCurrencyNetwork(usdt, tt.network)
+		if ok != tt.ok || n.Network != tt.want {
+			t.Errorf("FindCurrencyNetwork(%q) = %q, %v, want %q, %v", tt.network, n.Network, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, ok := FindCurrencyNetwork(&model.Currency{Code: "BTC"}, ""); ok {
+		t.Error("currency without networks should not match")
+	}
+}
@@ -0,0 +1,588 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+const (
+	// wsSubscriptionBuffer 每个订阅的消息缓冲大小
+	wsSubscriptionBuffer = 256
+	// wsHandshakeTimeout 建立连接的握手超时
+	wsHandshakeTimeout = 10 * time.Second
+	// wsWriteTimeout 发送消息超时
+	wsWriteTimeout = 10 * time.Second
+	// wsReadTimeout 超过该时间未收到任何消息时视为连接已断开
+	wsReadTimeout = 60 * time.Second
+	// wsReconnectMinDelay 断线重连的初始等待时间
+	wsReconnectMinDelay = 500 * time.Millisecond
+	// wsReconnectMaxDelay 断线重连的最大等待时间
+	wsReconnectMaxDelay = 30 * time.Second
+	// wsHeartbeatInterval 心跳间隔（推送稀疏时需主动心跳避免读超时和被交易所断开，Bybit 要求 20 秒）
+	wsHeartbeatInterval = 20 * time.Second
+)
+
+// ErrWSClosed WebSocket 管理器已关闭
+var ErrWSClosed = errors.New("websocket manager closed")
+
+// WSConn WebSocket 连接
+type WSConn interface {
+	// ReadMessage 读取一条消息，连接断开时返回错误
+	ReadMessage() ([]byte, error)
+	// WriteMessage 发送一条文本消息
+	WriteMessage(data []byte) error
+	// Close 关闭连接
+	Close() error
+}
+
+// WSDialer 建立 WebSocket 连接
+type WSDialer func(ctx context.Context) (WSConn, error)
+
+// WSProtocol 交易所 WebSocket 订阅协议
+type WSProtocol interface {
+	// SubscribeMessage 构建订阅消息，返回 nil 时不发送（如连接建立即推送、无需订阅的数据流）
+	SubscribeMessage(topics []string) ([]byte, error)
+	// UnsubscribeMessage 构建取消订阅消息，返回 nil 时不发送
+	UnsubscribeMessage(topics []string) ([]byte, error)
+	// Route 解析推送消息所属的主题，订阅确认、心跳等非数据消息返回 false
+	Route(msg []byte) (topic string, ok bool)
+}
+
+// WSHeartbeat 需要客户端主动发送心跳的协议实现该接口，连接建立后每 wsHeartbeatInterval 发送一次 PingMessage
+// 服务端发送 ping 帧的交易所（如 Binance）无需实现，wsConn 自动回复 pong
+type WSHeartbeat interface {
+	PingMessage() []byte
+}
+
+// WSStateHandler 连接状态变化回调，在管理器的锁外同步调用，不应阻塞
+type WSStateHandler = func(event model.StreamEvent)
+
+// WSManager 在单个 WebSocket 连接上复用多个主题订阅
+// 首次订阅时建立连接；新增主题时发送增量订阅消息，最后一个订阅者取消时发送取消订阅消息，均不重连。
+// 推送消息按 WSProtocol.Route 解析的主题分发给对应订阅者。
+type WSManager struct {
+	dial     WSDialer
+	protocol WSProtocol
+
+	exchange string
+	stream   string
+	onState  WSStateHandler
+
+	mu     sync.Mutex
+	conn   WSConn
+	subs   map[string]map[*WSSubscription]struct{}
+	closed bool
+}
+
+// NewWSManager 创建 WebSocket 订阅管理器
+func NewWSManager(dial WSDialer, protocol WSProtocol) *WSManager {
+	return &WSManager{
+		dial:     dial,
+		protocol: protocol,
+		subs:     make(map[string]map[*WSSubscription]struct{}),
+	}
+}
+
+// SetStateHandler 设置连接状态变化回调，exchange 和 stream 填入事件用于区分连接，需在首次订阅前调用
+func (m *WSManager) SetStateHandler(exchange, stream string, fn WSStateHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exchange, m.stream, m.onState = exchange, stream, fn
+}
+
+// notify 调用状态回调，调用方不能持有锁
+func (m *WSManager) notify(state model.StreamState, err error) {
+	m.mu.Lock()
+	fn := m.onState
+	event := model.StreamEvent{Exchange: m.exchange, Stream: m.stream, State: state, Err: err, Timestamp: types.ExTimestamp{Time: time.Now()}}
+	m.mu.Unlock()
+	if fn != nil {
+		fn(event)
+	}
+}
+
+// WSSubscription 单个主题订阅
+type WSSubscription struct {
+	// C 推送消息通道，取消订阅或连接断开后关闭
+	C <-chan []byte
+
+	topic   string
+	ch      chan []byte
+	manager *WSManager
+}
+
+// Topic 订阅的主题
+func (s *WSSubscription) Topic() string {
+	return s.topic
+}
+
+// Unsubscribe 取消订阅，该主题没有其他订阅者时向交易所发送取消订阅消息
+func (s *WSSubscription) Unsubscribe() error {
+	return s.manager.unsubscribe(s)
+}
+
+// Subscribe 订阅主题，必要时建立连接
+// 订阅者消费过慢导致缓冲区满时，新消息会被丢弃，避免阻塞同一连接上的其他订阅
+func (m *WSManager) Subscribe(ctx context.Context, topic string) (*WSSubscription, error) {
+	return m.subscribe(ctx, topic, false)
+}
+
+// SubscribeRefresh 同 Subscribe，但无论主题是否已有订阅者都向交易所发送订阅消息（已订阅时先发送取消订阅消息），
+// 用于全量数据只在订阅后推送一次的主题（如 Bybit、OKX 深度），使新订阅者也能收到全量推送；
+// 该全量推送同样会分发给主题的其他订阅者
+func (m *WSManager) SubscribeRefresh(ctx context.Context, topic string) (*WSSubscription, error) {
+	return m.subscribe(ctx, topic, true)
+}
+
+// subscribe 订阅主题，refresh 为 true 时主题已有订阅者也重新发送订阅消息
+func (m *WSManager) subscribe(ctx context.Context, topic string, refresh bool) (*WSSubscription, error) {
+	connected := false
+	defer func() {
+		if connected {
+			m.notify(model.StreamConnected, nil)
+		}
+	}()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrWSClosed
+	}
+
+	if m.conn == nil {
+		conn, err := m.dial(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("dial websocket: %w", err)
+		}
+		m.conn = conn
+		connected = true
+		go m.readLoop(conn)
+		if hb, ok := m.protocol.(WSHeartbeat); ok {
+			go m.heartbeatLoop(conn, hb)
+		}
+	}
+
+	if len(m.subs[topic]) > 0 && refresh {
+		msg, err := m.protocol.UnsubscribeMessage([]string{topic})
+		if err != nil {
+			return nil, fmt.Errorf("build unsubscribe message: %w", err)
+		}
+		if msg != nil {
+			if err := m.conn.WriteMessage(msg); err != nil {
+				return nil, fmt.Errorf("unsubscribe %s: %w", topic, err)
+			}
+		}
+	}
+	if len(m.subs[topic]) == 0 || refresh {
+		msg, err := m.protocol.SubscribeMessage([]string{topic})
+		if err != nil {
+			return nil, fmt.Errorf("build subscribe message: %w", err)
+		}
+		if msg != nil {
+			if err := m.conn.WriteMessage(msg); err != nil {
+				return nil, fmt.Errorf("subscribe %s: %w", topic, err)
+			}
+		}
+		if m.subs[topic] == nil {
+			m.subs[topic] = make(map[*WSSubscription]struct{})
+		}
+	}
+
+	ch := make(chan []byte, wsSubscriptionBuffer)
+	sub := &WSSubscription{C: ch, topic: topic, ch: ch, manager: m}
+	m.subs[topic][sub] = struct{}{}
+	return sub, nil
+}
+
+// CloseWSManagers 依次关闭订阅管理器（跳过 nil），错误合并返回
+func CloseWSManagers(managers ...*WSManager) error {
+	var errs []error
+	for _, m := range managers {
+		if m == nil {
+			continue
+		}
+		if err := m.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Topics 返回当前已订阅的主题数量
+func (m *WSManager) Topics() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.subs)
+}
+
+// Close 关闭连接并结束所有订阅
+func (m *WSManager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	active := m.conn != nil
+	err := m.resetLocked(m.conn)
+	m.mu.Unlock()
+
+	if active {
+		m.notify(model.StreamClosed, nil)
+	}
+	return err
+}
+
+// unsubscribe 移除订阅，主题无订阅者时发送取消订阅消息
+func (m *WSManager) unsubscribe(sub *WSSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subs, ok := m.subs[sub.topic]
+	if !ok {
+		return nil
+	}
+	if _, ok := subs[sub]; !ok {
+		return nil
+	}
+	delete(subs, sub)
+	close(sub.ch)
+
+	if len(subs) > 0 {
+		return nil
+	}
+	delete(m.subs, sub.topic)
+
+	if m.conn == nil {
+		return nil
+	}
+	msg, err := m.protocol.UnsubscribeMessage([]string{sub.topic})
+	if err != nil {
+		return fmt.Errorf("build unsubscribe message: %w", err)
+	}
+	if msg == nil {
+		return nil
+	}
+	if err := m.conn.WriteMessage(msg); err != nil {
+		return fmt.Errorf("unsubscribe %s: %w", sub.topic, err)
+	}
+	return nil
+}
+
+// readLoop 读取连接消息并按主题分发，连接断开后结束所有订阅
+func (m *WSManager) readLoop(conn WSConn) {
+	for {
+		msg, err := conn.ReadMessage()
+		if err != nil {
+			m.mu.Lock()
+			lost := conn == m.conn && !m.closed
+			_ = m.resetLocked(conn)
+			m.mu.Unlock()
+			if lost {
+				m.notify(model.StreamDisconnected, err)
+			}
+			return
+		}
+
+		topic, ok := m.protocol.Route(msg)
+		if !ok {
+			continue
+		}
+
+		m.mu.Lock()
+		for sub := range m.subs[topic] {
+			select {
+			case sub.ch <- msg:
+			default:
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// heartbeatLoop 定时发送心跳，连接被重置或发送失败后结束
+func (m *WSManager) heartbeatLoop(conn WSConn, hb WSHeartbeat) {
+	ticker := time.NewTicker(wsHeartbeatInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		m.mu.Lock()
+		current := m.conn
+		m.mu.Unlock()
+		if current != conn {
+			return
+		}
+		if err := conn.WriteMessage(hb.PingMessage()); err != nil {
+			return
+		}
+	}
+}
+
+// resetLocked 关闭连接并结束所有订阅（调用方需持有锁）
+// conn 与当前连接不一致时说明已被重置，直接返回
+func (m *WSManager) resetLocked(conn WSConn) error {
+	if conn == nil || conn != m.conn {
+		return nil
+	}
+
+	for topic, subs := range m.subs {
+		for sub := range subs {
+			close(sub.ch)
+		}
+		delete(m.subs, topic)
+	}
+	m.conn = nil
+	return conn.Close()
+}
+
+// NewWSDialer 创建连接到 wsURL 的拨号函数，proxyURL 非空时通过代理连接（与 REST 请求相同的 http/https/socks5 代理）
+// 代理地址无效时每次拨号都返回错误
+func NewWSDialer(wsURL, proxyURL string) WSDialer {
+	dialer, dialerErr := newWebsocketDialer(proxyURL)
+	return func(ctx context.Context) (WSConn, error) {
+		if dialerErr != nil {
+			return nil, dialerErr
+		}
+		conn, _, err := dialer.DialContext(ctx, wsURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		return newWSConn(conn), nil
+	}
+}
+
+// newWebsocketDialer 创建 WebSocket 拨号器，proxyURL 非空时设置代理
+// gorilla/websocket 的 SOCKS5 拨号器把主机名交给代理解析，socks5h 按 socks5 处理
+func newWebsocketDialer(proxyURL string) (*websocket.Dialer, error) {
+	dialer := &websocket.Dialer{HandshakeTimeout: wsHandshakeTimeout}
+	if proxyURL == "" {
+		return dialer, nil
+	}
+	proxy, err := ParseProxyURL(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	if proxy.Scheme == "socks5h" {
+		socks := *proxy
+		socks.Scheme = "socks5"
+		proxy = &socks
+	}
+	dialer.Proxy = http.ProxyURL(proxy)
+	return dialer, nil
+}
+
+// WSLogin 私有频道鉴权，在连接建立后、发送订阅消息前调用
+type WSLogin func(ctx context.Context, conn WSConn) error
+
+// NewAuthWSDialer 创建需要鉴权的拨号函数：每次建立连接（包括断线重连）后先调用 login 完成登录，
+// 登录失败时关闭连接并返回错误
+func NewAuthWSDialer(dial WSDialer, login WSLogin) WSDialer {
+	return func(ctx context.Context) (WSConn, error) {
+		conn, err := dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := login(ctx, conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("websocket login: %w", err)
+		}
+		return conn, nil
+	}
+}
+
+// WSLoginRequest 发送登录消息并等待登录结果
+// result 判断收到的消息是否为登录结果（done）及登录是否失败，登录结果之前的其他消息被丢弃
+func WSLoginRequest(conn WSConn, msg []byte, result func(msg []byte) (done bool, err error)) error {
+	if err := conn.WriteMessage(msg); err != nil {
+		return err
+	}
+	for {
+		resp, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if done, err := result(resp); done {
+			return err
+		}
+	}
+}
+
+// wsConn 基于 gorilla/websocket 的 WSConn 实现
+type wsConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex // 串行化写操作
+}
+
+// newWSConn 包装连接，收到服务端 ping 帧时回复 pong 并延长读超时（Binance 以 ping 帧保活，推送稀疏时避免误判断线）
+func newWSConn(conn *websocket.Conn) *wsConn {
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(wsReadTimeout))
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(wsWriteTimeout))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+	return &wsConn{conn: conn}
+}
+
+// ReadMessage 读取一条消息，超过 wsReadTimeout 未收到消息时返回超时错误
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	if err := c.conn.SetReadDeadline(time.Now().Add(wsReadTimeout)); err != nil {
+		return nil, err
+	}
+	_, data, err := c.conn.ReadMessage()
+	return data, err
+}
+
+// WriteMessage 发送一条文本消息
+func (c *wsConn) WriteMessage(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close 关闭连接
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
+
+// WSParser 将主题推送消息解析为 T，ok 为 false 时跳过该消息
+type WSParser[T any] func(msg []byte) (v T, ok bool)
+
+// WSResync 断线重新订阅成功后调用，用于通过 REST 补齐断线期间遗漏的数据，ok 为 false 时不推送
+type WSResync[T any] func(ctx context.Context) (v T, ok bool)
+
+// WatchTopic 订阅主题并推送解析后的数据
+// 首次订阅失败时直接返回错误；之后连接断开时按带随机抖动的指数退避重连并重新订阅同一主题，
+// 通道在 ctx 取消后关闭并取消订阅。
+func WatchTopic[T any](ctx context.Context, m *WSManager, topic string, parse WSParser[T]) (<-chan T, error) {
+	return WatchTopicResync(ctx, m, topic, parse, nil)
+}
+
+// WatchTopicResync 同 WatchTopic，每次断线重新订阅成功后先调用 resync，其结果在后续推送之前发送
+func WatchTopicResync[T any](ctx context.Context, m *WSManager, topic string, parse WSParser[T], resync WSResync[T]) (<-chan T, error) {
+	sub, err := m.Subscribe(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+
+		delay := wsReconnectMinDelay
+		for {
+			if !forwardTopic(ctx, sub, parse, ch, &delay) {
+				_ = sub.Unsubscribe()
+				return
+			}
+
+			// 连接断开，按指数退避重新订阅，等待时间加入随机抖动避免多个订阅同时重连
+			for {
+				timer := time.NewTimer(wsJitter(delay))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+
+				if delay *= 2; delay > wsReconnectMaxDelay {
+					delay = wsReconnectMaxDelay
+				}
+				if sub, err = m.Subscribe(ctx, topic); err == nil {
+					break
+				}
+				if errors.Is(err, ErrWSClosed) {
+					return
+				}
+			}
+
+			if resync == nil {
+				continue
+			}
+			if v, ok := resync(ctx); ok {
+				select {
+				case ch <- v:
+				case <-ctx.Done():
+					_ = sub.Unsubscribe()
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// wsJitter 返回 [delay/2, delay) 之间的随机等待时间
+func wsJitter(delay time.Duration) time.Duration {
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+// WatchTopicItems 同 WatchTopic，parse 将一条推送解析为多条数据（如一次推送多个订单），按顺序逐条发送
+func WatchTopicItems[T any](ctx context.Context, m *WSManager, topic string, parse WSParser[[]T]) (<-chan T, error) {
+	batches, err := WatchTopic(ctx, m, topic, parse)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for batch := range batches {
+			for _, v := range batch {
+				select {
+				case ch <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// forwardTopic 转发订阅消息直到连接断开（返回 true）或 ctx 取消（返回 false）
+// 收到消息后将重连等待时间重置为初始值
+func forwardTopic[T any](ctx context.Context, sub *WSSubscription, parse WSParser[T], ch chan<- T, delay *time.Duration) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case msg, ok := <-sub.C:
+			if !ok {
+				return true
+			}
+			*delay = wsReconnectMinDelay
+
+			v, ok := parse(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return false
+			}
+		}
+	}
+}
@@ -1,5 +1,12 @@
 package exchange
 
+import (
+	"context"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
 // Exchange 顶层交易所接口
 type Exchange interface {
 	// Spot 获取现货交易接口
@@ -10,4 +17,54 @@ type Exchange interface {
 
 	// Name 返回交易所名称
 	Name() string
+
+	// Has 返回交易所支持的功能，可在调用前判断功能是否可用
+	Has() model.Capabilities
+
+	// FetchTime 获取交易所服务器时间
+	FetchTime(ctx context.Context) (time.Time, error)
+
+	// FetchStatus 获取交易所系统状态（ok/maintenance），状态接口无法访问时返回 maintenance 并在 Err 中附带错误
+	FetchStatus(ctx context.Context) (*model.ExchangeStatus, error)
+
+	// Ping 通过服务器时间接口（无需签名）检查交易所是否可达
+	Ping(ctx context.Context) error
+
+	// VerifyCredentials 通过一次轻量签名请求（查询现货余额）校验 API 凭证，适合启动时快速失败
+	// 凭证无效时返回的错误匹配 common.ErrAuthenticationFailed，未配置凭证时返回 common.ErrAuthenticationRequired
+	VerifyCredentials(ctx context.Context) error
+
+	// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成，之后的请求使用新凭证
+	// password 仅用于需要 password 的交易所（如 OKX），其他交易所忽略
+	UpdateCredentials(apiKey, secretKey, password string)
+
+	// Symbols 返回已加载的现货和合约市场的标准化 symbol（按字母排序），未加载市场时为空
+	Symbols() []string
+
+	// NormalizeSymbol 将交易所原始市场ID（如 BTCUSDT、BTC-USDT-SWAP）转换为标准化 symbol（如 BTC/USDT、BTC/USDT:USDT）
+	// 先查已加载的现货市场再查合约市场，现货和合约ID相同时返回现货 symbol；市场未加载时按交易所格式解析（OKX、Gate），无法解析时返回 common.ErrMarketNotFound
+	NormalizeSymbol(nativeID string) (string, error)
+
+	// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID，带 :结算货币 的 symbol 按合约市场转换
+	// 市场未加载时按交易所格式转换（Binance、Bybit、OKX、Gate），无法转换时返回 common.ErrMarketNotFound
+	DenormalizeSymbol(symbol string) (string, error)
+
+	// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存后在启动时通过 ImportMarkets 恢复，避免请求交易所
+	ExportMarkets() ([]byte, error)
+
+	// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场；数据来自其他交易所时返回错误
+	ImportMarkets(data []byte) error
+
+	// Drain 优雅关闭：停止接受新的轮询订阅（PollOHLCV、WatchOHLCV、WatchTicker、WatchOrderBook、WatchPositions、WatchOrders、WatchBalance、TrackOrder），等待进行中的请求完成（最长到 ctx 截止）
+	// 通过 option.WithCancelOrdersOnDrain 启用后，还会撤销通过本实例创建且仍未结束的订单
+	Drain(ctx context.Context) error
+}
+
+// Placeholder 占位实现接口（可选能力）：交易所未接入某个市场类型时，Spot() 或 Perp() 返回的占位实现会实现该接口，其所有操作都返回 common.ErrNotSupported
+// 通过类型断言判断是否为占位实现：
+//
+//	if p, ok := ex.Perp().(exchange.Placeholder); ok { return p.NotSupported() }
+type Placeholder interface {
+	// NotSupported 返回说明该市场类型未接入的错误（包装 common.ErrNotSupported）
+	NotSupported() error
 }
@@ -0,0 +1,23 @@
+package exchange
+
+import (
+	"context"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+// GridTrader 网格策略接口（可选能力，目前仅 OKX 现货和永续合约支持）
+// 通过类型断言判断是否支持：
+//
+//	if grid, ok := ex.Spot().(exchange.GridTrader); ok { ... }
+type GridTrader interface {
+	// CreateGridOrder 创建网格策略
+	CreateGridOrder(ctx context.Context, symbol string, params option.GridParams) (*model.AlgoOrder, error)
+
+	// StopGridOrder 停止网格策略
+	StopGridOrder(ctx context.Context, symbol string, algoID string) error
+
+	// FetchGridOrder 查询网格策略
+	FetchGridOrder(ctx context.Context, symbol string, algoID string) (*model.AlgoOrder, error)
+}
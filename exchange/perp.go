@@ -2,9 +2,12 @@ package exchange
 
 import (
 	"context"
+	"time"
 
 	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // PerpExchange 永续合约交易接口
@@ -20,40 +23,136 @@ type PerpExchange interface {
 	// GetMarket 获取单个市场信息
 	GetMarket(symbol string) (*model.Market, error)
 
+	// GetMarketByID 按交易所原始市场ID（如 BTCUSDT、BTC-USDT-SWAP）获取已加载的市场信息，只匹配ID不匹配标准化 symbol
+	GetMarketByID(id string) (*model.Market, error)
+
+	// AmountToPrecision 将数量向下对齐到市场的数量步长（步长未知时按精度截断），低于最小下单量时返回 common.ErrInvalidOrder
+	AmountToPrecision(symbol, amount string) (string, error)
+
+	// PriceToPrecision 将价格向下对齐到市场的价格步长（步长未知时按精度截断）
+	PriceToPrecision(symbol, price string) (string, error)
+
+	// AmountToContracts 将币数量按合约面值（Market.ContractValue）换算为张数，并向下对齐到张数步长，低于最小下单张数时返回 common.ErrInvalidOrder
+	// Binance、Bybit 线性合约面值为 1，结果与 AmountToPrecision 相同；币本位合约返回错误
+	AmountToContracts(symbol, amount string) (string, error)
+
+	// ContractsToAmount 将合约张数按合约面值换算为币数量；币本位合约返回错误
+	ContractsToAmount(symbol, contracts string) (string, error)
+
 	// FetchTicker 获取行情（单个）
 	FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error)
 
 	// FetchTickers 批量获取行情
 	FetchTickers(ctx context.Context, opts ...option.ArgsOption) (model.Tickers, error)
 
-	// FetchOrderBook 获取订单簿
-	// FetchOrderBook(ctx context.Context, symbol string, limit ...int) (*types.OrderBook, error)
-	// TODO: 添加 OrderBook 类型到 types 包后启用
+	// FetchOrderBook 获取订单簿深度，limit 为每侧档位数（<= 0 使用交易所默认值，超出范围时调整为交易所支持的档位）
+	FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error)
+
+	// WatchTicker 通过 WebSocket 订阅行情，每次推送发送最新行情；断线后自动重连并重新订阅，ctx 取消后关闭通道
+	WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error)
+
+	// WatchOrderBook 通过 WebSocket 订阅深度并维护本地订单簿，每次应用推送后发送前 depth 档的完整订单簿（depth <= 0 时发送全部档位）
+	// 推送序号不连续时自动重新同步；断线后自动重连，ctx 取消后关闭通道
+	WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error)
 
 	// FetchOHLCVs 获取K线数据
 	FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error)
 
+	// FetchOHLCVRange 分页获取开盘时间在 [since, until) 内的K线（按开盘时间升序、去重），until 为零值时截止到当前时间
+	// 某一页无数据时停止；每页请求均经过限流器
+	FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error)
+
+	// WatchOHLCV 通过 WebSocket 订阅K线，每次更新推送当前K线，收盘K线的 Closed 为 true；断线后自动重连并重新订阅，ctx 取消后关闭通道
+	// 交易所不支持的时间框架返回 common.ErrNotSupported（与 FetchOHLCVs 一致）
+	WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error)
+
+	// PollOHLCV 轮询最新K线（无需 WebSocket），推送K线更新，收盘K线的 Closed 为 true
+	PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error)
+
+	// FetchAggregatedTrades 获取归集交易（Binance 原生支持，其他交易所由逐笔成交按价格/时间窗口归集）
+	FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error)
+
+	// FetchFundingRate 获取当期资金费率及下次结算时间
+	FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error)
+
+	// FetchFundingRateHistory 获取历史资金费率（按结算时间升序），since 为零值时不限制开始时间，limit <= 0 使用交易所默认值
+	FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error)
+
+	// FetchOpenInterest 获取合约当前持仓量及持仓价值，仅支持合约市场
+	FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error)
+
+	// FetchMarkPrice 获取合约标记价格，返回的行情中 MarkPrice、IndexPrice 为标记价格和指数价格，仅支持合约市场
+	// 其余字段（如 Last）交易所接口未提供时为 0
+	FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error)
+
+	// FetchIndexPrice 获取合约指数价格（标的现货指数），仅支持合约市场
+	FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error)
+
 	// ========== 账户信息 ==========
 
 	// FetchPositions 获取持仓
 	FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error)
 
+	// WatchPositions 轮询持仓（无需 WebSocket），推送相对上一次快照的持仓变化（开仓/加仓/减仓/平仓）
+	WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error)
+
+	// WatchBalance 通过私有 WebSocket 订阅合约账户余额，余额变化时推送最新余额（部分交易所只包含变化的币种）
+	// 断线后自动重连并重新鉴权，ctx 取消后关闭通道
+	WatchBalance(ctx context.Context) (<-chan model.Balances, error)
+
 	// ========== 订单操作 ==========
 
 	// CreateOrder 创建订单
 	CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error)
 
+	// CreateOrders 批量创建订单，返回的订单和错误与 requests 一一对应（成功的订单对应错误为 nil）
+	// 交易所支持批量下单接口时分批提交，否则逐个提交；第三个返回值为第一个整批失败的错误（如网络、鉴权错误）
+	CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error)
+
+	// ClosePosition 以只减仓市价单平掉交易对的持仓：查询当前持仓，按持仓反方向下单，数量为全部持仓或 option.WithClosePercent 指定的比例
+	// 其余选项（如 option.WithClientOrderID）传给 CreateOrder；没有持仓时返回 common.ErrPositionNotFound，双向持仓同时持有多空时返回 common.ErrInvalidOrder
+	ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error)
+
 	// CancelOrder 取消订单
 	CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error
 
-	// FetchOrder 查询订单
+	// EditOrder 修改挂单的数量和/或价格，newAmount、newPrice 为空表示不修改（至少提供一个），返回修改后的订单
+	// 订单ID和客户端订单ID保持不变；orderId 为空时通过 option.WithClientOrderID 指定订单
+	EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error)
+
+	// FetchOrder 查询订单，symbol 为空时仅按订单ID（或客户端订单ID）查询，交易所需要交易对时返回 common.ErrSymbolRequired
 	FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error)
 
+	// FetchOrderByClientID 按客户端订单ID（下单时的 option.WithClientOrderID 或自动生成的ID）查询订单
+	FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error)
+
+	// FetchMyTradesRange 获取账户在 symbol 上成交时间在 [since, until) 内的全部成交记录，按交易所原生游标翻页，按成交ID去重后按时间升序返回
+	// since 不能为零值，until 为零值时截止到当前时间，每页请求都经过限流器；交易所不支持时返回 common.ErrNotSupported
+	FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error)
+
+	// TrackOrder 跟踪订单成交进度（支持私有订单推送的交易所通过 WebSocket，否则轮询），推送增量成交及累计 VWAP，订单进入终态后关闭通道
+	TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error)
+
+	// WatchOrders 通过私有 WebSocket 订阅账户全部合约订单，订单每次状态变化推送最新订单
+	// 断线后自动重连并重新鉴权，ctx 取消后关闭通道
+	WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error)
+
 	// ========== 合约特有功能 ==========
 
 	// SetLeverage 设置杠杆
 	SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error
 
+	// SetLeverageSide 双向持仓模式下单独设置多头或空头的杠杆，另一方向杠杆保持不变
+	// 杠杆超出市场最大杠杆（market.Limits.Leverage.Max）时返回 common.ErrLeverageExceeded；交易所多空共用杠杆时返回 common.ErrNotSupported
+	SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error
+
 	// SetMarginType 设置保证金类型（isolated/cross）
 	SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error
+
+	// SetPositionMode 设置账户持仓模式，hedged 为 true 时为双向持仓（多空分开持仓），false 时为单向持仓
+	// 设置成功后 CreateOrder 未指定 option.WithHedgeMode 时按该模式下单
+	SetPositionMode(ctx context.Context, hedged bool) error
+
+	// GetPositionMode 查询账户持仓模式，true 为双向持仓
+	GetPositionMode(ctx context.Context) (bool, error)
 }
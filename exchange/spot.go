@@ -2,9 +2,11 @@ package exchange
 
 import (
 	"context"
+	"time"
 
 	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
 )
 
 // SpotExchange 现货交易接口
@@ -20,35 +22,145 @@ type SpotExchange interface {
 	// GetMarket 获取单个市场信息
 	GetMarket(symbol string) (*model.Market, error)
 
+	// GetMarketByID 按交易所原始市场ID（如 BTCUSDT、BTC-USDT-SWAP）获取已加载的市场信息，只匹配ID不匹配标准化 symbol
+	GetMarketByID(id string) (*model.Market, error)
+
 	// GetMarkets 从内存中获取所有市场信息
 	GetMarkets() ([]*model.Market, error)
 
+	// AmountToPrecision 将数量向下对齐到市场的数量步长（步长未知时按精度截断），低于最小下单量时返回 common.ErrInvalidOrder
+	AmountToPrecision(symbol, amount string) (string, error)
+
+	// PriceToPrecision 将价格向下对齐到市场的价格步长（步长未知时按精度截断）
+	PriceToPrecision(symbol, price string) (string, error)
+
+	// EstimateOrderCost 下单前估算订单成交金额和手续费，检查是否满足最小/最大数量和金额限制（如 MIN_NOTIONAL），不满足时 Valid 为 false
+	// price 为空时为市价单，按当前行情的对手价估算；手续费率通过 option.WithFeeRate 指定，未指定时手续费为 0
+	EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error)
+
 	// FetchTicker 获取行情（单个）
 	FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error)
 
 	// FetchTickers 批量获取行情
 	FetchTickers(ctx context.Context) (map[string]*model.Ticker, error)
 
-	// FetchOrderBook 获取订单簿
-	// FetchOrderBook(ctx context.Context, symbol string, limit ...int) (*types.OrderBook, error)
-	// TODO: 添加 OrderBook 类型到 types 包后启用
+	// FetchTickersOrdered 批量获取行情，结果与 symbols 顺序一一对应，缺失的交易对为 nil
+	FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error)
+
+	// FetchOrderBook 获取订单簿深度，limit 为每侧档位数（<= 0 使用交易所默认值，超出范围时调整为交易所支持的档位）
+	FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error)
+
+	// WatchTicker 通过 WebSocket 订阅行情，每次推送发送最新行情；断线后自动重连并重新订阅，ctx 取消后关闭通道
+	WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error)
+
+	// WatchOrderBook 通过 WebSocket 订阅深度并维护本地订单簿，每次应用推送后发送前 depth 档的完整订单簿（depth <= 0 时发送全部档位）
+	// 推送序号不连续时自动重新同步；断线后自动重连，ctx 取消后关闭通道
+	WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error)
 
 	// FetchOHLCVs 获取K线数据
 	FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error)
 
+	// FetchOHLCVRange 分页获取开盘时间在 [since, until) 内的K线（按开盘时间升序、去重），until 为零值时截止到当前时间
+	// 某一页无数据时停止；每页请求均经过限流器
+	FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error)
+
+	// WatchOHLCV 通过 WebSocket 订阅K线，每次更新推送当前K线，收盘K线的 Closed 为 true；断线后自动重连并重新订阅，ctx 取消后关闭通道
+	// 交易所不支持的时间框架返回 common.ErrNotSupported（与 FetchOHLCVs 一致）
+	WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error)
+
+	// PollOHLCV 轮询最新K线（无需 WebSocket），推送K线更新，收盘K线的 Closed 为 true
+	PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error)
+
+	// FetchAggregatedTrades 获取归集交易（Binance 原生支持，其他交易所由逐笔成交按价格/时间窗口归集）
+	FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error)
+
 	// ========== 账户信息 ==========
 
-	// FetchBalance 获取余额
-	FetchBalance(ctx context.Context) (model.Balances, error)
+	// FetchBalance 获取余额，默认查询现货账户，通过 option.WithAccountType 查询合约、杠杆或资金账户
+	// 交易所不支持的账户类型返回 common.ErrNotSupported
+	FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error)
+
+	// WatchBalance 通过私有 WebSocket 订阅现货账户余额，余额变化时推送最新余额（部分交易所只包含变化的币种）
+	// 断线后自动重连并重新鉴权，ctx 取消后关闭通道
+	WatchBalance(ctx context.Context) (<-chan model.Balances, error)
 
 	// ========== 订单操作 ==========
 
 	// CreateOrder 创建订单
 	CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error)
 
+	// CreateOrders 批量创建订单，返回的订单和错误与 requests 一一对应（成功的订单对应错误为 nil）
+	// 交易所支持批量下单接口时分批提交，否则逐个提交；第三个返回值为第一个整批失败的错误（如网络、鉴权错误）
+	CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error)
+
+	// CreateOCOOrder 创建 OCO 订单：price 处的限价单与 stopPrice 触发的止损单，一个成交或触发后另一个自动撤销
+	// stopLimitPrice 为止损单触发后的委托价，为空时触发后按市价成交；交易所没有原生 OCO 时返回 common.ErrNotSupported（不做模拟）
+	CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error)
+
 	// CancelOrder 取消订单
 	CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error
 
-	// FetchOrder 查询订单
+	// EditOrder 修改挂单的数量和/或价格，newAmount、newPrice 为空表示不修改（至少提供一个），返回修改后的订单
+	// 订单ID和客户端订单ID保持不变；orderId 为空时通过 option.WithClientOrderID 指定订单
+	EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error)
+
+	// FetchOrder 查询订单，symbol 为空时仅按订单ID（或客户端订单ID）查询，交易所需要交易对时返回 common.ErrSymbolRequired
 	FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error)
+
+	// FetchOrderByClientID 按客户端订单ID（下单时的 option.WithClientOrderID 或自动生成的ID）查询订单
+	FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error)
+
+	// FetchMyTradesRange 获取账户在 symbol 上成交时间在 [since, until) 内的全部成交记录，按交易所原生游标翻页，按成交ID去重后按时间升序返回
+	// since 不能为零值，until 为零值时截止到当前时间，每页请求都经过限流器；交易所不支持时返回 common.ErrNotSupported
+	FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error)
+
+	// TrackOrder 跟踪订单成交进度（支持私有订单推送的交易所通过 WebSocket，否则轮询），推送增量成交及累计 VWAP，订单进入终态后关闭通道
+	TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error)
+
+	// WatchOrders 通过私有 WebSocket 订阅账户全部现货订单，订单每次状态变化（新建、部分成交、成交、撤销等）推送最新订单
+	// 断线后自动重连并重新鉴权，ctx 取消后关闭通道
+	WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error)
+
+	// ========== 闪兑 ==========
+
+	// CreateConversion 闪兑（先询价再确认，from 为卖出币种，to 为买入币种，amount 为卖出数量）
+	CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error)
+
+	// ========== 钱包 ==========
+
+	// FetchCurrencies 获取全部币种的充提状态及支持的网络（按币种代码索引），需要 API 凭证
+	FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error)
+
+	// FetchWithdrawalFee 获取币种在指定网络的提币手续费（币种数量，不同于交易手续费），network 为空时使用默认网络
+	// 网络可传交易所网络标识或代币标准（如 TRC20、ERC20），币种不支持该网络时返回 common.ErrNotSupported
+	FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error)
+
+	// FetchDepositAddress 获取充值地址，network 为空时使用币种的默认网络
+	FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error)
+
+	// Withdraw 提币到链上地址，network 为空时使用币种的默认网络
+	// params 中的 "tag" 为地址标签（memo），其余参数为交易所特有参数，原样透传
+	Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error)
+
+	// Transfer 账户间资金划转（如现货账户与合约账户之间），交易所不支持的账户组合返回 common.ErrNotSupported
+	Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error)
+
+	// ========== 杠杆 ==========
+
+	// FetchBorrowRate 获取全仓杠杆借币利率（每小时），需要 API 凭证
+	FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error)
+
+	// Borrow 全仓杠杆借币，借入的币种计入杠杆账户（OKX、Bybit 为交易账户）
+	Borrow(ctx context.Context, currency, amount string) error
+
+	// Repay 归还全仓杠杆借币（含利息时由交易所按先利息后本金扣除）
+	Repay(ctx context.Context, currency, amount string) error
+
+	// ========== 子账户 ==========
+
+	// FetchSubAccounts 获取母账户下的子账户列表，需要母账户 API 凭证
+	FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error)
+
+	// FetchBalanceFor 获取指定子账户的余额，subAccountID 为 FetchSubAccounts 返回的 ID，需要母账户 API 凭证
+	FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error)
 }
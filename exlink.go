@@ -1,23 +1,36 @@
 package exlink
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/lemconn/exlink/binance"
+	"github.com/lemconn/exlink/bitget"
 	"github.com/lemconn/exlink/bybit"
+	"github.com/lemconn/exlink/coinbase"
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
 	"github.com/lemconn/exlink/gate"
+	"github.com/lemconn/exlink/kraken"
+	"github.com/lemconn/exlink/kucoin"
+	"github.com/lemconn/exlink/mexc"
 	"github.com/lemconn/exlink/okx"
 	"github.com/lemconn/exlink/option"
 )
 
 // 交易所名称常量
 const (
-	ExchangeBinance = "binance" // Binance 交易所
-	ExchangeBybit   = "bybit"   // Bybit 交易所
-	ExchangeOKX     = "okx"     // OKX 交易所
-	ExchangeGate    = "gate"    // Gate 交易所
+	ExchangeBinance  = "binance"  // Binance 交易所
+	ExchangeBybit    = "bybit"    // Bybit 交易所
+	ExchangeOKX      = "okx"      // OKX 交易所
+	ExchangeGate     = "gate"     // Gate 交易所
+	ExchangeKraken   = "kraken"   // Kraken 交易所（仅现货）
+	ExchangeKuCoin   = "kucoin"   // KuCoin 交易所（仅现货）
+	ExchangeBitget   = "bitget"   // Bitget 交易所（现货和 USDT 本位永续合约）
+	ExchangeMEXC     = "mexc"     // MEXC 交易所（仅现货）
+	ExchangeCoinbase = "coinbase" // Coinbase Advanced Trade（仅现货）
 )
 
 // 注意：ExchangeOptions 和 Option 相关定义已迁移到 option/init.go
@@ -42,6 +55,11 @@ func init() {
 	Register(ExchangeBybit, bybit.NewBybit)
 	Register(ExchangeOKX, okx.NewOKX)
 	Register(ExchangeGate, gate.NewGate)
+	Register(ExchangeKraken, kraken.NewKraken)
+	Register(ExchangeKuCoin, kucoin.NewKuCoin)
+	Register(ExchangeBitget, bitget.NewBitget)
+	Register(ExchangeMEXC, mexc.NewMEXC)
+	Register(ExchangeCoinbase, coinbase.NewCoinbase)
 }
 
 // Register 注册交易所
@@ -75,12 +93,64 @@ func NewExchange(name string, opts ...option.Option) (exchange.Exchange, error)
 	if options.BaseURL != "" {
 		optionsMap["baseURL"] = options.BaseURL
 	}
+	if options.SpotBaseURL != "" {
+		optionsMap["baseURL"] = options.SpotBaseURL
+	}
+	if options.PerpBaseURL != "" {
+		optionsMap["perpBaseURL"] = options.PerpBaseURL
+	}
 	if options.Password != "" {
 		optionsMap["password"] = options.Password
 	}
 	if options.Debug {
 		optionsMap["debug"] = options.Debug
 	}
+	if options.Timeout > 0 {
+		optionsMap["timeout"] = options.Timeout
+	}
+	if options.HTTPClient != nil {
+		optionsMap["httpClient"] = options.HTTPClient
+	}
+	if options.CorrelationHeader != "" {
+		optionsMap["correlationHeader"] = options.CorrelationHeader
+	}
+	if options.RequestHook != nil {
+		optionsMap["requestHook"] = options.RequestHook
+	}
+	if options.ResponseHook != nil {
+		optionsMap["responseHook"] = options.ResponseHook
+	}
+	if options.Logger != nil {
+		optionsMap["logger"] = options.Logger
+	}
+	if options.StreamStateHandler != nil {
+		optionsMap["streamStateHandler"] = options.StreamStateHandler
+	}
+	if options.CancelOrdersOnDrain {
+		optionsMap["cancelOrdersOnDrain"] = options.CancelOrdersOnDrain
+	}
+	if options.RateLimitWeight > 0 {
+		mode := common.RateLimitWait
+		if options.RateLimitReject {
+			mode = common.RateLimitReject
+		}
+		optionsMap["rateLimiter"] = common.NewRateLimiter(options.RateLimitWeight, options.RateLimitInterval, mode)
+	}
+	if options.RetryMaxRetries > 0 {
+		optionsMap["retryPolicy"] = common.RetryPolicy{
+			MaxAttempts: options.RetryMaxRetries + 1,
+			Backoff:     options.RetryBaseDelay,
+		}
+	}
+	if options.TimeSync {
+		optionsMap["timeSync"] = options.TimeSync
+	}
+	if options.MarketCacheTTL > 0 {
+		optionsMap["marketCacheTTL"] = options.MarketCacheTTL
+	}
+	if options.RecvWindow > 0 {
+		optionsMap["recvWindow"] = options.RecvWindow
+	}
 	// 合并自定义选项
 	for k, v := range options.Options {
 		optionsMap[k] = v
@@ -103,6 +173,50 @@ func NewExchange(name string, opts ...option.Option) (exchange.Exchange, error)
 	return ex, nil
 }
 
+// NewSpotExchange 创建交易所实例并返回现货交易接口
+// 交易所未接入现货时返回 common.ErrNotSupported，不会返回 nil 或占位实现
+func NewSpotExchange(name string, opts ...option.Option) (exchange.SpotExchange, error) {
+	ex, err := NewExchange(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	spot := ex.Spot()
+	if err := checkMarketHandle(name, "spot", spot); err != nil {
+		ex.Drain(context.Background())
+		return nil, err
+	}
+	return spot, nil
+}
+
+// NewPerpExchange 创建交易所实例并返回永续合约交易接口
+// 交易所未接入永续合约时（如 KuCoin、MEXC、Coinbase）返回 common.ErrNotSupported，不会返回 nil 或占位实现
+func NewPerpExchange(name string, opts ...option.Option) (exchange.PerpExchange, error) {
+	ex, err := NewExchange(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	perp := ex.Perp()
+	if err := checkMarketHandle(name, "perp", perp); err != nil {
+		ex.Drain(context.Background())
+		return nil, err
+	}
+	return perp, nil
+}
+
+// checkMarketHandle 检查 Spot()/Perp() 的返回值可用，nil（包括 nil 指针）或 exchange.Placeholder 占位实现返回 common.ErrNotSupported
+func checkMarketHandle(name, marketType string, handle interface{}) error {
+	if handle == nil {
+		return fmt.Errorf("%s %s: %w", name, marketType, common.ErrNotSupported)
+	}
+	if v := reflect.ValueOf(handle); v.Kind() == reflect.Pointer && v.IsNil() {
+		return fmt.Errorf("%s %s: %w", name, marketType, common.ErrNotSupported)
+	}
+	if placeholder, ok := handle.(exchange.Placeholder); ok {
+		return fmt.Errorf("%s: %w", name, placeholder.NotSupported())
+	}
+	return nil
+}
+
 // GetSupportedExchanges 获取支持的交易所列表
 func GetSupportedExchanges() []string {
 	globalRegistry.mu.RLock()
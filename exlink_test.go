@@ -0,0 +1,188 @@
+package exlink
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/kraken"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+func TestNewSpotPerpExchange(t *testing.T) {
+	// 未接入永续合约的交易所
+	spotOnly := []string{ExchangeKuCoin, ExchangeMEXC, ExchangeCoinbase}
+
+	for _, name := range GetSupportedExchanges() {
+		t.Run(name, func(t *testing.T) {
+			opts := []option.Option{option.WithAPIKey("key"), option.WithSecretKey("secret"), option.WithPassword("pass")}
+
+			// Has 与 NewSpotExchange/NewPerpExchange 的结果一致
+			ex, err := NewExchange(name, opts...)
+			if err != nil {
+				t.Fatalf("NewExchange: %v", err)
+			}
+			if has := ex.Has(); !has.Spot.Supported || has.Perp.Supported == slices.Contains(spotOnly, name) {
+				t.Errorf("Has = %+v", has)
+			}
+
+			spot, err := NewSpotExchange(name, opts...)
+			if err != nil || spot == nil {
+				t.Fatalf("NewSpotExchange = %v, %v", spot, err)
+			}
+			if _, ok := spot.(exchange.Placeholder); ok {
+				t.Errorf("NewSpotExchange returned a placeholder")
+			}
+
+			perp, err := NewPerpExchange(name, opts...)
+			if slices.Contains(spotOnly, name) {
+				if perp != nil || !errors.Is(err, common.ErrNotSupported) {
+					t.Errorf("NewPerpExchange = %v, %v, want ErrNotSupported", perp, err)
+				}
+				return
+			}
+			if err != nil || perp == nil {
+				t.Fatalf("NewPerpExchange = %v, %v", perp, err)
+			}
+			if _, ok := perp.(exchange.Placeholder); ok {
+				t.Errorf("NewPerpExchange returned a placeholder")
+			}
+		})
+	}
+}
+
+func TestNewSpotPerpExchange_Errors(t *testing.T) {
+	if _, err := NewSpotExchange("unknown"); err == nil {
+		t.Error("NewSpotExchange(unknown) error = nil")
+	}
+	if _, err := NewPerpExchange("unknown"); err == nil {
+		t.Error("NewPerpExchange(unknown) error = nil")
+	}
+
+	// Perp() 返回 nil 指针的交易所不会把 nil 包装成非 nil 接口返回
+	Register("nil-perp", func(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+		ex, err := kraken.NewKraken(apiKey, secretKey, options)
+		if err != nil {
+			return nil, err
+		}
+		return nilPerp{ex}, nil
+	})
+	t.Cleanup(func() {
+		globalRegistry.mu.Lock()
+		delete(globalRegistry.factories, "nil-perp")
+		globalRegistry.mu.Unlock()
+	})
+	if perp, err := NewPerpExchange("nil-perp"); perp != nil || !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("NewPerpExchange(nil-perp) = %v, %v, want ErrNotSupported", perp, err)
+	}
+}
+
+func TestWithMarketBaseURL(t *testing.T) {
+	// recorder 记录收到的请求路径
+	recorder := func(paths *[]string) *httptest.Server {
+		var mu sync.Mutex
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			*paths = append(*paths, r.URL.Path)
+			mu.Unlock()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	ctx := context.Background()
+
+	// Binance 现货和 U本位合约分别使用各自的地址
+	var spotPaths, perpPaths []string
+	spotSrv, perpSrv := recorder(&spotPaths), recorder(&perpPaths)
+	ex, err := NewExchange(ExchangeBinance,
+		option.WithMarketBaseURL(model.MarketTypeSpot, spotSrv.URL),
+		option.WithMarketBaseURL(model.MarketTypeSwap, perpSrv.URL))
+	if err != nil {
+		t.Fatalf("NewExchange: %v", err)
+	}
+	ex.FetchTime(ctx)
+	ex.Perp().LoadMarkets(ctx, true)
+	if !slices.Equal(spotPaths, []string{"/api/v3/time"}) || !slices.Equal(perpPaths, []string{"/fapi/v1/exchangeInfo"}) {
+		t.Errorf("spot paths = %v, perp paths = %v", spotPaths, perpPaths)
+	}
+
+	// Bybit 现货和合约共用同一地址，只设置合约地址时同样生效
+	var sharedPaths []string
+	sharedSrv := recorder(&sharedPaths)
+	ex, err = NewExchange(ExchangeBybit, option.WithMarketBaseURL(model.MarketTypeSwap, sharedSrv.URL))
+	if err != nil {
+		t.Fatalf("NewExchange: %v", err)
+	}
+	ex.FetchTime(ctx)
+	if len(sharedPaths) != 1 {
+		t.Errorf("shared paths = %v", sharedPaths)
+	}
+
+	if _, err := NewExchange(ExchangeBybit,
+		option.WithMarketBaseURL(model.MarketTypeSpot, spotSrv.URL),
+		option.WithMarketBaseURL(model.MarketTypeSwap, perpSrv.URL)); err == nil {
+		t.Error("NewExchange with different spot and perp base URLs on bybit: error = nil")
+	}
+}
+
+// roundTripFunc 用函数实现 http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestWithHTTPClient(t *testing.T) {
+	// 自定义 RoundTripper 回放固定响应，不访问网络
+	var paths []string
+	replay := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		body := `{}`
+		switch r.URL.Path {
+		case "/api/v3/exchangeInfo":
+			body = `{"symbols":[{"symbol":"BTCUSDT","baseAsset":"BTC","quoteAsset":"USDT","status":"TRADING","baseAssetPrecision":8,"quotePrecision":8,"filters":[]}]}`
+		case "/api/v3/ticker/24hr":
+			body = `{"symbol":"BTCUSDT","bidPrice":"64999","askPrice":"65001","lastPrice":"65000","closeTime":1700000000000}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Request: r}, nil
+	})
+	ctx := context.Background()
+
+	ex, err := NewExchange(ExchangeBinance, option.WithHTTPClient(&http.Client{Transport: replay}))
+	if err != nil {
+		t.Fatalf("NewExchange: %v", err)
+	}
+	if err := ex.Spot().LoadMarkets(ctx, true); err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+	ticker, err := ex.Spot().FetchTicker(ctx, "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Last.String() != "65000" || !slices.Equal(paths, []string{"/api/v3/exchangeInfo", "/api/v3/ticker/24hr"}) {
+		t.Errorf("ticker last = %s, paths = %v", ticker.Last, paths)
+	}
+
+	// 代理无法作用于自定义 RoundTripper
+	if _, err := NewExchange(ExchangeBinance, option.WithHTTPClient(&http.Client{Transport: replay}), option.WithProxy("http://127.0.0.1:8080")); err == nil {
+		t.Error("NewExchange with proxy and custom RoundTripper: error = nil")
+	}
+}
+
+// nilPerp Perp() 返回 nil 指针的交易所
+type nilPerp struct {
+	exchange.Exchange
+}
+
+func (nilPerp) Perp() exchange.PerpExchange {
+	var p *kraken.KrakenPerp
+	return p
+}
@@ -1,6 +1,9 @@
 package gate
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/lemconn/exlink/common"
 )
 
@@ -8,6 +11,30 @@ const (
 	gateName       = "gate"
 	gateBaseURL    = "https://api.gateio.ws"
 	gateSandboxURL = "https://api-testnet.gateapi.io"
+
+	// 公共 WebSocket 地址（永续合约为 USDT 结算）
+	gateSpotWSURL        = "wss://api.gateio.ws/ws/v4/"
+	gateSpotWSSandboxURL = "wss://ws-testnet.gate.com/v4/ws/spot"
+	gatePerpWSURL        = "wss://fx-ws.gateio.ws/v4/ws/usdt"
+	gatePerpWSSandboxURL = "wss://ws-testnet.gate.com/v4/ws/futures/usdt"
+
+	// gateMaxTradesLimit 逐笔成交单次最大返回条数
+	gateMaxTradesLimit = 1000
+
+	// gateMaxDepthLimit 深度单次最大档位数
+	gateMaxDepthLimit = 100
+
+	// gateOHLCVPageLimit FetchOHLCVRange 每页K线数
+	gateOHLCVPageLimit = 1000
+
+	// gatePriceOrderExpiration 价格触发订单的等待触发时长（秒），超时未触发自动取消
+	gatePriceOrderExpiration = 30 * 24 * 3600
+)
+
+// K线支持的周期
+var (
+	gateSpotTimeframes = []string{"10s", "1m", "5m", "15m", "30m", "1h", "4h", "8h", "1d", "7d", "30d"}
+	gatePerpTimeframes = []string{"10s", "30s", "1m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "7d", "30d"}
 )
 
 // Client Gate 客户端
@@ -15,10 +42,10 @@ type Client struct {
 	// HTTPClient HTTP 客户端
 	HTTPClient *common.HTTPClient
 
-	// APIKey API 密钥
+	// APIKey API 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	APIKey string
 
-	// SecretKey 密钥
+	// SecretKey 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	SecretKey string
 
 	// Sandbox 是否为模拟盘
@@ -32,15 +59,16 @@ type Client struct {
 }
 
 // NewClient 创建 Gate 客户端
+// 请求签名使用 Gate 持有的当前凭证（见 credentials），以支持运行时轮换
 func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Client, error) {
-	baseURL := gateBaseURL
+	baseURL, err := common.SharedBaseURL(options, gateBaseURL)
+	if err != nil {
+		return nil, err
+	}
 	sandbox := false
 	proxyURL := ""
 	debug := false
 
-	if v, ok := options["baseURL"].(string); ok {
-		baseURL = v
-	}
 	if v, ok := options["sandbox"].(bool); ok {
 		sandbox = v
 	}
@@ -55,8 +83,10 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		baseURL = gateSandboxURL
 	}
 
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
 	client := &Client{
-		HTTPClient: common.NewHTTPClient(baseURL),
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
 		APIKey:     apiKey,
 		SecretKey:  secretKey,
 		Sandbox:    sandbox,
@@ -64,6 +94,19 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		Debug:      debug,
 	}
 
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseGateError)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
 	// 设置代理
 	if proxyURL != "" {
 		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
@@ -76,6 +119,25 @@ func NewClient(apiKey, secretKey string, options map[string]interface{}) (*Clien
 		client.HTTPClient.SetDebug(true)
 	}
 
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
+	}
+
 	return client, nil
 }
 
@@ -0,0 +1,38 @@
+package gate
+
+import (
+	"encoding/json"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// gateErrorLabels Gate 错误标识（label）到统一错误的映射（现货和合约共用）
+var gateErrorLabels = map[string]error{
+	"BALANCE_NOT_ENOUGH":     common.ErrInsufficientFunds,    // 现货余额不足
+	"INSUFFICIENT_AVAILABLE": common.ErrInsufficientFunds,    // 合约可用保证金不足
+	"TOO_MANY_REQUESTS":      common.ErrRateLimitExceeded,    // 请求频率超限
+	"ORDER_NOT_FOUND":        common.ErrOrderNotFound,        // 订单不存在
+	"INVALID_PRECISION":      common.ErrInvalidOrder,         // 精度超出限制
+	"AMOUNT_TOO_LITTLE":      common.ErrInvalidOrder,         // 数量低于下限
+	"AMOUNT_TOO_MUCH":        common.ErrInvalidOrder,         // 数量超过上限
+	"SIZE_TOO_LARGE":         common.ErrInvalidOrder,         // 合约张数超过上限
+	"ORDER_POC_IMMEDIATE":    common.ErrInvalidOrder,         // 只做 maker 订单会立即成交
+	"INVALID_KEY":            common.ErrAuthenticationFailed, // API Key 无效
+	"INVALID_SIGNATURE":      common.ErrAuthenticationFailed, // 签名无效
+	"IP_FORBIDDEN":           common.ErrAuthenticationFailed, // IP 不在白名单
+	"FORBIDDEN":              common.ErrAuthenticationFailed, // 权限不足
+}
+
+// parseGateError 解析 Gate 非 2xx 响应体 {"label":"BALANCE_NOT_ENOUGH","message":"..."}
+func parseGateError(httpErr *common.HTTPError) error {
+	var body struct {
+		Label   string `json:"label"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Label == "" {
+		return nil
+	}
+	e := common.NewExchangeError("gate", body.Label, body.Message, gateErrorLabels)
+	e.Cause = httpErr
+	return e
+}
@@ -0,0 +1,42 @@
+package gate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+)
+
+func TestParseGateError(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"spot balance", `{"label":"BALANCE_NOT_ENOUGH","message":"Not enough balance"}`, common.ErrInsufficientFunds},
+		{"futures margin", `{"label":"INSUFFICIENT_AVAILABLE","message":"balance not enough"}`, common.ErrInsufficientFunds},
+		{"rate limit", `{"label":"TOO_MANY_REQUESTS","message":"Request Rate limit Exceeded"}`, common.ErrRateLimitExceeded},
+		{"order not found", `{"label":"ORDER_NOT_FOUND","message":"Order not found"}`, common.ErrOrderNotFound},
+		{"precision", `{"label":"INVALID_PRECISION","message":"Invalid precision"}`, common.ErrInvalidOrder},
+		{"invalid key", `{"label":"INVALID_KEY","message":"Invalid key provided"}`, common.ErrAuthenticationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseGateError(&common.HTTPError{StatusCode: 400, Body: tt.body})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want errors.Is %v", err, tt.want)
+			}
+			var exErr *common.ExchangeError
+			if !errors.As(err, &exErr) || exErr.Exchange != "gate" || exErr.Message == "" {
+				t.Errorf("err = %#v, want *ExchangeError with raw label/message", err)
+			}
+		})
+	}
+
+	err := parseGateError(&common.HTTPError{StatusCode: 400, Body: `{"label":"INVALID_PARAM_VALUE","message":"Invalid param value"}`})
+	var exErr *common.ExchangeError
+	if !errors.As(err, &exErr) || exErr.Err != nil || exErr.Code != "INVALID_PARAM_VALUE" {
+		t.Errorf("unknown label err = %#v, want unmapped *ExchangeError", err)
+	}
+}
@@ -1,8 +1,15 @@
 package gate
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
 	"github.com/lemconn/exlink/model"
 )
@@ -10,7 +17,7 @@ import (
 // Gate Gate 交易所实现
 type Gate struct {
 	client              *Client
-	signer              *Signer
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
 	spot                *GateSpot
 	perp                *GatePerp
 	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
@@ -18,6 +25,13 @@ type Gate struct {
 	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
 	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
 	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+	spotWS              *common.WSManager        // 现货公共 WebSocket 订阅
+	perpWS              *common.WSManager        // 合约公共 WebSocket 订阅
+	spotPrivateWS       *common.WSManager        // 现货私有 WebSocket 订阅（订单、余额）
+	perpPrivateWS       *common.WSManager        // USDT 合约私有 WebSocket 订阅（订单、余额）
 }
 
 // NewGate 创建 Gate 交易所实例
@@ -27,21 +41,50 @@ func NewGate(apiKey, secretKey string, options map[string]interface{}) (exchange
 		return nil, err
 	}
 
-	signer := NewSigner(secretKey)
-
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
 	gate := &Gate{
 		client:              client,
-		signer:              signer,
 		spotMarketsBySymbol: make(map[string]*model.Market),
 		spotMarketsByID:     make(map[string]*model.Market),
 		perpMarketsBySymbol: make(map[string]*model.Market),
 		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		gate.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(gate.lifecycle)
+
+	gate.UpdateCredentials(apiKey, secretKey, "")
+
+	// 公共 WebSocket 按需建立连接，所有订阅复用同一连接
+	spotWSURL, perpWSURL := gateSpotWSURL, gatePerpWSURL
+	if client.Sandbox {
+		spotWSURL, perpWSURL = gateSpotWSSandboxURL, gatePerpWSSandboxURL
+	}
+	gate.spotWS = common.NewWSManager(common.NewWSDialer(spotWSURL, client.ProxyURL), gateWSProtocol{pingChannel: "spot.ping"})
+	gate.perpWS = common.NewWSManager(common.NewWSDialer(perpWSURL, client.ProxyURL), gateWSProtocol{pingChannel: "futures.ping"})
+
+	// 私有频道与公共频道地址相同，每条订阅消息单独签名，断线重连后重新订阅即重新鉴权
+	gate.spotPrivateWS = common.NewWSManager(common.NewWSDialer(spotWSURL, client.ProxyURL), gatePrivateWSProtocol{gate: gate, pingChannel: "spot.ping"})
+	gate.perpPrivateWS = common.NewWSManager(common.NewWSDialer(perpWSURL, client.ProxyURL), gatePrivateWSProtocol{gate: gate, pingChannel: "futures.ping"})
+	if v, ok := options["streamStateHandler"].(common.WSStateHandler); ok {
+		gate.spotWS.SetStateHandler(gateName, "spot", v)
+		gate.perpWS.SetStateHandler(gateName, "perp", v)
+		gate.spotPrivateWS.SetStateHandler(gateName, "spot_private", v)
+		gate.perpPrivateWS.SetStateHandler(gateName, "perp_private", v)
 	}
 
 	// 初始化现货和合约实现
 	gate.spot = NewGateSpot(gate)
 	gate.perp = NewGatePerp(gate)
 
+	if v, ok := options["timeSync"].(bool); ok && v {
+		gate.clock.Start(common.TimeSyncInterval, gate.FetchTime)
+	}
+
 	return gate, nil
 }
 
@@ -59,3 +102,165 @@ func (g *Gate) Perp() exchange.PerpExchange {
 func (g *Gate) Name() string {
 	return gateName
 }
+
+// Has 返回 Gate 支持的功能
+func (g *Gate) Has() model.Capabilities {
+	return gateCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (g *Gate) Symbols() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return common.MarketSymbols(g.spotMarketsBySymbol, g.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (g *Gate) NormalizeSymbol(nativeID string) (string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, g.spotMarketsByID, g.perpMarketsByID, common.FromGateSymbol)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (g *Gate) DenormalizeSymbol(symbol string) (string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, g.spotMarketsBySymbol, g.perpMarketsBySymbol, common.ToGateSymbol)
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (g *Gate) ExportMarkets() ([]byte, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return common.MarshalMarkets(gateName, g.spotMarketsBySymbol, g.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (g *Gate) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(gateName, data)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		g.spotMarketsBySymbol, g.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		g.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		g.perpMarketsBySymbol, g.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		g.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后关闭 WebSocket 连接，已建立的推送和轮询订阅通道随之关闭
+func (g *Gate) Drain(ctx context.Context) error {
+	g.clock.Stop()
+	err := g.lifecycle.Drain(ctx, g.spot, g.perp)
+	return errors.Join(err, common.CloseWSManagers(g.spotWS, g.perpWS, g.spotPrivateWS, g.perpPrivateWS))
+}
+
+// FetchTime 获取交易所服务器时间
+func (g *Gate) FetchTime(ctx context.Context) (time.Time, error) {
+	resp, err := g.client.HTTPClient.Get(ctx, "/api/v4/spot/time", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+
+	var result gateTimeResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal time: %w", err)
+	}
+	return result.ServerTime.Time, nil
+}
+
+// FetchStatus 获取系统状态，Gate 没有状态接口，服务器时间接口可访问时视为正常，否则视为维护中
+func (g *Gate) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	if _, err := g.FetchTime(ctx); err != nil {
+		return common.UnreachableStatus(ctx, err)
+	}
+	return common.OKStatus(), nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (g *Gate) Ping(ctx context.Context) error {
+	return common.Ping(ctx, g.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (g *Gate) VerifyCredentials(ctx context.Context) error {
+	creds := g.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", g.spot.FetchBalance)
+}
+
+// gateCapabilities Gate 支持的功能
+var gateCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported:             true,
+		CreateStopOrder:       true,
+		EditOrder:             true,
+		FetchOrder:            true,
+		FetchOrderByClientID:  true,
+		TrackOrder:            true,
+		FetchAggregatedTrades: true,
+		WatchTicker:           true,
+		WatchOrderBook:        true,
+		WatchOHLCV:            true,
+		WatchOrders:           true,
+		WatchBalance:          true,
+		FetchCurrencies:       true,
+		FetchDepositAddress:   true,
+		Withdraw:              true,
+		Transfer:              true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:               true,
+		CreateStopOrder:         true,
+		EditOrder:               true,
+		FetchOrder:              true,
+		FetchOrderByClientID:    true,
+		TrackOrder:              true,
+		FetchAggregatedTrades:   true,
+		WatchTicker:             true,
+		WatchOrderBook:          true,
+		WatchOHLCV:              true,
+		WatchOrders:             true,
+		WatchBalance:            true,
+		WatchPositions:          true,
+		FetchFundingRate:        true,
+		FetchFundingRateHistory: true,
+		FetchOpenInterest:       true,
+		FetchMarkPrice:          true,
+		FetchIndexPrice:         true,
+		SetLeverage:             true,
+		SetPositionMode:         true,
+		GetPositionMode:         true,
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// Gate 不需要 password，忽略该参数
+func (g *Gate) UpdateCredentials(apiKey, secretKey, password string) {
+	g.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (g *Gate) credentials() *credentials {
+	return g.creds.Load()
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"math"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -19,7 +20,8 @@ import (
 
 // GatePerp Gate 永续合约实现
 type GatePerp struct {
-	gate *Gate
+	gate         *Gate
+	positionMode common.PositionMode // USDT 合约账户持仓模式（双仓模式）
 }
 
 // NewGatePerp 创建 Gate 永续合约实例
@@ -32,9 +34,9 @@ func NewGatePerp(g *Gate) *GatePerp {
 // ========== PerpExchange 接口实现 ==========
 
 func (p *GatePerp) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	p.gate.mu.RLock()
-	if !reload && len(p.gate.perpMarketsBySymbol) > 0 {
+	if !reload && len(p.gate.perpMarketsBySymbol) > 0 && !p.gate.marketCache.Expired(model.MarketTypeSwap) {
 		p.gate.mu.RUnlock()
 		return nil
 	}
@@ -85,6 +87,8 @@ func (p *GatePerp) LoadMarkets(ctx context.Context, reload bool) error {
 		}
 
 		// 解析精度
+		market.Precision.TickSize = s.OrderPriceRound
+		market.Precision.StepSize = types.ExDecimal{Decimal: decimal.NewFromInt(1)} // 按张下单，步长为 1 张
 		if !s.OrderPriceRound.IsZero() {
 			orderPriceRound := s.OrderPriceRound.InexactFloat64()
 			market.Precision.Price = getPrecisionDigits(orderPriceRound)
@@ -94,20 +98,17 @@ func (p *GatePerp) LoadMarkets(ctx context.Context, reload bool) error {
 		// 解析限制
 		market.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.NewFromInt(int64(s.OrderSizeMin))}
 		market.Limits.Amount.Max = types.ExDecimal{Decimal: decimal.NewFromInt(int64(s.OrderSizeMax))}
+		market.Limits.Leverage.Min = s.LeverageMin
+		market.Limits.Leverage.Max = s.LeverageMax
 
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	p.gate.mu.Lock()
-	if p.gate.perpMarketsBySymbol == nil {
-		p.gate.perpMarketsBySymbol = make(map[string]*model.Market)
-		p.gate.perpMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		p.gate.perpMarketsBySymbol[market.Symbol] = market
-		p.gate.perpMarketsByID[market.ID] = market
-	}
+	p.gate.perpMarketsBySymbol, p.gate.perpMarketsByID = bySymbol, byID
+	p.gate.marketCache.Touch(model.MarketTypeSwap)
 	p.gate.mu.Unlock()
 
 	return nil
@@ -149,9 +150,54 @@ func (p *GatePerp) GetMarket(symbol string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", symbol)
+	return nil, common.MarketNotFound(symbol, p.gate.perpMarketsBySymbol)
 }
 
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *GatePerp) GetMarketByID(id string) (*model.Market, error) {
+	p.gate.mu.RLock()
+	defer p.gate.mu.RUnlock()
+
+	if market, ok := p.gate.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.gate.perpMarketsByID)
+}
+
+func (p *GatePerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (p *GatePerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+func (p *GatePerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+func (p *GatePerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
+}
+
+// FetchTicker 获取合约行情，Bid/Ask 取自 highest_bid/lowest_ask，缺失时（无挂单或接口返回空串）回退到 1 档深度；
+// Gate 合约行情不提供开盘价、VWAP、成交笔数和时间戳，Open/VWAP/TradeCount 为 0，Timestamp 为本地时间
 func (p *GatePerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
 	// 获取市场信息
 	market, err := p.GetMarket(symbol)
@@ -200,6 +246,20 @@ func (p *GatePerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticke
 	ticker.Low = item.Low24h
 	ticker.Volume = item.Volume24hBase
 	ticker.QuoteVolume = item.Volume24hQuote
+	ticker.MarkPrice = item.MarkPrice
+	ticker.IndexPrice = item.IndexPrice
+
+	if ticker.Bid.IsZero() || ticker.Ask.IsZero() {
+		// 深度查询失败时仍返回行情，缺失的一侧保持为 0
+		if book, err := p.FetchOrderBook(ctx, symbol, 1); err == nil {
+			if ticker.Bid.IsZero() && len(book.Bids) > 0 {
+				ticker.Bid = types.ExDecimal{Decimal: book.Bids[0].Price}
+			}
+			if ticker.Ask.IsZero() && len(book.Asks) > 0 {
+				ticker.Ask = types.ExDecimal{Decimal: book.Asks[0].Price}
+			}
+		}
+	}
 
 	return ticker, nil
 }
@@ -211,6 +271,11 @@ func (p *GatePerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 		opt(argsOpts)
 	}
 
+	// 确保市场已加载
+	if err := p.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+
 	settle := "usdt" // Gate 永续合约默认使用 USDT 结算
 	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/tickers", settle), nil)
 	if err != nil {
@@ -225,13 +290,13 @@ func (p *GatePerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 
 	tickers := make(model.Tickers, 0, len(data))
 	for _, item := range data {
-		// 尝试从市场信息中查找标准化格式
-		market, err := p.GetMarket(item.Contract)
-		if err != nil {
-			continue
+		// 优先使用市场信息中的标准化格式，查不到时（如新上线的合约）直接转换原始合约名
+		symbol := symbolFromGateContract(item.Contract, settle)
+		if market, err := p.GetMarket(item.Contract); err == nil {
+			symbol = market.Symbol
 		}
 		ticker := &model.Ticker{
-			Symbol:    market.Symbol,
+			Symbol:    symbol,
 			Timestamp: types.ExTimestamp{Time: time.Now()}, // Gate 永续合约 API 没有返回时间戳
 		}
 		ticker.Bid = item.HighestBid
@@ -241,12 +306,106 @@ func (p *GatePerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption)
 		ticker.Low = item.Low24h
 		ticker.Volume = item.Volume24hBase
 		ticker.QuoteVolume = item.Volume24hQuote
+		ticker.MarkPrice = item.MarkPrice
+		ticker.IndexPrice = item.IndexPrice
 		tickers = append(tickers, ticker)
 	}
 
 	return tickers, nil
 }
 
+func (p *GatePerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	settle := strings.ToLower(market.Settle)
+	params := map[string]interface{}{
+		"contract": market.ID,
+		"with_id":  "true",
+	}
+	if limit = common.ClampOrderBookLimit(limit, gateMaxDepthLimit); limit > 0 {
+		params["limit"] = limit
+	}
+
+	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/order_book", settle), params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var data gatePerpOrderBookResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	toEntries := func(levels []gatePerpOrderBookLevel) []model.OrderBookEntry {
+		entries := make([]model.OrderBookEntry, 0, len(levels))
+		for _, level := range levels {
+			entries = append(entries, model.OrderBookEntry{Price: level.Price.Decimal, Amount: level.Size.Decimal})
+		}
+		return entries
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      toEntries(data.Bids),
+		Asks:      toEntries(data.Asks),
+		Nonce:     data.ID,
+		Timestamp: types.ExTimestamp{Time: time.UnixMilli(data.Current.Shift(3).IntPart())},
+	}, nil
+}
+
+func (p *GatePerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.gate.perpWS, gateWSTopic("futures.tickers", market.ID), parseGateWSPerpTicker(market.Symbol))
+}
+
+func (p *GatePerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  p.gate.perpWS,
+		Topic:    gateWSTopic("futures.order_book_update", market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequenceRange,
+		Parse:    parseGateWSPerpOrderBook,
+		FetchSnapshot: func(ctx context.Context) (*model.OrderBook, error) {
+			return p.FetchOrderBook(ctx, market.Symbol, gateMaxDepthLimit)
+		},
+	})
+}
+
+func (p *GatePerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.GateTimeframe(timeframe), gatePerpTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.gate.perpWS, gateWSTopic("futures.candlesticks", gateCandlestickName(interval, market.ID)), parseGateWSPerpCandlestick)
+}
+
 func (p *GatePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -264,7 +423,10 @@ func (p *GatePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe str
 	}
 
 	// 标准化时间框架
-	normalizedTimeframe := common.GateTimeframe(timeframe)
+	normalizedTimeframe, err := common.CheckTimeframe(timeframe, common.GateTimeframe(timeframe), gatePerpTimeframes)
+	if err != nil {
+		return nil, err
+	}
 
 	settle := strings.ToLower(market.Settle)
 	params := map[string]interface{}{
@@ -275,6 +437,13 @@ func (p *GatePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe str
 	if !since.IsZero() {
 		params["from"] = since.Unix()
 	}
+	if until, ok := option.GetTime(argsOpts.Until); ok {
+		params["to"] = until.Unix()
+		// Gate 不允许 limit 与 from、to 同时使用
+		if !since.IsZero() {
+			delete(params, "limit")
+		}
+	}
 
 	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/candlesticks", settle), params)
 	if err != nil {
@@ -304,9 +473,185 @@ func (p *GatePerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe str
 		ohlcvs = append(ohlcvs, ohlcv)
 	}
 
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, p.gate.clock.Now())
+	}
 	return ohlcvs, nil
 }
 
+func (p *GatePerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, gateOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, limit, option.WithSince(start), option.WithUntil(end))
+	})
+}
+
+func (p *GatePerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, 2)
+	})
+}
+
+func (p *GatePerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	settle := strings.ToLower(market.Settle)
+	params := map[string]interface{}{
+		"contract": market.ID,
+		"limit":    gateMaxTradesLimit,
+	}
+	if !since.IsZero() {
+		params["from"] = since.Unix()
+	}
+
+	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/trades", settle), params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch trades: %w", err)
+	}
+
+	var data gatePerpTradesResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
+	}
+
+	trades := make([]*model.Trade, 0, len(data))
+	for _, item := range data {
+		// size 为负表示主动卖出
+		side := "buy"
+		if item.Size.IsNegative() {
+			side = "sell"
+		}
+		trades = append(trades, &model.Trade{
+			ID:        strconv.FormatInt(item.ID, 10),
+			Symbol:    market.Symbol,
+			Side:      side,
+			Amount:    item.Size.Abs(),
+			Price:     item.Price.Decimal,
+			Timestamp: time.UnixMicro(item.CreateTimeMs.Shift(3).IntPart()),
+		})
+	}
+
+	return common.AggregateTrades(trades, since, limit), nil
+}
+
+func (p *GatePerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	settle := strings.ToLower(market.Settle)
+	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/contracts/%s", settle, market.ID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate: %w", err)
+	}
+
+	var data gatePerpContract
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate: %w", err)
+	}
+
+	// 合约信息不带时间戳，使用获取时间
+	return &model.FundingRate{
+		Symbol:          market.Symbol,
+		Rate:            data.FundingRate,
+		Timestamp:       types.ExTimestamp{Time: time.Now()},
+		NextFundingTime: data.FundingNextApply,
+	}, nil
+}
+
+func (p *GatePerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	settle := strings.ToLower(market.Settle)
+	params := map[string]interface{}{
+		"contract": market.ID,
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if !since.IsZero() {
+		params["from"] = since.Unix()
+	}
+
+	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/funding_rate", settle), params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate history: %w", err)
+	}
+
+	var data []gatePerpFundingRate
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate history: %w", err)
+	}
+
+	rates := make(model.FundingRates, 0, len(data))
+	for _, item := range data {
+		rates = append(rates, &model.FundingRate{
+			Symbol:    market.Symbol,
+			Rate:      item.Rate,
+			Timestamp: item.Time,
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Timestamp.Before(rates[j].Timestamp.Time) })
+	return rates, nil
+}
+
+// FetchOpenInterest 获取持仓量（合约统计 contract_stats 的最新一条），张数按合约乘数换算为币数量
+func (p *GatePerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	settle := strings.ToLower(market.Settle)
+	resp, err := p.gate.client.HTTPClient.Get(ctx, fmt.Sprintf("/api/v4/futures/%s/contract_stats", settle), map[string]interface{}{
+		"contract": market.ID,
+		"limit":    1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch open interest: %w", err)
+	}
+
+	var stats []gatePerpContractStats
+	if err := json.Unmarshal(resp, &stats); err != nil {
+		return nil, fmt.Errorf("unmarshal open interest: %w", err)
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("contract stats not found: %s", symbol)
+	}
+
+	data := stats[len(stats)-1]
+	return &model.OpenInterest{
+		Symbol:             market.Symbol,
+		OpenInterestAmount: types.ExDecimal{Decimal: data.OpenInterest.Mul(contractMultiplier(market))},
+		OpenInterestValue:  data.OpenInterestUsd,
+		Timestamp:          data.Time,
+	}, nil
+}
+
+// FetchMarkPrice 获取标记价格和指数价格，Gate 合约行情中包含 mark_price、index_price，同时返回最新成交价等字段
+func (p *GatePerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return p.FetchTicker(ctx, symbol)
+}
+
+// FetchIndexPrice 获取指数价格（合约行情中的 index_price）
+func (p *GatePerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.FetchTicker(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
 func (p *GatePerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -356,19 +701,30 @@ func (p *GatePerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption
 			amount = -amount
 		}
 
+		// Gate 杠杆为 0 表示全仓，此时实际杠杆为 cross_leverage_limit
+		marginMode := model.MarginModeIsolated
+		leverage := item.Leverage
+		if item.Leverage.IsZero() {
+			marginMode = model.MarginModeCross
+			leverage = item.CrossLeverageLimit
+		}
+
 		position := &model.Position{
-			Symbol:           market.Symbol,
-			Side:             side,
-			Amount:           types.ExDecimal{Decimal: decimal.NewFromFloat(amount)},
-			EntryPrice:       item.EntryPrice,
-			MarkPrice:        item.MarkPrice,
-			UnrealizedPnl:    item.UnrealisedPnl,
-			LiquidationPrice: item.LiqPrice,
-			RealizedPnl:      item.RealisedPnl,
-			Leverage:         item.Leverage,
-			Margin:           item.Margin,
-			Percentage:       types.ExDecimal{},
-			Timestamp:        item.UpdateTime,
+			Symbol:                market.Symbol,
+			Side:                  side,
+			Amount:                types.ExDecimal{Decimal: decimal.NewFromFloat(amount)},
+			EntryPrice:            item.EntryPrice,
+			MarkPrice:             item.MarkPrice,
+			UnrealizedPnl:         item.UnrealisedPnl,
+			LiquidationPrice:      item.LiqPrice,
+			RealizedPnl:           item.RealisedPnl,
+			Leverage:              leverage,
+			Margin:                item.Margin,
+			MarginMode:            marginMode,
+			MaintenanceMargin:     item.MaintenanceMargin,
+			MaintenanceMarginRate: item.MaintenanceRate,
+			Percentage:            types.ExDecimal{},
+			Timestamp:             item.UpdateTime,
 		}
 
 		positions = append(positions, position)
@@ -377,7 +733,99 @@ func (p *GatePerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption
 	return positions, nil
 }
 
+// WatchPositions 轮询持仓并推送持仓变化，同时订阅私有频道 futures.orders，强平、自动减仓导致的减仓/平仓事件填充 Reason
+// 订单订阅失败时仍推送持仓变化，但不填充 Reason
+func (p *GatePerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := p.WatchOrders(ctx)
+	if err != nil {
+		orders = nil
+	}
+	return common.PollPositionsWithOrders(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	}, orders)
+}
+
+// WatchBalance 通过私有频道 futures.balances 订阅 USDT 合约账户余额
+// Gate 推送只包含钱包余额（不含未实现盈亏），Available 和 Locked 为空，需要时通过 FetchBalance 查询
+func (p *GatePerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	account, err := p.fetchAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.gate.perpPrivateWS, gateWSPerpBalancesChannel+":"+account.userID(), parseGateWSPerpBalances)
+}
+
+// WatchOrders 通过私有频道 futures.orders 订阅全部 USDT 合约订单更新，订单每次状态变化推送一次
+func (p *GatePerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	account, err := p.fetchAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, p.gate.perpPrivateWS, gateWSPerpOrdersChannel+":"+account.userID(), p.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 futures.orders 的合约订单推送（WatchOrders 和 TrackOrder 共用）
+func (p *GatePerp) parseWSOrders() common.WSParser[[]*model.PerpOrder] {
+	return parseGateWSOrders(func(raw json.RawMessage) (*model.PerpOrder, bool) {
+		var item gatePerpFetchOrderResponse
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		// 张数换算需要合约面值，未加载市场信息的合约不推送
+		market, err := p.GetMarket(item.Contract)
+		if err != nil {
+			return nil, false
+		}
+		return toGatePerpOrder(market.Symbol, contractMultiplier(market), &item), true
+	})
+}
+
+// fetchAccount 查询 USDT 合约账户（用户ID用于合约私有频道订阅，in_dual_mode 为持仓模式）
+func (p *GatePerp) fetchAccount(ctx context.Context) (*gatePerpAccountResponse, error) {
+	resp, err := p.signAndRequest(ctx, "GET", "/api/v4/futures/usdt/accounts", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch futures account: %w", err)
+	}
+
+	var data gatePerpAccountResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal futures account: %w", err)
+	}
+	return &data, nil
+}
+
 func (p *GatePerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	order, err := common.CreateOrderIdempotent(ctx, p.gate.client.HTTPClient, p.gate.Name(), orderSide.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return p.createOrder(ctx, symbol, amount, orderSide, orderType, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.PerpOrder, error) {
+		return p.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		p.gate.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (p *GatePerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.PerpOrderRequest) (*model.NewOrder, error) {
+		return p.CreateOrder(ctx, r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+	})
+}
+
+func (p *GatePerp) createOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
 	// 解析选项
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -412,14 +860,14 @@ func (p *GatePerp) CreateOrder(ctx context.Context, symbol string, amount string
 		}
 	}
 
-	amountFloat, err := strconv.ParseFloat(amount, 64)
+	amountDecimal, err := decimal.NewFromString(amount)
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount: %w", err)
 	}
 
-	var priceFloat float64
+	var priceDecimal decimal.Decimal
 	if priceStr != "" {
-		priceFloat, err = strconv.ParseFloat(priceStr, 64)
+		priceDecimal, err = decimal.NewFromString(priceStr)
 		if err != nil {
 			return nil, fmt.Errorf("invalid price: %w", err)
 		}
@@ -433,25 +881,20 @@ func (p *GatePerp) CreateOrder(ctx context.Context, symbol string, amount string
 		Contract: gateSymbol,
 	}
 
-	// 从 PerpOrderSide 自动推断 PositionSide 和 reduceOnly
-	reduceOnly := orderSide.ToReduceOnly()
+	// 从 PerpOrderSide 自动推断 PositionSide 和 reduceOnly（option.WithReduceOnly 优先）
+	reduceOnly := common.ReduceOnly(orderSide, argsOpts)
+	if err := common.CheckTimeInForce(argsOpts, orderType == option.Limit, true); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, orderType == option.Limit)
+	if err != nil {
+		return nil, err
+	}
 
-	// 计算 size（张数）: 张数 = 币的个数 / quanto_multiplier
-	var size int64
-	exContractValue, err := decimal.NewFromString(market.ContractValue)
-	if err == nil && exContractValue.GreaterThan(decimal.Zero) {
-		amountDecimal := decimal.NewFromFloat(amountFloat)
-		contractSizeDecimal := amountDecimal.Div(exContractValue)
-		contractSizeFloat, _ := contractSizeDecimal.Float64()
-		size = int64(math.Ceil(contractSizeFloat))
-		if size < 1 {
-			size = 1
-		}
-	} else {
-		size = int64(math.Ceil(amountFloat))
-		if size < 1 {
-			size = 1
-		}
+	// amount 为币的数量，换算为张数: 张数 = 币的个数 / quanto_multiplier
+	size, err := toContractSize(market, amountDecimal)
+	if err != nil {
+		return nil, err
 	}
 
 	// 根据 PerpOrderSide 确定 size 符号
@@ -481,25 +924,38 @@ func (p *GatePerp) CreateOrder(ctx context.Context, symbol string, amount string
 	if orderType == option.Market {
 		req.Price = "0"
 	} else {
-		req.Price = strconv.FormatFloat(priceFloat, 'f', -1, 64)
+		req.Price, err = common.PriceToPrecision(market, priceDecimal.String())
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// TimeInForce 设置
-	if argsOpts.TimeInForce != nil {
-		req.Tif = argsOpts.TimeInForce.Lower()
-	} else if orderType == option.Market {
-		req.Tif = option.IOC.Lower() // 市价单固定 ioc
-	} else {
-		req.Tif = option.GTC.Lower() // 限价单默认 gtc
-	}
+	// TimeInForce 设置，只做 Maker 使用 poc（pending or cancelled）
+	req.Tif = gateTimeInForce(orderType == option.Limit, postOnly, argsOpts.TimeInForce)
 
 	// 客户端订单ID
 	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		req.Text = *argsOpts.ClientOrderID
+		req.Text = gateClientOrderText(*argsOpts.ClientOrderID)
 	} else {
 		// 将 PerpOrderSide 转换为 OrderSide 用于生成订单ID
 		req.Text = common.GenerateClientOrderID(p.gate.Name(), orderSide.ToSide())
 	}
+	// 返回的 ClientOrderID 去除了 "t-" 前缀，校验时同样去除
+	clientOrderID := gateClientOrderID(req.Text)
+
+	// 价格触发订单没有跟踪模式
+	if option.StringPresent(argsOpts.TrailingCallbackRate) {
+		return nil, fmt.Errorf("trailing stop: %w: Gate price-triggered orders have no trailing mode", common.ErrNotSupported)
+	}
+
+	// 设置触发价时创建价格触发订单
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if isStop {
+		return p.createPriceOrder(ctx, symbol, settle, req, stopPrice, triggerType.TriggersOnRise(orderSide.ToSide()))
+	}
 
 	// 将结构体转换为 map
 	reqBytes, err := json.Marshal(req)
@@ -522,20 +978,98 @@ func (p *GatePerp) CreateOrder(ctx context.Context, symbol string, amount string
 	}
 
 	// 构建 NewOrder 对象
-	// Gate 的 text 字段可能包含 "t-" 前缀，需要去除
-	clientOrderID := strings.TrimPrefix(respData.Text, "t-")
-
 	perpOrder := &model.NewOrder{
 		Symbol:        symbol,
-		OrderId:       respData.ID,
-		ClientOrderID: clientOrderID,
+		OrderId:       strconv.FormatInt(respData.ID, 10),
+		ClientOrderID: gateClientOrderID(respData.Text),
 		Timestamp:     respData.UpdateTime,
 	}
 
+	if strict, ok := option.GetBool(argsOpts.StrictClientID); ok && strict {
+		if err := common.CheckClientOrderID(clientOrderID, perpOrder.ClientOrderID); err != nil {
+			return perpOrder, err
+		}
+	}
+
 	return perpOrder, nil
 }
 
+// createPriceOrder 创建价格触发订单，触发后按 initial 下单
+// 价格触发订单不支持自定义订单ID，返回的 OrderId 为价格触发订单ID
+func (p *GatePerp) createPriceOrder(ctx context.Context, symbol, settle string, initial gatePerpCreateOrderRequest, stopPrice decimal.Decimal, onRise bool) (*model.NewOrder, error) {
+	var req gatePerpPriceOrderRequest
+	req.Initial = initial
+	req.Initial.Text = ""
+	req.Trigger.Price = stopPrice.String()
+	req.Trigger.Rule = 2
+	if onRise {
+		req.Trigger.Rule = 1
+	}
+	req.Trigger.Expiration = gatePriceOrderExpiration
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	var reqBody map[string]interface{}
+	if err := json.Unmarshal(reqBytes, &reqBody); err != nil {
+		return nil, fmt.Errorf("unmarshal request: %w", err)
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", fmt.Sprintf("/api/v4/futures/%s/price_orders", settle), nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("create price order: %w", err)
+	}
+
+	var respData gatePriceOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal price order: %w", err)
+	}
+
+	return &model.NewOrder{
+		Symbol:       symbol,
+		OrderId:      strconv.FormatInt(respData.ID, 10),
+		Timestamp:    types.ExTimestamp{Time: time.Now()},
+		TriggerPrice: types.ExDecimal{Decimal: stopPrice},
+	}, nil
+}
+
+// ClosePosition 以只减仓市价单平掉持仓，持仓张数按比例向下取整后换算为币数量下单
+func (p *GatePerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	_, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	contracts := amount.Floor()
+	if !contracts.IsPositive() {
+		return nil, fmt.Errorf("%w: close amount %s is below one contract", common.ErrInvalidOrder, amount)
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, contracts.Mul(contractMultiplier(market)).String(), side, option.Market, opts...)
+}
+
 func (p *GatePerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if err := p.cancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	p.gate.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+func (p *GatePerp) cancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -550,28 +1084,97 @@ func (p *GatePerp) CancelOrder(ctx context.Context, symbol string, orderId strin
 
 	settle := strings.ToLower(market.Settle)
 
-	// Gate API 支持通过 order_id 或 text 参数（clientOrderId）
-	var path string
-	var params map[string]interface{}
+	// 路径中的 order_id 支持订单ID或 text（客户端订单ID）
 	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
-	if orderId != "" {
-		path = fmt.Sprintf("/api/v4/futures/%s/orders/%s", settle, orderId)
-		params = nil
-	} else if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		// 使用 text 参数通过 clientOrderId 取消订单
-		path = fmt.Sprintf("/api/v4/futures/%s/orders", settle)
-		params = map[string]interface{}{
-			"text": *argsOpts.ClientOrderID,
+	if orderId == "" {
+		if argsOpts.ClientOrderID == nil || *argsOpts.ClientOrderID == "" {
+			return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 		}
-	} else {
-		return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
 	}
+	path := fmt.Sprintf("/api/v4/futures/%s/orders/%s", settle, orderId)
 
-	_, err = p.signAndRequest(ctx, "DELETE", path, params, nil)
+	_, err = p.signAndRequest(ctx, "DELETE", path, nil, nil)
 	return err
 }
 
+// EditOrder 修改挂单（PUT /futures/{settle}/orders/{order_id}）
+// Gate 的 size 为带方向的张数，修改数量时沿用原订单方向
+func (p *GatePerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	// 解析参数
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 获取市场信息（用于获取 settle）
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gate 改单路径中的 order_id 支持订单ID或 text（clientOrderId）
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId == "" {
+		if argsOpts.ClientOrderID == nil || *argsOpts.ClientOrderID == "" {
+			return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+		}
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
+	}
+
+	reqBody := map[string]interface{}{}
+	if newAmount != "" {
+		amountDecimal, err := decimal.NewFromString(newAmount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount: %w", err)
+		}
+		size, err := toContractSize(market, amountDecimal)
+		if err != nil {
+			return nil, err
+		}
+		// 查询原订单方向，卖单的 size 为负数
+		current, err := p.FetchOrder(ctx, symbol, orderId)
+		if err != nil {
+			return nil, err
+		}
+		if current.Side == "sell" {
+			size = -size
+		}
+		reqBody["size"] = size
+	}
+	if newPrice != "" {
+		price, err := common.PriceToPrecision(market, newPrice)
+		if err != nil {
+			return nil, err
+		}
+		reqBody["price"] = price
+	}
+
+	settle := strings.ToLower(market.Settle)
+	path := fmt.Sprintf("/api/v4/futures/%s/orders/%s", settle, orderId)
+	resp, err := p.signAndRequest(ctx, "PUT", path, nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var respData gatePerpFetchOrderResponse
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	// 改单响应与查询结构一致，重新查询以统一数量换算和字段转换
+	return p.FetchOrder(ctx, symbol, strconv.FormatInt(respData.ID, 10))
+}
+
 func (p *GatePerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -586,24 +1189,17 @@ func (p *GatePerp) FetchOrder(ctx context.Context, symbol string, orderId string
 
 	settle := strings.ToLower(market.Settle)
 
-	// Gate API 支持通过 order_id 或 text 参数（clientOrderId）
-	var path string
-	var params map[string]interface{}
+	// 路径中的 order_id 支持订单ID或 text（客户端订单ID）
 	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
-	if orderId != "" {
-		path = fmt.Sprintf("/api/v4/futures/%s/orders/%s", settle, orderId)
-		params = nil
-	} else if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		// 使用 text 参数通过 clientOrderId 查询订单
-		path = fmt.Sprintf("/api/v4/futures/%s/orders", settle)
-		params = map[string]interface{}{
-			"text": *argsOpts.ClientOrderID,
+	if orderId == "" {
+		if argsOpts.ClientOrderID == nil || *argsOpts.ClientOrderID == "" {
+			return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 		}
-	} else {
-		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
 	}
+	path := fmt.Sprintf("/api/v4/futures/%s/orders/%s", settle, orderId)
 
-	resp, err := p.signAndRequest(ctx, "GET", path, params, nil)
+	resp, err := p.signAndRequest(ctx, "GET", path, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("fetch order: %w", err)
 	}
@@ -613,15 +1209,29 @@ func (p *GatePerp) FetchOrder(ctx context.Context, symbol string, orderId string
 		return nil, fmt.Errorf("unmarshal order: %w", err)
 	}
 
-	// 将 Gate 响应转换为 model.PerpOrder
-	// 计算实际成交数量（size - left）
+	return toGatePerpOrder(symbol, contractMultiplier(market), &data), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (p *GatePerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 暂未接入 Gate 成交记录接口
+func (p *GatePerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, fmt.Errorf("fetch my trades: %w", common.ErrNotSupported)
+}
+
+// toGatePerpOrder 将 Gate 订单转换为 model.PerpOrder，张数按 quanto_multiplier 换算为币的数量（查询订单和私有频道推送共用）
+func toGatePerpOrder(symbol string, multiplier decimal.Decimal, data *gatePerpFetchOrderResponse) *model.PerpOrder {
+	// 计算实际成交数量（|size| - |left|，卖单的 size 为负数）
 	//nolint:staticcheck // QF1008: need to access Decimal field for Sub method
-	executedQtyDecimal := data.Size.Decimal.Sub(data.Left.Decimal)
+	executedQtyDecimal := data.Size.Decimal.Abs().Sub(data.Left.Decimal.Abs())
 	var executedQty types.ExDecimal
 	if executedQtyDecimal.IsNegative() {
 		executedQty = types.ExDecimal{Decimal: decimal.Zero}
 	} else {
-		executedQty = types.ExDecimal{Decimal: executedQtyDecimal}
+		executedQty = types.ExDecimal{Decimal: executedQtyDecimal.Mul(multiplier)}
 	}
 
 	// 确定订单方向和类型（Gate 的 size 正负表示方向）
@@ -643,9 +1253,9 @@ func (p *GatePerp) FetchOrder(ctx context.Context, symbol string, orderId string
 	// 确定 positionSide（Gate 没有明确的 positionSide，使用 BOTH）
 	positionSide := "BOTH"
 
-	order := &model.PerpOrder{
+	return &model.PerpOrder{
 		ID:           strconv.FormatInt(data.ID, 10),
-		ClientID:     data.Text,
+		ClientID:     gateClientOrderID(data.Text),
 		Type:         orderType,
 		Side:         side,
 		PositionSide: positionSide,
@@ -653,16 +1263,43 @@ func (p *GatePerp) FetchOrder(ctx context.Context, symbol string, orderId string
 		Price:        data.Price,
 		AvgPrice:     data.FillPrice,
 		//nolint:staticcheck // QF1008: need to access Decimal field for Abs method
-		Quantity:         types.ExDecimal{Decimal: data.Size.Decimal.Abs()}, // 使用绝对值作为数量
+		Quantity:         types.ExDecimal{Decimal: data.Size.Decimal.Abs().Mul(multiplier)}, // 使用绝对值作为数量
 		ExecutedQuantity: executedQty,
 		Status:           data.Status,
 		TimeInForce:      strings.ToUpper(data.Tif),
 		ReduceOnly:       data.IsReduceOnly,
 		CreateTime:       data.CreateTime,
 		UpdateTime:       data.UpdateTime,
+		Reason:           gatePerpOrderReason(data),
+	}
+}
+
+// gatePerpOrderReason 根据 is_liq 和 finish_as 识别强平（liquidated）和自动减仓（auto_deleveraged）订单
+func gatePerpOrderReason(data *gatePerpFetchOrderResponse) string {
+	switch {
+	case data.IsLiq || data.FinishAs == "liquidated":
+		return model.PositionReasonLiquidation
+	case data.FinishAs == "auto_deleveraged":
+		return model.PositionReasonADL
+	default:
+		return ""
 	}
+}
 
-	return order, nil
+func (p *GatePerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := p.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	account, err := p.fetchAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.PerpOrder]) (<-chan []*model.PerpOrder, error) {
+		return common.WatchTopicResync(ctx, p.gate.perpPrivateWS, gateWSPerpOrdersChannel+":"+account.userID(), p.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.PerpOrder, error) {
+		return p.FetchOrder(ctx, symbol, orderId)
+	}, common.PerpOrderFillSnapshot)
 }
 
 func (p *GatePerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
@@ -679,6 +1316,9 @@ func (p *GatePerp) SetLeverage(ctx context.Context, symbol string, leverage int,
 	if !market.Contract {
 		return fmt.Errorf("leverage only supported for contracts")
 	}
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
+	}
 
 	settle := strings.ToLower(market.Settle)
 	gateSymbol := market.ID
@@ -700,6 +1340,11 @@ func (p *GatePerp) SetLeverage(ctx context.Context, symbol string, leverage int,
 	return err
 }
 
+// SetLeverageSide Gate 双向持仓的杠杆接口（dual_comp/positions/{contract}/leverage）同时作用于多空两个方向，不支持单独设置
+func (p *GatePerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	return fmt.Errorf("set leverage side: %w: gate applies leverage to both sides", common.ErrNotSupported)
+}
+
 func (p *GatePerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -709,13 +1354,35 @@ func (p *GatePerp) SetMarginType(ctx context.Context, symbol string, marginType
 	return fmt.Errorf("not supported: Gate does not support setting margin type via API")
 }
 
+// SetPositionMode 设置 USDT 合约账户持仓模式（POST /futures/usdt/dual_mode），有持仓或挂单时无法修改
+// 双仓模式下平仓单的 size 方向与仓位相反并带 reduce_only，与单仓模式相同，下单无需额外参数
+func (p *GatePerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	params := map[string]interface{}{"dual_mode": strconv.FormatBool(hedged)}
+	if _, err := p.signAndRequest(ctx, "POST", "/api/v4/futures/usdt/dual_mode", params, nil); err != nil {
+		return fmt.Errorf("set position mode: %w", err)
+	}
+	p.positionMode.Set(hedged)
+	return nil
+}
+
+// GetPositionMode 查询 USDT 合约账户持仓模式（账户信息的 in_dual_mode）
+func (p *GatePerp) GetPositionMode(ctx context.Context) (bool, error) {
+	account, err := p.fetchAccount(ctx)
+	if err != nil {
+		return false, err
+	}
+	p.positionMode.Set(account.InDualMode)
+	return account.InDualMode, nil
+}
+
 var _ exchange.PerpExchange = (*GatePerp)(nil)
 
 // ========== 内部辅助方法 ==========
 
 // signAndRequest 签名并发送请求（Gate API）
 func (p *GatePerp) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if p.gate.client.SecretKey == "" {
+	creds := p.gate.credentials()
+	if creds.secretKey == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
@@ -736,20 +1403,33 @@ func (p *GatePerp) signAndRequest(ctx context.Context, method, path string, para
 	}
 
 	// 签名（使用同一个 timestamp 确保签名和请求头一致）
-	timestamp := common.GetTimestampSeconds()
-	signature := p.gate.signer.SignRequest(method, path, queryString, bodyStr, timestamp)
+	timestamp := p.gate.clock.TimestampSeconds()
+	signature := creds.signer.SignRequest(method, path, queryString, bodyStr, timestamp)
 
-	// 设置请求头
-	p.gate.client.HTTPClient.SetHeader("KEY", p.gate.client.APIKey)
-	p.gate.client.HTTPClient.SetHeader("Timestamp", strconv.FormatInt(timestamp, 10))
-	p.gate.client.HTTPClient.SetHeader("SIGN", signature)
-	p.gate.client.HTTPClient.SetHeader("Content-Type", "application/json")
-	p.gate.client.HTTPClient.SetHeader("X-Gate-Channel-Id", "api")
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"KEY":               creds.apiKey,
+		"Timestamp":         strconv.FormatInt(timestamp, 10),
+		"SIGN":              signature,
+		"Content-Type":      "application/json",
+		"X-Gate-Channel-Id": "api",
+	}
+
+	// body 为 nil 时不发送请求体，与签名时的空字符串一致
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
 
 	// 发送请求
-	if method == "GET" || method == "DELETE" {
-		return p.gate.client.HTTPClient.Get(ctx, path, params)
-	} else {
-		return p.gate.client.HTTPClient.Post(ctx, path, body)
+	switch method {
+	case "GET":
+		return p.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodGet, path, params, nil, headers)
+	case "DELETE":
+		return p.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodDelete, path, params, reqBody, headers)
+	case "PUT":
+		return p.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPut, path, nil, body, headers)
+	default:
+		return p.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, params, reqBody, headers)
 	}
 }
@@ -2,10 +2,19 @@ package gate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -194,3 +203,898 @@ func TestGatePerp_FetchTickers(t *testing.T) {
 		t.Logf("First ticker: %s, Last=%s", ticker.Symbol, ticker.Last.String())
 	}
 }
+
+// TestGatePerp_FetchPositions_MarginFields 测试持仓返回杠杆、强平价、保证金和保证金模式
+func TestGatePerp_FetchPositions_MarginFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/futures/usdt/positions" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"contract":"BTC_USDT","size":10,"leverage":"5","cross_leverage_limit":"0","entry_price":"50000","mark_price":"51000",
+			 "liq_price":"41000","margin":"100.5","maintenance_rate":"0.005","maintenance_margin":"2.55","value":"510",
+			 "unrealised_pnl":"10","realised_pnl":"-0.3","mode":"single","update_time":1700000000},
+			{"contract":"ETH_USDT","size":-3,"leverage":"0","cross_leverage_limit":"20","entry_price":"3000","mark_price":"2990",
+			 "liq_price":"3500","margin":"4.5","maintenance_rate":"0.01","maintenance_margin":"0.9","value":"89.7",
+			 "unrealised_pnl":"0.3","realised_pnl":"0","mode":"single","update_time":1700000000}
+		]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+
+	g := ex.(*Gate)
+	for _, m := range []*model.Market{
+		{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT"},
+		{ID: "ETH_USDT", Symbol: "ETH/USDT:USDT", Base: "ETH", Quote: "USDT"},
+	} {
+		g.perpMarketsBySymbol[m.Symbol] = m
+		g.perpMarketsByID[m.ID] = m
+	}
+
+	positions, err := ex.Perp().FetchPositions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2", len(positions))
+	}
+
+	tests := []struct {
+		symbol     string
+		side       string
+		leverage   string
+		liqPrice   string
+		margin     string
+		marginMode string
+		mmRate     string
+		realised   string
+	}{
+		{"BTC/USDT:USDT", "long", "5", "41000", "100.5", model.MarginModeIsolated, "0.005", "-0.3"},
+		{"ETH/USDT:USDT", "short", "20", "3500", "4.5", model.MarginModeCross, "0.01", "0"},
+	}
+
+	for i, tt := range tests {
+		p := positions[i]
+		if p.Symbol != tt.symbol || p.Side != tt.side {
+			t.Errorf("position %d = %s %s, want %s %s", i, p.Symbol, p.Side, tt.symbol, tt.side)
+		}
+		if !p.Leverage.Equal(decimal.RequireFromString(tt.leverage)) {
+			t.Errorf("%s Leverage = %s, want %s", tt.symbol, p.Leverage.String(), tt.leverage)
+		}
+		if !p.LiquidationPrice.Equal(decimal.RequireFromString(tt.liqPrice)) {
+			t.Errorf("%s LiquidationPrice = %s, want %s", tt.symbol, p.LiquidationPrice.String(), tt.liqPrice)
+		}
+		if !p.Margin.Equal(decimal.RequireFromString(tt.margin)) {
+			t.Errorf("%s Margin = %s, want %s", tt.symbol, p.Margin.String(), tt.margin)
+		}
+		if p.MarginMode != tt.marginMode {
+			t.Errorf("%s MarginMode = %s, want %s", tt.symbol, p.MarginMode, tt.marginMode)
+		}
+		if !p.MaintenanceMarginRate.Equal(decimal.RequireFromString(tt.mmRate)) {
+			t.Errorf("%s MaintenanceMarginRate = %s, want %s", tt.symbol, p.MaintenanceMarginRate.String(), tt.mmRate)
+		}
+		if !p.RealizedPnl.Equal(decimal.RequireFromString(tt.realised)) {
+			t.Errorf("%s RealizedPnl = %s, want %s", tt.symbol, p.RealizedPnl.String(), tt.realised)
+		}
+		if got := p.Timestamp.Unix(); got != 1700000000 {
+			t.Errorf("%s Timestamp = %d, want server update_time 1700000000", tt.symbol, got)
+		}
+	}
+}
+
+func TestGatePerp_CreateOrder_QuantoMultiplier(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/futures/usdt/orders":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"id":123456,"text":"t-my-order","update_time":1700000000}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/futures/usdt/orders/123456":
+			w.Write([]byte(`{"id":123456,"text":"t-my-order","contract":"BTC_USDT","price":"0","fill_price":"65000","size":-35,"left":-5,
+				"status":"open","tif":"ioc","is_reduce_only":false,"create_time":1700000000,"update_time":1700000001}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	// 每张合约 0.0001 BTC，最小 1 张
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	market.Limits.Amount.Min = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.00359", option.OpenShort, option.Market, option.WithClientOrderID("t-my-order")); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	// 0.00359 BTC / 0.0001 = 35.9 张，向下取整为 35 张，开空为负数
+	if body["size"] != float64(-35) {
+		t.Errorf("size = %v, want -35", body["size"])
+	}
+
+	// 不足 1 张时报错，而不是放大为 1 张
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.00005", option.OpenLong, option.Market); err == nil {
+		t.Error("expected error for amount below minimum contract size")
+	}
+
+	order, err := ex.Perp().FetchOrder(ctx, "BTC/USDT:USDT", "123456")
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	// 返回的数量换算为币的数量
+	if !order.Quantity.Equal(decimal.RequireFromString("0.0035")) {
+		t.Errorf("quantity = %s, want 0.0035", order.Quantity.String())
+	}
+	if !order.ExecutedQuantity.Equal(decimal.RequireFromString("0.003")) {
+		t.Errorf("executed quantity = %s, want 0.003", order.ExecutedQuantity.String())
+	}
+}
+
+func TestGatePerp_FetchOrderByClientID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/futures/usdt/orders":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			// Gate 要求 text 以 "t-" 开头
+			if body["text"] != "t-my-order" {
+				t.Errorf("text = %v, want t-my-order", body["text"])
+			}
+			w.Write([]byte(`{"id":123456,"text":"t-my-order","update_time":1700000000}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/futures/usdt/orders/t-my-order":
+			w.Write([]byte(`{"id":123456,"text":"t-my-order","contract":"BTC_USDT","price":"65000","fill_price":"0","size":10,"left":10,
+				"status":"open","tif":"gtc","is_reduce_only":false,"create_time":1700000000,"update_time":1700000001}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	created, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.001", option.OpenLong, option.Limit, option.WithPrice("65000"), option.WithClientOrderID("my-order"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if created.ClientOrderID != "my-order" {
+		t.Errorf("created ClientOrderID = %s, want my-order", created.ClientOrderID)
+	}
+	order, err := ex.Perp().FetchOrderByClientID(ctx, "BTC/USDT:USDT", created.ClientOrderID)
+	if err != nil {
+		t.Fatalf("FetchOrderByClientID: %v", err)
+	}
+	if order.ID != "123456" || order.ClientID != "my-order" {
+		t.Errorf("order = %+v, want ID 123456 and client ID my-order", order)
+	}
+}
+
+func TestGatePerp_FetchOrderBook(t *testing.T) {
+	var gotContract, gotLimit string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/futures/btc/order_book" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotContract = r.URL.Query().Get("contract")
+		gotLimit = r.URL.Query().Get("limit")
+		w.Write([]byte(`{"id":123456,"current":1700000000.123,"update":1700000000.1,
+			"asks":[{"p":"50000.5","s":120}],"bids":[{"p":"50000","s":80},{"p":"49999.9","s":30}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USD", Symbol: "BTC/USD:BTC", Base: "BTC", Quote: "USD", Settle: "BTC"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	book, err := ex.Perp().FetchOrderBook(context.Background(), "BTC/USD:BTC", 500)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+	if gotContract != "BTC_USD" || gotLimit != "100" {
+		t.Errorf("contract/limit = %s/%s, want BTC_USD/100", gotContract, gotLimit)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 1 {
+		t.Fatalf("got %d bids / %d asks, want 2/1", len(book.Bids), len(book.Asks))
+	}
+	if book.Asks[0].Price.String() != "50000.5" || book.Asks[0].Amount.String() != "120" {
+		t.Errorf("ask[0] = %s@%s, want 120@50000.5", book.Asks[0].Amount, book.Asks[0].Price)
+	}
+	if book.Nonce != 123456 {
+		t.Errorf("Nonce = %d, want 123456", book.Nonce)
+	}
+	if got := book.Timestamp.UnixMilli(); got != 1700000000123 {
+		t.Errorf("Timestamp = %d, want 1700000000123", got)
+	}
+}
+
+func TestGatePerp_CreateOrder_StopPrice(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/futures/usdt/price_orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"id":1432329}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	// 平多止损：价格下跌到触发价时按市价平仓
+	order, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "0.001", option.CloseLong, option.Market, option.WithStopPrice("60000"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "1432329" || order.TriggerPrice.String() != "60000" {
+		t.Errorf("OrderId/TriggerPrice = %s/%s, want 1432329/60000", order.OrderId, order.TriggerPrice)
+	}
+
+	initial, _ := body["initial"].(map[string]interface{})
+	trigger, _ := body["trigger"].(map[string]interface{})
+	if initial["size"] != float64(-10) || initial["price"] != "0" || initial["reduce_only"] != true || initial["text"] != nil {
+		t.Errorf("initial = %v, want size -10, price 0, reduce_only, no text", initial)
+	}
+	if trigger["price"] != "60000" || trigger["rule"] != float64(2) {
+		t.Errorf("trigger = %v, want price 60000 rule 2", trigger)
+	}
+}
+
+func TestGatePerp_FetchOpenInterest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/futures/usdt/contract_stats" || r.URL.Query().Get("contract") != "BTC_USDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"time":1700000000,"lsr_taker":1.2,"lsr_account":0.9,"long_liq_size":0,"short_liq_size":0,
+			"open_interest":124724,"short_liq_usd":0,"mark_price":"36500.1","top_lsr_size":1.02,"short_liq_amount":0,
+			"long_liq_amount":0,"open_interest_usd":45525583.28,"top_lsr_account":1.5,"long_liq_usd":0}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	oi, err := ex.Perp().FetchOpenInterest(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchOpenInterest: %v", err)
+	}
+	// 124724 张 × 0.0001 BTC
+	if oi.OpenInterestAmount.String() != "12.4724" || oi.OpenInterestValue.String() != "45525583.28" {
+		t.Errorf("unexpected open interest: amount=%s value=%s", oi.OpenInterestAmount, oi.OpenInterestValue)
+	}
+	if got := oi.Timestamp.Unix(); got != 1700000000 {
+		t.Errorf("Timestamp = %d, want 1700000000", got)
+	}
+
+	if _, err := ex.Perp().FetchOpenInterest(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestGate_Has(t *testing.T) {
+	ex, err := NewGate("", "", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+
+	// Gate 不支持通过 API 设置保证金模式，也不支持闪兑
+	has := ex.Has()
+	if !has.Spot.Supported || !has.Perp.Supported || has.Perp.SetMarginMode || has.Spot.CreateConversion {
+		t.Errorf("Has = %+v", has)
+	}
+	if !has.Perp.SetLeverage || !has.Perp.CreateStopOrder || !has.Perp.WatchPositions {
+		t.Errorf("Has().Perp = %+v", has.Perp)
+	}
+	if err := ex.Perp().SetMarginType(context.Background(), "BTC/USDT:USDT", option.ISOLATED); err == nil {
+		t.Error("SetMarginType error = nil")
+	}
+}
+
+func TestGateWSCandlestick(t *testing.T) {
+	msg, err := gateWSProtocol{}.SubscribeMessage([]string{gateWSTopic("futures.candlesticks", gateCandlestickName("1m", "BTC_USDT"))})
+	if err != nil {
+		t.Fatalf("SubscribeMessage: %v", err)
+	}
+	var req gateWSRequest
+	if err := json.Unmarshal(msg, &req); err != nil {
+		t.Fatalf("unmarshal subscribe message: %v", err)
+	}
+	if req.Channel != "futures.candlesticks" || len(req.Payload) != 2 || req.Payload[0] != "1m" || req.Payload[1] != "BTC_USDT" {
+		t.Errorf("unexpected subscribe message: %s", msg)
+	}
+
+	// 盘中更新后收盘推送，最终收盘K线与 REST 快照一致
+	frames := []string{
+		`{"time":1700000050,"time_ms":1700000050000,"channel":"futures.candlesticks","event":"update","result":[{"t":1700000040,"v":120,"c":"100.5","h":"101","l":"99.5","o":"100","n":"1m_BTC_USDT","a":"0.12","w":false}]}`,
+		`{"time":1700000100,"time_ms":1700000100000,"channel":"futures.candlesticks","event":"update","result":[{"t":1700000040,"v":300,"c":"101","h":"102.5","l":"99","o":"100","n":"1m_BTC_USDT","a":"0.3","w":true}]}`,
+	}
+	var candles []*model.OHLCV
+	for _, frame := range frames {
+		topic, ok := gateWSProtocol{}.Route([]byte(frame))
+		if !ok || topic != "futures.candlesticks:1m_BTC_USDT" {
+			t.Fatalf("Route = %q, %v", topic, ok)
+		}
+		candle, ok := parseGateWSPerpCandlestick([]byte(frame))
+		if !ok {
+			t.Fatalf("parse %s failed", frame)
+		}
+		candles = append(candles, candle)
+	}
+
+	if candles[0].Closed || !candles[1].Closed {
+		t.Errorf("Closed = %v, %v, want false, true", candles[0].Closed, candles[1].Closed)
+	}
+	var snapshot gatePerpKlineResponse
+	if err := json.Unmarshal([]byte(`[{"t":1700000040,"v":300,"c":"101","h":"102.5","l":"99","o":"100","sum":"30225"}]`), &snapshot); err != nil {
+		t.Fatalf("unmarshal snapshot: %v", err)
+	}
+	final, want := candles[1], snapshot[0]
+	if !final.Timestamp.Equal(want.Time.Time) || !final.Open.Equal(want.Open.Decimal) || !final.High.Equal(want.High.Decimal) ||
+		!final.Low.Equal(want.Low.Decimal) || !final.Close.Equal(want.Close.Decimal) || final.Volume.IntPart() != want.Volume {
+		t.Errorf("closed candle = %+v, want snapshot %+v", final, want)
+	}
+}
+
+func TestGatePerp_WatchOrders_SignedSubscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v4/futures/usdt/accounts" {
+			w.Write([]byte(`{"user":1666,"currency":"USDT","total":"100","available":"100"}`))
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		// 订阅消息附带用户ID和签名
+		var req gatePrivateWSRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		if req.Channel != "futures.orders" || req.Event != "subscribe" || strings.Join(req.Payload, ",") != "1666,!all" {
+			t.Errorf("unexpected subscribe message: %+v", req)
+		}
+		if req.Auth == nil || req.Auth.Method != "api_key" || req.Auth.Key != "key" || req.Auth.Sign != NewSigner("secret").SignWSRequest("futures.orders", "subscribe", req.Time) {
+			t.Errorf("invalid auth: %+v", req.Auth)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"time":1700000000,"channel":"futures.orders","event":"subscribe","result":{"status":"success"}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"time":1700000001,"channel":"futures.orders","event":"update","result":[
+			{"id":4872460,"text":"t-1","contract":"BTC_USDT","size":-100,"left":40,"price":50000,"fill_price":50010,"tif":"gtc","is_reduce_only":false,"status":"open","create_time":1700000000,"update_time":1700000001,"user":"1666"},
+			{"id":4872461,"contract":"ETH_USDT","size":1,"left":0,"price":0,"tif":"ioc","status":"finished","user":"1666"}
+		]}`))
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+	g.perpPrivateWS = common.NewWSManager(common.NewWSDialer("ws"+strings.TrimPrefix(srv.URL, "http"), ""), gatePrivateWSProtocol{gate: g, pingChannel: "futures.ping"})
+	defer g.perpPrivateWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	orders, err := ex.Perp().WatchOrders(ctx)
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+
+	// 未加载市场信息的 ETH_USDT 订单无法换算张数，不推送
+	select {
+	case order := <-orders:
+		if order.ID != "4872460" || order.Symbol != "BTC/USDT:USDT" || order.Side != "sell" || order.ClientID != "1" {
+			t.Errorf("unexpected order: %+v", order)
+		}
+		// 100 张 * 0.0001 = 0.01 BTC，已成交 60 张
+		if order.Quantity.String() != "0.01" || order.ExecutedQuantity.String() != "0.006" {
+			t.Errorf("Quantity = %s, ExecutedQuantity = %s", order.Quantity, order.ExecutedQuantity)
+		}
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for order")
+	}
+	select {
+	case order := <-orders:
+		t.Errorf("unexpected order: %+v", order)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestParseGateWSBalances(t *testing.T) {
+	spotMsg := []byte(`{"time":1700000000,"channel":"spot.balances","event":"update","result":[{"timestamp":"1700000000","timestamp_ms":"1700000000123","user":"1000001","currency":"USDT","change":"100","total":"1100","available":"1000","freeze":"100"}]}`)
+	if topic, ok := (gatePrivateWSProtocol{}).Route(spotMsg); !ok || topic != gateWSSpotBalancesTopic {
+		t.Fatalf("Route = %q, %v", topic, ok)
+	}
+	balances, ok := parseGateWSSpotBalances(spotMsg)
+	if !ok || len(balances) != 1 {
+		t.Fatalf("parseGateWSSpotBalances = %v, %v", balances, ok)
+	}
+	if bal := balances[0]; bal.Currency != "USDT" || bal.Total.String() != "1100" || bal.Available.String() != "1000" || bal.Locked.String() != "100" || bal.UpdatedAt.UnixMilli() != 1700000000123 {
+		t.Errorf("unexpected spot balance: %+v", bal)
+	}
+
+	perpMsg := []byte(`{"time":1700000000,"channel":"futures.balances","event":"update","result":[{"balance":9.5,"change":-0.5,"text":"BTC_USDT:1","time":1700000000,"time_ms":1700000000123,"type":"fee","user":"1666","currency":"usdt"}]}`)
+	if topic, ok := (gatePrivateWSProtocol{}).Route(perpMsg); !ok || topic != "futures.balances:1666" {
+		t.Fatalf("Route = %q, %v", topic, ok)
+	}
+	balances, ok = parseGateWSPerpBalances(perpMsg)
+	if !ok || len(balances) != 1 || balances[0].Currency != "USDT" || balances[0].Total.String() != "9.5" {
+		t.Errorf("parseGateWSPerpBalances = %v, %v", balances, ok)
+	}
+}
+
+func TestGatePerp_FetchMarkPrice(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/futures/usdt/tickers" || r.URL.Query().Get("contract") != "BTC_USDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"contract":"BTC_USDT","last":"6432","low_24h":"6278","high_24h":"6790","change_percentage":"4.43","total_size":"32323904","volume_24h":"184040233284","volume_24h_base":"28613220","volume_24h_quote":"184040233284","mark_price":"6534","funding_rate":"0.0001","index_price":"6531","highest_bid":"34089.7","lowest_ask":"34217.9","quanto_multiplier":"0.0001"}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	ticker, err := ex.Perp().FetchMarkPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchMarkPrice: %v", err)
+	}
+	if ticker.MarkPrice.String() != "6534" || ticker.IndexPrice.String() != "6531" || ticker.Last.String() != "6432" {
+		t.Errorf("mark/index/last = %s/%s/%s, want 6534/6531/6432", ticker.MarkPrice, ticker.IndexPrice, ticker.Last)
+	}
+
+	index, err := ex.Perp().FetchIndexPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil || index.String() != "6531" {
+		t.Errorf("FetchIndexPrice = %s, %v", index, err)
+	}
+
+	if _, err := ex.Perp().FetchMarkPrice(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestGatePerp_FetchTickers_LoadsMarkets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/futures/usdt/contracts":
+			w.Write([]byte(`[{"name":"BTC_USDT","type":"direct","quanto_multiplier":"0.0001","order_price_round":"0.1","order_size_min":1,"order_size_max":1000000}]`))
+		case "/api/v4/futures/usdt/tickers":
+			// ETH_USDT 不在市场信息中，直接由合约名称标准化
+			w.Write([]byte(`[{"contract":"BTC_USDT","last":"36500"},{"contract":"ETH_USDT","last":"2000"}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+
+	tickers, err := ex.Perp().FetchTickers(context.Background())
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	want := map[string]string{"BTC/USDT:USDT": "36500", "ETH/USDT:USDT": "2000"}
+	if len(tickers) != len(want) {
+		t.Fatalf("expected %d tickers, got %d", len(want), len(tickers))
+	}
+	for _, ticker := range tickers {
+		if last, ok := want[ticker.Symbol]; !ok || ticker.Last.String() != last {
+			t.Errorf("unexpected ticker %s last=%s", ticker.Symbol, ticker.Last)
+		}
+	}
+}
+
+func TestGatePerp_CreateOrder_ReduceOnlyPostOnly(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/futures/usdt/orders":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"id":123456,"text":"t-post","update_time":1700000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	ctx := context.Background()
+	_, err = ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.001", option.OpenShort, option.Limit,
+		option.WithPrice("60000"), option.WithPostOnly(true), option.WithReduceOnly(true), option.WithClientOrderID("t-post"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if body["tif"] != "poc" || body["reduce_only"] != true {
+		t.Errorf("tif/reduce_only = %v/%v, want poc/true", body["tif"], body["reduce_only"])
+	}
+
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.001", option.OpenShort, option.Market, option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only market order err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestGatePerp_CreateOrder_TimeInForce(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v4/futures/usdt/orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"id":123456,"text":"t-tif","update_time":1700000000}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	tests := []struct {
+		orderType option.OrderType
+		tif       option.TimeInForce
+		want      string
+	}{
+		{option.Limit, option.GTC, "gtc"},
+		{option.Limit, option.IOC, "ioc"},
+		{option.Limit, option.FOK, "fok"},
+		{option.Limit, option.GTX, "poc"},
+		{option.Market, option.GTC, "ioc"}, // 市价单忽略 GTC
+		{option.Market, option.FOK, "fok"},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		_, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.001", option.OpenLong, tt.orderType,
+			option.WithPrice("60000"), option.WithTimeInForce(tt.tif), option.WithClientOrderID("t-tif"))
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.orderType, tt.tif, err)
+		}
+		if body["tif"] != tt.want {
+			t.Errorf("%s %s: tif = %v, want %s", tt.orderType, tt.tif, body["tif"], tt.want)
+		}
+	}
+
+	if _, err := ex.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "0.001", option.OpenLong, option.Market, option.WithTimeInForce(option.GTX)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("market GTX: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+// TestGatePerp_ClosePosition 测试按持仓张数市价减仓平仓
+func TestGatePerp_ClosePosition(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/futures/usdt/positions":
+			w.Write([]byte(`[{"contract":"BTC_USDT","size":10,"leverage":"5","entry_price":"50000","mark_price":"51000",
+				"margin":"100","unrealised_pnl":"10","realised_pnl":"0","mode":"single","update_time":1700000000}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/futures/usdt/orders":
+			body = nil
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"id":123456,"text":"t-close","update_time":1700000000}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	tests := []struct {
+		percent string
+		size    float64
+	}{
+		{"", -10},
+		{"50", -5},
+		{"33", -3}, // 按整张向下取整
+	}
+	for _, tt := range tests {
+		var opts []option.ArgsOption
+		if tt.percent != "" {
+			opts = append(opts, option.WithClosePercent(tt.percent))
+		}
+		if _, err := ex.Perp().ClosePosition(context.Background(), market.Symbol, opts...); err != nil {
+			t.Fatalf("ClosePosition(%q): %v", tt.percent, err)
+		}
+		if body["size"] != tt.size || body["reduce_only"] != true || body["tif"] != "ioc" {
+			t.Errorf("ClosePosition(%q) body = %v, want size %v reduce-only ioc", tt.percent, body, tt.size)
+		}
+	}
+
+	if _, err := ex.Perp().ClosePosition(context.Background(), market.Symbol, option.WithClosePercent("5")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("close below one contract: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestGatePerp_FetchTicker_BidAsk(t *testing.T) {
+	var tickerBody string
+	var bookRequests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/futures/usdt/tickers":
+			w.Write([]byte(tickerBody))
+		case "/api/v4/futures/usdt/order_book":
+			bookRequests++
+			if r.URL.Query().Get("limit") != "1" {
+				t.Errorf("order book limit = %s, want 1", r.URL.Query().Get("limit"))
+			}
+			w.Write([]byte(`{"id":1,"current":1700000000.123,"update":1700000000.1,
+				"asks":[{"p":"50000.5","s":10}],"bids":[{"p":"49999.5","s":8}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	// 行情自带买一卖一时不请求深度
+	tickerBody = `[{"contract":"BTC_USDT","last":"50000","low_24h":"49000","high_24h":"51000","highest_bid":"49999.9","lowest_ask":"50000.1",
+		"volume_24h_base":"100","volume_24h_quote":"5000000","mark_price":"50000.2","index_price":"50000.3"}]`
+	ticker, err := ex.Perp().FetchTicker(ctx, "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Bid.String() != "49999.9" || ticker.Ask.String() != "50000.1" || ticker.High.String() != "51000" || ticker.Low.String() != "49000" {
+		t.Errorf("bid/ask/high/low = %s/%s/%s/%s", ticker.Bid, ticker.Ask, ticker.High, ticker.Low)
+	}
+	if bookRequests != 0 {
+		t.Errorf("order book requested %d times, want 0", bookRequests)
+	}
+
+	// 买一卖一为空时回退到 1 档深度
+	tickerBody = `[{"contract":"BTC_USDT","last":"50000","low_24h":"49000","high_24h":"51000","highest_bid":"","lowest_ask":"",
+		"volume_24h_base":"100","volume_24h_quote":"5000000","mark_price":"50000.2","index_price":"50000.3"}]`
+	ticker, err = ex.Perp().FetchTicker(ctx, "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker fallback: %v", err)
+	}
+	if ticker.Bid.String() != "49999.5" || ticker.Ask.String() != "50000.5" {
+		t.Errorf("fallback bid/ask = %s/%s, want 49999.5/50000.5", ticker.Bid, ticker.Ask)
+	}
+	if bookRequests != 1 {
+		t.Errorf("order book requested %d times, want 1", bookRequests)
+	}
+}
+
+func TestGatePerp_FetchOHLCVs_Precision(t *testing.T) {
+	// 收盘价超出 float64 精度，应原样解析为 decimal
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"t":1700000000,"v":12,"o":"65000.1","h":"65100.5","l":"64900.000000000000000001","c":"65000.123456789012345678","sum":"780000"}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+
+	ohlcvs, err := ex.Perp().FetchOHLCVs(context.Background(), "BTC/USDT:USDT", "1m", 1)
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Close.String() != "65000.123456789012345678" ||
+		ohlcvs[0].Low.String() != "64900.000000000000000001" || ohlcvs[0].Volume.String() != "12" {
+		t.Errorf("ohlcvs = %+v, want high-precision values preserved", ohlcvs)
+	}
+}
+
+func TestGatePerp_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v4/futures/usdt/orders/123456":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			edits = append(edits, body)
+			w.Write([]byte(`{"id":123456}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v4/futures/usdt/orders/123456":
+			w.Write([]byte(`{"id":123456,"contract":"BTC_USDT","price":"65000","fill_price":"0","size":-20,"left":-20,
+				"status":"open","tif":"gtc","is_reduce_only":false,"create_time":1700000000,"update_time":1700000001}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.1")}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	// 只改数量：0.00359 BTC 换算为 35 张，原订单为卖单，size 为负数，不发送价格
+	if _, err := ex.Perp().EditOrder(ctx, "BTC/USDT:USDT", "123456", "0.00359", ""); err != nil {
+		t.Fatalf("EditOrder amount: %v", err)
+	}
+	if len(edits) != 1 || edits[0]["size"] != float64(-35) || edits[0]["price"] != nil {
+		t.Errorf("amount edit body = %v, want size -35 without price", edits)
+	}
+
+	// 只改价格：按价格步长对齐，不发送数量
+	if _, err := ex.Perp().EditOrder(ctx, "BTC/USDT:USDT", "123456", "", "65000.27"); err != nil {
+		t.Fatalf("EditOrder price: %v", err)
+	}
+	if len(edits) != 2 || edits[1]["price"] != "65000.2" || edits[1]["size"] != nil {
+		t.Errorf("price edit body = %v, want price 65000.2 without size", edits[len(edits)-1])
+	}
+}
+
+func TestGatePerp_Drain_CancelsOrders(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path != "/api/v4/futures/usdt/orders/123456" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status := "open"
+		if r.Method == http.MethodDelete {
+			status = "finished"
+		}
+		w.Write([]byte(`{"id":123456,"contract":"BTC_USDT","price":"65000","fill_price":"0","size":20,"left":20,
+			"status":"` + status + `","finish_as":"cancelled","tif":"gtc","create_time":1700000000,"update_time":1700000001}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL, "cancelOrdersOnDrain": true})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT", Contract: true, ContractValue: "0.0001"}
+	g.perpMarketsBySymbol[market.Symbol] = market
+	g.perpMarketsByID[market.ID] = market
+	g.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: market.Symbol, OrderID: "123456"})
+
+	if err := ex.Drain(context.Background()); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	// 撤单必须以 DELETE 发送，以 GET 发送只会查询订单
+	want := []string{"GET /api/v4/futures/usdt/orders/123456", "DELETE /api/v4/futures/usdt/orders/123456"}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	if orders := g.lifecycle.Orders(); len(orders) != 0 {
+		t.Errorf("expected no tracked orders after drain, got %+v", orders)
+	}
+}
+
+func TestGatePerp_OrderReason(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"id":1,"size":-1,"left":0,"status":"finished","finish_as":"liquidated"}`, model.PositionReasonLiquidation},
+		{`{"id":2,"size":-1,"left":0,"status":"finished","finish_as":"filled","is_liq":true}`, model.PositionReasonLiquidation},
+		{`{"id":3,"size":-1,"left":0,"status":"finished","finish_as":"auto_deleveraged"}`, model.PositionReasonADL},
+		{`{"id":4,"size":-1,"left":0,"status":"finished","finish_as":"filled"}`, ""},
+	}
+	for _, tt := range tests {
+		var data gatePerpFetchOrderResponse
+		if err := json.Unmarshal([]byte(tt.body), &data); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if got := toGatePerpOrder("BTC/USDT:USDT", decimal.NewFromInt(1), &data).Reason; got != tt.want {
+			t.Errorf("%s: Reason = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
 	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // GateSpot Gate 现货实现
@@ -45,10 +47,35 @@ func (s *GateSpot) GetMarket(symbol string) (*model.Market, error) {
 	return s.market.GetMarket(symbol)
 }
 
+func (s *GateSpot) GetMarketByID(id string) (*model.Market, error) {
+	return s.market.GetMarketByID(id)
+}
+
 func (s *GateSpot) GetMarkets() ([]*model.Market, error) {
 	return s.market.GetMarkets()
 }
 
+func (s *GateSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *GateSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *GateSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
 func (s *GateSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
 	return s.market.FetchTicker(ctx, symbol)
 }
@@ -57,6 +84,68 @@ func (s *GateSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker,
 	return s.market.FetchTickers(ctx)
 }
 
+func (s *GateSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+func (s *GateSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return s.market.FetchOrderBook(ctx, symbol, limit)
+}
+
+func (s *GateSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.gate.spotWS, gateWSTopic("spot.tickers", market.ID), parseGateWSSpotTicker(market.Symbol))
+}
+
+func (s *GateSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  s.gate.spotWS,
+		Topic:    gateWSTopic("spot.order_book_update", market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequenceRange,
+		Parse:    parseGateWSSpotOrderBook,
+		FetchSnapshot: func(ctx context.Context) (*model.OrderBook, error) {
+			return s.FetchOrderBook(ctx, market.Symbol, gateMaxDepthLimit)
+		},
+	})
+}
+
+func (s *GateSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, common.GateTimeframe(timeframe), gateSpotTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.gate.spotWS, gateWSTopic("spot.candlesticks", gateCandlestickName(interval, market.ID)), parseGateWSSpotCandlestick)
+}
+
 func (s *GateSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -70,25 +159,187 @@ func (s *GateSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe str
 	if argsOpts.Since != nil {
 		since = *argsOpts.Since
 	}
-	return s.market.FetchOHLCVs(ctx, symbol, timeframe, since, limit)
+	until, _ := option.GetTime(argsOpts.Until)
+	ohlcvs, err := s.market.FetchOHLCVs(ctx, symbol, timeframe, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.gate.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *GateSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, gateOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *GateSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *GateSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return s.market.FetchAggregatedTrades(ctx, symbol, since, limit)
 }
 
-func (s *GateSpot) FetchBalance(ctx context.Context) (model.Balances, error) {
-	return s.order.FetchBalance(ctx)
+func (s *GateSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	return s.order.FetchBalance(ctx, opts...)
+}
+
+// WatchBalance 通过私有频道 spot.balances 订阅现货余额，推送只包含发生变化的币种
+func (s *GateSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.gate.spotPrivateWS, gateWSSpotBalancesTopic, parseGateWSSpotBalances)
 }
 
 func (s *GateSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	order, err := common.CreateOrderIdempotent(ctx, s.gate.client.HTTPClient, s.gate.Name(), side.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.SpotOrder, error) {
+		return s.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		s.gate.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+// CreateOrders 批量创建订单（Gate 现货逐个提交）
+func (s *GateSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+// CreateOCOOrder Gate 没有 OCO 订单接口
+func (s *GateSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, fmt.Errorf("create oco order: %w: Gate has no OCO order API", common.ErrNotSupported)
 }
 
 func (s *GateSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
-	return s.order.CancelOrder(ctx, symbol, orderId, opts...)
+	if err := s.order.CancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	s.gate.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *GateSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return s.order.EditOrder(ctx, symbol, orderId, newAmount, newPrice, opts...)
 }
 
 func (s *GateSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
 	return s.order.FetchOrder(ctx, symbol, orderId, opts...)
 }
 
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *GateSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 暂未接入 Gate 成交记录接口
+func (s *GateSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, fmt.Errorf("fetch my trades: %w", common.ErrNotSupported)
+}
+
+func (s *GateSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.SpotOrder]) (<-chan []*model.SpotOrder, error) {
+		return common.WatchTopicResync(ctx, s.gate.spotPrivateWS, gateWSSpotOrdersTopic, s.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.SpotOrder, error) {
+		return s.FetchOrder(ctx, symbol, orderId)
+	}, common.SpotOrderFillSnapshot)
+}
+
+// WatchOrders 通过私有频道 spot.orders 订阅全部交易对的现货订单更新，订单每次状态变化推送一次
+func (s *GateSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	ctx, err := s.gate.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, s.gate.spotPrivateWS, gateWSSpotOrdersTopic, s.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 spot.orders 的现货订单推送（WatchOrders 和 TrackOrder 共用）
+func (s *GateSpot) parseWSOrders() common.WSParser[[]*model.SpotOrder] {
+	return parseGateWSOrders(func(raw json.RawMessage) (*model.SpotOrder, bool) {
+		var item gateWSSpotOrder
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		symbol := item.CurrencyPair
+		if market, err := s.GetMarket(item.CurrencyPair); err == nil {
+			symbol = market.Symbol
+		}
+		return s.order.parseOrder(item.toSpotOrderResponse(), symbol), true
+	})
+}
+
+func (s *GateSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, fmt.Errorf("not supported: Gate does not support convert via API")
+}
+
+func (s *GateSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return s.order.FetchCurrencies(ctx)
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *GateSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *GateSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return s.order.FetchDepositAddress(ctx, currency, network)
+}
+
+func (s *GateSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return s.order.Withdraw(ctx, currency, amount, address, network, params)
+}
+
+func (s *GateSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return s.order.Transfer(ctx, currency, amount, fromAccount, toAccount)
+}
+
+// FetchBorrowRate 暂未接入 Gate 杠杆借贷接口
+func (s *GateSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, fmt.Errorf("fetch borrow rate: %w: Gate margin API is not integrated", common.ErrNotSupported)
+}
+
+// Borrow 暂未接入 Gate 杠杆借贷接口
+func (s *GateSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return fmt.Errorf("borrow: %w: Gate margin API is not integrated", common.ErrNotSupported)
+}
+
+// Repay 暂未接入 Gate 杠杆借贷接口
+func (s *GateSpot) Repay(ctx context.Context, currency, amount string) error {
+	return fmt.Errorf("repay: %w: Gate margin API is not integrated", common.ErrNotSupported)
+}
+
+// FetchSubAccounts 暂未接入 Gate 子账户接口
+func (s *GateSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, fmt.Errorf("fetch sub accounts: %w: Gate sub-account API is not integrated", common.ErrNotSupported)
+}
+
+// FetchBalanceFor 暂未接入 Gate 子账户接口
+func (s *GateSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, fmt.Errorf("fetch balance for: %w: Gate sub-account API is not integrated", common.ErrNotSupported)
+}
+
 var _ exchange.SpotExchange = (*GateSpot)(nil)
 
 // ========== 内部实现 ==========
@@ -98,9 +349,9 @@ type gateSpotMarket struct {
 }
 
 func (m *gateSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	m.gate.mu.RLock()
-	if !reload && len(m.gate.spotMarketsBySymbol) > 0 {
+	if !reload && len(m.gate.spotMarketsBySymbol) > 0 && !m.gate.marketCache.Expired(model.MarketTypeSpot) {
 		m.gate.mu.RUnlock()
 		return nil
 	}
@@ -136,9 +387,11 @@ func (m *gateSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 			Active: s.TradeStatus == "tradable",
 		}
 
-		// 解析精度
+		// 解析精度（Gate 现货只返回小数位数，步长为 10^-precision）
 		market.Precision.Amount = s.AmountPrecision
 		market.Precision.Price = s.Precision
+		market.Precision.StepSize = types.ExDecimal{Decimal: decimal.New(1, -int32(s.AmountPrecision))}
+		market.Precision.TickSize = types.ExDecimal{Decimal: decimal.New(1, -int32(s.Precision))}
 
 		// 解析限制
 		if !s.MinBaseAmount.IsZero() {
@@ -154,16 +407,11 @@ func (m *gateSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	m.gate.mu.Lock()
-	if m.gate.spotMarketsBySymbol == nil {
-		m.gate.spotMarketsBySymbol = make(map[string]*model.Market)
-		m.gate.spotMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		m.gate.spotMarketsBySymbol[market.Symbol] = market
-		m.gate.spotMarketsByID[market.ID] = market
-	}
+	m.gate.spotMarketsBySymbol, m.gate.spotMarketsByID = bySymbol, byID
+	m.gate.marketCache.Touch(model.MarketTypeSpot)
 	m.gate.mu.Unlock()
 
 	return nil
@@ -199,7 +447,18 @@ func (m *gateSpotMarket) GetMarket(key string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", key)
+	return nil, common.MarketNotFound(key, m.gate.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (m *gateSpotMarket) GetMarketByID(id string) (*model.Market, error) {
+	m.gate.mu.RLock()
+	defer m.gate.mu.RUnlock()
+
+	if market, ok := m.gate.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, m.gate.spotMarketsByID)
 }
 
 func (m *gateSpotMarket) GetMarkets() ([]*model.Market, error) {
@@ -301,7 +560,40 @@ func (m *gateSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.Ti
 	return tickers, nil
 }
 
-func (m *gateSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since time.Time, limit int) (model.OHLCVs, error) {
+func (m *gateSpotMarket) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"currency_pair": market.ID,
+		"with_id":       "true",
+	}
+	if limit = common.ClampOrderBookLimit(limit, gateMaxDepthLimit); limit > 0 {
+		params["limit"] = limit
+	}
+
+	resp, err := m.gate.client.HTTPClient.Get(ctx, "/api/v4/spot/order_book", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var data gateSpotOrderBookResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.ID,
+		Timestamp: data.Current,
+	}, nil
+}
+
+func (m *gateSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since, until time.Time, limit int) (model.OHLCVs, error) {
 	// 获取市场信息
 	market, err := m.GetMarket(symbol)
 	if err != nil {
@@ -309,7 +601,10 @@ func (m *gateSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefra
 	}
 
 	// 标准化时间框架
-	normalizedTimeframe := common.GateTimeframe(timeframe)
+	normalizedTimeframe, err := common.CheckTimeframe(timeframe, common.GateTimeframe(timeframe), gateSpotTimeframes)
+	if err != nil {
+		return nil, err
+	}
 
 	params := map[string]interface{}{
 		"currency_pair": market.ID,
@@ -319,6 +614,13 @@ func (m *gateSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefra
 	if !since.IsZero() {
 		params["from"] = since.Unix()
 	}
+	if !until.IsZero() {
+		params["to"] = until.Unix()
+		// Gate 不允许 limit 与 from、to 同时使用
+		if !since.IsZero() {
+			delete(params, "limit")
+		}
+	}
 
 	resp, err := m.gate.client.HTTPClient.Get(ctx, "/api/v4/spot/candlesticks", params)
 	if err != nil {
@@ -346,13 +648,54 @@ func (m *gateSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefra
 	return ohlcvs, nil
 }
 
+// FetchAggregatedTrades 获取归集交易（Gate 无原生接口，由逐笔成交归集）
+func (m *gateSpotMarket) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"currency_pair": market.ID,
+		"limit":         gateMaxTradesLimit,
+	}
+	if !since.IsZero() {
+		params["from"] = since.Unix()
+	}
+
+	resp, err := m.gate.client.HTTPClient.Get(ctx, "/api/v4/spot/trades", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch trades: %w", err)
+	}
+
+	var data gateSpotTradesResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
+	}
+
+	trades := make([]*model.Trade, 0, len(data))
+	for _, item := range data {
+		trades = append(trades, &model.Trade{
+			ID:        item.ID,
+			Symbol:    market.Symbol,
+			Side:      item.Side,
+			Amount:    item.Amount.Decimal,
+			Price:     item.Price.Decimal,
+			Timestamp: time.UnixMicro(item.CreateTimeMs.Shift(3).IntPart()),
+		})
+	}
+
+	return common.AggregateTrades(trades, since, limit), nil
+}
+
 type gateSpotOrder struct {
 	gate *Gate
 }
 
 // signAndRequest 签名并发送请求（Gate API）
 func (o *gateSpotOrder) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if o.gate.client.SecretKey == "" {
+	creds := o.gate.credentials()
+	if creds.secretKey == "" {
 		return nil, fmt.Errorf("authentication required")
 	}
 
@@ -373,28 +716,45 @@ func (o *gateSpotOrder) signAndRequest(ctx context.Context, method, path string,
 	}
 
 	// 签名（使用同一个 timestamp 确保签名和请求头一致）
-	timestamp := common.GetTimestampSeconds()
-	signature := o.gate.signer.SignRequest(method, path, queryString, bodyStr, timestamp)
+	timestamp := o.gate.clock.TimestampSeconds()
+	signature := creds.signer.SignRequest(method, path, queryString, bodyStr, timestamp)
 
-	// 设置请求头
-	o.gate.client.HTTPClient.SetHeader("KEY", o.gate.client.APIKey)
-	o.gate.client.HTTPClient.SetHeader("Timestamp", strconv.FormatInt(timestamp, 10))
-	o.gate.client.HTTPClient.SetHeader("SIGN", signature)
-	o.gate.client.HTTPClient.SetHeader("Content-Type", "application/json")
-	o.gate.client.HTTPClient.SetHeader("X-Gate-Channel-Id", "api")
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"KEY":               creds.apiKey,
+		"Timestamp":         strconv.FormatInt(timestamp, 10),
+		"SIGN":              signature,
+		"Content-Type":      "application/json",
+		"X-Gate-Channel-Id": "api",
+	}
 
 	// 发送请求
 	switch method {
 	case "GET":
-		return o.gate.client.HTTPClient.Get(ctx, path, params)
+		return o.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodGet, path, params, nil, headers)
 	case "DELETE":
-		return o.gate.client.HTTPClient.Delete(ctx, path, params, body)
+		return o.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodDelete, path, params, body, headers)
+	case "PATCH":
+		return o.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPatch, path, params, body, headers)
 	default:
-		return o.gate.client.HTTPClient.Post(ctx, path, body)
+		return o.gate.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, nil, body, headers)
 	}
 }
 
-func (o *gateSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error) {
+// FetchBalance 获取余额，支持现货和 USDT 合约账户（杠杆账户需指定交易对，Gate 没有独立的资金账户）
+func (o *gateSpotOrder) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	switch accountType := option.GetAccountType(argsOpts.AccountType); accountType {
+	case option.AccountSpot:
+	case option.AccountFutures:
+		return o.fetchFuturesBalance(ctx)
+	default:
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+
 	// Gate 现货余额
 	resp, err := o.signAndRequest(ctx, "GET", "/api/v4/spot/accounts", nil, nil)
 	if err != nil {
@@ -414,7 +774,7 @@ func (o *gateSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error
 			Available: bal.Available,
 			Locked:    bal.Locked,
 			Total:     types.ExDecimal{Decimal: total},
-			UpdatedAt: types.ExTimestamp{Time: time.Now()},
+			UpdatedAt: types.ExTimestamp{Time: time.Now()}, // Gate 现货余额接口没有返回更新时间
 		}
 		balances = append(balances, balance)
 	}
@@ -422,6 +782,29 @@ func (o *gateSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error
 	return balances, nil
 }
 
+// fetchFuturesBalance 获取 USDT 合约账户余额，Total 包含未实现盈亏，Locked 为仓位和委托占用的保证金
+func (o *gateSpotOrder) fetchFuturesBalance(ctx context.Context) (model.Balances, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v4/futures/usdt/accounts", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch futures balance: %w", err)
+	}
+
+	var data gateSpotFuturesAccountResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal futures balance: %w", err)
+	}
+
+	return model.Balances{
+		{
+			Currency:  strings.ToUpper(data.Currency),
+			Available: data.Available,
+			Locked:    types.ExDecimal{Decimal: data.PositionMargin.Add(data.OrderMargin.Decimal)},
+			Total:     types.ExDecimal{Decimal: data.Total.Add(data.UnrealisedPnl.Decimal)},
+			UpdatedAt: types.ExTimestamp{Time: time.Now()}, // Gate 合约账户接口没有返回更新时间
+		},
+	}, nil
+}
+
 func (o *gateSpotOrder) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
 	// 解析选项
 	options := &option.ExchangeArgsOptions{}
@@ -454,38 +837,52 @@ func (o *gateSpotOrder) CreateOrder(ctx context.Context, symbol string, side opt
 		}
 	}
 
-	amountFloat, err := strconv.ParseFloat(amount, 64)
+	amountDecimal, err := decimal.NewFromString(amount)
 	if err != nil {
 		return nil, fmt.Errorf("invalid amount: %w", err)
 	}
 
-	var priceFloat float64
+	var priceDecimal decimal.Decimal
 	if priceStr != "" {
-		priceFloat, err = strconv.ParseFloat(priceStr, 64)
+		priceDecimal, err = decimal.NewFromString(priceStr)
 		if err != nil {
 			return nil, fmt.Errorf("invalid price: %w", err)
 		}
 	}
 
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(options)
+	if err != nil {
+		return nil, err
+	}
+
 	reqBody := map[string]interface{}{
 		"currency_pair": gateSymbol,
 		"side":          strings.ToLower(string(side)),
 	}
 
+	if err := common.CheckTimeInForce(options, orderType == model.OrderTypeLimit, true); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(options, orderType == model.OrderTypeLimit)
+	if err != nil {
+		return nil, err
+	}
+	reqBody["time_in_force"] = gateTimeInForce(orderType == model.OrderTypeLimit, postOnly, options.TimeInForce)
+
 	if orderType == model.OrderTypeLimit {
 		reqBody["type"] = "limit"
-		reqBody["price"] = strconv.FormatFloat(priceFloat, 'f', -1, 64)
-		reqBody["amount"] = strconv.FormatFloat(amountFloat, 'f', -1, 64)
-
-		// TimeInForce 设置
-		if options.TimeInForce != nil {
-			reqBody["time_in_force"] = strings.ToLower(string(*options.TimeInForce))
-		} else {
-			reqBody["time_in_force"] = "gtc"
+		price, err := common.PriceToPrecision(market, priceDecimal.String())
+		if err != nil {
+			return nil, err
 		}
+		amount, err := common.AmountToPrecision(market, amountDecimal.String())
+		if err != nil {
+			return nil, err
+		}
+		reqBody["price"] = price
+		reqBody["amount"] = amount
 	} else {
 		reqBody["type"] = "market"
-		reqBody["time_in_force"] = "ioc" // 市价单固定 ioc
 
 		// 现货市价买单: 需要通过Ticker换算成USDT数量
 		if side == option.Buy {
@@ -494,24 +891,36 @@ func (o *gateSpotOrder) CreateOrder(ctx context.Context, symbol string, side opt
 				return nil, fmt.Errorf("fetch ticker for market buy: %w", err)
 			}
 
-			lastPrice, _ := strconv.ParseFloat(ticker.Last.String(), 64)
-			if lastPrice == 0 {
+			lastPrice := ticker.Last.Decimal
+			if isStop {
+				// 条件单按触发价估算成交额
+				lastPrice = stopPrice
+			}
+			if lastPrice.IsZero() {
 				return nil, fmt.Errorf("invalid ticker price")
 			}
 
-			cost := amountFloat * lastPrice
-			reqBody["amount"] = strconv.FormatFloat(cost, 'f', -1, 64)
+			cost := amountDecimal.Mul(lastPrice)
+			reqBody["amount"] = cost.String()
 		} else {
 			// 现货市价卖单: 直接使用 amount
-			reqBody["amount"] = strconv.FormatFloat(amountFloat, 'f', -1, 64)
+			amount, err := common.AmountToPrecision(market, amountDecimal.String())
+			if err != nil {
+				return nil, err
+			}
+			reqBody["amount"] = amount
 		}
 	}
 
 	// 客户端订单ID
+	clientOrderID := common.GenerateClientOrderID(o.gate.Name(), side.ToSide())
 	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
-		reqBody["text"] = *options.ClientOrderID
-	} else {
-		reqBody["text"] = common.GenerateClientOrderID(o.gate.Name(), side.ToSide())
+		clientOrderID = *options.ClientOrderID
+	}
+	reqBody["text"] = gateClientOrderText(clientOrderID)
+
+	if isStop {
+		return o.createPriceOrder(ctx, symbol, reqBody, stopPrice, triggerType)
 	}
 
 	resp, err := o.signAndRequest(ctx, "POST", "/api/v4/spot/orders", nil, reqBody)
@@ -526,14 +935,68 @@ func (o *gateSpotOrder) CreateOrder(ctx context.Context, symbol string, side opt
 
 	order := &model.NewOrder{
 		OrderId:       result.ID,
-		ClientOrderID: result.Text,
+		ClientOrderID: gateClientOrderID(result.Text),
 		Symbol:        symbol,
 		Timestamp:     result.CreateTimeMs,
 	}
 
+	if strict, ok := option.GetBool(options.StrictClientID); ok && strict {
+		if err := common.CheckClientOrderID(gateClientOrderID(clientOrderID), order.ClientOrderID); err != nil {
+			return order, err
+		}
+	}
+
 	return order, nil
 }
 
+// createPriceOrder 创建价格触发订单，触发后按 order 中的类型、数量和价格下单
+// 价格触发订单不支持自定义订单ID，返回的 OrderId 为价格触发订单ID
+func (o *gateSpotOrder) createPriceOrder(ctx context.Context, symbol string, order map[string]interface{}, stopPrice decimal.Decimal, triggerType option.TriggerType) (*model.NewOrder, error) {
+	var req gateSpotPriceOrderRequest
+	req.Market, _ = order["currency_pair"].(string)
+	req.Trigger.Price = stopPrice.String()
+	req.Trigger.Rule = "<="
+	if triggerType.TriggersOnRise(fmt.Sprint(order["side"])) {
+		req.Trigger.Rule = ">="
+	}
+	req.Trigger.Expiration = gatePriceOrderExpiration
+	req.Put.Type, _ = order["type"].(string)
+	req.Put.Side, _ = order["side"].(string)
+	req.Put.Amount, _ = order["amount"].(string)
+	req.Put.Account = "normal"
+	req.Put.TimeInForce, _ = order["time_in_force"].(string)
+	req.Put.Price = stopPrice.String()
+	if price, ok := order["price"].(string); ok {
+		req.Put.Price = price
+	}
+
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w", err)
+	}
+	var reqBody map[string]interface{}
+	if err := json.Unmarshal(reqBytes, &reqBody); err != nil {
+		return nil, fmt.Errorf("unmarshal request: %w", err)
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v4/spot/price_orders", nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("create price order: %w", err)
+	}
+
+	var result gatePriceOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal price order: %w", err)
+	}
+
+	return &model.NewOrder{
+		OrderId:      strconv.FormatInt(result.ID, 10),
+		Symbol:       symbol,
+		Timestamp:    types.ExTimestamp{Time: time.Now()},
+		TriggerPrice: types.ExDecimal{Decimal: stopPrice},
+	}, nil
+}
+
 func (o *gateSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
 	// 获取市场信息
 	market, err := o.gate.spot.market.GetMarket(symbol)
@@ -560,14 +1023,80 @@ func (o *gateSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId
 		"currency_pair": gateSymbol,
 	}
 
+	// 路径中的 order_id 支持订单ID或 text（客户端订单ID）
 	if orderId == "" && argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		orderId = *argsOpts.ClientOrderID
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
 	}
 
 	_, err = o.signAndRequest(ctx, "DELETE", "/api/v4/spot/orders/"+orderId, reqBody, nil)
 	return err
 }
 
+// EditOrder 修改挂单（PATCH /spot/orders/{order_id}），返回修改后的订单
+func (o *gateSpotOrder) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	// 获取市场信息
+	market, err := o.gate.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 获取交易所格式的 symbol ID
+	gateSymbol := market.ID
+	if gateSymbol == "" {
+		var err error
+		gateSymbol, err = ToGateSymbol(symbol, false)
+		if err != nil {
+			return nil, fmt.Errorf("get market ID: %w", err)
+		}
+	}
+
+	params := map[string]interface{}{
+		"currency_pair": gateSymbol,
+	}
+
+	// 路径中的 order_id 支持订单ID或 text（客户端订单ID）
+	if orderId == "" && argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
+	}
+	if orderId == "" {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := map[string]interface{}{}
+	if newAmount != "" {
+		reqBody["amount"] = newAmount
+	}
+	if newPrice != "" {
+		reqBody["price"] = newPrice
+	}
+
+	resp, err := o.signAndRequest(ctx, "PATCH", "/api/v4/spot/orders/"+orderId, params, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var data gateSpotFetchOrderResponse
+	if err := json.Unmarshal(resp, &data); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	return o.parseOrder(data, symbol), nil
+}
+
 // parseOrder 解析订单数据
 func (o *gateSpotOrder) parseOrder(data gateSpotFetchOrderResponse, symbol string) *model.SpotOrder {
 	// 计算剩余数量
@@ -604,7 +1133,7 @@ func (o *gateSpotOrder) parseOrder(data gateSpotFetchOrderResponse, symbol strin
 
 	order := &model.SpotOrder{
 		ID:            data.ID,
-		ClientOrderID: data.Text,
+		ClientOrderID: gateClientOrderID(data.Text),
 		Symbol:        symbol,
 		Type:          orderType,
 		Side:          side,
@@ -624,6 +1153,10 @@ func (o *gateSpotOrder) parseOrder(data gateSpotFetchOrderResponse, symbol strin
 }
 
 func (o *gateSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
 	// 获取市场信息
 	market, err := o.gate.spot.market.GetMarket(symbol)
 	if err != nil {
@@ -649,8 +1182,9 @@ func (o *gateSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId s
 		"currency_pair": gateSymbol,
 	}
 
+	// 路径中的 order_id 支持订单ID或 text（客户端订单ID）
 	if orderId == "" && argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		orderId = *argsOpts.ClientOrderID
+		orderId = gateClientOrderText(*argsOpts.ClientOrderID)
 	}
 
 	resp, err := o.signAndRequest(ctx, "GET", "/api/v4/spot/orders/"+orderId, params, nil)
@@ -665,3 +1199,189 @@ func (o *gateSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId s
 
 	return o.parseOrder(data, symbol), nil
 }
+
+// FetchCurrencies 获取全部币种的充提状态及支持的网络
+// currency_chains 每次只能查询一个币种，因此链信息来自 /api/v4/spot/currencies，提币手续费来自 /api/v4/wallet/withdraw_status
+func (o *gateSpotOrder) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	if o.gate.credentials().secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	resp, err := o.gate.client.HTTPClient.Get(ctx, "/api/v4/spot/currencies", nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch currencies: %w", err)
+	}
+	var items []gateSpotCurrency
+	if err := json.Unmarshal(resp, &items); err != nil {
+		return nil, fmt.Errorf("unmarshal currencies: %w", err)
+	}
+
+	resp, err = o.signAndRequest(ctx, "GET", "/api/v4/wallet/withdraw_status", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch withdraw status: %w", err)
+	}
+	var statuses []gateSpotWithdrawStatus
+	if err := json.Unmarshal(resp, &statuses); err != nil {
+		return nil, fmt.Errorf("unmarshal withdraw status: %w", err)
+	}
+	fees := make(map[string]*gateSpotWithdrawStatus, len(statuses))
+	for i := range statuses {
+		fees[statuses[i].Currency] = &statuses[i]
+	}
+
+	currencies := make(map[string]*model.Currency, len(items))
+	for _, item := range items {
+		status := fees[item.Currency]
+		networks := make([]model.CurrencyNetwork, 0, len(item.Chains))
+		for _, chain := range item.Chains {
+			network := model.CurrencyNetwork{
+				Network:         chain.Name,
+				Name:            chain.Name,
+				Default:         chain.Name == item.Chain,
+				DepositEnabled:  !chain.DepositDisabled,
+				WithdrawEnabled: !chain.WithdrawDisabled,
+			}
+			if status != nil {
+				network.WithdrawFee = status.WithdrawFixOnChains[chain.Name]
+				network.WithdrawMin = status.WithdrawAmountMini
+			}
+			networks = append(networks, network)
+		}
+		currencies[item.Currency] = common.NewCurrency(item.Currency, item.Name, networks)
+	}
+	return currencies, nil
+}
+
+// FetchDepositAddress 获取充值地址（/api/v4/wallet/deposit_address），network 为空时使用第一条可用的链
+func (o *gateSpotOrder) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	currency = strings.ToUpper(currency)
+
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v4/wallet/deposit_address", map[string]interface{}{
+		"currency": currency,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch deposit address: %w", err)
+	}
+
+	var result gateSpotDepositAddressResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal deposit address: %w", err)
+	}
+
+	for _, item := range result.MultichainAddresses {
+		if item.ObtainFailed != 0 {
+			continue
+		}
+		if network != "" && !strings.EqualFold(item.Chain, network) {
+			continue
+		}
+		return &model.DepositAddress{
+			Currency: result.Currency,
+			Address:  item.Address,
+			Tag:      item.PaymentID,
+			Network:  item.Chain,
+		}, nil
+	}
+	return nil, fmt.Errorf("deposit address not found: %s %s", currency, network)
+}
+
+// Withdraw 提币（/api/v4/withdrawals），params 中的 tag 转换为 memo
+func (o *gateSpotOrder) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	currency = strings.ToUpper(currency)
+
+	if _, err := decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	tag, body := common.SplitWithdrawParams(params)
+	if tag != "" {
+		body["memo"] = tag
+	}
+	body["currency"] = currency
+	body["address"] = address
+	body["amount"] = amount
+	if network != "" {
+		body["chain"] = strings.ToUpper(network)
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v4/withdrawals", nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("withdraw: %w", err)
+	}
+
+	var result gateSpotWithdrawResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal withdraw: %w", err)
+	}
+
+	return &model.Transaction{
+		ID:        result.ID,
+		TxID:      result.TxID,
+		Currency:  result.Currency,
+		Amount:    result.Amount,
+		Address:   result.Address,
+		Tag:       result.Memo,
+		Network:   result.Chain,
+		Status:    toGateTransactionStatus(result.Status),
+		Timestamp: result.Timestamp,
+	}, nil
+}
+
+// toGateTransactionStatus 将 Gate 充提币状态转换为统一状态
+func toGateTransactionStatus(status string) string {
+	switch status {
+	case "DONE":
+		return model.TransactionStatusOK
+	case "CANCEL":
+		return model.TransactionStatusCanceled
+	case "FAIL", "INVALID":
+		return model.TransactionStatusFailed
+	default:
+		return model.TransactionStatusPending
+	}
+}
+
+// gateAccountNames 划转的账户名称（杠杆账户需指定交易对，Gate 没有独立的资金账户，均不支持）
+var gateAccountNames = map[option.AccountType]string{
+	option.AccountSpot:    "spot",
+	option.AccountFutures: "futures",
+}
+
+// Transfer 现货与永续合约账户之间划转（/api/v4/wallet/transfers），合约账户按划转币种结算（如 USDT 划入 usdt 结算账户）
+func (o *gateSpotOrder) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	from, okFrom := gateAccountNames[fromAccount]
+	to, okTo := gateAccountNames[toAccount]
+	if !okFrom || !okTo || from == to {
+		return nil, common.UnsupportedTransferError(gateName, fromAccount, toAccount)
+	}
+
+	currency = strings.ToUpper(currency)
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v4/wallet/transfers", nil, map[string]interface{}{
+		"currency": currency,
+		"from":     from,
+		"to":       to,
+		"amount":   amount,
+		"settle":   strings.ToLower(currency),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	var result gateSpotTransferResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer: %w", err)
+	}
+
+	return &model.Transaction{
+		ID:        strconv.FormatInt(result.TxID, 10),
+		Currency:  currency,
+		Amount:    types.ExDecimal{Decimal: amountDecimal},
+		Status:    model.TransactionStatusOK,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
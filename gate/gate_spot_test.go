@@ -2,13 +2,23 @@ package gate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -243,3 +253,405 @@ func TestGateSpot_FetchTickers(t *testing.T) {
 		}
 	}
 }
+
+// TestGateSpot_FetchTicker_Decimal Gate 小额币种的价格以科学计数法字符串返回（如 1.234e-7），解析为精确的 decimal
+func TestGateSpot_FetchTicker_Decimal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/spot/tickers" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"currency_pair":"BTC_USDT","last":"1.234e-7","lowest_ask":"1.235E-7","highest_bid":"1.233e-7","base_volume":"98765432109876.54321","quote_volume":"12187654.3","high_24h":"1.3e-7","low_24h":"0.00000012"}]`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	e := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+
+	exchangetest.CheckTickerDecimals(t, ticker, map[string]string{
+		"bid": "0.0000001233", "ask": "0.0000001235", "last": "0.0000001234", "high": "0.00000013", "low": "0.00000012",
+		"volume": "98765432109876.54321", "quote_volume": "12187654.3",
+	})
+}
+
+func TestGateSpot_Wallet(t *testing.T) {
+	var withdrawBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("KEY") != "key" || r.Header.Get("SIGN") == "" {
+			t.Errorf("missing auth headers for %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/api/v4/wallet/deposit_address":
+			w.Write([]byte(`{"currency":"USDT","address":"TXyz","multichain_addresses":[
+				{"chain":"ETH","address":"0xabc","payment_id":"","payment_name":"","obtain_failed":1},
+				{"chain":"TRX","address":"TXyz","payment_id":"","payment_name":"","obtain_failed":0}]}`))
+		case "/api/v4/withdrawals":
+			if err := json.NewDecoder(r.Body).Decode(&withdrawBody); err != nil {
+				t.Errorf("decode withdraw body: %v", err)
+			}
+			w.Write([]byte(`{"id":"210496","timestamp":"1542000000","withdraw_order_id":"","currency":"USDT",
+				"address":"TXyz","txid":"","amount":"222.61","memo":"","status":"REQUEST","chain":"TRX"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	ctx := context.Background()
+
+	// 获取失败的链被跳过
+	addr, err := ex.Spot().FetchDepositAddress(ctx, "usdt", "")
+	if err != nil {
+		t.Fatalf("FetchDepositAddress: %v", err)
+	}
+	if addr.Address != "TXyz" || addr.Network != "TRX" {
+		t.Errorf("unexpected deposit address: %+v", addr)
+	}
+	if _, err := ex.Spot().FetchDepositAddress(ctx, "USDT", "ETH"); err == nil {
+		t.Error("expected error for failed ETH address")
+	}
+
+	tx, err := ex.Spot().Withdraw(ctx, "usdt", "222.61", "TXyz", "trx", nil)
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if withdrawBody["currency"] != "USDT" || withdrawBody["chain"] != "TRX" || withdrawBody["amount"] != "222.61" {
+		t.Errorf("unexpected withdraw body: %v", withdrawBody)
+	}
+	if tx.ID != "210496" || tx.Status != model.TransactionStatusPending || tx.Timestamp.Unix() != 1542000000 {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+}
+
+func TestGateSpot_Transfer(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/wallet/transfers" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode transfer body: %v", err)
+		}
+		w.Write([]byte(`{"tx_id":59636381286}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	ctx := context.Background()
+
+	tx, err := ex.Spot().Transfer(ctx, "usdt", "100", option.AccountSpot, option.AccountFutures)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if body["from"] != "spot" || body["to"] != "futures" || body["settle"] != "usdt" || body["currency"] != "USDT" {
+		t.Errorf("unexpected transfer body: %v", body)
+	}
+	if tx.ID != "59636381286" || tx.Status != model.TransactionStatusOK {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	for _, to := range []option.AccountType{option.AccountMargin, option.AccountFunding} {
+		if _, err := ex.Spot().Transfer(ctx, "USDT", "100", option.AccountSpot, to); !errors.Is(err, common.ErrNotSupported) {
+			t.Errorf("spot -> %s err = %v, want ErrNotSupported", to, err)
+		}
+	}
+}
+
+func TestGateSpot_FetchBalance_Futures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/futures/usdt/accounts" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"user":1666,"currency":"USDT","total":"9707.803567115145","unrealised_pnl":"3371.248828","position_margin":"38.712189181","order_margin":"0","available":"9669.091377934145","point":"0","bonus":"0","in_dual_mode":false}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	ctx := context.Background()
+
+	balances, err := ex.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFutures))
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if len(balances) != 1 {
+		t.Fatalf("len(balances) = %d, want 1", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "USDT" || b.Total.String() != "13079.052395115145" || b.Available.String() != "9669.091377934145" || b.Locked.String() != "38.712189181" {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+
+	if _, err := ex.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFunding)); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestGateSpot_CreateOrder_DecimalPrecision(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/spot/orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"id":"1","text":"t-my-order","create_time_ms":"1700000000000","currency_pair":"SHIB_USDT"}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "SHIB_USDT", Symbol: "SHIB/USDT", Base: "SHIB", Quote: "USDT"}
+	g.spotMarketsBySymbol[market.Symbol] = market
+	g.spotMarketsByID[market.ID] = market
+
+	_, err = ex.Spot().CreateOrder(context.Background(), "SHIB/USDT", option.Buy, "1000000000.00000001",
+		option.WithPrice("0.00001234"), option.WithClientOrderID("t-my-order"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if body["amount"] != "1000000000.00000001" {
+		t.Errorf("amount = %v, want 1000000000.00000001", body["amount"])
+	}
+	if body["price"] != "0.00001234" {
+		t.Errorf("price = %v, want 0.00001234", body["price"])
+	}
+}
+
+func TestGateSpot_CreateOrders_Sequential(t *testing.T) {
+	var amounts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/spot/orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		amount, _ := body["amount"].(string)
+		amounts = append(amounts, amount)
+		if amount == "100" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"label":"BALANCE_NOT_ENOUGH","message":"Not enough balance"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"` + strconv.Itoa(len(amounts)) + `","text":"` + body["text"].(string) + `","create_time_ms":"1700000000000","currency_pair":"BTC_USDT"}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	g.spotMarketsBySymbol[market.Symbol] = market
+	g.spotMarketsByID[market.ID] = market
+
+	orders, errs, err := ex.Spot().CreateOrders(context.Background(), []option.SpotOrderRequest{
+		{Symbol: "BTC/USDT", Side: option.Buy, Amount: "0.01", Opts: []option.ArgsOption{option.WithPrice("30000"), option.WithClientOrderID("t-1")}},
+		{Symbol: "BTC/USDT", Side: option.Buy, Amount: "100", Opts: []option.ArgsOption{option.WithPrice("30000")}},
+		{Symbol: "BTC/USDT", Side: option.Sell, Amount: "0.02", Opts: []option.ArgsOption{option.WithPrice("31000"), option.WithClientOrderID("t-3")}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if strings.Join(amounts, ",") != "0.01,100,0.02" {
+		t.Errorf("submitted amounts = %v, want every order in order", amounts)
+	}
+	if errs[0] != nil || orders[0] == nil || orders[0].OrderId != "1" {
+		t.Errorf("order 0 = %+v, %v", orders[0], errs[0])
+	}
+	if !errors.Is(errs[1], common.ErrInsufficientFunds) || orders[1] != nil {
+		t.Errorf("order 1 = %+v, %v, want ErrInsufficientFunds", orders[1], errs[1])
+	}
+	if errs[2] != nil || orders[2] == nil || orders[2].OrderId != "3" {
+		t.Errorf("order 2 = %+v, %v", orders[2], errs[2])
+	}
+}
+
+func TestGateSpot_FetchCurrencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v4/spot/currencies":
+			w.Write([]byte(`[
+				{"currency":"USDT","name":"Tether","delisted":false,"withdraw_disabled":false,"withdraw_delayed":false,"deposit_disabled":false,"trade_disabled":false,"chain":"ETH","chains":[
+					{"name":"ETH","addr":"0xdac17f958d2ee523a2206206994597c13d831ec7","withdraw_disabled":false,"withdraw_delayed":false,"deposit_disabled":false},
+					{"name":"TRX","addr":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t","withdraw_disabled":false,"withdraw_delayed":false,"deposit_disabled":false},
+					{"name":"BSC","addr":"0x55d398326f99059ff775485246999027b3197955","withdraw_disabled":true,"withdraw_delayed":false,"deposit_disabled":false}]},
+				{"currency":"GT","name":"GateToken","chain":"GTEVM","chains":[{"name":"GTEVM","withdraw_disabled":false,"deposit_disabled":false}]}
+			]`))
+		case "/api/v4/wallet/withdraw_status":
+			if r.Header.Get("KEY") != "key" || r.Header.Get("SIGN") == "" {
+				t.Errorf("missing auth headers for %s", r.URL.Path)
+			}
+			w.Write([]byte(`[{"currency":"USDT","name":"Tether","name_cn":"泰达币","deposit":"0","withdraw_percent":"0%","withdraw_fix":"5","withdraw_day_limit":"2000000","withdraw_amount_mini":"1.5","withdraw_eachtime_limit":"2000000","withdraw_fix_on_chains":{"BSC":"0.8","ETH":"5","TRX":"1"}}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+
+	currencies, err := ex.Spot().FetchCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrencies: %v", err)
+	}
+	usdt := currencies["USDT"]
+	if usdt == nil || usdt.Name != "Tether" || len(usdt.Networks) != 3 {
+		t.Fatalf("unexpected USDT: %+v", usdt)
+	}
+	if !usdt.DepositEnabled || !usdt.WithdrawEnabled || usdt.WithdrawFee.String() != "5" || !usdt.Networks[0].Default {
+		t.Errorf("USDT deposit=%v withdraw=%v fee=%s", usdt.DepositEnabled, usdt.WithdrawEnabled, usdt.WithdrawFee)
+	}
+	trx, bsc := usdt.Networks[1], usdt.Networks[2]
+	if trx.Network != "TRX" || trx.WithdrawFee.String() != "1" || trx.WithdrawMin.String() != "1.5" {
+		t.Errorf("unexpected TRX network: %+v", trx)
+	}
+	if bsc.Network != "BSC" || bsc.WithdrawEnabled || !bsc.DepositEnabled || bsc.WithdrawFee.String() != "0.8" {
+		t.Errorf("unexpected BSC network: %+v", bsc)
+	}
+	// 没有提币费率的币种手续费为 0
+	if gt := currencies["GT"]; gt == nil || !gt.WithdrawFee.IsZero() || len(gt.Networks) != 1 {
+		t.Errorf("unexpected GT: %+v", gt)
+	}
+}
+
+func TestGate_FetchStatus(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/spot/time" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`<html><body>System maintenance</body></html>`))
+			return
+		}
+		w.Write([]byte(`{"server_time":1700000000123}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	ctx := context.Background()
+
+	status, err := ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusOK || status.Err != nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// 没有状态接口，服务器时间接口不可用时视为维护中
+	down.Store(true)
+	status, err = ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusMaintenance || status.Err == nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	// ctx 已取消时返回错误，不视为维护
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := ex.FetchStatus(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestGateSpot_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v4/spot/orders/123" || r.URL.Query().Get("currency_pair") != "BTC_USDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		edits = append(edits, body)
+		w.Write([]byte(`{"id":"123","currency_pair":"BTC_USDT","type":"limit","side":"sell","amount":"1.234","price":"30000.5",
+			"status":"open","left":"1.234","filled_amount":"0","create_time_ms":"1700000000000","update_time_ms":"1700000001000"}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewGate("key", "secret", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewGate: %v", err)
+	}
+	g := ex.(*Gate)
+	market := &model.Market{ID: "BTC_USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.1")}
+	g.spotMarketsBySymbol[market.Symbol] = market
+	g.spotMarketsByID[market.ID] = market
+	ctx := context.Background()
+
+	// 数量和价格按市场精度对齐
+	order, err := ex.Spot().EditOrder(ctx, "BTC/USDT", "123", "1.23456", "30000.55")
+	if err != nil {
+		t.Fatalf("EditOrder: %v", err)
+	}
+	if order.ID != "123" || order.Side != "sell" {
+		t.Errorf("unexpected order: %+v", order)
+	}
+	if edits[0]["amount"] != "1.234" || edits[0]["price"] != "30000.5" {
+		t.Errorf("edit body = %v, want amount 1.234 price 30000.5", edits[0])
+	}
+
+	// 只改价格时不发送数量
+	if _, err := ex.Spot().EditOrder(ctx, "BTC/USDT", "123", "", "30100"); err != nil {
+		t.Fatalf("EditOrder price: %v", err)
+	}
+	if edits[1]["price"] != "30100" || edits[1]["amount"] != nil {
+		t.Errorf("price edit body = %v, want price only", edits[1])
+	}
+}
@@ -1,4 +1,15 @@
 package gate
 
-// Gate 交易所的现货和合约模型结构差异较大，没有共享的模型
-// 此文件保留为空，以保持与其他交易所的结构一致性
+import "github.com/lemconn/exlink/types"
+
+// Gate 交易所的现货和合约模型结构差异较大，仅少数响应结构共用
+
+// gatePriceOrderResponse Gate 价格触发订单创建响应（现货和合约共用）
+type gatePriceOrderResponse struct {
+	ID int64 `json:"id"` // 价格触发订单ID
+}
+
+// gateTimeResponse Gate 服务器时间响应
+type gateTimeResponse struct {
+	ServerTime types.ExTimestamp `json:"server_time"` // 服务器时间（毫秒）
+}
@@ -1,21 +1,35 @@
 package gate
 
 import (
+	"strconv"
+
 	"github.com/lemconn/exlink/types"
 )
 
 // gatePerpMarketsResponse Gate 永续合约市场信息响应
 type gatePerpMarketsResponse []gatePerpContract
 
+// gatePerpContractStats Gate 合约统计
+type gatePerpContractStats struct {
+	Time            types.ExTimestamp `json:"time"`              // 统计时间（秒）
+	OpenInterest    types.ExDecimal   `json:"open_interest"`     // 持仓量（张）
+	OpenInterestUsd types.ExDecimal   `json:"open_interest_usd"` // 持仓价值（美元）
+	MarkPrice       types.ExDecimal   `json:"mark_price"`        // 标记价格
+}
+
 // gatePerpContract Gate 永续合约信息
 type gatePerpContract struct {
-	Name             string          `json:"name"`
-	Type             string          `json:"type"`
-	QuantoMultiplier string          `json:"quanto_multiplier"`
-	OrderPriceRound  types.ExDecimal `json:"order_price_round"`
-	OrderSizeMin     int             `json:"order_size_min"`
-	OrderSizeMax     int             `json:"order_size_max"`
-	InDelisting      bool            `json:"in_delisting"`
+	Name             string            `json:"name"`
+	Type             string            `json:"type"`
+	QuantoMultiplier string            `json:"quanto_multiplier"`
+	OrderPriceRound  types.ExDecimal   `json:"order_price_round"`
+	OrderSizeMin     int               `json:"order_size_min"`
+	OrderSizeMax     int               `json:"order_size_max"`
+	LeverageMin      types.ExDecimal   `json:"leverage_min"`
+	LeverageMax      types.ExDecimal   `json:"leverage_max"`
+	InDelisting      bool              `json:"in_delisting"`
+	FundingRate      types.ExDecimal   `json:"funding_rate"`
+	FundingNextApply types.ExTimestamp `json:"funding_next_apply"`
 }
 
 // gatePerpTickerResponse Gate 永续合约 Ticker 响应
@@ -45,6 +59,12 @@ type gatePerpTickerItem struct {
 	QuantoMultiplier      types.ExDecimal `json:"quanto_multiplier"`
 }
 
+// gatePerpFundingRate Gate 永续合约历史资金费率
+type gatePerpFundingRate struct {
+	Time types.ExTimestamp `json:"t"` // 结算时间（秒）
+	Rate types.ExDecimal   `json:"r"` // 资金费率
+}
+
 // gatePerpKlineResponse Gate 永续合约 Kline 响应（数组格式）
 type gatePerpKlineResponse []gatePerpKline
 
@@ -114,9 +134,21 @@ type gatePerpCreateOrderRequest struct {
 	Text       string `json:"text,omitempty"`        // 自定义 ID
 }
 
+// gatePerpPriceOrderRequest Gate 永续合约价格触发订单请求
+type gatePerpPriceOrderRequest struct {
+	Initial gatePerpCreateOrderRequest `json:"initial"` // 触发后下单参数（不支持自定义 ID）
+	Trigger struct {
+		StrategyType int    `json:"strategy_type"` // 触发策略，0 为价格触发
+		PriceType    int    `json:"price_type"`    // 参考价格类型，0 为最新成交价
+		Price        string `json:"price"`         // 触发价格
+		Rule         int    `json:"rule"`          // 触发条件：1 价格上涨到触发价，2 价格下跌到触发价
+		Expiration   int    `json:"expiration"`    // 等待触发时长（秒）
+	} `json:"trigger"`
+}
+
 // gatePerpCreateOrderResponse Gate 永续合约创建订单响应
 type gatePerpCreateOrderResponse struct {
-	ID         string            `json:"id"`          // 系统订单号
+	ID         int64             `json:"id"`          // 系统订单号（数字）
 	Text       string            `json:"text"`        // 客户端订单ID
 	UpdateTime types.ExTimestamp `json:"update_time"` // 更新时间（秒级时间戳，带小数）
 }
@@ -135,4 +167,44 @@ type gatePerpFetchOrderResponse struct {
 	IsReduceOnly bool              `json:"is_reduce_only"` // 是否只减仓
 	CreateTime   types.ExTimestamp `json:"create_time"`    // 创建时间（秒）
 	UpdateTime   types.ExTimestamp `json:"update_time"`    // 更新时间（秒）
+	FinishAs     string            `json:"finish_as"`      // 结束方式（filled/cancelled/liquidated/auto_deleveraged 等）
+	IsLiq        bool              `json:"is_liq"`         // 是否为强平委托
+}
+
+// gatePerpTrade Gate 永续合约逐笔成交
+type gatePerpTrade struct {
+	ID           int64           `json:"id"`             // 成交ID
+	CreateTimeMs types.ExDecimal `json:"create_time_ms"` // 成交时间（毫秒，带小数）
+	Contract     string          `json:"contract"`       // 合约标的
+	Size         types.ExDecimal `json:"size"`           // 成交数量（张，负数为主动卖出）
+	Price        types.ExDecimal `json:"price"`          // 成交价格
+}
+
+// gatePerpTradesResponse Gate 永续合约逐笔成交响应
+type gatePerpTradesResponse []gatePerpTrade
+
+// gatePerpOrderBookLevel Gate 永续合约深度档位
+type gatePerpOrderBookLevel struct {
+	Price types.ExDecimal `json:"p"` // 价格
+	Size  types.ExDecimal `json:"s"` // 数量（张）
+}
+
+// gatePerpOrderBookResponse Gate 永续合约深度响应
+type gatePerpOrderBookResponse struct {
+	ID      int64                    `json:"id"`      // 深度更新ID（with_id=true 时返回）
+	Current types.ExDecimal          `json:"current"` // 响应生成时间（秒，带小数）
+	Update  types.ExDecimal          `json:"update"`  // 深度最后更新时间（秒，带小数）
+	Asks    []gatePerpOrderBookLevel `json:"asks"`    // 卖盘
+	Bids    []gatePerpOrderBookLevel `json:"bids"`    // 买盘
+}
+
+// gatePerpAccountResponse Gate USDT 合约账户（只解析私有频道订阅和持仓模式需要的字段）
+type gatePerpAccountResponse struct {
+	User       int64 `json:"user"`         // 用户ID
+	InDualMode bool  `json:"in_dual_mode"` // 是否为双仓模式
+}
+
+// userID 返回字符串格式的用户ID
+func (a *gatePerpAccountResponse) userID() string {
+	return strconv.FormatInt(a.User, 10)
 }
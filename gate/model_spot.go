@@ -149,6 +149,24 @@ type gateSpotBalanceItem struct {
 	Locked    types.ExDecimal `json:"locked"`
 }
 
+// gateSpotPriceOrderRequest Gate 现货价格触发订单请求
+type gateSpotPriceOrderRequest struct {
+	Trigger struct {
+		Price      string `json:"price"`      // 触发价格
+		Rule       string `json:"rule"`       // 触发条件：>= 价格上涨到触发价，<= 价格下跌到触发价
+		Expiration int    `json:"expiration"` // 等待触发时长（秒）
+	} `json:"trigger"`
+	Put struct {
+		Type        string `json:"type"`          // 触发后下单类型 limit/market
+		Side        string `json:"side"`          // 买卖方向
+		Price       string `json:"price"`         // 委托价格（市价单为触发价）
+		Amount      string `json:"amount"`        // 数量（市价买单为计价币金额）
+		Account     string `json:"account"`       // 账户类型，现货为 normal
+		TimeInForce string `json:"time_in_force"` // 订单有效期
+	} `json:"put"`
+	Market string `json:"market"` // 交易对
+}
+
 // gateSpotCreateOrderResponse Gate 现货创建订单响应
 type gateSpotCreateOrderResponse struct {
 	ID           string            `json:"id"`
@@ -200,3 +218,92 @@ type gateSpotFetchOrderResponse struct {
 	RebatedFeeCcy  string            `json:"rebated_fee_currency"` // 返还手续费币种
 	FinishAs       string            `json:"finish_as"`        // 订单完成方式
 }
+
+// gateSpotTrade Gate 现货逐笔成交
+type gateSpotTrade struct {
+	ID           string          `json:"id"`             // 成交ID
+	CreateTimeMs types.ExDecimal `json:"create_time_ms"` // 成交时间（毫秒，带小数）
+	CurrencyPair string          `json:"currency_pair"`  // 交易对
+	Side         string          `json:"side"`           // 主动成交方向（buy/sell）
+	Amount       types.ExDecimal `json:"amount"`         // 成交数量
+	Price        types.ExDecimal `json:"price"`          // 成交价格
+}
+
+// gateSpotTradesResponse Gate 现货逐笔成交响应
+type gateSpotTradesResponse []gateSpotTrade
+
+// gateSpotOrderBookResponse Gate 现货深度响应
+type gateSpotOrderBookResponse struct {
+	ID      int64               `json:"id"`      // 深度更新ID（with_id=true 时返回）
+	Current types.ExTimestamp   `json:"current"` // 响应生成时间（毫秒）
+	Update  types.ExTimestamp   `json:"update"`  // 深度最后更新时间（毫秒）
+	Asks    [][]types.ExDecimal `json:"asks"`    // 卖盘 [price, amount]
+	Bids    [][]types.ExDecimal `json:"bids"`    // 买盘 [price, amount]
+}
+
+// gateSpotCurrency Gate 币种信息（/api/v4/spot/currencies）
+type gateSpotCurrency struct {
+	Currency string                  `json:"currency"` // 币种
+	Name     string                  `json:"name"`     // 币种名称
+	Chain    string                  `json:"chain"`    // 默认链
+	Chains   []gateSpotCurrencyChain `json:"chains"`   // 支持的链
+}
+
+// gateSpotCurrencyChain Gate 币种单条链信息
+type gateSpotCurrencyChain struct {
+	Name             string `json:"name"`              // 链名称，如 ETH、TRX
+	DepositDisabled  bool   `json:"deposit_disabled"`  // 是否暂停充值
+	WithdrawDisabled bool   `json:"withdraw_disabled"` // 是否暂停提币
+}
+
+// gateSpotWithdrawStatus Gate 币种提币费率（/api/v4/wallet/withdraw_status）
+type gateSpotWithdrawStatus struct {
+	Currency            string                     `json:"currency"`               // 币种
+	WithdrawAmountMini  types.ExDecimal            `json:"withdraw_amount_mini"`   // 最小提币数量
+	WithdrawFix         types.ExDecimal            `json:"withdraw_fix"`           // 默认链提币手续费
+	WithdrawFixOnChains map[string]types.ExDecimal `json:"withdraw_fix_on_chains"` // 各链提币手续费
+}
+
+// gateSpotDepositAddressResponse Gate 充值地址响应
+type gateSpotDepositAddressResponse struct {
+	Currency            string                      `json:"currency"`             // 币种
+	Address             string                      `json:"address"`              // 默认链的充值地址
+	MultichainAddresses []gateSpotMultichainAddress `json:"multichain_addresses"` // 各链的充值地址
+}
+
+// gateSpotMultichainAddress Gate 单条链的充值地址
+type gateSpotMultichainAddress struct {
+	Chain        string `json:"chain"`         // 链名称
+	Address      string `json:"address"`       // 充值地址
+	PaymentID    string `json:"payment_id"`    // 地址标签（memo/payment id）
+	PaymentName  string `json:"payment_name"`  // 标签名称
+	ObtainFailed int    `json:"obtain_failed"` // 地址是否获取失败（1 失败）
+}
+
+// gateSpotWithdrawResponse Gate 提币响应
+type gateSpotWithdrawResponse struct {
+	ID        string            `json:"id"`        // 提币记录ID
+	TxID      string            `json:"txid"`      // 链上交易哈希
+	Timestamp types.ExTimestamp `json:"timestamp"` // 提交时间（秒）
+	Amount    types.ExDecimal   `json:"amount"`    // 提币数量
+	Currency  string            `json:"currency"`  // 币种
+	Address   string            `json:"address"`   // 提币地址
+	Memo      string            `json:"memo"`      // 地址标签
+	Status    string            `json:"status"`    // 状态（DONE/CANCEL/REQUEST/MANUAL/BCODE/EXTPEND/FAIL/INVALID/VERIFY/PROCES/PEND/DMOVE/SPLITPEND）
+	Chain     string            `json:"chain"`     // 链名称
+}
+
+// gateSpotTransferResponse Gate 账户划转响应
+type gateSpotTransferResponse struct {
+	TxID int64 `json:"tx_id"` // 划转ID
+}
+
+// gateSpotFuturesAccountResponse Gate USDT 合约账户响应
+type gateSpotFuturesAccountResponse struct {
+	Total          types.ExDecimal `json:"total"`           // 钱包余额（不含未实现盈亏）
+	UnrealisedPnl  types.ExDecimal `json:"unrealised_pnl"`  // 未实现盈亏
+	PositionMargin types.ExDecimal `json:"position_margin"` // 仓位保证金
+	OrderMargin    types.ExDecimal `json:"order_margin"`    // 委托保证金
+	Available      types.ExDecimal `json:"available"`       // 可用余额
+	Currency       string          `json:"currency"`        // 结算币种
+}
@@ -42,6 +42,11 @@ func (s *Signer) SignRequest(method, path, queryString, body string, timestamp i
 	return common.SignHMAC512(payload, s.secretKey)
 }
 
+// SignWSRequest 私有频道订阅签名，签名内容为 channel=%s&event=%s&time=%d
+func (s *Signer) SignWSRequest(channel, event string, timestamp int64) string {
+	return common.SignHMAC512(fmt.Sprintf("channel=%s&event=%s&time=%d", channel, event, timestamp), s.secretKey)
+}
+
 // Sign 对消息进行签名（SHA512，兼容旧方法）
 func (s *Signer) Sign(message string) string {
 	return common.SignHMAC512(message, s.secretKey)
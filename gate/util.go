@@ -3,6 +3,11 @@ package gate
 import (
 	"fmt"
 	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
 )
 
 // ToGateSymbol 转换为Gate格式的symbol
@@ -20,6 +25,31 @@ func ToGateSymbol(symbol string, isContract bool) (string, error) {
 	return base + "_" + quote, nil
 }
 
+// symbolFromGateContract 将 Gate 合约名称（如 BTC_USDT）转换为标准化格式 BTC/USDT:USDT，用于市场信息中查不到的合约
+// 无法解析时原样返回
+func symbolFromGateContract(contract, settle string) string {
+	parts := strings.Split(contract, "_")
+	if len(parts) != 2 {
+		return contract
+	}
+	return common.NormalizeContractSymbol(parts[0], parts[1], settle)
+}
+
+// gateTimeInForce 返回下单的 time_in_force：只做 Maker 为 poc（pending or cancelled）
+// 限价单默认 gtc，市价单只支持 ioc、fok，默认 ioc
+func gateTimeInForce(isLimit, postOnly bool, timeInForce *option.TimeInForce) string {
+	switch {
+	case postOnly:
+		return "poc"
+	case timeInForce != nil && timeInForce.IsFOK():
+		return option.FOK.Lower()
+	case timeInForce != nil && timeInForce.IsIOC(), !isLimit:
+		return option.IOC.Lower()
+	default:
+		return option.GTC.Lower()
+	}
+}
+
 // getPrecisionDigits 计算精度位数
 func getPrecisionDigits(value float64) int {
 	if value == 0 {
@@ -44,3 +74,38 @@ func getString(m map[string]interface{}, key string) string {
 	}
 	return ""
 }
+
+// contractMultiplier 返回合约乘数（quanto_multiplier，每张合约对应的币数量），未知时按 1 处理
+func contractMultiplier(market *model.Market) decimal.Decimal {
+	multiplier, err := decimal.NewFromString(market.ContractValue)
+	if err != nil || !multiplier.IsPositive() {
+		return decimal.NewFromInt(1)
+	}
+	return multiplier
+}
+
+// toContractSize 将币数量转换为合约张数（common.AmountToContracts 向下取整到整张，避免超出预期仓位），并校验最小下单张数
+func toContractSize(market *model.Market, amount decimal.Decimal) (int64, error) {
+	contracts, err := common.AmountToContracts(market, amount.String())
+	if err != nil {
+		return 0, err
+	}
+	size, err := decimal.NewFromString(contracts)
+	if err != nil {
+		return 0, fmt.Errorf("invalid contract size %q: %w", contracts, err)
+	}
+	return size.Floor().IntPart(), nil
+}
+
+// gateClientOrderText 将客户端订单ID转换为 Gate 的 text 字段（必须以 "t-" 开头，已有前缀时不重复添加）
+func gateClientOrderText(clientOrderID string) string {
+	if strings.HasPrefix(clientOrderID, "t-") {
+		return clientOrderID
+	}
+	return "t-" + clientOrderID
+}
+
+// gateClientOrderID 从 Gate 的 text 字段取回客户端订单ID（去除 "t-" 前缀），下单和查询订单返回的ID保持一致
+func gateClientOrderID(text string) string {
+	return strings.TrimPrefix(text, "t-")
+}
@@ -0,0 +1,483 @@
+package gate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// gateWSProtocol Gate V4 公共频道订阅协议
+// 主题格式为 "频道:交易对"，如 spot.tickers:BTC_USDT；
+// 订阅消息形如 {"time":1700000000,"channel":"spot.tickers","event":"subscribe","payload":["BTC_USDT"]}，
+// 推送消息形如 {"channel":"spot.tickers","event":"update","result":{...}}；
+// 连接空闲时需向 pingChannel（spot.ping / futures.ping）发送心跳
+type gateWSProtocol struct {
+	pingChannel string
+}
+
+// gateWSRequest Gate WebSocket 订阅请求
+type gateWSRequest struct {
+	Time    int64    `json:"time"`
+	Channel string   `json:"channel"`
+	Event   string   `json:"event"`
+	Payload []string `json:"payload"`
+}
+
+// gateWSTopic 构建订阅主题
+func gateWSTopic(channel, pair string) string {
+	return channel + ":" + pair
+}
+
+// gateWSChannelParams 频道在交易对之后需要附带的订阅参数（推送间隔、档位数）
+var gateWSChannelParams = map[string][]string{
+	"spot.order_book_update":    {"100ms"},
+	"futures.order_book_update": {"100ms", "100"},
+}
+
+// gateWSRequestMessage 构建订阅/取消订阅消息，同一消息中的主题须属于同一频道
+func gateWSRequestMessage(event string, topics []string) ([]byte, error) {
+	req := gateWSRequest{Time: time.Now().Unix(), Event: event}
+	for _, topic := range topics {
+		channel, pair, _ := strings.Cut(topic, ":")
+		if req.Channel != "" && req.Channel != channel {
+			return nil, fmt.Errorf("topics must share one channel: %s, %s", req.Channel, channel)
+		}
+		req.Channel = channel
+		if strings.HasSuffix(channel, ".candlesticks") {
+			// K线主题为 "周期_交易对"，订阅参数为 [周期, 交易对]
+			interval, contract, _ := strings.Cut(pair, "_")
+			req.Payload = append(req.Payload, interval, contract)
+			continue
+		}
+		req.Payload = append(req.Payload, pair)
+	}
+	req.Payload = append(req.Payload, gateWSChannelParams[req.Channel]...)
+	return json.Marshal(req)
+}
+
+// SubscribeMessage 构建订阅消息
+func (gateWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return gateWSRequestMessage("subscribe", topics)
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (gateWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return gateWSRequestMessage("unsubscribe", topics)
+}
+
+// PingMessage 心跳消息，服务端以 pong 事件回复，Route 不分发
+func (p gateWSProtocol) PingMessage() []byte {
+	return gateWSPingMessage(p.pingChannel)
+}
+
+// gateWSPingMessage 构建心跳消息
+func gateWSPingMessage(channel string) []byte {
+	msg, _ := json.Marshal(gatePrivateWSRequest{Time: time.Now().Unix(), Channel: channel})
+	return msg
+}
+
+// gateWSPair 推送数据中标识交易对的字段（现货为 currency_pair，合约为 contract，深度为 s，K线为 n）
+type gateWSPair struct {
+	CurrencyPair string `json:"currency_pair"`
+	Contract     string `json:"contract"`
+	S            string `json:"s"`
+	N            string `json:"n"`
+}
+
+// name 返回交易对名称
+func (p gateWSPair) name() string {
+	switch {
+	case p.CurrencyPair != "":
+		return p.CurrencyPair
+	case p.Contract != "":
+		return p.Contract
+	case p.N != "":
+		return p.N
+	default:
+		return p.S
+	}
+}
+
+// Route 根据频道和 result 中的交易对解析主题，只分发 update 事件（合约推送的 result 为数组）
+func (gateWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Channel string          `json:"channel"`
+		Event   string          `json:"event"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Event != "update" {
+		return "", false
+	}
+
+	var pair gateWSPair
+	result := bytes.TrimSpace(m.Result)
+	if len(result) > 0 && result[0] == '[' {
+		var items []gateWSPair
+		if err := json.Unmarshal(result, &items); err != nil || len(items) == 0 {
+			return "", false
+		}
+		pair = items[0]
+	} else if err := json.Unmarshal(result, &pair); err != nil {
+		return "", false
+	}
+
+	if pair.name() == "" {
+		return "", false
+	}
+	return gateWSTopic(m.Channel, pair.name()), true
+}
+
+// gateWSMessage Gate WebSocket 推送消息
+type gateWSMessage[T any] struct {
+	TimeMs types.ExTimestamp `json:"time_ms"`
+	Result T                 `json:"result"`
+}
+
+// parseGateWSSpotTicker 返回解析现货行情推送的函数，symbol 为标准化格式
+func parseGateWSSpotTicker(symbol string) common.WSParser[*model.Ticker] {
+	return func(msg []byte) (*model.Ticker, bool) {
+		var m gateWSMessage[gateSpotTickerItem]
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return nil, false
+		}
+
+		item := m.Result
+		ticker := &model.Ticker{
+			Symbol:    symbol,
+			Timestamp: m.TimeMs,
+		}
+		ticker.Bid = item.HighestBid
+		ticker.Ask = item.LowestAsk
+		ticker.Last = item.Last
+		ticker.High = item.High24h
+		ticker.Low = item.Low24h
+		ticker.Volume = item.BaseVolume
+		ticker.QuoteVolume = item.QuoteVolume
+		return ticker, true
+	}
+}
+
+// parseGateWSPerpTicker 返回解析合约行情推送的函数，symbol 为标准化格式
+func parseGateWSPerpTicker(symbol string) common.WSParser[*model.Ticker] {
+	return func(msg []byte) (*model.Ticker, bool) {
+		var m gateWSMessage[[]gatePerpTickerItem]
+		if err := json.Unmarshal(msg, &m); err != nil || len(m.Result) == 0 {
+			return nil, false
+		}
+
+		item := m.Result[0]
+		ticker := &model.Ticker{
+			Symbol:    symbol,
+			Timestamp: m.TimeMs,
+		}
+		ticker.Bid = item.HighestBid
+		ticker.Ask = item.LowestAsk
+		ticker.Last = item.Last
+		ticker.High = item.High24h
+		ticker.Low = item.Low24h
+		ticker.Volume = item.Volume24hBase
+		ticker.QuoteVolume = item.Volume24hQuote
+		return ticker, true
+	}
+}
+
+// gateWSSpotOrderBook Gate 现货深度增量推送
+type gateWSSpotOrderBook struct {
+	Time        types.ExTimestamp   `json:"t"` // 推送时间（毫秒）
+	FirstUpdate int64               `json:"U"` // 本次推送的第一个更新ID
+	LastUpdate  int64               `json:"u"` // 本次推送的最后一个更新ID
+	Bids        [][]types.ExDecimal `json:"b"` // 买盘变化 [price, amount]
+	Asks        [][]types.ExDecimal `json:"a"` // 卖盘变化 [price, amount]
+}
+
+// gateWSPerpOrderBook Gate 永续合约深度增量推送
+type gateWSPerpOrderBook struct {
+	Time        types.ExTimestamp        `json:"t"` // 推送时间（毫秒）
+	FirstUpdate int64                    `json:"U"` // 本次推送的第一个更新ID
+	LastUpdate  int64                    `json:"u"` // 本次推送的最后一个更新ID
+	Bids        []gatePerpOrderBookLevel `json:"b"` // 买盘变化
+	Asks        []gatePerpOrderBookLevel `json:"a"` // 卖盘变化
+}
+
+// parseGateWSSpotOrderBook 解析现货深度增量推送
+func parseGateWSSpotOrderBook(msg []byte) (*common.OrderBookDelta, bool) {
+	var m gateWSMessage[gateWSSpotOrderBook]
+	if err := json.Unmarshal(msg, &m); err != nil || m.Result.LastUpdate == 0 {
+		return nil, false
+	}
+
+	return &common.OrderBookDelta{
+		FirstUpdateID: m.Result.FirstUpdate,
+		FinalUpdateID: m.Result.LastUpdate,
+		Bids:          common.ParseOrderBookLevels(m.Result.Bids, 0),
+		Asks:          common.ParseOrderBookLevels(m.Result.Asks, 0),
+		Timestamp:     m.Result.Time,
+	}, true
+}
+
+// parseGateWSPerpOrderBook 解析合约深度增量推送，数量单位为张，与 FetchOrderBook 一致
+func parseGateWSPerpOrderBook(msg []byte) (*common.OrderBookDelta, bool) {
+	var m gateWSMessage[gateWSPerpOrderBook]
+	if err := json.Unmarshal(msg, &m); err != nil || m.Result.LastUpdate == 0 {
+		return nil, false
+	}
+
+	toEntries := func(levels []gatePerpOrderBookLevel) []model.OrderBookEntry {
+		entries := make([]model.OrderBookEntry, 0, len(levels))
+		for _, level := range levels {
+			entries = append(entries, model.OrderBookEntry{Price: level.Price.Decimal, Amount: level.Size.Decimal})
+		}
+		return entries
+	}
+
+	return &common.OrderBookDelta{
+		FirstUpdateID: m.Result.FirstUpdate,
+		FinalUpdateID: m.Result.LastUpdate,
+		Bids:          toEntries(m.Result.Bids),
+		Asks:          toEntries(m.Result.Asks),
+		Timestamp:     m.Result.Time,
+	}, true
+}
+
+// gateWSCandlestick Gate K线推送数据（现货和合约共用）
+type gateWSCandlestick struct {
+	Time   types.ExTimestamp `json:"t"` // 开盘时间（秒）
+	Volume types.ExDecimal   `json:"v"` // 现货为计价货币成交额，合约为成交张数
+	Close  types.ExDecimal   `json:"c"` // 收盘价
+	High   types.ExDecimal   `json:"h"` // 最高价
+	Low    types.ExDecimal   `json:"l"` // 最低价
+	Open   types.ExDecimal   `json:"o"` // 开盘价
+	Name   string            `json:"n"` // 周期_交易对，如 1m_BTC_USDT
+	Amount types.ExDecimal   `json:"a"` // 基础货币成交量
+	Closed bool              `json:"w"` // 是否已收盘
+}
+
+// gateCandlestickName 返回K线推送的名称（也是订阅主题中的交易对部分），如 1m_BTC_USDT
+func gateCandlestickName(interval, pair string) string {
+	return interval + "_" + pair
+}
+
+// toOHLCV 转换为统一的K线结构，volume 为成交量字段（现货取基础货币成交量，合约取成交张数，与 FetchOHLCVs 一致）
+func (k *gateWSCandlestick) toOHLCV(volume types.ExDecimal) *model.OHLCV {
+	return &model.OHLCV{
+		Timestamp: k.Time,
+		Open:      k.Open,
+		High:      k.High,
+		Low:       k.Low,
+		Close:     k.Close,
+		Volume:    volume,
+		Closed:    k.Closed,
+	}
+}
+
+// parseGateWSSpotCandlestick 解析现货K线推送
+func parseGateWSSpotCandlestick(msg []byte) (*model.OHLCV, bool) {
+	var m gateWSMessage[gateWSCandlestick]
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return nil, false
+	}
+	return m.Result.toOHLCV(m.Result.Amount), true
+}
+
+// parseGateWSPerpCandlestick 解析合约K线推送，result 为K线数组，取最后一根（最新）K线
+func parseGateWSPerpCandlestick(msg []byte) (*model.OHLCV, bool) {
+	var m gateWSMessage[[]gateWSCandlestick]
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Result) == 0 {
+		return nil, false
+	}
+	k := m.Result[len(m.Result)-1]
+	return k.toOHLCV(k.Volume), true
+}
+
+const (
+	// gateWSSpotOrdersTopic 现货私有频道订单主题
+	gateWSSpotOrdersTopic = "spot.orders"
+	// gateWSSpotBalancesTopic 现货私有频道余额主题
+	gateWSSpotBalancesTopic = "spot.balances"
+	// gateWSPerpOrdersChannel 合约私有频道订单频道，主题为 "futures.orders:用户ID"
+	gateWSPerpOrdersChannel = "futures.orders"
+	// gateWSPerpBalancesChannel 合约私有频道余额频道，主题为 "futures.balances:用户ID"
+	gateWSPerpBalancesChannel = "futures.balances"
+)
+
+// gatePrivateWSProtocol Gate V4 私有频道订阅协议
+// 主题格式为 "频道[:用户ID]"，合约私有频道订阅参数需要用户ID；每条订阅消息附带 auth 签名，连接空闲时需发送 ping
+type gatePrivateWSProtocol struct {
+	gate        *Gate
+	pingChannel string
+}
+
+// gateWSAuth 私有频道订阅鉴权信息
+type gateWSAuth struct {
+	Method string `json:"method"`
+	Key    string `json:"KEY"`
+	Sign   string `json:"SIGN"`
+}
+
+// gatePrivateWSRequest Gate 私有频道订阅请求
+type gatePrivateWSRequest struct {
+	Time    int64       `json:"time"`
+	Channel string      `json:"channel"`
+	Event   string      `json:"event"`
+	Payload []string    `json:"payload,omitempty"`
+	Auth    *gateWSAuth `json:"auth,omitempty"`
+}
+
+// requestMessage 构建私有频道订阅/取消订阅消息，订单频道订阅全部交易对
+func (p gatePrivateWSProtocol) requestMessage(event string, topics []string) ([]byte, error) {
+	creds := p.gate.credentials()
+	if creds.secretKey == "" {
+		return nil, common.ErrAuthenticationRequired
+	}
+
+	channel, userID, _ := strings.Cut(topics[0], ":")
+	req := gatePrivateWSRequest{Time: p.gate.clock.TimestampSeconds(), Channel: channel, Event: event}
+	if userID != "" {
+		req.Payload = append(req.Payload, userID)
+	}
+	if strings.HasSuffix(channel, ".orders") {
+		req.Payload = append(req.Payload, "!all")
+	}
+	req.Auth = &gateWSAuth{Method: "api_key", Key: creds.apiKey, Sign: creds.signer.SignWSRequest(channel, event, req.Time)}
+	return json.Marshal(req)
+}
+
+// SubscribeMessage 构建订阅消息
+func (p gatePrivateWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return p.requestMessage("subscribe", topics)
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (p gatePrivateWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return p.requestMessage("unsubscribe", topics)
+}
+
+// Route 根据频道解析主题，合约频道附带 result 中的用户ID，只分发 update 事件
+func (gatePrivateWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Channel string `json:"channel"`
+		Event   string `json:"event"`
+		Result  []struct {
+			User json.RawMessage `json:"user"` // 现货推送为数字，合约推送为字符串
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Event != "update" || len(m.Result) == 0 {
+		return "", false
+	}
+	if !strings.HasPrefix(m.Channel, "futures.") {
+		return m.Channel, true
+	}
+	return m.Channel + ":" + strings.Trim(string(m.Result[0].User), `"`), true
+}
+
+// PingMessage 心跳消息
+func (p gatePrivateWSProtocol) PingMessage() []byte {
+	return gateWSPingMessage(p.pingChannel)
+}
+
+// gateWSSpotOrder 现货私有频道订单推送，event 为 put（创建）、update（成交）、finish（结束）
+type gateWSSpotOrder struct {
+	gateSpotFetchOrderResponse
+	Event        string          `json:"event"`          // 订单事件
+	AvgDealPrice types.ExDecimal `json:"avg_deal_price"` // 平均成交价格
+}
+
+// toSpotOrderResponse 补全推送中缺少的订单状态、成交数量和成交均价，转换为查询订单格式
+func (o *gateWSSpotOrder) toSpotOrderResponse() gateSpotFetchOrderResponse {
+	data := o.gateSpotFetchOrderResponse
+	if data.Status == "" {
+		switch {
+		case o.Event != "finish":
+			data.Status = "open"
+		case data.FinishAs == "filled":
+			data.Status = "finished"
+		default:
+			data.Status = "cancelled"
+		}
+	}
+	if data.FilledAmount.IsZero() {
+		data.FilledAmount = types.ExDecimal{Decimal: data.Amount.Sub(data.Left.Decimal)}
+	}
+	if data.FillPrice.IsZero() {
+		data.FillPrice = o.AvgDealPrice
+	}
+	return data
+}
+
+// parseGateWSOrders 返回解析私有频道订单推送的函数，一条推送可能包含多个订单
+func parseGateWSOrders[T any](convert func(raw json.RawMessage) (T, bool)) common.WSParser[[]T] {
+	return func(msg []byte) ([]T, bool) {
+		var m struct {
+			Result []json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return nil, false
+		}
+
+		var orders []T
+		for _, raw := range m.Result {
+			if order, ok := convert(raw); ok {
+				orders = append(orders, order)
+			}
+		}
+		return orders, len(orders) > 0
+	}
+}
+
+// parseGateWSSpotBalances 解析 spot.balances 推送，只包含发生变化的币种
+func parseGateWSSpotBalances(msg []byte) (model.Balances, bool) {
+	var m struct {
+		Result []struct {
+			Currency    string            `json:"currency"`     // 币种
+			Total       types.ExDecimal   `json:"total"`        // 总余额
+			Available   types.ExDecimal   `json:"available"`    // 可用余额
+			Freeze      types.ExDecimal   `json:"freeze"`       // 冻结余额
+			TimestampMs types.ExTimestamp `json:"timestamp_ms"` // 更新时间（毫秒）
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Result) == 0 {
+		return nil, false
+	}
+
+	balances := make(model.Balances, 0, len(m.Result))
+	for _, item := range m.Result {
+		balances = append(balances, &model.Balance{
+			Currency:  item.Currency,
+			Available: item.Available,
+			Locked:    item.Freeze,
+			Total:     item.Total,
+			UpdatedAt: item.TimestampMs,
+		})
+	}
+	return balances, true
+}
+
+// parseGateWSPerpBalances 解析 futures.balances 推送，推送只包含钱包余额（不含未实现盈亏），不区分可用和占用
+func parseGateWSPerpBalances(msg []byte) (model.Balances, bool) {
+	var m struct {
+		Result []struct {
+			Currency string            `json:"currency"` // 结算币种
+			Balance  types.ExDecimal   `json:"balance"`  // 变化后的钱包余额
+			TimeMs   types.ExTimestamp `json:"time_ms"`  // 更新时间（毫秒）
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Result) == 0 {
+		return nil, false
+	}
+
+	// 同一推送中同一币种可能有多次变化，取最后一次
+	item := m.Result[len(m.Result)-1]
+	return model.Balances{
+		{
+			Currency:  strings.ToUpper(item.Currency),
+			Total:     item.Balance,
+			UpdatedAt: item.TimeMs,
+		},
+	}, true
+}
@@ -2,4 +2,7 @@ module github.com/lemconn/exlink
 
 go 1.21
 
-require github.com/shopspring/decimal v1.4.0
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/shopspring/decimal v1.4.0
+)
@@ -0,0 +1,90 @@
+// Package exchangetest 交易所适配器测试共用的 httptest 脚手架
+package exchangetest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// Constructor 交易所构造函数，如 kucoin.NewKuCoin
+type Constructor func(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error)
+
+// Venue 测试交易所配置
+type Venue struct {
+	New         Constructor            // 交易所构造函数
+	SecretKey   string                 // 测试 secretKey，apiKey 固定为 "key"
+	Options     map[string]interface{} // 额外选项（如 password），URLOption 由 New 设置
+	URLOption   string                 // 指向测试服务的选项名，默认 baseURL
+	MarketsPath string                 // 市场列表接口路径
+	Markets     string                 // 市场列表接口的固定响应
+	Perp        bool                   // 加载永续合约市场（默认加载现货市场）
+}
+
+// New 启动测试服务并创建请求指向它的交易所实例：MarketsPath 返回 Markets，其他请求交给 handler
+// 返回前加载市场，测试结束时关闭服务
+func New[T exchange.Exchange](t *testing.T, v Venue, handler http.HandlerFunc) T {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == v.MarketsPath {
+			w.Write([]byte(v.Markets))
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	options := make(map[string]interface{}, len(v.Options)+1)
+	for key, value := range v.Options {
+		options[key] = value
+	}
+	urlOption := v.URLOption
+	if urlOption == "" {
+		urlOption = "baseURL"
+	}
+	options[urlOption] = srv.URL
+
+	ex, err := v.New("key", v.SecretKey, options)
+	if err != nil {
+		t.Fatalf("new exchange: %v", err)
+	}
+	if v.Perp {
+		err = ex.Perp().LoadMarkets(context.Background(), false)
+	} else {
+		err = ex.Spot().LoadMarkets(context.Background(), false)
+	}
+	if err != nil {
+		t.Fatalf("LoadMarkets: %v", err)
+	}
+	return ex.(T)
+}
+
+// CheckTickerDecimals 按 JSON 字段名（bid、ask、last、open、high、low、volume、quote_volume）比较 Ticker 的 decimal 字段
+// want 为 decimal 的规范字符串（不带多余的零），未列出的字段不比较
+func CheckTickerDecimals(t *testing.T, ticker *model.Ticker, want map[string]string) {
+	t.Helper()
+	fields := map[string]types.ExDecimal{
+		"bid":          ticker.Bid,
+		"ask":          ticker.Ask,
+		"last":         ticker.Last,
+		"open":         ticker.Open,
+		"high":         ticker.High,
+		"low":          ticker.Low,
+		"volume":       ticker.Volume,
+		"quote_volume": ticker.QuoteVolume,
+	}
+	for name, w := range want {
+		got, ok := fields[name]
+		if !ok {
+			t.Fatalf("unknown ticker field %q", name)
+		}
+		if got.String() != w {
+			t.Errorf("%s = %s, want %s", name, got.String(), w)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package kraken
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+)
+
+const (
+	krakenName    = "kraken"
+	krakenBaseURL = "https://api.kraken.com"
+
+	// Kraken 合约（Futures API）使用独立域名
+	krakenFuturesBaseURL        = "https://futures.kraken.com"
+	krakenFuturesSandboxBaseURL = "https://demo-futures.kraken.com"
+
+	// krakenMaxDepthLimit 深度单次最大档位数
+	krakenMaxDepthLimit = 500
+
+	// krakenOHLCVPageLimit 单次返回的最大K线数（Kraken 只返回最近 720 根）
+	krakenOHLCVPageLimit = 720
+)
+
+// krakenTimeframes K线支持的周期（分钟）
+var krakenTimeframes = []string{"1", "5", "15", "30", "60", "240", "1440", "10080", "21600"}
+
+// Client Kraken 客户端
+type Client struct {
+	// HTTPClient 现货 HTTP 客户端
+	HTTPClient *common.HTTPClient
+
+	// FuturesClient 合约 HTTP 客户端（futures.kraken.com）
+	FuturesClient *common.HTTPClient
+
+	// Sandbox 是否为模拟盘（只对合约生效，Kraken 现货没有模拟盘）
+	Sandbox bool
+
+	// ProxyURL 代理地址
+	ProxyURL string
+
+	// Debug 是否启用调试模式
+	Debug bool
+}
+
+// NewClient 创建 Kraken 客户端
+// API 凭证由 Kraken 持有（见 credentials），以支持运行时轮换；sandbox 选项只切换合约域名（demo-futures.kraken.com），Kraken 现货没有模拟盘
+func NewClient(options map[string]interface{}) (*Client, error) {
+	baseURL := krakenBaseURL
+	futuresBaseURL := krakenFuturesBaseURL
+	sandbox := false
+	proxyURL := ""
+	debug := false
+
+	if v, ok := options["baseURL"].(string); ok {
+		baseURL = v
+	}
+	if v, ok := options["sandbox"].(bool); ok {
+		sandbox = v
+	}
+	if sandbox {
+		futuresBaseURL = krakenFuturesSandboxBaseURL
+	}
+	// perpBaseURL 来自 option.WithMarketBaseURL
+	if v, ok := options["perpBaseURL"].(string); ok {
+		futuresBaseURL = v
+	}
+	if v, ok := options["proxy"].(string); ok {
+		proxyURL = v
+	}
+	if v, ok := options["debug"].(bool); ok {
+		debug = v
+	}
+
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
+	client := &Client{
+		HTTPClient:    common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
+		FuturesClient: common.NewHTTPClient(futuresBaseURL, common.WithRateLimiter(limiter)),
+		Sandbox:       sandbox,
+		ProxyURL:      proxyURL,
+		Debug:         debug,
+	}
+
+	// 解析交易所错误码（现货和合约的错误格式不同）
+	client.HTTPClient.SetErrorParser(parseKrakenError)
+	client.FuturesClient.SetErrorParser(parseKrakenFuturesError)
+
+	for _, httpClient := range []*common.HTTPClient{client.HTTPClient, client.FuturesClient} {
+		// 设置重试策略
+		if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+			httpClient.SetRetryPolicy(v)
+		}
+
+		// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+		if v, ok := options["httpClient"].(*http.Client); ok {
+			httpClient.SetHTTPClient(v)
+		}
+
+		// 设置代理
+		if proxyURL != "" {
+			if err := httpClient.SetProxy(proxyURL); err != nil {
+				return nil, err
+			}
+		}
+
+		// 设置调试模式
+		if debug {
+			httpClient.SetDebug(true)
+		}
+
+		// 设置请求超时（未设置时使用默认超时）
+		if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+			httpClient.SetTimeout(v)
+		}
+
+		// 设置关联ID请求头和请求/响应回调
+		if v, ok := options["correlationHeader"].(string); ok {
+			httpClient.SetCorrelationHeader(v)
+		}
+		if v, ok := options["logger"].(common.Logger); ok {
+			httpClient.SetLogger(v)
+		}
+		if v, ok := options["requestHook"].(common.RequestHook); ok {
+			httpClient.OnRequest(v)
+		}
+		if v, ok := options["responseHook"].(common.ResponseHook); ok {
+			httpClient.OnResponse(v)
+		}
+	}
+
+	return client, nil
+}
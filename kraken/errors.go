@@ -0,0 +1,76 @@
+package kraken
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// krakenErrorCodes Kraken 错误（"类别:信息" 格式）到统一错误的映射
+var krakenErrorCodes = map[string]error{
+	"EOrder:Insufficient funds":     common.ErrInsufficientFunds,    // 余额不足
+	"EAPI:Rate limit exceeded":      common.ErrRateLimitExceeded,    // 请求频率超限
+	"EOrder:Rate limit exceeded":    common.ErrRateLimitExceeded,    // 下单频率超限
+	"EGeneral:Too many requests":    common.ErrRateLimitExceeded,    // 请求过多
+	"EOrder:Unknown order":          common.ErrOrderNotFound,        // 订单不存在
+	"EOrder:Order minimum not met":  common.ErrInvalidOrder,         // 数量低于下限
+	"EOrder:Cost minimum not met":   common.ErrInvalidOrder,         // 金额低于下限
+	"EOrder:Tick size check failed": common.ErrInvalidOrder,         // 价格不是最小变动单位的整数倍
+	"EOrder:Post only order":        common.ErrInvalidOrder,         // 只做 maker 订单会立即成交
+	"EAPI:Invalid key":              common.ErrAuthenticationFailed, // API Key 无效
+	"EAPI:Invalid signature":        common.ErrAuthenticationFailed, // 签名无效
+	"EGeneral:Permission denied":    common.ErrAuthenticationFailed, // 权限不足
+}
+
+// newKrakenError 根据响应中的 error 数组创建交易所错误，第一个错误作为错误码
+func newKrakenError(errs []string) *common.ExchangeError {
+	return common.NewExchangeError(krakenName, errs[0], strings.Join(errs, "; "), krakenErrorCodes)
+}
+
+// parseKrakenError 解析 Kraken 非 2xx 响应体 {"error":["EAPI:Rate limit exceeded"]}
+func parseKrakenError(httpErr *common.HTTPError) error {
+	var body krakenResponse
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || len(body.Error) == 0 {
+		return nil
+	}
+	e := newKrakenError(body.Error)
+	e.Cause = httpErr
+	return e
+}
+
+// krakenFuturesErrorCodes Kraken 合约错误（响应中的 error 和下单/撤单的 status）到统一错误的映射
+var krakenFuturesErrorCodes = map[string]error{
+	"authenticationError":        common.ErrAuthenticationFailed, // 签名或 API Key 无效
+	"apiLimitExceeded":           common.ErrRateLimitExceeded,    // 请求频率超限
+	"insufficientAvailableFunds": common.ErrInsufficientFunds,    // 可用保证金不足
+	"invalidOrderType":           common.ErrInvalidOrder,         // 订单类型无效
+	"invalidSide":                common.ErrInvalidOrder,         // 订单方向无效
+	"invalidSize":                common.ErrInvalidOrder,         // 数量无效
+	"invalidPrice":               common.ErrInvalidOrder,         // 价格无效
+	"tooManySmallOrders":         common.ErrInvalidOrder,         // 小额订单过多
+	"maxPositionViolation":       common.ErrInvalidOrder,         // 超过最大持仓
+	"outsidePriceCollar":         common.ErrInvalidOrder,         // 价格超出限制范围
+	"postWouldExecute":           common.ErrInvalidOrder,         // 只做 maker 订单会立即成交
+	"iocWouldNotExecute":         common.ErrInvalidOrder,         // IOC 订单无法成交
+	"wouldNotReducePosition":     common.ErrInvalidOrder,         // 只减仓订单不会减少持仓
+	"clientOrderIdAlreadyExist":  common.ErrInvalidOrder,         // 客户端订单ID重复
+	"clientOrderIdTooLong":       common.ErrInvalidOrder,         // 客户端订单ID过长
+	"notFound":                   common.ErrOrderNotFound,        // 订单不存在
+}
+
+// newKrakenFuturesError 根据合约响应中的错误码创建交易所错误
+func newKrakenFuturesError(code string) *common.ExchangeError {
+	return common.NewExchangeError(krakenName, code, code, krakenFuturesErrorCodes)
+}
+
+// parseKrakenFuturesError 解析合约非 2xx 响应体 {"result":"error","error":"authenticationError"}
+func parseKrakenFuturesError(httpErr *common.HTTPError) error {
+	var body krakenFuturesResponse
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Error == "" {
+		return nil
+	}
+	e := newKrakenFuturesError(body.Error)
+	e.Cause = httpErr
+	return e
+}
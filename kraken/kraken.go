@@ -0,0 +1,350 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+)
+
+// Kraken Kraken 交易所实现
+type Kraken struct {
+	client              *Client
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
+	nonce               atomic.Int64                // 上一次私有请求使用的 nonce（毫秒，严格递增）
+	spot                *KrakenSpot
+	perp                *KrakenPerp
+	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
+	spotMarketsByID     map[string]*model.Market // 现货市场信息（原始格式索引）
+	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
+	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
+	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（nonce 按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+}
+
+// NewKraken 创建 Kraken 交易所实例
+func NewKraken(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
+	kraken := &Kraken{
+		client:              client,
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		perpMarketsBySymbol: make(map[string]*model.Market),
+		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		kraken.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(kraken.lifecycle)
+	client.FuturesClient.SetLifecycle(kraken.lifecycle)
+
+	kraken.UpdateCredentials(apiKey, secretKey, "")
+
+	// 初始化现货和合约实现
+	kraken.spot = NewKrakenSpot(kraken)
+	kraken.perp = NewKrakenPerp(kraken)
+
+	if v, ok := options["timeSync"].(bool); ok && v {
+		kraken.clock.Start(common.TimeSyncInterval, kraken.FetchTime)
+	}
+
+	return kraken, nil
+}
+
+// Spot 返回现货交易接口
+func (k *Kraken) Spot() exchange.SpotExchange {
+	return k.spot
+}
+
+// Perp 返回永续合约交易接口（Futures API 的多币种保证金永续合约 PF_*）
+func (k *Kraken) Perp() exchange.PerpExchange {
+	return k.perp
+}
+
+// Name 返回交易所名称
+func (k *Kraken) Name() string {
+	return krakenName
+}
+
+// Has 返回 Kraken 支持的功能
+func (k *Kraken) Has() model.Capabilities {
+	return krakenCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (k *Kraken) Symbols() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarketSymbols(k.spotMarketsBySymbol, k.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (k *Kraken) NormalizeSymbol(nativeID string) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, k.spotMarketsByID, k.perpMarketsByID, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (k *Kraken) DenormalizeSymbol(symbol string) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, k.spotMarketsBySymbol, k.perpMarketsBySymbol, nil)
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (k *Kraken) ExportMarkets() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarshalMarkets(krakenName, k.spotMarketsBySymbol, k.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (k *Kraken) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(krakenName, data)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		k.spotMarketsBySymbol, k.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		k.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		k.perpMarketsBySymbol, k.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		k.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后已建立的轮询订阅通道随之关闭
+func (k *Kraken) Drain(ctx context.Context) error {
+	k.clock.Stop()
+	return k.lifecycle.Drain(ctx, k.spot, k.perp)
+}
+
+// FetchTime 获取交易所服务器时间
+func (k *Kraken) FetchTime(ctx context.Context) (time.Time, error) {
+	var result krakenTimeResponse
+	if err := k.publicGet(ctx, "/0/public/Time", nil, &result); err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+	return time.Unix(result.UnixTime, 0), nil
+}
+
+// FetchStatus 获取系统状态（/0/public/SystemStatus），online 以外的状态（维护、只允许撤单/只做 maker）视为维护中
+func (k *Kraken) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	var result krakenSystemStatusResponse
+	if err := k.publicGet(ctx, "/0/public/SystemStatus", nil, &result); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+
+	status := common.OKStatus()
+	if result.Status != "online" {
+		status.Status = model.ExchangeStatusMaintenance
+		if !result.Timestamp.IsZero() {
+			status.Updated = result.Timestamp
+		}
+	}
+	return status, nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (k *Kraken) Ping(ctx context.Context) error {
+	return common.Ping(ctx, k.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (k *Kraken) VerifyCredentials(ctx context.Context) error {
+	creds := k.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", k.spot.FetchBalance)
+}
+
+// krakenCapabilities Kraken 支持的功能
+var krakenCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported: true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:            true,
+		FetchOrder:           true, // 只能查询未结束或刚结束的订单
+		FetchOrderByClientID: true,
+		WatchPositions:       true, // 轮询持仓
+		FetchMarkPrice:       true,
+		FetchIndexPrice:      true,
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// Kraken 不需要 password，忽略该参数
+func (k *Kraken) UpdateCredentials(apiKey, secretKey, password string) {
+	k.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (k *Kraken) credentials() *credentials {
+	return k.creds.Load()
+}
+
+// nextNonce 返回严格递增的 nonce（毫秒时间戳），同一毫秒内的并发请求依次加一
+func (k *Kraken) nextNonce() int64 {
+	for {
+		last := k.nonce.Load()
+		next := k.clock.Timestamp()
+		if next <= last {
+			next = last + 1
+		}
+		if k.nonce.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
+// publicGet 请求公共接口并解析 result
+func (k *Kraken) publicGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := k.client.HTTPClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	return parseKrakenResponse(resp, result)
+}
+
+// privatePost 签名并请求私有接口（JSON 请求体），签名内容与发送的请求体一致
+func (k *Kraken) privatePost(ctx context.Context, path string, body map[string]interface{}, result interface{}) error {
+	creds := k.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+	nonce := k.nextNonce()
+	body["nonce"] = nonce
+	postData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal body: %w", err)
+	}
+	signature, err := creds.signer.Sign(path, nonce, string(postData))
+	if err != nil {
+		return err
+	}
+
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"API-Key":  creds.apiKey,
+		"API-Sign": signature,
+	}
+	resp, err := k.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, nil, body, headers)
+	if err != nil {
+		return err
+	}
+	return parseKrakenResponse(resp, result)
+}
+
+// futuresGet 请求合约公共接口并解析响应
+func (k *Kraken) futuresGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := k.client.FuturesClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	return parseKrakenFuturesResponse(resp, result)
+}
+
+// futuresRequest 签名并请求合约私有接口，GET 和 POST 的参数都放在查询字符串中，签名内容与发送的查询字符串一致
+func (k *Kraken) futuresRequest(ctx context.Context, method, path string, params map[string]interface{}, result interface{}) error {
+	creds := k.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	nonce := k.nextNonce()
+	signature, err := creds.signer.SignFutures(strings.TrimPrefix(path, "/derivatives"), nonce, common.BuildQueryString(params))
+	if err != nil {
+		return err
+	}
+
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"APIKey":  creds.apiKey,
+		"Nonce":   strconv.FormatInt(nonce, 10),
+		"Authent": signature,
+	}
+	resp, err := k.client.FuturesClient.RequestWithHeaders(ctx, method, path, params, nil, headers)
+	if err != nil {
+		return err
+	}
+	return parseKrakenFuturesResponse(resp, result)
+}
+
+// parseKrakenFuturesResponse 解析合约响应，result 为 error 时返回交易所错误；数据字段与 result 在同一层
+func parseKrakenFuturesResponse(resp []byte, result interface{}) error {
+	var envelope krakenFuturesResponse
+	if err := json.Unmarshal(resp, &envelope); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	if envelope.Result == "error" || envelope.Error != "" {
+		return newKrakenFuturesError(envelope.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("unmarshal result: %w", err)
+	}
+	return nil
+}
+
+// parseKrakenResponse 解析响应外层结构，error 非空时返回交易所错误
+func parseKrakenResponse(resp []byte, result interface{}) error {
+	var envelope krakenResponse
+	if err := json.Unmarshal(resp, &envelope); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	if len(envelope.Error) > 0 {
+		return newKrakenError(envelope.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("unmarshal result: %w", err)
+	}
+	return nil
+}
+
+var _ exchange.Exchange = (*Kraken)(nil)
@@ -0,0 +1,626 @@
+package kraken
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// krakenPerpPrefix 多币种保证金永续合约ID前缀（PF_XBTUSD），PI_ 反向永续和 FF_/FI_ 交割合约不接入
+const krakenPerpPrefix = "PF_"
+
+// KrakenPerp Kraken 永续合约实现
+// Kraken 合约使用独立的 Futures API（futures.kraken.com），API Key 需在合约站单独创建；只接入多币种保证金永续合约（PF_*，USD 计价和结算，数量为基础货币数量），单向持仓
+type KrakenPerp struct {
+	kraken *Kraken
+}
+
+// NewKrakenPerp 创建 Kraken 永续合约实例
+func NewKrakenPerp(k *Kraken) *KrakenPerp {
+	return &KrakenPerp{kraken: k}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载永续合约市场（/derivatives/api/v3/instruments），只保留 PF_ 永续合约，资产代码转换为通用代码（XBT -> BTC）
+func (p *KrakenPerp) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	p.kraken.mu.RLock()
+	if !reload && len(p.kraken.perpMarketsBySymbol) > 0 && !p.kraken.marketCache.Expired(model.MarketTypeSwap) {
+		p.kraken.mu.RUnlock()
+		return nil
+	}
+	p.kraken.mu.RUnlock()
+
+	var data krakenFuturesInstrumentsResponse
+	if err := p.kraken.futuresGet(ctx, "/derivatives/api/v3/instruments", nil, &data); err != nil {
+		return fmt.Errorf("fetch swap markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data.Instruments))
+	for _, item := range data.Instruments {
+		// 交割合约带最后交易时间
+		if !strings.HasPrefix(item.Symbol, krakenPerpPrefix) || item.LastTradingTime != "" {
+			continue
+		}
+
+		base := krakenCurrency(item.Base)
+		quote := krakenCurrency(item.Quote)
+		market := &model.Market{
+			ID:            item.Symbol,                                        // Kraken 原始格式 (PF_XBTUSD)
+			Symbol:        common.NormalizeContractSymbol(base, quote, quote), // 标准化格式 (BTC/USD:USD)
+			Base:          base,
+			Quote:         quote,
+			Settle:        quote,
+			Type:          model.MarketTypeSwap,
+			Active:        item.Tradeable,
+			Contract:      true,
+			Linear:        true,
+			ContractValue: item.ContractSize.String(),
+		}
+
+		market.Precision.Amount = max(item.ContractValueTradePrecision, 0)
+		market.Precision.Price = max(-int(item.TickSize.Exponent()), 0)
+		market.Precision.StepSize = types.ExDecimal{Decimal: decimal.New(1, -int32(item.ContractValueTradePrecision))}
+		market.Precision.TickSize = item.TickSize
+		market.Limits.Amount.Min = market.Precision.StepSize
+		market.Limits.Amount.Max = item.MaxPositionSize
+		if len(item.MarginLevels) > 0 && item.MarginLevels[0].InitialMargin.IsPositive() {
+			market.Limits.Leverage.Max = types.ExDecimal{Decimal: decimal.NewFromInt(1).Div(item.MarginLevels[0].InitialMargin.Decimal).Floor()}
+		}
+
+		markets = append(markets, market)
+	}
+
+	p.kraken.mu.Lock()
+	p.kraken.perpMarketsBySymbol, p.kraken.perpMarketsByID = common.IndexMarkets(markets)
+	p.kraken.marketCache.Touch(model.MarketTypeSwap)
+	p.kraken.mu.Unlock()
+
+	return nil
+}
+
+func (p *KrakenPerp) FetchMarkets(ctx context.Context, opts ...option.ArgsOption) (model.Markets, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 确保市场已加载
+	if err := p.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		market, err := p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		return model.Markets{market}, nil
+	}
+
+	p.kraken.mu.RLock()
+	defer p.kraken.mu.RUnlock()
+
+	markets := make(model.Markets, 0, len(p.kraken.perpMarketsBySymbol))
+	for _, market := range p.kraken.perpMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (p *KrakenPerp) GetMarket(symbol string) (*model.Market, error) {
+	p.kraken.mu.RLock()
+	defer p.kraken.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := p.kraken.perpMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := p.kraken.perpMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, p.kraken.perpMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *KrakenPerp) GetMarketByID(id string) (*model.Market, error) {
+	p.kraken.mu.RLock()
+	defer p.kraken.mu.RUnlock()
+
+	if market, ok := p.kraken.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.kraken.perpMarketsByID)
+}
+
+func (p *KrakenPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (p *KrakenPerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+func (p *KrakenPerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+func (p *KrakenPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
+}
+
+// FetchTicker 获取行情（/derivatives/api/v3/tickers 中的对应合约），包含标记价格和指数价格
+func (p *KrakenPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data krakenFuturesTickersResponse
+	if err := p.kraken.futuresGet(ctx, "/derivatives/api/v3/tickers", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+
+	for _, item := range data.Tickers {
+		if item.Symbol == market.ID {
+			return item.toTicker(market.Symbol, data.ServerTime), nil
+		}
+	}
+	return nil, fmt.Errorf("ticker not found")
+}
+
+// FetchTickers 获取永续合约行情（/derivatives/api/v3/tickers），设置 option.WithSymbol 时只返回该合约，跳过未加载的合约
+func (p *KrakenPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (model.Tickers, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		ticker, err := p.FetchTicker(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return model.Tickers{ticker}, nil
+	}
+
+	var data krakenFuturesTickersResponse
+	if err := p.kraken.futuresGet(ctx, "/derivatives/api/v3/tickers", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(model.Tickers, 0, len(data.Tickers))
+	for _, item := range data.Tickers {
+		market, err := p.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+		tickers = append(tickers, item.toTicker(market.Symbol, data.ServerTime))
+	}
+
+	return tickers, nil
+}
+
+// FetchOrderBook 获取订单簿深度（/derivatives/api/v3/orderbook），接口返回完整深度，按 limit 截取
+func (p *KrakenPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data krakenFuturesOrderBookResponse
+	if err := p.kraken.futuresGet(ctx, "/derivatives/api/v3/orderbook", map[string]interface{}{"symbol": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.OrderBook.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.OrderBook.Asks, limit),
+		Timestamp: data.ServerTime,
+	}, nil
+}
+
+func (p *KrakenPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch perp ticker")
+}
+
+func (p *KrakenPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch perp order book")
+}
+
+func (p *KrakenPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	return nil, notSupported("fetch perp ohlcv")
+}
+
+func (p *KrakenPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return nil, notSupported("fetch perp ohlcv")
+}
+
+func (p *KrakenPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch perp ohlcv")
+}
+
+func (p *KrakenPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("poll perp ohlcv")
+}
+
+func (p *KrakenPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch perp aggregated trades")
+}
+
+func (p *KrakenPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	return nil, notSupported("fetch funding rate")
+}
+
+func (p *KrakenPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	return nil, notSupported("fetch funding rate history")
+}
+
+func (p *KrakenPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	return nil, notSupported("fetch open interest")
+}
+
+// FetchMarkPrice 获取标记价格，合约行情接口同时返回标记价格和指数价格
+func (p *KrakenPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return p.FetchTicker(ctx, symbol)
+}
+
+// FetchIndexPrice 获取指数价格（取自合约行情）
+func (p *KrakenPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.FetchTicker(ctx, symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
+// ========== 账户信息 ==========
+
+// FetchPositions 获取永续合约持仓（/derivatives/api/v3/openpositions），设置 option.WithSymbol 时只返回该合约的持仓
+// 持仓接口不返回标记价格和未实现盈亏；返回 maxFixedLeverage 的持仓为逐仓，其他为全仓
+func (p *KrakenPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	var marketID string
+	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
+		market, err := p.GetMarket(symbol)
+		if err != nil {
+			return nil, err
+		}
+		marketID = market.ID
+	}
+
+	var data krakenFuturesPositionsResponse
+	if err := p.kraken.futuresRequest(ctx, http.MethodGet, "/derivatives/api/v3/openpositions", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch positions: %w", err)
+	}
+
+	positions := make(model.Positions, 0, len(data.OpenPositions))
+	for _, item := range data.OpenPositions {
+		if item.Size.IsZero() || (marketID != "" && item.Symbol != marketID) {
+			continue
+		}
+		market, err := p.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+
+		side := string(types.PositionSideLong)
+		if item.Side == "short" {
+			side = string(types.PositionSideShort)
+		}
+		marginMode := model.MarginModeCross
+		if item.MaxFixedLeverage.IsPositive() {
+			marginMode = model.MarginModeIsolated
+		}
+
+		positions = append(positions, &model.Position{
+			Symbol:     market.Symbol,
+			Side:       side,
+			Amount:     item.Size,
+			EntryPrice: item.Price,
+			Leverage:   item.MaxFixedLeverage,
+			MarginMode: marginMode,
+			Timestamp:  item.FillTime,
+		})
+	}
+
+	return positions, nil
+}
+
+func (p *KrakenPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.kraken.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollPositions(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	})
+}
+
+func (p *KrakenPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch perp balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/derivatives/api/v3/sendorder），数量为基础货币数量
+// 限价单 orderType 为 lmt（只做 maker 为 post，IOC 为 ioc），市价单为 mkt；平仓单带 reduceOnly；不支持 FOK、条件单和跟踪止损
+func (p *KrakenPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+	if option.StringPresent(argsOpts.TrailingCallbackRate) {
+		return nil, notSupported("trailing stop order")
+	}
+	if hedged, ok := option.GetBool(argsOpts.HedgeMode); ok && hedged {
+		return nil, notSupported("hedge mode")
+	}
+
+	isLimit := orderType == option.Limit
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	if argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsFOK() {
+		return nil, fmt.Errorf("time in force FOK is not supported by kraken: %w", common.ErrInvalidOrder)
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{
+		"symbol":    market.ID,
+		"side":      strings.ToLower(orderSide.ToSide()),
+		"size":      size,
+		"orderType": "mkt",
+	}
+	if isLimit {
+		price, ok := option.GetDecimalFromString(argsOpts.Price)
+		if !ok || price.IsZero() {
+			return nil, fmt.Errorf("limit order requires price")
+		}
+		limitPrice, err := common.PriceToPrecision(market, price.String())
+		if err != nil {
+			return nil, err
+		}
+		params["limitPrice"] = limitPrice
+		switch {
+		case postOnly:
+			params["orderType"] = "post"
+		case argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsIOC():
+			params["orderType"] = "ioc"
+		default:
+			params["orderType"] = "lmt"
+		}
+	}
+	if common.ReduceOnly(orderSide, argsOpts) {
+		params["reduceOnly"] = "true"
+	}
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		params["cliOrdId"] = *argsOpts.ClientOrderID
+	}
+
+	var result krakenFuturesSendOrderResponse
+	if err := p.kraken.futuresRequest(ctx, http.MethodPost, "/derivatives/api/v3/sendorder", params, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+	// 请求成功但订单被拒绝时，status 为拒绝原因
+	switch status := result.SendStatus.Status; status {
+	case "placed", "partiallyFilled", "filled":
+	default:
+		return nil, fmt.Errorf("create order: %w", newKrakenFuturesError(status))
+	}
+
+	order := &model.NewOrder{
+		OrderId:   result.SendStatus.OrderID,
+		Symbol:    symbol,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}
+	if argsOpts.ClientOrderID != nil {
+		order.ClientOrderID = *argsOpts.ClientOrderID
+	}
+	p.kraken.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (p *KrakenPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.PerpOrderRequest) (*model.NewOrder, error) {
+		return p.CreateOrder(ctx, r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+	})
+}
+
+// ClosePosition 以只减仓市价单平掉持仓
+func (p *KrakenPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	_, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
+}
+
+// CancelOrder 撤销订单（/derivatives/api/v3/cancelorder），orderId 为空时按 option.WithClientOrderID 撤销
+// 订单不存在或已结束时返回 common.ErrOrderNotFound
+func (p *KrakenPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	params := map[string]interface{}{"order_id": orderId}
+	if orderId == "" {
+		if !option.StringPresent(argsOpts.ClientOrderID) {
+			return fmt.Errorf("cancel order: order id or client order id required")
+		}
+		params = map[string]interface{}{"cliOrdId": *argsOpts.ClientOrderID}
+	}
+
+	var result krakenFuturesCancelOrderResponse
+	if err := p.kraken.futuresRequest(ctx, http.MethodPost, "/derivatives/api/v3/cancelorder", params, &result); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	switch result.CancelStatus.Status {
+	case "cancelled":
+	case "filled", "notFound":
+		return fmt.Errorf("cancel order %s: %w: %s", orderId, common.ErrOrderNotFound, result.CancelStatus.Status)
+	default:
+		return fmt.Errorf("cancel order: %w", newKrakenFuturesError(result.CancelStatus.Status))
+	}
+	p.kraken.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+func (p *KrakenPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return nil, notSupported("edit perp order")
+}
+
+// FetchOrder 查询订单（/derivatives/api/v3/orders/status），只能查到未结束或刚结束的订单，其他返回 common.ErrOrderNotFound
+func (p *KrakenPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	return p.fetchOrderStatus(ctx, symbol, map[string]interface{}{"orderIds": orderId})
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单（/derivatives/api/v3/orders/status），限制与 FetchOrder 相同
+func (p *KrakenPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.fetchOrderStatus(ctx, symbol, map[string]interface{}{"cliOrdIds": clientOrderID})
+}
+
+// fetchOrderStatus 按订单ID或客户端订单ID查询单个订单
+func (p *KrakenPerp) fetchOrderStatus(ctx context.Context, symbol string, params map[string]interface{}) (*model.PerpOrder, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data krakenFuturesOrderStatusResponse
+	if err := p.kraken.futuresRequest(ctx, http.MethodPost, "/derivatives/api/v3/orders/status", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch order: %w", err)
+	}
+	if len(data.Orders) == 0 {
+		return nil, fmt.Errorf("fetch order: %w", common.ErrOrderNotFound)
+	}
+	return toKrakenPerpOrder(market.Symbol, &data.Orders[0]), nil
+}
+
+// toKrakenPerpOrder 转换为统一的合约订单结构，带限价的订单为限价单
+func toKrakenPerpOrder(symbol string, item *krakenFuturesOrderStatus) *model.PerpOrder {
+	orderType := "market"
+	if item.Order.LimitPrice.IsPositive() {
+		orderType = "limit"
+	}
+	return &model.PerpOrder{
+		ID:               item.Order.OrderID,
+		ClientID:         item.Order.CliOrdID,
+		Type:             orderType,
+		Side:             item.Order.Side,
+		PositionSide:     "NET",
+		Symbol:           symbol,
+		Price:            item.Order.LimitPrice,
+		Quantity:         item.Order.Quantity,
+		ExecutedQuantity: item.Order.Filled,
+		Status:           item.Status,
+		ReduceOnly:       item.Order.ReduceOnly,
+		CreateTime:       item.Order.Timestamp,
+		UpdateTime:       item.Order.LastUpdateTimestamp,
+	}
+}
+
+func (p *KrakenPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (p *KrakenPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track perp order")
+}
+
+func (p *KrakenPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	return nil, notSupported("watch perp orders")
+}
+
+// ========== 合约特有功能 ==========
+
+func (p *KrakenPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
+	return notSupported("set leverage")
+}
+
+func (p *KrakenPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	return notSupported("set leverage side")
+}
+
+func (p *KrakenPerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
+	return notSupported("set margin type")
+}
+
+// SetPositionMode Kraken 合约只支持单向持仓，设置双向持仓返回 common.ErrNotSupported
+func (p *KrakenPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	if hedged {
+		return notSupported("hedge mode")
+	}
+	return nil
+}
+
+// GetPositionMode Kraken 合约只支持单向持仓
+func (p *KrakenPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+var _ exchange.PerpExchange = (*KrakenPerp)(nil)
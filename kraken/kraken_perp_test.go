@@ -0,0 +1,260 @@
+package kraken
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+const testFuturesInstruments = `{"result":"success","instruments":[
+	{"symbol":"PF_XBTUSD","type":"flexible_futures","base":"XBT","quote":"USD","tradeable":true,"tickSize":0.5,
+		"contractSize":1,"contractValueTradePrecision":4,"maxPositionSize":1000000,
+		"marginLevels":[{"contracts":0,"initialMargin":0.02,"maintenanceMargin":0.01}]},
+	{"symbol":"PF_DOGEUSD","type":"flexible_futures","base":"DOGE","quote":"USD","tradeable":false,"tickSize":0.00001,
+		"contractSize":1,"contractValueTradePrecision":-1,"maxPositionSize":5000000,
+		"marginLevels":[{"contracts":0,"initialMargin":0.1,"maintenanceMargin":0.05}]},
+	{"symbol":"PI_XBTUSD","type":"futures_inverse","base":"XBT","quote":"USD","tradeable":true,"tickSize":0.5,"contractSize":1},
+	{"symbol":"FF_XBTUSD_260925","type":"flexible_futures","base":"XBT","quote":"USD","tradeable":true,"tickSize":1,
+		"contractSize":1,"lastTradingTime":"2026-09-25T15:00:00.000Z"}
+],"serverTime":"2026-10-17T08:00:00.000Z"}`
+
+// newTestKrakenPerp 创建合约请求指向 handler 的 Kraken 实例，instruments 返回 testFuturesInstruments
+func newTestKrakenPerp(t *testing.T, handler http.HandlerFunc) *Kraken {
+	t.Helper()
+	return exchangetest.New[*Kraken](t, exchangetest.Venue{
+		New:         NewKraken,
+		SecretKey:   testSecret,
+		URLOption:   "perpBaseURL",
+		MarketsPath: "/derivatives/api/v3/instruments",
+		Markets:     testFuturesInstruments,
+		Perp:        true,
+	}, handler)
+}
+
+// checkFuturesAuth 校验合约私有请求头，Authent 按发送的查询字符串重新计算
+func checkFuturesAuth(t *testing.T, r *http.Request) {
+	t.Helper()
+	if r.Header.Get("APIKey") != "key" || r.Header.Get("Nonce") == "" {
+		t.Errorf("headers = %v", r.Header)
+	}
+	nonce, _ := decimal.NewFromString(r.Header.Get("Nonce"))
+	want, _ := NewSigner(testSecret).SignFutures(r.URL.Path[len("/derivatives"):], nonce.IntPart(), r.URL.RawQuery)
+	if got := r.Header.Get("Authent"); got != want {
+		t.Errorf("Authent = %s, want %s", got, want)
+	}
+}
+
+func TestKrakenPerp_LoadMarkets(t *testing.T) {
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	markets, _ := k.Perp().FetchMarkets(context.Background())
+	if len(markets) != 2 {
+		t.Fatalf("got %d markets, want 2 (inverse and fixed maturity skipped)", len(markets))
+	}
+
+	btc, err := k.Perp().GetMarket("BTC/USD:USD")
+	if err != nil {
+		t.Fatalf("GetMarket: %v", err)
+	}
+	if btc.ID != "PF_XBTUSD" || btc.Type != model.MarketTypeSwap || !btc.Linear || !btc.Active ||
+		btc.Precision.Amount != 4 || btc.Precision.Price != 1 || !btc.Precision.StepSize.Equal(decimal.RequireFromString("0.0001")) ||
+		!btc.Limits.Leverage.Max.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("market = %+v", btc)
+	}
+	// 负数精度表示数量为 10 的整数倍
+	doge, err := k.Perp().GetMarketByID("PF_DOGEUSD")
+	if err != nil {
+		t.Fatalf("GetMarketByID: %v", err)
+	}
+	if doge.Symbol != "DOGE/USD:USD" || doge.Active || doge.Precision.Amount != 0 || !doge.Precision.StepSize.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("market = %+v", doge)
+	}
+	if id, err := k.DenormalizeSymbol("BTC/USD:USD"); err != nil || id != "PF_XBTUSD" {
+		t.Errorf("DenormalizeSymbol = %s, %v", id, err)
+	}
+}
+
+func TestKrakenPerp_FetchTicker(t *testing.T) {
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/derivatives/api/v3/tickers" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"result":"success","tickers":[
+			{"symbol":"PF_XBTUSD","last":67000.5,"bid":67000,"ask":67001,"markPrice":67000.2,"indexPrice":66999.8,"vol24h":1234.5},
+			{"symbol":"PI_XBTUSD","last":67010,"bid":67009,"ask":67011}
+		],"serverTime":"2026-10-17T08:00:00.000Z"}`))
+	})
+
+	ticker, err := k.Perp().FetchTicker(context.Background(), "BTC/USD:USD")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Symbol != "BTC/USD:USD" || !ticker.Last.Equal(decimal.RequireFromString("67000.5")) ||
+		!ticker.MarkPrice.Equal(decimal.RequireFromString("67000.2")) || !ticker.IndexPrice.Equal(decimal.RequireFromString("66999.8")) {
+		t.Errorf("ticker = %+v", ticker)
+	}
+
+	// 未加载的合约被跳过
+	tickers, err := k.Perp().FetchTickers(context.Background())
+	if err != nil || len(tickers) != 1 {
+		t.Errorf("FetchTickers = %d, %v, want 1", len(tickers), err)
+	}
+}
+
+func TestKrakenPerp_FetchPositions(t *testing.T) {
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/derivatives/api/v3/openpositions" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		checkFuturesAuth(t, r)
+		w.Write([]byte(`{"result":"success","openPositions":[
+			{"side":"short","symbol":"PF_XBTUSD","price":67000,"fillTime":"2026-10-17T07:00:00.000Z","size":0.5,"maxFixedLeverage":10},
+			{"side":"long","symbol":"PF_DOGEUSD","price":0.12,"fillTime":"2026-10-17T07:30:00.000Z","size":1000}
+		],"serverTime":"2026-10-17T08:00:00.000Z"}`))
+	})
+
+	positions, err := k.Perp().FetchPositions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("got %d positions, want 2", len(positions))
+	}
+	if p := positions[0]; p.Symbol != "BTC/USD:USD" || p.Side != "short" || p.MarginMode != model.MarginModeIsolated ||
+		!p.Amount.Equal(decimal.RequireFromString("0.5")) || !p.Leverage.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("positions[0] = %+v", p)
+	}
+	if p := positions[1]; p.Symbol != "DOGE/USD:USD" || p.Side != "long" || p.MarginMode != model.MarginModeCross {
+		t.Errorf("positions[1] = %+v", p)
+	}
+
+	positions, err = k.Perp().FetchPositions(context.Background(), option.WithSymbol("DOGE/USD:USD"))
+	if err != nil || len(positions) != 1 || positions[0].Symbol != "DOGE/USD:USD" {
+		t.Errorf("FetchPositions(WithSymbol) = %v, %v", positions, err)
+	}
+}
+
+func TestKrakenPerp_CreateOrder(t *testing.T) {
+	var query map[string]string
+	status := "placed"
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/derivatives/api/v3/sendorder" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		checkFuturesAuth(t, r)
+		query = map[string]string{}
+		for key := range r.URL.Query() {
+			query[key] = r.URL.Query().Get(key)
+		}
+		w.Write([]byte(`{"result":"success","sendStatus":{"order_id":"c18f0c17-9971-40e6-8e5b-10df05d422f0","status":"` + status + `"}}`))
+	})
+
+	order, err := k.Perp().CreateOrder(context.Background(), "BTC/USD:USD", "0.12345", option.OpenLong, option.Limit,
+		option.WithPrice("67000.3"), option.WithPostOnly(true), option.WithClientOrderID("my-order"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "c18f0c17-9971-40e6-8e5b-10df05d422f0" || order.ClientOrderID != "my-order" {
+		t.Errorf("order = %+v", order)
+	}
+	want := map[string]string{"orderType": "post", "symbol": "PF_XBTUSD", "side": "buy", "size": "0.1234", "limitPrice": "67000", "cliOrdId": "my-order"}
+	for key, v := range want {
+		if query[key] != v {
+			t.Errorf("%s = %q, want %q", key, query[key], v)
+		}
+	}
+	if _, ok := query["reduceOnly"]; ok {
+		t.Error("reduceOnly sent for open order")
+	}
+
+	// 平仓市价单带 reduceOnly
+	if _, err := k.Perp().CreateOrder(context.Background(), "BTC/USD:USD", "0.1", option.CloseShort, option.Market); err != nil {
+		t.Fatalf("CreateOrder(CloseShort): %v", err)
+	}
+	if query["orderType"] != "mkt" || query["side"] != "buy" || query["reduceOnly"] != "true" {
+		t.Errorf("query = %v", query)
+	}
+
+	// 被拒绝的订单按 status 映射错误
+	status = "insufficientAvailableFunds"
+	_, err = k.Perp().CreateOrder(context.Background(), "BTC/USD:USD", "0.1", option.OpenShort, option.Market)
+	if !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("CreateOrder(rejected) = %v, want ErrInsufficientFunds", err)
+	}
+
+	// 不支持 FOK
+	_, err = k.Perp().CreateOrder(context.Background(), "BTC/USD:USD", "0.1", option.OpenLong, option.Limit,
+		option.WithPrice("67000"), option.WithTimeInForce(option.FOK))
+	if !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("CreateOrder(FOK) = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestKrakenPerp_CancelAndFetchOrder(t *testing.T) {
+	cancelStatus := "cancelled"
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		checkFuturesAuth(t, r)
+		switch r.URL.Path {
+		case "/derivatives/api/v3/cancelorder":
+			if r.URL.Query().Get("order_id") != "oid-1" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"result":"success","cancelStatus":{"order_id":"oid-1","status":"` + cancelStatus + `"}}`))
+		case "/derivatives/api/v3/orders/status":
+			if r.URL.Query().Get("cliOrdIds") == "missing" {
+				w.Write([]byte(`{"result":"success","orders":[]}`))
+				return
+			}
+			w.Write([]byte(`{"result":"success","orders":[{"order":{"type":"ORDER","orderId":"oid-1","cliOrdId":"my-order",
+				"symbol":"PF_XBTUSD","side":"sell","quantity":0.5,"filled":0.5,"limitPrice":67000,"reduceOnly":true,
+				"timestamp":"2026-10-17T07:00:00.000Z","lastUpdateTimestamp":"2026-10-17T07:01:00.000Z"},"status":"FULLY_EXECUTED"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	if err := k.Perp().CancelOrder(ctx, "BTC/USD:USD", "oid-1"); err != nil {
+		t.Errorf("CancelOrder: %v", err)
+	}
+	cancelStatus = "notFound"
+	if err := k.Perp().CancelOrder(ctx, "BTC/USD:USD", "oid-1"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("CancelOrder(notFound) = %v, want ErrOrderNotFound", err)
+	}
+
+	order, err := k.Perp().FetchOrderByClientID(ctx, "BTC/USD:USD", "my-order")
+	if err != nil {
+		t.Fatalf("FetchOrderByClientID: %v", err)
+	}
+	if order.ID != "oid-1" || order.Type != "limit" || order.Side != "sell" || !order.ReduceOnly ||
+		!order.ExecutedQuantity.Equal(decimal.RequireFromString("0.5")) || !common.IsTerminalOrderStatus(order.Status) {
+		t.Errorf("order = %+v", order)
+	}
+	if _, err := k.Perp().FetchOrderByClientID(ctx, "BTC/USD:USD", "missing"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("FetchOrderByClientID(missing) = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestKrakenPerp_Errors(t *testing.T) {
+	k := newTestKrakenPerp(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"result":"error","error":"authenticationError","serverTime":"2026-10-17T08:00:00.000Z"}`))
+	})
+
+	_, err := k.Perp().FetchPositions(context.Background())
+	if !errors.Is(err, common.ErrAuthenticationFailed) {
+		t.Errorf("FetchPositions = %v, want ErrAuthenticationFailed", err)
+	}
+	if err := k.Perp().SetPositionMode(context.Background(), true); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("SetPositionMode(true) = %v, want ErrNotSupported", err)
+	}
+}
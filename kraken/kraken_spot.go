@@ -0,0 +1,551 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// KrakenSpot Kraken 现货实现
+type KrakenSpot struct {
+	kraken *Kraken
+}
+
+// NewKrakenSpot 创建 Kraken 现货实例
+func NewKrakenSpot(k *Kraken) *KrakenSpot {
+	return &KrakenSpot{kraken: k}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载现货市场（/0/public/AssetPairs），资产代码转换为通用代码（XBT -> BTC、XDG -> DOGE）
+func (s *KrakenSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	s.kraken.mu.RLock()
+	if !reload && len(s.kraken.spotMarketsBySymbol) > 0 && !s.kraken.marketCache.Expired(model.MarketTypeSpot) {
+		s.kraken.mu.RUnlock()
+		return nil
+	}
+	s.kraken.mu.RUnlock()
+
+	var data map[string]krakenAssetPair
+	if err := s.kraken.publicGet(ctx, "/0/public/AssetPairs", nil, &data); err != nil {
+		return fmt.Errorf("fetch spot markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data))
+	for id, pair := range data {
+		// 暗池交易对（.d 后缀）不在订单簿交易
+		if strings.HasSuffix(id, ".d") {
+			continue
+		}
+
+		symbol := krakenSymbol(pair)
+		base, quote, _ := strings.Cut(symbol, "/")
+		market := &model.Market{
+			ID:     id,     // Kraken 原始格式 (XXBTZUSD)
+			Symbol: symbol, // 标准化格式 (BTC/USD)
+			Base:   base,
+			Quote:  quote,
+			Type:   model.MarketTypeSpot,
+			Active: pair.Status == "online",
+		}
+
+		market.Precision.Amount = pair.LotDecimals
+		market.Precision.Price = pair.PairDecimals
+		market.Precision.StepSize = types.ExDecimal{Decimal: decimal.New(1, -int32(pair.LotDecimals))}
+		market.Precision.TickSize = pair.TickSize
+		if !market.Precision.TickSize.IsPositive() {
+			market.Precision.TickSize = types.ExDecimal{Decimal: decimal.New(1, -int32(pair.PairDecimals))}
+		}
+		market.Limits.Amount.Min = pair.OrderMin
+		market.Limits.Cost.Min = pair.CostMin
+
+		markets = append(markets, market)
+	}
+
+	s.kraken.mu.Lock()
+	s.kraken.spotMarketsBySymbol, s.kraken.spotMarketsByID = common.IndexMarkets(markets)
+	s.kraken.marketCache.Touch(model.MarketTypeSpot)
+	s.kraken.mu.Unlock()
+
+	return nil
+}
+
+// FetchMarkets 获取现货市场列表
+func (s *KrakenSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	// 确保市场已加载
+	if err := s.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.GetMarkets()
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (s *KrakenSpot) GetMarket(symbol string) (*model.Market, error) {
+	s.kraken.mu.RLock()
+	defer s.kraken.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := s.kraken.spotMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := s.kraken.spotMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, s.kraken.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *KrakenSpot) GetMarketByID(id string) (*model.Market, error) {
+	s.kraken.mu.RLock()
+	defer s.kraken.mu.RUnlock()
+
+	if market, ok := s.kraken.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, s.kraken.spotMarketsByID)
+}
+
+// GetMarkets 从内存中获取所有现货市场信息
+func (s *KrakenSpot) GetMarkets() ([]*model.Market, error) {
+	s.kraken.mu.RLock()
+	defer s.kraken.mu.RUnlock()
+
+	markets := make([]*model.Market, 0, len(s.kraken.spotMarketsBySymbol))
+	for _, market := range s.kraken.spotMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+func (s *KrakenSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *KrakenSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *KrakenSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取行情（/0/public/Ticker）
+func (s *KrakenSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]krakenTicker
+	if err := s.kraken.publicGet(ctx, "/0/public/Ticker", map[string]interface{}{"pair": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+
+	item, ok := data[market.ID]
+	if !ok {
+		return nil, fmt.Errorf("ticker not found")
+	}
+	return item.toTicker(market.Symbol), nil
+}
+
+// FetchTickers 获取全部现货行情，跳过未加载的交易对
+func (s *KrakenSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	var data map[string]krakenTicker
+	if err := s.kraken.publicGet(ctx, "/0/public/Ticker", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(map[string]*model.Ticker, len(data))
+	for id, item := range data {
+		market, err := s.GetMarketByID(id)
+		if err != nil {
+			continue
+		}
+		tickers[market.Symbol] = item.toTicker(market.Symbol)
+	}
+
+	return tickers, nil
+}
+
+func (s *KrakenSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取订单簿深度（/0/public/Depth），Kraken 不返回更新ID，Nonce 为 0
+func (s *KrakenSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{"pair": market.ID}
+	if limit = common.ClampOrderBookLimit(limit, krakenMaxDepthLimit); limit > 0 {
+		params["count"] = limit
+	}
+
+	var data map[string]krakenOrderBook
+	if err := s.kraken.publicGet(ctx, "/0/public/Depth", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	book, ok := data[market.ID]
+	if !ok {
+		return nil, fmt.Errorf("order book not found")
+	}
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(book.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(book.Asks, limit),
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
+
+func (s *KrakenSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (s *KrakenSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/0/public/OHLC），Kraken 只返回最近 720 根，since 早于该范围时从最早可用的K线开始
+// limit 保留最近的 limit 根，option.WithUntil 过滤开盘时间不早于 until 的K线
+func (s *KrakenSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, krakenTimeframe(timeframe), krakenTimeframes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"pair":     market.ID,
+		"interval": interval,
+	}
+	if argsOpts.Since != nil && !argsOpts.Since.IsZero() {
+		// since 为开区间，减 1 秒使开盘时间等于 since 的K线也返回
+		params["since"] = argsOpts.Since.Unix() - 1
+	}
+
+	// 结果中除交易对外还包含 last（下一次轮询使用的 since）
+	var data map[string]json.RawMessage
+	if err := s.kraken.publicGet(ctx, "/0/public/OHLC", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch ohlcv: %w", err)
+	}
+	var klines []krakenKline
+	if raw, ok := data[market.ID]; ok {
+		if err := json.Unmarshal(raw, &klines); err != nil {
+			return nil, fmt.Errorf("unmarshal ohlcv: %w", err)
+		}
+	}
+
+	until, _ := option.GetTime(argsOpts.Until)
+	ohlcvs := make(model.OHLCVs, 0, len(klines))
+	for _, item := range klines {
+		if !until.IsZero() && !item.Time.Before(until) {
+			break
+		}
+		ohlcvs = append(ohlcvs, &model.OHLCV{
+			Timestamp: item.Time,
+			Open:      item.Open,
+			High:      item.High,
+			Low:       item.Low,
+			Close:     item.Close,
+			Volume:    item.Volume,
+		})
+	}
+	if argsOpts.Limit != nil && *argsOpts.Limit > 0 && len(ohlcvs) > *argsOpts.Limit {
+		ohlcvs = ohlcvs[len(ohlcvs)-*argsOpts.Limit:]
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.kraken.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *KrakenSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, krakenOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end))
+	})
+}
+
+func (s *KrakenSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (s *KrakenSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.kraken.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *KrakenSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// FetchBalance 获取现货余额（/0/private/BalanceEx），Locked 为挂单冻结部分，其他账户类型返回 common.ErrNotSupported
+func (s *KrakenSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	if accountType := option.GetAccountType(argsOpts.AccountType); accountType != option.AccountSpot {
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+
+	var data map[string]krakenBalance
+	if err := s.kraken.privatePost(ctx, "/0/private/BalanceEx", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	balances := make(model.Balances, 0, len(data))
+	for asset, bal := range data {
+		balances = append(balances, &model.Balance{
+			Currency:  krakenCurrency(asset),
+			Available: types.ExDecimal{Decimal: bal.Balance.Sub(bal.HoldTrade.Decimal)},
+			Locked:    bal.HoldTrade,
+			Total:     bal.Balance,
+			UpdatedAt: types.ExTimestamp{Time: time.Now()}, // Kraken 余额接口没有返回更新时间
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+func (s *KrakenSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/0/private/AddOrder），设置 WithPrice 时为限价单，否则为市价单（市价买单数量同样为基础货币数量）
+// Kraken 的 cl_ord_id 最长 18 个字符，只在设置 option.WithClientOrderID 时发送；不支持 FOK 和条件单
+func (s *KrakenSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+
+	isLimit := option.StringPresent(argsOpts.Price)
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	if argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsFOK() {
+		return nil, fmt.Errorf("time in force FOK is not supported by kraken: %w", common.ErrInvalidOrder)
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{
+		"pair":      market.ID,
+		"type":      strings.ToLower(string(side)),
+		"ordertype": "market",
+		"volume":    volume,
+	}
+	if isLimit {
+		price, err := common.PriceToPrecision(market, *argsOpts.Price)
+		if err != nil {
+			return nil, err
+		}
+		body["ordertype"] = "limit"
+		body["price"] = price
+		if argsOpts.TimeInForce != nil && argsOpts.TimeInForce.IsIOC() {
+			body["timeinforce"] = option.IOC.Upper()
+		}
+	}
+	if postOnly {
+		body["oflags"] = "post"
+	}
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		body["cl_ord_id"] = *argsOpts.ClientOrderID
+	}
+
+	var result krakenAddOrderResponse
+	if err := s.kraken.privatePost(ctx, "/0/private/AddOrder", body, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+	if len(result.TxID) == 0 {
+		return nil, fmt.Errorf("create order: no order id returned")
+	}
+
+	order := &model.NewOrder{
+		OrderId:   result.TxID[0],
+		Symbol:    symbol,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}
+	if argsOpts.ClientOrderID != nil {
+		order.ClientOrderID = *argsOpts.ClientOrderID
+	}
+	s.kraken.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *KrakenSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *KrakenSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// CancelOrder 撤销订单（/0/private/CancelOrder），orderId 为空时按 option.WithClientOrderID 撤销
+func (s *KrakenSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	body := map[string]interface{}{"txid": orderId}
+	if orderId == "" && option.StringPresent(argsOpts.ClientOrderID) {
+		body = map[string]interface{}{"cl_ord_id": *argsOpts.ClientOrderID}
+	}
+
+	var result krakenCancelOrderResponse
+	if err := s.kraken.privatePost(ctx, "/0/private/CancelOrder", body, &result); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	if result.Count == 0 {
+		return fmt.Errorf("cancel order %s: %w", orderId, common.ErrOrderNotFound)
+	}
+	s.kraken.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *KrakenSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+func (s *KrakenSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order")
+}
+
+func (s *KrakenSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order by client id")
+}
+
+func (s *KrakenSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (s *KrakenSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (s *KrakenSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+func (s *KrakenSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+func (s *KrakenSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *KrakenSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *KrakenSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+func (s *KrakenSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+func (s *KrakenSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+func (s *KrakenSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+func (s *KrakenSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+func (s *KrakenSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+func (s *KrakenSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+func (s *KrakenSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+var _ exchange.SpotExchange = (*KrakenSpot)(nil)
@@ -0,0 +1,290 @@
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+// testSecret Kraken 文档示例中的 secretKey（base64）
+const testSecret = "kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg=="
+
+const testAssetPairs = `{"error":[],"result":{
+	"XXBTZUSD":{"altname":"XBTUSD","wsname":"XBT/USD","base":"XXBT","quote":"ZUSD","pair_decimals":1,"lot_decimals":8,
+		"ordermin":"0.0001","costmin":"0.5","tick_size":"0.1","status":"online"},
+	"XDGUSD":{"altname":"XDGUSD","wsname":"XDG/USD","base":"XXDG","quote":"ZUSD","pair_decimals":7,"lot_decimals":8,
+		"ordermin":"50","costmin":"0.5","tick_size":"0.0000001","status":"online"},
+	"SOLUSD":{"altname":"SOLUSD","wsname":"SOL/USD","base":"SOL","quote":"ZUSD","pair_decimals":2,"lot_decimals":8,
+		"ordermin":"0.02","costmin":"0.5","tick_size":"0.01","status":"cancel_only"},
+	"XXBTZUSD.d":{"altname":"XBTUSD.d","base":"XXBT","quote":"ZUSD","pair_decimals":1,"lot_decimals":8,"status":"online"}
+}}`
+
+// newTestKraken 创建请求指向 handler 的 Kraken 实例，AssetPairs 返回 testAssetPairs
+func newTestKraken(t *testing.T, handler http.HandlerFunc) *Kraken {
+	t.Helper()
+	return exchangetest.New[*Kraken](t, exchangetest.Venue{
+		New:         NewKraken,
+		SecretKey:   testSecret,
+		MarketsPath: "/0/public/AssetPairs",
+		Markets:     testAssetPairs,
+	}, handler)
+}
+
+func TestKrakenSpot_LoadMarkets(t *testing.T) {
+	k := newTestKraken(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	markets, _ := k.Spot().GetMarkets()
+	if len(markets) != 3 {
+		t.Fatalf("got %d markets, want 3 (dark pool pair skipped)", len(markets))
+	}
+
+	tests := []struct {
+		symbol, id, base, quote string
+		active                  bool
+		tick                    string
+	}{
+		{"BTC/USD", "XXBTZUSD", "BTC", "USD", true, "0.1"},
+		{"DOGE/USD", "XDGUSD", "DOGE", "USD", true, "0.0000001"},
+		{"SOL/USD", "SOLUSD", "SOL", "USD", false, "0.01"},
+	}
+	for _, tt := range tests {
+		market, err := k.Spot().GetMarket(tt.symbol)
+		if err != nil {
+			t.Errorf("GetMarket(%s): %v", tt.symbol, err)
+			continue
+		}
+		if market.ID != tt.id || market.Base != tt.base || market.Quote != tt.quote || market.Active != tt.active ||
+			!market.Precision.TickSize.Equal(decimal.RequireFromString(tt.tick)) {
+			t.Errorf("market %s = %+v", tt.symbol, market)
+		}
+		if byID, err := k.Spot().GetMarketByID(tt.id); err != nil || byID != market {
+			t.Errorf("GetMarketByID(%s) = %v, %v", tt.id, byID, err)
+		}
+	}
+}
+
+func TestKrakenSpot_FetchTickerOHLCV(t *testing.T) {
+	k := newTestKraken(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/0/public/Ticker" && r.URL.Query().Get("pair") == "XXBTZUSD":
+			w.Write([]byte(`{"error":[],"result":{"XXBTZUSD":{"a":["30300.10000","1","1.000"],"b":["30300.00000","2","2.000"],
+				"c":["30303.20000","0.00067643"],"v":["4083.67001100","4412.73601799"],"p":["30706.77771","30689.13205"],
+				"t":[34619,38907],"l":["29868.30000","29868.30000"],"h":["31631.00000","31631.00000"],"o":"30502.80000"}}}`))
+		case r.URL.Path == "/0/public/OHLC" && r.URL.Query().Get("interval") == "60":
+			if got := r.URL.Query().Get("since"); got != "1688670000" {
+				t.Errorf("since = %s, want 1688670000", got)
+			}
+			w.Write([]byte(`{"error":[],"result":{"XXBTZUSD":[
+				[1688670000,"30306.1","30306.2","30305.7","30305.7","30306.1","3.39243896",23],
+				[1688673600,"30305.7","30310.0","30300.0","30309.9","30305.4","1.25",10]
+			],"last":1688673600}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ticker, err := k.Spot().FetchTicker(ctx, "BTC/USD")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Symbol != "BTC/USD" || ticker.Bid.String() != "30300" || ticker.Ask.String() != "30300.1" ||
+		ticker.Last.String() != "30303.2" || ticker.Volume.String() != "4412.73601799" || ticker.TradeCount != 38907 {
+		t.Errorf("ticker = %+v", ticker)
+	}
+
+	ohlcvs, err := k.Spot().FetchOHLCVs(ctx, "BTC/USD", "1h", option.WithSince(time.Unix(1688670001, 0)), option.WithLimit(1))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Timestamp.Unix() != 1688673600 || ohlcvs[0].Close.String() != "30309.9" || ohlcvs[0].Volume.String() != "1.25" {
+		t.Errorf("ohlcvs = %v, want the latest candle only", ohlcvs)
+	}
+
+	if _, err := k.Spot().FetchOHLCVs(ctx, "BTC/USD", "2h"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("unsupported timeframe: err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestKrakenSpot_CreateOrder(t *testing.T) {
+	var body map[string]interface{}
+	k := newTestKraken(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		body = nil
+		json.Unmarshal(raw, &body)
+
+		// 签名覆盖实际发送的请求体
+		var payload struct {
+			Nonce int64 `json:"nonce"`
+		}
+		json.Unmarshal(raw, &payload)
+		want, _ := NewSigner(testSecret).Sign(r.URL.Path, payload.Nonce, string(raw))
+		if r.Header.Get("API-Key") != "key" || r.Header.Get("API-Sign") != want {
+			t.Errorf("headers API-Key=%q API-Sign=%q, want signature %q", r.Header.Get("API-Key"), r.Header.Get("API-Sign"), want)
+		}
+
+		switch r.URL.Path {
+		case "/0/private/AddOrder":
+			if body["volume"] == "1000" {
+				w.Write([]byte(`{"error":["EOrder:Insufficient funds"]}`))
+				return
+			}
+			w.Write([]byte(`{"error":[],"result":{"descr":{"order":"buy 1.25 XBTUSD @ limit 37500.0"},"txid":["OUF4EM-FRGI2-MQMWZD"]}}`))
+		case "/0/private/CancelOrder":
+			w.Write([]byte(`{"error":[],"result":{"count":1}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	order, err := k.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "1.25", option.WithPrice("37500.04"), option.WithPostOnly(true), option.WithClientOrderID("my-order-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "OUF4EM-FRGI2-MQMWZD" || order.ClientOrderID != "my-order-1" {
+		t.Errorf("order = %+v", order)
+	}
+	if body["pair"] != "XXBTZUSD" || body["type"] != "buy" || body["ordertype"] != "limit" || body["price"] != "37500" ||
+		body["volume"] != "1.25" || body["oflags"] != "post" || body["cl_ord_id"] != "my-order-1" {
+		t.Errorf("order body = %v", body)
+	}
+
+	if _, err := k.Spot().CreateOrder(ctx, "BTC/USD", option.Sell, "0.5"); err != nil {
+		t.Fatalf("market CreateOrder: %v", err)
+	}
+	if body["ordertype"] != "market" || body["type"] != "sell" || body["price"] != nil {
+		t.Errorf("market order body = %v", body)
+	}
+
+	if _, err := k.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "1000"); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("insufficient funds: err = %v, want ErrInsufficientFunds", err)
+	}
+
+	if err := k.Spot().CancelOrder(ctx, "BTC/USD", "OUF4EM-FRGI2-MQMWZD"); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if body["txid"] != "OUF4EM-FRGI2-MQMWZD" {
+		t.Errorf("cancel body = %v", body)
+	}
+}
+
+func TestKrakenSpot_FetchBalance(t *testing.T) {
+	k := newTestKraken(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0/private/BalanceEx" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"error":[],"result":{
+			"XXBT":{"balance":"1.5","hold_trade":"0.5"},
+			"ZUSD":{"balance":"1000.25","hold_trade":"0"},
+			"XXDG":{"balance":"200","hold_trade":"0"}
+		}}`))
+	})
+
+	balances, err := k.Spot().FetchBalance(context.Background())
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	want := []struct{ currency, available, locked string }{
+		{"BTC", "1", "0.5"},
+		{"DOGE", "200", "0"},
+		{"USD", "1000.25", "0"},
+	}
+	if len(balances) != len(want) {
+		t.Fatalf("got %d balances, want %d", len(balances), len(want))
+	}
+	for i, w := range want {
+		b := balances[i]
+		if b.Currency != w.currency || b.Available.String() != w.available || b.Locked.String() != w.locked {
+			t.Errorf("balance %d = %s %s/%s, want %s %s/%s", i, b.Currency, b.Available.String(), b.Locked.String(), w.currency, w.available, w.locked)
+		}
+	}
+
+	if _, err := k.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFutures)); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("futures balance: err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestKrakenCurrency(t *testing.T) {
+	tests := []struct{ asset, want string }{
+		{"XXBT", "BTC"},
+		{"XBT", "BTC"},
+		{"XXDG", "DOGE"},
+		{"xdg", "DOGE"},
+		{"XETH", "ETH"},
+		{"XZEC", "ZEC"},
+		{"ZUSD", "USD"},
+		{"ZEUR", "EUR"},
+		// 以 X/Z 开头但不是旧代码的资产保持不变
+		{"XTZ", "XTZ"},
+		{"ZRX", "ZRX"},
+		{"ZEUS", "ZEUS"},
+		{"SOL", "SOL"},
+	}
+	for _, tt := range tests {
+		if got := krakenCurrency(tt.asset); got != tt.want {
+			t.Errorf("krakenCurrency(%q) = %q, want %q", tt.asset, got, tt.want)
+		}
+	}
+}
+
+// TestKrakenSpot_ErrorArray Kraken 在 error 数组中返回错误（HTTP 状态码通常为 200），第一个错误决定错误码
+func TestKrakenSpot_ErrorArray(t *testing.T) {
+	k := newTestKraken(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/0/private/CancelOrder":
+			w.Write([]byte(`{"error":["EOrder:Unknown order","EGeneral:Internal error"]}`))
+		case "/0/private/BalanceEx":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":["EAPI:Invalid key"]}`))
+		case "/0/private/AddOrder":
+			w.Write([]byte(`{"error":["EGeneral:Invalid arguments:volume"]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	err := k.Spot().CancelOrder(ctx, "BTC/USD", "OUF4EM-FRGI2-MQMWZD")
+	var exErr *common.ExchangeError
+	if !errors.Is(err, common.ErrOrderNotFound) || !errors.As(err, &exErr) {
+		t.Fatalf("CancelOrder = %v, want ErrOrderNotFound", err)
+	}
+	if exErr.Code != "EOrder:Unknown order" || exErr.Message != "EOrder:Unknown order; EGeneral:Internal error" {
+		t.Errorf("error = %+v", exErr)
+	}
+
+	if _, err := k.Spot().FetchBalance(ctx); !errors.Is(err, common.ErrAuthenticationFailed) {
+		t.Errorf("FetchBalance = %v, want ErrAuthenticationFailed", err)
+	}
+
+	// 未知错误保留原始错误码
+	_, err = k.Spot().CreateOrder(ctx, "BTC/USD", option.Buy, "1")
+	if !errors.As(err, &exErr) || exErr.Code != "EGeneral:Invalid arguments:volume" || exErr.Err != nil {
+		t.Errorf("CreateOrder = %v, want unmapped ExchangeError", err)
+	}
+}
+
+func TestKraken_NextNonce(t *testing.T) {
+	k := &Kraken{clock: &common.Clock{}}
+	k.nonce.Store(k.clock.Timestamp() + 1000)
+	first := k.nextNonce()
+	if second := k.nextNonce(); second != first+1 {
+		t.Errorf("nonce = %d then %d, want strictly increasing", first, second)
+	}
+}
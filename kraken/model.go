@@ -0,0 +1,273 @@
+package kraken
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+)
+
+// krakenResponse Kraken 响应外层结构，error 非空表示请求失败
+type krakenResponse struct {
+	Error  []string        `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// krakenTimeResponse Kraken 服务器时间
+type krakenTimeResponse struct {
+	UnixTime int64  `json:"unixtime"` // 服务器时间（秒）
+	RFC1123  string `json:"rfc1123"`
+}
+
+// krakenSystemStatusResponse Kraken 系统状态
+type krakenSystemStatusResponse struct {
+	Status    string            `json:"status"`    // online/maintenance/cancel_only/post_only
+	Timestamp types.ExTimestamp `json:"timestamp"` // 状态时间（RFC3339）
+}
+
+// krakenAssetPair Kraken 交易对信息（/0/public/AssetPairs，结果按交易对名称索引）
+type krakenAssetPair struct {
+	Altname      string          `json:"altname"`       // 交易对别名，如 XBTUSD
+	WSName       string          `json:"wsname"`        // WebSocket 名称，如 XBT/USD
+	Base         string          `json:"base"`          // 基础资产代码，如 XXBT
+	Quote        string          `json:"quote"`         // 计价资产代码，如 ZUSD
+	PairDecimals int             `json:"pair_decimals"` // 价格精度
+	LotDecimals  int             `json:"lot_decimals"`  // 数量精度
+	OrderMin     types.ExDecimal `json:"ordermin"`      // 最小下单量
+	CostMin      types.ExDecimal `json:"costmin"`       // 最小下单金额
+	TickSize     types.ExDecimal `json:"tick_size"`     // 价格最小变动单位
+	Status       string          `json:"status"`        // online/cancel_only/post_only/limit_only/reduce_only
+}
+
+// krakenTicker Kraken 行情（/0/public/Ticker，结果按交易对名称索引）
+// 数组字段: a/b 为 [价格, 整手数量, 数量]，c 为 [价格, 数量]，v/p/t/l/h 为 [今日, 最近24小时]
+type krakenTicker struct {
+	Ask    []types.ExDecimal `json:"a"`
+	Bid    []types.ExDecimal `json:"b"`
+	Close  []types.ExDecimal `json:"c"`
+	Volume []types.ExDecimal `json:"v"`
+	VWAP   []types.ExDecimal `json:"p"`
+	Trades []int64           `json:"t"`
+	Low    []types.ExDecimal `json:"l"`
+	High   []types.ExDecimal `json:"h"`
+	Open   types.ExDecimal   `json:"o"`
+}
+
+// toTicker 转换为统一的行情结构，24小时统计取最近24小时的值
+func (t krakenTicker) toTicker(symbol string) *model.Ticker {
+	at := func(values []types.ExDecimal, i int) types.ExDecimal {
+		if i < len(values) {
+			return values[i]
+		}
+		return types.ExDecimal{}
+	}
+	ticker := &model.Ticker{
+		Symbol:    symbol,
+		Bid:       at(t.Bid, 0),
+		Ask:       at(t.Ask, 0),
+		Last:      at(t.Close, 0),
+		Open:      t.Open,
+		High:      at(t.High, 1),
+		Low:       at(t.Low, 1),
+		Volume:    at(t.Volume, 1),
+		VWAP:      at(t.VWAP, 1),
+		Timestamp: types.ExTimestamp{Time: time.Now()}, // Kraken 行情接口没有返回时间戳
+	}
+	ticker.QuoteVolume = types.ExDecimal{Decimal: ticker.Volume.Mul(ticker.VWAP.Decimal)}
+	if len(t.Trades) > 1 {
+		ticker.TradeCount = t.Trades[1]
+	}
+	return ticker
+}
+
+// krakenOrderBook Kraken 深度（/0/public/Depth），档位为 [价格, 数量, 时间戳]
+type krakenOrderBook struct {
+	Asks [][]types.ExDecimal `json:"asks"`
+	Bids [][]types.ExDecimal `json:"bids"`
+}
+
+// krakenKline Kraken K线 [time, open, high, low, close, vwap, volume, count]
+type krakenKline struct {
+	Time   types.ExTimestamp
+	Open   types.ExDecimal
+	High   types.ExDecimal
+	Low    types.ExDecimal
+	Close  types.ExDecimal
+	Volume types.ExDecimal
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化，解析数组格式
+func (k *krakenKline) UnmarshalJSON(data []byte) error {
+	var arr []json.RawMessage
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	if len(arr) < 7 {
+		return fmt.Errorf("invalid kline array length: %d", len(arr))
+	}
+	fields := []json.Unmarshaler{&k.Time, &k.Open, &k.High, &k.Low, &k.Close, nil, &k.Volume}
+	for i, field := range fields {
+		if field == nil {
+			continue
+		}
+		if err := field.UnmarshalJSON(arr[i]); err != nil {
+			return fmt.Errorf("parse kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// krakenBalance Kraken 扩展余额（/0/private/BalanceEx，结果按资产代码索引）
+type krakenBalance struct {
+	Balance   types.ExDecimal `json:"balance"`    // 总余额
+	HoldTrade types.ExDecimal `json:"hold_trade"` // 挂单冻结
+}
+
+// krakenAddOrderResponse Kraken 下单响应
+type krakenAddOrderResponse struct {
+	Descr struct {
+		Order string `json:"order"` // 订单描述
+	} `json:"descr"`
+	TxID []string `json:"txid"` // 订单ID
+}
+
+// krakenCancelOrderResponse Kraken 撤单响应
+type krakenCancelOrderResponse struct {
+	Count int `json:"count"` // 撤销的订单数
+}
+
+// ========== 合约（Futures API） ==========
+
+// krakenFuturesResponse Kraken 合约响应外层结构，result 为 error 时 error 为错误码
+type krakenFuturesResponse struct {
+	Result     string            `json:"result"`     // success/error
+	Error      string            `json:"error"`      // 错误码
+	ServerTime types.ExTimestamp `json:"serverTime"` // 服务器时间（RFC3339）
+}
+
+// krakenFuturesInstrumentsResponse Kraken 合约列表（/derivatives/api/v3/instruments）
+type krakenFuturesInstrumentsResponse struct {
+	Instruments []krakenFuturesInstrument `json:"instruments"`
+}
+
+// krakenFuturesInstrument Kraken 合约信息
+type krakenFuturesInstrument struct {
+	Symbol                      string          `json:"symbol"`                      // 合约ID，如 PF_XBTUSD
+	Type                        string          `json:"type"`                        // 合约类型，多币种保证金合约为 flexible_futures
+	Base                        string          `json:"base"`                        // 基础货币，如 XBT
+	Quote                       string          `json:"quote"`                       // 计价货币，如 USD
+	Tradeable                   bool            `json:"tradeable"`                   // 是否可交易
+	TickSize                    types.ExDecimal `json:"tickSize"`                    // 价格最小变动单位
+	ContractSize                types.ExDecimal `json:"contractSize"`                // 合约面值（PF 合约为 1 个基础货币）
+	ContractValueTradePrecision int             `json:"contractValueTradePrecision"` // 数量精度（可为负数，如 -1 表示 10 的整数倍）
+	MaxPositionSize             types.ExDecimal `json:"maxPositionSize"`             // 最大持仓数量
+	LastTradingTime             string          `json:"lastTradingTime"`             // 最后交易时间（仅交割合约）
+	MarginLevels                []struct {
+		InitialMargin     types.ExDecimal `json:"initialMargin"`     // 初始保证金率
+		MaintenanceMargin types.ExDecimal `json:"maintenanceMargin"` // 维持保证金率
+	} `json:"marginLevels"` // 阶梯保证金，第一档对应最大杠杆
+}
+
+// krakenFuturesTickersResponse Kraken 合约行情（/derivatives/api/v3/tickers）
+type krakenFuturesTickersResponse struct {
+	Tickers    []krakenFuturesTicker `json:"tickers"`
+	ServerTime types.ExTimestamp     `json:"serverTime"`
+}
+
+// krakenFuturesTicker Kraken 合约行情
+type krakenFuturesTicker struct {
+	Symbol      string          `json:"symbol"`      // 合约ID
+	Last        types.ExDecimal `json:"last"`        // 最新价
+	Bid         types.ExDecimal `json:"bid"`         // 买一价
+	Ask         types.ExDecimal `json:"ask"`         // 卖一价
+	Open24h     types.ExDecimal `json:"open24h"`     // 24小时开盘价
+	High24h     types.ExDecimal `json:"high24h"`     // 24小时最高价
+	Low24h      types.ExDecimal `json:"low24h"`      // 24小时最低价
+	Vol24h      types.ExDecimal `json:"vol24h"`      // 24小时成交量（基础货币）
+	VolumeQuote types.ExDecimal `json:"volumeQuote"` // 24小时成交额（计价货币）
+	MarkPrice   types.ExDecimal `json:"markPrice"`   // 标记价格
+	IndexPrice  types.ExDecimal `json:"indexPrice"`  // 指数价格
+	Suspended   bool            `json:"suspended"`   // 是否暂停交易
+}
+
+// toTicker 转换为统一的行情结构，Kraken 合约行情没有单独的时间戳，使用响应的服务器时间
+func (t krakenFuturesTicker) toTicker(symbol string, serverTime types.ExTimestamp) *model.Ticker {
+	return &model.Ticker{
+		Symbol:      symbol,
+		Bid:         t.Bid,
+		Ask:         t.Ask,
+		Last:        t.Last,
+		Open:        t.Open24h,
+		High:        t.High24h,
+		Low:         t.Low24h,
+		Volume:      t.Vol24h,
+		QuoteVolume: t.VolumeQuote,
+		MarkPrice:   t.MarkPrice,
+		IndexPrice:  t.IndexPrice,
+		Timestamp:   serverTime,
+	}
+}
+
+// krakenFuturesOrderBookResponse Kraken 合约深度（/derivatives/api/v3/orderbook），档位为 [价格, 数量]
+type krakenFuturesOrderBookResponse struct {
+	OrderBook struct {
+		Bids [][]types.ExDecimal `json:"bids"`
+		Asks [][]types.ExDecimal `json:"asks"`
+	} `json:"orderBook"`
+	ServerTime types.ExTimestamp `json:"serverTime"`
+}
+
+// krakenFuturesPositionsResponse Kraken 合约持仓（/derivatives/api/v3/openpositions）
+type krakenFuturesPositionsResponse struct {
+	OpenPositions []krakenFuturesPosition `json:"openPositions"`
+}
+
+// krakenFuturesPosition Kraken 合约持仓（单向持仓，每个合约最多一个持仓）
+type krakenFuturesPosition struct {
+	Symbol           string            `json:"symbol"`           // 合约ID
+	Side             string            `json:"side"`             // long/short
+	Price            types.ExDecimal   `json:"price"`            // 开仓均价
+	Size             types.ExDecimal   `json:"size"`             // 持仓数量
+	FillTime         types.ExTimestamp `json:"fillTime"`         // 最近一次成交时间
+	MaxFixedLeverage types.ExDecimal   `json:"maxFixedLeverage"` // 逐仓杠杆（仅逐仓持仓返回）
+}
+
+// krakenFuturesSendOrderResponse Kraken 合约下单响应（/derivatives/api/v3/sendorder）
+type krakenFuturesSendOrderResponse struct {
+	SendStatus struct {
+		OrderID string `json:"order_id"` // 订单ID
+		Status  string `json:"status"`   // placed/partiallyFilled/filled 表示成功，其他为拒绝原因
+	} `json:"sendStatus"`
+}
+
+// krakenFuturesCancelOrderResponse Kraken 合约撤单响应（/derivatives/api/v3/cancelorder）
+type krakenFuturesCancelOrderResponse struct {
+	CancelStatus struct {
+		OrderID string `json:"order_id"` // 订单ID
+		Status  string `json:"status"`   // cancelled 表示成功，filled/notFound 表示订单已结束或不存在
+	} `json:"cancelStatus"`
+}
+
+// krakenFuturesOrderStatusResponse Kraken 合约订单状态（/derivatives/api/v3/orders/status）
+type krakenFuturesOrderStatusResponse struct {
+	Orders []krakenFuturesOrderStatus `json:"orders"`
+}
+
+// krakenFuturesOrderStatus Kraken 合约订单及状态
+type krakenFuturesOrderStatus struct {
+	Order struct {
+		OrderID             string            `json:"orderId"`             // 订单ID
+		CliOrdID            string            `json:"cliOrdId"`            // 客户端订单ID
+		Symbol              string            `json:"symbol"`              // 合约ID
+		Side                string            `json:"side"`                // buy/sell
+		Quantity            types.ExDecimal   `json:"quantity"`            // 下单数量
+		Filled              types.ExDecimal   `json:"filled"`              // 已成交数量
+		LimitPrice          types.ExDecimal   `json:"limitPrice"`          // 限价（市价单为空）
+		ReduceOnly          bool              `json:"reduceOnly"`          // 是否只减仓
+		Timestamp           types.ExTimestamp `json:"timestamp"`           // 创建时间
+		LastUpdateTimestamp types.ExTimestamp `json:"lastUpdateTimestamp"` // 更新时间
+	} `json:"order"`
+	Status string `json:"status"` // ENTERED_BOOK/FULLY_EXECUTED/REJECTED/CANCELLED/TRIGGER_PLACED/TRIGGER_ACTIVATED
+}
@@ -0,0 +1,61 @@
+package kraken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// Signer Kraken 签名工具
+type Signer struct {
+	secret []byte
+	err    error // secretKey 不是合法的 base64 时的错误，签名时返回
+}
+
+// NewSigner 创建签名工具，Kraken 的 secretKey 为 base64 编码
+func NewSigner(secretKey string) *Signer {
+	secret, err := base64.StdEncoding.DecodeString(secretKey)
+	if err != nil {
+		err = fmt.Errorf("decode kraken secret key: %w", err)
+	}
+	return &Signer{
+		secret: secret,
+		err:    err,
+	}
+}
+
+// Sign Kraken 签名方法
+// path: API 路径（如 /0/private/AddOrder）
+// nonce: 请求体中的 nonce
+// postData: 请求体（与发送的内容完全一致）
+// 签名: base64(HMAC-SHA512(path + SHA256(nonce + postData), base64decode(secretKey)))
+func (s *Signer) Sign(path string, nonce int64, postData string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	hash := sha256.Sum256([]byte(strconv.FormatInt(nonce, 10) + postData))
+
+	mac := hmac.New(sha512.New, s.secret)
+	mac.Write([]byte(path))
+	mac.Write(hash[:])
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// SignFutures Kraken 合约（Futures API）签名方法
+// endpointPath: 去掉 /derivatives 前缀的 API 路径（如 /api/v3/sendorder）
+// nonce: 请求头 Nonce 的值
+// postData: URL 编码的请求参数（与发送的查询字符串完全一致）
+// 签名: base64(HMAC-SHA512(SHA256(postData + nonce + endpointPath), base64decode(secretKey)))
+func (s *Signer) SignFutures(endpointPath string, nonce int64, postData string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	hash := sha256.Sum256([]byte(postData + strconv.FormatInt(nonce, 10) + endpointPath))
+
+	mac := hmac.New(sha512.New, s.secret)
+	mac.Write(hash[:])
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,44 @@
+package kraken
+
+import "testing"
+
+// TestSigner_Sign 使用 Kraken 文档中的签名示例
+func TestSigner_Sign(t *testing.T) {
+	signer := NewSigner("kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg==")
+	postData := "nonce=1616492376594&ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25"
+
+	got, err := signer.Sign("/0/private/AddOrder", 1616492376594, postData)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	want := "4/dpxb3iT4tp/ZCVEwSnEsLxx0bqyhLpdfOpc6fn7OR8+UClSV5n9E6aSS8MPtnRfp32bAb0nmbRn6H8ndwLUQ=="
+	if got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+
+	if _, err := NewSigner("not base64!").Sign("/0/private/Balance", 1, "nonce=1"); err == nil {
+		t.Error("Sign with invalid secret: want error")
+	}
+}
+
+// TestSigner_SignFutures 合约签名：base64(HMAC-SHA512(SHA256(postData + nonce + endpointPath)))
+func TestSigner_SignFutures(t *testing.T) {
+	signer := NewSigner("kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg==")
+	postData := "limitPrice=67000&orderType=lmt&side=buy&size=0.1&symbol=PF_XBTUSD"
+
+	got, err := signer.SignFutures("/api/v3/sendorder", 1616492376594, postData)
+	if err != nil {
+		t.Fatalf("SignFutures: %v", err)
+	}
+	want := "8FVVUoZ4k3Nxi+O6w4FN8sdZAxiIKjnB3jwFNV4oeCYc7T66n90wVZnFR/p61hxCQQqRmI2SE18Adnmb0TctxA=="
+	if got != want {
+		t.Errorf("SignFutures = %s, want %s", got, want)
+	}
+	if other, _ := signer.SignFutures("/api/v3/cancelorder", 1616492376594, postData); other == got {
+		t.Error("SignFutures ignores endpoint path")
+	}
+
+	if _, err := NewSigner("not base64!").SignFutures("/api/v3/openpositions", 1, ""); err == nil {
+		t.Error("SignFutures with invalid secret: want error")
+	}
+}
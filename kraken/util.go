@@ -0,0 +1,86 @@
+package kraken
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// krakenLegacyAssets Kraken 早期资产使用 X（加密货币）/Z（法币）前缀的四位代码，去掉前缀后为通用代码
+var krakenLegacyAssets = map[string]string{
+	"XXBT": "XBT",
+	"XETH": "ETH",
+	"XETC": "ETC",
+	"XLTC": "LTC",
+	"XXRP": "XRP",
+	"XXLM": "XLM",
+	"XXMR": "XMR",
+	"XZEC": "ZEC",
+	"XXDG": "XDG",
+	"XMLN": "MLN",
+	"XREP": "REP",
+	"ZUSD": "USD",
+	"ZEUR": "EUR",
+	"ZGBP": "GBP",
+	"ZJPY": "JPY",
+	"ZCAD": "CAD",
+	"ZAUD": "AUD",
+}
+
+// krakenCurrencyAliases Kraken 专有币种代码到通用代码的映射
+var krakenCurrencyAliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+// krakenCurrency 将 Kraken 资产代码转换为标准化币种代码（XXBT -> BTC、XXDG -> DOGE、ZUSD -> USD）
+func krakenCurrency(asset string) string {
+	asset = strings.ToUpper(asset)
+	if code, ok := krakenLegacyAssets[asset]; ok {
+		asset = code
+	}
+	if alias, ok := krakenCurrencyAliases[asset]; ok {
+		return alias
+	}
+	return asset
+}
+
+// krakenSymbol 将 Kraken 交易对转换为标准化格式，优先使用 wsname（如 XBT/USD -> BTC/USD）
+func krakenSymbol(pair krakenAssetPair) string {
+	if base, quote, ok := strings.Cut(pair.WSName, "/"); ok {
+		return common.NormalizeSymbol(krakenCurrency(base), krakenCurrency(quote))
+	}
+	return common.NormalizeSymbol(krakenCurrency(pair.Base), krakenCurrency(pair.Quote))
+}
+
+// krakenTimeframe 转换为 Kraken K线周期（分钟数），不支持的周期原样返回
+func krakenTimeframe(timeframe string) string {
+	switch common.NormalizeTimeframe(timeframe) {
+	case "1m":
+		return "1"
+	case "5m":
+		return "5"
+	case "15m":
+		return "15"
+	case "30m":
+		return "30"
+	case "1h":
+		return "60"
+	case "4h":
+		return "240"
+	case "1d":
+		return "1440"
+	case "1w":
+		return "10080"
+	case "15d":
+		return "21600"
+	default:
+		return timeframe
+	}
+}
+
+// notSupported Kraken 适配器不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for kraken", operation, common.ErrNotSupported)
+}
@@ -0,0 +1,32 @@
+package kucoin
+
+import (
+	"encoding/json"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// kucoinErrorCodes KuCoin 错误码到统一错误的映射
+var kucoinErrorCodes = map[string]error{
+	"200004": common.ErrInsufficientFunds,    // 余额不足
+	"429000": common.ErrRateLimitExceeded,    // 请求频率超限
+	"400003": common.ErrAuthenticationFailed, // API Key 不存在
+	"400004": common.ErrAuthenticationFailed, // Passphrase 错误
+	"400005": common.ErrAuthenticationFailed, // 签名无效
+}
+
+// newKuCoinError 根据 code/msg 创建交易所错误
+func newKuCoinError(code, msg string) *common.ExchangeError {
+	return common.NewExchangeError(kucoinName, code, msg, kucoinErrorCodes)
+}
+
+// parseKuCoinError 解析 KuCoin 非 2xx 响应体 {"code":"429000","msg":"..."}
+func parseKuCoinError(httpErr *common.HTTPError) error {
+	var body kucoinResponse
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Code == "" || body.Code == kucoinSuccessCode {
+		return nil
+	}
+	e := newKuCoinError(body.Code, body.Msg)
+	e.Cause = httpErr
+	return e
+}
@@ -0,0 +1,274 @@
+package kucoin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+)
+
+// KuCoin KuCoin 交易所实现
+type KuCoin struct {
+	client              *Client
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
+	spot                *KuCoinSpot
+	perp                *common.UnsupportedPerp  // 永续合约占位实现（未接入）
+	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
+	spotMarketsByID     map[string]*model.Market // 现货市场信息（原始格式索引）
+	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+}
+
+// NewKuCoin 创建 KuCoin 交易所实例，options["password"] 为创建 API Key 时设置的 passphrase
+func NewKuCoin(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+	passphrase := ""
+	if v, ok := options["password"].(string); ok {
+		passphrase = v
+	}
+
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
+	kucoin := &KuCoin{
+		client:              client,
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		kucoin.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(kucoin.lifecycle)
+
+	kucoin.UpdateCredentials(apiKey, secretKey, passphrase)
+
+	// 初始化现货和合约实现
+	kucoin.spot = NewKuCoinSpot(kucoin)
+	kucoin.perp = common.NewUnsupportedPerp(kucoinName)
+
+	if v, ok := options["timeSync"].(bool); ok && v {
+		kucoin.clock.Start(common.TimeSyncInterval, kucoin.FetchTime)
+	}
+
+	return kucoin, nil
+}
+
+// Spot 返回现货交易接口
+func (k *KuCoin) Spot() exchange.SpotExchange {
+	return k.spot
+}
+
+// Perp 返回永续合约交易接口（KuCoin 合约使用独立的 Futures API，暂不支持）
+func (k *KuCoin) Perp() exchange.PerpExchange {
+	return k.perp
+}
+
+// Name 返回交易所名称
+func (k *KuCoin) Name() string {
+	return kucoinName
+}
+
+// Has 返回 KuCoin 支持的功能
+func (k *KuCoin) Has() model.Capabilities {
+	return kucoinCapabilities
+}
+
+// Symbols 返回已加载的现货市场的标准化 symbol，按字母排序（未接入合约）
+func (k *KuCoin) Symbols() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarketSymbols(k.spotMarketsBySymbol, nil)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (k *KuCoin) NormalizeSymbol(nativeID string) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, k.spotMarketsByID, nil, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (k *KuCoin) DenormalizeSymbol(symbol string) (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, k.spotMarketsBySymbol, nil, nil)
+}
+
+// ExportMarkets 导出已加载的现货市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (k *KuCoin) ExportMarkets() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return common.MarshalMarkets(kucoinName, k.spotMarketsBySymbol, nil)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换已加载的现货市场（导出时为空则保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (k *KuCoin) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(kucoinName, data)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		k.spotMarketsBySymbol, k.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		k.marketCache.Touch(model.MarketTypeSpot)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后已建立的轮询订阅通道随之关闭
+func (k *KuCoin) Drain(ctx context.Context) error {
+	k.clock.Stop()
+	return k.lifecycle.Drain(ctx, k.spot, k.perp)
+}
+
+// FetchTime 获取交易所服务器时间
+func (k *KuCoin) FetchTime(ctx context.Context) (time.Time, error) {
+	var result kucoinTimeResponse
+	if err := k.publicGet(ctx, "/api/v1/timestamp", nil, &result); err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+	return time.UnixMilli(int64(result)), nil
+}
+
+// FetchStatus 获取系统状态（/api/v1/status），open 以外的状态（close、cancelonly）视为维护中
+func (k *KuCoin) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	var result kucoinStatusResponse
+	if err := k.publicGet(ctx, "/api/v1/status", nil, &result); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+
+	status := common.OKStatus()
+	if result.Status != "open" {
+		status.Status = model.ExchangeStatusMaintenance
+	}
+	return status, nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (k *KuCoin) Ping(ctx context.Context) error {
+	return common.Ping(ctx, k.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (k *KuCoin) VerifyCredentials(ctx context.Context) error {
+	creds := k.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", k.spot.FetchBalance)
+}
+
+// kucoinCapabilities KuCoin 支持的功能
+var kucoinCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported: true,
+	},
+	Perp: model.MarketCapabilities{},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// password 为 API Key 的 passphrase
+func (k *KuCoin) UpdateCredentials(apiKey, secretKey, password string) {
+	k.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(secretKey, password),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (k *KuCoin) credentials() *credentials {
+	return k.creds.Load()
+}
+
+// publicGet 请求公共接口并解析 data
+func (k *KuCoin) publicGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := k.client.HTTPClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	return parseKuCoinResponse(resp, result)
+}
+
+// signAndRequest 签名并发送请求（KuCoin API Key v2），GET/DELETE 签名包含查询字符串，POST 签名包含 JSON 请求体
+func (k *KuCoin) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}, result interface{}) error {
+	creds := k.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	endpoint := path
+	if query := common.BuildQueryString(params); query != "" {
+		endpoint += "?" + query
+	}
+	bodyStr := ""
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal body: %w", err)
+		}
+		bodyStr = string(bodyBytes)
+	}
+
+	// 签名（使用同一个 timestamp 确保签名和请求头一致）
+	timestamp := k.clock.Timestamp()
+	headers := map[string]string{
+		"KC-API-KEY":         creds.apiKey,
+		"KC-API-SIGN":        creds.signer.SignRequest(timestamp, method, endpoint, bodyStr),
+		"KC-API-TIMESTAMP":   strconv.FormatInt(timestamp, 10),
+		"KC-API-PASSPHRASE":  creds.signer.Passphrase(),
+		"KC-API-KEY-VERSION": "2",
+	}
+
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
+	resp, err := k.client.HTTPClient.RequestWithHeaders(ctx, method, path, params, reqBody, headers)
+	if err != nil {
+		return err
+	}
+	return parseKuCoinResponse(resp, result)
+}
+
+// parseKuCoinResponse 解析响应外层结构，code 不为 200000 时返回交易所错误
+func parseKuCoinResponse(resp []byte, result interface{}) error {
+	var envelope kucoinResponse
+	if err := json.Unmarshal(resp, &envelope); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	if envelope.Code != kucoinSuccessCode {
+		return newKuCoinError(envelope.Code, envelope.Msg)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Data, result); err != nil {
+		return fmt.Errorf("unmarshal data: %w", err)
+	}
+	return nil
+}
+
+var _ exchange.Exchange = (*KuCoin)(nil)
@@ -0,0 +1,566 @@
+package kucoin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// KuCoinSpot KuCoin 现货实现
+type KuCoinSpot struct {
+	kucoin *KuCoin
+}
+
+// NewKuCoinSpot 创建 KuCoin 现货实例
+func NewKuCoinSpot(k *KuCoin) *KuCoinSpot {
+	return &KuCoinSpot{kucoin: k}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载现货市场（/api/v2/symbols）
+func (s *KuCoinSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	s.kucoin.mu.RLock()
+	if !reload && len(s.kucoin.spotMarketsBySymbol) > 0 && !s.kucoin.marketCache.Expired(model.MarketTypeSpot) {
+		s.kucoin.mu.RUnlock()
+		return nil
+	}
+	s.kucoin.mu.RUnlock()
+
+	var data []kucoinSymbol
+	if err := s.kucoin.publicGet(ctx, "/api/v2/symbols", nil, &data); err != nil {
+		return fmt.Errorf("fetch spot markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data))
+	for _, item := range data {
+		symbol := common.NormalizeSymbol(item.BaseCurrency, item.QuoteCurrency)
+		base, quote, _ := strings.Cut(symbol, "/")
+		market := &model.Market{
+			ID:     item.Symbol, // KuCoin 原始格式 (BTC-USDT)
+			Symbol: symbol,      // 标准化格式 (BTC/USDT)
+			Base:   base,
+			Quote:  quote,
+			Type:   model.MarketTypeSpot,
+			Active: item.EnableTrading,
+		}
+
+		market.Precision.Amount = decimalPlaces(item.BaseIncrement)
+		market.Precision.Price = decimalPlaces(item.PriceIncrement)
+		market.Precision.StepSize = item.BaseIncrement
+		market.Precision.TickSize = item.PriceIncrement
+		market.Limits.Amount.Min = item.BaseMinSize
+		market.Limits.Amount.Max = item.BaseMaxSize
+		market.Limits.Cost.Min = item.MinFunds
+
+		markets = append(markets, market)
+	}
+
+	s.kucoin.mu.Lock()
+	s.kucoin.spotMarketsBySymbol, s.kucoin.spotMarketsByID = common.IndexMarkets(markets)
+	s.kucoin.marketCache.Touch(model.MarketTypeSpot)
+	s.kucoin.mu.Unlock()
+
+	return nil
+}
+
+// FetchMarkets 获取现货市场列表
+func (s *KuCoinSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	// 确保市场已加载
+	if err := s.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.GetMarkets()
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (s *KuCoinSpot) GetMarket(symbol string) (*model.Market, error) {
+	s.kucoin.mu.RLock()
+	defer s.kucoin.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := s.kucoin.spotMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := s.kucoin.spotMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, s.kucoin.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *KuCoinSpot) GetMarketByID(id string) (*model.Market, error) {
+	s.kucoin.mu.RLock()
+	defer s.kucoin.mu.RUnlock()
+
+	if market, ok := s.kucoin.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, s.kucoin.spotMarketsByID)
+}
+
+// GetMarkets 从内存中获取所有现货市场信息
+func (s *KuCoinSpot) GetMarkets() ([]*model.Market, error) {
+	s.kucoin.mu.RLock()
+	defer s.kucoin.mu.RUnlock()
+
+	markets := make([]*model.Market, 0, len(s.kucoin.spotMarketsBySymbol))
+	for _, market := range s.kucoin.spotMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+func (s *KuCoinSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *KuCoinSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *KuCoinSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取行情（/api/v1/market/orderbook/level1），该接口只返回最优挂单和最新成交，24小时统计字段为 0
+func (s *KuCoinSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *kucoinLevel1
+	if err := s.kucoin.publicGet(ctx, "/api/v1/market/orderbook/level1", map[string]interface{}{"symbol": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+	if data == nil {
+		return nil, fmt.Errorf("ticker not found")
+	}
+
+	return &model.Ticker{
+		Symbol:    market.Symbol,
+		Bid:       data.BestBid,
+		Ask:       data.BestAsk,
+		Last:      data.Price,
+		Timestamp: data.Time,
+	}, nil
+}
+
+// FetchTickers 获取全部现货行情（/api/v1/market/allTickers），跳过未加载的交易对
+func (s *KuCoinSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	var data kucoinAllTickers
+	if err := s.kucoin.publicGet(ctx, "/api/v1/market/allTickers", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(map[string]*model.Ticker, len(data.Ticker))
+	for _, item := range data.Ticker {
+		market, err := s.GetMarketByID(item.Symbol)
+		if err != nil {
+			continue
+		}
+		tickers[market.Symbol] = &model.Ticker{
+			Symbol:      market.Symbol,
+			Bid:         item.Buy,
+			Ask:         item.Sell,
+			Last:        item.Last,
+			High:        item.High,
+			Low:         item.Low,
+			Volume:      item.Vol,
+			QuoteVolume: item.VolValue,
+			VWAP:        item.AveragePrice,
+			Timestamp:   data.Time,
+		}
+	}
+
+	return tickers, nil
+}
+
+func (s *KuCoinSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取订单簿深度（/api/v1/market/orderbook/level2_20、level2_100），limit 超过 20 时使用 100 档接口
+func (s *KuCoinSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = common.ClampOrderBookLimit(limit, 100)
+	path := "/api/v1/market/orderbook/level2_20"
+	if limit > 20 {
+		path = "/api/v1/market/orderbook/level2_100"
+	}
+
+	var data kucoinOrderBook
+	if err := s.kucoin.publicGet(ctx, path, map[string]interface{}{"symbol": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	nonce, _ := decimal.NewFromString(data.Sequence)
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     nonce.IntPart(),
+		Timestamp: data.Time,
+	}, nil
+}
+
+func (s *KuCoinSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (s *KuCoinSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/api/v1/market/candles），KuCoin 按开盘时间倒序返回，最多 1500 根，结果转换为升序
+// 设置 since 时保留从 since 开始的 limit 根，否则保留最近的 limit 根
+func (s *KuCoinSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, kucoinTimeframe(timeframe), kucoinTimeframes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol": market.ID,
+		"type":   interval,
+	}
+	since, hasSince := option.GetTime(argsOpts.Since)
+	if hasSince {
+		params["startAt"] = since.Unix()
+	}
+	until, hasUntil := option.GetTime(argsOpts.Until)
+	if hasUntil {
+		params["endAt"] = until.Unix()
+	}
+
+	var klines []kucoinKline
+	if err := s.kucoin.publicGet(ctx, "/api/v1/market/candles", params, &klines); err != nil {
+		return nil, fmt.Errorf("fetch ohlcv: %w", err)
+	}
+
+	ohlcvs := make(model.OHLCVs, 0, len(klines))
+	for _, item := range klines {
+		if (hasSince && item.Time.Before(since)) || (hasUntil && item.Time.After(until)) {
+			continue
+		}
+		ohlcvs = append(ohlcvs, &model.OHLCV{
+			Timestamp: item.Time,
+			Open:      item.Open,
+			High:      item.High,
+			Low:       item.Low,
+			Close:     item.Close,
+			Volume:    item.Volume,
+		})
+	}
+	slices.Reverse(ohlcvs)
+
+	if argsOpts.Limit != nil && *argsOpts.Limit > 0 && len(ohlcvs) > *argsOpts.Limit {
+		if hasSince {
+			ohlcvs = ohlcvs[:*argsOpts.Limit]
+		} else {
+			ohlcvs = ohlcvs[len(ohlcvs)-*argsOpts.Limit:]
+		}
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.kucoin.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *KuCoinSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, kucoinOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *KuCoinSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (s *KuCoinSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.kucoin.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *KuCoinSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// kucoinAccountTypes 账户类型到 KuCoin 账户的映射（trade 为现货交易账户，main 为资金账户）
+var kucoinAccountTypes = map[option.AccountType]string{
+	option.AccountSpot:    "trade",
+	option.AccountFunding: "main",
+	option.AccountMargin:  "margin",
+}
+
+// FetchBalance 获取余额（/api/v1/accounts），默认现货交易账户，合约账户返回 common.ErrNotSupported
+func (s *KuCoinSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	accountType := option.GetAccountType(argsOpts.AccountType)
+	kcType, ok := kucoinAccountTypes[accountType]
+	if !ok {
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+
+	var data []kucoinAccount
+	if err := s.kucoin.signAndRequest(ctx, http.MethodGet, "/api/v1/accounts", map[string]interface{}{"type": kcType}, nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	balances := make(model.Balances, 0, len(data))
+	for _, item := range data {
+		balances = append(balances, &model.Balance{
+			Currency:  common.NormalizeCurrency(item.Currency),
+			Available: item.Available,
+			Locked:    item.Holds,
+			Total:     item.Balance,
+			UpdatedAt: types.ExTimestamp{Time: time.Now()}, // KuCoin 余额接口没有返回更新时间
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+func (s *KuCoinSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（/api/v1/orders），设置 WithPrice 时为限价单，否则为市价单（数量为基础货币数量）
+// 未设置 option.WithClientOrderID 时自动生成 clientOid；不支持条件单
+func (s *KuCoinSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+
+	isLimit := option.StringPresent(argsOpts.Price)
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	clientOid := common.GenerateClientOrderID(kucoinName, strings.ToLower(string(side)))
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		clientOid = *argsOpts.ClientOrderID
+	}
+	body := map[string]interface{}{
+		"clientOid": clientOid,
+		"symbol":    market.ID,
+		"side":      strings.ToLower(string(side)),
+		"type":      "market",
+		"size":      size,
+	}
+	if isLimit {
+		price, err := common.PriceToPrecision(market, *argsOpts.Price)
+		if err != nil {
+			return nil, err
+		}
+		body["type"] = "limit"
+		body["price"] = price
+		// GTX 通过 postOnly 表达
+		if argsOpts.TimeInForce != nil && !argsOpts.TimeInForce.IsGTX() {
+			body["timeInForce"] = argsOpts.TimeInForce.Upper()
+		}
+	}
+	if postOnly {
+		body["postOnly"] = true
+	}
+
+	var result kucoinCreateOrderResponse
+	if err := s.kucoin.signAndRequest(ctx, http.MethodPost, "/api/v1/orders", nil, body, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	order := &model.NewOrder{
+		OrderId:       result.OrderID,
+		ClientOrderID: clientOid,
+		Symbol:        symbol,
+		Timestamp:     types.ExTimestamp{Time: time.Now()},
+	}
+	s.kucoin.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *KuCoinSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *KuCoinSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// CancelOrder 撤销订单（DELETE /api/v1/orders/{orderId}），orderId 为空时按 option.WithClientOrderID 撤销
+func (s *KuCoinSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	path := "/api/v1/orders/" + orderId
+	if orderId == "" {
+		if !option.StringPresent(argsOpts.ClientOrderID) {
+			return fmt.Errorf("cancel order: order id or client order id is required: %w", common.ErrInvalidOrder)
+		}
+		path = "/api/v1/order/client-order/" + *argsOpts.ClientOrderID
+	}
+
+	var result kucoinCancelOrderResponse
+	if err := s.kucoin.signAndRequest(ctx, http.MethodDelete, path, nil, nil, &result); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	s.kucoin.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *KuCoinSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+func (s *KuCoinSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order")
+}
+
+func (s *KuCoinSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return nil, notSupported("fetch order by client id")
+}
+
+func (s *KuCoinSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (s *KuCoinSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (s *KuCoinSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+func (s *KuCoinSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+func (s *KuCoinSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *KuCoinSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *KuCoinSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+func (s *KuCoinSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+func (s *KuCoinSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+func (s *KuCoinSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+func (s *KuCoinSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+func (s *KuCoinSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+func (s *KuCoinSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+func (s *KuCoinSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+var _ exchange.SpotExchange = (*KuCoinSpot)(nil)
@@ -0,0 +1,307 @@
+package kucoin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+const testSymbols = `{"code":"200000","data":[
+	{"symbol":"BTC-USDT","baseCurrency":"BTC","quoteCurrency":"USDT","baseMinSize":"0.00001","baseMaxSize":"10000000000",
+		"baseIncrement":"0.00000001","priceIncrement":"0.1","minFunds":"0.1","enableTrading":true},
+	{"symbol":"ETH-BTC","baseCurrency":"ETH","quoteCurrency":"BTC","baseMinSize":"0.0001","baseMaxSize":"10000000000",
+		"baseIncrement":"0.0000001","priceIncrement":"0.000001","minFunds":"0.00001","enableTrading":true},
+	{"symbol":"LUNA-USDT","baseCurrency":"LUNA","quoteCurrency":"USDT","baseMinSize":"0.1","baseMaxSize":"10000000000",
+		"baseIncrement":"0.0001","priceIncrement":"0.0001","minFunds":"0.1","enableTrading":false}
+]}`
+
+// newTestKuCoin 创建请求指向 handler 的 KuCoin 实例，/api/v2/symbols 返回 testSymbols
+func newTestKuCoin(t *testing.T, handler http.HandlerFunc) *KuCoin {
+	t.Helper()
+	return exchangetest.New[*KuCoin](t, exchangetest.Venue{
+		New:         NewKuCoin,
+		SecretKey:   testSecret,
+		Options:     map[string]interface{}{"password": "my-passphrase"},
+		MarketsPath: "/api/v2/symbols",
+		Markets:     testSymbols,
+	}, handler)
+}
+
+// checkSignature 校验请求头中的签名覆盖实际发送的路径、查询字符串和请求体
+func checkSignature(t *testing.T, r *http.Request, body []byte) {
+	t.Helper()
+	ts, err := strconv.ParseInt(r.Header.Get("KC-API-TIMESTAMP"), 10, 64)
+	if err != nil {
+		t.Fatalf("KC-API-TIMESTAMP = %q", r.Header.Get("KC-API-TIMESTAMP"))
+	}
+	signer := NewSigner(testSecret, "my-passphrase")
+	endpoint := r.URL.Path
+	if r.URL.RawQuery != "" {
+		endpoint += "?" + r.URL.RawQuery
+	}
+	if want := signer.SignRequest(ts, r.Method, endpoint, string(body)); r.Header.Get("KC-API-SIGN") != want {
+		t.Errorf("KC-API-SIGN = %q, want %q", r.Header.Get("KC-API-SIGN"), want)
+	}
+	if r.Header.Get("KC-API-KEY") != "key" || r.Header.Get("KC-API-PASSPHRASE") != signer.Passphrase() || r.Header.Get("KC-API-KEY-VERSION") != "2" {
+		t.Errorf("headers = %v", r.Header)
+	}
+}
+
+func TestKuCoinSpot_LoadMarkets(t *testing.T) {
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	tests := []struct {
+		symbol, id    string
+		active        bool
+		amountPrec    int
+		tick, minCost string
+	}{
+		{"BTC/USDT", "BTC-USDT", true, 8, "0.1", "0.1"},
+		{"ETH/BTC", "ETH-BTC", true, 7, "0.000001", "0.00001"},
+		{"LUNA/USDT", "LUNA-USDT", false, 4, "0.0001", "0.1"},
+	}
+	for _, tt := range tests {
+		market, err := k.Spot().GetMarket(tt.symbol)
+		if err != nil {
+			t.Errorf("GetMarket(%s): %v", tt.symbol, err)
+			continue
+		}
+		if market.ID != tt.id || market.Active != tt.active || market.Precision.Amount != tt.amountPrec ||
+			!market.Precision.TickSize.Equal(decimal.RequireFromString(tt.tick)) ||
+			!market.Limits.Cost.Min.Equal(decimal.RequireFromString(tt.minCost)) {
+			t.Errorf("market %s = %+v", tt.symbol, market)
+		}
+		if byID, err := k.Spot().GetMarketByID(tt.id); err != nil || byID != market {
+			t.Errorf("GetMarketByID(%s) = %v, %v", tt.id, byID, err)
+		}
+	}
+}
+
+func TestKuCoinSpot_FetchTickerOHLCV(t *testing.T) {
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/market/orderbook/level1" && r.URL.Query().Get("symbol") == "BTC-USDT":
+			w.Write([]byte(`{"code":"200000","data":{"time":1700000000123,"sequence":"1550467636704","price":"37000.5","size":"0.01",
+				"bestBid":"37000.4","bestBidSize":"0.5","bestAsk":"37000.6","bestAskSize":"0.3"}}`))
+		case r.URL.Path == "/api/v1/market/candles" && r.URL.Query().Get("type") == "1hour":
+			if got := r.URL.Query().Get("startAt"); got != "1699995600" {
+				t.Errorf("startAt = %s, want 1699995600", got)
+			}
+			// KuCoin 按开盘时间倒序返回
+			w.Write([]byte(`{"code":"200000","data":[
+				["1700002800","37100","37150","37200","37050","12.5","463750"],
+				["1699999200","37000","37100","37120","36990","10","370000"],
+				["1699995600","36900","37000","37010","36880","8","295000"]
+			]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	ticker, err := k.Spot().FetchTicker(ctx, "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+	if ticker.Symbol != "BTC/USDT" || ticker.Bid.String() != "37000.4" || ticker.Ask.String() != "37000.6" ||
+		ticker.Last.String() != "37000.5" || ticker.Timestamp.UnixMilli() != 1700000000123 {
+		t.Errorf("ticker = %+v", ticker)
+	}
+
+	ohlcvs, err := k.Spot().FetchOHLCVs(ctx, "BTC/USDT", "1h", option.WithSince(time.Unix(1699995600, 0)), option.WithLimit(2))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 2 || ohlcvs[0].Timestamp.Unix() != 1699995600 || ohlcvs[1].Timestamp.Unix() != 1699999200 ||
+		ohlcvs[0].Close.String() != "37000" || ohlcvs[0].High.String() != "37010" || ohlcvs[0].Volume.String() != "8" {
+		t.Errorf("ohlcvs = %v, want the first two candles in ascending order", ohlcvs)
+	}
+
+	if _, err := k.Spot().FetchOHLCVs(ctx, "BTC/USDT", "1s"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("unsupported timeframe: err = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestKuCoinSpot_CreateOrder(t *testing.T) {
+	var body map[string]interface{}
+	var path string
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		checkSignature(t, r, raw)
+		body = nil
+		json.Unmarshal(raw, &body)
+		path = r.Method + " " + r.URL.Path
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/orders":
+			if body["size"] == "1000" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":"200004","msg":"Balance insufficient!"}`))
+				return
+			}
+			w.Write([]byte(`{"code":"200000","data":{"orderId":"5bd6e9286d99522a52e458de"}}`))
+		case r.Method == http.MethodDelete:
+			w.Write([]byte(`{"code":"200000","data":{"cancelledOrderIds":["5bd6e9286d99522a52e458de"]}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	order, err := k.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "0.01", option.WithPrice("37000.04"), option.WithPostOnly(true), option.WithClientOrderID("my-order-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "5bd6e9286d99522a52e458de" || order.ClientOrderID != "my-order-1" {
+		t.Errorf("order = %+v", order)
+	}
+	if body["symbol"] != "BTC-USDT" || body["side"] != "buy" || body["type"] != "limit" || body["price"] != "37000" ||
+		body["size"] != "0.01" || body["postOnly"] != true || body["clientOid"] != "my-order-1" {
+		t.Errorf("order body = %v", body)
+	}
+
+	order, err = k.Spot().CreateOrder(ctx, "BTC/USDT", option.Sell, "0.5", option.WithTimeInForce(option.IOC))
+	if err != nil {
+		t.Fatalf("market CreateOrder: %v", err)
+	}
+	if body["type"] != "market" || body["side"] != "sell" || body["price"] != nil || body["timeInForce"] != nil ||
+		order.ClientOrderID == "" || body["clientOid"] != order.ClientOrderID {
+		t.Errorf("market order body = %v, order = %+v", body, order)
+	}
+
+	if _, err := k.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1000"); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("insufficient funds: err = %v, want ErrInsufficientFunds", err)
+	}
+
+	if err := k.Spot().CancelOrder(ctx, "BTC/USDT", "5bd6e9286d99522a52e458de"); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if path != "DELETE /api/v1/orders/5bd6e9286d99522a52e458de" {
+		t.Errorf("cancel request = %s", path)
+	}
+	if err := k.Spot().CancelOrder(ctx, "BTC/USDT", "", option.WithClientOrderID("my-order-1")); err != nil {
+		t.Fatalf("CancelOrder by client id: %v", err)
+	}
+	if path != "DELETE /api/v1/order/client-order/my-order-1" {
+		t.Errorf("cancel by client id request = %s", path)
+	}
+}
+
+// TestKuCoinSpot_CreateOrderIDOnly 下单接口只返回 orderId，交易对和客户端订单ID取自请求
+func TestKuCoinSpot_CreateOrderIDOnly(t *testing.T) {
+	var body map[string]interface{}
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		json.Unmarshal(raw, &body)
+		w.Write([]byte(`{"code":"200000","data":{"orderId":"670fd33bf9406e0007ab3945"}}`))
+	})
+
+	before := time.Now()
+	order, err := k.Spot().CreateOrder(context.Background(), "ETH/BTC", option.Sell, "0.1")
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.OrderId != "670fd33bf9406e0007ab3945" || order.Symbol != "ETH/BTC" || order.Timestamp.Before(before) {
+		t.Errorf("order = %+v", order)
+	}
+	// 未指定时生成客户端订单ID，返回值与发送的 clientOid 一致
+	if order.ClientOrderID == "" || body["clientOid"] != order.ClientOrderID {
+		t.Errorf("ClientOrderID = %q, sent %v", order.ClientOrderID, body["clientOid"])
+	}
+}
+
+func TestKuCoinSpot_FetchBalance(t *testing.T) {
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		checkSignature(t, r, nil)
+		if r.URL.Path != "/api/v1/accounts" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("type") == "main" {
+			w.Write([]byte(`{"code":"200000","data":[{"id":"3","currency":"USDT","type":"main","balance":"50","available":"50","holds":"0"}]}`))
+			return
+		}
+		w.Write([]byte(`{"code":"200000","data":[
+			{"id":"1","currency":"USDT","type":"trade","balance":"1000.25","available":"900.25","holds":"100"},
+			{"id":"2","currency":"BTC","type":"trade","balance":"1.5","available":"1","holds":"0.5"}
+		]}`))
+	})
+	ctx := context.Background()
+
+	balances, err := k.Spot().FetchBalance(ctx)
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	want := []struct{ currency, available, locked, total string }{
+		{"BTC", "1", "0.5", "1.5"},
+		{"USDT", "900.25", "100", "1000.25"},
+	}
+	if len(balances) != len(want) {
+		t.Fatalf("got %d balances, want %d", len(balances), len(want))
+	}
+	for i, w := range want {
+		b := balances[i]
+		if b.Currency != w.currency || b.Available.String() != w.available || b.Locked.String() != w.locked || b.Total.String() != w.total {
+			t.Errorf("balance %d = %+v, want %v", i, b, w)
+		}
+	}
+
+	funding, err := k.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFunding))
+	if err != nil || len(funding) != 1 || funding[0].Total.String() != "50" {
+		t.Errorf("funding balance = %v, %v", funding, err)
+	}
+
+	if _, err := k.Spot().FetchBalance(ctx, option.WithAccountType(option.AccountFutures)); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("futures balance: err = %v, want ErrNotSupported", err)
+	}
+}
+
+// TestKuCoin_PassphraseV2 API Key v2 发送 HMAC-SHA256(passphrase, secretKey) 而不是明文 passphrase，凭证轮换后使用新的 passphrase
+func TestKuCoin_PassphraseV2(t *testing.T) {
+	var passphrase, version string
+	k := newTestKuCoin(t, func(w http.ResponseWriter, r *http.Request) {
+		passphrase, version = r.Header.Get("KC-API-PASSPHRASE"), r.Header.Get("KC-API-KEY-VERSION")
+		w.Write([]byte(`{"code":"200000","data":[]}`))
+	})
+	ctx := context.Background()
+
+	if _, err := k.Spot().FetchBalance(ctx); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if passphrase != "YrJb5BnYyTGhhuQ1mP7XE/famg3XlSSvQ/EJcUnndu8=" || version != "2" {
+		t.Errorf("KC-API-PASSPHRASE = %q, KC-API-KEY-VERSION = %q", passphrase, version)
+	}
+
+	k.UpdateCredentials("key", "rotated-secret", "rotated-passphrase")
+	if _, err := k.Spot().FetchBalance(ctx); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if passphrase != "qu4Ww69s/UVK8gwjqzG2cJ2AQ2VGMgsyHltT/v9sYRc=" {
+		t.Errorf("rotated KC-API-PASSPHRASE = %q", passphrase)
+	}
+}
+
+func TestKuCoin_AuthenticationRequired(t *testing.T) {
+	ex, err := NewKuCoin("", "", nil)
+	if err != nil {
+		t.Fatalf("NewKuCoin: %v", err)
+	}
+	if _, err := ex.Spot().FetchBalance(context.Background()); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("err = %v, want ErrAuthenticationRequired", err)
+	}
+}
@@ -0,0 +1,123 @@
+package kucoin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lemconn/exlink/types"
+)
+
+// kucoinResponse KuCoin 响应外层结构，code 为 200000 表示成功
+type kucoinResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// kucoinSymbol KuCoin 交易对信息（/api/v2/symbols）
+type kucoinSymbol struct {
+	Symbol         string          `json:"symbol"`         // 交易对，如 BTC-USDT
+	BaseCurrency   string          `json:"baseCurrency"`   // 基础货币
+	QuoteCurrency  string          `json:"quoteCurrency"`  // 计价货币
+	BaseMinSize    types.ExDecimal `json:"baseMinSize"`    // 最小下单量
+	BaseMaxSize    types.ExDecimal `json:"baseMaxSize"`    // 最大下单量
+	BaseIncrement  types.ExDecimal `json:"baseIncrement"`  // 数量步长
+	PriceIncrement types.ExDecimal `json:"priceIncrement"` // 价格步长
+	MinFunds       types.ExDecimal `json:"minFunds"`       // 最小下单金额
+	EnableTrading  bool            `json:"enableTrading"`  // 是否可交易
+}
+
+// kucoinLevel1 KuCoin 最优挂单行情（/api/v1/market/orderbook/level1）
+type kucoinLevel1 struct {
+	Time        types.ExTimestamp `json:"time"`
+	Sequence    string            `json:"sequence"`
+	Price       types.ExDecimal   `json:"price"` // 最新成交价
+	Size        types.ExDecimal   `json:"size"`  // 最新成交量
+	BestBid     types.ExDecimal   `json:"bestBid"`
+	BestBidSize types.ExDecimal   `json:"bestBidSize"`
+	BestAsk     types.ExDecimal   `json:"bestAsk"`
+	BestAskSize types.ExDecimal   `json:"bestAskSize"`
+}
+
+// kucoinAllTickers KuCoin 全部行情（/api/v1/market/allTickers）
+type kucoinAllTickers struct {
+	Time   types.ExTimestamp `json:"time"`
+	Ticker []struct {
+		Symbol       string          `json:"symbol"`
+		Buy          types.ExDecimal `json:"buy"`
+		Sell         types.ExDecimal `json:"sell"`
+		Last         types.ExDecimal `json:"last"`
+		High         types.ExDecimal `json:"high"`
+		Low          types.ExDecimal `json:"low"`
+		Vol          types.ExDecimal `json:"vol"`          // 24小时成交量（基础货币）
+		VolValue     types.ExDecimal `json:"volValue"`     // 24小时成交额（计价货币）
+		AveragePrice types.ExDecimal `json:"averagePrice"` // 24小时均价
+	} `json:"ticker"`
+}
+
+// kucoinOrderBook KuCoin 深度（/api/v1/market/orderbook/level2_20、level2_100），档位为 [价格, 数量]
+type kucoinOrderBook struct {
+	Sequence string              `json:"sequence"`
+	Time     types.ExTimestamp   `json:"time"`
+	Bids     [][]types.ExDecimal `json:"bids"`
+	Asks     [][]types.ExDecimal `json:"asks"`
+}
+
+// kucoinKline KuCoin K线 [time(秒), open, close, high, low, volume, turnover]
+type kucoinKline struct {
+	Time   types.ExTimestamp
+	Open   types.ExDecimal
+	Close  types.ExDecimal
+	High   types.ExDecimal
+	Low    types.ExDecimal
+	Volume types.ExDecimal
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化，解析数组格式
+func (k *kucoinKline) UnmarshalJSON(data []byte) error {
+	var arr []json.RawMessage
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	if len(arr) < 6 {
+		return fmt.Errorf("invalid kline array length: %d", len(arr))
+	}
+	fields := []json.Unmarshaler{&k.Time, &k.Open, &k.Close, &k.High, &k.Low, &k.Volume}
+	for i, field := range fields {
+		if err := field.UnmarshalJSON(arr[i]); err != nil {
+			return fmt.Errorf("parse kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// kucoinAccount KuCoin 账户余额（/api/v1/accounts）
+type kucoinAccount struct {
+	ID        string          `json:"id"`
+	Currency  string          `json:"currency"`
+	Type      string          `json:"type"` // main/trade/margin
+	Balance   types.ExDecimal `json:"balance"`
+	Available types.ExDecimal `json:"available"`
+	Holds     types.ExDecimal `json:"holds"`
+}
+
+// kucoinCreateOrderResponse KuCoin 下单响应
+type kucoinCreateOrderResponse struct {
+	OrderID   string `json:"orderId"`
+	ClientOid string `json:"clientOid"`
+}
+
+// kucoinCancelOrderResponse KuCoin 撤单响应
+type kucoinCancelOrderResponse struct {
+	CancelledOrderIDs []string `json:"cancelledOrderIds"` // 按订单ID撤单
+	CancelledOrderID  string   `json:"cancelledOrderId"`  // 按客户端订单ID撤单
+}
+
+// kucoinTimeResponse KuCoin 服务器时间（毫秒）
+type kucoinTimeResponse int64
+
+// kucoinStatusResponse KuCoin 系统状态（/api/v1/status）
+type kucoinStatusResponse struct {
+	Status string `json:"status"` // open/close/cancelonly
+	Msg    string `json:"msg"`
+}
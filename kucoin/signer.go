@@ -0,0 +1,36 @@
+package kucoin
+
+import (
+	"strconv"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// Signer KuCoin 签名工具
+type Signer struct {
+	secretKey  string
+	passphrase string
+}
+
+// NewSigner 创建签名工具
+func NewSigner(secretKey, passphrase string) *Signer {
+	return &Signer{
+		secretKey:  secretKey,
+		passphrase: passphrase,
+	}
+}
+
+// SignRequest 对请求进行签名（KuCoin API）
+// timestamp: 毫秒时间戳（与 KC-API-TIMESTAMP 一致）
+// method: GET, POST, DELETE
+// endpoint: API 路径，包含查询字符串（如 /api/v1/accounts?type=trade）
+// body: 请求体（POST 时使用）
+// KuCoin 签名格式: base64(HMAC-SHA256(timestamp + method + endpoint + body, secretKey))
+func (s *Signer) SignRequest(timestamp int64, method, endpoint, body string) string {
+	return common.SignHMAC256Base64(strconv.FormatInt(timestamp, 10)+method+endpoint+body, s.secretKey)
+}
+
+// Passphrase 返回 KC-API-PASSPHRASE，API Key v2 要求 passphrase 使用 secretKey 签名后发送
+func (s *Signer) Passphrase() string {
+	return common.SignHMAC256Base64(s.passphrase, s.secretKey)
+}
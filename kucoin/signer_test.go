@@ -0,0 +1,38 @@
+package kucoin
+
+import "testing"
+
+const testSecret = "2b52a2a6-0b6c-4b4f-8a06-2f2f3f1c7e55"
+
+func TestSigner_SignRequest(t *testing.T) {
+	signer := NewSigner(testSecret, "my-passphrase")
+
+	tests := []struct {
+		name, method, endpoint, body, want string
+	}{
+		{
+			name:     "post with body",
+			method:   "POST",
+			endpoint: "/api/v1/orders",
+			body:     `{"clientOid":"5c52e11203aa677f33e493fb","side":"buy","size":"0.01","symbol":"BTC-USDT","type":"market"}`,
+			want:     "Aj1Tt0Nd2uei3OLeuhLYGv24bNsHx627NWjxYFufgoc=",
+		},
+		{
+			name:     "get with query",
+			method:   "GET",
+			endpoint: "/api/v1/accounts?type=trade",
+			want:     "CrL139TF25FIC0tEYDauGbrxh6l1DOkK5qLwpOtsQlE=",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signer.SignRequest(1700000000000, tt.method, tt.endpoint, tt.body); got != tt.want {
+				t.Errorf("SignRequest = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if got, want := signer.Passphrase(), "YrJb5BnYyTGhhuQ1mP7XE/famg3XlSSvQ/EJcUnndu8="; got != want {
+		t.Errorf("Passphrase = %s, want %s", got, want)
+	}
+}
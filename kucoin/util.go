@@ -0,0 +1,57 @@
+package kucoin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/types"
+)
+
+// kucoinTimeframe 转换为 KuCoin K线周期（如 1m -> 1min、1h -> 1hour），不支持的周期原样返回
+func kucoinTimeframe(timeframe string) string {
+	switch common.NormalizeTimeframe(timeframe) {
+	case "1m":
+		return "1min"
+	case "3m":
+		return "3min"
+	case "5m":
+		return "5min"
+	case "15m":
+		return "15min"
+	case "30m":
+		return "30min"
+	case "1h":
+		return "1hour"
+	case "2h":
+		return "2hour"
+	case "4h":
+		return "4hour"
+	case "6h":
+		return "6hour"
+	case "8h":
+		return "8hour"
+	case "12h":
+		return "12hour"
+	case "1d":
+		return "1day"
+	case "1w":
+		return "1week"
+	default:
+		return timeframe
+	}
+}
+
+// decimalPlaces 返回步长的小数位数（如 0.0001 -> 4）
+func decimalPlaces(step types.ExDecimal) int {
+	_, frac, ok := strings.Cut(step.String(), ".")
+	if !ok {
+		return 0
+	}
+	return len(strings.TrimRight(frac, "0"))
+}
+
+// notSupported KuCoin 适配器不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for kucoin", operation, common.ErrNotSupported)
+}
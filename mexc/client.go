@@ -0,0 +1,109 @@
+package mexc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+)
+
+const (
+	mexcName    = "mexc"
+	mexcBaseURL = "https://api.mexc.com"
+
+	// mexcOHLCVPageLimit 单次返回的最大K线数
+	mexcOHLCVPageLimit = 1000
+
+	// mexcMaxDepthLimit 深度接口支持的最大档位数
+	mexcMaxDepthLimit = 5000
+
+	// mexcMaxRecvWindow 签名请求接收窗口上限（毫秒）
+	mexcMaxRecvWindow = 60000
+)
+
+// mexcTimeframes K线支持的周期（小时线只有 60m，周线为 1W）
+var mexcTimeframes = []string{"1m", "5m", "15m", "30m", "60m", "4h", "1d", "1W", "1M"}
+
+// Client MEXC 客户端
+type Client struct {
+	// HTTPClient HTTP 客户端
+	HTTPClient *common.HTTPClient
+
+	// ProxyURL 代理地址
+	ProxyURL string
+
+	// Debug 是否启用调试模式
+	Debug bool
+}
+
+// NewClient 创建 MEXC 客户端
+// API 凭证由 MEXC 持有（见 credentials），以支持运行时轮换；MEXC 没有模拟盘，忽略 sandbox 选项
+func NewClient(options map[string]interface{}) (*Client, error) {
+	baseURL := mexcBaseURL
+	proxyURL := ""
+	debug := false
+
+	if v, ok := options["baseURL"].(string); ok {
+		baseURL = v
+	}
+	if v, ok := options["proxy"].(string); ok {
+		proxyURL = v
+	}
+	if v, ok := options["debug"].(bool); ok {
+		debug = v
+	}
+
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
+	client := &Client{
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
+		ProxyURL:   proxyURL,
+		Debug:      debug,
+	}
+
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseMEXCError)
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
+	// 设置代理
+	if proxyURL != "" {
+		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
+			return nil, err
+		}
+	}
+
+	// 设置调试模式
+	if debug {
+		client.HTTPClient.SetDebug(true)
+	}
+
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,35 @@
+package mexc
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// mexcErrorCodes MEXC 错误码到统一错误的映射
+var mexcErrorCodes = map[string]error{
+	"429":    common.ErrRateLimitExceeded,    // 请求频率超限
+	"-2011":  common.ErrOrderNotFound,        // 撤单被拒绝（订单不存在）
+	"-2013":  common.ErrOrderNotFound,        // 订单不存在
+	"30002":  common.ErrInvalidOrder,         // 低于最小下单金额
+	"30004":  common.ErrInsufficientFunds,    // 持仓不足
+	"30005":  common.ErrInsufficientFunds,    // 超卖
+	"30029":  common.ErrInvalidOrder,         // 超过最大下单数量
+	"10072":  common.ErrAuthenticationFailed, // API Key 无效
+	"700002": common.ErrAuthenticationFailed, // 签名无效
+}
+
+// parseMEXCError 解析 MEXC 非 2xx 响应体 {"code":30004,"msg":"..."}
+func parseMEXCError(httpErr *common.HTTPError) error {
+	var body struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Code == 0 {
+		return nil
+	}
+	e := common.NewExchangeError(mexcName, strconv.Itoa(body.Code), body.Msg, mexcErrorCodes)
+	e.Cause = httpErr
+	return e
+}
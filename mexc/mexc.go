@@ -0,0 +1,248 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+)
+
+// MEXC MEXC 交易所实现
+type MEXC struct {
+	client              *Client
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
+	spot                *MEXCSpot
+	perp                *common.UnsupportedPerp  // 永续合约占位实现（未接入）
+	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
+	spotMarketsByID     map[string]*model.Market // 现货市场信息（原始格式索引）
+	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+	recvWindow          int                      // 签名请求接收窗口（毫秒），为 0 时不发送使用交易所默认值
+}
+
+// NewMEXC 创建 MEXC 交易所实例
+func NewMEXC(apiKey, secretKey string, options map[string]interface{}) (exchange.Exchange, error) {
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
+	mexc := &MEXC{
+		client:              client,
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		mexc.lifecycle.SetCancelOrders(v)
+	}
+	if v, ok := options["recvWindow"].(int); ok && v > 0 {
+		mexc.recvWindow = min(v, mexcMaxRecvWindow)
+	}
+	client.HTTPClient.SetLifecycle(mexc.lifecycle)
+
+	mexc.UpdateCredentials(apiKey, secretKey, "")
+
+	// 初始化现货和合约实现
+	mexc.spot = NewMEXCSpot(mexc)
+	mexc.perp = common.NewUnsupportedPerp(mexcName)
+
+	if v, ok := options["timeSync"].(bool); ok && v {
+		mexc.clock.Start(common.TimeSyncInterval, mexc.FetchTime)
+	}
+
+	return mexc, nil
+}
+
+// Spot 返回现货交易接口
+func (m *MEXC) Spot() exchange.SpotExchange {
+	return m.spot
+}
+
+// Perp 返回永续合约交易接口（MEXC 合约使用独立的 Contract API，暂不支持）
+func (m *MEXC) Perp() exchange.PerpExchange {
+	return m.perp
+}
+
+// Name 返回交易所名称
+func (m *MEXC) Name() string {
+	return mexcName
+}
+
+// Has 返回 MEXC 支持的功能
+func (m *MEXC) Has() model.Capabilities {
+	return mexcCapabilities
+}
+
+// Symbols 返回已加载的现货市场的标准化 symbol，按字母排序（未接入合约）
+func (m *MEXC) Symbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return common.MarketSymbols(m.spotMarketsBySymbol, nil)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (m *MEXC) NormalizeSymbol(nativeID string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, m.spotMarketsByID, nil, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (m *MEXC) DenormalizeSymbol(symbol string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, m.spotMarketsBySymbol, nil, nil)
+}
+
+// ExportMarkets 导出已加载的现货市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (m *MEXC) ExportMarkets() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return common.MarshalMarkets(mexcName, m.spotMarketsBySymbol, nil)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换已加载的现货市场（导出时为空则保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (m *MEXC) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(mexcName, data)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		m.spotMarketsBySymbol, m.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		m.marketCache.Touch(model.MarketTypeSpot)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后已建立的轮询订阅通道随之关闭
+func (m *MEXC) Drain(ctx context.Context) error {
+	m.clock.Stop()
+	return m.lifecycle.Drain(ctx, m.spot, m.perp)
+}
+
+// FetchTime 获取交易所服务器时间
+func (m *MEXC) FetchTime(ctx context.Context) (time.Time, error) {
+	var result mexcTimeResponse
+	if err := m.publicGet(ctx, "/api/v3/time", nil, &result); err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+	return result.ServerTime.Time, nil
+}
+
+// FetchStatus 获取系统状态，MEXC 没有系统状态接口，/api/v3/ping 可访问即视为正常
+func (m *MEXC) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	if _, err := m.client.HTTPClient.Get(ctx, "/api/v3/ping", nil); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+	return common.OKStatus(), nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (m *MEXC) Ping(ctx context.Context) error {
+	return common.Ping(ctx, m.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (m *MEXC) VerifyCredentials(ctx context.Context) error {
+	creds := m.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "", m.spot.FetchBalance)
+}
+
+// mexcCapabilities MEXC 支持的功能
+var mexcCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported:            true,
+		FetchOrder:           true,
+		FetchOrderByClientID: true,
+	},
+	Perp: model.MarketCapabilities{},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey    string
+	secretKey string
+	signer    *Signer
+}
+
+// headers 返回签名请求需要的请求头
+func (c *credentials) headers() map[string]string {
+	return map[string]string{"X-MEXC-APIKEY": c.apiKey}
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+// MEXC 不需要 password，忽略该参数
+func (m *MEXC) UpdateCredentials(apiKey, secretKey, password string) {
+	m.creds.Store(&credentials{
+		apiKey:    apiKey,
+		secretKey: secretKey,
+		signer:    NewSigner(secretKey),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (m *MEXC) credentials() *credentials {
+	return m.creds.Load()
+}
+
+// publicGet 请求公共接口并解析响应
+func (m *MEXC) publicGet(ctx context.Context, path string, params map[string]interface{}, result interface{}) error {
+	resp, err := m.client.HTTPClient.Get(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+// signedRequest 签名并发送请求，参数（含 timestamp 和 recvWindow）全部放在查询字符串中
+// signature 追加在查询字符串最后，签名覆盖它前面的全部参数
+func (m *MEXC) signedRequest(ctx context.Context, method, path string, params map[string]interface{}, result interface{}) error {
+	creds := m.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	if params == nil {
+		params = make(map[string]interface{})
+	}
+	params["timestamp"] = m.clock.Timestamp()
+	if m.recvWindow > 0 {
+		params["recvWindow"] = m.recvWindow
+	}
+	query := common.BuildQueryString(params)
+	path += "?" + query + "&signature=" + creds.signer.Sign(query)
+
+	resp, err := m.client.HTTPClient.RequestWithHeaders(ctx, method, path, nil, nil, creds.headers())
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+var _ exchange.Exchange = (*MEXC)(nil)
@@ -0,0 +1,561 @@
+package mexc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// MEXCSpot MEXC 现货实现
+type MEXCSpot struct {
+	mexc *MEXC
+}
+
+// NewMEXCSpot 创建 MEXC 现货实例
+func NewMEXCSpot(m *MEXC) *MEXCSpot {
+	return &MEXCSpot{mexc: m}
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 加载现货市场（/api/v3/exchangeInfo），status 为 1 且允许现货交易的交易对视为可交易
+func (s *MEXCSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
+	s.mexc.mu.RLock()
+	if !reload && len(s.mexc.spotMarketsBySymbol) > 0 && !s.mexc.marketCache.Expired(model.MarketTypeSpot) {
+		s.mexc.mu.RUnlock()
+		return nil
+	}
+	s.mexc.mu.RUnlock()
+
+	var data mexcExchangeInfo
+	if err := s.mexc.publicGet(ctx, "/api/v3/exchangeInfo", nil, &data); err != nil {
+		return fmt.Errorf("fetch spot markets: %w", err)
+	}
+
+	markets := make(model.Markets, 0, len(data.Symbols))
+	for _, item := range data.Symbols {
+		symbol := common.NormalizeSymbol(item.BaseAsset, item.QuoteAsset)
+		base, quote, _ := strings.Cut(symbol, "/")
+		market := &model.Market{
+			ID:     item.Symbol, // MEXC 原始格式 (BTCUSDT)
+			Symbol: symbol,      // 标准化格式 (BTC/USDT)
+			Base:   base,
+			Quote:  quote,
+			Type:   model.MarketTypeSpot,
+			Active: item.Status == "1" && item.IsSpotTradingAllowed,
+		}
+
+		market.Precision.Amount = item.BaseAssetPrecision
+		market.Precision.Price = item.QuotePrecision
+		market.Precision.StepSize = item.BaseSizePrecision
+		if market.Precision.StepSize.IsZero() {
+			market.Precision.StepSize = precisionStep(item.BaseAssetPrecision)
+		}
+		market.Precision.TickSize = precisionStep(item.QuotePrecision)
+		market.Limits.Amount.Min = market.Precision.StepSize
+		market.Limits.Cost.Min = item.QuoteAmountPrecision
+		market.Limits.Cost.Max = item.MaxQuoteAmount
+
+		markets = append(markets, market)
+	}
+
+	s.mexc.mu.Lock()
+	s.mexc.spotMarketsBySymbol, s.mexc.spotMarketsByID = common.IndexMarkets(markets)
+	s.mexc.marketCache.Touch(model.MarketTypeSpot)
+	s.mexc.mu.Unlock()
+
+	return nil
+}
+
+// FetchMarkets 获取现货市场列表
+func (s *MEXCSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	// 确保市场已加载
+	if err := s.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+	return s.GetMarkets()
+}
+
+// GetMarket 获取单个市场信息（支持标准化格式和原始格式）
+func (s *MEXCSpot) GetMarket(symbol string) (*model.Market, error) {
+	s.mexc.mu.RLock()
+	defer s.mexc.mu.RUnlock()
+
+	// 先尝试标准化格式
+	if market, ok := s.mexc.spotMarketsBySymbol[symbol]; ok {
+		return market, nil
+	}
+	// 再尝试原始格式
+	if market, ok := s.mexc.spotMarketsByID[symbol]; ok {
+		return market, nil
+	}
+
+	return nil, common.MarketNotFound(symbol, s.mexc.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (s *MEXCSpot) GetMarketByID(id string) (*model.Market, error) {
+	s.mexc.mu.RLock()
+	defer s.mexc.mu.RUnlock()
+
+	if market, ok := s.mexc.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, s.mexc.spotMarketsByID)
+}
+
+// GetMarkets 从内存中获取所有现货市场信息
+func (s *MEXCSpot) GetMarkets() ([]*model.Market, error) {
+	s.mexc.mu.RLock()
+	defer s.mexc.mu.RUnlock()
+
+	markets := make([]*model.Market, 0, len(s.mexc.spotMarketsBySymbol))
+	for _, market := range s.mexc.spotMarketsBySymbol {
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+func (s *MEXCSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *MEXCSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *MEXCSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取24小时行情（/api/v3/ticker/24hr）
+func (s *MEXCSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var data mexcTicker
+	if err := s.mexc.publicGet(ctx, "/api/v3/ticker/24hr", map[string]interface{}{"symbol": market.ID}, &data); err != nil {
+		return nil, fmt.Errorf("fetch ticker: %w", err)
+	}
+	if data.Symbol != market.ID {
+		return nil, fmt.Errorf("ticker not found: %s", symbol)
+	}
+
+	return data.toTicker(market.Symbol), nil
+}
+
+// FetchTickers 获取全部现货行情（/api/v3/ticker/24hr），跳过未加载的交易对
+func (s *MEXCSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	var data []mexcTicker
+	if err := s.mexc.publicGet(ctx, "/api/v3/ticker/24hr", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch tickers: %w", err)
+	}
+
+	tickers := make(map[string]*model.Ticker, len(data))
+	for i := range data {
+		market, err := s.GetMarketByID(data[i].Symbol)
+		if err != nil {
+			continue
+		}
+		tickers[market.Symbol] = data[i].toTicker(market.Symbol)
+	}
+
+	return tickers, nil
+}
+
+func (s *MEXCSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取订单簿深度（/api/v3/depth），最多 5000 档
+func (s *MEXCSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = common.ClampOrderBookLimit(limit, mexcMaxDepthLimit)
+	var data mexcOrderBook
+	if err := s.mexc.publicGet(ctx, "/api/v3/depth", map[string]interface{}{"symbol": market.ID, "limit": limit}, &data); err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.LastUpdateID,
+		Timestamp: data.Timestamp,
+	}, nil
+}
+
+func (s *MEXCSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+func (s *MEXCSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 获取K线（/api/v3/klines），按开盘时间升序返回，单次最多 1000 根
+// MEXC 小时线只支持 60m（即 1h），不支持 3m、2h、6h 等周期
+func (s *MEXCSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval, err := common.CheckTimeframe(timeframe, mexcTimeframe(timeframe), mexcTimeframes)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"symbol":   market.ID,
+		"interval": interval,
+	}
+	if argsOpts.Limit != nil && *argsOpts.Limit > 0 {
+		params["limit"] = min(*argsOpts.Limit, mexcOHLCVPageLimit)
+	}
+	if since, ok := option.GetTime(argsOpts.Since); ok {
+		params["startTime"] = since.UnixMilli()
+	}
+	if until, ok := option.GetTime(argsOpts.Until); ok {
+		params["endTime"] = until.UnixMilli()
+	}
+
+	var klines []mexcKline
+	if err := s.mexc.publicGet(ctx, "/api/v3/klines", params, &klines); err != nil {
+		return nil, fmt.Errorf("fetch ohlcv: %w", err)
+	}
+
+	ohlcvs := make(model.OHLCVs, 0, len(klines))
+	for _, item := range klines {
+		ohlcvs = append(ohlcvs, &model.OHLCV{
+			Timestamp: item.OpenTime,
+			Open:      item.Open,
+			High:      item.High,
+			Low:       item.Low,
+			Close:     item.Close,
+			Volume:    item.Volume,
+		})
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.mexc.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *MEXCSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, mexcOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *MEXCSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+func (s *MEXCSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.mexc.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *MEXCSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// FetchBalance 获取现货账户余额（/api/v3/account），其他账户类型返回 common.ErrNotSupported
+func (s *MEXCSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+	if accountType := option.GetAccountType(argsOpts.AccountType); accountType != option.AccountSpot {
+		return nil, fmt.Errorf("fetch balance: %w: account type %s", common.ErrNotSupported, accountType)
+	}
+
+	var data mexcAccount
+	if err := s.mexc.signedRequest(ctx, http.MethodGet, "/api/v3/account", nil, &data); err != nil {
+		return nil, fmt.Errorf("fetch balance: %w", err)
+	}
+
+	updatedAt := data.UpdateTime
+	if updatedAt.IsZero() {
+		updatedAt = types.ExTimestamp{Time: time.Now()}
+	}
+	balances := make(model.Balances, 0, len(data.Balances))
+	for _, item := range data.Balances {
+		balances = append(balances, &model.Balance{
+			Currency:  common.NormalizeCurrency(item.Asset),
+			Available: item.Free,
+			Locked:    item.Locked,
+			Total:     types.ExDecimal{Decimal: item.Free.Add(item.Locked.Decimal)},
+			UpdatedAt: updatedAt,
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Currency < balances[j].Currency
+	})
+
+	return balances, nil
+}
+
+func (s *MEXCSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单（POST /api/v3/order），设置 WithPrice 时为限价单，否则为市价单（数量为基础货币数量）
+// MEXC 通过订单类型表达有效期：IOC 为 IMMEDIATE_OR_CANCEL，FOK 为 FILL_OR_KILL，只做 Maker 为 LIMIT_MAKER
+// 未设置 option.WithClientOrderID 时自动生成 newClientOrderId；不支持条件单
+func (s *MEXCSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, isStop, err := common.ParseStopOrder(argsOpts); err != nil {
+		return nil, err
+	} else if isStop {
+		return nil, notSupported("stop order")
+	}
+
+	isLimit := option.StringPresent(argsOpts.Price)
+	if err := common.CheckTimeInForce(argsOpts, isLimit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, isLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	clientOrderID := common.GenerateClientOrderID(mexcName, side.ToSide())
+	if option.StringPresent(argsOpts.ClientOrderID) {
+		clientOrderID = *argsOpts.ClientOrderID
+	}
+	params := map[string]interface{}{
+		"symbol":           market.ID,
+		"side":             strings.ToUpper(string(side)),
+		"type":             "MARKET",
+		"quantity":         quantity,
+		"newClientOrderId": clientOrderID,
+	}
+	if isLimit {
+		price, err := common.PriceToPrecision(market, *argsOpts.Price)
+		if err != nil {
+			return nil, err
+		}
+		params["price"] = price
+		switch tif := argsOpts.TimeInForce; {
+		case postOnly:
+			params["type"] = "LIMIT_MAKER"
+		case tif != nil && tif.IsIOC():
+			params["type"] = "IMMEDIATE_OR_CANCEL"
+		case tif != nil && tif.IsFOK():
+			params["type"] = "FILL_OR_KILL"
+		default:
+			params["type"] = "LIMIT"
+		}
+	}
+
+	var result mexcCreateOrderResponse
+	if err := s.mexc.signedRequest(ctx, http.MethodPost, "/api/v3/order", params, &result); err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	order := &model.NewOrder{
+		OrderId:       result.OrderID,
+		ClientOrderID: clientOrderID,
+		Symbol:        symbol,
+		Timestamp:     result.TransactTime,
+	}
+	s.mexc.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	return order, nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *MEXCSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *MEXCSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// orderParams 构建撤单和查询订单参数，orderId 为空时按 option.WithClientOrderID 查找
+func (s *MEXCSpot) orderParams(symbol, orderId string, opts []option.ArgsOption) (*model.Market, map[string]interface{}, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	params := map[string]interface{}{"symbol": market.ID}
+	switch {
+	case orderId != "":
+		params["orderId"] = orderId
+	case option.StringPresent(argsOpts.ClientOrderID):
+		params["origClientOrderId"] = *argsOpts.ClientOrderID
+	default:
+		return nil, nil, fmt.Errorf("order id or client order id is required: %w", common.ErrInvalidOrder)
+	}
+	return market, params, nil
+}
+
+// CancelOrder 撤销订单（DELETE /api/v3/order），orderId 为空时按 option.WithClientOrderID 撤销
+func (s *MEXCSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	_, params, err := s.orderParams(symbol, orderId, opts)
+	if err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+
+	if err := s.mexc.signedRequest(ctx, http.MethodDelete, "/api/v3/order", params, nil); err != nil {
+		return fmt.Errorf("cancel order: %w", err)
+	}
+	s.mexc.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *MEXCSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return nil, notSupported("edit order")
+}
+
+// FetchOrder 查询订单（GET /api/v3/order），orderId 为空时按 option.WithClientOrderID 查询
+func (s *MEXCSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
+	market, params, err := s.orderParams(symbol, orderId, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order: %w", err)
+	}
+
+	var data mexcOrder
+	if err := s.mexc.signedRequest(ctx, http.MethodGet, "/api/v3/order", params, &data); err != nil {
+		return nil, fmt.Errorf("fetch order: %w", err)
+	}
+	return data.toSpotOrder(market.Symbol), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *MEXCSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+func (s *MEXCSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+func (s *MEXCSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return nil, notSupported("track order")
+}
+
+func (s *MEXCSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+func (s *MEXCSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+func (s *MEXCSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *MEXCSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *MEXCSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+func (s *MEXCSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+func (s *MEXCSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+func (s *MEXCSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+func (s *MEXCSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+func (s *MEXCSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+func (s *MEXCSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+func (s *MEXCSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+var _ exchange.SpotExchange = (*MEXCSpot)(nil)
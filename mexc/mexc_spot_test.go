@@ -0,0 +1,281 @@
+package mexc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+const testExchangeInfo = `{"timezone":"CST","serverTime":1700000000000,"symbols":[
+	{"symbol":"BTCUSDT","status":"1","baseAsset":"BTC","baseAssetPrecision":6,"quoteAsset":"USDT","quotePrecision":2,
+		"quoteAssetPrecision":2,"quoteAmountPrecision":"1","baseSizePrecision":"0.000001","maxQuoteAmount":"2000000",
+		"isSpotTradingAllowed":true,"permissions":["SPOT"]},
+	{"symbol":"MXUSDT","status":"1","baseAsset":"MX","baseAssetPrecision":2,"quoteAsset":"USDT","quotePrecision":4,
+		"quoteAssetPrecision":4,"quoteAmountPrecision":"5","baseSizePrecision":"0","maxQuoteAmount":"500000",
+		"isSpotTradingAllowed":true,"permissions":["SPOT"]},
+	{"symbol":"LUNAUSDT","status":"3","baseAsset":"LUNA","baseAssetPrecision":2,"quoteAsset":"USDT","quotePrecision":4,
+		"quoteAssetPrecision":4,"quoteAmountPrecision":"5","baseSizePrecision":"0.01","maxQuoteAmount":"500000",
+		"isSpotTradingAllowed":true,"permissions":["SPOT"]}
+]}`
+
+// newTestMEXC 创建请求指向 handler 的 MEXC 实例，/api/v3/exchangeInfo 返回 testExchangeInfo
+func newTestMEXC(t *testing.T, handler http.HandlerFunc) *MEXC {
+	t.Helper()
+	return exchangetest.New[*MEXC](t, exchangetest.Venue{
+		New:         NewMEXC,
+		SecretKey:   testSecret,
+		MarketsPath: "/api/v3/exchangeInfo",
+		Markets:     testExchangeInfo,
+	}, handler)
+}
+
+// checkSignature 校验签名覆盖除 signature 外的全部查询参数，并返回查询参数
+func checkSignature(t *testing.T, r *http.Request) url.Values {
+	t.Helper()
+	query := r.URL.Query()
+	signature := query.Get("signature")
+	query.Del("signature")
+	if want := NewSigner(testSecret).Sign(query.Encode()); signature != want {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+	if r.Header.Get("X-MEXC-APIKEY") != "key" || query.Get("timestamp") == "" {
+		t.Errorf("headers = %v, query = %v", r.Header, query)
+	}
+	return query
+}
+
+func TestMEXCSpot_LoadMarkets(t *testing.T) {
+	m := newTestMEXC(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	tests := []struct {
+		symbol, id       string
+		active           bool
+		step, tick, cost string
+	}{
+		{"BTC/USDT", "BTCUSDT", true, "0.000001", "0.01", "1"},
+		{"MX/USDT", "MXUSDT", true, "0.01", "0.0001", "5"}, // baseSizePrecision 为 0 时按 baseAssetPrecision
+		{"LUNA/USDT", "LUNAUSDT", false, "0.01", "0.0001", "5"},
+	}
+	for _, tt := range tests {
+		market, err := m.Spot().GetMarket(tt.symbol)
+		if err != nil {
+			t.Errorf("GetMarket(%s): %v", tt.symbol, err)
+			continue
+		}
+		if market.ID != tt.id || market.Active != tt.active ||
+			!market.Precision.StepSize.Equal(decimal.RequireFromString(tt.step)) ||
+			!market.Precision.TickSize.Equal(decimal.RequireFromString(tt.tick)) ||
+			!market.Limits.Cost.Min.Equal(decimal.RequireFromString(tt.cost)) {
+			t.Errorf("market %s = %+v", tt.symbol, market)
+		}
+	}
+}
+
+func TestMEXCSpot_FetchOHLCVs(t *testing.T) {
+	m := newTestMEXC(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/api/v3/klines" || query.Get("symbol") != "BTCUSDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			return
+		}
+		if query.Get("interval") != "60m" || query.Get("startTime") != "1699995600000" || query.Get("limit") != "2" {
+			t.Errorf("query = %v", query)
+		}
+		// MEXC K线只有 8 个字段
+		w.Write([]byte(`[
+			[1699995600000,"36900","37010","36880","37000","8",1699999199999,"295000"],
+			[1699999200000,"37000","37120","36990","37100","10",1700002799999,"370000"]
+		]`))
+	})
+
+	ohlcvs, err := m.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1h",
+		option.WithSince(time.UnixMilli(1699995600000)), option.WithLimit(2))
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 2 || ohlcvs[0].Timestamp.UnixMilli() != 1699995600000 ||
+		ohlcvs[1].Close.String() != "37100" || ohlcvs[1].High.String() != "37120" || ohlcvs[0].Volume.String() != "8" {
+		t.Errorf("ohlcvs = %+v", ohlcvs)
+	}
+
+	if _, err := m.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "2h"); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("FetchOHLCVs(2h) error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMEXCKline_UnmarshalJSON(t *testing.T) {
+	var kline mexcKline
+	if err := json.Unmarshal([]byte(`[1700000000000,"1.5","2","1","1.8","100",1700000059999,"175"]`), &kline); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if kline.OpenTime.UnixMilli() != 1700000000000 || kline.CloseTime.UnixMilli() != 1700000059999 ||
+		kline.Open.String() != "1.5" || kline.Close.String() != "1.8" || kline.QuoteVolume.String() != "175" {
+		t.Errorf("kline = %+v", kline)
+	}
+
+	// 字段不足 8 个时报错
+	if err := json.Unmarshal([]byte(`[1700000000000,"1.5","2","1","1.8","100"]`), &kline); err == nil {
+		t.Error("Unmarshal(short kline) error = nil")
+	}
+}
+
+func TestMEXCSpot_CreateOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		side  option.SpotOrderSide
+		opts  []option.ArgsOption
+		query map[string]string
+	}{
+		{
+			name:  "limit",
+			side:  option.Buy,
+			opts:  []option.ArgsOption{option.WithPrice("37000.129"), option.WithClientOrderID("my-order-1")},
+			query: map[string]string{"type": "LIMIT", "side": "BUY", "price": "37000.12", "quantity": "0.012345", "newClientOrderId": "my-order-1"},
+		},
+		{
+			name:  "market",
+			side:  option.Sell,
+			query: map[string]string{"type": "MARKET", "side": "SELL", "quantity": "0.012345", "price": ""},
+		},
+		{
+			name:  "ioc",
+			side:  option.Buy,
+			opts:  []option.ArgsOption{option.WithPrice("37000"), option.WithTimeInForce(option.IOC)},
+			query: map[string]string{"type": "IMMEDIATE_OR_CANCEL", "timeInForce": ""},
+		},
+		{
+			name:  "fok",
+			side:  option.Buy,
+			opts:  []option.ArgsOption{option.WithPrice("37000"), option.WithTimeInForce(option.FOK)},
+			query: map[string]string{"type": "FILL_OR_KILL"},
+		},
+		{
+			name:  "post only",
+			side:  option.Buy,
+			opts:  []option.ArgsOption{option.WithPrice("37000"), option.WithPostOnly(true)},
+			query: map[string]string{"type": "LIMIT_MAKER"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestMEXC(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/v3/order" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					return
+				}
+				query := checkSignature(t, r)
+				if query.Get("symbol") != "BTCUSDT" || query.Get("newClientOrderId") == "" {
+					t.Errorf("query = %v", query)
+				}
+				for k, want := range tt.query {
+					if got := query.Get(k); got != want {
+						t.Errorf("%s = %q, want %q", k, got, want)
+					}
+				}
+				w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__443776347957968896088","orderListId":-1,"price":"37000.12",
+					"origQty":"0.012345","type":"LIMIT","side":"BUY","transactTime":1700000000123}`))
+			})
+
+			order, err := m.Spot().CreateOrder(context.Background(), "BTC/USDT", tt.side, "0.0123456", tt.opts...)
+			if err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+			if order.OrderId != "C02__443776347957968896088" || order.ClientOrderID == "" || order.Timestamp.UnixMilli() != 1700000000123 {
+				t.Errorf("order = %+v", order)
+			}
+		})
+	}
+
+	m := newTestMEXC(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+	if _, err := m.Spot().CreateOrder(context.Background(), "BTC/USDT", option.Buy, "0.01", option.WithStopPrice("36000")); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("CreateOrder(stop) error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMEXCSpot_FetchCancelOrder(t *testing.T) {
+	m := newTestMEXC(t, func(w http.ResponseWriter, r *http.Request) {
+		query := checkSignature(t, r)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v3/order" && query.Get("orderId") == "C02__1":
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__1","orderListId":-1,"clientOrderId":"my-order-1","price":"37000",
+				"origQty":"0.5","executedQty":"0.2","cummulativeQuoteQty":"7380","status":"PARTIALLY_CANCELED","timeInForce":"",
+				"type":"LIMIT","side":"BUY","stopPrice":"","time":1700000000000,"updateTime":null,"isWorking":true}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v3/order" && query.Get("origClientOrderId") == "my-order-1":
+			if query.Has("orderId") {
+				t.Errorf("query = %v", query)
+			}
+			w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__1","origClientOrderId":"my-order-1","status":"CANCELED"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-2011,"msg":"Unknown order sent."}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	})
+
+	order, err := m.Spot().FetchOrder(context.Background(), "BTC/USDT", "C02__1")
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	if order.ID != "C02__1" || order.ClientOrderID != "my-order-1" || order.Symbol != "BTC/USDT" ||
+		order.Status != model.OrderStatusCanceled || order.Side != model.OrderSideBuy ||
+		order.Remaining.String() != "0.3" || order.Average.String() != "36900" {
+		t.Errorf("order = %+v", order)
+	}
+
+	if err := m.Spot().CancelOrder(context.Background(), "BTC/USDT", "", option.WithClientOrderID("my-order-1")); err != nil {
+		t.Errorf("CancelOrder: %v", err)
+	}
+	if err := m.Spot().CancelOrder(context.Background(), "BTC/USDT", "C02__2"); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("CancelOrder(unknown) error = %v, want ErrOrderNotFound", err)
+	}
+	if err := m.Spot().CancelOrder(context.Background(), "BTC/USDT", ""); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("CancelOrder(no id) error = %v, want ErrInvalidOrder", err)
+	}
+}
+
+// TestMEXC_SignedQueryOrder 参数按键排序（含 recvWindow），signature 在查询字符串最后并覆盖它前面的全部参数
+func TestMEXC_SignedQueryOrder(t *testing.T) {
+	var rawQuery string
+	m := exchangetest.New[*MEXC](t, exchangetest.Venue{
+		New:         NewMEXC,
+		SecretKey:   testSecret,
+		Options:     map[string]interface{}{"recvWindow": 90000},
+		MarketsPath: "/api/v3/exchangeInfo",
+		Markets:     testExchangeInfo,
+	}, func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Write([]byte(`{"symbol":"BTCUSDT","orderId":"C02__1","status":"CANCELED"}`))
+	})
+
+	if err := m.Spot().CancelOrder(context.Background(), "BTC/USDT", "C02__1"); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	signed, signature, ok := strings.Cut(rawQuery, "&signature=")
+	if !ok || strings.Contains(signature, "&") {
+		t.Fatalf("query = %s, want signature last", rawQuery)
+	}
+	if want := NewSigner(testSecret).Sign(signed); signature != want {
+		t.Errorf("signature = %s, want %s", signature, want)
+	}
+	// recvWindow 超过上限时按 60000 发送
+	if !regexp.MustCompile(`^orderId=C02__1&recvWindow=60000&symbol=BTCUSDT&timestamp=\d{13}$`).MatchString(signed) {
+		t.Errorf("signed query = %s", signed)
+	}
+}
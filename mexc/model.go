@@ -0,0 +1,172 @@
+package mexc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// mexcExchangeInfo MEXC 交易规则（/api/v3/exchangeInfo）
+type mexcExchangeInfo struct {
+	ServerTime types.ExTimestamp `json:"serverTime"`
+	Symbols    []mexcSymbol      `json:"symbols"`
+}
+
+// mexcSymbol MEXC 交易对信息，status 为 1 表示上线（2 暂停、3 下线），与 Binance 的 TRADING 不同
+type mexcSymbol struct {
+	Symbol               string          `json:"symbol"`               // 交易对，如 BTCUSDT
+	Status               string          `json:"status"`               // 状态：1 上线、2 暂停、3 下线
+	BaseAsset            string          `json:"baseAsset"`            // 基础货币
+	BaseAssetPrecision   int             `json:"baseAssetPrecision"`   // 数量精度（小数位）
+	QuoteAsset           string          `json:"quoteAsset"`           // 计价货币
+	QuotePrecision       int             `json:"quotePrecision"`       // 价格精度（小数位）
+	QuoteAmountPrecision types.ExDecimal `json:"quoteAmountPrecision"` // 最小下单金额
+	BaseSizePrecision    types.ExDecimal `json:"baseSizePrecision"`    // 数量步长（为 0 时按 baseAssetPrecision）
+	MaxQuoteAmount       types.ExDecimal `json:"maxQuoteAmount"`       // 最大下单金额
+	IsSpotTradingAllowed bool            `json:"isSpotTradingAllowed"` // 是否允许现货交易
+}
+
+// mexcTicker MEXC 24小时行情（/api/v3/ticker/24hr）
+type mexcTicker struct {
+	Symbol      string            `json:"symbol"`
+	LastPrice   types.ExDecimal   `json:"lastPrice"`
+	BidPrice    types.ExDecimal   `json:"bidPrice"`
+	AskPrice    types.ExDecimal   `json:"askPrice"`
+	OpenPrice   types.ExDecimal   `json:"openPrice"`
+	HighPrice   types.ExDecimal   `json:"highPrice"`
+	LowPrice    types.ExDecimal   `json:"lowPrice"`
+	Volume      types.ExDecimal   `json:"volume"`      // 24小时成交量（基础货币）
+	QuoteVolume types.ExDecimal   `json:"quoteVolume"` // 24小时成交额（计价货币）
+	CloseTime   types.ExTimestamp `json:"closeTime"`
+}
+
+// toTicker 转换为标准化行情，MEXC 不返回成交均价，VWAP 为 0
+func (t *mexcTicker) toTicker(symbol string) *model.Ticker {
+	return &model.Ticker{
+		Symbol:      symbol,
+		Bid:         t.BidPrice,
+		Ask:         t.AskPrice,
+		Last:        t.LastPrice,
+		Open:        t.OpenPrice,
+		High:        t.HighPrice,
+		Low:         t.LowPrice,
+		Volume:      t.Volume,
+		QuoteVolume: t.QuoteVolume,
+		Timestamp:   t.CloseTime,
+	}
+}
+
+// mexcOrderBook MEXC 深度（/api/v3/depth），档位为 [价格, 数量]
+type mexcOrderBook struct {
+	LastUpdateID int64               `json:"lastUpdateId"`
+	Bids         [][]types.ExDecimal `json:"bids"`
+	Asks         [][]types.ExDecimal `json:"asks"`
+	Timestamp    types.ExTimestamp   `json:"timestamp"`
+}
+
+// mexcKline MEXC K线 [openTime, open, high, low, close, volume, closeTime, quoteVolume]
+// 与 Binance 不同，MEXC 只返回 8 个字段（没有成交笔数和主动买入量）
+type mexcKline struct {
+	OpenTime    types.ExTimestamp
+	Open        types.ExDecimal
+	High        types.ExDecimal
+	Low         types.ExDecimal
+	Close       types.ExDecimal
+	Volume      types.ExDecimal
+	CloseTime   types.ExTimestamp
+	QuoteVolume types.ExDecimal
+}
+
+// UnmarshalJSON 自定义 JSON 反序列化，解析数组格式
+func (k *mexcKline) UnmarshalJSON(data []byte) error {
+	var arr []json.RawMessage
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	if len(arr) < 8 {
+		return fmt.Errorf("invalid kline array length: %d", len(arr))
+	}
+	fields := []json.Unmarshaler{&k.OpenTime, &k.Open, &k.High, &k.Low, &k.Close, &k.Volume, &k.CloseTime, &k.QuoteVolume}
+	for i, field := range fields {
+		if err := field.UnmarshalJSON(arr[i]); err != nil {
+			return fmt.Errorf("parse kline field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// mexcAccount MEXC 现货账户信息（/api/v3/account）
+type mexcAccount struct {
+	UpdateTime types.ExTimestamp `json:"updateTime"` // MEXC 通常返回 null
+	Balances   []struct {
+		Asset  string          `json:"asset"`
+		Free   types.ExDecimal `json:"free"`
+		Locked types.ExDecimal `json:"locked"`
+	} `json:"balances"`
+}
+
+// mexcCreateOrderResponse MEXC 下单响应，orderId 为字符串，不返回 clientOrderId
+type mexcCreateOrderResponse struct {
+	Symbol       string            `json:"symbol"`
+	OrderID      string            `json:"orderId"`
+	TransactTime types.ExTimestamp `json:"transactTime"`
+}
+
+// mexcOrder MEXC 查询订单响应（/api/v3/order）
+type mexcOrder struct {
+	Symbol              string            `json:"symbol"`              // 交易对
+	OrderID             string            `json:"orderId"`             // 订单ID
+	ClientOrderID       string            `json:"clientOrderId"`       // 客户端订单ID
+	Price               types.ExDecimal   `json:"price"`               // 订单价格
+	OrigQty             types.ExDecimal   `json:"origQty"`             // 订单数量
+	ExecutedQty         types.ExDecimal   `json:"executedQty"`         // 已成交数量
+	CummulativeQuoteQty types.ExDecimal   `json:"cummulativeQuoteQty"` // 已成交金额
+	Status              string            `json:"status"`              // 订单状态
+	TimeInForce         string            `json:"timeInForce"`         // 订单有效期
+	Type                string            `json:"type"`                // 订单类型
+	Side                string            `json:"side"`                // 订单方向
+	Time                types.ExTimestamp `json:"time"`                // 创建时间
+	UpdateTime          types.ExTimestamp `json:"updateTime"`          // 更新时间
+}
+
+// toSpotOrder 转换为标准化现货订单，均价按成交金额除以成交数量计算
+func (o *mexcOrder) toSpotOrder(symbol string) *model.SpotOrder {
+	orderType := model.OrderTypeLimit
+	if o.Type == "MARKET" {
+		orderType = model.OrderTypeMarket
+	}
+	side := model.OrderSideSell
+	if o.Side == "BUY" {
+		side = model.OrderSideBuy
+	}
+	var average decimal.Decimal
+	if o.ExecutedQty.IsPositive() {
+		average = o.CummulativeQuoteQty.Div(o.ExecutedQty.Decimal)
+	}
+
+	return &model.SpotOrder{
+		ID:            o.OrderID,
+		ClientOrderID: o.ClientOrderID,
+		Symbol:        symbol,
+		Type:          orderType,
+		Side:          side,
+		Amount:        o.OrigQty,
+		Price:         o.Price,
+		Filled:        o.ExecutedQty,
+		Remaining:     types.ExDecimal{Decimal: o.OrigQty.Sub(o.ExecutedQty.Decimal)},
+		Cost:          o.CummulativeQuoteQty,
+		Average:       types.ExDecimal{Decimal: average},
+		Status:        mexcOrderStatus(o.Status),
+		TimeInForce:   o.TimeInForce,
+		CreatedAt:     o.Time,
+		UpdatedAt:     o.UpdateTime,
+	}
+}
+
+// mexcTimeResponse MEXC 服务器时间
+type mexcTimeResponse struct {
+	ServerTime types.ExTimestamp `json:"serverTime"`
+}
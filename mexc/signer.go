@@ -0,0 +1,23 @@
+package mexc
+
+import (
+	"github.com/lemconn/exlink/common"
+)
+
+// Signer MEXC 签名工具
+type Signer struct {
+	secretKey string
+}
+
+// NewSigner 创建签名工具
+func NewSigner(secretKey string) *Signer {
+	return &Signer{
+		secretKey: secretKey,
+	}
+}
+
+// Sign 对查询字符串进行签名
+// MEXC 签名格式: hex(HMAC-SHA256(queryString, secretKey))，与 Binance 相同
+func (s *Signer) Sign(queryString string) string {
+	return common.SignHMAC256(queryString, s.secretKey)
+}
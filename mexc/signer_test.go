@@ -0,0 +1,13 @@
+package mexc
+
+import "testing"
+
+const testSecret = "NhqPtmdSJYdKjVHjA7PZj4Mge3R5YNiP1e3UZjInClVN65XAbvqqM6A7H5fATj0j"
+
+func TestSigner_Sign(t *testing.T) {
+	signer := NewSigner(testSecret)
+	query := "symbol=LTCBTC&side=BUY&type=LIMIT&timeInForce=GTC&quantity=1&price=0.1&recvWindow=5000&timestamp=1499827319559"
+	if got, want := signer.Sign(query), "c8db56825ae71d6d79447849e617115f4a920fa2acdcab2b053c4b2838bd6b71"; got != want {
+		t.Errorf("Sign = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package mexc
+
+import (
+	"fmt"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// mexcTimeframe 转换为 MEXC K线周期（1h -> 60m、1w -> 1W），不支持的周期原样返回
+func mexcTimeframe(timeframe string) string {
+	switch normalized := common.NormalizeTimeframe(timeframe); normalized {
+	case "1h":
+		return "60m"
+	case "1w":
+		return "1W"
+	default:
+		return normalized
+	}
+}
+
+// mexcOrderStatus 转换 MEXC 订单状态，PARTIALLY_CANCELED 为部分成交后撤单
+func mexcOrderStatus(status string) model.OrderStatus {
+	switch status {
+	case "NEW":
+		return model.OrderStatusNew
+	case "PARTIALLY_FILLED":
+		return model.OrderStatusOpen
+	case "FILLED":
+		return model.OrderStatusFilled
+	case "CANCELED", "PARTIALLY_CANCELED":
+		return model.OrderStatusCanceled
+	default:
+		return model.OrderStatusNew
+	}
+}
+
+// precisionStep 返回小数位数对应的步长（如 4 -> 0.0001）
+func precisionStep(places int) types.ExDecimal {
+	return types.ExDecimal{Decimal: decimal.New(1, -int32(places))}
+}
+
+// notSupported MEXC 适配器不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: not implemented for mexc", operation, common.ErrNotSupported)
+}
@@ -0,0 +1,493 @@
+package mock
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// order 模拟交易所内部订单（现货和合约共用）
+type order struct {
+	perp        bool
+	id          string
+	clientID    string
+	symbol      string
+	base        string // 现货基础货币
+	quote       string // 现货计价货币
+	buy         bool
+	perpSide    option.PerpOrderSide
+	orderType   option.OrderType
+	timeInForce option.TimeInForce
+	price       decimal.Decimal // 限价单价格
+	amount      decimal.Decimal
+	filled      decimal.Decimal
+	cost        decimal.Decimal // 累计成交额
+	reduceOnly  bool
+	status      model.OrderStatus
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// remaining 未成交数量
+func (o *order) remaining() decimal.Decimal {
+	return o.amount.Sub(o.filled)
+}
+
+// average 成交均价，未成交时为 0
+func (o *order) average() decimal.Decimal {
+	if !o.filled.IsPositive() {
+		return decimal.Zero
+	}
+	return o.cost.DivRound(o.filled, 16)
+}
+
+// crosses 判断对手价是否满足订单价格（市价单始终满足）
+func (o *order) crosses(price decimal.Decimal) bool {
+	if o.orderType.IsMarket() {
+		return true
+	}
+	if o.buy {
+		return price.LessThanOrEqual(o.price)
+	}
+	return price.GreaterThanOrEqual(o.price)
+}
+
+// spotOrder 转换为现货订单
+func (o *order) spotOrder() *model.SpotOrder {
+	side := model.OrderSideSell
+	if o.buy {
+		side = model.OrderSideBuy
+	}
+	orderType := model.OrderTypeLimit
+	if o.orderType.IsMarket() {
+		orderType = model.OrderTypeMarket
+	}
+	return &model.SpotOrder{
+		ID:            o.id,
+		ClientOrderID: o.clientID,
+		Symbol:        o.symbol,
+		Type:          orderType,
+		Side:          side,
+		Amount:        types.ExDecimal{Decimal: o.amount},
+		Price:         types.ExDecimal{Decimal: o.price},
+		Filled:        types.ExDecimal{Decimal: o.filled},
+		Remaining:     types.ExDecimal{Decimal: o.remaining()},
+		Cost:          types.ExDecimal{Decimal: o.cost},
+		Average:       types.ExDecimal{Decimal: o.average()},
+		Status:        o.status,
+		TimeInForce:   string(o.timeInForce),
+		CreatedAt:     types.ExTimestamp{Time: o.createdAt},
+		UpdatedAt:     types.ExTimestamp{Time: o.updatedAt},
+	}
+}
+
+// perpOrder 转换为合约订单
+func (o *order) perpOrder() *model.PerpOrder {
+	return &model.PerpOrder{
+		ID:               o.id,
+		ClientID:         o.clientID,
+		Type:             o.orderType.Upper(),
+		Side:             o.perpSide.ToSide(),
+		PositionSide:     o.perpSide.ToPositionSide(),
+		Symbol:           o.symbol,
+		Price:            types.ExDecimal{Decimal: o.price},
+		AvgPrice:         types.ExDecimal{Decimal: o.average()},
+		Quantity:         types.ExDecimal{Decimal: o.amount},
+		ExecutedQuantity: types.ExDecimal{Decimal: o.filled},
+		Status:           string(o.status),
+		TimeInForce:      string(o.timeInForce),
+		ReduceOnly:       o.reduceOnly,
+		CreateTime:       types.ExTimestamp{Time: o.createdAt},
+		UpdateTime:       types.ExTimestamp{Time: o.updatedAt},
+	}
+}
+
+// newOrder 转换为下单结果
+func (o *order) newOrder() *model.NewOrder {
+	return &model.NewOrder{
+		Symbol:        o.symbol,
+		OrderId:       o.id,
+		ClientOrderID: o.clientID,
+		Timestamp:     types.ExTimestamp{Time: o.createdAt},
+	}
+}
+
+// newOrderFromOptions 解析下单参数并校验，返回未撮合的订单
+func (m *Mock) newOrderFromOptions(perp bool, symbol, amount string, orderType option.OrderType, opts *option.ExchangeArgsOptions) (*order, error) {
+	if option.StringPresent(opts.StopPrice) {
+		return nil, fmt.Errorf("%w: mock exchange does not support conditional orders", common.ErrNotSupported)
+	}
+
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil || !amountDecimal.IsPositive() {
+		return nil, fmt.Errorf("%w: invalid amount %q", common.ErrInvalidOrder, amount)
+	}
+	if market, err := m.getMarket(perp, symbol); err == nil {
+		if _, err := common.AmountToPrecision(market, amount); err != nil {
+			return nil, err
+		}
+	}
+
+	o := &order{
+		perp:        perp,
+		symbol:      symbol,
+		orderType:   orderType,
+		timeInForce: option.GTC,
+		amount:      amountDecimal,
+		status:      model.OrderStatusNew,
+	}
+	if clientID, ok := option.GetString(opts.ClientOrderID); ok {
+		o.clientID = clientID
+	}
+	if orderType.IsLimit() {
+		price, ok := option.GetDecimalFromString(opts.Price)
+		if !ok || !price.IsPositive() {
+			return nil, fmt.Errorf("%w: limit order requires price > 0", common.ErrInvalidOrder)
+		}
+		o.price = price
+	}
+
+	if err := common.CheckTimeInForce(opts, orderType.IsLimit(), true); err != nil {
+		return nil, err
+	}
+	if opts.TimeInForce != nil {
+		o.timeInForce = *opts.TimeInForce
+	}
+	postOnly, err := common.ParsePostOnly(opts, orderType.IsLimit())
+	if err != nil {
+		return nil, err
+	}
+	if postOnly {
+		o.timeInForce = option.GTX
+	}
+	if orderType.IsMarket() {
+		o.timeInForce = option.IOC
+		if opts.TimeInForce != nil && opts.TimeInForce.IsFOK() {
+			o.timeInForce = option.FOK
+		}
+	}
+	return o, nil
+}
+
+// placeOrder 撮合新订单：只做 Maker 的订单会立即成交时拒绝；FOK 无法全部成交时过期；
+// 市价单和 IOC 未成交部分撤销，其余限价单未成交部分挂单。调用方需持有锁
+func (m *Mock) placeOrder(o *order) error {
+	hasData := m.hasMarketData(o.symbol)
+	if o.orderType.IsMarket() && !hasData {
+		return fmt.Errorf("%w: no ticker or order book set for %s", common.ErrInvalidOrder, o.symbol)
+	}
+	fillable, cost := m.preview(o)
+	if o.timeInForce.IsGTX() && fillable.IsPositive() {
+		return fmt.Errorf("%w: post-only order would take liquidity", common.ErrInvalidOrder)
+	}
+	if !o.perp {
+		if err := m.lockSpot(o, cost); err != nil {
+			return err
+		}
+	}
+
+	now := m.now()
+	o.id = m.newOrderID()
+	o.createdAt, o.updatedAt = now, now
+	m.orders[o.id] = o
+
+	switch {
+	case o.timeInForce.IsFOK() && fillable.LessThan(o.amount):
+		m.finish(o, model.OrderStatusExpired)
+	case o.orderType.IsMarket() || o.timeInForce.IsIOC():
+		m.fill(o)
+		m.finish(o, model.OrderStatusCanceled)
+	default:
+		m.fill(o)
+		if o.remaining().IsPositive() {
+			m.resting = append(m.resting, o)
+		}
+	}
+	return nil
+}
+
+// hasMarketData 判断交易对是否设置了行情或订单簿
+func (m *Mock) hasMarketData(symbol string) bool {
+	_, hasBook := m.books[symbol]
+	_, hasTicker := m.tickers[symbol]
+	return hasBook || hasTicker
+}
+
+// tickerPrice 只有行情时的对手价：买单按卖一价，卖单按买一价，缺失时按最新价
+func tickerPrice(ticker *model.Ticker, buy bool) decimal.Decimal {
+	price := ticker.Bid.Decimal
+	if buy {
+		price = ticker.Ask.Decimal
+	}
+	if !price.IsPositive() {
+		price = ticker.Last.Decimal
+	}
+	return price
+}
+
+// walk 按价格优先遍历订单可成交的对手盘（不消耗数量），visit 返回 false 时停止
+// 有订单簿时按档位数量，只有行情时按对手价一次成交全部剩余数量
+func (m *Mock) walk(o *order, visit func(qty, price decimal.Decimal) bool) {
+	remaining := o.remaining()
+	if book, ok := m.books[o.symbol]; ok {
+		levels := book.Bids
+		if o.buy {
+			levels = book.Asks
+		}
+		for _, level := range levels {
+			if !remaining.IsPositive() || !o.crosses(level.Price) {
+				return
+			}
+			qty := decimal.Min(remaining, level.Amount)
+			if !visit(qty, level.Price) {
+				return
+			}
+			remaining = remaining.Sub(qty)
+		}
+		return
+	}
+	if ticker, ok := m.tickers[o.symbol]; ok {
+		if price := tickerPrice(ticker, o.buy); price.IsPositive() && o.crosses(price) && remaining.IsPositive() {
+			visit(remaining, price)
+		}
+	}
+}
+
+// preview 计算订单当前可成交数量及成交额
+func (m *Mock) preview(o *order) (fillable, cost decimal.Decimal) {
+	m.walk(o, func(qty, price decimal.Decimal) bool {
+		fillable = fillable.Add(qty)
+		cost = cost.Add(qty.Mul(price))
+		return true
+	})
+	return fillable, cost
+}
+
+// fill 按当前对手盘成交订单，消耗订单簿档位数量
+func (m *Mock) fill(o *order) {
+	type execution struct{ qty, price decimal.Decimal }
+	var executions []execution
+	m.walk(o, func(qty, price decimal.Decimal) bool {
+		executions = append(executions, execution{qty, price})
+		return true
+	})
+
+	for _, e := range executions {
+		m.consume(o.symbol, o.buy, e.qty)
+		m.execute(o, e.qty, e.price)
+	}
+}
+
+// consume 从订单簿最优档开始扣减成交数量
+func (m *Mock) consume(symbol string, buy bool, qty decimal.Decimal) {
+	book, ok := m.books[symbol]
+	if !ok {
+		return
+	}
+	levels := &book.Bids
+	if buy {
+		levels = &book.Asks
+	}
+	for qty.IsPositive() && len(*levels) > 0 {
+		level := &(*levels)[0]
+		if qty.LessThan(level.Amount) {
+			level.Amount = level.Amount.Sub(qty)
+			return
+		}
+		qty = qty.Sub(level.Amount)
+		*levels = (*levels)[1:]
+	}
+}
+
+// execute 记录一笔成交并更新余额或持仓
+func (m *Mock) execute(o *order, qty, price decimal.Decimal) {
+	o.filled = o.filled.Add(qty)
+	o.cost = o.cost.Add(qty.Mul(price))
+	o.updatedAt = m.now()
+	if o.remaining().IsPositive() {
+		o.status = model.OrderStatusPartiallyFilled
+	} else {
+		o.status = model.OrderStatusFilled
+	}
+
+	if o.perp {
+		m.applyPosition(o, qty, price)
+	} else {
+		m.settleSpot(o, qty, price)
+	}
+}
+
+// finish 结束订单：全部成交时为 filled，否则为 status，并解冻未成交部分的余额
+func (m *Mock) finish(o *order, status model.OrderStatus) {
+	o.updatedAt = m.now()
+	if !o.remaining().IsPositive() {
+		o.status = model.OrderStatusFilled
+		return
+	}
+	o.status = status
+	if !o.perp {
+		m.unlockSpot(o, o.remaining())
+	}
+}
+
+// matchResting 按创建顺序撮合交易对的挂单，移除全部成交的订单。调用方需持有锁
+func (m *Mock) matchResting(symbol string) {
+	resting := m.resting[:0]
+	for _, o := range m.resting {
+		if o.symbol == symbol {
+			m.fill(o)
+		}
+		if o.remaining().IsPositive() {
+			resting = append(resting, o)
+		}
+	}
+	m.resting = resting
+}
+
+// removeResting 从挂单列表中移除订单
+func (m *Mock) removeResting(o *order) {
+	for i, r := range m.resting {
+		if r == o {
+			m.resting = append(m.resting[:i], m.resting[i+1:]...)
+			return
+		}
+	}
+}
+
+// findOrder 按订单ID（为空时按 option.WithClientOrderID）查找订单，symbol 为空时不限交易对。调用方需持有锁
+func (m *Mock) findOrder(perp bool, symbol, orderID string, opts *option.ExchangeArgsOptions) (*order, error) {
+	if orderID == "" {
+		clientID, ok := option.GetString(opts.ClientOrderID)
+		if !ok {
+			return nil, fmt.Errorf("order id or client order id is required")
+		}
+		for _, o := range m.orders {
+			if o.perp == perp && (symbol == "" || o.symbol == symbol) && o.clientID == clientID {
+				return o, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: client order id %s", common.ErrOrderNotFound, clientID)
+	}
+	o, ok := m.orders[orderID]
+	if !ok || o.perp != perp || symbol != "" && o.symbol != symbol {
+		return nil, fmt.Errorf("%w: %s", common.ErrOrderNotFound, orderID)
+	}
+	return o, nil
+}
+
+// cancelOrder 撤销挂单，已结束的订单返回 common.ErrOrderNotFound
+func (m *Mock) cancelOrder(perp bool, symbol, orderID string, opts *option.ExchangeArgsOptions) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, err := m.findOrder(perp, symbol, orderID, opts)
+	if err != nil {
+		return err
+	}
+	if common.IsTerminalOrderStatus(string(o.status)) {
+		return fmt.Errorf("%w: order %s is %s", common.ErrOrderNotFound, o.id, o.status)
+	}
+	m.removeResting(o)
+	m.finish(o, model.OrderStatusCanceled)
+	return nil
+}
+
+// editOrder 修改挂单数量和/或价格并按新价格撮合，新数量不能小于已成交数量
+func (m *Mock) editOrder(perp bool, symbol, orderID, newAmount, newPrice string, opts *option.ExchangeArgsOptions) (*order, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o, err := m.findOrder(perp, symbol, orderID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if common.IsTerminalOrderStatus(string(o.status)) {
+		return nil, fmt.Errorf("%w: order %s is %s", common.ErrOrderNotFound, o.id, o.status)
+	}
+
+	amount, price := o.amount, o.price
+	if newAmount != "" {
+		amount, _ = decimal.NewFromString(newAmount)
+	}
+	if newPrice != "" {
+		price, _ = decimal.NewFromString(newPrice)
+	}
+	if amount.LessThanOrEqual(o.filled) {
+		return nil, fmt.Errorf("%w: new amount %s must be greater than filled %s", common.ErrInvalidOrder, amount, o.filled)
+	}
+
+	if !perp {
+		// 按新数量和价格重新冻结余额，余额不足时恢复原订单
+		m.unlockSpot(o, o.remaining())
+		oldAmount, oldPrice := o.amount, o.price
+		o.amount, o.price = amount, price
+		if err := m.lockSpot(o, decimal.Zero); err != nil {
+			o.amount, o.price = oldAmount, oldPrice
+			_ = m.lockSpot(o, decimal.Zero)
+			return nil, err
+		}
+	} else {
+		o.amount, o.price = amount, price
+	}
+	o.updatedAt = m.now()
+
+	m.fill(o)
+	if !o.remaining().IsPositive() {
+		m.removeResting(o)
+	}
+	return o, nil
+}
+
+// lockSpot 冻结现货订单未成交部分所需余额：限价买单冻结计价货币，卖单冻结基础货币
+// 市价买单不冻结，要求可用计价货币不少于预估成交额 cost
+func (m *Mock) lockSpot(o *order, cost decimal.Decimal) error {
+	currency, required := o.base, o.remaining()
+	if o.buy {
+		currency, required = o.quote, cost
+		if o.orderType.IsLimit() {
+			required = o.remaining().Mul(o.price)
+		}
+	}
+	if m.balance(currency).Available.LessThan(required) {
+		return fmt.Errorf("%w: %s available %s, required %s", common.ErrInsufficientFunds, currency, m.balance(currency).Available.String(), required)
+	}
+	if o.buy && o.orderType.IsMarket() {
+		return nil
+	}
+	m.addBalance(currency, required.Neg(), required)
+	return nil
+}
+
+// unlockSpot 解冻现货订单 qty 数量对应的余额
+func (m *Mock) unlockSpot(o *order, qty decimal.Decimal) {
+	switch {
+	case !o.buy:
+		m.addBalance(o.base, qty, qty.Neg())
+	case o.orderType.IsLimit():
+		amount := qty.Mul(o.price)
+		m.addBalance(o.quote, amount, amount.Neg())
+	}
+}
+
+// settleSpot 现货成交结算：买入增加基础货币、扣减计价货币（限价单以挂单价冻结，差额退回）；卖出相反
+func (m *Mock) settleSpot(o *order, qty, price decimal.Decimal) {
+	cost := qty.Mul(price)
+	if o.buy {
+		if o.orderType.IsLimit() {
+			m.addBalance(o.quote, qty.Mul(o.price).Sub(cost), qty.Mul(o.price).Neg())
+		} else {
+			m.addBalance(o.quote, cost.Neg(), decimal.Zero)
+		}
+		m.addBalance(o.base, qty, decimal.Zero)
+		return
+	}
+	m.addBalance(o.base, decimal.Zero, qty.Neg())
+	m.addBalance(o.quote, cost, decimal.Zero)
+}
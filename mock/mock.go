@@ -0,0 +1,402 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+const mockName = "mock"
+
+// Mock 内存模拟交易所，用于在不请求真实 API 的情况下测试策略代码
+// 行情、订单簿、余额通过 SetTicker、SetOrderBook、SetBalance 等方法设置，订单按确定的规则撮合：
+// 市价单按最优价成交，限价单在价格交叉时成交，未成交部分挂单，之后行情或订单簿变化时继续撮合
+// 有订单簿时按档位数量成交（可能部分成交），只有行情时按买一/卖一价（缺失时按最新价）全部成交
+// 不计手续费；现货成交会更新余额，合约成交会更新持仓（不计算保证金）
+type Mock struct {
+	mu                  sync.Mutex
+	spotMarketsBySymbol map[string]*model.Market
+	spotMarketsByID     map[string]*model.Market
+	perpMarketsBySymbol map[string]*model.Market
+	perpMarketsByID     map[string]*model.Market
+	tickers             map[string]*model.Ticker    // 按 symbol 索引的行情
+	books               map[string]*model.OrderBook // 按 symbol 索引的订单簿（成交会消耗档位数量）
+	balances            map[string]*model.Balance   // 按币种索引的现货余额
+	orders              map[string]*order           // 按订单ID索引的全部订单
+	resting             []*order                    // 按创建顺序排列的未结束限价单
+	positions           map[string]*model.Position  // 按 symbol+方向索引的合约持仓
+	leverages           map[string]int              // 按 symbol+方向索引的杠杆
+	marginTypes         map[string]string           // 按 symbol 索引的保证金模式
+	hedged              bool                        // 是否为双向持仓
+	nextID              int64
+	now                 func() time.Time
+	spot                *MockSpot
+	perp                *MockPerp
+}
+
+// NewMock 创建模拟交易所实例
+func NewMock() *Mock {
+	m := &Mock{
+		spotMarketsBySymbol: make(map[string]*model.Market),
+		spotMarketsByID:     make(map[string]*model.Market),
+		perpMarketsBySymbol: make(map[string]*model.Market),
+		perpMarketsByID:     make(map[string]*model.Market),
+		tickers:             make(map[string]*model.Ticker),
+		books:               make(map[string]*model.OrderBook),
+		balances:            make(map[string]*model.Balance),
+		orders:              make(map[string]*order),
+		positions:           make(map[string]*model.Position),
+		leverages:           make(map[string]int),
+		marginTypes:         make(map[string]string),
+		now:                 time.Now,
+	}
+	m.spot = &MockSpot{mock: m}
+	m.perp = &MockPerp{mock: m}
+	return m
+}
+
+// Spot 获取现货交易接口
+func (m *Mock) Spot() exchange.SpotExchange {
+	return m.spot
+}
+
+// Perp 获取永续合约交易接口
+func (m *Mock) Perp() exchange.PerpExchange {
+	return m.perp
+}
+
+// Name 返回交易所名称
+func (m *Mock) Name() string {
+	return mockName
+}
+
+// Has 返回模拟交易所支持的功能（不支持K线、订阅、条件单和钱包操作）
+func (m *Mock) Has() model.Capabilities {
+	return model.Capabilities{
+		Spot: model.MarketCapabilities{
+			Supported:            true,
+			EditOrder:            true,
+			FetchOrder:           true,
+			FetchOrderByClientID: true,
+			TrackOrder:           true,
+		},
+		Perp: model.MarketCapabilities{
+			Supported:            true,
+			EditOrder:            true,
+			FetchOrder:           true,
+			FetchOrderByClientID: true,
+			TrackOrder:           true,
+			FetchMarkPrice:       true,
+			SetLeverage:          true,
+			SetLeverageSide:      true,
+			SetMarginMode:        true,
+			SetPositionMode:      true,
+			GetPositionMode:      true,
+		},
+	}
+}
+
+// SetClock 设置模拟交易所的时钟（订单、行情时间戳及 FetchTime 使用），nil 时恢复为 time.Now
+func (m *Mock) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if now == nil {
+		now = time.Now
+	}
+	m.now = now
+}
+
+// FetchTime 获取模拟交易所时间
+func (m *Mock) FetchTime(ctx context.Context) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now(), nil
+}
+
+// FetchStatus 模拟交易所始终为正常状态
+func (m *Mock) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &model.ExchangeStatus{Status: model.ExchangeStatusOK, Updated: types.ExTimestamp{Time: m.now()}}, nil
+}
+
+// Ping 模拟交易所始终可达
+func (m *Mock) Ping(ctx context.Context) error {
+	return nil
+}
+
+// VerifyCredentials 模拟交易所不校验凭证，始终成功
+func (m *Mock) VerifyCredentials(ctx context.Context) error {
+	return nil
+}
+
+// UpdateCredentials 模拟交易所不校验凭证，忽略
+func (m *Mock) UpdateCredentials(apiKey, secretKey, password string) {}
+
+// Symbols 返回通过 SetMarket 设置的现货和合约市场的标准化 symbol，按字母排序
+func (m *Mock) Symbols() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return common.MarketSymbols(m.spotMarketsBySymbol, m.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (m *Mock) NormalizeSymbol(nativeID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return common.SymbolByMarketID(nativeID, m.spotMarketsByID, m.perpMarketsByID, nil)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (m *Mock) DenormalizeSymbol(symbol string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return common.MarketIDBySymbol(symbol, m.spotMarketsBySymbol, m.perpMarketsBySymbol, nil)
+}
+
+// ExportMarkets 导出通过 SetMarket 设置的市场信息
+func (m *Mock) ExportMarkets() ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return common.MarshalMarkets(mockName, m.spotMarketsBySymbol, m.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已设置的市场
+func (m *Mock) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(mockName, data)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		m.spotMarketsBySymbol, m.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+	}
+	if len(snapshot.Perp) > 0 {
+		m.perpMarketsBySymbol, m.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+	}
+	return nil
+}
+
+// Drain 模拟交易所没有进行中的请求和轮询订阅，直接返回
+func (m *Mock) Drain(ctx context.Context) error {
+	return nil
+}
+
+// SetMarket 设置市场信息，market.Contract 为 true 时为合约市场，否则为现货市场
+// 设置后 AmountToPrecision、PriceToPrecision 按市场精度对齐，下单时数量低于最小下单量返回 common.ErrInvalidOrder
+func (m *Mock) SetMarket(market *model.Market) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if market.Contract {
+		m.perpMarketsBySymbol[market.Symbol] = market
+		m.perpMarketsByID[market.ID] = market
+	} else {
+		m.spotMarketsBySymbol[market.Symbol] = market
+		m.spotMarketsByID[market.ID] = market
+	}
+}
+
+// SetTicker 设置行情（Symbol 为空时使用 symbol），并按新价格撮合该交易对的挂单
+func (m *Mock) SetTicker(symbol string, ticker *model.Ticker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := *ticker
+	t.Symbol = symbol
+	if t.Timestamp.IsZero() {
+		t.Timestamp = types.ExTimestamp{Time: m.now()}
+	}
+	m.tickers[symbol] = &t
+	m.matchResting(symbol)
+}
+
+// SetOrderBook 设置订单簿（bids 价格从高到低，asks 价格从低到高），并按新订单簿撮合该交易对的挂单
+// 设置订单簿后按档位数量成交，成交会消耗对应档位的数量
+func (m *Mock) SetOrderBook(symbol string, bids, asks []model.OrderBookEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.books[symbol] = &model.OrderBook{
+		Symbol:    symbol,
+		Bids:      append([]model.OrderBookEntry(nil), bids...),
+		Asks:      append([]model.OrderBookEntry(nil), asks...),
+		Timestamp: types.ExTimestamp{Time: m.now()},
+	}
+	m.matchResting(symbol)
+}
+
+// SetBalance 设置现货币种的可用余额（不影响挂单冻结的余额）
+func (m *Mock) SetBalance(currency, amount string) error {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.balance(currency).Available = types.ExDecimal{Decimal: value}
+	m.refreshBalance(currency)
+	return nil
+}
+
+// balance 获取币种余额，不存在时创建
+func (m *Mock) balance(currency string) *model.Balance {
+	b, ok := m.balances[currency]
+	if !ok {
+		b = &model.Balance{Currency: currency}
+		m.balances[currency] = b
+	}
+	return b
+}
+
+// addBalance 调整币种的可用和冻结余额
+func (m *Mock) addBalance(currency string, available, locked decimal.Decimal) {
+	b := m.balance(currency)
+	b.Available = types.ExDecimal{Decimal: b.Available.Add(available)}
+	b.Locked = types.ExDecimal{Decimal: b.Locked.Add(locked)}
+	m.refreshBalance(currency)
+}
+
+// refreshBalance 更新币种总余额和更新时间
+func (m *Mock) refreshBalance(currency string) {
+	b := m.balances[currency]
+	b.Total = types.ExDecimal{Decimal: b.Available.Add(b.Locked.Decimal)}
+	b.UpdatedAt = types.ExTimestamp{Time: m.now()}
+}
+
+// sortedBalances 按币种排序的余额副本
+func (m *Mock) sortedBalances() model.Balances {
+	balances := make(model.Balances, 0, len(m.balances))
+	for _, b := range m.balances {
+		c := *b
+		balances = append(balances, &c)
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Currency < balances[j].Currency })
+	return balances
+}
+
+// getMarket 按 symbol 或 ID 获取已设置的市场
+func (m *Mock) getMarket(perp bool, key string) (*model.Market, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bySymbol, byID := m.spotMarketsBySymbol, m.spotMarketsByID
+	if perp {
+		bySymbol, byID = m.perpMarketsBySymbol, m.perpMarketsByID
+	}
+	if market, ok := bySymbol[key]; ok {
+		return market, nil
+	}
+	if market, ok := byID[key]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(key, bySymbol)
+}
+
+// getMarketByID 按 ID 获取已设置的市场
+func (m *Mock) getMarketByID(perp bool, id string) (*model.Market, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byID := m.spotMarketsByID
+	if perp {
+		byID = m.perpMarketsByID
+	}
+	if market, ok := byID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, byID)
+}
+
+// sortedMarkets 按 symbol 排序的市场列表
+func (m *Mock) sortedMarkets(perp bool) model.Markets {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	bySymbol := m.spotMarketsBySymbol
+	if perp {
+		bySymbol = m.perpMarketsBySymbol
+	}
+	markets := make(model.Markets, 0, len(bySymbol))
+	for _, market := range bySymbol {
+		markets = append(markets, market)
+	}
+	sort.Slice(markets, func(i, j int) bool { return markets[i].Symbol < markets[j].Symbol })
+	return markets
+}
+
+// fetchTicker 获取行情副本
+func (m *Mock) fetchTicker(symbol string) (*model.Ticker, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ticker, ok := m.tickers[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no ticker set for %s", symbol)
+	}
+	t := *ticker
+	return &t, nil
+}
+
+// fetchTickers 获取全部行情副本，filter 为 nil 时不过滤
+func (m *Mock) fetchTickers(filter func(symbol string) bool) map[string]*model.Ticker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tickers := make(map[string]*model.Ticker, len(m.tickers))
+	for symbol, ticker := range m.tickers {
+		if filter != nil && !filter(symbol) {
+			continue
+		}
+		t := *ticker
+		tickers[symbol] = &t
+	}
+	return tickers
+}
+
+// fetchOrderBook 获取订单簿副本，limit > 0 时每侧最多返回 limit 档
+func (m *Mock) fetchOrderBook(symbol string, limit int) (*model.OrderBook, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no order book set for %s", symbol)
+	}
+	bids, asks := book.Bids, book.Asks
+	if limit > 0 && len(bids) > limit {
+		bids = bids[:limit]
+	}
+	if limit > 0 && len(asks) > limit {
+		asks = asks[:limit]
+	}
+	return &model.OrderBook{
+		Symbol:    symbol,
+		Bids:      append([]model.OrderBookEntry(nil), bids...),
+		Asks:      append([]model.OrderBookEntry(nil), asks...),
+		Nonce:     book.Nonce,
+		Timestamp: book.Timestamp,
+	}, nil
+}
+
+// newOrderID 生成递增的订单ID
+func (m *Mock) newOrderID() string {
+	m.nextID++
+	return strconv.FormatInt(m.nextID, 10)
+}
+
+// splitSymbol 由标准化 symbol（如 BTC/USDT、BTC/USDT:USDT）解析基础货币和计价货币
+func splitSymbol(symbol string) (base, quote string, err error) {
+	symbol, _, _ = strings.Cut(symbol, ":")
+	base, quote, ok := strings.Cut(symbol, "/")
+	if !ok || base == "" || quote == "" {
+		return "", "", fmt.Errorf("invalid symbol: %s", symbol)
+	}
+	return base, quote, nil
+}
+
+var _ exchange.Exchange = (*Mock)(nil)
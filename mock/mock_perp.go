@@ -0,0 +1,502 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// MockPerp 模拟交易所永续合约接口
+// 持仓按交易对和方向（多/空）分别记录，开仓按成交均价计算开仓价，平仓累计已实现盈亏；不计算保证金和强平
+type MockPerp struct {
+	mock *Mock
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 市场信息通过 Mock.SetMarket 设置，无需加载
+func (p *MockPerp) LoadMarkets(ctx context.Context, reload bool) error {
+	return nil
+}
+
+// FetchMarkets 获取已设置的合约市场列表
+func (p *MockPerp) FetchMarkets(ctx context.Context, opts ...option.ArgsOption) (model.Markets, error) {
+	return p.mock.sortedMarkets(true), nil
+}
+
+// GetMarket 获取单个市场信息
+func (p *MockPerp) GetMarket(symbol string) (*model.Market, error) {
+	return p.mock.getMarket(true, symbol)
+}
+
+// GetMarketByID 按市场ID获取市场信息
+func (p *MockPerp) GetMarketByID(id string) (*model.Market, error) {
+	return p.mock.getMarketByID(true, id)
+}
+
+// AmountToPrecision 将数量对齐到市场数量步长
+func (p *MockPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+// PriceToPrecision 将价格对齐到市场价格步长
+func (p *MockPerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// AmountToContracts 将币数量换算为合约张数
+func (p *MockPerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+// ContractsToAmount 将合约张数换算为币数量
+func (p *MockPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
+}
+
+// FetchTicker 获取通过 Mock.SetTicker 设置的行情
+func (p *MockPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return p.mock.fetchTicker(symbol)
+}
+
+// FetchTickers 获取全部合约行情（按 symbol 排序），可通过 option.WithSymbols 过滤
+func (p *MockPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (model.Tickers, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	wanted := make(map[string]bool, len(options.Symbols))
+	for _, symbol := range options.Symbols {
+		wanted[symbol] = true
+	}
+
+	tickers := make(model.Tickers, 0)
+	for symbol, ticker := range p.mock.fetchTickers(func(symbol string) bool { return !isSpotSymbol(symbol) }) {
+		if len(wanted) == 0 || wanted[symbol] {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Slice(tickers, func(i, j int) bool { return tickers[i].Symbol < tickers[j].Symbol })
+	return tickers, nil
+}
+
+// FetchOrderBook 获取通过 Mock.SetOrderBook 设置的订单簿（包含已被成交消耗的变化）
+func (p *MockPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return p.mock.fetchOrderBook(symbol, limit)
+}
+
+// WatchTicker 模拟交易所不支持 WebSocket 订阅
+func (p *MockPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+// WatchOrderBook 模拟交易所不支持 WebSocket 订阅
+func (p *MockPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 模拟交易所不提供K线数据
+func (p *MockPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	return nil, notSupported("fetch ohlcv")
+}
+
+// FetchOHLCVRange 模拟交易所不提供K线数据
+func (p *MockPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return nil, notSupported("fetch ohlcv")
+}
+
+// WatchOHLCV 模拟交易所不提供K线数据
+func (p *MockPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+// PollOHLCV 模拟交易所不提供K线数据
+func (p *MockPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("poll ohlcv")
+}
+
+// FetchAggregatedTrades 模拟交易所不提供成交数据
+func (p *MockPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// FetchFundingRate 模拟交易所不提供资金费率
+func (p *MockPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	return nil, notSupported("fetch funding rate")
+}
+
+// FetchFundingRateHistory 模拟交易所不提供资金费率
+func (p *MockPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	return nil, notSupported("fetch funding rate history")
+}
+
+// FetchOpenInterest 模拟交易所不提供持仓量
+func (p *MockPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	return nil, notSupported("fetch open interest")
+}
+
+// FetchMarkPrice 获取行情中的标记价格，未设置时使用最新价
+func (p *MockPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	ticker, err := p.mock.fetchTicker(symbol)
+	if err != nil {
+		return nil, err
+	}
+	if !ticker.MarkPrice.IsPositive() {
+		ticker.MarkPrice = ticker.Last
+	}
+	return ticker, nil
+}
+
+// FetchIndexPrice 获取行情中的指数价格，未设置时返回 common.ErrNotSupported
+func (p *MockPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	ticker, err := p.mock.fetchTicker(symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if !ticker.IndexPrice.IsPositive() {
+		return decimal.Zero, notSupported("fetch index price")
+	}
+	return ticker.IndexPrice.Decimal, nil
+}
+
+// ========== 账户信息 ==========
+
+// FetchPositions 获取持仓（按交易对和方向排序），未实现盈亏按标记价格（未设置时为最新价）计算
+// 可通过 option.WithSymbol 过滤交易对
+func (p *MockPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	symbol, filter := option.GetString(options.Symbol)
+
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	positions := make(model.Positions, 0, len(p.mock.positions))
+	for _, position := range p.mock.positions {
+		if filter && position.Symbol != symbol {
+			continue
+		}
+		pos := *position
+		if ticker, ok := p.mock.tickers[pos.Symbol]; ok {
+			mark := ticker.MarkPrice.Decimal
+			if !mark.IsPositive() {
+				mark = ticker.Last.Decimal
+			}
+			pos.MarkPrice = types.ExDecimal{Decimal: mark}
+			pnl := mark.Sub(pos.EntryPrice.Decimal).Mul(pos.Amount.Decimal)
+			if pos.Side == string(model.PositionSideShort) {
+				pnl = pnl.Neg()
+			}
+			pos.UnrealizedPnl = types.ExDecimal{Decimal: pnl}
+		}
+		positions = append(positions, &pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Symbol != positions[j].Symbol {
+			return positions[i].Symbol < positions[j].Symbol
+		}
+		return positions[i].Side < positions[j].Side
+	})
+	return positions, nil
+}
+
+// WatchPositions 模拟交易所不支持持仓订阅
+func (p *MockPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	return nil, notSupported("watch positions")
+}
+
+// WatchBalance 模拟交易所不支持 WebSocket 订阅
+func (p *MockPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单并立即按当前行情撮合
+// 平仓订单数量超过当前持仓时返回 common.ErrInvalidOrder
+func (p *MockPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if orderSide.ToSide() == "" {
+		return nil, fmt.Errorf("%w: invalid order side %q", common.ErrInvalidOrder, orderSide)
+	}
+	if !orderType.IsMarket() && !orderType.IsLimit() {
+		return nil, fmt.Errorf("%w: invalid order type %q", common.ErrInvalidOrder, orderType)
+	}
+	if option.StringPresent(options.TrailingCallbackRate) {
+		return nil, notSupported("trailing stop order")
+	}
+	o, err := p.mock.newOrderFromOptions(true, symbol, amount, orderType, options)
+	if err != nil {
+		return nil, err
+	}
+	o.perpSide, o.buy = orderSide, orderSide.ToSide() == "BUY"
+	o.reduceOnly = common.ReduceOnly(orderSide, options)
+
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	if orderSide.ToReduceOnly() {
+		held := decimal.Zero
+		if position, ok := p.mock.positions[positionKey(symbol, positionSide(orderSide))]; ok {
+			held = position.Amount.Decimal
+		}
+		if o.amount.GreaterThan(held) {
+			return nil, fmt.Errorf("%w: close amount %s exceeds position %s", common.ErrInvalidOrder, o.amount, held)
+		}
+	}
+	if err := p.mock.placeOrder(o); err != nil {
+		return nil, err
+	}
+	return o.newOrder(), nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (p *MockPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.PerpOrderRequest) (*model.NewOrder, error) {
+		return p.CreateOrder(ctx, r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+	})
+}
+
+// ClosePosition 以只减仓市价单平掉持仓
+func (p *MockPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	_, side, amount, err := common.ClosePositionOrder(positions, symbol, options)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
+}
+
+// CancelOrder 撤销挂单
+func (p *MockPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return p.mock.cancelOrder(true, symbol, orderId, options)
+}
+
+// EditOrder 修改挂单数量和/或价格，修改后按新价格撮合
+func (p *MockPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	o, err := p.mock.editOrder(true, symbol, orderId, newAmount, newPrice, options)
+	if err != nil {
+		return nil, err
+	}
+	return o.perpOrder(), nil
+}
+
+// FetchOrder 查询订单
+func (p *MockPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	o, err := p.mock.findOrder(true, symbol, orderId, options)
+	if err != nil {
+		return nil, err
+	}
+	return o.perpOrder(), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (p *MockPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 模拟交易所不记录成交明细
+func (p *MockPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+// TrackOrder 轮询订单成交进度
+func (p *MockPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return common.PollOrderFills(ctx, orderId, symbol, 0, func(ctx context.Context) (*common.OrderFillSnapshot, error) {
+		order, err := p.FetchOrder(ctx, symbol, orderId)
+		if err != nil {
+			return nil, err
+		}
+		return common.PerpOrderFillSnapshot(order), nil
+	})
+}
+
+// WatchOrders 模拟交易所不支持 WebSocket 订阅
+func (p *MockPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 合约特有功能 ==========
+
+// SetLeverage 设置杠杆（记录在持仓的 Leverage 中）
+func (p *MockPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
+	if leverage <= 0 {
+		return fmt.Errorf("invalid leverage: %d", leverage)
+	}
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	p.mock.setLeverage(symbol, string(types.PositionSideLong), leverage)
+	p.mock.setLeverage(symbol, string(types.PositionSideShort), leverage)
+	return nil
+}
+
+// SetLeverageSide 单独设置多头或空头杠杆，通过 SetMarket 设置了市场时按 market.Limits.Leverage 校验
+func (p *MockPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	if err := common.CheckPositionSide(side); err != nil {
+		return err
+	}
+	if leverage <= 0 {
+		return fmt.Errorf("invalid leverage: %d", leverage)
+	}
+	if market, err := p.GetMarket(symbol); err == nil {
+		if err := common.CheckLeverage(market, leverage); err != nil {
+			return err
+		}
+	}
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	p.mock.setLeverage(symbol, side.Lower(), leverage)
+	return nil
+}
+
+// setLeverage 记录 symbol 一个方向的杠杆并同步到已有持仓，调用方需持有锁
+func (m *Mock) setLeverage(symbol, side string, leverage int) {
+	key := positionKey(symbol, side)
+	m.leverages[key] = leverage
+	if position, ok := m.positions[key]; ok {
+		position.Leverage = types.ExDecimal{Decimal: decimal.NewFromInt(int64(leverage))}
+	}
+}
+
+// SetMarginType 设置保证金类型（记录在持仓的 MarginMode 中）
+func (p *MockPerp) SetMarginType(ctx context.Context, symbol string, marginType option.MarginType, opts ...option.ArgsOption) error {
+	var mode string
+	switch {
+	case marginType.IsIsolated():
+		mode = model.MarginModeIsolated
+	case marginType.IsCrossed():
+		mode = model.MarginModeCross
+	default:
+		return fmt.Errorf("invalid margin type: %s", marginType)
+	}
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	p.mock.marginTypes[symbol] = mode
+	for _, position := range p.mock.positions {
+		if position.Symbol == symbol {
+			position.MarginMode = mode
+		}
+	}
+	return nil
+}
+
+// SetPositionMode 设置持仓模式（只记录，持仓始终按多空方向分别记录）
+func (p *MockPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	p.mock.hedged = hedged
+	return nil
+}
+
+// GetPositionMode 查询持仓模式
+func (p *MockPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	p.mock.mu.Lock()
+	defer p.mock.mu.Unlock()
+	return p.mock.hedged, nil
+}
+
+// applyPosition 合约成交更新持仓：开仓按成交均价更新开仓价，平仓累计已实现盈亏，持仓归零时移除
+func (m *Mock) applyPosition(o *order, qty, price decimal.Decimal) {
+	side := positionSide(o.perpSide)
+	key := positionKey(o.symbol, side)
+	position, ok := m.positions[key]
+	if !ok {
+		if o.perpSide.ToReduceOnly() {
+			return
+		}
+		position = &model.Position{Symbol: o.symbol, Side: side, MarginMode: model.MarginModeCross}
+		if mode, ok := m.marginTypes[o.symbol]; ok {
+			position.MarginMode = mode
+		}
+		if leverage, ok := m.leverages[key]; ok {
+			position.Leverage = types.ExDecimal{Decimal: decimal.NewFromInt(int64(leverage))}
+		}
+		m.positions[key] = position
+	}
+	position.Timestamp = types.ExTimestamp{Time: m.now()}
+
+	amount := position.Amount.Decimal
+	if !o.perpSide.ToReduceOnly() {
+		total := amount.Add(qty)
+		entry := position.EntryPrice.Mul(amount).Add(price.Mul(qty)).DivRound(total, 16)
+		position.Amount = types.ExDecimal{Decimal: total}
+		position.EntryPrice = types.ExDecimal{Decimal: entry}
+		return
+	}
+
+	closed := decimal.Min(qty, amount)
+	pnl := price.Sub(position.EntryPrice.Decimal).Mul(closed)
+	if side == string(model.PositionSideShort) {
+		pnl = pnl.Neg()
+	}
+	position.RealizedPnl = types.ExDecimal{Decimal: position.RealizedPnl.Add(pnl)}
+	position.Amount = types.ExDecimal{Decimal: amount.Sub(closed)}
+	if !position.Amount.IsPositive() {
+		delete(m.positions, key)
+	}
+}
+
+// positionSide 开平仓方向对应的持仓方向（long/short）
+func positionSide(orderSide option.PerpOrderSide) string {
+	return strings.ToLower(orderSide.ToPositionSide())
+}
+
+// positionKey 持仓索引
+func positionKey(symbol, side string) string {
+	return symbol + "|" + side
+}
+
+var _ exchange.PerpExchange = (*MockPerp)(nil)
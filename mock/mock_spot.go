@@ -0,0 +1,328 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/shopspring/decimal"
+)
+
+// MockSpot 模拟交易所现货接口
+type MockSpot struct {
+	mock *Mock
+}
+
+// ========== 市场数据 ==========
+
+// LoadMarkets 市场信息通过 Mock.SetMarket 设置，无需加载
+func (s *MockSpot) LoadMarkets(ctx context.Context, reload bool) error {
+	return nil
+}
+
+// FetchMarkets 获取已设置的现货市场列表
+func (s *MockSpot) FetchMarkets(ctx context.Context) ([]*model.Market, error) {
+	return s.mock.sortedMarkets(false), nil
+}
+
+// GetMarket 获取单个市场信息
+func (s *MockSpot) GetMarket(symbol string) (*model.Market, error) {
+	return s.mock.getMarket(false, symbol)
+}
+
+// GetMarketByID 按市场ID获取市场信息
+func (s *MockSpot) GetMarketByID(id string) (*model.Market, error) {
+	return s.mock.getMarketByID(false, id)
+}
+
+// GetMarkets 获取已设置的现货市场列表
+func (s *MockSpot) GetMarkets() ([]*model.Market, error) {
+	return s.mock.sortedMarkets(false), nil
+}
+
+// AmountToPrecision 将数量对齐到市场数量步长
+func (s *MockSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+// PriceToPrecision 将价格对齐到市场价格步长
+func (s *MockSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *MockSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
+// FetchTicker 获取通过 Mock.SetTicker 设置的行情
+func (s *MockSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
+	return s.mock.fetchTicker(symbol)
+}
+
+// FetchTickers 获取全部现货行情
+func (s *MockSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	return s.mock.fetchTickers(isSpotSymbol), nil
+}
+
+// FetchTickersOrdered 批量获取行情，结果与 symbols 顺序一一对应，缺失的交易对为 nil
+func (s *MockSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+// FetchOrderBook 获取通过 Mock.SetOrderBook 设置的订单簿（包含已被成交消耗的变化）
+func (s *MockSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return s.mock.fetchOrderBook(symbol, limit)
+}
+
+// WatchTicker 模拟交易所不支持 WebSocket 订阅
+func (s *MockSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	return nil, notSupported("watch ticker")
+}
+
+// WatchOrderBook 模拟交易所不支持 WebSocket 订阅
+func (s *MockSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	return nil, notSupported("watch order book")
+}
+
+// FetchOHLCVs 模拟交易所不提供K线数据
+func (s *MockSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
+	return nil, notSupported("fetch ohlcv")
+}
+
+// FetchOHLCVRange 模拟交易所不提供K线数据
+func (s *MockSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return nil, notSupported("fetch ohlcv")
+}
+
+// WatchOHLCV 模拟交易所不提供K线数据
+func (s *MockSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("watch ohlcv")
+}
+
+// PollOHLCV 模拟交易所不提供K线数据
+func (s *MockSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	return nil, notSupported("poll ohlcv")
+}
+
+// FetchAggregatedTrades 模拟交易所不提供成交数据
+func (s *MockSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return nil, notSupported("fetch aggregated trades")
+}
+
+// ========== 账户信息 ==========
+
+// FetchBalance 获取现货余额（按币种排序），其他账户类型返回 common.ErrNotSupported
+func (s *MockSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if accountType := option.GetAccountType(options.AccountType); accountType != option.AccountSpot {
+		return nil, notSupported(fmt.Sprintf("fetch %s balance", accountType))
+	}
+
+	s.mock.mu.Lock()
+	defer s.mock.mu.Unlock()
+	return s.mock.sortedBalances(), nil
+}
+
+// WatchBalance 模拟交易所不支持 WebSocket 订阅
+func (s *MockSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	return nil, notSupported("watch balance")
+}
+
+// ========== 订单操作 ==========
+
+// CreateOrder 创建订单并立即按当前行情撮合（设置 WithPrice 时为限价单，否则为市价单）
+// 限价买单冻结计价货币，卖单冻结基础货币，可用余额不足时返回 common.ErrInsufficientFunds
+func (s *MockSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	base, quote, err := splitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	orderType := option.Market
+	if option.StringPresent(options.Price) {
+		orderType = option.Limit
+	}
+	o, err := s.mock.newOrderFromOptions(false, symbol, amount, orderType, options)
+	if err != nil {
+		return nil, err
+	}
+	o.base, o.quote, o.buy = base, quote, side == option.Buy
+
+	s.mock.mu.Lock()
+	defer s.mock.mu.Unlock()
+	if err := s.mock.placeOrder(o); err != nil {
+		return nil, err
+	}
+	return o.newOrder(), nil
+}
+
+// CreateOrders 批量创建订单（逐个提交）
+func (s *MockSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	return common.CreateOrdersSequential(ctx, requests, func(ctx context.Context, r option.SpotOrderRequest) (*model.NewOrder, error) {
+		return s.CreateOrder(ctx, r.Symbol, r.Side, r.Amount, r.Opts...)
+	})
+}
+
+func (s *MockSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return nil, notSupported("create oco order")
+}
+
+// CancelOrder 撤销挂单并解冻未成交部分的余额
+func (s *MockSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return s.mock.cancelOrder(false, symbol, orderId, options)
+}
+
+// EditOrder 修改挂单数量和/或价格，修改后按新价格撮合
+func (s *MockSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	o, err := s.mock.editOrder(false, symbol, orderId, newAmount, newPrice, options)
+	if err != nil {
+		return nil, err
+	}
+	return o.spotOrder(), nil
+}
+
+// FetchOrder 查询订单
+func (s *MockSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	s.mock.mu.Lock()
+	defer s.mock.mu.Unlock()
+	o, err := s.mock.findOrder(false, symbol, orderId, options)
+	if err != nil {
+		return nil, err
+	}
+	return o.spotOrder(), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *MockSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 模拟交易所不记录成交明细
+func (s *MockSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	return nil, notSupported("fetch my trades")
+}
+
+// TrackOrder 轮询订单成交进度
+func (s *MockSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	return common.PollOrderFills(ctx, orderId, symbol, 0, func(ctx context.Context) (*common.OrderFillSnapshot, error) {
+		order, err := s.FetchOrder(ctx, symbol, orderId)
+		if err != nil {
+			return nil, err
+		}
+		return common.SpotOrderFillSnapshot(order), nil
+	})
+}
+
+// WatchOrders 模拟交易所不支持 WebSocket 订阅
+func (s *MockSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	return nil, notSupported("watch orders")
+}
+
+// ========== 闪兑 ==========
+
+// CreateConversion 模拟交易所不支持闪兑
+func (s *MockSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return nil, notSupported("create conversion")
+}
+
+// ========== 钱包 ==========
+
+// FetchCurrencies 模拟交易所不支持钱包操作
+func (s *MockSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return nil, notSupported("fetch currencies")
+}
+
+// FetchWithdrawalFee 模拟交易所不支持钱包操作
+func (s *MockSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return decimal.Zero, notSupported("fetch withdrawal fee")
+}
+
+// FetchDepositAddress 模拟交易所不支持钱包操作
+func (s *MockSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return nil, notSupported("fetch deposit address")
+}
+
+// Withdraw 模拟交易所不支持钱包操作
+func (s *MockSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return nil, notSupported("withdraw")
+}
+
+// Transfer 模拟交易所不支持账户划转
+func (s *MockSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return nil, notSupported("transfer")
+}
+
+// FetchBorrowRate 模拟交易所不支持杠杆借币
+func (s *MockSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return nil, notSupported("fetch borrow rate")
+}
+
+// Borrow 模拟交易所不支持杠杆借币
+func (s *MockSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return notSupported("borrow")
+}
+
+// Repay 模拟交易所不支持杠杆借币
+func (s *MockSpot) Repay(ctx context.Context, currency, amount string) error {
+	return notSupported("repay")
+}
+
+// FetchSubAccounts 模拟交易所不支持子账户
+func (s *MockSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return nil, notSupported("fetch sub accounts")
+}
+
+// FetchBalanceFor 模拟交易所不支持子账户
+func (s *MockSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return nil, notSupported("fetch balance for")
+}
+
+// isSpotSymbol 现货 symbol 不包含结算货币后缀
+func isSpotSymbol(symbol string) bool {
+	return !strings.Contains(symbol, ":")
+}
+
+// notSupported 模拟交易所不支持的操作
+func notSupported(operation string) error {
+	return fmt.Errorf("%s: %w: mock exchange does not support this operation", operation, common.ErrNotSupported)
+}
+
+var _ exchange.SpotExchange = (*MockSpot)(nil)
@@ -0,0 +1,400 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func dec(s string) types.ExDecimal {
+	return types.ExDecimal{Decimal: decimal.RequireFromString(s)}
+}
+
+func level(price, amount string) model.OrderBookEntry {
+	return model.OrderBookEntry{Price: decimal.RequireFromString(price), Amount: decimal.RequireFromString(amount)}
+}
+
+func balanceOf(t *testing.T, m *Mock, currency string) *model.Balance {
+	t.Helper()
+	balances, err := m.Spot().FetchBalance(context.Background())
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	for _, b := range balances {
+		if b.Currency == currency {
+			return b
+		}
+	}
+	return &model.Balance{Currency: currency}
+}
+
+func assertBalance(t *testing.T, m *Mock, currency, available, locked string) {
+	t.Helper()
+	b := balanceOf(t, m, currency)
+	if !b.Available.Equal(decimal.RequireFromString(available)) || !b.Locked.Equal(decimal.RequireFromString(locked)) {
+		t.Errorf("%s balance = %s available / %s locked, want %s / %s", currency, b.Available.String(), b.Locked.String(), available, locked)
+	}
+}
+
+func fetchSpotOrder(t *testing.T, m *Mock, symbol, orderID string) *model.SpotOrder {
+	t.Helper()
+	order, err := m.Spot().FetchOrder(context.Background(), symbol, orderID)
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	return order
+}
+
+func TestMockSpot_MarketOrderFillsAtBest(t *testing.T) {
+	m := NewMock()
+	m.SetTicker("BTC/USDT", &model.Ticker{Bid: dec("49990"), Ask: dec("50010"), Last: dec("50000")})
+	if err := m.SetBalance("USDT", "100000"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	buy, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1")
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	order := fetchSpotOrder(t, m, "BTC/USDT", buy.OrderId)
+	if order.Status != model.OrderStatusFilled || !order.Filled.Equal(decimal.NewFromInt(1)) || !order.Average.Equal(decimal.NewFromInt(50010)) {
+		t.Errorf("market buy = %s filled %s at %s, want filled 1 at 50010", order.Status, order.Filled.String(), order.Average.String())
+	}
+	assertBalance(t, m, "USDT", "49990", "0")
+	assertBalance(t, m, "BTC", "1", "0")
+
+	sell, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Sell, "0.5")
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order := fetchSpotOrder(t, m, "BTC/USDT", sell.OrderId); !order.Average.Equal(decimal.NewFromInt(49990)) {
+		t.Errorf("market sell average = %s, want 49990", order.Average.String())
+	}
+	assertBalance(t, m, "USDT", "74985", "0")
+	assertBalance(t, m, "BTC", "0.5", "0")
+}
+
+func TestMockSpot_LimitOrderFillsWhenCrossed(t *testing.T) {
+	m := NewMock()
+	m.SetTicker("BTC/USDT", &model.Ticker{Bid: dec("49990"), Ask: dec("50010")})
+	m.SetBalance("USDT", "60000")
+	ctx := context.Background()
+
+	created, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1", option.WithPrice("49000"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order := fetchSpotOrder(t, m, "BTC/USDT", created.OrderId); order.Status != model.OrderStatusNew {
+		t.Fatalf("status = %s, want new", order.Status)
+	}
+	assertBalance(t, m, "USDT", "11000", "49000")
+
+	// 卖一价跌破限价后按卖一价成交，冻结差额退回
+	m.SetTicker("BTC/USDT", &model.Ticker{Bid: dec("48890"), Ask: dec("48900")})
+	order := fetchSpotOrder(t, m, "BTC/USDT", created.OrderId)
+	if order.Status != model.OrderStatusFilled || !order.Average.Equal(decimal.NewFromInt(48900)) {
+		t.Errorf("order = %s at %s, want filled at 48900", order.Status, order.Average.String())
+	}
+	assertBalance(t, m, "USDT", "11100", "0")
+	assertBalance(t, m, "BTC", "1", "0")
+}
+
+func TestMockSpot_PartialFills(t *testing.T) {
+	m := NewMock()
+	m.SetOrderBook("BTC/USDT",
+		[]model.OrderBookEntry{level("99", "5")},
+		[]model.OrderBookEntry{level("100", "1"), level("101", "1"), level("105", "5")})
+	m.SetBalance("USDT", "1000")
+	ctx := context.Background()
+
+	// 限价 102 只能吃掉前两档，剩余挂单
+	created, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "3", option.WithPrice("102"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	order := fetchSpotOrder(t, m, "BTC/USDT", created.OrderId)
+	if order.Status != model.OrderStatusPartiallyFilled || !order.Filled.Equal(decimal.NewFromInt(2)) || !order.Cost.Equal(decimal.NewFromInt(201)) {
+		t.Fatalf("order = %s filled %s cost %s, want partially_filled 2 cost 201", order.Status, order.Filled.String(), order.Cost.String())
+	}
+	assertBalance(t, m, "USDT", "697", "102")
+
+	book, err := m.Spot().FetchOrderBook(ctx, "BTC/USDT", 0)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+	if len(book.Asks) != 1 || !book.Asks[0].Price.Equal(decimal.NewFromInt(105)) {
+		t.Errorf("asks = %v, want consumed down to 105", book.Asks)
+	}
+
+	// 新的订单簿出现 102 的卖单后挂单继续成交
+	m.SetOrderBook("BTC/USDT", nil, []model.OrderBookEntry{level("102", "0.4"), level("102.5", "5")})
+	order = fetchSpotOrder(t, m, "BTC/USDT", created.OrderId)
+	if order.Status != model.OrderStatusPartiallyFilled || !order.Filled.Equal(decimal.RequireFromString("2.4")) {
+		t.Fatalf("order = %s filled %s, want partially_filled 2.4", order.Status, order.Filled.String())
+	}
+	m.SetOrderBook("BTC/USDT", nil, []model.OrderBookEntry{level("101.5", "5")})
+	order = fetchSpotOrder(t, m, "BTC/USDT", created.OrderId)
+	if order.Status != model.OrderStatusFilled || !order.Cost.Equal(decimal.RequireFromString("302.7")) {
+		t.Errorf("order = %s cost %s, want filled cost 302.7", order.Status, order.Cost.String())
+	}
+	assertBalance(t, m, "USDT", "697.3", "0")
+	assertBalance(t, m, "BTC", "3", "0")
+
+	// 市价单深度不足时部分成交，剩余撤销并解冻
+	m.SetOrderBook("BTC/USDT", []model.OrderBookEntry{level("99", "1"), level("98", "0.5")}, nil)
+	market, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Sell, "3")
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	order = fetchSpotOrder(t, m, "BTC/USDT", market.OrderId)
+	if order.Status != model.OrderStatusCanceled || !order.Filled.Equal(decimal.RequireFromString("1.5")) || !order.Cost.Equal(decimal.NewFromInt(148)) {
+		t.Errorf("market sell = %s filled %s cost %s, want canceled filled 1.5 cost 148", order.Status, order.Filled.String(), order.Cost.String())
+	}
+	assertBalance(t, m, "BTC", "1.5", "0")
+	assertBalance(t, m, "USDT", "845.3", "0")
+}
+
+func TestMockSpot_TimeInForce(t *testing.T) {
+	m := NewMock()
+	m.SetOrderBook("BTC/USDT", []model.OrderBookEntry{level("99", "1")}, []model.OrderBookEntry{level("100", "1"), level("101", "1")})
+	m.SetBalance("USDT", "1000")
+	ctx := context.Background()
+
+	// FOK 无法全部成交时过期，不消耗订单簿
+	fok, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "3", option.WithPrice("101"), option.WithTimeInForce(option.FOK))
+	if err != nil {
+		t.Fatalf("CreateOrder FOK: %v", err)
+	}
+	if order := fetchSpotOrder(t, m, "BTC/USDT", fok.OrderId); order.Status != model.OrderStatusExpired || !order.Filled.IsZero() {
+		t.Errorf("FOK = %s filled %s, want expired unfilled", order.Status, order.Filled.String())
+	}
+	assertBalance(t, m, "USDT", "1000", "0")
+
+	// IOC 成交可成交部分，剩余撤销
+	ioc, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "3", option.WithPrice("100"), option.WithTimeInForce(option.IOC))
+	if err != nil {
+		t.Fatalf("CreateOrder IOC: %v", err)
+	}
+	if order := fetchSpotOrder(t, m, "BTC/USDT", ioc.OrderId); order.Status != model.OrderStatusCanceled || !order.Filled.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("IOC = %s filled %s, want canceled filled 1", order.Status, order.Filled.String())
+	}
+	assertBalance(t, m, "USDT", "900", "0")
+
+	// 只做 Maker 的订单会立即成交时拒绝
+	if _, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1", option.WithPrice("101"), option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only crossing: err = %v, want ErrInvalidOrder", err)
+	}
+	if _, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1", option.WithPrice("100.5"), option.WithPostOnly(true)); err != nil {
+		t.Errorf("post-only resting: %v", err)
+	}
+}
+
+func TestMockSpot_BalancesAndCancel(t *testing.T) {
+	m := NewMock()
+	m.SetTicker("ETH/USDT", &model.Ticker{Last: dec("2000")})
+	m.SetBalance("USDT", "1000")
+	ctx := context.Background()
+
+	if _, err := m.Spot().CreateOrder(ctx, "ETH/USDT", option.Buy, "1"); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("market buy: err = %v, want ErrInsufficientFunds", err)
+	}
+	if _, err := m.Spot().CreateOrder(ctx, "ETH/USDT", option.Sell, "1", option.WithPrice("2100")); !errors.Is(err, common.ErrInsufficientFunds) {
+		t.Errorf("limit sell: err = %v, want ErrInsufficientFunds", err)
+	}
+	if _, err := m.Spot().CreateOrder(ctx, "BTC/USDT", option.Buy, "1"); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("no market data: err = %v, want ErrInvalidOrder", err)
+	}
+
+	created, err := m.Spot().CreateOrder(ctx, "ETH/USDT", option.Buy, "0.4", option.WithPrice("1900"), option.WithClientOrderID("c-1"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	assertBalance(t, m, "USDT", "240", "760")
+
+	edited, err := m.Spot().EditOrder(ctx, "ETH/USDT", "", "0.5", "", option.WithClientOrderID("c-1"))
+	if err != nil {
+		t.Fatalf("EditOrder: %v", err)
+	}
+	if edited.ID != created.OrderId || !edited.Amount.Equal(decimal.RequireFromString("0.5")) {
+		t.Errorf("edited = %s amount %s, want %s amount 0.5", edited.ID, edited.Amount.String(), created.OrderId)
+	}
+	assertBalance(t, m, "USDT", "50", "950")
+
+	fetched, err := m.Spot().FetchOrderByClientID(ctx, "ETH/USDT", "c-1")
+	if err != nil {
+		t.Fatalf("FetchOrderByClientID: %v", err)
+	}
+	if fetched.ID != created.OrderId || fetched.ClientOrderID != "c-1" {
+		t.Errorf("fetched = %s client %s, want %s client c-1", fetched.ID, fetched.ClientOrderID, created.OrderId)
+	}
+
+	if err := m.Spot().CancelOrder(ctx, "ETH/USDT", created.OrderId); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	assertBalance(t, m, "USDT", "1000", "0")
+	if err := m.Spot().CancelOrder(ctx, "ETH/USDT", created.OrderId); !errors.Is(err, common.ErrOrderNotFound) {
+		t.Errorf("cancel twice: err = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestMockPerp_Positions(t *testing.T) {
+	m := NewMock()
+	const symbol = "BTC/USDT:USDT"
+	m.SetTicker(symbol, &model.Ticker{Bid: dec("50000"), Ask: dec("50000"), Last: dec("50000")})
+	ctx := context.Background()
+
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.CloseLong, option.Market); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("close without position: err = %v, want ErrInvalidOrder", err)
+	}
+
+	if err := m.Perp().SetLeverage(ctx, symbol, 10); err != nil {
+		t.Fatalf("SetLeverage: %v", err)
+	}
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.OpenLong, option.Market); err != nil {
+		t.Fatalf("open long: %v", err)
+	}
+	m.SetTicker(symbol, &model.Ticker{Bid: dec("52000"), Ask: dec("52000"), Last: dec("52000")})
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.OpenLong, option.Market); err != nil {
+		t.Fatalf("add long: %v", err)
+	}
+
+	// 限价平仓单挂单，价格上涨到限价后成交
+	created, err := m.Perp().CreateOrder(ctx, symbol, "0.5", option.CloseLong, option.Limit, option.WithPrice("55000"))
+	if err != nil {
+		t.Fatalf("close long: %v", err)
+	}
+	positions, err := m.Perp().FetchPositions(ctx)
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("positions = %d, want 1", len(positions))
+	}
+	p := positions[0]
+	if p.Side != "long" || !p.Amount.Equal(decimal.NewFromInt(2)) || !p.EntryPrice.Equal(decimal.NewFromInt(51000)) ||
+		!p.UnrealizedPnl.Equal(decimal.NewFromInt(2000)) || !p.Leverage.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("position = %s %s @ %s pnl %s x%s, want long 2 @ 51000 pnl 2000 x10",
+			p.Side, p.Amount.String(), p.EntryPrice.String(), p.UnrealizedPnl.String(), p.Leverage.String())
+	}
+
+	m.SetTicker(symbol, &model.Ticker{Bid: dec("55500"), Ask: dec("55500"), Last: dec("55500")})
+	order, err := m.Perp().FetchOrder(ctx, symbol, created.OrderId)
+	if err != nil {
+		t.Fatalf("FetchOrder: %v", err)
+	}
+	if order.Status != string(model.OrderStatusFilled) || !order.AvgPrice.Equal(decimal.NewFromInt(55500)) || !order.ReduceOnly {
+		t.Errorf("close order = %s at %s reduceOnly %v, want filled at 55500 reduce-only", order.Status, order.AvgPrice.String(), order.ReduceOnly)
+	}
+	positions, _ = m.Perp().FetchPositions(ctx, option.WithSymbol(symbol))
+	if len(positions) != 1 || !positions[0].Amount.Equal(decimal.RequireFromString("1.5")) || !positions[0].RealizedPnl.Equal(decimal.NewFromInt(2250)) {
+		t.Errorf("after close = %v, want 1.5 with realized pnl 2250", positions)
+	}
+
+	// 空头持仓与多头分开记录，全部平仓后移除
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.OpenShort, option.Market); err != nil {
+		t.Fatalf("open short: %v", err)
+	}
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.CloseShort, option.Market); err != nil {
+		t.Fatalf("close short: %v", err)
+	}
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1.5", option.CloseLong, option.Market); err != nil {
+		t.Fatalf("close long: %v", err)
+	}
+	if positions, _ := m.Perp().FetchPositions(ctx); len(positions) != 0 {
+		t.Errorf("positions = %d, want 0 after closing", len(positions))
+	}
+}
+
+func TestMockPerp_ClosePosition(t *testing.T) {
+	m := NewMock()
+	const symbol = "BTC/USDT:USDT"
+	m.SetTicker(symbol, &model.Ticker{Bid: dec("50000"), Ask: dec("50000"), Last: dec("50000")})
+	ctx := context.Background()
+
+	if _, err := m.Perp().ClosePosition(ctx, symbol); !errors.Is(err, common.ErrPositionNotFound) {
+		t.Errorf("close without position: err = %v, want ErrPositionNotFound", err)
+	}
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "2", option.OpenShort, option.Market); err != nil {
+		t.Fatalf("open short: %v", err)
+	}
+
+	if _, err := m.Perp().ClosePosition(ctx, symbol, option.WithClosePercent("25")); err != nil {
+		t.Fatalf("ClosePosition 25%%: %v", err)
+	}
+	positions, _ := m.Perp().FetchPositions(ctx, option.WithSymbol(symbol))
+	if len(positions) != 1 || positions[0].Side != "short" || !positions[0].Amount.Abs().Equal(decimal.RequireFromString("1.5")) {
+		t.Fatalf("after partial close = %v, want short 1.5", positions)
+	}
+
+	if _, err := m.Perp().ClosePosition(ctx, symbol); err != nil {
+		t.Fatalf("ClosePosition: %v", err)
+	}
+	if positions, _ = m.Perp().FetchPositions(ctx, option.WithSymbol(symbol)); len(positions) != 0 {
+		t.Errorf("after close = %v, want no positions", positions)
+	}
+}
+
+func TestMockPerp_SetLeverageSide(t *testing.T) {
+	m := NewMock()
+	const symbol = "BTC/USDT:USDT"
+	market := &model.Market{ID: "BTCUSDT", Symbol: symbol, Base: "BTC", Quote: "USDT", Settle: "USDT", Type: model.MarketTypeSwap, Contract: true, Linear: true}
+	market.Limits.Leverage.Max = types.ExDecimal{Decimal: decimal.NewFromInt(20)}
+	m.SetMarket(market)
+	m.SetTicker(symbol, &model.Ticker{Bid: dec("50000"), Ask: dec("50000"), Last: dec("50000")})
+	ctx := context.Background()
+
+	if err := m.Perp().SetLeverage(ctx, symbol, 10); err != nil {
+		t.Fatalf("SetLeverage: %v", err)
+	}
+	if err := m.Perp().SetLeverageSide(ctx, symbol, types.PositionSideShort, 3); err != nil {
+		t.Fatalf("SetLeverageSide: %v", err)
+	}
+	if err := m.Perp().SetLeverageSide(ctx, symbol, types.PositionSideLong, 25); !errors.Is(err, common.ErrLeverageExceeded) {
+		t.Errorf("over limit: err = %v, want ErrLeverageExceeded", err)
+	}
+	if _, err := m.Perp().CreateOrder(ctx, symbol, "1", option.OpenShort, option.Market); err != nil {
+		t.Fatalf("open short: %v", err)
+	}
+
+	positions, err := m.Perp().FetchPositions(ctx)
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 1 || positions[0].Side != "short" || !positions[0].Leverage.Equal(decimal.NewFromInt(3)) {
+		t.Fatalf("positions = %+v, want one short x3", positions)
+	}
+}
+
+func TestMockSpot_EstimateOrderCost(t *testing.T) {
+	m := NewMock()
+	market := &model.Market{ID: "ETHUSDT", Symbol: "ETH/USDT", Base: "ETH", Quote: "USDT", Type: model.MarketTypeSpot, Active: true}
+	market.Precision.StepSize = dec("0.001")
+	market.Limits.Cost.Min = dec("10")
+	m.SetMarket(market)
+	m.SetTicker("ETH/USDT", &model.Ticker{Bid: dec("1999"), Ask: dec("2001"), Last: dec("2000")})
+	ctx := context.Background()
+
+	// 市价买单按卖一价估算，低于 10 USDT 最小成交额时在下单前标记
+	estimate, err := m.Spot().EstimateOrderCost(ctx, "ETH/USDT", option.Buy, "0.004", "", option.WithFeeRate("0.001"))
+	if err != nil {
+		t.Fatalf("EstimateOrderCost: %v", err)
+	}
+	if estimate.Valid || !estimate.Market || estimate.Cost.String() != "8.004" || estimate.Fee.String() != "0.008004" {
+		t.Errorf("too-small estimate = %+v", estimate)
+	}
+
+	estimate, err = m.Spot().EstimateOrderCost(ctx, "ETH/USDT", option.Buy, "0.005", "2000")
+	if err != nil {
+		t.Fatalf("EstimateOrderCost: %v", err)
+	}
+	if !estimate.Valid || estimate.Cost.String() != "10" {
+		t.Errorf("estimate = %+v", estimate)
+	}
+}
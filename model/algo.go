@@ -0,0 +1,29 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// AlgoOrder 策略委托（网格等）
+type AlgoOrder struct {
+	// ID 策略ID
+	ID string `json:"id"`
+	// Symbol 交易对
+	Symbol string `json:"symbol"`
+	// Type 策略类型（如 grid、contract_grid）
+	Type string `json:"type"`
+	// Status 策略状态（starting/running/stopping/stopped 等，取交易所原始值）
+	Status string `json:"status"`
+	// MinPrice 区间最低价
+	MinPrice types.ExDecimal `json:"min_price"`
+	// MaxPrice 区间最高价
+	MaxPrice types.ExDecimal `json:"max_price"`
+	// GridNum 网格数量
+	GridNum int `json:"grid_num"`
+	// Investment 投入金额
+	Investment types.ExDecimal `json:"investment"`
+	// TotalPnl 总收益
+	TotalPnl types.ExDecimal `json:"total_pnl"`
+	// CreatedAt 创建时间
+	CreatedAt types.ExTimestamp `json:"created_at"`
+	// UpdatedAt 更新时间
+	UpdatedAt types.ExTimestamp `json:"updated_at"`
+}
@@ -8,9 +8,9 @@ type Balance struct {
 	Currency string `json:"currency"`
 	// Available 可用余额
 	Available types.ExDecimal `json:"available"`
-	// Locked 冻结余额
+	// Locked 冻结余额（合约和杠杆账户为已占用的保证金，即 Total - Available）
 	Locked types.ExDecimal `json:"locked"`
-	// Total 总余额
+	// Total 总余额（合约账户为包含未实现盈亏的保证金余额）
 	Total types.ExDecimal `json:"total"`
 	// Timestamp 更新时间
 	UpdatedAt types.ExTimestamp `json:"updated_at"`
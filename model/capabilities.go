@@ -0,0 +1,84 @@
+package model
+
+// Capabilities 交易所支持的功能（Exchange.Has 返回），用于在调用前判断功能是否可用，而不是在运行时才得到 common.ErrNotSupported
+// 只列出并非所有交易所都支持的功能；行情、余额、下单和撤单在已接入的市场类型上都可用
+type Capabilities struct {
+	// Spot 现货支持的功能
+	Spot MarketCapabilities `json:"spot"`
+	// Perp 永续合约支持的功能
+	Perp MarketCapabilities `json:"perp"`
+}
+
+// MarketCapabilities 单个市场类型支持的功能，合约专属功能在现货中始终为 false，现货专属功能在合约中始终为 false
+type MarketCapabilities struct {
+	// Supported 是否已接入该市场类型，为 false 时其他功能都为 false
+	Supported bool `json:"supported"`
+
+	// CreateStopOrder CreateOrder 是否支持条件单（option.WithStopPrice）
+	CreateStopOrder bool `json:"create_stop_order"`
+	// CreateOCOOrder 是否支持 OCO 订单（现货）
+	CreateOCOOrder bool `json:"create_oco_order"`
+	// CreateTrailingStopOrder CreateOrder 是否支持跟踪止损（option.WithTrailingStop，合约）
+	CreateTrailingStopOrder bool `json:"create_trailing_stop_order"`
+	// EditOrder 是否支持修改挂单
+	EditOrder bool `json:"edit_order"`
+	// FetchOrder 是否支持查询订单
+	FetchOrder bool `json:"fetch_order"`
+	// FetchOrderByClientID 是否支持按客户端订单ID查询订单
+	FetchOrderByClientID bool `json:"fetch_order_by_client_id"`
+	// TrackOrder 是否支持跟踪订单成交
+	TrackOrder bool `json:"track_order"`
+	// FetchAggregatedTrades 是否支持查询归集成交
+	FetchAggregatedTrades bool `json:"fetch_aggregated_trades"`
+	// FetchMyTrades 是否支持查询账户成交记录（FetchMyTradesRange）
+	FetchMyTrades bool `json:"fetch_my_trades"`
+
+	// WatchTicker 是否支持订阅行情
+	WatchTicker bool `json:"watch_ticker"`
+	// WatchOrderBook 是否支持订阅深度
+	WatchOrderBook bool `json:"watch_order_book"`
+	// WatchOHLCV 是否支持订阅K线
+	WatchOHLCV bool `json:"watch_ohlcv"`
+	// WatchOrders 是否支持订阅订单更新
+	WatchOrders bool `json:"watch_orders"`
+	// WatchBalance 是否支持订阅余额更新
+	WatchBalance bool `json:"watch_balance"`
+	// WatchPositions 是否支持订阅持仓更新（合约）
+	WatchPositions bool `json:"watch_positions"`
+
+	// FetchFundingRate 是否支持查询资金费率（合约）
+	FetchFundingRate bool `json:"fetch_funding_rate"`
+	// FetchFundingRateHistory 是否支持查询历史资金费率（合约）
+	FetchFundingRateHistory bool `json:"fetch_funding_rate_history"`
+	// FetchOpenInterest 是否支持查询持仓量（合约）
+	FetchOpenInterest bool `json:"fetch_open_interest"`
+	// FetchMarkPrice 是否支持查询标记价格（合约）
+	FetchMarkPrice bool `json:"fetch_mark_price"`
+	// FetchIndexPrice 是否支持查询指数价格（合约）
+	FetchIndexPrice bool `json:"fetch_index_price"`
+	// SetLeverage 是否支持设置杠杆（合约）
+	SetLeverage bool `json:"set_leverage"`
+	// SetLeverageSide 是否支持分别设置多空杠杆（合约）
+	SetLeverageSide bool `json:"set_leverage_side"`
+	// SetMarginMode 是否支持通过 API 设置保证金模式（合约）
+	SetMarginMode bool `json:"set_margin_mode"`
+	// SetPositionMode 是否支持设置持仓模式（合约）
+	SetPositionMode bool `json:"set_position_mode"`
+	// GetPositionMode 是否支持查询持仓模式（合约）
+	GetPositionMode bool `json:"get_position_mode"`
+
+	// CreateConversion 是否支持闪兑（现货）
+	CreateConversion bool `json:"create_conversion"`
+	// FetchCurrencies 是否支持查询币种和网络（现货）
+	FetchCurrencies bool `json:"fetch_currencies"`
+	// FetchDepositAddress 是否支持查询充值地址（现货）
+	FetchDepositAddress bool `json:"fetch_deposit_address"`
+	// Withdraw 是否支持提现（现货）
+	Withdraw bool `json:"withdraw"`
+	// Transfer 是否支持账户间划转（现货）
+	Transfer bool `json:"transfer"`
+	// Margin 是否支持全仓杠杆借币、还币和查询借币利率（现货）
+	Margin bool `json:"margin"`
+	// FetchSubAccounts 是否支持查询子账户及子账户余额（现货）
+	FetchSubAccounts bool `json:"fetch_sub_accounts"`
+}
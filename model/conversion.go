@@ -0,0 +1,25 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// Conversion 闪兑结果
+type Conversion struct {
+	// ID 闪兑订单ID
+	ID string `json:"id"`
+	// QuoteID 询价ID
+	QuoteID string `json:"quote_id"`
+	// From 卖出币种
+	From string `json:"from"`
+	// To 买入币种
+	To string `json:"to"`
+	// FromAmount 卖出数量
+	FromAmount types.ExDecimal `json:"from_amount"`
+	// ToAmount 买入数量
+	ToAmount types.ExDecimal `json:"to_amount"`
+	// Rate 成交汇率（1 个 From 可兑换的 To 数量）
+	Rate types.ExDecimal `json:"rate"`
+	// Status 闪兑状态
+	Status string `json:"status"`
+	// Timestamp 时间戳
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// FundingRate 资金费率
+type FundingRate struct {
+	// Symbol 交易对
+	Symbol string `json:"symbol"`
+	// Rate 资金费率（当期预测费率或历史结算费率）
+	Rate types.ExDecimal `json:"rate"`
+	// Timestamp 费率时间（当期费率为获取时间，历史费率为结算时间）
+	Timestamp types.ExTimestamp `json:"timestamp"`
+	// NextFundingTime 下次结算时间（历史费率为零值）
+	NextFundingTime types.ExTimestamp `json:"next_funding_time"`
+}
+
+// FundingRates 资金费率数组
+type FundingRates []*FundingRate
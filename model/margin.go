@@ -0,0 +1,13 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// BorrowRate 全仓杠杆借币利率
+type BorrowRate struct {
+	// Currency 币种
+	Currency string `json:"currency"`
+	// Rate 每小时借币利率（如 0.00000571 表示每小时 0.000571%）
+	Rate types.ExDecimal `json:"rate"`
+	// Timestamp 利率查询时间
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
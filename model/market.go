@@ -1,6 +1,9 @@
 package model
 
-import "github.com/lemconn/exlink/types"
+import (
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
 
 // MarketType 市场类型
 type MarketType string
@@ -46,7 +49,7 @@ type Market struct {
 	// Contract 是否为合约市场
 	Contract bool `json:"contract,omitempty"`
 
-	// ContractValue 合约面值（每张合约等于多少个币），仅合约市场有效
+	// ContractValue 合约面值，仅合约市场有效：U本位为每张合约等于多少个币，币本位为每张合约的美元面值
 	ContractValue string `json:"contract_value,omitempty"`
 
 	// Precision 精度信息
@@ -55,6 +58,10 @@ type Market struct {
 		Amount int `json:"amount"`
 		// Price 价格精度
 		Price int `json:"price"`
+		// StepSize 数量最小变动单位（如 0.001），为零表示未知
+		StepSize types.ExDecimal `json:"step_size"`
+		// TickSize 价格最小变动单位（如 0.5、0.05），为零表示未知
+		TickSize types.ExDecimal `json:"tick_size"`
 	} `json:"precision"`
 
 	// Limits 限制信息
@@ -80,6 +87,13 @@ type Market struct {
 			// Max 最大成本
 			Max types.ExDecimal `json:"max"`
 		} `json:"cost"`
+		// Leverage 杠杆倍数限制（合约），为零表示未知
+		Leverage struct {
+			// Min 最小杠杆倍数
+			Min types.ExDecimal `json:"min"`
+			// Max 最大杠杆倍数
+			Max types.ExDecimal `json:"max"`
+		} `json:"leverage"`
 	} `json:"limits"`
 
 	// Info 交易所原始信息
@@ -88,3 +102,21 @@ type Market struct {
 
 // Markets 市场列表
 type Markets []*Market
+
+// SnapAmount 将数量向下对齐到数量步长（StepSize），步长未知时按数量精度截断
+func (m *Market) SnapAmount(amount decimal.Decimal) decimal.Decimal {
+	return snapToStep(amount, m.Precision.StepSize.Decimal, m.Precision.Amount)
+}
+
+// SnapPrice 将价格向下对齐到价格步长（TickSize），步长未知时按价格精度截断
+func (m *Market) SnapPrice(price decimal.Decimal) decimal.Decimal {
+	return snapToStep(price, m.Precision.TickSize.Decimal, m.Precision.Price)
+}
+
+// snapToStep 向零方向对齐到 step 的整数倍
+func snapToStep(value, step decimal.Decimal, precision int) decimal.Decimal {
+	if step.IsPositive() {
+		return value.Div(step).Truncate(0).Mul(step)
+	}
+	return value.Truncate(int32(precision))
+}
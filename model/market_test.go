@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+func TestMarket_SnapToStep(t *testing.T) {
+	market := &Market{Symbol: "BTC/USDT:USDT"}
+	market.Precision.Price = 1
+	market.Precision.Amount = 3
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.5")}
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.05")}
+
+	tests := []struct {
+		name string
+		fn   func(decimal.Decimal) decimal.Decimal
+		in   string
+		want string
+	}{
+		{"price below half tick", market.SnapPrice, "65000.4", "65000"},
+		{"price above half tick", market.SnapPrice, "65000.9", "65000.5"},
+		{"price on tick", market.SnapPrice, "65000.5", "65000.5"},
+		{"amount on step", market.SnapAmount, "1.25", "1.25"},
+		{"amount between steps", market.SnapAmount, "1.27", "1.25"},
+		{"amount below step", market.SnapAmount, "0.049", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fn(decimal.RequireFromString(tt.in))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("snap(%s) = %s, want %s", tt.in, got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMarket_SnapWithoutStep(t *testing.T) {
+	// 步长未知时回退到精度位数截断
+	market := &Market{Symbol: "BTC/USDT"}
+	market.Precision.Price = 2
+	market.Precision.Amount = 4
+
+	if got := market.SnapPrice(decimal.RequireFromString("123.456")); !got.Equal(decimal.RequireFromString("123.45")) {
+		t.Errorf("SnapPrice = %s, want 123.45", got.String())
+	}
+	if got := market.SnapAmount(decimal.RequireFromString("0.123456")); !got.Equal(decimal.RequireFromString("0.1234")) {
+		t.Errorf("SnapAmount = %s, want 0.1234", got.String())
+	}
+}
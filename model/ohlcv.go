@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // OHLCV K线数据
@@ -18,7 +19,23 @@ type OHLCV struct {
 	Close types.ExDecimal `json:"close"`
 	// Volume 成交量
 	Volume types.ExDecimal `json:"volume"`
+	// Closed 是否已收盘（仅在 PollOHLCV、WatchOHLCV 推送时设置）
+	Closed bool `json:"closed,omitempty"`
 }
 
 // OHLCVs K线数据数组
 type OHLCVs []*OHLCV
+
+// Range 返回K线振幅（最高价 - 最低价）
+func (o *OHLCV) Range() decimal.Decimal {
+	return o.High.Sub(o.Low.Decimal)
+}
+
+// Closes 按顺序返回收盘价，用于指标计算
+func (o OHLCVs) Closes() []decimal.Decimal {
+	closes := make([]decimal.Decimal, len(o))
+	for i, ohlcv := range o {
+		closes[i] = ohlcv.Close.Decimal
+	}
+	return closes
+}
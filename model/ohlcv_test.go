@@ -0,0 +1,27 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOHLCVs_RangeCloses(t *testing.T) {
+	// 收盘价超出 float64 精度，解析后应原样保留
+	var ohlcvs OHLCVs
+	data := `[{"timestamp":1700000000000,"open":"65000.1","high":"65100.123456789012345678","low":"64900.000000000000000001","close":"65000.123456789012345678","volume":"1"},
+		{"timestamp":1700000060000,"open":"65000.2","high":"65001","low":"64999","close":"65000.000000000000000009","volume":"2"}]`
+	if err := json.Unmarshal([]byte(data), &ohlcvs); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	closes := ohlcvs.Closes()
+	if len(closes) != 2 || closes[0].String() != "65000.123456789012345678" || closes[1].String() != "65000.000000000000000009" {
+		t.Errorf("Closes = %v", closes)
+	}
+	if got := ohlcvs[0].Range().String(); got != "200.123456789012345677" {
+		t.Errorf("Range = %s, want 200.123456789012345677", got)
+	}
+	if len(OHLCVs(nil).Closes()) != 0 {
+		t.Error("Closes of empty OHLCVs is not empty")
+	}
+}
@@ -0,0 +1,15 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// OpenInterest 合约持仓量
+type OpenInterest struct {
+	// Symbol 交易对
+	Symbol string `json:"symbol"`
+	// OpenInterestAmount 持仓量（U 本位合约为基础币数量，币本位合约为张数）
+	OpenInterestAmount types.ExDecimal `json:"open_interest_amount"`
+	// OpenInterestValue 持仓价值（计价币，币本位合约为美元）
+	OpenInterestValue types.ExDecimal `json:"open_interest_value"`
+	// Timestamp 数据时间
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
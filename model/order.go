@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // OrderSide 订单方向
@@ -203,6 +204,41 @@ type NewOrder struct {
 	OrderId       string
 	ClientOrderID string
 	Timestamp     types.ExTimestamp
+	// TriggerPrice 条件单触发价格（普通订单为 0）
+	TriggerPrice types.ExDecimal
+	// CallbackRate 跟踪止损回调比例（百分比，普通订单为 0）
+	CallbackRate types.ExDecimal
+	// Fills 下单响应中的成交明细（交易所未返回时为空，如 Binance 现货 FULL 响应的 fills）
+	Fills []*Trade
+	// Fee 成交手续费合计（未成交或币种不一致时为 nil）
+	Fee *Fee
+}
+
+// Filled 返回下单响应中的已成交数量
+func (o *NewOrder) Filled() decimal.Decimal {
+	filled := decimal.Zero
+	for _, fill := range o.Fills {
+		filled = filled.Add(fill.Amount)
+	}
+	return filled
+}
+
+// Cost 返回下单响应中的成交金额
+func (o *NewOrder) Cost() decimal.Decimal {
+	cost := decimal.Zero
+	for _, fill := range o.Fills {
+		cost = cost.Add(fill.Cost)
+	}
+	return cost
+}
+
+// AvgPrice 返回下单响应中的成交均价，未成交时为 0
+func (o *NewOrder) AvgPrice() decimal.Decimal {
+	filled := o.Filled()
+	if filled.IsZero() {
+		return decimal.Zero
+	}
+	return o.Cost().DivRound(filled, divisionPrecision)
 }
 
 // PerpOrder 永续合约订单信息
@@ -222,4 +258,53 @@ type PerpOrder struct {
 	ReduceOnly       bool              `json:"reduce_only"`       // ReduceOnly 是否只减仓
 	CreateTime       types.ExTimestamp `json:"create_time"`       // CreateTime 订单创建时间
 	UpdateTime       types.ExTimestamp `json:"update_time"`       // UpdateTime 订单更新时间
+	Reason           string            `json:"reason,omitempty"`  // Reason 系统订单原因（强平 liquidation / 自动减仓 adl），普通订单为空
+}
+
+// OrderFillEvent 订单成交进度事件（TrackOrder 推送）
+type OrderFillEvent struct {
+	OrderID    string            `json:"order_id"`    // OrderID 订单ID
+	Symbol     string            `json:"symbol"`      // Symbol 交易对
+	FillAmount types.ExDecimal   `json:"fill_amount"` // FillAmount 本次新增成交数量（终态事件可能为 0）
+	FillPrice  types.ExDecimal   `json:"fill_price"`  // FillPrice 本次新增成交均价
+	Filled     types.ExDecimal   `json:"filled"`      // Filled 累计成交数量
+	VWAP       types.ExDecimal   `json:"vwap"`        // VWAP 累计成交量加权均价
+	Status     string            `json:"status"`      // Status 交易所返回的订单状态
+	Final      bool              `json:"final"`       // Final 是否为终态（最后一个事件，之后通道关闭）
+	Timestamp  types.ExTimestamp `json:"timestamp"`   // Timestamp 事件时间
+}
+
+// NewOrder 将查询到的订单转换为下单结果（如下单重试时按客户端订单ID找到已提交的订单）
+func (o *SpotOrder) NewOrder() *NewOrder {
+	return &NewOrder{
+		Symbol:        o.Symbol,
+		OrderId:       o.ID,
+		ClientOrderID: o.ClientOrderID,
+		Timestamp:     o.CreatedAt,
+	}
+}
+
+// NewOrder 将查询到的订单转换为下单结果（如下单重试时按客户端订单ID找到已提交的订单）
+func (o *PerpOrder) NewOrder() *NewOrder {
+	return &NewOrder{
+		Symbol:        o.Symbol,
+		OrderId:       o.ID,
+		ClientOrderID: o.ClientID,
+		Timestamp:     o.CreateTime,
+	}
+}
+
+// CostEstimate 下单前的订单成本估算（EstimateOrderCost），数量和价格已按市场精度对齐
+type CostEstimate struct {
+	Symbol     string          `json:"symbol"`     // Symbol 交易对
+	Side       OrderSide       `json:"side"`       // Side 订单方向
+	Amount     types.ExDecimal `json:"amount"`     // Amount 按数量步长向下对齐后的数量
+	Price      types.ExDecimal `json:"price"`      // Price 估算价格（限价单为对齐后的委托价，市价单取行情价）
+	Market     bool            `json:"market"`     // Market 是否为市价单（价格来自行情，实际成交价可能不同）
+	Cost       types.ExDecimal `json:"cost"`       // Cost 成交金额（Amount * Price，计价货币）
+	FeeRate    types.ExDecimal `json:"fee_rate"`   // FeeRate 手续费率（未通过 option.WithFeeRate 指定时为 0）
+	Fee        types.ExDecimal `json:"fee"`        // Fee 预估手续费（Cost * FeeRate，计价货币）
+	Currency   string          `json:"currency"`   // Currency 成交金额和手续费的计价货币
+	Valid      bool            `json:"valid"`      // Valid 是否满足市场的数量和金额限制
+	Violations []string        `json:"violations"` // Violations 不满足的限制说明，如 "cost 5 is below the minimum 10"
 }
@@ -0,0 +1,39 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// OrderList 订单组（OCO 等），一个子订单成交或触发后交易所自动撤销其他子订单
+type OrderList struct {
+	// ID 订单组ID（Binance orderListId，OKX algoId）
+	ID string `json:"id"`
+	// ClientID 客户端订单组ID
+	ClientID string `json:"client_id"`
+	// Symbol 交易对
+	Symbol string `json:"symbol"`
+	// Type 组合类型（如 OCO）
+	Type string `json:"type"`
+	// Status 订单组状态（取交易所原始值，交易所未返回时为空）
+	Status string `json:"status"`
+	// Orders 子订单
+	Orders []*OrderListOrder `json:"orders"`
+	// Timestamp 创建时间
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
+
+// OrderListOrder 订单组中的子订单
+type OrderListOrder struct {
+	// ID 子订单ID（交易所不单独返回子订单ID时为空，如 OKX 策略委托）
+	ID string `json:"id"`
+	// ClientID 子订单客户端ID
+	ClientID string `json:"client_id"`
+	// Type 子订单类型（取交易所原始值，如 LIMIT_MAKER、STOP_LOSS_LIMIT）
+	Type string `json:"type"`
+	// Side 订单方向
+	Side OrderSide `json:"side"`
+	// Amount 订单数量
+	Amount types.ExDecimal `json:"amount"`
+	// Price 委托价格（触发后按市价成交时为 0）
+	Price types.ExDecimal `json:"price"`
+	// StopPrice 触发价格（限价子订单为 0）
+	StopPrice types.ExDecimal `json:"stop_price"`
+}
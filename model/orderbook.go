@@ -1,6 +1,12 @@
 package model
 
-import "github.com/shopspring/decimal"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
 
 // OrderBookEntry 订单簿条目
 type OrderBookEntry struct {
@@ -18,6 +24,113 @@ type OrderBook struct {
 	Bids []OrderBookEntry `json:"bids"`
 	// Asks 卖单列表（价格从低到高）
 	Asks []OrderBookEntry `json:"asks"`
+	// Nonce 订单簿更新ID（Binance lastUpdateId、Bybit u、OKX seqId、Gate id），用于与增量推送对齐
+	Nonce int64 `json:"nonce"`
 	// Timestamp 时间戳
-	Timestamp int64 `json:"timestamp"`
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
+
+// ErrInsufficientDepth 订单簿深度不足以成交指定数量
+var ErrInsufficientDepth = errors.New("insufficient order book depth")
+
+// divisionPrecision 均价等计算中除法保留的小数位数
+const divisionPrecision = 16
+
+// BestBid 返回买一档，买单为空时返回 false
+func (b *OrderBook) BestBid() (OrderBookEntry, bool) {
+	if len(b.Bids) == 0 {
+		return OrderBookEntry{}, false
+	}
+	return b.Bids[0], true
+}
+
+// BestAsk 返回卖一档，卖单为空时返回 false
+func (b *OrderBook) BestAsk() (OrderBookEntry, bool) {
+	if len(b.Asks) == 0 {
+		return OrderBookEntry{}, false
+	}
+	return b.Asks[0], true
+}
+
+// MidPrice 返回中间价（(买一 + 卖一) / 2），任一侧为空时返回 0
+func (b *OrderBook) MidPrice() decimal.Decimal {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero
+	}
+	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2))
+}
+
+// Spread 返回买卖价差（卖一 - 买一），任一侧为空时返回 0
+func (b *OrderBook) Spread() decimal.Decimal {
+	bid, okBid := b.BestBid()
+	ask, okAsk := b.BestAsk()
+	if !okBid || !okAsk {
+		return decimal.Zero
+	}
+	return ask.Price.Sub(bid.Price)
+}
+
+// VWAP 返回按档位依次吃单成交 amount 的平均价格：买入吃卖单，卖出吃买单
+// 深度不足时返回已有深度的平均价格和 ErrInsufficientDepth，没有可成交档位时价格为 0
+func (b *OrderBook) VWAP(side OrderSide, amount decimal.Decimal) (decimal.Decimal, error) {
+	if !amount.IsPositive() {
+		return decimal.Zero, fmt.Errorf("vwap: amount must be positive: %s", amount)
+	}
+
+	var levels []OrderBookEntry
+	switch side {
+	case OrderSideBuy:
+		levels = b.Asks
+	case OrderSideSell:
+		levels = b.Bids
+	default:
+		return decimal.Zero, fmt.Errorf("vwap: invalid side: %q", side)
+	}
+
+	remaining := amount
+	cost := decimal.Zero
+	for _, level := range levels {
+		fill := decimal.Min(remaining, level.Amount)
+		cost = cost.Add(fill.Mul(level.Price))
+		remaining = remaining.Sub(fill)
+		if remaining.IsZero() {
+			break
+		}
+	}
+
+	filled := amount.Sub(remaining)
+	if filled.IsZero() {
+		return decimal.Zero, fmt.Errorf("vwap: %w: %s side is empty", ErrInsufficientDepth, side)
+	}
+	vwap := cost.DivRound(filled, divisionPrecision)
+	if remaining.IsPositive() {
+		return vwap, fmt.Errorf("vwap: %w: filled %s of %s", ErrInsufficientDepth, filled, amount)
+	}
+	return vwap, nil
+}
+
+// Imbalance 返回前 levels 档的买卖量失衡度 (买量 - 卖量) / (买量 + 卖量)，取值 [-1, 1]，正数表示买盘更强
+// levels 小于等于 0 时统计全部档位，两侧均为空时返回 0
+func (b *OrderBook) Imbalance(levels int) decimal.Decimal {
+	bidVolume := sumBookAmount(b.Bids, levels)
+	askVolume := sumBookAmount(b.Asks, levels)
+	total := bidVolume.Add(askVolume)
+	if total.IsZero() {
+		return decimal.Zero
+	}
+	return bidVolume.Sub(askVolume).DivRound(total, divisionPrecision)
+}
+
+// sumBookAmount 累计前 levels 档的数量（levels 小于等于 0 时累计全部）
+func sumBookAmount(entries []OrderBookEntry, levels int) decimal.Decimal {
+	if levels > 0 && levels < len(entries) {
+		entries = entries[:levels]
+	}
+	sum := decimal.Zero
+	for _, entry := range entries {
+		sum = sum.Add(entry.Amount)
+	}
+	return sum
 }
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func newTestBook() *OrderBook {
+	entry := func(price, amount string) OrderBookEntry {
+		return OrderBookEntry{Price: decimal.RequireFromString(price), Amount: decimal.RequireFromString(amount)}
+	}
+	return &OrderBook{
+		Symbol: "BTC/USDT",
+		Bids:   []OrderBookEntry{entry("99", "1"), entry("98", "2"), entry("97", "3")},
+		Asks:   []OrderBookEntry{entry("101", "0.5"), entry("102", "1.5"), entry("105", "1")},
+	}
+}
+
+func TestOrderBook_MidPriceSpread(t *testing.T) {
+	book := newTestBook()
+	if got := book.MidPrice().String(); got != "100" {
+		t.Errorf("MidPrice = %s, want 100", got)
+	}
+	if got := book.Spread().String(); got != "2" {
+		t.Errorf("Spread = %s, want 2", got)
+	}
+
+	// 单侧为空时返回 0
+	oneSided := &OrderBook{Bids: book.Bids}
+	if !oneSided.MidPrice().IsZero() || !oneSided.Spread().IsZero() {
+		t.Errorf("one-sided book: mid %s spread %s, want 0", oneSided.MidPrice(), oneSided.Spread())
+	}
+}
+
+func TestOrderBook_VWAP(t *testing.T) {
+	book := newTestBook()
+
+	tests := []struct {
+		side   OrderSide
+		amount string
+		want   string
+	}{
+		{OrderSideBuy, "0.5", "101"},                // 只吃卖一
+		{OrderSideBuy, "1", "101.5"},                // (0.5*101 + 0.5*102) / 1
+		{OrderSideBuy, "3", "102.8333333333333333"}, // (0.5*101 + 1.5*102 + 1*105) / 3
+		{OrderSideSell, "2", "98.5"},                // (1*99 + 1*98) / 2
+		{OrderSideSell, "6", "97.6666666666666667"},
+	}
+	for _, tt := range tests {
+		got, err := book.VWAP(tt.side, decimal.RequireFromString(tt.amount))
+		if err != nil || got.String() != tt.want {
+			t.Errorf("VWAP(%s, %s) = %s, %v, want %s", tt.side, tt.amount, got, err, tt.want)
+		}
+	}
+
+	// 深度不足时返回已有深度的均价和 ErrInsufficientDepth
+	got, err := book.VWAP(OrderSideBuy, decimal.NewFromInt(5))
+	if !errors.Is(err, ErrInsufficientDepth) || got.String() != "102.8333333333333333" {
+		t.Errorf("partial depth = %s, %v, want 102.8333333333333333 with ErrInsufficientDepth", got, err)
+	}
+	got, err = (&OrderBook{}).VWAP(OrderSideSell, decimal.NewFromInt(1))
+	if !errors.Is(err, ErrInsufficientDepth) || !got.IsZero() {
+		t.Errorf("empty book = %s, %v, want 0 with ErrInsufficientDepth", got, err)
+	}
+
+	if _, err := book.VWAP(OrderSideBuy, decimal.Zero); err == nil {
+		t.Error("zero amount: want error")
+	}
+	if _, err := book.VWAP("hold", decimal.NewFromInt(1)); err == nil {
+		t.Error("invalid side: want error")
+	}
+}
+
+func TestOrderBook_Imbalance(t *testing.T) {
+	book := newTestBook()
+
+	tests := []struct {
+		levels int
+		want   string
+	}{
+		{1, "0.3333333333333333"}, // (1 - 0.5) / 1.5
+		{2, "0.2"},                // (3 - 2) / 5
+		{0, "0.3333333333333333"}, // 全部档位：(6 - 3) / 9
+		{10, "0.3333333333333333"},
+	}
+	for _, tt := range tests {
+		if got := book.Imbalance(tt.levels).String(); got != tt.want {
+			t.Errorf("Imbalance(%d) = %s, want %s", tt.levels, got, tt.want)
+		}
+	}
+	if got := (&OrderBook{}).Imbalance(5); !got.IsZero() {
+		t.Errorf("empty book Imbalance = %s, want 0", got)
+	}
+}
@@ -4,6 +4,12 @@ import (
 	"github.com/lemconn/exlink/types"
 )
 
+// 持仓保证金模式
+const (
+	MarginModeIsolated = "isolated" // MarginModeIsolated 逐仓
+	MarginModeCross    = "cross"    // MarginModeCross 全仓
+)
+
 // Position 持仓信息（用于合约）
 type Position struct {
 	// Symbol 交易对
@@ -26,6 +32,12 @@ type Position struct {
 	Leverage types.ExDecimal `json:"leverage"`
 	// Margin 保证金
 	Margin types.ExDecimal `json:"margin"`
+	// MarginMode 保证金模式（isolated/cross）
+	MarginMode string `json:"margin_mode"`
+	// MaintenanceMargin 维持保证金
+	MaintenanceMargin types.ExDecimal `json:"maintenance_margin"`
+	// MaintenanceMarginRate 维持保证金率
+	MaintenanceMarginRate types.ExDecimal `json:"maintenance_margin_rate"`
 	// Percentage 持仓占比
 	Percentage types.ExDecimal `json:"percentage"`
 	// Timestamp 时间戳
@@ -36,3 +48,34 @@ type Position struct {
 
 // Positions 持仓列表
 type Positions []*Position
+
+// 持仓变化类型
+const (
+	PositionActionOpened    = "opened"    // PositionActionOpened 开仓
+	PositionActionIncreased = "increased" // PositionActionIncreased 加仓
+	PositionActionReduced   = "reduced"   // PositionActionReduced 减仓
+	PositionActionClosed    = "closed"    // PositionActionClosed 平仓
+	PositionActionUpdated   = "updated"   // PositionActionUpdated 数量未变（仅价格、盈亏等变化）
+)
+
+// 持仓变化原因（交易所系统触发的减仓/平仓）
+const (
+	PositionReasonLiquidation = "liquidation" // PositionReasonLiquidation 强平
+	PositionReasonADL         = "adl"         // PositionReasonADL 自动减仓
+)
+
+// PositionUpdate 持仓变化事件（相对上一次快照）
+type PositionUpdate struct {
+	// Position 最新持仓（平仓时为数量归零的持仓）
+	Position *Position `json:"position"`
+	// Previous 上一次快照中的持仓，开仓时为 nil
+	Previous *Position `json:"previous,omitempty"`
+	// Action 变化类型（opened/increased/reduced/closed/updated）
+	Action string `json:"action"`
+	// AmountChange 持仓数量变化（增加为正，减少为负）
+	AmountChange types.ExDecimal `json:"amount_change"`
+	// PnlChange 未实现盈亏变化
+	PnlChange types.ExDecimal `json:"pnl_change"`
+	// Reason 变化原因（liquidation/adl），由私有订单推送中的强平、自动减仓订单确定，普通变化为空
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,23 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// 交易所系统状态
+const (
+	ExchangeStatusOK          = "ok"          // ExchangeStatusOK 正常
+	ExchangeStatusMaintenance = "maintenance" // ExchangeStatusMaintenance 维护中（包括状态接口无法访问）
+)
+
+// ExchangeStatus 交易所系统状态
+type ExchangeStatus struct {
+	// Status 状态（ok/maintenance）
+	Status string `json:"status"`
+	// Updated 状态时间：维护中为维护开始时间，交易所未提供时为查询时间
+	Updated types.ExTimestamp `json:"updated"`
+	// ETA 维护预计结束时间，未知时为零值
+	ETA types.ExTimestamp `json:"eta"`
+	// URL 维护公告链接，未提供时为空
+	URL string `json:"url,omitempty"`
+	// Err 状态接口无法访问时的错误（此时 Status 为 maintenance）
+	Err error `json:"-"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// StreamState WebSocket 连接状态
+type StreamState string
+
+const (
+	StreamConnected    StreamState = "connected"    // 连接已建立
+	StreamDisconnected StreamState = "disconnected" // 连接意外断开（读超时、服务端关闭等），订阅会自动重连
+	StreamClosed       StreamState = "closed"       // 连接已主动关闭，不再重连
+)
+
+// StreamEvent WebSocket 连接状态变化事件
+type StreamEvent struct {
+	// Exchange 交易所名称
+	Exchange string `json:"exchange"`
+	// Stream 连接名称（如 spot、perp、private）
+	Stream string `json:"stream"`
+	// State 新状态
+	State StreamState `json:"state"`
+	// Err 断开原因，仅 disconnected 时非空
+	Err error `json:"-"`
+	// Timestamp 状态变化时间
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
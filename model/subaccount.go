@@ -0,0 +1,15 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// SubAccount 子账户信息
+type SubAccount struct {
+	// ID 子账户标识，可作为 FetchBalanceFor 的参数（Binance 为邮箱，OKX 为子账户名称，Bybit 为子账户 UID）
+	ID string `json:"id"`
+	// Name 子账户名称或备注，交易所未提供时与 ID 相同
+	Name string `json:"name"`
+	// Frozen 子账户是否已冻结
+	Frozen bool `json:"frozen"`
+	// CreatedAt 创建时间，交易所未提供时为零值
+	CreatedAt types.ExTimestamp `json:"created_at"`
+}
@@ -22,6 +22,14 @@ type Ticker struct {
 	Volume types.ExDecimal `json:"volume"`
 	// QuoteVolume 24小时成交额
 	QuoteVolume types.ExDecimal `json:"quote_volume"`
+	// VWAP 24小时成交量加权均价（交易所未提供时为 0）
+	VWAP types.ExDecimal `json:"vwap"`
+	// TradeCount 24小时成交笔数（交易所未提供时为 0）
+	TradeCount int64 `json:"trade_count"`
+	// MarkPrice 合约标记价格（现货及交易所未提供时为 0）
+	MarkPrice types.ExDecimal `json:"mark_price"`
+	// IndexPrice 合约指数价格（现货及交易所未提供时为 0）
+	IndexPrice types.ExDecimal `json:"index_price"`
 	// Timestamp 时间戳
 	Timestamp types.ExTimestamp `json:"timestamp"`
 	// Info 交易所原始信息
@@ -3,6 +3,7 @@ package model
 import (
 	"time"
 
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -26,6 +27,58 @@ type Trade struct {
 	Cost decimal.Decimal `json:"cost"`
 	// Timestamp 时间戳
 	Timestamp time.Time `json:"timestamp"`
+	// Fee 手续费（交易所未提供时为 nil）
+	Fee *Fee `json:"fee,omitempty"`
 	// Info 交易所原始信息
 	Info map[string]interface{} `json:"info,omitempty"`
 }
+
+// Fee 手续费
+type Fee struct {
+	// Currency 手续费币种
+	Currency string `json:"currency"`
+	// Cost 手续费金额
+	Cost decimal.Decimal `json:"cost"`
+}
+
+// SumFees 汇总成交的手续费，没有手续费或币种不一致时返回 nil（按 Trade.Fee 分别计算）
+func SumFees(trades []*Trade) *Fee {
+	var total *Fee
+	for _, trade := range trades {
+		if trade.Fee == nil {
+			continue
+		}
+		if total == nil {
+			total = &Fee{Currency: trade.Fee.Currency}
+		} else if total.Currency != trade.Fee.Currency {
+			return nil
+		}
+		total.Cost = total.Cost.Add(trade.Fee.Cost)
+	}
+	return total
+}
+
+// AggTrade 归集交易（同一价格、同一方向、相近时间的成交合并为一条）
+type AggTrade struct {
+	// ID 归集交易ID（交易所未提供时为首笔成交ID）
+	ID string `json:"id"`
+	// Symbol 交易对
+	Symbol string `json:"symbol"`
+	// Side 主动成交方向（buy/sell）
+	Side string `json:"side"`
+	// Price 成交价格
+	Price types.ExDecimal `json:"price"`
+	// Amount 归集成交数量
+	Amount types.ExDecimal `json:"amount"`
+	// FirstTradeID 首笔成交ID
+	FirstTradeID string `json:"first_trade_id"`
+	// LastTradeID 末笔成交ID
+	LastTradeID string `json:"last_trade_id"`
+	// Count 归集的成交笔数（交易所未提供时为 0）
+	Count int `json:"count,omitempty"`
+	// Timestamp 首笔成交时间
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
+
+// AggTrades 归集交易数组
+type AggTrades []*AggTrade
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSumFees(t *testing.T) {
+	fee := func(currency, cost string) *Fee {
+		return &Fee{Currency: currency, Cost: decimal.RequireFromString(cost)}
+	}
+
+	total := SumFees([]*Trade{{Fee: fee("BNB", "0.001")}, {}, {Fee: fee("BNB", "0.0025")}})
+	if total == nil || total.Currency != "BNB" || total.Cost.String() != "0.0035" {
+		t.Errorf("SumFees = %+v, want 0.0035 BNB", total)
+	}
+	if total := SumFees([]*Trade{{Fee: fee("BNB", "0.001")}, {Fee: fee("USDT", "0.5")}}); total != nil {
+		t.Errorf("mixed currencies = %+v, want nil", total)
+	}
+	if total := SumFees(nil); total != nil {
+		t.Errorf("no fills = %+v, want nil", total)
+	}
+	if avg := (&NewOrder{}).AvgPrice(); !avg.IsZero() {
+		t.Errorf("AvgPrice without fills = %s, want 0", avg)
+	}
+}
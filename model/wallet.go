@@ -0,0 +1,79 @@
+package model
+
+import "github.com/lemconn/exlink/types"
+
+// DepositAddress 充值地址
+type DepositAddress struct {
+	// Currency 币种
+	Currency string `json:"currency"`
+	// Address 充值地址
+	Address string `json:"address"`
+	// Tag 地址标签（memo/payment id），不需要标签的币种为空
+	Tag string `json:"tag,omitempty"`
+	// Network 充值网络（链名称）
+	Network string `json:"network"`
+}
+
+// 充提币状态
+const (
+	TransactionStatusPending  = "pending"  // TransactionStatusPending 处理中（已提交、审核中或链上确认中）
+	TransactionStatusOK       = "ok"       // TransactionStatusOK 已完成
+	TransactionStatusFailed   = "failed"   // TransactionStatusFailed 失败
+	TransactionStatusCanceled = "canceled" // TransactionStatusCanceled 已取消
+)
+
+// Transaction 充提币记录
+type Transaction struct {
+	// ID 交易所充提币记录ID
+	ID string `json:"id"`
+	// TxID 链上交易哈希，提币申请刚提交时通常为空
+	TxID string `json:"txid,omitempty"`
+	// Currency 币种
+	Currency string `json:"currency"`
+	// Amount 数量
+	Amount types.ExDecimal `json:"amount"`
+	// Address 地址
+	Address string `json:"address"`
+	// Tag 地址标签
+	Tag string `json:"tag,omitempty"`
+	// Network 网络（链名称）
+	Network string `json:"network"`
+	// Status 状态（pending/ok/failed/canceled）
+	Status string `json:"status"`
+	// Timestamp 时间戳
+	Timestamp types.ExTimestamp `json:"timestamp"`
+}
+
+// CurrencyNetwork 币种支持的充提网络
+type CurrencyNetwork struct {
+	// Network 网络标识，可作为 FetchDepositAddress、Withdraw 的 network 参数
+	Network string `json:"network"`
+	// Name 网络名称（交易所展示名称，未提供时与 Network 相同）
+	Name string `json:"name"`
+	// Default 是否为币种的默认网络
+	Default bool `json:"default,omitempty"`
+	// DepositEnabled 是否可充值
+	DepositEnabled bool `json:"deposit_enabled"`
+	// WithdrawEnabled 是否可提币
+	WithdrawEnabled bool `json:"withdraw_enabled"`
+	// WithdrawFee 提币手续费（币种数量），交易所未提供时为 0
+	WithdrawFee types.ExDecimal `json:"withdraw_fee"`
+	// WithdrawMin 最小提币数量，交易所未提供时为 0
+	WithdrawMin types.ExDecimal `json:"withdraw_min"`
+}
+
+// Currency 币种信息
+type Currency struct {
+	// Code 币种代码，如 "USDT"
+	Code string `json:"code"`
+	// Name 币种名称，如 "TetherUS"
+	Name string `json:"name"`
+	// Networks 支持的充提网络
+	Networks []CurrencyNetwork `json:"networks"`
+	// DepositEnabled 是否可充值（任一网络可充值即为 true）
+	DepositEnabled bool `json:"deposit_enabled"`
+	// WithdrawEnabled 是否可提币（任一网络可提币即为 true）
+	WithdrawEnabled bool `json:"withdraw_enabled"`
+	// WithdrawFee 默认网络的提币手续费（没有默认网络时取第一个网络），各网络手续费见 Networks
+	WithdrawFee types.ExDecimal `json:"withdraw_fee"`
+}
@@ -1,6 +1,9 @@
 package okx
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/lemconn/exlink/common"
 )
 
@@ -8,20 +11,47 @@ const (
 	okxName       = "okx"
 	okxBaseURL    = "https://www.okx.com"
 	okxSandboxURL = "https://www.okx.com" // OKX使用同一个域名，通过header区分
+
+	// okxSimulatedTradingHeader 模拟盘请求头
+	okxSimulatedTradingHeader = "x-simulated-trading"
+
+	// 公共 WebSocket 地址（现货和合约共用）
+	okxWSURL        = "wss://ws.okx.com:8443/ws/v5/public"
+	okxWSSandboxURL = "wss://wspap.okx.com:8443/ws/v5/public"
+
+	// 业务 WebSocket 地址（K线等频道只在业务地址提供）
+	okxBusinessWSURL        = "wss://ws.okx.com:8443/ws/v5/business"
+	okxBusinessWSSandboxURL = "wss://wspap.okx.com:8443/ws/v5/business"
+
+	// 私有 WebSocket 地址（订单、账户推送，现货和合约共用）
+	okxPrivateWSURL        = "wss://ws.okx.com:8443/ws/v5/private"
+	okxPrivateWSSandboxURL = "wss://wspap.okx.com:8443/ws/v5/private"
+
+	// okxMaxTradesLimit 逐笔成交单次最大返回条数
+	okxMaxTradesLimit = 500
+
+	// okxMaxDepthLimit 深度单次最大档位数
+	okxMaxDepthLimit = 400
+
+	// okxOHLCVPageLimit FetchOHLCVRange 每页K线数（历史K线接口单次最多 100 条）
+	okxOHLCVPageLimit = 100
 )
 
+// okxTimeframes K线支持的周期（现货和合约共用）
+var okxTimeframes = []string{"1s", "1m", "3m", "5m", "15m", "30m", "1H", "2H", "4H", "6H", "12H", "1D", "2D", "3D", "1W", "1M", "3M"}
+
 // Client OKX 客户端
 type Client struct {
 	// HTTPClient HTTP 客户端
 	HTTPClient *common.HTTPClient
 
-	// APIKey API 密钥
+	// APIKey API 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	APIKey string
 
-	// SecretKey 密钥
+	// SecretKey 密钥（创建时传入的值，UpdateCredentials 轮换后不更新）
 	SecretKey string
 
-	// Passphrase 密码短语
+	// Passphrase 密码短语（创建时传入的值，UpdateCredentials 轮换后不更新）
 	Passphrase string
 
 	// Sandbox 是否为模拟盘
@@ -35,15 +65,16 @@ type Client struct {
 }
 
 // NewClient 创建 OKX 客户端
+// 请求签名使用 OKX 持有的当前凭证（见 credentials），以支持运行时轮换
 func NewClient(apiKey, secretKey, passphrase string, options map[string]interface{}) (*Client, error) {
-	baseURL := okxBaseURL
+	baseURL, err := common.SharedBaseURL(options, okxBaseURL)
+	if err != nil {
+		return nil, err
+	}
 	sandbox := false
 	proxyURL := ""
 	debug := false
 
-	if v, ok := options["baseURL"].(string); ok {
-		baseURL = v
-	}
 	if v, ok := options["sandbox"].(bool); ok {
 		sandbox = v
 	}
@@ -54,8 +85,10 @@ func NewClient(apiKey, secretKey, passphrase string, options map[string]interfac
 		debug = v
 	}
 
+	limiter, _ := options["rateLimiter"].(*common.RateLimiter)
+
 	client := &Client{
-		HTTPClient: common.NewHTTPClient(baseURL),
+		HTTPClient: common.NewHTTPClient(baseURL, common.WithRateLimiter(limiter)),
 		APIKey:     apiKey,
 		SecretKey:  secretKey,
 		Passphrase: passphrase,
@@ -64,6 +97,24 @@ func NewClient(apiKey, secretKey, passphrase string, options map[string]interfac
 		Debug:      debug,
 	}
 
+	// 解析交易所错误码
+	client.HTTPClient.SetErrorParser(parseOKXError)
+
+	// 模拟盘与实盘共用域名，所有请求（包括公共接口）都需携带模拟盘请求头
+	if sandbox {
+		client.HTTPClient.SetHeader(okxSimulatedTradingHeader, "1")
+	}
+
+	// 设置重试策略
+	if v, ok := options["retryPolicy"].(common.RetryPolicy); ok {
+		client.HTTPClient.SetRetryPolicy(v)
+	}
+
+	// 替换底层 HTTP 客户端（如录制回放测试），代理和超时在其上生效
+	if v, ok := options["httpClient"].(*http.Client); ok {
+		client.HTTPClient.SetHTTPClient(v)
+	}
+
 	// 设置代理
 	if proxyURL != "" {
 		if err := client.HTTPClient.SetProxy(proxyURL); err != nil {
@@ -76,6 +127,25 @@ func NewClient(apiKey, secretKey, passphrase string, options map[string]interfac
 		client.HTTPClient.SetDebug(true)
 	}
 
+	// 设置请求超时（未设置时使用默认超时）
+	if v, ok := options["timeout"].(time.Duration); ok && v > 0 {
+		client.HTTPClient.SetTimeout(v)
+	}
+
+	// 设置关联ID请求头和请求/响应回调
+	if v, ok := options["correlationHeader"].(string); ok {
+		client.HTTPClient.SetCorrelationHeader(v)
+	}
+	if v, ok := options["logger"].(common.Logger); ok {
+		client.HTTPClient.SetLogger(v)
+	}
+	if v, ok := options["requestHook"].(common.RequestHook); ok {
+		client.HTTPClient.OnRequest(v)
+	}
+	if v, ok := options["responseHook"].(common.ResponseHook); ok {
+		client.HTTPClient.OnResponse(v)
+	}
+
 	return client, nil
 }
 
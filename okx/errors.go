@@ -0,0 +1,72 @@
+package okx
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lemconn/exlink/common"
+)
+
+// okxErrorCodes OKX 错误码（code/sCode）到统一错误的映射
+var okxErrorCodes = map[string]error{
+	"50011": common.ErrRateLimitExceeded,    // 请求频率超限
+	"50061": common.ErrRateLimitExceeded,    // 子账户请求频率超限
+	"51008": common.ErrInsufficientFunds,    // 余额不足
+	"51119": common.ErrInsufficientFunds,    // 保证金不足
+	"51131": common.ErrInsufficientFunds,    // 余额不足
+	"51400": common.ErrOrderNotFound,        // 撤单失败，订单不存在或已完成
+	"51503": common.ErrOrderNotFound,        // 改单失败，订单不存在或已完成
+	"51603": common.ErrOrderNotFound,        // 订单不存在
+	"51006": common.ErrInvalidOrder,         // 委托价格超出限价范围
+	"51020": common.ErrInvalidOrder,         // 委托数量低于下限
+	"51121": common.ErrInvalidOrder,         // 委托数量不是下单精度的整数倍
+	"50105": common.ErrAuthenticationFailed, // Passphrase 错误
+	"50110": common.ErrAuthenticationFailed, // IP 不在白名单
+	"50111": common.ErrAuthenticationFailed, // API Key 无效
+	"50113": common.ErrAuthenticationFailed, // 签名无效
+}
+
+// newOKXError 根据 code/msg 创建交易所错误
+func newOKXError(code, msg string) error {
+	return common.NewExchangeError("okx", code, msg, okxErrorCodes)
+}
+
+// parseOKXError 解析 OKX 非 2xx 响应体 {"code":"50011","msg":"..."}
+func parseOKXError(httpErr *common.HTTPError) error {
+	var body struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal([]byte(httpErr.Body), &body); err != nil || body.Code == "" || body.Code == "0" {
+		return nil
+	}
+	e := common.NewExchangeError("okx", body.Code, body.Msg, okxErrorCodes)
+	e.Cause = httpErr
+	return e
+}
+
+// OrderError OKX 订单级错误（对应响应中每笔订单的 sCode/sMsg）
+// 批量下单时 OKX 可能部分成功，每笔失败的订单各自返回一个 OrderError
+type OrderError struct {
+	// Code 订单级错误码（sCode）
+	Code string
+	// Message 订单级错误信息（sMsg）
+	Message string
+	// OrderID 订单ID（失败时通常为空）
+	OrderID string
+	// ClientOrderID 客户端订单ID
+	ClientOrderID string
+}
+
+// Error 实现 error 接口
+func (e *OrderError) Error() string {
+	if e.ClientOrderID != "" {
+		return fmt.Sprintf("okx api error: %s (code: %s, clOrdId: %s)", e.Message, e.Code, e.ClientOrderID)
+	}
+	return fmt.Sprintf("okx api error: %s (code: %s)", e.Message, e.Code)
+}
+
+// Unwrap 返回错误码映射的统一错误，未知错误码时为 nil
+func (e *OrderError) Unwrap() error {
+	return okxErrorCodes[e.Code]
+}
@@ -0,0 +1,117 @@
+package okx
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lemconn/exlink/common"
+)
+
+func TestOKXOrderResponse_PartialBatch(t *testing.T) {
+	payload := `{
+		"code": "2",
+		"msg": "",
+		"data": [
+			{"clOrdId": "leg1", "ordId": "", "tag": "", "sCode": "51008", "sMsg": "Order failed. Insufficient USDT balance in account.", "ts": "1700000000000"},
+			{"clOrdId": "leg2", "ordId": "312269865356374016", "tag": "", "sCode": "0", "sMsg": "Order placed", "ts": "1700000000000"}
+		],
+		"inTime": "1700000000000000",
+		"outTime": "1700000000001000"
+	}`
+
+	var resp okxOrderResponse
+	if err := json.Unmarshal([]byte(payload), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	results, errs, err := resp.orderResults()
+	if err != nil {
+		t.Fatalf("orderResults: %v", err)
+	}
+	if len(results) != 2 || len(errs) != 2 {
+		t.Fatalf("got %d results and %d errors, want 2 and 2", len(results), len(errs))
+	}
+
+	var orderErr *OrderError
+	if !errors.As(errs[0], &orderErr) {
+		t.Fatalf("leg1 error = %v, want *OrderError", errs[0])
+	}
+	if orderErr.Code != "51008" || orderErr.ClientOrderID != "leg1" {
+		t.Errorf("leg1 error = %+v, want code 51008 for leg1", orderErr)
+	}
+	if !strings.Contains(orderErr.Error(), "Insufficient USDT balance") {
+		t.Errorf("leg1 error message = %q, want original sMsg", orderErr.Error())
+	}
+
+	if errs[1] != nil {
+		t.Errorf("leg2 error = %v, want nil", errs[1])
+	}
+	if results[1].OrdID != "312269865356374016" || results[1].ClOrdID != "leg2" {
+		t.Errorf("leg2 result = %+v", results[1])
+	}
+}
+
+func TestOKXOrderResponse_TopLevelError(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{"request error without data", `{"code":"50011","msg":"Too Many Requests","data":[]}`},
+		{"all failed without data", `{"code":"1","msg":"All operations failed","data":[]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp okxOrderResponse
+			if err := json.Unmarshal([]byte(tt.payload), &resp); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if _, _, err := resp.orderResults(); err == nil {
+				t.Error("expected top-level error")
+			}
+		})
+	}
+}
+
+func TestOKXError_Sentinels(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want error
+	}{
+		{"insufficient balance", "51008", common.ErrInsufficientFunds},
+		{"rate limit", "50011", common.ErrRateLimitExceeded},
+		{"order not found", "51603", common.ErrOrderNotFound},
+		{"lot size", "51121", common.ErrInvalidOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := newOKXError(tt.code, "msg"); !errors.Is(err, tt.want) {
+				t.Errorf("newOKXError(%s) = %v, want errors.Is %v", tt.code, err, tt.want)
+			}
+			if err := error(&OrderError{Code: tt.code, Message: "msg"}); !errors.Is(err, tt.want) {
+				t.Errorf("OrderError(%s) = %v, want errors.Is %v", tt.code, err, tt.want)
+			}
+		})
+	}
+
+	var resp okxOrderResponse
+	if err := json.Unmarshal([]byte(`{"code":"50011","msg":"Too Many Requests","data":[]}`), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	_, _, err := resp.orderResults()
+	var exErr *common.ExchangeError
+	if !errors.As(err, &exErr) || exErr.Code != "50011" || exErr.Message != "Too Many Requests" {
+		t.Errorf("orderResults err = %#v, want *ExchangeError 50011", err)
+	}
+	if !errors.Is(err, common.ErrRateLimitExceeded) {
+		t.Errorf("orderResults err = %v, want ErrRateLimitExceeded", err)
+	}
+
+	if err := parseOKXError(&common.HTTPError{StatusCode: 429, Body: `{"code":"50011","msg":"Too Many Requests"}`}); !errors.Is(err, common.ErrRateLimitExceeded) {
+		t.Errorf("parseOKXError = %v, want ErrRateLimitExceeded", err)
+	}
+}
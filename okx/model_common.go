@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/types"
 )
 
@@ -123,3 +124,182 @@ func (k *okxKline) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// okxOrderResponse OKX 下单响应（单笔和批量下单共用，现货和合约共用）
+type okxOrderResponse struct {
+	Code    string            `json:"code"` // 返回码，"0" 全部成功，"1" 全部失败，"2" 部分成功
+	Msg     string            `json:"msg"`  // 返回消息
+	Data    []okxOrderResult  `json:"data"` // 每笔订单的结果，顺序与请求一致
+	InTime  types.ExTimestamp `json:"inTime"`
+	OutTime types.ExTimestamp `json:"outTime"`
+}
+
+// okxOrderResult OKX 单笔订单结果
+type okxOrderResult struct {
+	ClOrdID string            `json:"clOrdId"` // 客户端订单ID
+	OrdID   string            `json:"ordId"`   // 系统订单号
+	Tag     string            `json:"tag"`     // 订单标签
+	SCode   string            `json:"sCode"`   // 订单级返回码，"0" 表示成功
+	SMsg    string            `json:"sMsg"`    // 订单级返回消息
+	Ts      types.ExTimestamp `json:"ts"`      // 时间戳（毫秒）
+
+	AlgoID      string `json:"algoId"`      // 策略委托单号（条件单）
+	AlgoClOrdID string `json:"algoClOrdId"` // 客户端策略委托单号（条件单）
+}
+
+// orderResults 拆分下单结果，返回与 Data 一一对应的订单级错误
+// 仅当整体请求失败且没有订单级结果时返回 err
+func (r *okxOrderResponse) orderResults() ([]okxOrderResult, []error, error) {
+	if r.Code != "0" && r.Code != "1" && r.Code != "2" {
+		return nil, nil, newOKXError(r.Code, r.Msg)
+	}
+	if len(r.Data) == 0 {
+		if r.Code != "0" {
+			return nil, nil, newOKXError(r.Code, r.Msg)
+		}
+		return nil, nil, fmt.Errorf("okx api error: no order data returned")
+	}
+
+	errs := make([]error, len(r.Data))
+	for i, item := range r.Data {
+		if item.SCode != "" && item.SCode != "0" {
+			msg := item.SMsg
+			if msg == "" {
+				msg = r.Msg
+			}
+			errs[i] = &OrderError{
+				Code:          item.SCode,
+				Message:       msg,
+				OrderID:       item.OrdID,
+				ClientOrderID: item.ClOrdID,
+			}
+		}
+	}
+
+	return r.Data, errs, nil
+}
+
+// okxTrade OKX 逐笔成交（现货和合约共用）
+type okxTrade struct {
+	InstID  string            `json:"instId"`
+	TradeID string            `json:"tradeId"`
+	Px      types.ExDecimal   `json:"px"`
+	Sz      types.ExDecimal   `json:"sz"`
+	Side    string            `json:"side"`
+	Ts      types.ExTimestamp `json:"ts"`
+}
+
+// okxFill OKX 账户成交明细（/api/v5/trade/fills-history，现货和合约共用）
+type okxFill struct {
+	InstID  string            `json:"instId"`
+	TradeID string            `json:"tradeId"`
+	OrdID   string            `json:"ordId"`
+	BillID  string            `json:"billId"`
+	Side    string            `json:"side"`
+	FillSz  types.ExDecimal   `json:"fillSz"`
+	FillPx  types.ExDecimal   `json:"fillPx"`
+	Ts      types.ExTimestamp `json:"ts"`
+}
+
+// okxFillsResponse OKX 账户成交明细响应
+type okxFillsResponse struct {
+	Code string    `json:"code"`
+	Msg  string    `json:"msg"`
+	Data []okxFill `json:"data"`
+}
+
+// okxTradesResponse OKX 逐笔成交响应
+type okxTradesResponse struct {
+	Code string     `json:"code"`
+	Msg  string     `json:"msg"`
+	Data []okxTrade `json:"data"`
+}
+
+// toTrades 转换为统一的成交结构
+func (r okxTradesResponse) toTrades(symbol string) []*model.Trade {
+	trades := make([]*model.Trade, 0, len(r.Data))
+	for _, item := range r.Data {
+		trades = append(trades, &model.Trade{
+			ID:        item.TradeID,
+			Symbol:    symbol,
+			Side:      item.Side,
+			Amount:    item.Sz.Decimal,
+			Price:     item.Px.Decimal,
+			Timestamp: item.Ts.Time,
+		})
+	}
+	return trades
+}
+
+// okxGridOrderResponse OKX 网格策略下单/停止响应
+type okxGridOrderResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		AlgoID      string `json:"algoId"`
+		AlgoClOrdID string `json:"algoClOrdId"`
+		SCode       string `json:"sCode"`
+		SMsg        string `json:"sMsg"`
+		Tag         string `json:"tag"`
+	} `json:"data"`
+}
+
+// okxGridOrderDetail OKX 网格策略详情
+type okxGridOrderDetail struct {
+	AlgoID      string            `json:"algoId"`
+	InstID      string            `json:"instId"`
+	AlgoOrdType string            `json:"algoOrdType"`
+	State       string            `json:"state"`
+	MinPx       types.ExDecimal   `json:"minPx"`
+	MaxPx       types.ExDecimal   `json:"maxPx"`
+	GridNum     string            `json:"gridNum"`
+	Investment  types.ExDecimal   `json:"investment"`
+	TotalPnl    types.ExDecimal   `json:"totalPnl"`
+	CTime       types.ExTimestamp `json:"cTime"`
+	UTime       types.ExTimestamp `json:"uTime"`
+}
+
+// okxGridOrderDetailResponse OKX 网格策略详情响应
+type okxGridOrderDetailResponse struct {
+	Code string               `json:"code"`
+	Msg  string               `json:"msg"`
+	Data []okxGridOrderDetail `json:"data"`
+}
+
+// okxOrderBookResponse OKX 深度响应（现货和合约共用）
+type okxOrderBookResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Asks  [][]types.ExDecimal `json:"asks"`  // 卖盘 [price, size, 废弃字段, 订单数]
+		Bids  [][]types.ExDecimal `json:"bids"`  // 买盘 [price, size, 废弃字段, 订单数]
+		Ts    types.ExTimestamp   `json:"ts"`    // 深度产生的时间
+		SeqID int64               `json:"seqId"` // 序列号
+	} `json:"data"`
+}
+
+// okxSystemStatusResponse OKX 系统维护状态响应
+type okxSystemStatusResponse struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Data []okxSystemStatus `json:"data"`
+}
+
+// okxSystemStatus OKX 系统维护事件
+type okxSystemStatus struct {
+	Title       string            `json:"title"`       // 标题
+	State       string            `json:"state"`       // 状态：scheduled/ongoing/pre_open/completed/canceled
+	Begin       types.ExTimestamp `json:"begin"`       // 开始时间（毫秒）
+	End         types.ExTimestamp `json:"end"`         // 结束时间（毫秒）
+	Href        string            `json:"href"`        // 公告链接
+	ServiceType string            `json:"serviceType"` // 维护的服务类型
+}
+
+// okxTimeResponse OKX 服务器时间响应
+type okxTimeResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Ts types.ExTimestamp `json:"ts"` // 服务器时间（毫秒）
+	} `json:"data"`
+}
@@ -4,6 +4,25 @@ import (
 	"github.com/lemconn/exlink/types"
 )
 
+// okxPerpOrderItem OKX 合约订单详情（查询订单和私有频道 orders 推送共用）
+type okxPerpOrderItem struct {
+	OrdID      string            `json:"ordId"`      // 订单ID
+	ClOrdID    string            `json:"clOrdId"`    // 客户端自定义订单ID
+	InstID     string            `json:"instId"`     // 合约标的
+	Px         types.ExDecimal   `json:"px"`         // 下单价格（市价单为空）
+	AvgPx      types.ExDecimal   `json:"avgPx"`      // 成交均价
+	Sz         types.ExDecimal   `json:"sz"`         // 下单数量
+	AccFillSz  types.ExDecimal   `json:"accFillSz"`  // 实际成交数量
+	State      string            `json:"state"`      // 订单状态
+	ReduceOnly string            `json:"reduceOnly"` // 是否只减仓（字符串 "true"/"false"）
+	OrdType    string            `json:"ordType"`    // 订单类型
+	Side       string            `json:"side"`       // 订单方向
+	PosSide    string            `json:"posSide"`    // 单向持仓 net, 双向持仓 long / short
+	CTime      types.ExTimestamp `json:"cTime"`      // 创建时间（毫秒）
+	UTime      types.ExTimestamp `json:"uTime"`      // 更新时间（毫秒）
+	Category   string            `json:"category"`   // 订单种类（normal/full_liquidation/partial_liquidation/adl 等）
+}
+
 // okxPerpTickerResponse OKX 永续合约 Ticker 响应
 type okxPerpTickerResponse struct {
 	Code string          `json:"code"`
@@ -85,3 +104,66 @@ type okxPerpPosition struct {
 	VegaBS                 types.ExDecimal   `json:"vegaBS"`
 	VegaPA                 types.ExDecimal   `json:"vegaPA"`
 }
+
+// okxOpenInterestResponse OKX 持仓量响应
+type okxOpenInterestResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		InstID string            `json:"instId"` // 产品ID
+		Oi     types.ExDecimal   `json:"oi"`     // 持仓量（张）
+		OiCcy  types.ExDecimal   `json:"oiCcy"`  // 持仓量（币）
+		OiUsd  types.ExDecimal   `json:"oiUsd"`  // 持仓量（美元）
+		Ts     types.ExTimestamp `json:"ts"`     // 数据返回时间
+	} `json:"data"`
+}
+
+// okxMarkPriceResponse OKX 标记价格响应
+type okxMarkPriceResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		InstID string            `json:"instId"` // 产品ID
+		MarkPx types.ExDecimal   `json:"markPx"` // 标记价格
+		Ts     types.ExTimestamp `json:"ts"`     // 数据返回时间
+	} `json:"data"`
+}
+
+// okxIndexTickerResponse OKX 指数行情响应
+type okxIndexTickerResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		InstID string            `json:"instId"` // 指数ID，如 BTC-USDT
+		IdxPx  types.ExDecimal   `json:"idxPx"`  // 最新指数价格
+		Ts     types.ExTimestamp `json:"ts"`     // 数据返回时间
+	} `json:"data"`
+}
+
+// okxFundingRateResponse OKX 当期资金费率响应
+type okxFundingRateResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		InstID          string            `json:"instId"`          // 产品ID
+		InstType        string            `json:"instType"`        // 产品类型
+		FundingRate     types.ExDecimal   `json:"fundingRate"`     // 当期资金费率
+		FundingTime     types.ExTimestamp `json:"fundingTime"`     // 当期资金费率的结算时间
+		NextFundingRate types.ExDecimal   `json:"nextFundingRate"` // 下一期预测资金费率
+		NextFundingTime types.ExTimestamp `json:"nextFundingTime"` // 下一期资金费率的结算时间
+		Ts              types.ExTimestamp `json:"ts"`              // 数据更新时间
+	} `json:"data"`
+}
+
+// okxFundingRateHistoryResponse OKX 历史资金费率响应
+type okxFundingRateHistoryResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		InstID       string            `json:"instId"`       // 产品ID
+		InstType     string            `json:"instType"`     // 产品类型
+		FundingRate  types.ExDecimal   `json:"fundingRate"`  // 预测资金费率
+		RealizedRate types.ExDecimal   `json:"realizedRate"` // 实际资金费率
+		FundingTime  types.ExTimestamp `json:"fundingTime"`  // 结算时间
+	} `json:"data"`
+}
@@ -63,25 +63,6 @@ type okxSpotBalanceDetail struct {
 	UTime     types.ExTimestamp `json:"uTime"`
 }
 
-// okxSpotCreateOrderResponse OKX 现货创建订单响应
-type okxSpotCreateOrderResponse struct {
-	Code    string                   `json:"code"`
-	Msg     string                   `json:"msg"`
-	Data    []okxSpotCreateOrderData `json:"data"`
-	InTime  types.ExTimestamp        `json:"inTime"`
-	OutTime types.ExTimestamp        `json:"outTime"`
-}
-
-// okxSpotCreateOrderData OKX 现货创建订单数据
-type okxSpotCreateOrderData struct {
-	ClOrdId string            `json:"clOrdId"`
-	OrdId   string            `json:"ordId"`
-	Tag     string            `json:"tag"`
-	SCode   string            `json:"sCode"`
-	SMsg    string            `json:"sMsg"`
-	Ts      types.ExTimestamp `json:"ts"`
-}
-
 // okxSpotFetchOrderResponse OKX 现货查询订单响应
 type okxSpotFetchOrderResponse struct {
 	Code string                  `json:"code"`
@@ -112,3 +93,176 @@ type okxSpotFetchOrderData struct {
 	TdMode     string            `json:"tdMode"`     // 交易模式
 	UTime      types.ExTimestamp `json:"uTime"`      // 更新时间
 }
+
+// okxSpotConvertQuoteResponse OKX 闪兑询价响应
+type okxSpotConvertQuoteResponse struct {
+	Code string                    `json:"code"`
+	Msg  string                    `json:"msg"`
+	Data []okxSpotConvertQuoteData `json:"data"`
+}
+
+// okxSpotConvertQuoteData OKX 闪兑询价数据
+type okxSpotConvertQuoteData struct {
+	QuoteID   string            `json:"quoteId"`   // 询价ID
+	BaseCcy   string            `json:"baseCcy"`   // 交易货币
+	QuoteCcy  string            `json:"quoteCcy"`  // 计价货币
+	Side      string            `json:"side"`      // 报价方向
+	CnvtPx    types.ExDecimal   `json:"cnvtPx"`    // 闪兑价格（单位为计价币）
+	RfqSz     types.ExDecimal   `json:"rfqSz"`     // 询价数量
+	RfqSzCcy  string            `json:"rfqSzCcy"`  // 询价币种
+	BaseSz    types.ExDecimal   `json:"baseSz"`    // 交易货币数量
+	QuoteSz   types.ExDecimal   `json:"quoteSz"`   // 计价货币数量
+	TtlMs     string            `json:"ttlMs"`     // 报价有效期（毫秒）
+	QuoteTime types.ExTimestamp `json:"quoteTime"` // 报价生成时间
+}
+
+// okxSpotConvertPairResponse OKX 闪兑币对响应
+type okxSpotConvertPairResponse struct {
+	Code string                   `json:"code"`
+	Msg  string                   `json:"msg"`
+	Data []okxSpotConvertPairData `json:"data"`
+}
+
+// okxSpotConvertPairData OKX 闪兑币对数据
+type okxSpotConvertPairData struct {
+	InstID   string `json:"instId"`   // 币对
+	BaseCcy  string `json:"baseCcy"`  // 交易货币
+	QuoteCcy string `json:"quoteCcy"` // 计价货币
+}
+
+// okxSpotConvertTradeResponse OKX 闪兑交易响应
+type okxSpotConvertTradeResponse struct {
+	Code string                    `json:"code"`
+	Msg  string                    `json:"msg"`
+	Data []okxSpotConvertTradeData `json:"data"`
+}
+
+// okxSpotConvertTradeData OKX 闪兑交易数据
+type okxSpotConvertTradeData struct {
+	TradeID     string            `json:"tradeId"`     // 闪兑交易ID
+	QuoteID     string            `json:"quoteId"`     // 询价ID
+	State       string            `json:"state"`       // 闪兑状态（fullyFilled/rejected）
+	InstID      string            `json:"instId"`      // 币对
+	BaseCcy     string            `json:"baseCcy"`     // 交易货币
+	QuoteCcy    string            `json:"quoteCcy"`    // 计价货币
+	Side        string            `json:"side"`        // 交易方向
+	FillPx      types.ExDecimal   `json:"fillPx"`      // 成交价格（单位为计价币）
+	FillBaseSz  types.ExDecimal   `json:"fillBaseSz"`  // 交易货币成交数量
+	FillQuoteSz types.ExDecimal   `json:"fillQuoteSz"` // 计价货币成交数量
+	Ts          types.ExTimestamp `json:"ts"`          // 成交时间
+}
+
+// okxSpotCurrencyResponse OKX 币种信息响应（每个币种的每条链一条记录）
+type okxSpotCurrencyResponse struct {
+	Code string                `json:"code"`
+	Msg  string                `json:"msg"`
+	Data []okxSpotCurrencyData `json:"data"`
+}
+
+// okxSpotCurrencyData OKX 币种单条链信息
+type okxSpotCurrencyData struct {
+	Ccy     string          `json:"ccy"`     // 币种
+	Name    string          `json:"name"`    // 币种名称
+	Chain   string          `json:"chain"`   // 链名称，如 USDT-TRC20
+	CanDep  bool            `json:"canDep"`  // 是否可充值
+	CanWd   bool            `json:"canWd"`   // 是否可提币
+	MainNet bool            `json:"mainNet"` // 是否为主网
+	Fee     types.ExDecimal `json:"fee"`     // 提币手续费
+	MinFee  types.ExDecimal `json:"minFee"`  // 最小提币手续费（旧字段，未返回 fee 时使用）
+	MinWd   types.ExDecimal `json:"minWd"`   // 最小提币数量
+}
+
+// okxSpotDepositAddressResponse OKX 充值地址响应
+type okxSpotDepositAddressResponse struct {
+	Code string                      `json:"code"`
+	Msg  string                      `json:"msg"`
+	Data []okxSpotDepositAddressData `json:"data"`
+}
+
+// okxSpotDepositAddressData OKX 充值地址数据
+type okxSpotDepositAddressData struct {
+	Ccy      string `json:"ccy"`      // 币种
+	Chain    string `json:"chain"`    // 链名称，如 USDT-TRC20
+	Addr     string `json:"addr"`     // 充值地址
+	Tag      string `json:"tag"`      // 部分币种的地址标签
+	Memo     string `json:"memo"`     // 部分币种的 memo
+	PmtID    string `json:"pmtId"`    // 部分币种的 payment id
+	Selected bool   `json:"selected"` // 是否为当前选中的地址
+}
+
+// okxSpotWithdrawResponse OKX 提币响应
+type okxSpotWithdrawResponse struct {
+	Code string                `json:"code"`
+	Msg  string                `json:"msg"`
+	Data []okxSpotWithdrawData `json:"data"`
+}
+
+// okxSpotWithdrawData OKX 提币数据
+type okxSpotWithdrawData struct {
+	WdID     string          `json:"wdId"`     // 提币申请ID
+	Ccy      string          `json:"ccy"`      // 币种
+	Chain    string          `json:"chain"`    // 链名称
+	Amt      types.ExDecimal `json:"amt"`      // 提币数量
+	ClientID string          `json:"clientId"` // 客户自定义ID
+}
+
+// okxInterestRateResponse OKX 借币利率响应
+type okxInterestRateResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Ccy          string          `json:"ccy"`          // 币种
+		InterestRate types.ExDecimal `json:"interestRate"` // 每小时借币利率
+	} `json:"data"`
+}
+
+// okxBorrowRepayResponse OKX 手动借币还币响应
+type okxBorrowRepayResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Ccy  string          `json:"ccy"`  // 币种
+		Side string          `json:"side"` // borrow 或 repay
+		Amt  types.ExDecimal `json:"amt"`  // 数量
+	} `json:"data"`
+}
+
+// okxSubAccountListResponse OKX 子账户列表响应
+type okxSubAccountListResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		SubAcct string            `json:"subAcct"` // 子账户名称
+		Label   string            `json:"label"`   // 备注
+		Enable  bool              `json:"enable"`  // 是否启用（false 为冻结）
+		Ts      types.ExTimestamp `json:"ts"`      // 创建时间
+	} `json:"data"`
+}
+
+// okxSpotTransferResponse OKX 资金划转响应
+type okxSpotTransferResponse struct {
+	Code string                `json:"code"`
+	Msg  string                `json:"msg"`
+	Data []okxSpotTransferData `json:"data"`
+}
+
+// okxSpotTransferData OKX 资金划转数据
+type okxSpotTransferData struct {
+	TransID string          `json:"transId"` // 划转ID
+	Ccy     string          `json:"ccy"`     // 币种
+	From    string          `json:"from"`    // 转出账户
+	To      string          `json:"to"`      // 转入账户
+	Amt     types.ExDecimal `json:"amt"`     // 划转数量
+}
+
+// okxSpotFundingBalanceResponse OKX 资金账户余额响应
+type okxSpotFundingBalanceResponse struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+	Data []struct {
+		Ccy       string          `json:"ccy"`       // 币种
+		Bal       types.ExDecimal `json:"bal"`       // 余额
+		FrozenBal types.ExDecimal `json:"frozenBal"` // 冻结余额
+		AvailBal  types.ExDecimal `json:"availBal"`  // 可用余额
+	} `json:"data"`
+}
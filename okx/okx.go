@@ -1,16 +1,28 @@
 package okx
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
 	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // OKX OKX 交易所实现
 type OKX struct {
 	client              *Client
-	signer              *Signer
+	creds               atomic.Pointer[credentials] // 当前 API 凭证（轮换时整体替换）
 	spot                *OKXSpot
 	perp                *OKXPerp
 	spotMarketsBySymbol map[string]*model.Market // 现货市场信息（标准化格式索引）
@@ -18,6 +30,12 @@ type OKX struct {
 	perpMarketsBySymbol map[string]*model.Market // 合约市场信息（标准化格式索引）
 	perpMarketsByID     map[string]*model.Market // 合约市场信息（原始格式索引）
 	mu                  sync.RWMutex             // 保护市场信息的读写锁
+	lifecycle           *common.Lifecycle        // 进行中的请求和新建订单跟踪（Drain 使用）
+	clock               *common.Clock            // 服务器时钟（签名时间戳按服务器时间偏移校正）
+	marketCache         *common.MarketCache      // 市场信息加载时间（TTL 过期判断）
+	ws                  *common.WSManager        // 公共 WebSocket 订阅（现货和合约共用）
+	businessWS          *common.WSManager        // 业务 WebSocket 订阅（K线，现货和合约共用）
+	privateWS           *common.WSManager        // 私有 WebSocket 订阅（订单、账户，现货和合约共用）
 }
 
 // NewOKX 创建 OKX 交易所实例
@@ -32,21 +50,52 @@ func NewOKX(apiKey, secretKey string, options map[string]interface{}) (exchange.
 		return nil, err
 	}
 
-	signer := NewSigner(secretKey, passphrase)
-
+	marketCacheTTL, _ := options["marketCacheTTL"].(time.Duration)
 	okx := &OKX{
 		client:              client,
-		signer:              signer,
 		spotMarketsBySymbol: make(map[string]*model.Market),
 		spotMarketsByID:     make(map[string]*model.Market),
 		perpMarketsBySymbol: make(map[string]*model.Market),
 		perpMarketsByID:     make(map[string]*model.Market),
+		lifecycle:           common.NewLifecycle(),
+		clock:               &common.Clock{},
+		marketCache:         common.NewMarketCache(marketCacheTTL),
+	}
+	if v, ok := options["cancelOrdersOnDrain"].(bool); ok {
+		okx.lifecycle.SetCancelOrders(v)
+	}
+	client.HTTPClient.SetLifecycle(okx.lifecycle)
+
+	okx.UpdateCredentials(apiKey, secretKey, passphrase)
+
+	// 公共 WebSocket 按需建立连接，所有订阅复用同一连接
+	wsURL, businessWSURL := okxWSURL, okxBusinessWSURL
+	if client.Sandbox {
+		wsURL, businessWSURL = okxWSSandboxURL, okxBusinessWSSandboxURL
+	}
+	okx.ws = common.NewWSManager(common.NewWSDialer(wsURL, client.ProxyURL), okxWSProtocol{})
+	okx.businessWS = common.NewWSManager(common.NewWSDialer(businessWSURL, client.ProxyURL), okxWSProtocol{})
+
+	// 私有 WebSocket 每次建立连接（包括断线重连）后先登录再订阅
+	privateWSURL := okxPrivateWSURL
+	if client.Sandbox {
+		privateWSURL = okxPrivateWSSandboxURL
+	}
+	okx.privateWS = common.NewWSManager(common.NewAuthWSDialer(common.NewWSDialer(privateWSURL, client.ProxyURL), okx.wsLogin), okxPrivateWSProtocol{})
+	if v, ok := options["streamStateHandler"].(common.WSStateHandler); ok {
+		okx.ws.SetStateHandler(okxName, "public", v)
+		okx.businessWS.SetStateHandler(okxName, "business", v)
+		okx.privateWS.SetStateHandler(okxName, "private", v)
 	}
 
 	// 初始化现货和合约实现
 	okx.spot = NewOKXSpot(okx)
 	okx.perp = NewOKXPerp(okx)
 
+	if v, ok := options["timeSync"].(bool); ok && v {
+		okx.clock.Start(common.TimeSyncInterval, okx.FetchTime)
+	}
+
 	return okx, nil
 }
 
@@ -64,3 +113,442 @@ func (o *OKX) Perp() exchange.PerpExchange {
 func (o *OKX) Name() string {
 	return okxName
 }
+
+// Has 返回 OKX 支持的功能
+func (o *OKX) Has() model.Capabilities {
+	return okxCapabilities
+}
+
+// Symbols 返回已加载的现货和合约市场的标准化 symbol，按字母排序
+func (o *OKX) Symbols() []string {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return common.MarketSymbols(o.spotMarketsBySymbol, o.perpMarketsBySymbol)
+}
+
+// NormalizeSymbol 将交易所原始市场ID转换为标准化 symbol，先查现货市场再查合约市场
+func (o *OKX) NormalizeSymbol(nativeID string) (string, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return common.SymbolByMarketID(nativeID, o.spotMarketsByID, o.perpMarketsByID, common.FromOKXSymbol)
+}
+
+// DenormalizeSymbol 将标准化 symbol 转换为交易所原始市场ID
+func (o *OKX) DenormalizeSymbol(symbol string) (string, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return common.MarketIDBySymbol(symbol, o.spotMarketsBySymbol, o.perpMarketsBySymbol, common.ToOKXSymbol)
+}
+
+// ExportMarkets 导出已加载的现货和合约市场信息（JSON），可保存到磁盘，启动时通过 ImportMarkets 恢复
+func (o *OKX) ExportMarkets() ([]byte, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return common.MarshalMarkets(okxName, o.spotMarketsBySymbol, o.perpMarketsBySymbol)
+}
+
+// ImportMarkets 导入 ExportMarkets 导出的市场信息，替换对应类型已加载的市场（导出时为空的类型保持不变）
+// 导入的市场视为刚加载，缓存有效期从导入时开始计算
+func (o *OKX) ImportMarkets(data []byte) error {
+	snapshot, err := common.UnmarshalMarkets(okxName, data)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(snapshot.Spot) > 0 {
+		o.spotMarketsBySymbol, o.spotMarketsByID = common.IndexMarkets(snapshot.Spot)
+		o.marketCache.Touch(model.MarketTypeSpot)
+	}
+	if len(snapshot.Perp) > 0 {
+		o.perpMarketsBySymbol, o.perpMarketsByID = common.IndexMarkets(snapshot.Perp)
+		o.marketCache.Touch(model.MarketTypeSwap)
+	}
+	return nil
+}
+
+// Drain 优雅关闭：停止接受新的轮询订阅，等待进行中的请求完成
+// 启用 cancelOrdersOnDrain 时撤销通过本实例创建且仍未结束的订单，之后关闭 WebSocket 连接，已建立的推送和轮询订阅通道随之关闭
+func (o *OKX) Drain(ctx context.Context) error {
+	o.clock.Stop()
+	err := o.lifecycle.Drain(ctx, o.spot, o.perp)
+	return errors.Join(err, common.CloseWSManagers(o.ws, o.businessWS, o.privateWS))
+}
+
+// FetchTime 获取交易所服务器时间
+func (o *OKX) FetchTime(ctx context.Context) (time.Time, error) {
+	resp, err := o.client.HTTPClient.Get(ctx, "/api/v5/public/time", nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fetch time: %w", err)
+	}
+
+	var result okxTimeResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshal time: %w", err)
+	}
+	if result.Code != "0" || len(result.Data) == 0 {
+		return time.Time{}, newOKXError(result.Code, result.Msg)
+	}
+	return result.Data[0].Ts.Time, nil
+}
+
+// FetchStatus 获取系统状态（/api/v5/system/status），存在进行中的维护时为维护中，接口无法访问时视为维护中
+func (o *OKX) FetchStatus(ctx context.Context) (*model.ExchangeStatus, error) {
+	resp, err := o.client.HTTPClient.Get(ctx, "/api/v5/system/status", nil)
+	if err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("fetch system status: %w", err))
+	}
+
+	var result okxSystemStatusResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return common.UnreachableStatus(ctx, fmt.Errorf("unmarshal system status: %w", err))
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	status := common.OKStatus()
+	for _, item := range result.Data {
+		if item.State != "ongoing" {
+			continue
+		}
+		status.Status = model.ExchangeStatusMaintenance
+		status.Updated = item.Begin
+		status.ETA = item.End
+		status.URL = item.Href
+		break
+	}
+	return status, nil
+}
+
+// Ping 通过服务器时间接口检查交易所是否可达
+func (o *OKX) Ping(ctx context.Context) error {
+	return common.Ping(ctx, o.FetchTime)
+}
+
+// VerifyCredentials 通过查询现货余额校验 API 凭证
+func (o *OKX) VerifyCredentials(ctx context.Context) error {
+	creds := o.credentials()
+	return common.VerifyCredentials(ctx, creds.apiKey != "" && creds.secretKey != "" && creds.passphrase != "", o.spot.FetchBalance)
+}
+
+// okxCapabilities OKX 支持的功能
+var okxCapabilities = model.Capabilities{
+	Spot: model.MarketCapabilities{
+		Supported:             true,
+		CreateStopOrder:       true,
+		CreateOCOOrder:        true,
+		EditOrder:             true,
+		FetchOrder:            true,
+		FetchOrderByClientID:  true,
+		TrackOrder:            true,
+		FetchAggregatedTrades: true,
+		FetchMyTrades:         true,
+		WatchTicker:           true,
+		WatchOrderBook:        true,
+		WatchOHLCV:            true,
+		WatchOrders:           true,
+		WatchBalance:          true,
+		CreateConversion:      true,
+		FetchCurrencies:       true,
+		FetchDepositAddress:   true,
+		Withdraw:              true,
+		Transfer:              true,
+		FetchSubAccounts:      true,
+		Margin:                true,
+	},
+	Perp: model.MarketCapabilities{
+		Supported:               true,
+		CreateStopOrder:         true,
+		CreateTrailingStopOrder: true,
+		EditOrder:               true,
+		FetchOrder:              true,
+		FetchOrderByClientID:    true,
+		TrackOrder:              true,
+		FetchAggregatedTrades:   true,
+		FetchMyTrades:           true,
+		WatchTicker:             true,
+		WatchOrderBook:          true,
+		WatchOHLCV:              true,
+		WatchOrders:             true,
+		WatchBalance:            true,
+		WatchPositions:          true,
+		FetchFundingRate:        true,
+		FetchFundingRateHistory: true,
+		FetchOpenInterest:       true,
+		FetchMarkPrice:          true,
+		FetchIndexPrice:         true,
+		SetLeverage:             true,
+		SetLeverageSide:         true,
+		SetPositionMode:         true,
+		GetPositionMode:         true,
+	},
+}
+
+// credentials API 凭证快照，请求开始时取一次，保证同一请求的签名和请求头使用同一组凭证
+type credentials struct {
+	apiKey     string
+	secretKey  string
+	passphrase string
+	signer     *Signer
+}
+
+// UpdateCredentials 轮换 API 凭证（并发安全），进行中的请求使用旧凭证完成
+func (o *OKX) UpdateCredentials(apiKey, secretKey, password string) {
+	o.creds.Store(&credentials{
+		apiKey:     apiKey,
+		secretKey:  secretKey,
+		passphrase: password,
+		signer:     NewSigner(secretKey, password),
+	})
+}
+
+// credentials 返回当前 API 凭证快照
+func (o *OKX) credentials() *credentials {
+	return o.creds.Load()
+}
+
+// signAndRequest 签名并发送请求（OKX API，现货、合约和策略接口共用）
+// body 为对象或数组（部分批量接口要求数组请求体）
+func (o *OKX) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body interface{}) ([]byte, error) {
+	creds := o.credentials()
+	if creds.secretKey == "" {
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	// 构建请求体
+	bodyStr := ""
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshal body: %w", err)
+		}
+		bodyStr = string(bodyBytes)
+	}
+
+	// 生成时间戳和签名
+	timestamp := o.clock.ISO8601Timestamp()
+	signature := creds.signer.SignRequest(method, path, timestamp, bodyStr, params)
+
+	// 设置请求头（按请求传入，避免并发请求互相覆盖）
+	headers := map[string]string{
+		"OK-ACCESS-SIGN":       signature,
+		"OK-ACCESS-TIMESTAMP":  timestamp,
+		"OK-ACCESS-PASSPHRASE": creds.passphrase,
+		"OK-ACCESS-KEY":        creds.apiKey,
+		"Content-Type":         "application/json",
+	}
+	if o.client.Sandbox {
+		headers[okxSimulatedTradingHeader] = "1"
+	}
+
+	// 发送请求
+	if method == "GET" || method == "DELETE" {
+		return o.client.HTTPClient.RequestWithHeaders(ctx, http.MethodGet, path, params, nil, headers)
+	} else {
+		return o.client.HTTPClient.RequestWithHeaders(ctx, http.MethodPost, path, nil, body, headers)
+	}
+}
+
+// toConditionalOrder 将普通下单参数转换为条件单参数（/api/v5/trade/order-algo，ordType=conditional）
+// 止损使用 slTriggerPx/slOrdPx，止盈使用 tpTriggerPx/tpOrdPx；未设置 px 时委托价为 -1，触发后按市价下单
+func toConditionalOrder(body map[string]interface{}, stopPrice decimal.Decimal, triggerType option.TriggerType) {
+	ordPx := "-1"
+	if px, ok := body["px"]; ok {
+		ordPx = fmt.Sprint(px)
+		delete(body, "px")
+	}
+
+	prefix := "sl"
+	if triggerType.IsTakeProfit() {
+		prefix = "tp"
+	}
+	body[prefix+"TriggerPx"] = stopPrice.String()
+	body[prefix+"OrdPx"] = ordPx
+	body["ordType"] = "conditional"
+
+	// 策略委托使用 algoClOrdId 作为客户端订单ID
+	if clOrdID, ok := body["clOrdId"]; ok {
+		body["algoClOrdId"] = clOrdID
+		delete(body, "clOrdId")
+	}
+}
+
+// toTrailingStopOrder 将普通下单参数转换为跟踪止损参数（/api/v5/trade/order-algo，ordType=move_order_stop）
+// callbackRate 为百分比，转换为 callbackRatio（0.01 表示 1%）；activationPrice 为零时不设置 activePx
+func toTrailingStopOrder(body map[string]interface{}, callbackRate, activationPrice string) {
+	ratio, _ := decimal.NewFromString(callbackRate)
+	body["ordType"] = "move_order_stop"
+	body["callbackRatio"] = ratio.Div(decimal.NewFromInt(100)).String()
+	if activationPrice != "" {
+		body["activePx"] = activationPrice
+	}
+
+	// 策略委托使用 algoClOrdId 作为客户端订单ID
+	if clOrdID, ok := body["clOrdId"]; ok {
+		body["algoClOrdId"] = clOrdID
+		delete(body, "clOrdId")
+	}
+}
+
+// okxOrderParams 已构建好的下单参数（现货和合约共用，单笔下单和批量下单共用）
+type okxOrderParams struct {
+	symbol        string
+	path          string // /api/v5/trade/order 或条件单的 /api/v5/trade/order-algo
+	body          map[string]interface{}
+	clientOrderID string
+	stopPrice     decimal.Decimal
+	callbackRate  decimal.Decimal
+	isStop        bool // 策略委托（条件单或跟踪止损），订单ID为 algoId
+	strict        bool
+}
+
+// toNewOrder 将下单结果转换为 NewOrder，开启严格校验时检查客户端订单ID
+func (params *okxOrderParams) toNewOrder(data okxOrderResult) (*model.NewOrder, error) {
+	order := &model.NewOrder{
+		Symbol:        params.symbol,
+		OrderId:       data.OrdID,
+		ClientOrderID: data.ClOrdID,
+		Timestamp:     data.Ts,
+	}
+	if params.isStop {
+		order.OrderId = data.AlgoID
+		order.ClientOrderID = data.AlgoClOrdID
+		order.TriggerPrice = types.ExDecimal{Decimal: params.stopPrice}
+		order.CallbackRate = types.ExDecimal{Decimal: params.callbackRate}
+	}
+
+	if params.strict {
+		if err := common.CheckClientOrderID(params.clientOrderID, order.ClientOrderID); err != nil {
+			return order, err
+		}
+	}
+
+	return order, nil
+}
+
+// submitOrder 提交单个订单
+func (o *OKX) submitOrder(ctx context.Context, params *okxOrderParams) (*model.NewOrder, error) {
+	resp, err := o.signAndRequest(ctx, "POST", params.path, nil, params.body)
+	if err != nil {
+		return nil, fmt.Errorf("create order: %w", err)
+	}
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order: %w", err)
+	}
+
+	results, errs, err := result.orderResults()
+	if err != nil {
+		return nil, err
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	return params.toNewOrder(results[0])
+}
+
+// submitBatchOrders 提交同一接口的一批订单，普通订单通过 /api/v5/trade/batch-orders 一次提交，条件单逐个提交
+func (o *OKX) submitBatchOrders(ctx context.Context, path string, batch []*okxOrderParams) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(batch))
+	errs := make([]error, len(batch))
+
+	if path != "/api/v5/trade/order" {
+		for i, params := range batch {
+			orders[i], errs[i] = o.submitOrder(ctx, params)
+		}
+		return orders, errs, nil
+	}
+
+	body := make([]map[string]interface{}, len(batch))
+	for i, params := range batch {
+		body[i] = params.body
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/trade/batch-orders", nil, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create orders: %w", err)
+	}
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal batch orders: %w", err)
+	}
+
+	results, itemErrs, err := result.orderResults()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(results) != len(batch) {
+		return nil, nil, fmt.Errorf("create orders: expected %d results, got %d", len(batch), len(results))
+	}
+
+	for i, params := range batch {
+		if itemErrs[i] != nil {
+			errs[i] = itemErrs[i]
+			continue
+		}
+		orders[i], errs[i] = params.toNewOrder(results[i])
+	}
+	return orders, errs, nil
+}
+
+// okxFillsPageLimit 成交明细每页最大条数
+const okxFillsPageLimit = 100
+
+// fetchMyTradesRange 通过 /api/v5/trade/fills-history 查询账户成交记录（近三个月）
+// 结果按时间倒序返回，整页时以最后一条的 billId 作为 after 游标继续查询更早的成交
+func (o *OKX) fetchMyTradesRange(ctx context.Context, instType string, market *model.Market, since, until time.Time) ([]*model.Trade, error) {
+	return common.FetchTradesRange(ctx, since, until, func(ctx context.Context, since, until time.Time, cursor string) ([]*model.Trade, string, error) {
+		params := map[string]interface{}{
+			"instType": instType,
+			"instId":   market.ID,
+			"begin":    strconv.FormatInt(since.UnixMilli(), 10),
+			"end":      strconv.FormatInt(until.UnixMilli(), 10),
+			"limit":    strconv.Itoa(okxFillsPageLimit),
+		}
+		if cursor != "" {
+			params["after"] = cursor
+		}
+
+		resp, err := o.signAndRequest(ctx, "GET", "/api/v5/trade/fills-history", params, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("fetch my trades: %w", err)
+		}
+
+		var result okxFillsResponse
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, "", fmt.Errorf("unmarshal my trades: %w", err)
+		}
+		if result.Code != "0" {
+			return nil, "", newOKXError(result.Code, result.Msg)
+		}
+
+		trades := make([]*model.Trade, 0, len(result.Data))
+		for _, item := range result.Data {
+			trade := &model.Trade{
+				ID:        item.TradeID,
+				OrderID:   item.OrdID,
+				Symbol:    market.Symbol,
+				Side:      item.Side,
+				Amount:    item.FillSz.Decimal,
+				Price:     item.FillPx.Decimal,
+				Timestamp: item.Ts.Time,
+			}
+			// 合约数量为张数，成交金额需结合合约面值，仅现货计算
+			if instType == "SPOT" {
+				trade.Cost = item.FillSz.Mul(item.FillPx.Decimal)
+			}
+			trades = append(trades, trade)
+		}
+
+		next := ""
+		if len(result.Data) >= okxFillsPageLimit {
+			next = result.Data[len(result.Data)-1].BillID
+		}
+		return trades, next, nil
+	})
+}
@@ -0,0 +1,202 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	okxAlgoOrdTypeGrid         = "grid"          // 现货网格
+	okxAlgoOrdTypeContractGrid = "contract_grid" // 合约网格
+)
+
+// okxGrid 网格策略（现货网格和合约网格共用）
+type okxGrid struct {
+	okx         *OKX
+	algoOrdType string
+	getMarket   func(symbol string) (*model.Market, error)
+}
+
+// CreateGridOrder 创建网格策略
+func (g *okxGrid) CreateGridOrder(ctx context.Context, symbol string, params option.GridParams) (*model.AlgoOrder, error) {
+	market, err := g.getMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.MinPrice == "" || params.MaxPrice == "" {
+		return nil, fmt.Errorf("grid price range is required")
+	}
+	if params.GridNum <= 0 {
+		return nil, fmt.Errorf("grid number must be greater than 0")
+	}
+	if params.Investment == "" {
+		return nil, fmt.Errorf("grid investment is required")
+	}
+
+	// runType: 1 等差，2 等比
+	runType := "1"
+	if params.RunType == option.GridGeometric {
+		runType = "2"
+	}
+
+	req := types.NewExValues()
+	req.SetBody("instId", market.ID)
+	req.SetBody("algoOrdType", g.algoOrdType)
+	req.SetBody("minPx", params.MinPrice)
+	req.SetBody("maxPx", params.MaxPrice)
+	req.SetBody("gridNum", strconv.Itoa(params.GridNum))
+	req.SetBody("runType", runType)
+	if g.algoOrdType == okxAlgoOrdTypeContractGrid {
+		if params.Direction == "" {
+			return nil, fmt.Errorf("contract grid direction is required")
+		}
+		if params.Leverage <= 0 {
+			return nil, fmt.Errorf("contract grid leverage must be greater than 0")
+		}
+		req.SetBody("sz", params.Investment)
+		req.SetBody("direction", params.Direction)
+		req.SetBody("lever", strconv.Itoa(params.Leverage))
+	} else {
+		req.SetBody("quoteSz", params.Investment)
+	}
+
+	resp, err := g.okx.signAndRequest(ctx, "POST", "/api/v5/tradingBot/grid/order-algo", nil, req.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("create grid order: %w", err)
+	}
+
+	algoID, err := parseGridOrderResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("create grid order: %w", err)
+	}
+
+	return &model.AlgoOrder{
+		ID:         algoID,
+		Symbol:     market.Symbol,
+		Type:       g.algoOrdType,
+		Status:     "starting",
+		GridNum:    params.GridNum,
+		MinPrice:   parseExDecimal(params.MinPrice),
+		MaxPrice:   parseExDecimal(params.MaxPrice),
+		Investment: parseExDecimal(params.Investment),
+	}, nil
+}
+
+// StopGridOrder 停止网格策略（现货网格卖出持有的交易币，合约网格市价平仓）
+func (g *okxGrid) StopGridOrder(ctx context.Context, symbol string, algoID string) error {
+	market, err := g.getMarket(symbol)
+	if err != nil {
+		return err
+	}
+
+	// 停止接口要求数组请求体
+	body := []map[string]interface{}{{
+		"algoId":      algoID,
+		"instId":      market.ID,
+		"algoOrdType": g.algoOrdType,
+		"stopType":    "1",
+	}}
+
+	resp, err := g.okx.signAndRequest(ctx, "POST", "/api/v5/tradingBot/grid/stop-order-algo", nil, body)
+	if err != nil {
+		return fmt.Errorf("stop grid order: %w", err)
+	}
+
+	if _, err := parseGridOrderResponse(resp); err != nil {
+		return fmt.Errorf("stop grid order: %w", err)
+	}
+	return nil
+}
+
+// FetchGridOrder 查询网格策略
+func (g *okxGrid) FetchGridOrder(ctx context.Context, symbol string, algoID string) (*model.AlgoOrder, error) {
+	market, err := g.getMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"algoOrdType": g.algoOrdType,
+		"algoId":      algoID,
+	}
+
+	resp, err := g.okx.signAndRequest(ctx, "GET", "/api/v5/tradingBot/grid/orders-algo-details", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch grid order: %w", err)
+	}
+
+	var result okxGridOrderDetailResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal grid order: %w", err)
+	}
+
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("grid order not found")
+	}
+
+	item := result.Data[0]
+	gridNum, _ := strconv.Atoi(item.GridNum)
+	return &model.AlgoOrder{
+		ID:         item.AlgoID,
+		Symbol:     market.Symbol,
+		Type:       item.AlgoOrdType,
+		Status:     item.State,
+		MinPrice:   item.MinPx,
+		MaxPrice:   item.MaxPx,
+		GridNum:    gridNum,
+		Investment: item.Investment,
+		TotalPnl:   item.TotalPnl,
+		CreatedAt:  item.CTime,
+		UpdatedAt:  item.UTime,
+	}, nil
+}
+
+// parseGridOrderResponse 解析网格策略下单/停止响应，返回策略ID
+func parseGridOrderResponse(resp []byte) (string, error) {
+	var result okxGridOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	// 策略级错误优先于顶层错误，便于定位具体原因
+	if len(result.Data) > 0 && result.Data[0].SCode != "" && result.Data[0].SCode != "0" {
+		return "", &OrderError{
+			Code:          result.Data[0].SCode,
+			Message:       result.Data[0].SMsg,
+			OrderID:       result.Data[0].AlgoID,
+			ClientOrderID: result.Data[0].AlgoClOrdID,
+		}
+	}
+	if result.Code != "0" {
+		return "", newOKXError(result.Code, result.Msg)
+	}
+	if len(result.Data) == 0 || result.Data[0].AlgoID == "" {
+		return "", fmt.Errorf("no algo id returned")
+	}
+
+	return result.Data[0].AlgoID, nil
+}
+
+// parseExDecimal 解析十进制字符串，无效时返回零值
+func parseExDecimal(s string) types.ExDecimal {
+	d, _ := decimal.NewFromString(s)
+	return types.ExDecimal{Decimal: d}
+}
+
+// 确保 OKX 现货和永续合约实现了 exchange.GridTrader 接口
+var (
+	_ exchange.GridTrader = (*OKXSpot)(nil)
+	_ exchange.GridTrader = (*OKXPerp)(nil)
+)
@@ -0,0 +1,132 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/option"
+)
+
+func newGridTestOKX(t *testing.T, handler http.HandlerFunc) *OKX {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	o := ex.(*OKX)
+	spot := &model.Market{ID: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	o.spotMarketsBySymbol[spot.Symbol] = spot
+	o.spotMarketsByID[spot.ID] = spot
+	perp := &model.Market{ID: "BTC-USDT-SWAP", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	o.perpMarketsBySymbol[perp.Symbol] = perp
+	o.perpMarketsByID[perp.ID] = perp
+	return o
+}
+
+func TestOKXSpot_CreateGridOrder(t *testing.T) {
+	var body map[string]interface{}
+	o := newGridTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/tradingBot/grid/order-algo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"algoId":"447053782921515008","algoClOrdId":"","sCode":"0","sMsg":"","tag":""}]}`))
+	})
+
+	grid, ok := o.Spot().(exchange.GridTrader)
+	if !ok {
+		t.Fatal("OKX spot should implement exchange.GridTrader")
+	}
+
+	algo, err := grid.CreateGridOrder(context.Background(), "BTC/USDT", option.GridParams{
+		MinPrice:   "60000",
+		MaxPrice:   "70000",
+		GridNum:    10,
+		RunType:    option.GridGeometric,
+		Investment: "1000",
+	})
+	if err != nil {
+		t.Fatalf("CreateGridOrder: %v", err)
+	}
+
+	if algo.ID != "447053782921515008" || algo.Symbol != "BTC/USDT" || algo.Type != "grid" {
+		t.Errorf("algo = %+v", algo)
+	}
+	if algo.GridNum != 10 || algo.MinPrice.String() != "60000" || algo.Investment.String() != "1000" {
+		t.Errorf("algo params = %+v", algo)
+	}
+
+	want := map[string]string{"instId": "BTC-USDT", "algoOrdType": "grid", "minPx": "60000", "maxPx": "70000", "gridNum": "10", "runType": "2", "quoteSz": "1000"}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("body[%s] = %v, want %s", k, body[k], v)
+		}
+	}
+}
+
+func TestOKXPerp_CreateGridOrder_Error(t *testing.T) {
+	var body map[string]interface{}
+	o := newGridTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"1","msg":"","data":[{"algoId":"","algoClOrdId":"","sCode":"51340","sMsg":"Used margin must be greater than 10 USDT","tag":""}]}`))
+	})
+
+	_, err := o.Perp().(exchange.GridTrader).CreateGridOrder(context.Background(), "BTC/USDT:USDT", option.GridParams{
+		MinPrice:   "60000",
+		MaxPrice:   "70000",
+		GridNum:    10,
+		Investment: "5",
+		Direction:  "long",
+		Leverage:   3,
+	})
+
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Code != "51340" {
+		t.Fatalf("err = %v, want OrderError with code 51340", err)
+	}
+	if body["algoOrdType"] != "contract_grid" || body["sz"] != "5" || body["direction"] != "long" || body["lever"] != "3" {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestOKXSpot_StopGridOrder(t *testing.T) {
+	var body []map[string]interface{}
+	o := newGridTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/tradingBot/grid/stop-order-algo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// 停止接口的请求体为数组
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"algoId":"447053782921515008","algoClOrdId":"","sCode":"0","sMsg":"","tag":""}]}`))
+	})
+
+	if err := o.Spot().(exchange.GridTrader).StopGridOrder(context.Background(), "BTC/USDT", "447053782921515008"); err != nil {
+		t.Fatalf("StopGridOrder: %v", err)
+	}
+	if len(body) != 1 || body[0]["algoId"] != "447053782921515008" || body[0]["instId"] != "BTC-USDT" {
+		t.Errorf("body = %v", body)
+	}
+}
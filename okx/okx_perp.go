@@ -18,22 +18,26 @@ import (
 
 // OKXPerp OKX 永续合约实现
 type OKXPerp struct {
-	okx *OKX
+	okx          *OKX
+	grid         *okxGrid
+	positionMode common.PositionMode // 账户持仓模式（U本位和币本位合约共用）
 }
 
 // NewOKXPerp 创建 OKX 永续合约实例
 func NewOKXPerp(o *OKX) *OKXPerp {
-	return &OKXPerp{
+	p := &OKXPerp{
 		okx: o,
 	}
+	p.grid = &okxGrid{okx: o, algoOrdType: okxAlgoOrdTypeContractGrid, getMarket: p.GetMarket}
+	return p
 }
 
 // ========== PerpExchange 接口实现 ==========
 
 func (p *OKXPerp) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	p.okx.mu.RLock()
-	if !reload && len(p.okx.perpMarketsBySymbol) > 0 {
+	if !reload && len(p.okx.perpMarketsBySymbol) > 0 && !p.okx.marketCache.Expired(model.MarketTypeSwap) {
 		p.okx.mu.RUnlock()
 		return nil
 	}
@@ -68,6 +72,7 @@ func (p *OKXPerp) LoadMarkets(ctx context.Context, reload bool) error {
 			LotSz      types.ExDecimal `json:"lotSz"`
 			TickSz     types.ExDecimal `json:"tickSz"`
 			MinSzVal   types.ExDecimal `json:"minSzVal"`
+			Lever      types.ExDecimal `json:"lever"` // 最大杠杆倍数
 		} `json:"data"`
 	}
 	if err := json.Unmarshal(resp, &respData); err != nil {
@@ -75,7 +80,7 @@ func (p *OKXPerp) LoadMarkets(ctx context.Context, reload bool) error {
 	}
 
 	if respData.Code != "0" {
-		return fmt.Errorf("okx api error: %s", respData.Msg)
+		return newOKXError(respData.Code, respData.Msg)
 	}
 
 	markets := make([]*model.Market, 0)
@@ -119,8 +124,11 @@ func (p *OKXPerp) LoadMarkets(ctx context.Context, reload bool) error {
 		if !item.MinSzVal.IsZero() {
 			market.Limits.Cost.Min = item.MinSzVal
 		}
+		market.Limits.Leverage.Max = item.Lever
 
 		// 计算精度
+		market.Precision.StepSize = item.LotSz
+		market.Precision.TickSize = item.TickSz
 		if !item.LotSz.IsZero() {
 			lotSzStr := item.LotSz.String()
 			parts := strings.Split(lotSzStr, ".")
@@ -139,16 +147,11 @@ func (p *OKXPerp) LoadMarkets(ctx context.Context, reload bool) error {
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	p.okx.mu.Lock()
-	if p.okx.perpMarketsBySymbol == nil {
-		p.okx.perpMarketsBySymbol = make(map[string]*model.Market)
-		p.okx.perpMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		p.okx.perpMarketsBySymbol[market.Symbol] = market
-		p.okx.perpMarketsByID[market.ID] = market
-	}
+	p.okx.perpMarketsBySymbol, p.okx.perpMarketsByID = bySymbol, byID
+	p.okx.marketCache.Touch(model.MarketTypeSwap)
 	p.okx.mu.Unlock()
 
 	return nil
@@ -198,7 +201,50 @@ func (p *OKXPerp) GetMarket(symbol string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", symbol)
+	return nil, common.MarketNotFound(symbol, p.okx.perpMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (p *OKXPerp) GetMarketByID(id string) (*model.Market, error) {
+	p.okx.mu.RLock()
+	defer p.okx.mu.RUnlock()
+
+	if market, ok := p.okx.perpMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, p.okx.perpMarketsByID)
+}
+
+func (p *OKXPerp) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (p *OKXPerp) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+func (p *OKXPerp) AmountToContracts(symbol, amount string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToContracts(market, amount)
+}
+
+func (p *OKXPerp) ContractsToAmount(symbol, contracts string) (string, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.ContractsToAmount(market, contracts)
 }
 
 func (p *OKXPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
@@ -232,7 +278,7 @@ func (p *OKXPerp) FetchTicker(ctx context.Context, symbol string) (*model.Ticker
 	}
 
 	if result.Code != "0" || len(result.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	data := result.Data[0]
@@ -260,6 +306,11 @@ func (p *OKXPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (
 		opt(argsOpts)
 	}
 
+	// 确保市场已加载
+	if err := p.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+
 	var querySymbol string
 	if symbol, ok := option.GetString(argsOpts.Symbol); ok {
 		market, err := p.GetMarket(symbol)
@@ -304,23 +355,23 @@ func (p *OKXPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (
 	}
 
 	if respData.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", respData.Msg)
+		return nil, newOKXError(respData.Code, respData.Msg)
 	}
 
 	tickers := make(model.Tickers, 0, len(respData.Data))
 	for _, item := range respData.Data {
-		// 尝试从市场信息中查找标准化格式
-		market, err := p.GetMarket(item.InstID)
-		if err != nil {
+		if querySymbol != "" && item.InstID != querySymbol {
 			continue
 		}
 
-		if querySymbol != "" && market.ID != querySymbol {
-			continue
+		// 优先使用市场信息中的标准化格式，查不到时（如新上线的合约）直接转换原始ID
+		symbol := symbolFromOKXID(item.InstID)
+		if market, err := p.GetMarket(item.InstID); err == nil {
+			symbol = market.Symbol
 		}
 
 		ticker := &model.Ticker{
-			Symbol:    market.Symbol,
+			Symbol:    symbol,
 			Timestamp: item.Ts,
 		}
 		ticker.Bid = item.BidPx
@@ -337,6 +388,91 @@ func (p *OKXPerp) FetchTickers(ctx context.Context, opts ...option.ArgsOption) (
 	return tickers, nil
 }
 
+func (p *OKXPerp) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"instId": market.ID,
+	}
+	if limit = common.ClampOrderBookLimit(limit, okxMaxDepthLimit); limit > 0 {
+		params["sz"] = limit
+	}
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/market/books", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var result okxOrderBookResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	if result.Code != "0" || len(result.Data) == 0 {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	data := result.Data[0]
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.SeqID,
+		Timestamp: data.Ts,
+	}, nil
+}
+
+func (p *OKXPerp) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.okx.ws, okxWSTopic("tickers", market.ID), parseOKXWSTicker(market.Symbol))
+}
+
+func (p *OKXPerp) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  p.okx.ws,
+		Topic:    okxWSTopic("books", market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequencePrev,
+		Parse:    parseOKXWSOrderBook,
+	})
+}
+
+// WatchOHLCV 通过业务 WebSocket 订阅K线（candle 频道），每次更新推送当前K线，收盘K线的 Closed 为 true
+func (p *OKXPerp) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	bar, err := common.CheckTimeframe(timeframe, common.OKXTimeframe(timeframe), okxTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.okx.businessWS, okxWSTopic(okxCandleChannel(bar), market.ID), parseOKXWSCandle)
+}
+
 func (p *OKXPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, limit int, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -347,15 +483,28 @@ func (p *OKXPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe stri
 	if err != nil {
 		return nil, err
 	}
+	bar, err := common.CheckTimeframe(timeframe, common.OKXTimeframe(timeframe), okxTimeframes)
+	if err != nil {
+		return nil, err
+	}
 	req := types.NewExValues()
 	req.SetQuery("instId", market.ID)
-	req.SetQuery("bar", common.OKXTimeframe(timeframe))
+	req.SetQuery("bar", bar)
 	req.SetQuery("limit", limit)
-	if since, ok := option.GetTime(argsOpts.Since); ok {
+	path := "/api/v5/market/candles"
+	since, hasSince := option.GetTime(argsOpts.Since)
+	if until, ok := option.GetTime(argsOpts.Until); ok {
+		// 指定截止时间时使用历史K线接口：after 返回早于该时间的记录，before 返回晚于该时间的记录
+		path = "/api/v5/market/history-candles"
+		req.SetQuery("after", until.UnixMilli()+1)
+		if hasSince {
+			req.SetQuery("before", since.UnixMilli()-1)
+		}
+	} else if hasSince {
 		req.SetQuery("after", since.UnixMilli())
 	}
 
-	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/market/candles", req.ToQueryMap())
+	resp, err := p.okx.client.HTTPClient.Get(ctx, path, req.ToQueryMap())
 	if err != nil {
 		return nil, fmt.Errorf("fetch ohlcv: %w", err)
 	}
@@ -370,7 +519,7 @@ func (p *OKXPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe stri
 	}
 
 	if respData.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", respData.Msg)
+		return nil, newOKXError(respData.Code, respData.Msg)
 	}
 
 	ohlcvs := make(model.OHLCVs, 0, len(respData.Data))
@@ -399,9 +548,232 @@ func (p *OKXPerp) FetchOHLCVs(ctx context.Context, symbol string, timeframe stri
 		ohlcvs = append(ohlcvs, ohlcv)
 	}
 
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, p.okx.clock.Now())
+	}
 	return ohlcvs, nil
 }
 
+func (p *OKXPerp) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, okxOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, limit, option.WithSince(start), option.WithUntil(end))
+	})
+}
+
+func (p *OKXPerp) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return p.FetchOHLCVs(ctx, symbol, timeframe, 2)
+	})
+}
+
+func (p *OKXPerp) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetQuery("instId", market.ID)
+	req.SetQuery("limit", okxMaxTradesLimit)
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/market/trades", req.ToQueryMap())
+	if err != nil {
+		return nil, fmt.Errorf("fetch trades: %w", err)
+	}
+
+	var respData okxTradesResponse
+	if err = json.Unmarshal(resp, &respData); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
+	}
+
+	if respData.Code != "0" {
+		return nil, newOKXError(respData.Code, respData.Msg)
+	}
+
+	return common.AggregateTrades(respData.toTrades(market.Symbol), since, limit), nil
+}
+
+func (p *OKXPerp) FetchFundingRate(ctx context.Context, symbol string) (*model.FundingRate, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/public/funding-rate", map[string]interface{}{
+		"instId": market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate: %w", err)
+	}
+
+	var result okxFundingRateResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate: %w", err)
+	}
+
+	if result.Code != "0" || len(result.Data) == 0 {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	// fundingTime 为当期费率的结算时间，即下次结算时间
+	data := result.Data[0]
+	return &model.FundingRate{
+		Symbol:          market.Symbol,
+		Rate:            data.FundingRate,
+		Timestamp:       data.Ts,
+		NextFundingTime: data.FundingTime,
+	}, nil
+}
+
+func (p *OKXPerp) FetchFundingRateHistory(ctx context.Context, symbol string, since time.Time, limit int) (model.FundingRates, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"instId": market.ID,
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if !since.IsZero() {
+		// before 返回结算时间晚于该值的记录
+		params["before"] = since.UnixMilli() - 1
+	}
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/public/funding-rate-history", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding rate history: %w", err)
+	}
+
+	var result okxFundingRateHistoryResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal funding rate history: %w", err)
+	}
+
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	// 接口按时间倒序返回
+	rates := make(model.FundingRates, 0, len(result.Data))
+	for i := len(result.Data) - 1; i >= 0; i-- {
+		item := result.Data[i]
+		rates = append(rates, &model.FundingRate{
+			Symbol:    market.Symbol,
+			Rate:      item.RealizedRate,
+			Timestamp: item.FundingTime,
+		})
+	}
+	return rates, nil
+}
+
+// FetchOpenInterest 获取持仓量（/api/v5/public/open-interest），U 本位合约取币数量 oiCcy，币本位合约取张数 oi
+func (p *OKXPerp) FetchOpenInterest(ctx context.Context, symbol string) (*model.OpenInterest, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/public/open-interest", map[string]interface{}{
+		"instType": "SWAP",
+		"instId":   market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch open interest: %w", err)
+	}
+
+	var result okxOpenInterestResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal open interest: %w", err)
+	}
+
+	if result.Code != "0" || len(result.Data) == 0 {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	data := result.Data[0]
+	amount := data.OiCcy
+	if market.Inverse {
+		amount = data.Oi
+	}
+	return &model.OpenInterest{
+		Symbol:             market.Symbol,
+		OpenInterestAmount: amount,
+		OpenInterestValue:  data.OiUsd,
+		Timestamp:          data.Ts,
+	}, nil
+}
+
+// FetchMarkPrice 获取标记价格（/api/v5/public/mark-price）和指数价格（/api/v5/market/index-tickers），接口不返回最新成交价
+func (p *OKXPerp) FetchMarkPrice(ctx context.Context, symbol string) (*model.Ticker, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/public/mark-price", map[string]interface{}{
+		"instType": "SWAP",
+		"instId":   market.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch mark price: %w", err)
+	}
+
+	var result okxMarkPriceResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal mark price: %w", err)
+	}
+	if result.Code != "0" || len(result.Data) == 0 {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	indexPrice, err := p.fetchIndexPrice(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Ticker{
+		Symbol:     market.Symbol,
+		MarkPrice:  result.Data[0].MarkPx,
+		IndexPrice: types.ExDecimal{Decimal: indexPrice},
+		Timestamp:  result.Data[0].Ts,
+	}, nil
+}
+
+// FetchIndexPrice 获取指数价格（/api/v5/market/index-tickers）
+func (p *OKXPerp) FetchIndexPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return p.fetchIndexPrice(ctx, market)
+}
+
+// fetchIndexPrice 查询合约对应的指数价格，指数ID为去掉 -SWAP 后缀的产品ID（如 BTC-USDT、BTC-USD）
+func (p *OKXPerp) fetchIndexPrice(ctx context.Context, market *model.Market) (decimal.Decimal, error) {
+	resp, err := p.okx.client.HTTPClient.Get(ctx, "/api/v5/market/index-tickers", map[string]interface{}{
+		"instId": strings.TrimSuffix(market.ID, "-SWAP"),
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("fetch index price: %w", err)
+	}
+
+	var result okxIndexTickerResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return decimal.Zero, fmt.Errorf("unmarshal index price: %w", err)
+	}
+	if result.Code != "0" || len(result.Data) == 0 {
+		return decimal.Zero, newOKXError(result.Code, result.Msg)
+	}
+	return result.Data[0].IdxPx.Decimal, nil
+}
+
 func (p *OKXPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption) (model.Positions, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -429,86 +801,16 @@ func (p *OKXPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption)
 	}
 
 	var result okxPerpPositionResponse
-	var respData struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			Adl                    types.ExDecimal   `json:"adl"`
-			AvailPos               types.ExDecimal   `json:"availPos"`
-			AvgPx                  types.ExDecimal   `json:"avgPx"`
-			BaseBal                types.ExDecimal   `json:"baseBal"`
-			BaseBorrowed           types.ExDecimal   `json:"baseBorrowed"`
-			BaseInterest           types.ExDecimal   `json:"baseInterest"`
-			BePx                   types.ExDecimal   `json:"bePx"`
-			BizRefId               string            `json:"bizRefId"`
-			BizRefType             string            `json:"bizRefType"`
-			CTime                  types.ExTimestamp `json:"cTime"`
-			Ccy                    string            `json:"ccy"`
-			ClSpotInUseAmt         types.ExDecimal   `json:"clSpotInUseAmt"`
-			CloseOrderAlgo         []interface{}     `json:"closeOrderAlgo"`
-			DeltaBS                types.ExDecimal   `json:"deltaBS"`
-			DeltaPA                types.ExDecimal   `json:"deltaPA"`
-			Fee                    types.ExDecimal   `json:"fee"`
-			FundingFee             types.ExDecimal   `json:"fundingFee"`
-			GammaBS                types.ExDecimal   `json:"gammaBS"`
-			GammaPA                types.ExDecimal   `json:"gammaPA"`
-			HedgedPos              types.ExDecimal   `json:"hedgedPos"`
-			IdxPx                  types.ExDecimal   `json:"idxPx"`
-			Imr                    types.ExDecimal   `json:"imr"`
-			InstID                 string            `json:"instId"`
-			InstType               string            `json:"instType"`
-			Interest               types.ExDecimal   `json:"interest"`
-			Last                   types.ExDecimal   `json:"last"`
-			Lever                  types.ExDecimal   `json:"lever"`
-			Liab                   types.ExDecimal   `json:"liab"`
-			LiabCcy                string            `json:"liabCcy"`
-			LiqPenalty             types.ExDecimal   `json:"liqPenalty"`
-			LiqPx                  types.ExDecimal   `json:"liqPx"`
-			Margin                 types.ExDecimal   `json:"margin"`
-			MarkPx                 types.ExDecimal   `json:"markPx"`
-			MaxSpotInUseAmt        types.ExDecimal   `json:"maxSpotInUseAmt"`
-			MgnMode                string            `json:"mgnMode"`
-			MgnRatio               types.ExDecimal   `json:"mgnRatio"`
-			Mmr                    types.ExDecimal   `json:"mmr"`
-			NonSettleAvgPx         types.ExDecimal   `json:"nonSettleAvgPx"`
-			NotionalUsd            types.ExDecimal   `json:"notionalUsd"`
-			OptVal                 types.ExDecimal   `json:"optVal"`
-			PendingCloseOrdLiabVal types.ExDecimal   `json:"pendingCloseOrdLiabVal"`
-			Pnl                    types.ExDecimal   `json:"pnl"`
-			Pos                    types.ExDecimal   `json:"pos"`
-			PosCcy                 string            `json:"posCcy"`
-			PosID                  string            `json:"posId"`
-			PosSide                string            `json:"posSide"`
-			QuoteBal               types.ExDecimal   `json:"quoteBal"`
-			QuoteBorrowed          types.ExDecimal   `json:"quoteBorrowed"`
-			QuoteInterest          types.ExDecimal   `json:"quoteInterest"`
-			RealizedPnl            types.ExDecimal   `json:"realizedPnl"`
-			SettledPnl             types.ExDecimal   `json:"settledPnl"`
-			SpotInUseAmt           types.ExDecimal   `json:"spotInUseAmt"`
-			SpotInUseCcy           string            `json:"spotInUseCcy"`
-			ThetaBS                types.ExDecimal   `json:"thetaBS"`
-			ThetaPA                types.ExDecimal   `json:"thetaPA"`
-			TradeID                string            `json:"tradeId"`
-			UTime                  types.ExTimestamp `json:"uTime"`
-			Upl                    types.ExDecimal   `json:"upl"`
-			UplLastPx              types.ExDecimal   `json:"uplLastPx"`
-			UplRatio               types.ExDecimal   `json:"uplRatio"`
-			UplRatioLastPx         types.ExDecimal   `json:"uplRatioLastPx"`
-			UsdPx                  types.ExDecimal   `json:"usdPx"`
-			VegaBS                 types.ExDecimal   `json:"vegaBS"`
-			VegaPA                 types.ExDecimal   `json:"vegaPA"`
-		} `json:"data"`
-	}
-	if err = json.Unmarshal(resp, &respData); err != nil {
+	if err = json.Unmarshal(resp, &result); err != nil {
 		return nil, fmt.Errorf("unmarshal positions: %w", err)
 	}
 
-	if respData.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	positions := make([]*model.Position, 0)
-	for _, item := range respData.Data {
+	for _, item := range result.Data {
 		if item.Pos.IsZero() {
 			continue
 		}
@@ -526,19 +828,33 @@ func (p *OKXPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption)
 			side = string(types.PositionSideShort)
 		}
 
+		// 买卖模式下空仓 pos 为负数
+		amount := item.Pos
+		if amount.IsNegative() {
+			amount = types.ExDecimal{Decimal: amount.Neg()}
+		}
+
+		// 全仓持仓不返回 margin，使用初始保证金 imr
+		margin := item.Margin
+		if item.MgnMode == model.MarginModeCross && margin.IsZero() {
+			margin = item.Imr
+		}
+
 		position := &model.Position{
-			Symbol:           market.Symbol,
-			Side:             side,
-			Amount:           item.Pos,
-			EntryPrice:       item.AvgPx,
-			MarkPrice:        item.MarkPx,
-			UnrealizedPnl:    item.Upl,
-			LiquidationPrice: item.LiqPx,
-			RealizedPnl:      item.RealizedPnl,
-			Leverage:         item.Lever,
-			Margin:           item.Margin,
-			Percentage:       types.ExDecimal{},
-			Timestamp:        item.UTime,
+			Symbol:            market.Symbol,
+			Side:              side,
+			Amount:            amount,
+			EntryPrice:        item.AvgPx,
+			MarkPrice:         item.MarkPx,
+			UnrealizedPnl:     item.Upl,
+			LiquidationPrice:  item.LiqPx,
+			RealizedPnl:       item.RealizedPnl,
+			Leverage:          item.Lever,
+			Margin:            margin,
+			MarginMode:        item.MgnMode,
+			MaintenanceMargin: item.Mmr,
+			Percentage:        types.ExDecimal{},
+			Timestamp:         item.UTime,
 		}
 
 		positions = append(positions, position)
@@ -547,7 +863,83 @@ func (p *OKXPerp) FetchPositions(ctx context.Context, opts ...option.ArgsOption)
 	return positions, nil
 }
 
+// WatchPositions 轮询持仓并推送持仓变化，同时订阅私有频道 orders，强平、自动减仓导致的减仓/平仓事件填充 Reason
+// 订单订阅失败时仍推送持仓变化，但不填充 Reason
+func (p *OKXPerp) WatchPositions(ctx context.Context, opts ...option.ArgsOption) (<-chan *model.PositionUpdate, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orders, err := p.WatchOrders(ctx)
+	if err != nil {
+		orders = nil
+	}
+	return common.PollPositionsWithOrders(ctx, 0, func(ctx context.Context) (model.Positions, error) {
+		return p.FetchPositions(ctx, opts...)
+	}, orders)
+}
+
+// WatchBalance 通过私有频道 account 订阅交易账户余额，推送包含发生变化的币种
+func (p *OKXPerp) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, p.okx.privateWS, okxWSAccountTopic, parseOKXWSAccount)
+}
+
+// WatchOrders 通过私有频道 orders 订阅永续合约订单更新，订单每次状态变化推送一次
+func (p *OKXPerp) WatchOrders(ctx context.Context) (<-chan *model.PerpOrder, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, p.okx.privateWS, okxWSSwapOrdersTopic, p.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 orders 的永续合约订单推送（WatchOrders 和 TrackOrder 共用）
+func (p *OKXPerp) parseWSOrders() common.WSParser[[]*model.PerpOrder] {
+	return parseOKXWSOrders(func(raw json.RawMessage) (*model.PerpOrder, bool) {
+		var item okxPerpOrderItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		symbol := item.InstID
+		if market, err := p.GetMarket(item.InstID); err == nil {
+			symbol = market.Symbol
+		}
+		return toOKXPerpOrder(symbol, &item), true
+	})
+}
+
+// 跟踪止损回调比例范围（百分比）
+var (
+	okxMinCallbackRate = decimal.NewFromFloat(0.1)
+	okxMaxCallbackRate = decimal.NewFromInt(100)
+)
+
 func (p *OKXPerp) CreateOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	order, err := common.CreateOrderIdempotent(ctx, p.okx.client.HTTPClient, p.okx.Name(), orderSide.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return p.createOrder(ctx, symbol, amount, orderSide, orderType, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.PerpOrder, error) {
+		return p.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		p.okx.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+func (p *OKXPerp) createOrder(ctx context.Context, symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	params, err := p.buildOrderParams(symbol, amount, orderSide, orderType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return p.okx.submitOrder(ctx, params)
+}
+
+// buildOrderParams 校验并构建下单参数（单笔下单和批量下单共用）
+func (p *OKXPerp) buildOrderParams(symbol string, amount string, orderSide option.PerpOrderSide, orderType option.OrderType, opts ...option.ArgsOption) (*okxOrderParams, error) {
 	// 解析选项
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -576,34 +968,43 @@ func (p *OKXPerp) CreateOrder(ctx context.Context, symbol string, amount string,
 		return nil, fmt.Errorf("MarginType must be either ISOLATED or CROSSED")
 	}
 
-	timeInForce := argsOpts.TimeInForce
 	// 限价单，必须设置价格
-	if orderType == option.Limit || *timeInForce == option.FOK || *timeInForce == option.IOC {
+	if orderType == option.Limit {
 		price, ok := option.GetDecimalFromString(argsOpts.Price)
 		if !ok || price.IsZero() {
 			return nil, fmt.Errorf("limit order requires price")
 		}
-		req.SetBody("px", price)
-		if timeInForce != nil {
-			req.SetBody("ordType", timeInForce.Lower())
+		px, err := common.PriceToPrecision(market, price.String())
+		if err != nil {
+			return nil, err
 		}
+		req.SetBody("px", px)
 	}
 
-	// 设置数量（合约张数）
-	if quantity, ok := option.GetDecimalFromString(&amount); ok {
-		req.SetBody("sz", quantity.String())
-	} else {
+	// 设置数量（合约张数，向下对齐到 lotSz）
+	quantity, ok := option.GetDecimalFromString(&amount)
+	if !ok {
 		return nil, fmt.Errorf("amount is required and must be a valid decimal")
 	}
+	sz, err := common.AmountToPrecision(market, quantity.String())
+	if err != nil {
+		return nil, err
+	}
+	req.SetBody("sz", sz)
 
-	if !req.HasBody("ordType") {
-		req.SetBody("ordType", orderType.Lower())
+	// 订单有效期通过 ordType 表示
+	if err := common.CheckTimeInForce(argsOpts, orderType == option.Limit, false); err != nil {
+		return nil, err
+	}
+	postOnly, err := common.ParsePostOnly(argsOpts, orderType == option.Limit)
+	if err != nil {
+		return nil, err
 	}
+	req.SetBody("ordType", okxOrdType(orderType == option.Limit, postOnly, argsOpts.TimeInForce))
 	req.SetBody("side", strings.ToLower(orderSide.ToSide()))
-	req.SetBody("reduceOnly", orderSide.ToReduceOnly())
 
-	if hedgeMode, ok := option.GetBool(argsOpts.HedgeMode); hedgeMode && ok {
-		// 双向持仓模式
+	if p.positionMode.Hedged(argsOpts.HedgeMode) {
+		// 双向持仓模式，由 posSide 区分多空仓位，reduceOnly 只适用于单向持仓，不发送
 		// 开多/平多: posSide=long
 		// 开空/平空: posSide=short
 		if orderSide.ToPositionSide() == "LONG" {
@@ -612,57 +1013,122 @@ func (p *OKXPerp) CreateOrder(ctx context.Context, symbol string, amount string,
 			req.SetBody("posSide", "short")
 		}
 	} else {
+		// 单向持仓模式，平仓单通过 reduceOnly 避免反向开仓
 		req.SetBody("posSide", "net")
+		req.SetBody("reduceOnly", common.ReduceOnly(orderSide, argsOpts))
 	}
 
-	if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
-		req.SetBody("clOrdId", clientOrderId)
-	} else {
+	clientOrderID, ok := option.GetString(argsOpts.ClientOrderID)
+	if !ok {
 		// 生成订单 ID
-		generatedID := common.GenerateClientOrderID(p.okx.Name(), orderSide.ToSide())
-		req.SetBody("clOrdId", generatedID)
+		clientOrderID = common.GenerateClientOrderID(p.okx.Name(), orderSide.ToSide())
 	}
+	req.SetBody("clOrdId", clientOrderID)
 
-	resp, err := p.signAndRequest(ctx, "POST", "/api/v5/trade/order", nil, req.ToBodyMap())
+	// 设置触发价时通过策略委托下条件单
+	path := "/api/v5/trade/order"
+	body := req.ToBodyMap()
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(argsOpts)
 	if err != nil {
-		return nil, fmt.Errorf("create order: %w", err)
-	}
-
-	var respData struct {
-		Code string `json:"code"` // 返回码，"0" 表示成功
-		Data []struct {
-			ClOrdID string            `json:"clOrdId"` // 客户端订单ID
-			OrdID   string            `json:"ordId"`   // 系统订单号
-			TS      types.ExTimestamp `json:"ts"`      // 时间戳（毫秒）
-		} `json:"data"` // 订单数据数组
-		Msg string `json:"msg,omitempty"` // 返回消息
+		return nil, err
 	}
-	if err = json.Unmarshal(resp, &respData); err != nil {
-		return nil, fmt.Errorf("unmarshal order: %w", err)
+	if isStop {
+		toConditionalOrder(body, stopPrice, triggerType)
+		path = "/api/v5/trade/order-algo"
 	}
 
-	if respData.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", respData.Msg)
+	// 跟踪止损通过策略委托下单，触发后按市价成交
+	callbackRate, activationPrice, isTrailing, err := common.ParseTrailingStop(argsOpts, okxMinCallbackRate, okxMaxCallbackRate)
+	if err != nil {
+		return nil, err
 	}
+	if isTrailing {
+		if orderType != option.Market {
+			return nil, fmt.Errorf("trailing stop requires a market order: %w", common.ErrInvalidOrder)
+		}
+		activePx := ""
+		if activationPrice.IsPositive() {
+			if activePx, err = common.PriceToPrecision(market, activationPrice.String()); err != nil {
+				return nil, err
+			}
+		}
+		toTrailingStopOrder(body, callbackRate.String(), activePx)
+		path = "/api/v5/trade/order-algo"
+	}
+
+	strict, ok := option.GetBool(argsOpts.StrictClientID)
+	return &okxOrderParams{
+		symbol:        symbol,
+		path:          path,
+		body:          body,
+		clientOrderID: clientOrderID,
+		stopPrice:     stopPrice,
+		callbackRate:  callbackRate,
+		isStop:        isStop || isTrailing,
+		strict:        ok && strict,
+	}, nil
+}
 
-	if len(respData.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: no order data returned")
+// CreateOrders 批量创建订单（POST /api/v5/trade/batch-orders，每批最多 20 个）
+// 条件单没有批量接口，逐个提交；参数校验失败的订单不提交，其错误写入对应位置
+func (p *OKXPerp) CreateOrders(ctx context.Context, requests []option.PerpOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
+
+	var items []common.BatchItem[*okxOrderParams]
+	for i, r := range requests {
+		params, err := p.buildOrderParams(r.Symbol, r.Amount, r.Side, r.Type, r.Opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, common.BatchItem[*okxOrderParams]{Index: i, Group: params.path, Params: params})
 	}
 
-	data := respData.Data[0]
+	err := common.SubmitBatches(ctx, items, 20, orders, errs, p.okx.submitBatchOrders)
+	for _, order := range orders {
+		if order != nil {
+			p.okx.lifecycle.AddOrder(common.TrackedOrder{Perp: true, Symbol: order.Symbol, OrderID: order.OrderId})
+		}
+	}
+	return orders, errs, err
+}
 
-	// 构建 NewOrder 对象
-	perpOrder := &model.NewOrder{
-		Symbol:        symbol,
-		OrderId:       data.OrdID,
-		ClientOrderID: data.ClOrdID,
-		Timestamp:     data.TS,
+// ClosePosition 以只减仓市价单平掉持仓（张数），未指定 option.WithMarginType 时使用持仓的保证金模式
+func (p *OKXPerp) ClosePosition(ctx context.Context, symbol string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
 	}
 
-	return perpOrder, nil
+	positions, err := p.FetchPositions(ctx, option.WithSymbol(symbol))
+	if err != nil {
+		return nil, err
+	}
+	position, side, amount, err := common.ClosePositionOrder(positions, symbol, argsOpts)
+	if err != nil {
+		return nil, err
+	}
+	if argsOpts.MarginType == nil {
+		marginType := option.CROSSED
+		if position.MarginMode == model.MarginModeIsolated {
+			marginType = option.ISOLATED
+		}
+		opts = append(opts[:len(opts):len(opts)], option.WithMarginType(marginType))
+	}
+	opts = append(opts[:len(opts):len(opts)], option.WithReduceOnly(true))
+	return p.CreateOrder(ctx, symbol, amount.String(), side, option.Market, opts...)
 }
 
 func (p *OKXPerp) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
+	if err := p.cancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	p.okx.lifecycle.RemoveOrder(true, orderId)
+	return nil
+}
+
+func (p *OKXPerp) cancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -692,22 +1158,89 @@ func (p *OKXPerp) CancelOrder(ctx context.Context, symbol string, orderId string
 		return err
 	}
 
-	var respData struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
+	// 部分撤单接口成功时返回空响应体
+	if common.IsEmptyBody(resp) {
+		return nil
 	}
-	if err = json.Unmarshal(resp, &respData); err != nil {
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("unmarshal cancel order: %w", err)
+	}
+
+	_, errs, err := result.orderResults()
+	if err != nil {
 		return err
 	}
+	return errs[0]
+}
 
-	if respData.Code != "0" {
-		return fmt.Errorf("okx api error: %s", respData.Msg)
+func (p *OKXPerp) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
 	}
 
-	return nil
+	// 解析参数
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	// 获取市场信息
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.NewExValues()
+	req.SetBody("instId", market.ID)
+
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		req.SetBody("ordId", orderId)
+	} else if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		req.SetBody("clOrdId", clientOrderId)
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+	if newAmount != "" {
+		req.SetBody("newSz", newAmount)
+	}
+	if newPrice != "" {
+		req.SetBody("newPx", newPrice)
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/api/v5/trade/amend-order", nil, req.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	results, errs, err := result.orderResults()
+	if err != nil {
+		return nil, err
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	// 改单接口只返回订单ID，查询最新订单
+	return p.FetchOrder(ctx, symbol, results[0].OrdID, opts...)
 }
 
 func (p *OKXPerp) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.PerpOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
 	// 解析参数
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -738,43 +1271,36 @@ func (p *OKXPerp) FetchOrder(ctx context.Context, symbol string, orderId string,
 	}
 
 	var respData struct {
-		Code string `json:"code"`
-		Msg  string `json:"msg"`
-		Data []struct {
-			OrdID      string            `json:"ordId"`      // 订单ID
-			ClOrdID    string            `json:"clOrdId"`    // 客户端自定义订单ID
-			InstID     string            `json:"instId"`     // 合约标的
-			Px         types.ExDecimal   `json:"px"`         // 下单价格（市价单为空）
-			AvgPx      types.ExDecimal   `json:"avgPx"`      // 成交均价
-			Sz         types.ExDecimal   `json:"sz"`         // 下单数量
-			AccFillSz  types.ExDecimal   `json:"accFillSz"`  // 实际成交数量
-			State      string            `json:"state"`      // 订单状态
-			ReduceOnly string            `json:"reduceOnly"` // 是否只减仓（字符串 "true"/"false"）
-			OrdType    string            `json:"ordType"`    // 订单类型
-			Side       string            `json:"side"`       // 订单方向
-			PosSide    string            `json:"posSide"`    // 单向持仓 net, 双向持仓 long / short
-			CTime      types.ExTimestamp `json:"cTime"`      // 创建时间（毫秒）
-			UTime      types.ExTimestamp `json:"uTime"`      // 更新时间（毫秒）
-		} `json:"data"`
+		Code string             `json:"code"`
+		Msg  string             `json:"msg"`
+		Data []okxPerpOrderItem `json:"data"`
 	}
 	if err := json.Unmarshal(resp, &respData); err != nil {
 		return nil, fmt.Errorf("unmarshal order: %w", err)
 	}
 
 	if respData.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", respData.Msg)
+		return nil, newOKXError(respData.Code, respData.Msg)
 	}
 
 	if len(respData.Data) == 0 {
-		return nil, fmt.Errorf("order not found")
+		return nil, common.ErrOrderNotFound
 	}
 
-	// 将 OKX 响应转换为 model.PerpOrder
-	item := respData.Data[0]
+	return toOKXPerpOrder(symbol, &respData.Data[0]), nil
+}
+
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (p *OKXPerp) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.PerpOrder, error) {
+	return p.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// toOKXPerpOrder 将 OKX 订单转换为 model.PerpOrder（查询订单和私有频道推送共用）
+func toOKXPerpOrder(symbol string, item *okxPerpOrderItem) *model.PerpOrder {
 	// 转换 reduceOnly 字符串为 bool
 	reduceOnly := strings.ToLower(item.ReduceOnly) == "true"
 
-	order := &model.PerpOrder{
+	return &model.PerpOrder{
 		ID:               item.OrdID,
 		ClientID:         item.ClOrdID,
 		Type:             item.OrdType,
@@ -790,9 +1316,41 @@ func (p *OKXPerp) FetchOrder(ctx context.Context, symbol string, orderId string,
 		ReduceOnly:       reduceOnly,
 		CreateTime:       item.CTime,
 		UpdateTime:       item.UTime,
+		Reason:           okxPerpOrderReason(item.Category),
+	}
+}
+
+// okxPerpOrderReason 根据订单种类识别强平（full_liquidation/partial_liquidation）和自动减仓（adl）订单
+func okxPerpOrderReason(category string) string {
+	switch category {
+	case "full_liquidation", "partial_liquidation":
+		return model.PositionReasonLiquidation
+	case "adl":
+		return model.PositionReasonADL
+	default:
+		return ""
+	}
+}
+
+// FetchMyTradesRange 查询账户成交记录，按 billId 游标向前翻页（数量为合约张数）
+func (p *OKXPerp) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return nil, err
 	}
+	return p.okx.fetchMyTradesRange(ctx, "SWAP", market, since, until)
+}
 
-	return order, nil
+func (p *OKXPerp) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := p.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.PerpOrder]) (<-chan []*model.PerpOrder, error) {
+		return common.WatchTopicResync(ctx, p.okx.privateWS, okxWSSwapOrdersTopic, p.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.PerpOrder, error) {
+		return p.FetchOrder(ctx, symbol, orderId)
+	}, common.PerpOrderFillSnapshot)
 }
 
 func (p *OKXPerp) SetLeverage(ctx context.Context, symbol string, leverage int, opts ...option.ArgsOption) error {
@@ -808,8 +1366,8 @@ func (p *OKXPerp) SetLeverage(ctx context.Context, symbol string, leverage int,
 
 	req := types.NewExValues()
 	req.SetBody("instId", market.ID)
-	if leverage < 1 || leverage > 125 {
-		return fmt.Errorf("leverage must be between 1 and 125")
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
 	}
 	req.SetBody("lever", leverage)
 
@@ -836,9 +1394,46 @@ func (p *OKXPerp) SetLeverage(ctx context.Context, symbol string, leverage int,
 	}
 
 	if respData.Code != "0" {
-		return fmt.Errorf(respData.Msg)
+		return newOKXError(respData.Code, respData.Msg)
+	}
+
+	return nil
+}
+
+// SetLeverageSide 设置逐仓双向持仓模式下多头或空头的杠杆（posSide）
+// OKX 全仓模式下多空共用杠杆，只有逐仓支持按方向设置，因此固定使用 mgnMode=isolated
+func (p *OKXPerp) SetLeverageSide(ctx context.Context, symbol string, side types.PositionSide, leverage int) error {
+	if err := common.CheckPositionSide(side); err != nil {
+		return err
+	}
+	market, err := p.GetMarket(symbol)
+	if err != nil {
+		return err
+	}
+	if err := common.CheckLeverage(market, leverage); err != nil {
+		return err
+	}
+
+	resp, err := p.signAndRequest(ctx, "POST", "/api/v5/account/set-leverage", nil, map[string]interface{}{
+		"instId":  market.ID,
+		"lever":   strconv.Itoa(leverage),
+		"mgnMode": "isolated",
+		"posSide": side.Lower(),
+	})
+	if err != nil {
+		return fmt.Errorf("set leverage: %w", err)
 	}
 
+	var respData struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return fmt.Errorf("unmarshal set leverage: %w", err)
+	}
+	if respData.Code != "0" {
+		return newOKXError(respData.Code, respData.Msg)
+	}
 	return nil
 }
 
@@ -850,44 +1445,76 @@ func (p *OKXPerp) SetMarginType(ctx context.Context, symbol string, marginType o
 	return fmt.Errorf("not supported: OKX does not support setting margin type via API")
 }
 
-var _ exchange.PerpExchange = (*OKXPerp)(nil)
+func (p *OKXPerp) CreateGridOrder(ctx context.Context, symbol string, params option.GridParams) (*model.AlgoOrder, error) {
+	return p.grid.CreateGridOrder(ctx, symbol, params)
+}
 
-// ========== 内部辅助方法 ==========
+func (p *OKXPerp) StopGridOrder(ctx context.Context, symbol string, algoID string) error {
+	return p.grid.StopGridOrder(ctx, symbol, algoID)
+}
 
-// signAndRequest 签名并发送请求（OKX API）
-func (p *OKXPerp) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if p.okx.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
+func (p *OKXPerp) FetchGridOrder(ctx context.Context, symbol string, algoID string) (*model.AlgoOrder, error) {
+	return p.grid.FetchGridOrder(ctx, symbol, algoID)
+}
+
+// SetPositionMode 设置账户持仓模式（POST /api/v5/account/set-position-mode），long_short_mode 为双向持仓，net_mode 为单向持仓
+func (p *OKXPerp) SetPositionMode(ctx context.Context, hedged bool) error {
+	posMode := "net_mode"
+	if hedged {
+		posMode = "long_short_mode"
 	}
 
-	// 构建请求体
-	bodyStr := ""
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("marshal body: %w", err)
-		}
-		bodyStr = string(bodyBytes)
+	resp, err := p.signAndRequest(ctx, "POST", "/api/v5/account/set-position-mode", nil, map[string]interface{}{"posMode": posMode})
+	if err != nil {
+		return fmt.Errorf("set position mode: %w", err)
 	}
 
-	// 生成时间戳和签名
-	timestamp := common.GetISO8601Timestamp()
-	signature := p.okx.signer.SignRequest(method, path, timestamp, bodyStr, params)
+	var respData struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return fmt.Errorf("unmarshal position mode: %w", err)
+	}
+	if respData.Code != "0" {
+		return newOKXError(respData.Code, respData.Msg)
+	}
+
+	p.positionMode.Set(hedged)
+	return nil
+}
 
-	// 设置请求头
-	p.okx.client.HTTPClient.SetHeader("OK-ACCESS-SIGN", signature)
-	p.okx.client.HTTPClient.SetHeader("OK-ACCESS-TIMESTAMP", timestamp)
-	p.okx.client.HTTPClient.SetHeader("OK-ACCESS-PASSPHRASE", p.okx.client.Passphrase)
-	p.okx.client.HTTPClient.SetHeader("OK-ACCESS-KEY", p.okx.client.APIKey)
-	if p.okx.client.Sandbox {
-		p.okx.client.HTTPClient.SetHeader("x-simulated-trading", "1")
+// GetPositionMode 查询账户持仓模式（GET /api/v5/account/config 的 posMode）
+func (p *OKXPerp) GetPositionMode(ctx context.Context) (bool, error) {
+	resp, err := p.signAndRequest(ctx, "GET", "/api/v5/account/config", nil, nil)
+	if err != nil {
+		return false, fmt.Errorf("get position mode: %w", err)
 	}
-	p.okx.client.HTTPClient.SetHeader("Content-Type", "application/json")
 
-	// 发送请求
-	if method == "GET" || method == "DELETE" {
-		return p.okx.client.HTTPClient.Get(ctx, path, params)
-	} else {
-		return p.okx.client.HTTPClient.Post(ctx, path, body)
+	var respData struct {
+		Code string `json:"code"`
+		Msg  string `json:"msg"`
+		Data []struct {
+			PosMode string `json:"posMode"` // long_short_mode 双向持仓，net_mode 单向持仓
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(resp, &respData); err != nil {
+		return false, fmt.Errorf("unmarshal position mode: %w", err)
 	}
+	if respData.Code != "0" || len(respData.Data) == 0 {
+		return false, newOKXError(respData.Code, respData.Msg)
+	}
+
+	hedged := respData.Data[0].PosMode == "long_short_mode"
+	p.positionMode.Set(hedged)
+	return hedged, nil
+}
+
+var _ exchange.PerpExchange = (*OKXPerp)(nil)
+
+// ========== 内部辅助方法 ==========
+
+// signAndRequest 签名并发送请求（OKX API）
+func (p *OKXPerp) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
+	return p.okx.signAndRequest(ctx, method, path, params, body)
 }
@@ -2,10 +2,18 @@ package okx
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -194,3 +202,451 @@ func TestOKXPerp_FetchTickers(t *testing.T) {
 		t.Logf("First ticker: %s, Last=%s", ticker.Symbol, ticker.Last.String())
 	}
 }
+
+func TestOKXPerp_FetchPositions_ServerTimestamp(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/account/positions" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","posSide":"long","pos":"2","avgPx":"50000","markPx":"51000","upl":"20",
+			 "lever":"10","mgnMode":"isolated","margin":"100","cTime":"1699990000000","uTime":"1700000000123"}
+		]}`))
+	})
+
+	positions, err := o.Perp().FetchPositions(context.Background())
+	if err != nil {
+		t.Fatalf("FetchPositions: %v", err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("got %d positions, want 1", len(positions))
+	}
+	if got := positions[0].Timestamp.UnixMilli(); got != 1700000000123 {
+		t.Errorf("Timestamp = %d, want server uTime 1700000000123", got)
+	}
+}
+
+func TestOKXPerp_FetchOpenInterest(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/public/open-interest" || r.URL.Query().Get("instId") != "BTC-USDT-SWAP" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT-SWAP","instType":"SWAP","oi":"2125419.3","oiCcy":"21254.193","oiUsd":"1434218463.59","ts":"1700000000123"}
+		]}`))
+	})
+
+	oi, err := o.Perp().FetchOpenInterest(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchOpenInterest: %v", err)
+	}
+	// U 本位合约取币数量 oiCcy
+	if oi.Symbol != "BTC/USDT:USDT" || oi.OpenInterestAmount.String() != "21254.193" || oi.OpenInterestValue.String() != "1434218463.59" {
+		t.Errorf("unexpected open interest: symbol=%s amount=%s value=%s", oi.Symbol, oi.OpenInterestAmount, oi.OpenInterestValue)
+	}
+	if got := oi.Timestamp.UnixMilli(); got != 1700000000123 {
+		t.Errorf("Timestamp = %d, want 1700000000123", got)
+	}
+
+	if _, err := o.Perp().FetchOpenInterest(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestParseOKXWSCandle(t *testing.T) {
+	topic, ok := okxWSProtocol{}.Route([]byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT-SWAP"},"data":[["1700000040000","100","101","99.5","100.5","12","0.12","12.06","0"]]}`))
+	if !ok || topic != okxWSTopic(okxCandleChannel("1m"), "BTC-USDT-SWAP") {
+		t.Fatalf("Route = %q, %v", topic, ok)
+	}
+
+	candle, ok := parseOKXWSCandle([]byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT-SWAP"},"data":[["1700000040000","100","101","99.5","100.5","12","0.12","12.06","0"]]}`))
+	if !ok || candle.Closed {
+		t.Fatalf("in-progress candle = %+v, %v", candle, ok)
+	}
+
+	candle, ok = parseOKXWSCandle([]byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT-SWAP"},"data":[["1700000040000","100","102.5","99","101","30","0.3","30.15","1"]]}`))
+	if !ok || !candle.Closed {
+		t.Fatalf("closed candle = %+v, %v", candle, ok)
+	}
+	if candle.Timestamp.UnixMilli() != 1700000040000 || !candle.High.Equal(decimal.RequireFromString("102.5")) ||
+		!candle.Close.Equal(decimal.RequireFromString("101")) || !candle.Volume.Equal(decimal.RequireFromString("30")) {
+		t.Errorf("unexpected closed candle: %+v", candle)
+	}
+}
+
+func TestOKXPerp_PositionMode_ReduceOnly(t *testing.T) {
+	var body map[string]interface{}
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/account/config":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"posMode":"long_short_mode","acctLv":"2"}]}`))
+		case "/api/v5/trade/order":
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"1","clOrdId":"c1","sCode":"0","sMsg":""}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := context.Background()
+	hedged, err := o.Perp().GetPositionMode(ctx)
+	if err != nil || !hedged {
+		t.Fatalf("GetPositionMode = %v, %v", hedged, err)
+	}
+
+	// 双向持仓：平空通过 posSide=short 平掉空仓，不发送 reduceOnly
+	opts := []option.ArgsOption{option.WithPrice("50000"), option.WithMarginType(option.CROSSED)}
+	if _, err := o.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "1", option.CloseShort, option.Limit, opts...); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if body["posSide"] != "short" || body["side"] != "buy" {
+		t.Errorf("posSide/side = %v/%v, want short/buy", body["posSide"], body["side"])
+	}
+	if _, ok := body["reduceOnly"]; ok {
+		t.Errorf("reduceOnly sent in hedge mode: %v", body["reduceOnly"])
+	}
+
+	// option.WithHedgeMode(false) 优先于账户模式：单向持仓平仓单带 reduceOnly
+	if _, err := o.Perp().CreateOrder(ctx, "BTC/USDT:USDT", "1", option.CloseShort, option.Limit, append(opts, option.WithHedgeMode(false))...); err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if body["posSide"] != "net" || fmt.Sprint(body["reduceOnly"]) != "true" {
+		t.Errorf("posSide/reduceOnly = %v/%v, want net/true", body["posSide"], body["reduceOnly"])
+	}
+}
+
+func TestOKXPerp_ClosePosition(t *testing.T) {
+	var body map[string]interface{}
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/account/positions":
+			w.Write([]byte(`{"code":"0","msg":"","data":[
+				{"instId":"BTC-USDT-SWAP","posSide":"net","pos":"-4","avgPx":"50000","markPx":"51000","upl":"-40",
+				 "lever":"10","mgnMode":"isolated","margin":"100","cTime":"1699990000000","uTime":"1700000000123"}
+			]}`))
+		case "/api/v5/trade/order":
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"1","clOrdId":"c1","sCode":"0","sMsg":""}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	// 空头持仓：市价买入平仓，保证金模式默认沿用持仓的逐仓模式
+	if _, err := o.Perp().ClosePosition(context.Background(), "BTC/USDT:USDT", option.WithClosePercent("50")); err != nil {
+		t.Fatalf("ClosePosition: %v", err)
+	}
+	if body["side"] != "buy" || body["ordType"] != "market" || body["sz"] != "2" || body["tdMode"] != "isolated" ||
+		fmt.Sprint(body["reduceOnly"]) != "true" {
+		t.Errorf("order body = %v, want market buy 2 isolated reduce-only", body)
+	}
+}
+
+func TestOKXPerp_FetchMarkPrice(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v5/public/mark-price" && r.URL.Query().Get("instId") == "BTC-USDT-SWAP":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","markPx":"36502.4","ts":"1700000000123"}]}`))
+		case r.URL.Path == "/api/v5/market/index-tickers" && r.URL.Query().Get("instId") == "BTC-USDT":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT","idxPx":"36497.8","high24h":"37000","sodUtc0":"36000","open24h":"36100","low24h":"35900","sodUtc8":"36050","ts":"1700000000100"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ticker, err := o.Perp().FetchMarkPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil {
+		t.Fatalf("FetchMarkPrice: %v", err)
+	}
+	if ticker.Symbol != "BTC/USDT:USDT" || ticker.MarkPrice.String() != "36502.4" || ticker.IndexPrice.String() != "36497.8" {
+		t.Errorf("unexpected mark price: symbol=%s mark=%s index=%s", ticker.Symbol, ticker.MarkPrice, ticker.IndexPrice)
+	}
+	if !ticker.Last.IsZero() || ticker.Timestamp.UnixMilli() != 1700000000123 {
+		t.Errorf("Last = %s, Timestamp = %d", ticker.Last, ticker.Timestamp.UnixMilli())
+	}
+
+	index, err := o.Perp().FetchIndexPrice(context.Background(), "BTC/USDT:USDT")
+	if err != nil || index.String() != "36497.8" {
+		t.Errorf("FetchIndexPrice = %s, %v", index, err)
+	}
+
+	if _, err := o.Perp().FetchIndexPrice(context.Background(), "BTC/USDT"); err == nil {
+		t.Error("expected error for spot symbol")
+	}
+}
+
+func TestOKXPerp_FetchTickers_LoadsMarkets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v5/public/instruments" && r.URL.Query().Get("instType") == "SWAP":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","instFamily":"BTC-USDT","settleCcy":"USDT","ctVal":"0.01","state":"live","minSz":"1","lotSz":"1","tickSz":"0.1"}]}`))
+		case r.URL.Path == "/api/v5/market/tickers" && r.URL.Query().Get("instType") == "SWAP":
+			// ETH-USDT-SWAP、BTC-USD-SWAP 不在市场信息中，直接由原始ID标准化
+			w.Write([]byte(`{"code":"0","msg":"","data":[
+				{"instType":"SWAP","instId":"BTC-USDT-SWAP","last":"36500","ts":"1700000000000"},
+				{"instType":"SWAP","instId":"ETH-USDT-SWAP","last":"2000","ts":"1700000000000"},
+				{"instType":"SWAP","instId":"BTC-USD-SWAP","last":"36510","ts":"1700000000000"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	tickers, err := ex.Perp().FetchTickers(context.Background())
+	if err != nil {
+		t.Fatalf("FetchTickers: %v", err)
+	}
+	want := map[string]string{"BTC/USDT:USDT": "36500", "ETH/USDT:USDT": "2000", "BTC/USD:BTC": "36510"}
+	if len(tickers) != len(want) {
+		t.Fatalf("expected %d tickers, got %d", len(want), len(tickers))
+	}
+	for _, ticker := range tickers {
+		if last, ok := want[ticker.Symbol]; !ok || ticker.Last.String() != last {
+			t.Errorf("unexpected ticker %s last=%s", ticker.Symbol, ticker.Last)
+		}
+	}
+
+	// 指定交易对时只返回该合约
+	tickers, err = ex.Perp().FetchTickers(context.Background(), option.WithSymbol("BTC/USDT:USDT"))
+	if err != nil {
+		t.Fatalf("FetchTickers(symbol): %v", err)
+	}
+	if len(tickers) != 1 || tickers[0].Symbol != "BTC/USDT:USDT" {
+		t.Errorf("unexpected tickers for symbol: %+v", tickers)
+	}
+}
+
+func TestOKXPerp_CreateOrder_ReduceOnlyPostOnly(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	params, err := o.perp.buildOrderParams("BTC/USDT:USDT", "1", option.OpenLong, option.Limit,
+		option.WithPrice("50000"), option.WithMarginType(option.CROSSED), option.WithPostOnly(true), option.WithReduceOnly(true))
+	if err != nil {
+		t.Fatalf("buildOrderParams: %v", err)
+	}
+	if params.body["ordType"] != "post_only" || params.body["reduceOnly"] != "true" {
+		t.Errorf("ordType/reduceOnly = %v/%v, want post_only/true", params.body["ordType"], params.body["reduceOnly"])
+	}
+
+	// 未设置 TimeInForce 的市价单
+	params, err = o.perp.buildOrderParams("BTC/USDT:USDT", "1", option.CloseLong, option.Market, option.WithMarginType(option.CROSSED))
+	if err != nil {
+		t.Fatalf("buildOrderParams: %v", err)
+	}
+	if params.body["ordType"] != "market" || params.body["reduceOnly"] != "true" {
+		t.Errorf("ordType/reduceOnly = %v/%v, want market/true", params.body["ordType"], params.body["reduceOnly"])
+	}
+
+	if _, err := o.perp.buildOrderParams("BTC/USDT:USDT", "1", option.OpenLong, option.Market,
+		option.WithMarginType(option.CROSSED), option.WithPostOnly(true)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("post-only market order err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestOKXPerp_CreateOrder_TrailingStop(t *testing.T) {
+	var body map[string]interface{}
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v5/trade/order-algo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"algoId":"681096944655273985","clOrdId":"","algoClOrdId":"ts-1","sCode":"0","sMsg":""}]}`))
+	})
+
+	order, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "1", option.CloseLong, option.Market,
+		option.WithMarginType(option.CROSSED), option.WithClientOrderID("ts-1"), option.WithTrailingStop("2", "51000"))
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	want := map[string]string{"ordType": "move_order_stop", "callbackRatio": "0.02", "activePx": "51000", "algoClOrdId": "ts-1"}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %q", k, body[k], v)
+		}
+	}
+	if _, ok := body["clOrdId"]; ok {
+		t.Errorf("unexpected clOrdId in algo order: %v", body)
+	}
+	if order.OrderId != "681096944655273985" || order.ClientOrderID != "ts-1" || order.CallbackRate.String() != "2" {
+		t.Errorf("order = %s/%s/%s, want algo ID, ts-1 and callback rate 2", order.OrderId, order.ClientOrderID, order.CallbackRate)
+	}
+
+	if _, err := ex.Perp().CreateOrder(context.Background(), "BTC/USDT:USDT", "1", option.CloseLong, option.Market,
+		option.WithMarginType(option.CROSSED), option.WithTrailingStop("0.05", "")); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("out-of-range callback rate err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestOKXPerp_CreateOrder_TimeInForce(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	tests := []struct {
+		orderType option.OrderType
+		tif       option.TimeInForce
+		want      string
+	}{
+		{option.Limit, option.GTC, "limit"},
+		{option.Limit, option.IOC, "ioc"},
+		{option.Limit, option.FOK, "fok"},
+		{option.Limit, option.GTX, "post_only"},
+		{option.Market, option.IOC, "market"},
+	}
+	for _, tt := range tests {
+		params, err := o.perp.buildOrderParams("BTC/USDT:USDT", "1", option.OpenLong, tt.orderType,
+			option.WithPrice("50000"), option.WithMarginType(option.CROSSED), option.WithTimeInForce(tt.tif))
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.orderType, tt.tif, err)
+		}
+		if params.body["ordType"] != tt.want {
+			t.Errorf("%s %s: ordType = %v, want %s", tt.orderType, tt.tif, params.body["ordType"], tt.want)
+		}
+	}
+
+	if _, err := o.perp.buildOrderParams("BTC/USDT:USDT", "1", option.OpenLong, option.Market,
+		option.WithMarginType(option.CROSSED), option.WithTimeInForce(option.FOK)); !errors.Is(err, common.ErrInvalidOrder) {
+		t.Errorf("market FOK: err = %v, want ErrInvalidOrder", err)
+	}
+}
+
+func TestOKXPerp_SetLeverageSide(t *testing.T) {
+	var body map[string]interface{}
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v5/account/set-leverage" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT-SWAP","lever":"20","mgnMode":"isolated","posSide":"short"}]}`))
+	})
+	o.perpMarketsBySymbol["BTC/USDT:USDT"].Limits.Leverage.Max = types.ExDecimal{Decimal: decimal.NewFromInt(100)}
+
+	if err := o.Perp().SetLeverageSide(context.Background(), "BTC/USDT:USDT", types.PositionSideShort, 20); err != nil {
+		t.Fatalf("SetLeverageSide: %v", err)
+	}
+	want := map[string]string{"instId": "BTC-USDT-SWAP", "lever": "20", "mgnMode": "isolated", "posSide": "short"}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %q", k, body[k], v)
+		}
+	}
+
+	body = nil
+	if err := o.Perp().SetLeverageSide(context.Background(), "BTC/USDT:USDT", types.PositionSideLong, 125); !errors.Is(err, common.ErrLeverageExceeded) {
+		t.Errorf("over limit: err = %v, want ErrLeverageExceeded", err)
+	}
+	if body != nil {
+		t.Errorf("over-limit leverage sent a request: %v", body)
+	}
+}
+
+func TestOKX_NormalizeSymbol(t *testing.T) {
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	// 未加载市场时按 OKX 产品ID格式转换
+	if got, err := ex.NormalizeSymbol("BTC-USDT-SWAP"); err != nil || got != "BTC/USDT:USDT" {
+		t.Errorf("NormalizeSymbol = %q, %v, want BTC/USDT:USDT", got, err)
+	}
+	if got, err := ex.DenormalizeSymbol("BTC/USDT:USDT"); err != nil || got != "BTC-USDT-SWAP" {
+		t.Errorf("DenormalizeSymbol = %q, %v, want BTC-USDT-SWAP", got, err)
+	}
+
+	// 已加载的市场按市场信息转换
+	ex.perpMarketsBySymbol, ex.perpMarketsByID = common.IndexMarkets(model.Markets{{ID: "ETH-USD-SWAP", Symbol: "ETH/USD:ETH"}})
+	if got, err := ex.NormalizeSymbol("ETH-USD-SWAP"); err != nil || got != "ETH/USD:ETH" {
+		t.Errorf("NormalizeSymbol = %q, %v, want ETH/USD:ETH", got, err)
+	}
+	if got, err := ex.DenormalizeSymbol("ETH/USD:ETH"); err != nil || got != "ETH-USD-SWAP" {
+		t.Errorf("DenormalizeSymbol = %q, %v, want ETH-USD-SWAP", got, err)
+	}
+	if _, err := ex.NormalizeSymbol("BTC-USD-240329"); err == nil {
+		t.Error("expected error for unsupported instrument ID")
+	}
+}
+
+func TestOKXPerp_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v5/trade/amend-order":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			edits = append(edits, body)
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"p1","clOrdId":"c1","sCode":"0","sMsg":""}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v5/trade/order":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SWAP","instId":"BTC-USDT-SWAP","ordId":"p1","clOrdId":"c1","px":"50000.5","sz":"3","ordType":"limit","side":"sell","posSide":"net","accFillSz":"0","state":"live","cTime":"1700000000000","uTime":"1700000000100"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	market := o.perpMarketsBySymbol["BTC/USDT:USDT"]
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.NewFromInt(1)}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.5")}
+	ctx := context.Background()
+
+	// 按客户端订单ID只改价格，价格按步长对齐
+	if _, err := o.Perp().EditOrder(ctx, "BTC/USDT:USDT", "", "", "50000.9", option.WithClientOrderID("c1")); err != nil {
+		t.Fatalf("EditOrder price: %v", err)
+	}
+	if e := edits[0]; e["instId"] != "BTC-USDT-SWAP" || e["clOrdId"] != "c1" || e["ordId"] != nil || e["newPx"] != "50000.5" || e["newSz"] != nil {
+		t.Errorf("price edit body = %v, want clOrdId c1 newPx 50000.5 only", e)
+	}
+
+	// 数量为合约张数，向下对齐到 lotSz
+	if _, err := o.Perp().EditOrder(ctx, "BTC/USDT:USDT", "p1", "3.7", ""); err != nil {
+		t.Fatalf("EditOrder amount: %v", err)
+	}
+	if e := edits[1]; e["ordId"] != "p1" || e["newSz"] != "3" || e["newPx"] != nil {
+		t.Errorf("amount edit body = %v, want newSz 3 only", e)
+	}
+}
+
+func TestOKXPerp_OrderReason(t *testing.T) {
+	tests := map[string]string{
+		"full_liquidation":    model.PositionReasonLiquidation,
+		"partial_liquidation": model.PositionReasonLiquidation,
+		"adl":                 model.PositionReasonADL,
+		"normal":              "",
+	}
+	for category, want := range tests {
+		var item okxPerpOrderItem
+		if err := json.Unmarshal([]byte(`{"ordId":"1","instId":"BTC-USDT-SWAP","state":"filled","category":"`+category+`"}`), &item); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if got := toOKXPerpOrder("BTC/USDT:USDT", &item).Reason; got != want {
+			t.Errorf("%s: Reason = %q, want %q", category, got, want)
+		}
+	}
+}
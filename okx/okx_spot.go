@@ -12,6 +12,7 @@ import (
 	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
 	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
 )
 
 // OKXSpot OKX 现货实现
@@ -19,14 +20,17 @@ type OKXSpot struct {
 	okx    *OKX
 	market *okxSpotMarket
 	order  *okxSpotOrder
+	grid   *okxGrid
 }
 
 // NewOKXSpot 创建 OKX 现货实例
 func NewOKXSpot(o *OKX) *OKXSpot {
+	market := &okxSpotMarket{okx: o}
 	return &OKXSpot{
 		okx:    o,
-		market: &okxSpotMarket{okx: o},
+		market: market,
 		order:  &okxSpotOrder{okx: o},
+		grid:   &okxGrid{okx: o, algoOrdType: okxAlgoOrdTypeGrid, getMarket: market.GetMarket},
 	}
 }
 
@@ -44,10 +48,35 @@ func (s *OKXSpot) GetMarket(symbol string) (*model.Market, error) {
 	return s.market.GetMarket(symbol)
 }
 
+func (s *OKXSpot) GetMarketByID(id string) (*model.Market, error) {
+	return s.market.GetMarketByID(id)
+}
+
 func (s *OKXSpot) GetMarkets() ([]*model.Market, error) {
 	return s.market.GetMarkets()
 }
 
+func (s *OKXSpot) AmountToPrecision(symbol, amount string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.AmountToPrecision(market, amount)
+}
+
+func (s *OKXSpot) PriceToPrecision(symbol, price string) (string, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return "", err
+	}
+	return common.PriceToPrecision(market, price)
+}
+
+// EstimateOrderCost 下单前估算订单成本并检查市场限制
+func (s *OKXSpot) EstimateOrderCost(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price string, opts ...option.ArgsOption) (*model.CostEstimate, error) {
+	return common.EstimateSpotOrderCost(ctx, s, symbol, side, amount, price, opts...)
+}
+
 func (s *OKXSpot) FetchTicker(ctx context.Context, symbol string) (*model.Ticker, error) {
 	return s.market.FetchTicker(ctx, symbol)
 }
@@ -56,6 +85,66 @@ func (s *OKXSpot) FetchTickers(ctx context.Context) (map[string]*model.Ticker, e
 	return s.market.FetchTickers(ctx)
 }
 
+func (s *OKXSpot) FetchTickersOrdered(ctx context.Context, symbols ...string) ([]*model.Ticker, error) {
+	tickers, err := s.FetchTickers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.OrderTickers(tickers, symbols, s.GetMarket), nil
+}
+
+func (s *OKXSpot) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	return s.market.FetchOrderBook(ctx, symbol, limit)
+}
+
+func (s *OKXSpot) WatchTicker(ctx context.Context, symbol string) (<-chan *model.Ticker, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.okx.ws, okxWSTopic("tickers", market.ID), parseOKXWSTicker(market.Symbol))
+}
+
+func (s *OKXSpot) WatchOrderBook(ctx context.Context, symbol string, depth int) (<-chan *model.OrderBook, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchOrderBook(ctx, common.OrderBookWatcher{
+		Manager:  s.okx.ws,
+		Topic:    okxWSTopic("books", market.ID),
+		Symbol:   market.Symbol,
+		Depth:    depth,
+		Sequence: common.OrderBookSequencePrev,
+		Parse:    parseOKXWSOrderBook,
+	})
+}
+
+// WatchOHLCV 通过业务 WebSocket 订阅K线（candle 频道），每次更新推送当前K线，收盘K线的 Closed 为 true
+func (s *OKXSpot) WatchOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	bar, err := common.CheckTimeframe(timeframe, common.OKXTimeframe(timeframe), okxTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.okx.businessWS, okxWSTopic(okxCandleChannel(bar), market.ID), parseOKXWSCandle)
+}
+
 func (s *OKXSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, opts ...option.ArgsOption) (model.OHLCVs, error) {
 	argsOpts := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -69,25 +158,201 @@ func (s *OKXSpot) FetchOHLCVs(ctx context.Context, symbol string, timeframe stri
 	if argsOpts.Since != nil {
 		since = *argsOpts.Since
 	}
-	return s.market.FetchOHLCVs(ctx, symbol, timeframe, since, limit)
+	until, _ := option.GetTime(argsOpts.Until)
+	ohlcvs, err := s.market.FetchOHLCVs(ctx, symbol, timeframe, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if closedOnly, ok := option.GetBool(argsOpts.ClosedCandlesOnly); ok && closedOnly {
+		return common.DropUnclosedOHLCVs(ohlcvs, timeframe, s.okx.clock.Now())
+	}
+	return ohlcvs, nil
+}
+
+func (s *OKXSpot) FetchOHLCVRange(ctx context.Context, symbol string, timeframe string, since, until time.Time) (model.OHLCVs, error) {
+	return common.FetchOHLCVRange(ctx, timeframe, since, until, okxOHLCVPageLimit, func(ctx context.Context, start, end time.Time, limit int) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithSince(start), option.WithUntil(end), option.WithLimit(limit))
+	})
+}
+
+func (s *OKXSpot) PollOHLCV(ctx context.Context, symbol string, timeframe string) (<-chan *model.OHLCV, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.PollOHLCV(ctx, timeframe, 0, func(ctx context.Context) (model.OHLCVs, error) {
+		return s.FetchOHLCVs(ctx, symbol, timeframe, option.WithLimit(2))
+	})
+}
+
+func (s *OKXSpot) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	return s.market.FetchAggregatedTrades(ctx, symbol, since, limit)
 }
 
-func (s *OKXSpot) FetchBalance(ctx context.Context) (model.Balances, error) {
-	return s.order.FetchBalance(ctx)
+func (s *OKXSpot) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	return s.order.FetchBalance(ctx, opts...)
+}
+
+// WatchBalance 通过私有频道 account 订阅交易账户余额，推送包含发生变化的币种
+func (s *OKXSpot) WatchBalance(ctx context.Context) (<-chan model.Balances, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopic(ctx, s.okx.privateWS, okxWSAccountTopic, parseOKXWSAccount)
 }
 
 func (s *OKXSpot) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
-	return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	order, err := common.CreateOrderIdempotent(ctx, s.okx.client.HTTPClient, s.okx.Name(), side.ToSide(), opts, func(ctx context.Context, opts []option.ArgsOption) (*model.NewOrder, error) {
+		return s.order.CreateOrder(ctx, symbol, side, amount, opts...)
+	}, func(ctx context.Context, clientOrderID string) (*model.SpotOrder, error) {
+		return s.FetchOrderByClientID(ctx, symbol, clientOrderID)
+	})
+	if order != nil {
+		s.okx.lifecycle.AddOrder(common.TrackedOrder{Symbol: symbol, OrderID: order.OrderId})
+	}
+	return order, err
+}
+
+func (s *OKXSpot) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders, errs, err := s.order.CreateOrders(ctx, requests)
+	for _, order := range orders {
+		if order != nil {
+			s.okx.lifecycle.AddOrder(common.TrackedOrder{Symbol: order.Symbol, OrderID: order.OrderId})
+		}
+	}
+	return orders, errs, err
+}
+
+// CreateOCOOrder 创建 OCO 策略委托，返回的订单组 ID 为 algoId（普通 CancelOrder 不接受）
+func (s *OKXSpot) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	return s.order.CreateOCOOrder(ctx, symbol, side, amount, price, stopPrice, stopLimitPrice, opts...)
 }
 
 func (s *OKXSpot) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
-	return s.order.CancelOrder(ctx, symbol, orderId, opts...)
+	if err := s.order.CancelOrder(ctx, symbol, orderId, opts...); err != nil {
+		return err
+	}
+	s.okx.lifecycle.RemoveOrder(false, orderId)
+	return nil
+}
+
+func (s *OKXSpot) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	return s.order.EditOrder(ctx, symbol, orderId, newAmount, newPrice, opts...)
 }
 
 func (s *OKXSpot) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
 	return s.order.FetchOrder(ctx, symbol, orderId, opts...)
 }
 
+// FetchOrderByClientID 按客户端订单ID查询订单
+func (s *OKXSpot) FetchOrderByClientID(ctx context.Context, symbol string, clientOrderID string) (*model.SpotOrder, error) {
+	return s.FetchOrder(ctx, symbol, "", option.WithClientOrderID(clientOrderID))
+}
+
+// FetchMyTradesRange 查询账户成交记录，按 billId 游标向前翻页
+func (s *OKXSpot) FetchMyTradesRange(ctx context.Context, symbol string, since, until time.Time) ([]*model.Trade, error) {
+	market, err := s.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return s.okx.fetchMyTradesRange(ctx, "SPOT", market, since, until)
+}
+
+func (s *OKXSpot) TrackOrder(ctx context.Context, symbol string, orderId string) (<-chan *model.OrderFillEvent, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.TrackOrderFills(ctx, orderId, symbol, func(ctx context.Context, resync common.WSResync[[]*model.SpotOrder]) (<-chan []*model.SpotOrder, error) {
+		return common.WatchTopicResync(ctx, s.okx.privateWS, okxWSSpotOrdersTopic, s.parseWSOrders(), resync)
+	}, func(ctx context.Context) (*model.SpotOrder, error) {
+		return s.FetchOrder(ctx, symbol, orderId)
+	}, common.SpotOrderFillSnapshot)
+}
+
+// WatchOrders 通过私有频道 orders 订阅现货订单更新，订单每次状态变化推送一次
+func (s *OKXSpot) WatchOrders(ctx context.Context) (<-chan *model.SpotOrder, error) {
+	ctx, err := s.okx.lifecycle.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return common.WatchTopicItems(ctx, s.okx.privateWS, okxWSSpotOrdersTopic, s.parseWSOrders())
+}
+
+// parseWSOrders 解析私有频道 orders 的现货订单推送（WatchOrders 和 TrackOrder 共用）
+func (s *OKXSpot) parseWSOrders() common.WSParser[[]*model.SpotOrder] {
+	return parseOKXWSOrders(func(raw json.RawMessage) (*model.SpotOrder, bool) {
+		var item okxSpotFetchOrderData
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, false
+		}
+		symbol := item.InstID
+		if market, err := s.GetMarket(item.InstID); err == nil {
+			symbol = market.Symbol
+		}
+		return s.order.parseOrder(item, symbol), true
+	})
+}
+
+func (s *OKXSpot) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	return s.order.CreateConversion(ctx, from, to, amount)
+}
+
+func (s *OKXSpot) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	return s.order.FetchCurrencies(ctx)
+}
+
+// FetchWithdrawalFee 根据 FetchCurrencies 的网络信息获取提币手续费
+func (s *OKXSpot) FetchWithdrawalFee(ctx context.Context, currency, network string) (decimal.Decimal, error) {
+	return common.WithdrawalFee(ctx, s.FetchCurrencies, currency, network)
+}
+
+func (s *OKXSpot) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	return s.order.FetchDepositAddress(ctx, currency, network)
+}
+
+func (s *OKXSpot) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	return s.order.Withdraw(ctx, currency, amount, address, network, params)
+}
+
+func (s *OKXSpot) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	return s.order.Transfer(ctx, currency, amount, fromAccount, toAccount)
+}
+
+func (s *OKXSpot) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	return s.order.FetchBorrowRate(ctx, currency)
+}
+
+func (s *OKXSpot) Borrow(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "borrow", currency, amount)
+}
+
+func (s *OKXSpot) Repay(ctx context.Context, currency, amount string) error {
+	return s.order.borrowRepay(ctx, "repay", currency, amount)
+}
+
+func (s *OKXSpot) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	return s.order.FetchSubAccounts(ctx)
+}
+
+func (s *OKXSpot) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	return s.order.FetchBalanceFor(ctx, subAccountID)
+}
+
+func (s *OKXSpot) CreateGridOrder(ctx context.Context, symbol string, params option.GridParams) (*model.AlgoOrder, error) {
+	return s.grid.CreateGridOrder(ctx, symbol, params)
+}
+
+func (s *OKXSpot) StopGridOrder(ctx context.Context, symbol string, algoID string) error {
+	return s.grid.StopGridOrder(ctx, symbol, algoID)
+}
+
+func (s *OKXSpot) FetchGridOrder(ctx context.Context, symbol string, algoID string) (*model.AlgoOrder, error) {
+	return s.grid.FetchGridOrder(ctx, symbol, algoID)
+}
+
 var _ exchange.SpotExchange = (*OKXSpot)(nil)
 
 // ========== 内部实现 ==========
@@ -97,9 +362,9 @@ type okxSpotMarket struct {
 }
 
 func (m *okxSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
-	// 如果已加载且不需要重新加载，直接返回
+	// 如果已加载、未超过缓存有效期且不需要重新加载，直接返回
 	m.okx.mu.RLock()
-	if !reload && len(m.okx.spotMarketsBySymbol) > 0 {
+	if !reload && len(m.okx.spotMarketsBySymbol) > 0 && !m.okx.marketCache.Expired(model.MarketTypeSpot) {
 		m.okx.mu.RUnlock()
 		return nil
 	}
@@ -119,7 +384,7 @@ func (m *okxSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 	}
 
 	if result.Code != "0" {
-		return fmt.Errorf("okx api error: %s", result.Msg)
+		return newOKXError(result.Code, result.Msg)
 	}
 
 	markets := make([]*model.Market, 0)
@@ -152,6 +417,8 @@ func (m *okxSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 		}
 
 		// 计算精度
+		market.Precision.StepSize = item.LotSz
+		market.Precision.TickSize = item.TickSz
 		if !item.LotSz.IsZero() {
 			lotSzStr := item.LotSz.String()
 			parts := strings.Split(lotSzStr, ".")
@@ -170,16 +437,11 @@ func (m *okxSpotMarket) LoadMarkets(ctx context.Context, reload bool) error {
 		markets = append(markets, market)
 	}
 
-	// 存储市场信息
+	// 存储市场信息（整体替换两个索引，重新加载后已下架的市场不再保留）
+	bySymbol, byID := common.IndexMarkets(markets)
 	m.okx.mu.Lock()
-	if m.okx.spotMarketsBySymbol == nil {
-		m.okx.spotMarketsBySymbol = make(map[string]*model.Market)
-		m.okx.spotMarketsByID = make(map[string]*model.Market)
-	}
-	for _, market := range markets {
-		m.okx.spotMarketsBySymbol[market.Symbol] = market
-		m.okx.spotMarketsByID[market.ID] = market
-	}
+	m.okx.spotMarketsBySymbol, m.okx.spotMarketsByID = bySymbol, byID
+	m.okx.marketCache.Touch(model.MarketTypeSpot)
 	m.okx.mu.Unlock()
 
 	return nil
@@ -215,7 +477,18 @@ func (m *okxSpotMarket) GetMarket(key string) (*model.Market, error) {
 		return market, nil
 	}
 
-	return nil, fmt.Errorf("market not found: %s", key)
+	return nil, common.MarketNotFound(key, m.okx.spotMarketsBySymbol)
+}
+
+// GetMarketByID 按交易所原始市场ID获取市场信息
+func (m *okxSpotMarket) GetMarketByID(id string) (*model.Market, error) {
+	m.okx.mu.RLock()
+	defer m.okx.mu.RUnlock()
+
+	if market, ok := m.okx.spotMarketsByID[id]; ok {
+		return market, nil
+	}
+	return nil, common.MarketNotFound(id, m.okx.spotMarketsByID)
 }
 
 func (m *okxSpotMarket) GetMarkets() ([]*model.Market, error) {
@@ -261,7 +534,7 @@ func (m *okxSpotMarket) FetchTicker(ctx context.Context, symbol string) (*model.
 	}
 
 	if result.Code != "0" || len(result.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	data := result.Data[0]
@@ -283,6 +556,11 @@ func (m *okxSpotMarket) FetchTicker(ctx context.Context, symbol string) (*model.
 }
 
 func (m *okxSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.Ticker, error) {
+	// 确保市场已加载
+	if err := m.LoadMarkets(ctx, false); err != nil {
+		return nil, err
+	}
+
 	resp, err := m.okx.client.HTTPClient.Get(ctx, "/api/v5/market/tickers", map[string]interface{}{
 		"instType": "SPOT",
 	})
@@ -297,18 +575,18 @@ func (m *okxSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.Tic
 	}
 
 	if result.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	tickers := make(map[string]*model.Ticker)
 	for _, item := range result.Data {
-		// 尝试从市场信息中查找标准化格式
-		market, err := m.GetMarket(item.InstID)
-		if err != nil {
-			continue
+		// 优先使用市场信息中的标准化格式，查不到时直接转换原始ID
+		symbol := symbolFromOKXID(item.InstID)
+		if market, err := m.GetMarket(item.InstID); err == nil {
+			symbol = market.Symbol
 		}
 		ticker := &model.Ticker{
-			Symbol:    market.Symbol,
+			Symbol:    symbol,
 			Timestamp: item.Ts,
 		}
 		ticker.Bid = item.BidPx
@@ -319,13 +597,50 @@ func (m *okxSpotMarket) FetchTickers(ctx context.Context) (map[string]*model.Tic
 		ticker.Low = item.Low24h
 		ticker.Volume = item.Vol24h
 		ticker.QuoteVolume = item.VolCcy24h
-		tickers[market.Symbol] = ticker
+		tickers[symbol] = ticker
 	}
 
 	return tickers, nil
 }
 
-func (m *okxSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since time.Time, limit int) (model.OHLCVs, error) {
+func (m *okxSpotMarket) FetchOrderBook(ctx context.Context, symbol string, limit int) (*model.OrderBook, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{
+		"instId": market.ID,
+	}
+	if limit = common.ClampOrderBookLimit(limit, okxMaxDepthLimit); limit > 0 {
+		params["sz"] = limit
+	}
+
+	resp, err := m.okx.client.HTTPClient.Get(ctx, "/api/v5/market/books", params)
+	if err != nil {
+		return nil, fmt.Errorf("fetch order book: %w", err)
+	}
+
+	var result okxOrderBookResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal order book: %w", err)
+	}
+
+	if result.Code != "0" || len(result.Data) == 0 {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	data := result.Data[0]
+	return &model.OrderBook{
+		Symbol:    market.Symbol,
+		Bids:      common.ParseOrderBookLevels(data.Bids, limit),
+		Asks:      common.ParseOrderBookLevels(data.Asks, limit),
+		Nonce:     data.SeqID,
+		Timestamp: data.Ts,
+	}, nil
+}
+
+func (m *okxSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timeframe string, since, until time.Time, limit int) (model.OHLCVs, error) {
 	// 获取市场信息
 	market, err := m.GetMarket(symbol)
 	if err != nil {
@@ -333,18 +648,31 @@ func (m *okxSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefram
 	}
 
 	// 标准化时间框架
-	normalizedTimeframe := common.OKXTimeframe(timeframe)
+	normalizedTimeframe, err := common.CheckTimeframe(timeframe, common.OKXTimeframe(timeframe), okxTimeframes)
+	if err != nil {
+		return nil, err
+	}
 
 	params := map[string]interface{}{
 		"instId": market.ID,
 		"bar":    normalizedTimeframe,
 		"limit":  limit,
 	}
-	if !since.IsZero() {
-		params["after"] = since.UnixMilli()
+	path := "/api/v5/market/candles"
+	if until.IsZero() {
+		if !since.IsZero() {
+			params["after"] = since.UnixMilli()
+		}
+	} else {
+		// 指定截止时间时使用历史K线接口：after 返回早于该时间的记录，before 返回晚于该时间的记录
+		path = "/api/v5/market/history-candles"
+		params["after"] = until.UnixMilli() + 1
+		if !since.IsZero() {
+			params["before"] = since.UnixMilli() - 1
+		}
 	}
 
-	resp, err := m.okx.client.HTTPClient.Get(ctx, "/api/v5/market/candles", params)
+	resp, err := m.okx.client.HTTPClient.Get(ctx, path, params)
 	if err != nil {
 		return nil, fmt.Errorf("fetch ohlcv: %w", err)
 	}
@@ -355,7 +683,7 @@ func (m *okxSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefram
 	}
 
 	if result.Code != "0" {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	ohlcvs := make(model.OHLCVs, 0, len(result.Data))
@@ -374,49 +702,52 @@ func (m *okxSpotMarket) FetchOHLCVs(ctx context.Context, symbol string, timefram
 	return ohlcvs, nil
 }
 
+// FetchAggregatedTrades 获取归集交易（OKX 无原生接口，由最近逐笔成交归集）
+func (m *okxSpotMarket) FetchAggregatedTrades(ctx context.Context, symbol string, since time.Time, limit int) (model.AggTrades, error) {
+	market, err := m.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.okx.client.HTTPClient.Get(ctx, "/api/v5/market/trades", map[string]interface{}{
+		"instId": market.ID,
+		"limit":  okxMaxTradesLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch trades: %w", err)
+	}
+
+	var result okxTradesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal trades: %w", err)
+	}
+
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	return common.AggregateTrades(result.toTrades(market.Symbol), since, limit), nil
+}
+
 type okxSpotOrder struct {
 	okx *OKX
 }
 
 // signAndRequest 签名并发送请求（OKX API）
 func (o *okxSpotOrder) signAndRequest(ctx context.Context, method, path string, params map[string]interface{}, body map[string]interface{}) ([]byte, error) {
-	if o.okx.client.SecretKey == "" {
-		return nil, fmt.Errorf("authentication required")
-	}
-
-	// 构建请求体
-	bodyStr := ""
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("marshal body: %w", err)
-		}
-		bodyStr = string(bodyBytes)
-	}
-
-	// 生成时间戳和签名
-	timestamp := common.GetISO8601Timestamp()
-	signature := o.okx.signer.SignRequest(method, path, timestamp, bodyStr, params)
+	return o.okx.signAndRequest(ctx, method, path, params, body)
+}
 
-	// 设置请求头
-	o.okx.client.HTTPClient.SetHeader("OK-ACCESS-SIGN", signature)
-	o.okx.client.HTTPClient.SetHeader("OK-ACCESS-TIMESTAMP", timestamp)
-	o.okx.client.HTTPClient.SetHeader("OK-ACCESS-PASSPHRASE", o.okx.client.Passphrase)
-	o.okx.client.HTTPClient.SetHeader("OK-ACCESS-KEY", o.okx.client.APIKey)
-	if o.okx.client.Sandbox {
-		o.okx.client.HTTPClient.SetHeader("x-simulated-trading", "1")
+// FetchBalance 获取余额，现货、合约和杠杆共用交易账户，资金账户单独查询
+func (o *okxSpotOrder) FetchBalance(ctx context.Context, opts ...option.ArgsOption) (model.Balances, error) {
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
 	}
-	o.okx.client.HTTPClient.SetHeader("Content-Type", "application/json")
-
-	// 发送请求
-	if method == "GET" || method == "DELETE" {
-		return o.okx.client.HTTPClient.Get(ctx, path, params)
-	} else {
-		return o.okx.client.HTTPClient.Post(ctx, path, body)
+	if option.GetAccountType(argsOpts.AccountType) == option.AccountFunding {
+		return o.fetchFundingBalance(ctx)
 	}
-}
 
-func (o *okxSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error) {
 	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/account/balance", nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("fetch balance: %w", err)
@@ -428,25 +759,66 @@ func (o *okxSpotOrder) FetchBalance(ctx context.Context) (model.Balances, error)
 	}
 
 	if result.Code != "0" || len(result.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
-	balances := make(model.Balances, 0)
-	for _, detail := range result.Data[0].Details {
-		balance := &model.Balance{
+	return toOKXBalances(result.Data[0].Details), nil
+}
+
+// toOKXBalances 将交易账户币种余额转换为 model.Balances（查询余额和私有频道 account 推送共用）
+func toOKXBalances(details []okxSpotBalanceDetail) model.Balances {
+	balances := make(model.Balances, 0, len(details))
+	for _, detail := range details {
+		balances = append(balances, &model.Balance{
 			Currency:  detail.Ccy,
 			Available: detail.AvailBal,
 			Locked:    detail.FrozenBal,
 			Total:     detail.Eq,
 			UpdatedAt: detail.UTime,
-		}
-		balances = append(balances, balance)
+		})
+	}
+	return balances
+}
+
+// fetchFundingBalance 获取资金账户余额（/api/v5/asset/balances）
+func (o *okxSpotOrder) fetchFundingBalance(ctx context.Context) (model.Balances, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/asset/balances", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch funding balance: %w", err)
+	}
+
+	var result okxSpotFundingBalanceResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal funding balance: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	balances := make(model.Balances, 0, len(result.Data))
+	for _, item := range result.Data {
+		balances = append(balances, &model.Balance{
+			Currency:  item.Ccy,
+			Available: item.AvailBal,
+			Locked:    item.FrozenBal,
+			Total:     item.Bal,
+			UpdatedAt: types.ExTimestamp{Time: time.Now()}, // 资金账户余额接口没有返回更新时间
+		})
 	}
 
 	return balances, nil
 }
 
 func (o *okxSpotOrder) CreateOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*model.NewOrder, error) {
+	params, err := o.buildOrderParams(symbol, side, amount, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return o.okx.submitOrder(ctx, params)
+}
+
+// buildOrderParams 校验并构建下单参数（单笔下单和批量下单共用）
+func (o *okxSpotOrder) buildOrderParams(symbol string, side option.SpotOrderSide, amount string, opts ...option.ArgsOption) (*okxOrderParams, error) {
 	// 解析选项
 	options := &option.ExchangeArgsOptions{}
 	for _, opt := range opts {
@@ -483,8 +855,11 @@ func (o *okxSpotOrder) CreateOrder(ctx context.Context, symbol string, side opti
 	// 确定交易模式（现货默认 cash）
 	tdMode := "cash"
 
-	// 计算 sz（数量）
-	sz := amount
+	// 计算 sz（数量，向下对齐到 lotSz）
+	sz, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
 
 	reqBody := map[string]interface{}{
 		"instId":  okxSymbol,
@@ -499,55 +874,181 @@ func (o *okxSpotOrder) CreateOrder(ctx context.Context, symbol string, side opti
 
 	// 限价单设置价格
 	if orderType == model.OrderTypeLimit {
-		reqBody["px"] = priceStr
+		px, err := common.PriceToPrecision(market, priceStr)
+		if err != nil {
+			return nil, err
+		}
+		reqBody["px"] = px
+	}
+
+	// 订单有效期通过 ordType 表示
+	if err := common.CheckTimeInForce(options, orderType == model.OrderTypeLimit, false); err != nil {
+		return nil, err
 	}
+	postOnly, err := common.ParsePostOnly(options, orderType == model.OrderTypeLimit)
+	if err != nil {
+		return nil, err
+	}
+	reqBody["ordType"] = okxOrdType(orderType == model.OrderTypeLimit, postOnly, options.TimeInForce)
 
 	// 客户端订单ID
+	clientOrderID := common.GenerateClientOrderID(o.okx.Name(), side.ToSide())
 	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
-		reqBody["clOrdId"] = *options.ClientOrderID
-	} else {
-		reqBody["clOrdId"] = common.GenerateClientOrderID(o.okx.Name(), side.ToSide())
+		clientOrderID = *options.ClientOrderID
 	}
+	reqBody["clOrdId"] = clientOrderID
 
-	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/trade/order", nil, reqBody)
+	// 设置触发价时通过策略委托下条件单
+	path := "/api/v5/trade/order"
+	stopPrice, triggerType, isStop, err := common.ParseStopOrder(options)
 	if err != nil {
-		return nil, fmt.Errorf("create order: %w", err)
+		return nil, err
 	}
+	if isStop {
+		toConditionalOrder(reqBody, stopPrice, triggerType)
+		path = "/api/v5/trade/order-algo"
+	}
+
+	strict, ok := option.GetBool(options.StrictClientID)
+	return &okxOrderParams{
+		symbol:        symbol,
+		path:          path,
+		body:          reqBody,
+		clientOrderID: clientOrderID,
+		stopPrice:     stopPrice,
+		isStop:        isStop,
+		strict:        ok && strict,
+	}, nil
+}
 
-	var result okxSpotCreateOrderResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("unmarshal order: %w", err)
+// CreateOCOOrder 创建 OCO 策略委托（/api/v5/trade/order-algo，ordType=oco）
+// 止盈腿在 price 触发并以 price 限价委托，止损腿在 stopPrice 触发并以 stopLimitPrice 委托（为空时按市价）
+// OKX 只返回策略委托单号，子订单没有单独的订单ID
+func (o *okxSpotOrder) CreateOCOOrder(ctx context.Context, symbol string, side option.SpotOrderSide, amount, price, stopPrice, stopLimitPrice string, opts ...option.ArgsOption) (*model.OrderList, error) {
+	options := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(options)
 	}
 
-	if result.Code != "0" {
-		errMsg := result.Msg
-		if len(result.Data) > 0 && result.Data[0].SMsg != "" {
-			errMsg = fmt.Sprintf("%s: %s", result.Msg, result.Data[0].SMsg)
-		}
-		return nil, fmt.Errorf("okx api error: %s", errMsg)
+	if err := common.ValidateOCOOrder(side, price, stopPrice, stopLimitPrice); err != nil {
+		return nil, err
 	}
 
-	if len(result.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: no order data returned")
+	market, err := o.okx.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
 	}
 
-	data := result.Data[0]
-	if data.SCode != "" && data.SCode != "0" {
-		errMsg := data.SMsg
-		if errMsg == "" {
-			errMsg = result.Msg
-		}
-		return nil, fmt.Errorf("okx api error: %s (code: %s)", errMsg, data.SCode)
+	sz, err := common.AmountToPrecision(market, amount)
+	if err != nil {
+		return nil, err
+	}
+	tpPx, err := common.PriceToPrecision(market, price)
+	if err != nil {
+		return nil, err
+	}
+	slTriggerPx, err := common.PriceToPrecision(market, stopPrice)
+	if err != nil {
+		return nil, err
+	}
+	slOrdPx := "-1"
+	if stopLimitPrice != "" {
+		if slOrdPx, err = common.PriceToPrecision(market, stopLimitPrice); err != nil {
+			return nil, err
+		}
 	}
 
-	order := &model.NewOrder{
-		OrderId:       data.OrdId,
-		ClientOrderID: data.ClOrdId,
-		Symbol:        symbol,
-		Timestamp:     data.Ts,
+	clientOrderID := common.GenerateClientOrderID(o.okx.Name(), side.ToSide())
+	if options.ClientOrderID != nil && *options.ClientOrderID != "" {
+		clientOrderID = *options.ClientOrderID
+	}
+
+	reqBody := map[string]interface{}{
+		"instId":      market.ID,
+		"tdMode":      "cash",
+		"side":        strings.ToLower(side.ToSide()),
+		"ordType":     "oco",
+		"sz":          sz,
+		"tgtCcy":      "base_ccy",
+		"tpTriggerPx": tpPx,
+		"tpOrdPx":     tpPx,
+		"slTriggerPx": slTriggerPx,
+		"slOrdPx":     slOrdPx,
+		"algoClOrdId": clientOrderID,
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/trade/order-algo", nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("create oco order: %w", err)
 	}
 
-	return order, nil
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal oco order: %w", err)
+	}
+	results, errs, err := result.orderResults()
+	if err != nil {
+		return nil, err
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	orderSide := model.OrderSide(strings.ToLower(side.ToSide()))
+	stopLeg := &model.OrderListOrder{
+		Type:      "stop_loss",
+		Side:      orderSide,
+		Amount:    types.ExDecimal{Decimal: decimal.RequireFromString(sz)},
+		StopPrice: types.ExDecimal{Decimal: decimal.RequireFromString(slTriggerPx)},
+	}
+	if slOrdPx != "-1" {
+		stopLeg.Price = types.ExDecimal{Decimal: decimal.RequireFromString(slOrdPx)}
+	}
+	list := &model.OrderList{
+		ID:       results[0].AlgoID,
+		ClientID: results[0].AlgoClOrdID,
+		Symbol:   market.Symbol,
+		Type:     "OCO",
+		Orders: []*model.OrderListOrder{
+			{
+				Type:      "take_profit",
+				Side:      orderSide,
+				Amount:    types.ExDecimal{Decimal: decimal.RequireFromString(sz)},
+				Price:     types.ExDecimal{Decimal: decimal.RequireFromString(tpPx)},
+				StopPrice: types.ExDecimal{Decimal: decimal.RequireFromString(tpPx)},
+			},
+			stopLeg,
+		},
+		Timestamp: results[0].Ts,
+	}
+
+	if strict, ok := option.GetBool(options.StrictClientID); ok && strict {
+		if err := common.CheckClientOrderID(clientOrderID, list.ClientID); err != nil {
+			return list, err
+		}
+	}
+
+	return list, nil
+}
+
+// CreateOrders 批量创建订单（POST /api/v5/trade/batch-orders，每批最多 20 个）
+// 条件单没有批量接口，逐个提交；参数校验失败的订单不提交，其错误写入对应位置
+func (o *okxSpotOrder) CreateOrders(ctx context.Context, requests []option.SpotOrderRequest) ([]*model.NewOrder, []error, error) {
+	orders := make([]*model.NewOrder, len(requests))
+	errs := make([]error, len(requests))
+
+	var items []common.BatchItem[*okxOrderParams]
+	for i, r := range requests {
+		params, err := o.buildOrderParams(r.Symbol, r.Side, r.Amount, r.Opts...)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, common.BatchItem[*okxOrderParams]{Index: i, Group: params.path, Params: params})
+	}
+
+	err := common.SubmitBatches(ctx, items, 20, orders, errs, o.okx.submitBatchOrders)
+	return orders, errs, err
 }
 
 func (o *okxSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) error {
@@ -574,14 +1075,95 @@ func (o *okxSpotOrder) CancelOrder(ctx context.Context, symbol string, orderId s
 
 	reqBody := map[string]interface{}{
 		"instId": okxSymbol,
-		"ordId":  orderId,
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		reqBody["clOrdId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		reqBody["ordId"] = orderId
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		reqBody["clOrdId"] = clientOrderID
+	} else {
+		return fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/trade/cancel-order", nil, reqBody)
+	if err != nil {
+		return err
 	}
 
-	_, err = o.signAndRequest(ctx, "POST", "/api/v5/trade/cancel-order", nil, reqBody)
-	return err
+	// 部分撤单接口成功时返回空响应体
+	if common.IsEmptyBody(resp) {
+		return nil
+	}
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("unmarshal cancel order: %w", err)
+	}
+
+	_, errs, err := result.orderResults()
+	if err != nil {
+		return err
+	}
+	return errs[0]
+}
+
+// EditOrder 修改挂单的数量和/或价格，修改成功后查询并返回最新订单
+func (o *okxSpotOrder) EditOrder(ctx context.Context, symbol string, orderId string, newAmount, newPrice string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.ValidateEditOrder(newAmount, newPrice); err != nil {
+		return nil, err
+	}
+
+	market, err := o.okx.spot.market.GetMarket(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	argsOpts := &option.ExchangeArgsOptions{}
+	for _, opt := range opts {
+		opt(argsOpts)
+	}
+
+	reqBody := map[string]interface{}{
+		"instId": market.ID,
+	}
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		reqBody["ordId"] = orderId
+	} else if clientOrderId, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		reqBody["clOrdId"] = clientOrderId
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
+	}
+	newAmount, newPrice, err = common.EditOrderToPrecision(market, newAmount, newPrice)
+	if err != nil {
+		return nil, err
+	}
+	if newAmount != "" {
+		reqBody["newSz"] = newAmount
+	}
+	if newPrice != "" {
+		reqBody["newPx"] = newPrice
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/trade/amend-order", nil, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("edit order: %w", err)
+	}
+
+	var result okxOrderResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal edit order: %w", err)
+	}
+
+	results, errs, err := result.orderResults()
+	if err != nil {
+		return nil, err
+	}
+	if errs[0] != nil {
+		return nil, errs[0]
+	}
+
+	return o.FetchOrder(ctx, symbol, results[0].OrdID, opts...)
 }
 
 // parseOrder 解析订单数据
@@ -640,6 +1222,10 @@ func (o *okxSpotOrder) parseOrder(item okxSpotFetchOrderData, symbol string) *mo
 }
 
 func (o *okxSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId string, opts ...option.ArgsOption) (*model.SpotOrder, error) {
+	if err := common.RequireSymbol("fetch order", symbol); err != nil {
+		return nil, err
+	}
+
 	// 获取市场信息
 	market, err := o.okx.spot.market.GetMarket(symbol)
 	if err != nil {
@@ -663,10 +1249,14 @@ func (o *okxSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId st
 
 	params := map[string]interface{}{
 		"instId": okxSymbol,
-		"ordId":  orderId,
 	}
-	if argsOpts.ClientOrderID != nil && *argsOpts.ClientOrderID != "" {
-		params["clOrdId"] = *argsOpts.ClientOrderID
+	// 优先使用 orderId 参数，如果没有则使用 ClientOrderID
+	if orderId != "" {
+		params["ordId"] = orderId
+	} else if clientOrderID, ok := option.GetString(argsOpts.ClientOrderID); ok {
+		params["clOrdId"] = clientOrderID
+	} else {
+		return nil, fmt.Errorf("either orderId parameter or ClientOrderID option must be provided")
 	}
 
 	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/trade/order", params, nil)
@@ -680,8 +1270,464 @@ func (o *okxSpotOrder) FetchOrder(ctx context.Context, symbol string, orderId st
 	}
 
 	if result.Code != "0" || len(result.Data) == 0 {
-		return nil, fmt.Errorf("okx api error: %s", result.Msg)
+		return nil, newOKXError(result.Code, result.Msg)
 	}
 
 	return o.parseOrder(result.Data[0], symbol), nil
 }
+
+// CreateConversion 闪兑（先通过 estimate-quote 询价，再通过 trade 确认报价）
+func (o *okxSpotOrder) CreateConversion(ctx context.Context, from, to, amount string) (*model.Conversion, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+
+	if _, err := decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	// OKX 闪兑以 baseCcy/quoteCcy 币对报价：from 为交易货币时卖出，为计价货币时买入
+	pair, err := o.fetchConvertPair(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	baseCcy, quoteCcy, side := pair.BaseCcy, pair.QuoteCcy, "sell"
+	if strings.EqualFold(pair.QuoteCcy, from) {
+		side = "buy"
+	}
+
+	// 询价
+	quoteReq := types.NewExValues()
+	quoteReq.SetBody("baseCcy", baseCcy)
+	quoteReq.SetBody("quoteCcy", quoteCcy)
+	quoteReq.SetBody("side", side)
+	quoteReq.SetBody("rfqSz", amount)
+	quoteReq.SetBody("rfqSzCcy", from)
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/asset/convert/estimate-quote", nil, quoteReq.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("estimate convert quote: %w", err)
+	}
+
+	var quoteResult okxSpotConvertQuoteResponse
+	if err := json.Unmarshal(resp, &quoteResult); err != nil {
+		return nil, fmt.Errorf("unmarshal convert quote: %w", err)
+	}
+	if quoteResult.Code != "0" {
+		return nil, newOKXError(quoteResult.Code, quoteResult.Msg)
+	}
+	if len(quoteResult.Data) == 0 {
+		return nil, fmt.Errorf("okx api error: no quote data returned")
+	}
+	quote := quoteResult.Data[0]
+
+	// 确认报价
+	tradeReq := types.NewExValues()
+	tradeReq.SetBody("quoteId", quote.QuoteID)
+	tradeReq.SetBody("baseCcy", baseCcy)
+	tradeReq.SetBody("quoteCcy", quoteCcy)
+	tradeReq.SetBody("side", side)
+	tradeReq.SetBody("sz", amount)
+	tradeReq.SetBody("szCcy", from)
+
+	resp, err = o.signAndRequest(ctx, "POST", "/api/v5/asset/convert/trade", nil, tradeReq.ToBodyMap())
+	if err != nil {
+		return nil, fmt.Errorf("convert trade: %w", err)
+	}
+
+	var tradeResult okxSpotConvertTradeResponse
+	if err := json.Unmarshal(resp, &tradeResult); err != nil {
+		return nil, fmt.Errorf("unmarshal convert trade: %w", err)
+	}
+	if tradeResult.Code != "0" {
+		return nil, newOKXError(tradeResult.Code, tradeResult.Msg)
+	}
+	if len(tradeResult.Data) == 0 {
+		return nil, fmt.Errorf("okx api error: no convert trade data returned")
+	}
+	trade := tradeResult.Data[0]
+
+	// 卖出 base 时得到 quote，买入 base 时得到 base
+	fromAmount, toAmount := trade.FillBaseSz, trade.FillQuoteSz
+	if side == "buy" {
+		fromAmount, toAmount = trade.FillQuoteSz, trade.FillBaseSz
+	}
+
+	conversion := &model.Conversion{
+		ID:         trade.TradeID,
+		QuoteID:    trade.QuoteID,
+		From:       from,
+		To:         to,
+		FromAmount: fromAmount,
+		ToAmount:   toAmount,
+		Status:     trade.State,
+		Timestamp:  trade.Ts,
+	}
+	if !fromAmount.IsZero() {
+		conversion.Rate = types.ExDecimal{Decimal: toAmount.Div(fromAmount.Decimal)}
+	}
+
+	return conversion, nil
+}
+
+// fetchConvertPair 查询两个币种的闪兑币对（GET /api/v5/asset/convert/currency-pair），不支持闪兑时返回错误
+func (o *okxSpotOrder) fetchConvertPair(ctx context.Context, from, to string) (*okxSpotConvertPairData, error) {
+	params := map[string]interface{}{
+		"fromCcy": from,
+		"toCcy":   to,
+	}
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/asset/convert/currency-pair", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch convert currency pair: %w", err)
+	}
+
+	var result okxSpotConvertPairResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal convert currency pair: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	for _, pair := range result.Data {
+		if (strings.EqualFold(pair.BaseCcy, from) && strings.EqualFold(pair.QuoteCcy, to)) ||
+			(strings.EqualFold(pair.BaseCcy, to) && strings.EqualFold(pair.QuoteCcy, from)) {
+			return &pair, nil
+		}
+	}
+	return nil, fmt.Errorf("convert %s to %s is not supported: %w", from, to, common.ErrNotSupported)
+}
+
+// okxChain 将网络转换为 OKX 链名称（币种-网络，如 USDT-TRC20），已带币种前缀时原样返回
+func okxChain(currency, network string) string {
+	if network == "" || strings.HasPrefix(strings.ToUpper(network), currency+"-") {
+		return network
+	}
+	return currency + "-" + network
+}
+
+// FetchCurrencies 获取全部币种的充提状态及支持的网络（/api/v5/asset/currencies）
+// 网络标识去掉链名称的币种前缀（USDT-TRC20 为 TRC20），Name 为完整链名称
+func (o *okxSpotOrder) FetchCurrencies(ctx context.Context) (map[string]*model.Currency, error) {
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/asset/currencies", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch currencies: %w", err)
+	}
+
+	var result okxSpotCurrencyResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal currencies: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	// 每条链一条记录，按币种归并并保持返回顺序
+	var codes []string
+	names := make(map[string]string)
+	networks := make(map[string][]model.CurrencyNetwork)
+	for _, item := range result.Data {
+		if _, ok := networks[item.Ccy]; !ok {
+			codes = append(codes, item.Ccy)
+			names[item.Ccy] = item.Name
+		}
+		fee := item.Fee
+		if fee.IsZero() {
+			fee = item.MinFee
+		}
+		networks[item.Ccy] = append(networks[item.Ccy], model.CurrencyNetwork{
+			Network:         strings.TrimPrefix(item.Chain, item.Ccy+"-"),
+			Name:            item.Chain,
+			Default:         item.MainNet,
+			DepositEnabled:  item.CanDep,
+			WithdrawEnabled: item.CanWd,
+			WithdrawFee:     fee,
+			WithdrawMin:     item.MinWd,
+		})
+	}
+
+	currencies := make(map[string]*model.Currency, len(codes))
+	for _, code := range codes {
+		currencies[code] = common.NewCurrency(code, names[code], networks[code])
+	}
+	return currencies, nil
+}
+
+// FetchDepositAddress 获取充值地址（/api/v5/asset/deposit-address），network 为空时使用当前选中的地址
+func (o *okxSpotOrder) FetchDepositAddress(ctx context.Context, currency, network string) (*model.DepositAddress, error) {
+	currency = strings.ToUpper(currency)
+
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/asset/deposit-address", map[string]interface{}{
+		"ccy": currency,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch deposit address: %w", err)
+	}
+
+	var result okxSpotDepositAddressResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal deposit address: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+
+	chain := okxChain(currency, network)
+	var data *okxSpotDepositAddressData
+	for i := range result.Data {
+		item := &result.Data[i]
+		if chain != "" {
+			if strings.EqualFold(item.Chain, chain) {
+				data = item
+				break
+			}
+			continue
+		}
+		if data == nil || item.Selected && !data.Selected {
+			data = item
+		}
+	}
+	if data == nil {
+		return nil, fmt.Errorf("deposit address not found: %s %s", currency, network)
+	}
+
+	tag := data.Tag
+	if tag == "" {
+		tag = data.Memo
+	}
+	if tag == "" {
+		tag = data.PmtID
+	}
+
+	return &model.DepositAddress{
+		Currency: data.Ccy,
+		Address:  data.Addr,
+		Tag:      tag,
+		Network:  strings.TrimPrefix(data.Chain, data.Ccy+"-"),
+	}, nil
+}
+
+// Withdraw 链上提币（/api/v5/asset/withdrawal，dest=4），params 中的 tag 按 OKX 要求拼接为 地址:标签
+func (o *okxSpotOrder) Withdraw(ctx context.Context, currency, amount, address, network string, params map[string]interface{}) (*model.Transaction, error) {
+	currency = strings.ToUpper(currency)
+
+	if _, err := decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	tag, body := common.SplitWithdrawParams(params)
+	toAddr := address
+	if tag != "" {
+		toAddr = address + ":" + tag
+	}
+	body["ccy"] = currency
+	body["amt"] = amount
+	body["dest"] = "4"
+	body["toAddr"] = toAddr
+	if chain := okxChain(currency, network); chain != "" {
+		body["chain"] = chain
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/asset/withdrawal", nil, body)
+	if err != nil {
+		return nil, fmt.Errorf("withdraw: %w", err)
+	}
+
+	var result okxSpotWithdrawResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal withdraw: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("okx api error: no withdrawal data returned")
+	}
+	data := result.Data[0]
+
+	return &model.Transaction{
+		ID:        data.WdID,
+		Currency:  currency,
+		Amount:    data.Amt,
+		Address:   address,
+		Tag:       tag,
+		Network:   strings.TrimPrefix(data.Chain, currency+"-"),
+		Status:    model.TransactionStatusPending,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
+
+// okxAccountIDs 资金划转的账户类型：6 资金账户，18 交易账户（统一账户下现货、杠杆和合约共用交易账户）
+var okxAccountIDs = map[option.AccountType]string{
+	option.AccountSpot:    "18",
+	option.AccountFutures: "18",
+	option.AccountMargin:  "18",
+	option.AccountFunding: "6",
+}
+
+// Transfer 资金账户与交易账户之间划转（/api/v5/asset/transfer）
+func (o *okxSpotOrder) Transfer(ctx context.Context, currency, amount string, fromAccount, toAccount option.AccountType) (*model.Transaction, error) {
+	from, okFrom := okxAccountIDs[fromAccount]
+	to, okTo := okxAccountIDs[toAccount]
+	if !okFrom || !okTo || from == to {
+		return nil, common.UnsupportedTransferError(okxName, fromAccount, toAccount)
+	}
+
+	currency = strings.ToUpper(currency)
+	if _, err := decimal.NewFromString(amount); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/asset/transfer", nil, map[string]interface{}{
+		"ccy":  currency,
+		"amt":  amount,
+		"from": from,
+		"to":   to,
+		"type": "0", // 母账户内划转
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transfer: %w", err)
+	}
+
+	var result okxSpotTransferResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal transfer: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	if len(result.Data) == 0 {
+		return nil, fmt.Errorf("okx api error: no transfer data returned")
+	}
+	data := result.Data[0]
+
+	return &model.Transaction{
+		ID:        data.TransID,
+		Currency:  currency,
+		Amount:    data.Amt,
+		Status:    model.TransactionStatusOK,
+		Timestamp: types.ExTimestamp{Time: time.Now()},
+	}, nil
+}
+
+// FetchBorrowRate 获取杠杆借币利率（/api/v5/account/interest-rate），返回账户等级下的每小时利率
+func (o *okxSpotOrder) FetchBorrowRate(ctx context.Context, currency string) (*model.BorrowRate, error) {
+	currency = strings.ToUpper(currency)
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/account/interest-rate", map[string]interface{}{
+		"ccy": currency,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch borrow rate: %w", err)
+	}
+
+	var result okxInterestRateResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal borrow rate: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	for _, item := range result.Data {
+		if strings.EqualFold(item.Ccy, currency) {
+			return &model.BorrowRate{
+				Currency:  currency,
+				Rate:      item.InterestRate,
+				Timestamp: types.ExTimestamp{Time: time.Now()},
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("fetch borrow rate: no rate returned for %s", currency)
+}
+
+// borrowRepay 手动借币或还币（/api/v5/account/spot-manual-borrow-repay），side 为 borrow 或 repay，适用于现货模式账户
+func (o *okxSpotOrder) borrowRepay(ctx context.Context, side, currency, amount string) error {
+	amountDecimal, err := decimal.NewFromString(amount)
+	if err != nil {
+		return fmt.Errorf("invalid amount: %w", err)
+	}
+	if !amountDecimal.IsPositive() {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+
+	resp, err := o.signAndRequest(ctx, "POST", "/api/v5/account/spot-manual-borrow-repay", nil, map[string]interface{}{
+		"ccy":  strings.ToUpper(currency),
+		"side": side,
+		"amt":  amountDecimal.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", side, err)
+	}
+
+	var result okxBorrowRepayResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", side, err)
+	}
+	if result.Code != "0" {
+		return newOKXError(result.Code, result.Msg)
+	}
+	return nil
+}
+
+// okxSubAccountPageSize 子账户列表每页数量（最大 100）
+const okxSubAccountPageSize = 100
+
+// FetchSubAccounts 获取子账户列表（/api/v5/users/subaccount/list），子账户以名称标识，按创建时间向前翻页
+func (o *okxSpotOrder) FetchSubAccounts(ctx context.Context) ([]*model.SubAccount, error) {
+	accounts := make([]*model.SubAccount, 0)
+	params := map[string]interface{}{"limit": okxSubAccountPageSize}
+	for {
+		resp, err := o.signAndRequest(ctx, "GET", "/api/v5/users/subaccount/list", params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("fetch sub accounts: %w", err)
+		}
+
+		var result okxSubAccountListResponse
+		if err := json.Unmarshal(resp, &result); err != nil {
+			return nil, fmt.Errorf("unmarshal sub accounts: %w", err)
+		}
+		if result.Code != "0" {
+			return nil, newOKXError(result.Code, result.Msg)
+		}
+		for _, item := range result.Data {
+			name := item.Label
+			if name == "" {
+				name = item.SubAcct
+			}
+			accounts = append(accounts, &model.SubAccount{
+				ID:        item.SubAcct,
+				Name:      name,
+				Frozen:    !item.Enable,
+				CreatedAt: item.Ts,
+			})
+		}
+		if len(result.Data) < okxSubAccountPageSize {
+			return accounts, nil
+		}
+		params["after"] = result.Data[len(result.Data)-1].Ts.UnixMilli()
+	}
+}
+
+// FetchBalanceFor 获取子账户交易账户余额（/api/v5/account/subaccount/balances），subAccountID 为子账户名称
+func (o *okxSpotOrder) FetchBalanceFor(ctx context.Context, subAccountID string) (model.Balances, error) {
+	if subAccountID == "" {
+		return nil, fmt.Errorf("fetch balance for: sub account id is required")
+	}
+
+	resp, err := o.signAndRequest(ctx, "GET", "/api/v5/account/subaccount/balances", map[string]interface{}{
+		"subAcct": subAccountID,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch balance for: %w", err)
+	}
+
+	var result okxSpotBalanceResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal sub account balance: %w", err)
+	}
+	if result.Code != "0" {
+		return nil, newOKXError(result.Code, result.Msg)
+	}
+	if len(result.Data) == 0 {
+		return model.Balances{}, nil
+	}
+
+	return toOKXBalances(result.Data[0].Details), nil
+}
@@ -2,13 +2,24 @@ package okx
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/lemconn/exlink/common"
 	"github.com/lemconn/exlink/exchange"
+	"github.com/lemconn/exlink/internal/exchangetest"
+	"github.com/lemconn/exlink/model"
 	"github.com/lemconn/exlink/option"
+	"github.com/lemconn/exlink/types"
 	"github.com/shopspring/decimal"
 )
 
@@ -56,6 +67,30 @@ func shouldSkipTestForHTTPError(t *testing.T, err error) bool {
 	return false
 }
 
+// newTestOKX 创建请求指向 handler 的 OKX 实例，预置 BTC/USDT 现货和 BTC/USDT:USDT 永续合约市场
+func newTestOKX(t *testing.T, handler http.HandlerFunc) *OKX {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	o := ex.(*OKX)
+	spot := &model.Market{ID: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	o.spotMarketsBySymbol[spot.Symbol] = spot
+	o.spotMarketsByID[spot.ID] = spot
+	perp := &model.Market{ID: "BTC-USDT-SWAP", Symbol: "BTC/USDT:USDT", Base: "BTC", Quote: "USDT", Settle: "USDT"}
+	o.perpMarketsBySymbol[perp.Symbol] = perp
+	o.perpMarketsByID[perp.ID] = perp
+	return o
+}
+
 func TestOKXSpot_FetchOHLCVs(t *testing.T) {
 	// 创建 OKX 实例（从环境变量获取配置）
 	ex, err := setupTestExchange()
@@ -140,6 +175,22 @@ func TestOKXSpot_FetchOHLCVs(t *testing.T) {
 	}
 }
 
+func TestOKXSpot_FetchOHLCVs_Precision(t *testing.T) {
+	// 收盘价超出 float64 精度，应原样解析为 decimal
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"0","msg":"","data":[["1700000000000","65000.1","65100.5","64900.000000000000000001","65000.123456789012345678","12.345678901234567891","802500","802500","1"]]}`))
+	})
+
+	ohlcvs, err := ex.Spot().FetchOHLCVs(context.Background(), "BTC/USDT", "1m")
+	if err != nil {
+		t.Fatalf("FetchOHLCVs: %v", err)
+	}
+	if len(ohlcvs) != 1 || ohlcvs[0].Close.String() != "65000.123456789012345678" ||
+		ohlcvs[0].Low.String() != "64900.000000000000000001" || ohlcvs[0].Volume.String() != "12.345678901234567891" {
+		t.Errorf("ohlcvs = %+v, want high-precision values preserved", ohlcvs)
+	}
+}
+
 func TestOKXSpot_FetchMarkets(t *testing.T) {
 	// 创建 OKX 实例（从环境变量获取配置）
 	ex, err := setupTestExchange()
@@ -247,3 +298,944 @@ func TestOKXSpot_FetchTickers(t *testing.T) {
 		}
 	}
 }
+
+// TestOKXSpot_CreateConversion 测试闪兑先询价再确认的两步流程
+func TestOKXSpot_CreateConversion(t *testing.T) {
+	var requests []string
+	var quoteBody, tradeBody map[string]interface{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Header.Get("OK-ACCESS-KEY") == "" || r.Header.Get("OK-ACCESS-SIGN") == "" {
+			t.Errorf("missing auth headers for %s", r.URL.Path)
+		}
+
+		switch r.URL.Path {
+		case "/api/v5/asset/convert/currency-pair":
+			if q := r.URL.Query(); q.Get("fromCcy") != "USDT" || q.Get("toCcy") != "BTC" {
+				t.Errorf("unexpected currency pair query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT","baseCcy":"BTC","baseCcyMax":"0.5","baseCcyMin":"0.0001","quoteCcy":"USDT","quoteCcyMax":"10000","quoteCcyMin":"1"}]}`))
+		case "/api/v5/asset/convert/estimate-quote":
+			if err := json.NewDecoder(r.Body).Decode(&quoteBody); err != nil {
+				t.Errorf("decode quote body: %v", err)
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"quoteId":"quoter123","baseCcy":"BTC","quoteCcy":"USDT","side":"buy","cnvtPx":"50000","rfqSz":"100","rfqSzCcy":"USDT","baseSz":"0.002","quoteSz":"100","ttlMs":"10000","quoteTime":"1700000000000"}]}`))
+		case "/api/v5/asset/convert/trade":
+			if err := json.NewDecoder(r.Body).Decode(&tradeBody); err != nil {
+				t.Errorf("decode trade body: %v", err)
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"tradeId":"trader456","quoteId":"quoter123","state":"fullyFilled","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","side":"buy","fillPx":"50000","fillBaseSz":"0.002","fillQuoteSz":"100","ts":"1700000001000"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	// 未加载市场信息：由闪兑币对接口得到 BTC-USDT，卖出 USDT 换 BTC 应按 buy 方向询价
+	conversion, err := ex.Spot().CreateConversion(context.Background(), "usdt", "btc", "100")
+	if err != nil {
+		t.Fatalf("CreateConversion: %v", err)
+	}
+
+	if len(requests) != 3 || requests[0] != "GET /api/v5/asset/convert/currency-pair" ||
+		requests[1] != "POST /api/v5/asset/convert/estimate-quote" || requests[2] != "POST /api/v5/asset/convert/trade" {
+		t.Fatalf("unexpected request sequence: %v", requests)
+	}
+
+	if quoteBody["baseCcy"] != "BTC" || quoteBody["quoteCcy"] != "USDT" || quoteBody["side"] != "buy" ||
+		quoteBody["rfqSz"] != "100" || quoteBody["rfqSzCcy"] != "USDT" {
+		t.Errorf("unexpected quote body: %v", quoteBody)
+	}
+	if tradeBody["quoteId"] != "quoter123" || tradeBody["side"] != "buy" || tradeBody["sz"] != "100" || tradeBody["szCcy"] != "USDT" {
+		t.Errorf("unexpected trade body: %v", tradeBody)
+	}
+
+	if conversion.ID != "trader456" || conversion.QuoteID != "quoter123" {
+		t.Errorf("unexpected ids: %s %s", conversion.ID, conversion.QuoteID)
+	}
+	if conversion.From != "USDT" || conversion.To != "BTC" {
+		t.Errorf("unexpected currencies: %s -> %s", conversion.From, conversion.To)
+	}
+	if !conversion.FromAmount.Equal(decimal.RequireFromString("100")) {
+		t.Errorf("FromAmount = %s, want 100", conversion.FromAmount.String())
+	}
+	if !conversion.ToAmount.Equal(decimal.RequireFromString("0.002")) {
+		t.Errorf("ToAmount = %s, want 0.002", conversion.ToAmount.String())
+	}
+	if !conversion.Rate.Equal(decimal.RequireFromString("0.00002")) {
+		t.Errorf("Rate = %s, want 0.00002", conversion.Rate.String())
+	}
+	if conversion.Status != "fullyFilled" {
+		t.Errorf("Status = %s, want fullyFilled", conversion.Status)
+	}
+	if conversion.Timestamp.UnixMilli() != 1700000001000 {
+		t.Errorf("Timestamp = %d, want 1700000001000", conversion.Timestamp.UnixMilli())
+	}
+}
+
+// TestOKXSpot_CreateConversion_QuoteError 测试询价失败时不会发起确认
+func TestOKXSpot_CreateConversion_QuoteError(t *testing.T) {
+	var tradeCalled bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/asset/convert/currency-pair":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT"}]}`))
+		case "/api/v5/asset/convert/estimate-quote":
+			w.Write([]byte(`{"code":"52914","msg":"Insufficient available balance","data":[]}`))
+		case "/api/v5/asset/convert/trade":
+			tradeCalled = true
+			w.Write([]byte(`{"code":"0","msg":"","data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	_, err = ex.Spot().CreateConversion(context.Background(), "BTC", "USDT", "1")
+	if err == nil || !strings.Contains(err.Error(), "Insufficient available balance") {
+		t.Fatalf("expected quote error, got %v", err)
+	}
+	if tradeCalled {
+		t.Error("trade endpoint should not be called when quote fails")
+	}
+}
+
+func TestOKXSpot_CreateConversion_UnsupportedPair(t *testing.T) {
+	var quoted bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/asset/convert/currency-pair":
+			// 不支持的币对返回空列表
+			w.Write([]byte(`{"code":"0","msg":"","data":[]}`))
+		default:
+			quoted = true
+			w.Write([]byte(`{"code":"0","msg":"","data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	// 不能默认按 sell 方向询价
+	if _, err := ex.Spot().CreateConversion(context.Background(), "USDT", "XYZ", "1"); !errors.Is(err, common.ErrNotSupported) {
+		t.Fatalf("CreateConversion = %v, want ErrNotSupported", err)
+	}
+	if quoted {
+		t.Error("quote should not be requested for an unsupported pair")
+	}
+}
+
+// TestOKXSpot_FetchTicker_Decimal OKX 现货 vol24h 为基础货币成交量、volCcy24h 为计价货币成交额，没有卖单时 askPx 为空字符串
+func TestOKXSpot_FetchTicker_Decimal(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/ticker" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","last":"65000.00000001","bidPx":"64999.12345678","askPx":"","open24h":"64000","high24h":"66000","low24h":"63000","vol24h":"1234.56789012345678","volCcy24h":"80246913.58024691","ts":"1700000000000"}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	e := ex.(*OKX)
+	market := &model.Market{ID: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	e.spotMarketsBySymbol[market.Symbol] = market
+	e.spotMarketsByID[market.ID] = market
+
+	ticker, err := ex.Spot().FetchTicker(context.Background(), "BTC/USDT")
+	if err != nil {
+		t.Fatalf("FetchTicker: %v", err)
+	}
+
+	exchangetest.CheckTickerDecimals(t, ticker, map[string]string{
+		"bid": "64999.12345678", "ask": "0", "last": "65000.00000001", "open": "64000",
+		"volume": "1234.56789012345678", "quote_volume": "80246913.58024691",
+	})
+	if ticker.Timestamp.UnixMilli() != 1700000000000 {
+		t.Errorf("timestamp = %v", ticker.Timestamp)
+	}
+}
+
+// TestOKXSpot_CancelOrder_EmptyBody 测试撤单返回空响应体时视为成功，返回 sCode 时报错
+func TestOKXSpot_CancelOrder_EmptyBody(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/trade/cancel-order" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if body == "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	o := ex.(*OKX)
+	market := &model.Market{ID: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	o.spotMarketsBySymbol[market.Symbol] = market
+	o.spotMarketsByID[market.ID] = market
+
+	if err := ex.Spot().CancelOrder(context.Background(), "BTC/USDT", "123"); err != nil {
+		t.Fatalf("CancelOrder with empty body: %v", err)
+	}
+
+	body = `{"code":"1","msg":"","data":[{"clOrdId":"","ordId":"123","sCode":"51400","sMsg":"Order cancellation failed as the order has been filled, canceled or does not exist"}]}`
+	err = ex.Spot().CancelOrder(context.Background(), "BTC/USDT", "123")
+	var orderErr *OrderError
+	if !errors.As(err, &orderErr) || orderErr.Code != "51400" {
+		t.Fatalf("CancelOrder err = %v, want OrderError 51400", err)
+	}
+}
+
+func TestOKXSpot_FetchOrderBook(t *testing.T) {
+	var gotInstID, gotSz string
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/books" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotInstID = r.URL.Query().Get("instId")
+		gotSz = r.URL.Query().Get("sz")
+		w.Write([]byte(`{"code":"0","msg":"","data":[{
+			"asks":[["50000.5","0.8","0","3"],["50001","1.2","0","5"]],
+			"bids":[["50000","1.1","0","4"]],
+			"ts":"1700000000654","seqId":3235846}]}`))
+	})
+
+	book, err := ex.Spot().FetchOrderBook(context.Background(), "BTC/USDT", 1000)
+	if err != nil {
+		t.Fatalf("FetchOrderBook: %v", err)
+	}
+	if gotInstID != "BTC-USDT" || gotSz != "400" {
+		t.Errorf("instId/sz = %s/%s, want BTC-USDT/400", gotInstID, gotSz)
+	}
+	if len(book.Bids) != 1 || len(book.Asks) != 2 {
+		t.Fatalf("got %d bids / %d asks, want 1/2", len(book.Bids), len(book.Asks))
+	}
+	if book.Asks[1].Price.String() != "50001" || book.Asks[1].Amount.String() != "1.2" {
+		t.Errorf("ask[1] = %s@%s, want 1.2@50001", book.Asks[1].Amount, book.Asks[1].Price)
+	}
+	if book.Nonce != 3235846 {
+		t.Errorf("Nonce = %d, want 3235846", book.Nonce)
+	}
+	if got := book.Timestamp.UnixMilli(); got != 1700000000654 {
+		t.Errorf("Timestamp = %d, want 1700000000654", got)
+	}
+}
+
+// TestOKXSpot_Wallet 测试充值地址按网络选择和提币请求体
+func TestOKXSpot_Wallet(t *testing.T) {
+	var withdrawBody map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("OK-ACCESS-KEY") == "" || r.Header.Get("OK-ACCESS-SIGN") == "" {
+			t.Errorf("missing auth headers for %s", r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/api/v5/asset/deposit-address":
+			w.Write([]byte(`{"code":"0","msg":"","data":[
+				{"chain":"USDT-ERC20","ccy":"USDT","addr":"0xabc","selected":false},
+				{"chain":"USDT-TRC20","ccy":"USDT","addr":"TXyz","selected":true}]}`))
+		case "/api/v5/asset/withdrawal":
+			if err := json.NewDecoder(r.Body).Decode(&withdrawBody); err != nil {
+				t.Errorf("decode withdraw body: %v", err)
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"amt":"10","wdId":"67485","ccy":"EOS","clientId":"","chain":"EOS-EOS"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	ctx := context.Background()
+
+	addr, err := ex.Spot().FetchDepositAddress(ctx, "usdt", "ERC20")
+	if err != nil {
+		t.Fatalf("FetchDepositAddress: %v", err)
+	}
+	if addr.Address != "0xabc" || addr.Network != "ERC20" {
+		t.Errorf("unexpected ERC20 deposit address: %+v", addr)
+	}
+
+	// 未指定网络时使用当前选中的地址
+	addr, err = ex.Spot().FetchDepositAddress(ctx, "USDT", "")
+	if err != nil {
+		t.Fatalf("FetchDepositAddress: %v", err)
+	}
+	if addr.Address != "TXyz" || addr.Network != "TRC20" {
+		t.Errorf("unexpected default deposit address: %+v", addr)
+	}
+
+	tx, err := ex.Spot().Withdraw(ctx, "eos", "10", "eosaccount", "EOS", map[string]interface{}{"tag": "memo1"})
+	if err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if withdrawBody["toAddr"] != "eosaccount:memo1" || withdrawBody["chain"] != "EOS-EOS" ||
+		withdrawBody["dest"] != "4" || withdrawBody["ccy"] != "EOS" || withdrawBody["amt"] != "10" {
+		t.Errorf("unexpected withdraw body: %v", withdrawBody)
+	}
+	if tx.ID != "67485" || tx.Network != "EOS" || tx.Tag != "memo1" || tx.Status != model.TransactionStatusPending {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+}
+
+// TestOKXSpot_Transfer 测试资金账户与交易账户的映射，现货与合约同属交易账户时不支持划转
+func TestOKXSpot_Transfer(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/asset/transfer" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode transfer body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"transId":"754147","ccy":"USDT","clientId":"","from":"6","amt":"1.5","to":"18"}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	ctx := context.Background()
+
+	tx, err := ex.Spot().Transfer(ctx, "usdt", "1.5", option.AccountFunding, option.AccountFutures)
+	if err != nil {
+		t.Fatalf("Transfer: %v", err)
+	}
+	if body["from"] != "6" || body["to"] != "18" || body["ccy"] != "USDT" || body["amt"] != "1.5" {
+		t.Errorf("unexpected transfer body: %v", body)
+	}
+	if tx.ID != "754147" || tx.Amount.String() != "1.5" || tx.Status != model.TransactionStatusOK {
+		t.Errorf("unexpected transaction: %+v", tx)
+	}
+
+	body = nil
+	if _, err := ex.Spot().Transfer(ctx, "USDT", "1", option.AccountSpot, option.AccountFutures); !errors.Is(err, common.ErrNotSupported) {
+		t.Errorf("err = %v, want ErrNotSupported", err)
+	}
+	if body != nil {
+		t.Error("unsupported transfer should not send a request")
+	}
+}
+
+func TestOKXSpot_FetchBalance_Funding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/asset/balances" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"availBal":"37.11","bal":"37.11","ccy":"USDT","frozenBal":"0"}]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	balances, err := ex.Spot().FetchBalance(context.Background(), option.WithAccountType(option.AccountFunding))
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if len(balances) != 1 {
+		t.Fatalf("len(balances) = %d, want 1", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "USDT" || b.Total.String() != "37.11" || b.Available.String() != "37.11" || !b.Locked.IsZero() {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+}
+
+func TestOKXSpot_FetchBalanceFor(t *testing.T) {
+	var gotSubAcct string
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/account/subaccount/balances" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotSubAcct = r.URL.Query().Get("subAcct")
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"totalEq":"1000","uTime":"1700000000000","details":[{"ccy":"USDT","availBal":"800","frozenBal":"200","eq":"1000","uTime":"1700000000000"}]}]}`))
+	})
+
+	balances, err := ex.Spot().FetchBalanceFor(context.Background(), "desk-a")
+	if err != nil {
+		t.Fatalf("FetchBalanceFor: %v", err)
+	}
+	if gotSubAcct != "desk-a" {
+		t.Errorf("subAcct = %q, want desk-a", gotSubAcct)
+	}
+	if len(balances) != 1 {
+		t.Fatalf("len(balances) = %d, want 1", len(balances))
+	}
+	b := balances[0]
+	if b.Currency != "USDT" || b.Available.String() != "800" || b.Locked.String() != "200" || b.Total.String() != "1000" {
+		t.Errorf("unexpected balance: currency=%s total=%s available=%s locked=%s", b.Currency, b.Total, b.Available, b.Locked)
+	}
+}
+
+func TestOKXSpot_Margin(t *testing.T) {
+	var body map[string]interface{}
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/account/interest-rate":
+			if r.URL.Query().Get("ccy") != "USDT" {
+				t.Errorf("ccy = %s, want USDT", r.URL.Query().Get("ccy"))
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ccy":"USDT","interestRate":"0.0000045"}]}`))
+		case "/api/v5/account/spot-manual-borrow-repay":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ccy":"USDT","side":"borrow","amt":"100"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	rate, err := ex.Spot().FetchBorrowRate(ctx, "usdt")
+	if err != nil {
+		t.Fatalf("FetchBorrowRate: %v", err)
+	}
+	if rate.Currency != "USDT" || rate.Rate.String() != "0.0000045" {
+		t.Errorf("unexpected rate: %+v", rate)
+	}
+
+	if err := ex.Spot().Borrow(ctx, "usdt", "100"); err != nil {
+		t.Fatalf("Borrow: %v", err)
+	}
+	if body["ccy"] != "USDT" || body["side"] != "borrow" || body["amt"] != "100" {
+		t.Errorf("unexpected borrow body: %v", body)
+	}
+	if err := ex.Spot().Repay(ctx, "USDT", "40"); err != nil {
+		t.Fatalf("Repay: %v", err)
+	}
+	if body["side"] != "repay" || body["amt"] != "40" {
+		t.Errorf("unexpected repay body: %v", body)
+	}
+}
+
+func TestOKXSpot_CreateOrders_MixedResults(t *testing.T) {
+	var body []map[string]interface{}
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/trade/batch-orders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"2","msg":"","data":[{"ordId":"1","clOrdId":"c-1","sCode":"0","sMsg":"","ts":"1700000000000"},{"ordId":"","clOrdId":"c-2","sCode":"51008","sMsg":"Order failed. Insufficient USDT balance in account."}]}`))
+	})
+
+	orders, errs, err := ex.Spot().CreateOrders(context.Background(), []option.SpotOrderRequest{
+		{Symbol: "BTC/USDT", Side: option.Buy, Amount: "0.01", Opts: []option.ArgsOption{option.WithPrice("30000"), option.WithClientOrderID("c-1")}},
+		{Symbol: "BTC/USDT", Side: option.Buy, Amount: "100", Opts: []option.ArgsOption{option.WithPrice("30000"), option.WithClientOrderID("c-2")}},
+		// 未加载的交易对，本地校验失败，不提交
+		{Symbol: "ETH/USDT", Side: option.Sell, Amount: "1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrders: %v", err)
+	}
+	if len(body) != 2 || body[0]["clOrdId"] != "c-1" || body[1]["clOrdId"] != "c-2" {
+		t.Errorf("unexpected batch body: %v", body)
+	}
+	if errs[0] != nil || orders[0] == nil || orders[0].OrderId != "1" || orders[0].ClientOrderID != "c-1" {
+		t.Errorf("order 0 = %+v, %v", orders[0], errs[0])
+	}
+	if !errors.Is(errs[1], common.ErrInsufficientFunds) || orders[1] != nil {
+		t.Errorf("order 1 = %+v, %v, want ErrInsufficientFunds", orders[1], errs[1])
+	}
+	if errs[2] == nil || orders[2] != nil {
+		t.Errorf("order 2 = %+v, %v, want market error", orders[2], errs[2])
+	}
+}
+
+func TestOKXSpot_WatchOrders_Login(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		// 先登录再订阅
+		var login struct {
+			Op   string          `json:"op"`
+			Args []okxWSLoginArg `json:"args"`
+		}
+		if err := conn.ReadJSON(&login); err != nil {
+			return
+		}
+		if login.Op != "login" || len(login.Args) != 1 {
+			t.Errorf("unexpected login message: %+v", login)
+			return
+		}
+		arg := login.Args[0]
+		if arg.APIKey != "key" || arg.Passphrase != "pass" || arg.Sign != NewSigner("secret", "pass").SignRequest("GET", "/users/self/verify", arg.Timestamp, "", nil) {
+			t.Errorf("invalid login args: %+v", arg)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"login","code":"0","msg":"","connId":"c1"}`))
+
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(msg) != `{"op":"subscribe","args":[{"channel":"orders","instType":"SPOT"}]}` {
+			t.Errorf("unexpected subscribe message: %s", msg)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"event":"subscribe","arg":{"channel":"orders","instType":"SPOT"},"connId":"c1"}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"arg":{"channel":"orders","instType":"SPOT","uid":"1"},"data":[
+			{"instType":"SPOT","instId":"BTC-USDT","ordId":"o1","clOrdId":"c1","px":"50000","sz":"0.02","ordType":"limit","side":"buy","accFillSz":"0.01","avgPx":"50000","state":"partially_filled","cTime":"1700000000000","uTime":"1700000000100"},
+			{"instType":"SPOT","instId":"ETH-USDT","ordId":"o2","px":"3000","sz":"1","ordType":"limit","side":"sell","accFillSz":"0","state":"live","cTime":"1700000000000","uTime":"1700000000100"}
+		]}`))
+		conn.ReadMessage() // 等待客户端断开
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	o := ex.(*OKX)
+	o.spotMarketsBySymbol["BTC/USDT"] = &model.Market{ID: "BTC-USDT", Symbol: "BTC/USDT", Base: "BTC", Quote: "USDT"}
+	o.spotMarketsByID["BTC-USDT"] = o.spotMarketsBySymbol["BTC/USDT"]
+	o.privateWS = common.NewWSManager(common.NewAuthWSDialer(common.NewWSDialer("ws"+strings.TrimPrefix(srv.URL, "http"), ""), o.wsLogin), okxPrivateWSProtocol{})
+	defer o.privateWS.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	orders, err := ex.Spot().WatchOrders(ctx)
+	if err != nil {
+		t.Fatalf("WatchOrders: %v", err)
+	}
+
+	var got []*model.SpotOrder
+	for len(got) < 2 {
+		select {
+		case order := <-orders:
+			got = append(got, order)
+		case <-ctx.Done():
+			t.Fatalf("timeout waiting for orders, got %d", len(got))
+		}
+	}
+	if got[0].ID != "o1" || got[0].Symbol != "BTC/USDT" || got[0].ClientOrderID != "c1" || got[0].Filled.String() != "0.01" || got[0].Status != model.OrderStatusOpen {
+		t.Errorf("unexpected order: %+v", got[0])
+	}
+	// 未加载市场信息时保留原始产品ID
+	if got[1].ID != "o2" || got[1].Symbol != "ETH-USDT" {
+		t.Errorf("unexpected order: %+v", got[1])
+	}
+}
+
+func TestParseOKXWSAccount(t *testing.T) {
+	msg := []byte(`{"arg":{"channel":"account","uid":"1"},"data":[{"uTime":"1700000000000","details":[
+		{"ccy":"USDT","eq":"1000","availBal":"900","frozenBal":"100","uTime":"1700000000000"}
+	]}]}`)
+	topic, ok := okxPrivateWSProtocol{}.Route(msg)
+	if !ok || topic != okxWSAccountTopic {
+		t.Fatalf("Route = %q, %v", topic, ok)
+	}
+
+	balances, ok := parseOKXWSAccount(msg)
+	if !ok || len(balances) != 1 {
+		t.Fatalf("parseOKXWSAccount = %v, %v", balances, ok)
+	}
+	bal := balances[0]
+	if bal.Currency != "USDT" || bal.Total.String() != "1000" || bal.Available.String() != "900" || bal.Locked.String() != "100" {
+		t.Errorf("unexpected balance: %+v", bal)
+	}
+}
+
+func TestOKXSpot_FetchCurrencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/asset/currencies" || r.Header.Get("OK-ACCESS-SIGN") == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[
+			{"ccy":"USDT","name":"Tether","chain":"USDT-ERC20","canDep":true,"canWd":true,"canInternal":true,"mainNet":false,"fee":"3.9","minFee":"","minWd":"10","needTag":false},
+			{"ccy":"USDT","name":"Tether","chain":"USDT-TRC20","canDep":true,"canWd":true,"canInternal":true,"mainNet":true,"fee":"1","minWd":"0.1","needTag":false},
+			{"ccy":"USDT","name":"Tether","chain":"USDT-BSC","canDep":false,"canWd":false,"canInternal":true,"mainNet":false,"minFee":"0.8","minWd":"10","needTag":false},
+			{"ccy":"BTC","name":"Bitcoin","chain":"BTC-Bitcoin","canDep":true,"canWd":true,"canInternal":true,"mainNet":true,"fee":"0.0002","minWd":"0.0005","needTag":false}
+		]}`))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{
+		"baseURL":  srv.URL,
+		"password": "pass",
+	})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+
+	currencies, err := ex.Spot().FetchCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("FetchCurrencies: %v", err)
+	}
+	if len(currencies) != 2 {
+		t.Fatalf("len(currencies) = %d, want 2", len(currencies))
+	}
+	usdt := currencies["USDT"]
+	if usdt == nil || usdt.Name != "Tether" || len(usdt.Networks) != 3 {
+		t.Fatalf("unexpected USDT: %+v", usdt)
+	}
+	// 手续费取主网（TRC20）
+	if !usdt.DepositEnabled || !usdt.WithdrawEnabled || usdt.WithdrawFee.String() != "1" {
+		t.Errorf("USDT deposit=%v withdraw=%v fee=%s", usdt.DepositEnabled, usdt.WithdrawEnabled, usdt.WithdrawFee)
+	}
+	// 网络标识去掉币种前缀，可直接用于 FetchDepositAddress
+	erc20, bsc := usdt.Networks[0], usdt.Networks[2]
+	if erc20.Network != "ERC20" || erc20.Name != "USDT-ERC20" || erc20.WithdrawFee.String() != "3.9" {
+		t.Errorf("unexpected ERC20 network: %+v", erc20)
+	}
+	if bsc.Network != "BSC" || bsc.DepositEnabled || bsc.WithdrawFee.String() != "0.8" {
+		t.Errorf("unexpected BSC network: %+v", bsc)
+	}
+	if btc := currencies["BTC"]; btc == nil || btc.Networks[0].Network != "Bitcoin" || btc.WithdrawFee.String() != "0.0002" {
+		t.Errorf("unexpected BTC: %+v", btc)
+	}
+}
+
+func TestOKX_FetchStatus(t *testing.T) {
+	var body atomic.Value
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/system/status" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("", "", map[string]interface{}{"baseURL": srv.URL})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	ctx := context.Background()
+
+	// 已完成和计划中的维护不影响状态
+	body.Store(`{"code":"0","msg":"","data":[
+		{"begin":"1672823400000","end":"1672825980000","href":"","preOpenBegin":"","scheDesc":"","serviceType":"0","state":"completed","maintType":"1","env":"1","system":"unified","title":"Trading account system upgrade"},
+		{"begin":"1893456000000","end":"1893459600000","href":"https://www.okx.com/help/next","preOpenBegin":"","scheDesc":"","serviceType":"5","state":"scheduled","maintType":"1","env":"1","system":"unified","title":"Scheduled upgrade"}]}`)
+	status, err := ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusOK || !status.ETA.IsZero() || status.URL != "" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	body.Store(`{"code":"0","msg":"","data":[
+		{"begin":"1700000000000","end":"1700003600000","href":"https://www.okx.com/help/maintenance","preOpenBegin":"","scheDesc":"","serviceType":"8","state":"ongoing","maintType":"1","env":"1","system":"unified","title":"Trading system upgrade"}]}`)
+	status, err = ex.FetchStatus(ctx)
+	if err != nil {
+		t.Fatalf("FetchStatus: %v", err)
+	}
+	if status.Status != model.ExchangeStatusMaintenance || status.Updated.UnixMilli() != 1700000000000 ||
+		status.ETA.UnixMilli() != 1700003600000 || status.URL != "https://www.okx.com/help/maintenance" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestOKX_SandboxHeader(t *testing.T) {
+	var requests, missing atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("x-simulated-trading") != "1" {
+			missing.Add(1)
+			t.Errorf("%s %s: x-simulated-trading = %q, want 1", r.Method, r.URL.Path, r.Header.Get("x-simulated-trading"))
+		}
+		switch r.URL.Path {
+		case "/api/v5/public/time":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ts":"1700000000000"}]}`))
+		case "/api/v5/account/balance":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"details":[]}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{"baseURL": srv.URL, "password": "pass", "sandbox": true})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	ctx := context.Background()
+
+	// 公共接口
+	if _, err := ex.FetchTime(ctx); err != nil {
+		t.Fatalf("FetchTime: %v", err)
+	}
+	// 签名接口
+	if _, err := ex.Spot().FetchBalance(ctx); err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if requests.Load() != 2 || missing.Load() != 0 {
+		t.Errorf("requests = %d, missing header = %d", requests.Load(), missing.Load())
+	}
+}
+
+func TestOKXSpot_CreateOCOOrder(t *testing.T) {
+	var body map[string]interface{}
+	ex := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v5/trade/order-algo" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[{"algoId":"681096944655273984","clOrdId":"","algoClOrdId":"oco-1","sCode":"0","sMsg":""}]}`))
+	})
+	ctx := context.Background()
+
+	list, err := ex.Spot().CreateOCOOrder(ctx, "BTC/USDT", option.Sell, "0.1", "55000", "48000", "47900", option.WithClientOrderID("oco-1"))
+	if err != nil {
+		t.Fatalf("CreateOCOOrder: %v", err)
+	}
+	want := map[string]string{
+		"instId": "BTC-USDT", "tdMode": "cash", "side": "sell", "ordType": "oco", "sz": "0.1", "algoClOrdId": "oco-1",
+		"tpTriggerPx": "55000", "tpOrdPx": "55000", "slTriggerPx": "48000", "slOrdPx": "47900",
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %q", k, body[k], v)
+		}
+	}
+
+	if list.ID != "681096944655273984" || list.ClientID != "oco-1" || list.Type != "OCO" || list.Symbol != "BTC/USDT" || len(list.Orders) != 2 {
+		t.Fatalf("list = %+v", list)
+	}
+	tp, sl := list.Orders[0], list.Orders[1]
+	if tp.Type != "take_profit" || tp.Price.String() != "55000" || tp.Side != model.OrderSideSell || tp.Amount.String() != "0.1" {
+		t.Errorf("take profit leg = %+v", tp)
+	}
+	if sl.Type != "stop_loss" || sl.StopPrice.String() != "48000" || sl.Price.String() != "47900" {
+		t.Errorf("stop loss leg = %+v", sl)
+	}
+
+	// 未指定止损委托价时触发后按市价成交
+	list, err = ex.Spot().CreateOCOOrder(ctx, "BTC/USDT", option.Buy, "0.1", "45000", "52000", "")
+	if err != nil {
+		t.Fatalf("CreateOCOOrder buy: %v", err)
+	}
+	if body["side"] != "buy" || body["slOrdPx"] != "-1" || !list.Orders[1].Price.IsZero() {
+		t.Errorf("buy body = %v, stop leg price = %s", body, list.Orders[1].Price)
+	}
+}
+
+func TestOKXSpot_FetchMyTradesRange(t *testing.T) {
+	since := time.UnixMilli(1700000000000)
+	until := since.Add(time.Hour)
+	fill := func(n int) string {
+		return fmt.Sprintf(`{"instId":"BTC-USDT","tradeId":"t%d","ordId":"o%d","billId":"b%d","side":"buy","fillSz":"0.1","fillPx":"50000","ts":"%d"}`,
+			n, n, n, since.Add(time.Duration(n)*time.Second).UnixMilli())
+	}
+
+	var afters []string
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v5/trade/fills-history" || q.Get("instType") != "SPOT" || q.Get("instId") != "BTC-USDT" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.String())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if q.Get("begin") != "1700000000000" || q.Get("end") != "1700003600000" || q.Get("limit") != "100" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		afters = append(afters, q.Get("after"))
+
+		// 按时间倒序返回：第一页 t200..t101 整页，第二页与第一页重叠一条，并包含一条早于 since 的成交
+		var items []string
+		switch q.Get("after") {
+		case "":
+			for n := 200; n > 100; n-- {
+				items = append(items, fill(n))
+			}
+		case "b101":
+			items = append(items, fill(101), fill(100), fill(99),
+				`{"instId":"BTC-USDT","tradeId":"t0","ordId":"o0","billId":"b0","side":"sell","fillSz":"1","fillPx":"49000","ts":"1699999999000"}`)
+		default:
+			t.Errorf("unexpected after cursor: %s", q.Get("after"))
+		}
+		w.Write([]byte(`{"code":"0","msg":"","data":[` + strings.Join(items, ",") + `]}`))
+	})
+
+	trades, err := o.Spot().FetchMyTradesRange(context.Background(), "BTC/USDT", since, until)
+	if err != nil {
+		t.Fatalf("FetchMyTradesRange: %v", err)
+	}
+	if len(afters) != 2 || afters[1] != "b101" {
+		t.Errorf("after cursors = %v, want [\"\" b101]", afters)
+	}
+	if len(trades) != 102 {
+		t.Fatalf("got %d trades, want 102", len(trades))
+	}
+	if trades[0].ID != "t99" || trades[101].ID != "t200" {
+		t.Errorf("trades range %s..%s, want t99..t200 ascending", trades[0].ID, trades[101].ID)
+	}
+	first := trades[0]
+	if first.OrderID != "o99" || first.Symbol != "BTC/USDT" || first.Side != "buy" || first.Cost.String() != "5000" {
+		t.Errorf("trade = %+v", first)
+	}
+}
+
+func TestOKX_VerifyCredentials(t *testing.T) {
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v5/public/time":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ts":"1700000000000"}]}`))
+		case "/api/v5/account/balance":
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code":"50111","msg":"Invalid OK-ACCESS-KEY","data":[]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	ctx := context.Background()
+
+	if err := o.Ping(ctx); err != nil {
+		t.Errorf("Ping: %v", err)
+	}
+	err := o.VerifyCredentials(ctx)
+	var exErr *common.ExchangeError
+	if !errors.Is(err, common.ErrAuthenticationFailed) || !errors.As(err, &exErr) || exErr.Code != "50111" {
+		t.Errorf("VerifyCredentials err = %v, want ErrAuthenticationFailed with code 50111", err)
+	}
+
+	// 缺少 passphrase 视为未配置凭证
+	o.UpdateCredentials("key", "secret", "")
+	if err := o.VerifyCredentials(ctx); !errors.Is(err, common.ErrAuthenticationRequired) {
+		t.Errorf("without passphrase err = %v, want ErrAuthenticationRequired", err)
+	}
+}
+
+func TestOKX_FetchOrder_SymbolRequired(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	ex, err := NewOKX("key", "secret", map[string]interface{}{"baseURL": srv.URL, "password": "pass"})
+	if err != nil {
+		t.Fatalf("NewOKX: %v", err)
+	}
+	// /api/v5/trade/order 必须指定 instId，不发送请求直接返回错误
+	ctx := context.Background()
+	if _, err := ex.Spot().FetchOrder(ctx, "", "1"); !errors.Is(err, common.ErrSymbolRequired) {
+		t.Errorf("spot: err = %v, want ErrSymbolRequired", err)
+	}
+	if _, err := ex.Perp().FetchOrderByClientID(ctx, "", "my-order"); !errors.Is(err, common.ErrSymbolRequired) {
+		t.Errorf("perp: err = %v, want ErrSymbolRequired", err)
+	}
+}
+
+func TestOKXSpot_EditOrder(t *testing.T) {
+	var edits []map[string]interface{}
+	o := newTestOKX(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v5/trade/amend-order":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			edits = append(edits, body)
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"ordId":"o1","clOrdId":"","sCode":"0","sMsg":""}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v5/trade/order":
+			w.Write([]byte(`{"code":"0","msg":"","data":[{"instType":"SPOT","instId":"BTC-USDT","ordId":"o1","px":"50000.1","sz":"0.012","ordType":"limit","side":"buy","accFillSz":"0","state":"live","cTime":"1700000000000","uTime":"1700000000100"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	market := o.spotMarketsBySymbol["BTC/USDT"]
+	market.Precision.StepSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.001")}
+	market.Precision.TickSize = types.ExDecimal{Decimal: decimal.RequireFromString("0.1")}
+	ctx := context.Background()
+
+	order, err := o.Spot().EditOrder(ctx, "BTC/USDT", "o1", "0.01234", "50000.19")
+	if err != nil {
+		t.Fatalf("EditOrder: %v", err)
+	}
+	if order.ID != "o1" {
+		t.Errorf("unexpected order: %+v", order)
+	}
+	if e := edits[0]; e["instId"] != "BTC-USDT" || e["ordId"] != "o1" || e["newSz"] != "0.012" || e["newPx"] != "50000.1" {
+		t.Errorf("edit body = %v, want newSz 0.012 newPx 50000.1", e)
+	}
+
+	// 只改数量时不发送价格
+	if _, err := o.Spot().EditOrder(ctx, "BTC/USDT", "o1", "0.02", ""); err != nil {
+		t.Fatalf("EditOrder amount: %v", err)
+	}
+	if e := edits[1]; e["newSz"] != "0.02" || e["newPx"] != nil {
+		t.Errorf("amount edit body = %v, want newSz only", e)
+	}
+}
@@ -3,6 +3,9 @@ package okx
 import (
 	"fmt"
 	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/option"
 )
 
 // ToOKXSymbol 转换为OKX格式的symbol
@@ -23,3 +26,40 @@ func ToOKXSymbol(symbol string, isContract bool) (string, error) {
 	}
 	return base + "-" + quote, nil
 }
+
+// symbolFromOKXID 将 OKX 原始交易对ID转换为标准化格式，用于市场信息中查不到的交易对
+// 现货: BTC-USDT -> BTC/USDT
+// 永续: BTC-USDT-SWAP -> BTC/USDT:USDT，币本位 BTC-USD-SWAP -> BTC/USD:BTC
+// 无法解析时原样返回
+func symbolFromOKXID(id string) string {
+	parts := strings.Split(id, "-")
+	switch {
+	case len(parts) == 2:
+		return common.NormalizeSymbol(parts[0], parts[1])
+	case len(parts) == 3 && parts[2] == "SWAP":
+		settle := parts[1]
+		if settle == "USD" {
+			settle = parts[0]
+		}
+		return common.NormalizeContractSymbol(parts[0], parts[1], settle)
+	default:
+		return id
+	}
+}
+
+// okxOrdType 返回下单的 ordType：市价单为 market，限价单按订单有效期转换
+// GTC -> limit，IOC -> ioc，FOK -> fok，只做 Maker -> post_only
+func okxOrdType(isLimit, postOnly bool, timeInForce *option.TimeInForce) string {
+	switch {
+	case !isLimit:
+		return "market"
+	case postOnly:
+		return "post_only"
+	case timeInForce != nil && timeInForce.IsIOC():
+		return "ioc"
+	case timeInForce != nil && timeInForce.IsFOK():
+		return "fok"
+	default:
+		return "limit"
+	}
+}
@@ -0,0 +1,307 @@
+package okx
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/lemconn/exlink/common"
+	"github.com/lemconn/exlink/model"
+	"github.com/lemconn/exlink/types"
+	"github.com/shopspring/decimal"
+)
+
+// okxWSProtocol OKX V5 公共频道订阅协议
+// 主题格式为 "频道:产品ID"，如 tickers:BTC-USDT；
+// 订阅消息形如 {"op":"subscribe","args":[{"channel":"tickers","instId":"BTC-USDT"}]}，推送消息形如 {"arg":{...},"data":[...]}；
+// 30 秒内无消息时服务端断开连接，需定时发送字符串 ping
+type okxWSProtocol struct{}
+
+// okxWSArg OKX WebSocket 订阅参数
+type okxWSArg struct {
+	Channel string `json:"channel"`
+	InstID  string `json:"instId"`
+}
+
+// okxWSRequest OKX WebSocket 订阅请求
+type okxWSRequest struct {
+	Op   string     `json:"op"`
+	Args []okxWSArg `json:"args"`
+}
+
+// okxWSTopic 构建订阅主题
+func okxWSTopic(channel, instID string) string {
+	return channel + ":" + instID
+}
+
+// okxWSArgs 将订阅主题解析为订阅参数
+func okxWSArgs(topics []string) []okxWSArg {
+	args := make([]okxWSArg, 0, len(topics))
+	for _, topic := range topics {
+		channel, instID, _ := strings.Cut(topic, ":")
+		args = append(args, okxWSArg{Channel: channel, InstID: instID})
+	}
+	return args
+}
+
+// SubscribeMessage 构建订阅消息
+func (okxWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(okxWSRequest{Op: "subscribe", Args: okxWSArgs(topics)})
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (okxWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(okxWSRequest{Op: "unsubscribe", Args: okxWSArgs(topics)})
+}
+
+// Route 根据 arg 中的频道和产品ID解析主题，订阅响应等事件消息带有 event 字段，不分发
+func (okxWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Event string   `json:"event"`
+		Arg   okxWSArg `json:"arg"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Event != "" || m.Arg.Channel == "" {
+		return "", false
+	}
+	return okxWSTopic(m.Arg.Channel, m.Arg.InstID), true
+}
+
+// PingMessage 心跳消息，服务端回复字符串 pong（非 JSON，Route 不分发）
+func (okxWSProtocol) PingMessage() []byte {
+	return []byte("ping")
+}
+
+// parseOKXWSTicker 返回解析行情推送的函数，symbol 为标准化格式
+func parseOKXWSTicker(symbol string) common.WSParser[*model.Ticker] {
+	return func(msg []byte) (*model.Ticker, bool) {
+		var m struct {
+			Data []okxTickerItem `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+			return nil, false
+		}
+
+		data := m.Data[0]
+		ticker := &model.Ticker{
+			Symbol:    symbol,
+			Timestamp: data.Ts,
+		}
+		ticker.Bid = data.BidPx
+		ticker.Ask = data.AskPx
+		ticker.Last = data.Last
+		ticker.Open = data.Open24h
+		ticker.High = data.High24h
+		ticker.Low = data.Low24h
+		ticker.Volume = data.Vol24h
+		ticker.QuoteVolume = data.VolCcy24h
+		return ticker, true
+	}
+}
+
+// okxWSOrderBook OKX 深度推送数据
+type okxWSOrderBook struct {
+	Asks      [][]types.ExDecimal `json:"asks"`      // 卖盘 [price, size, 废弃字段, 订单数]
+	Bids      [][]types.ExDecimal `json:"bids"`      // 买盘 [price, size, 废弃字段, 订单数]
+	Ts        types.ExTimestamp   `json:"ts"`        // 时间戳
+	SeqID     int64               `json:"seqId"`     // 本次推送序号
+	PrevSeqID int64               `json:"prevSeqId"` // 上一次推送序号，全量推送为 -1
+}
+
+// parseOKXWSOrderBook 解析 books 频道推送，action 为 snapshot 时为全量数据，update 时为增量
+func parseOKXWSOrderBook(msg []byte) (*common.OrderBookDelta, bool) {
+	var m struct {
+		Action string           `json:"action"`
+		Data   []okxWSOrderBook `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+		return nil, false
+	}
+
+	data := m.Data[0]
+	return &common.OrderBookDelta{
+		Snapshot:      m.Action == "snapshot",
+		FinalUpdateID: data.SeqID,
+		PrevUpdateID:  data.PrevSeqID,
+		Bids:          common.ParseOrderBookLevels(data.Bids, 0),
+		Asks:          common.ParseOrderBookLevels(data.Asks, 0),
+		Timestamp:     data.Ts,
+	}, true
+}
+
+// okxCandleChannel 返回K线频道名，如 candle1m、candle1H
+func okxCandleChannel(bar string) string {
+	return "candle" + bar
+}
+
+// parseOKXWSCandle 解析 candle 频道推送，数据格式与 REST K线相同，confirm 为 1 时为收盘K线
+func parseOKXWSCandle(msg []byte) (*model.OHLCV, bool) {
+	var m struct {
+		Data []okxKline `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+		return nil, false
+	}
+
+	k := m.Data[len(m.Data)-1]
+	return &model.OHLCV{
+		Timestamp: k.Ts,
+		Open:      k.Open,
+		High:      k.High,
+		Low:       k.Low,
+		Close:     k.Close,
+		Volume:    k.Volume,
+		Closed:    k.Confirm.Equal(decimal.NewFromInt(1)),
+	}, true
+}
+
+const (
+	// okxWSSpotOrdersTopic 私有频道现货订单主题
+	okxWSSpotOrdersTopic = "orders:SPOT"
+	// okxWSSwapOrdersTopic 私有频道永续合约订单主题
+	okxWSSwapOrdersTopic = "orders:SWAP"
+	// okxWSAccountTopic 私有频道账户余额主题
+	okxWSAccountTopic = "account:"
+)
+
+// okxPrivateWSProtocol OKX V5 私有频道订阅协议
+// 主题格式为 "频道:产品类型"，如 orders:SPOT，account 频道产品类型为空；连接空闲时需发送字符串 ping
+type okxPrivateWSProtocol struct{}
+
+// okxPrivateWSArg OKX 私有频道订阅参数
+type okxPrivateWSArg struct {
+	Channel  string `json:"channel"`
+	InstType string `json:"instType,omitempty"`
+}
+
+// okxPrivateWSRequest OKX 私有频道订阅请求
+type okxPrivateWSRequest struct {
+	Op   string            `json:"op"`
+	Args []okxPrivateWSArg `json:"args"`
+}
+
+// okxPrivateWSArgs 将订阅主题解析为私有频道订阅参数
+func okxPrivateWSArgs(topics []string) []okxPrivateWSArg {
+	args := make([]okxPrivateWSArg, 0, len(topics))
+	for _, topic := range topics {
+		channel, instType, _ := strings.Cut(topic, ":")
+		args = append(args, okxPrivateWSArg{Channel: channel, InstType: instType})
+	}
+	return args
+}
+
+// SubscribeMessage 构建订阅消息
+func (okxPrivateWSProtocol) SubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(okxPrivateWSRequest{Op: "subscribe", Args: okxPrivateWSArgs(topics)})
+}
+
+// UnsubscribeMessage 构建取消订阅消息
+func (okxPrivateWSProtocol) UnsubscribeMessage(topics []string) ([]byte, error) {
+	return json.Marshal(okxPrivateWSRequest{Op: "unsubscribe", Args: okxPrivateWSArgs(topics)})
+}
+
+// Route 根据 arg 中的频道和产品类型解析主题，登录和订阅响应等事件消息不分发
+func (okxPrivateWSProtocol) Route(msg []byte) (string, bool) {
+	var m struct {
+		Event string          `json:"event"`
+		Arg   okxPrivateWSArg `json:"arg"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || m.Event != "" || m.Arg.Channel == "" {
+		return "", false
+	}
+	return m.Arg.Channel + ":" + m.Arg.InstType, true
+}
+
+// PingMessage 心跳消息，服务端回复字符串 pong
+func (okxPrivateWSProtocol) PingMessage() []byte {
+	return []byte("ping")
+}
+
+// okxWSLoginArg 私有频道登录参数
+type okxWSLoginArg struct {
+	APIKey     string `json:"apiKey"`
+	Passphrase string `json:"passphrase"`
+	Timestamp  string `json:"timestamp"`
+	Sign       string `json:"sign"`
+}
+
+// okxWSLoginRequest 私有频道登录请求
+type okxWSLoginRequest struct {
+	Op   string          `json:"op"`
+	Args []okxWSLoginArg `json:"args"`
+}
+
+// wsLogin 私有频道登录，每次建立连接（包括断线重连）后调用
+// 签名内容为 timestamp + "GET" + "/users/self/verify"，timestamp 为秒级时间戳
+func (o *OKX) wsLogin(ctx context.Context, conn common.WSConn) error {
+	creds := o.credentials()
+	if creds.secretKey == "" {
+		return common.ErrAuthenticationRequired
+	}
+
+	timestamp := strconv.FormatInt(o.clock.TimestampSeconds(), 10)
+	msg, err := json.Marshal(okxWSLoginRequest{
+		Op: "login",
+		Args: []okxWSLoginArg{{
+			APIKey:     creds.apiKey,
+			Passphrase: creds.passphrase,
+			Timestamp:  timestamp,
+			Sign:       creds.signer.SignRequest("GET", "/users/self/verify", timestamp, "", nil),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return common.WSLoginRequest(conn, msg, func(resp []byte) (bool, error) {
+		var m struct {
+			Event string `json:"event"`
+			Code  string `json:"code"`
+			Msg   string `json:"msg"`
+		}
+		if err := json.Unmarshal(resp, &m); err != nil {
+			return false, nil
+		}
+		switch m.Event {
+		case "login":
+			if m.Code != "0" {
+				return true, newOKXError(m.Code, m.Msg)
+			}
+			return true, nil
+		case "error":
+			return true, newOKXError(m.Code, m.Msg)
+		}
+		return false, nil
+	})
+}
+
+// parseOKXWSOrders 返回解析私有频道 orders 推送的函数，一条推送可能包含多个订单
+func parseOKXWSOrders[T any](convert func(raw json.RawMessage) (T, bool)) common.WSParser[[]T] {
+	return func(msg []byte) ([]T, bool) {
+		var m struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return nil, false
+		}
+
+		var orders []T
+		for _, raw := range m.Data {
+			if order, ok := convert(raw); ok {
+				orders = append(orders, order)
+			}
+		}
+		return orders, len(orders) > 0
+	}
+}
+
+// parseOKXWSAccount 解析私有频道 account 推送，数据格式与查询余额相同
+func parseOKXWSAccount(msg []byte) (model.Balances, bool) {
+	var m struct {
+		Data []okxSpotBalanceAccount `json:"data"`
+	}
+	if err := json.Unmarshal(msg, &m); err != nil || len(m.Data) == 0 {
+		return nil, false
+	}
+	return toOKXBalances(m.Data[0].Details), true
+}
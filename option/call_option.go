@@ -11,10 +11,14 @@ type ExchangeArgsOptions struct {
 	Limit *int
 	// Since 起始时间（默认值：time.Time{}，表示不限制）
 	Since *time.Time
+	// Until 截止时间，包含该时刻开盘的K线（用于 FetchOHLCVs，默认值：time.Time{}，表示不限制）
+	Until *time.Time
 	// Symbol 单个交易对（用于 FetchPositions 等方法，如果设置则返回单个仓位信息）
 	Symbol *string
 	// Symbols 交易对列表（用于 FetchPositions 等方法）
 	Symbols []string
+	// ClosedCandlesOnly 仅返回已收盘K线（用于 FetchOHLCVs）
+	ClosedCandlesOnly *bool
 
 	// ========== 订单相关参数 ==========
 	// OrderType 订单类型（MARKET/LIMIT）
@@ -25,12 +29,34 @@ type ExchangeArgsOptions struct {
 	Amount *string
 	// ClientOrderID 客户端订单ID（所有交易所通用）
 	ClientOrderID *string
-	// TimeInForce 订单有效期（GTC/IOC/FOK，所有交易所通用）
+	// TimeInForce 订单有效期（GTC/IOC/FOK/GTX，所有交易所通用）
 	TimeInForce *TimeInForce
 	// HedgeMode 是否为双向持仓模式（合约订单）
 	HedgeMode *bool
 	// MarginType 保证金类型
 	MarginType *MarginType
+	// StrictClientID 下单后校验交易所回传的客户端订单ID是否与请求一致
+	StrictClientID *bool
+	// StopPrice 触发价格（设置后为条件单，触发后按市价或限价下单）
+	StopPrice *string
+	// TriggerType 条件单触发类型（止损/止盈，默认止损）
+	TriggerType *TriggerType
+	// TrailingCallbackRate 跟踪止损回调比例（百分比，1 表示 1%，合约订单）
+	TrailingCallbackRate *string
+	// TrailingActivationPrice 跟踪止损激活价格（可选）
+	TrailingActivationPrice *string
+	// ReduceOnly 是否只减仓（合约订单，未设置时由下单方向推断：平仓单只减仓）
+	ReduceOnly *bool
+	// PostOnly 是否只做 Maker（仅限价单）
+	PostOnly *bool
+	// ClosePercent 平仓比例（百分比，用于 ClosePosition，默认 100）
+	ClosePercent *string
+	// FeeRate 手续费率（用于 EstimateOrderCost，如 "0.001" 表示 0.1%）
+	FeeRate *string
+
+	// ========== 账户相关参数 ==========
+	// AccountType 账户类型（用于 FetchBalance，默认现货账户）
+	AccountType *AccountType
 }
 
 // ArgsOption 方法调用参数选项函数类型
@@ -52,6 +78,21 @@ func WithSince(since time.Time) ArgsOption {
 	}
 }
 
+// WithUntil 设置截止时间（包含该时刻开盘的K线）
+func WithUntil(until time.Time) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.Until = &until
+	}
+}
+
+// WithClosedCandlesOnly 仅返回已收盘K线，丢弃仍在形成中的最新K线（默认关闭）
+func WithClosedCandlesOnly() ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		closedOnly := true
+		opts.ClosedCandlesOnly = &closedOnly
+	}
+}
+
 // WithSymbol 设置单个交易对（用于 FetchPositions 等方法）
 func WithSymbol(symbol string) ArgsOption {
 	return func(opts *ExchangeArgsOptions) {
@@ -96,7 +137,8 @@ func WithClientOrderID(clientOrderID string) ArgsOption {
 	}
 }
 
-// WithTimeInForce 设置订单有效期（GTC/IOC/FOK，所有交易所通用）
+// WithTimeInForce 设置订单有效期（GTC/IOC/FOK/GTX，所有交易所通用），各交易所转换为原生取值
+// 市价单忽略 GTC、接受 IOC；FOK 仅 Gate 支持市价单；GTX 仅限价单可用，等同于 WithPostOnly(true)
 func WithTimeInForce(timeInForce TimeInForce) ArgsOption {
 	return func(opts *ExchangeArgsOptions) {
 		opts.TimeInForce = &timeInForce
@@ -116,3 +158,77 @@ func WithMarginType(marginType MarginType) ArgsOption {
 		opts.MarginType = &marginType
 	}
 }
+
+// WithStrictClientID 下单后校验交易所回传的客户端订单ID是否与请求一致（默认关闭）
+// 不一致时返回错误，此时订单已提交，仍会返回订单信息以便后续处理
+func WithStrictClientID() ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		strict := true
+		opts.StrictClientID = &strict
+	}
+}
+
+// WithStopPrice 设置触发价格，订单变为条件单：价格到达触发价后按市价（未设置 Price）或限价下单
+func WithStopPrice(price string) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.StopPrice = &price
+	}
+}
+
+// WithTriggerType 设置条件单触发类型（StopLoss/TakeProfit，默认 StopLoss），需与 WithStopPrice 一起使用
+func WithTriggerType(triggerType TriggerType) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.TriggerType = &triggerType
+	}
+}
+
+// WithTrailingStop 设置合约跟踪止损：callbackRate 为回调比例（百分比，1 表示 1%），activationPrice 为激活价格（可为空）
+// 跟踪止损单触发后按市价成交，不能与 WithPrice、WithStopPrice 同时使用
+func WithTrailingStop(callbackRate string, activationPrice string) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.TrailingCallbackRate = &callbackRate
+		if activationPrice != "" {
+			opts.TrailingActivationPrice = &activationPrice
+		}
+	}
+}
+
+// WithReduceOnly 设置合约订单是否只减仓，覆盖由下单方向推断的默认值（平仓单只减仓）
+// 双向持仓模式下 Binance、OKX 由平仓方向决定，不发送该参数
+func WithReduceOnly(reduceOnly bool) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.ReduceOnly = &reduceOnly
+	}
+}
+
+// WithPostOnly 设置订单是否只做 Maker，仅限价单可用，会立即成交时交易所拒绝或取消订单
+// 各交易所映射：Binance 合约 timeInForce=GTX、现货 LIMIT_MAKER，OKX ordType=post_only，Bybit timeInForce=PostOnly，Gate time_in_force=poc
+func WithPostOnly(postOnly bool) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.PostOnly = &postOnly
+	}
+}
+
+// WithClosePercent 设置 ClosePosition 的平仓比例（百分比，如 "50" 表示平掉一半持仓），取值范围 (0, 100]
+// 平仓数量按市场数量步长向下对齐，对齐后为零时返回 common.ErrInvalidOrder
+func WithClosePercent(percent string) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.ClosePercent = &percent
+	}
+}
+
+// WithFeeRate 设置 EstimateOrderCost 估算手续费使用的费率（如 "0.001" 表示 0.1%），未设置时手续费按 0 估算
+func WithFeeRate(rate string) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.FeeRate = &rate
+	}
+}
+
+// ========== 账户相关参数选项 ==========
+
+// WithAccountType 设置查询余额的账户类型（现货/合约/杠杆/资金账户，默认现货账户）
+func WithAccountType(accountType AccountType) ArgsOption {
+	return func(opts *ExchangeArgsOptions) {
+		opts.AccountType = &accountType
+	}
+}
@@ -1,5 +1,13 @@
 package option
 
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lemconn/exlink/model"
+)
+
 // ExchangeOptions 交易所配置选项（用于 Exchange 初始化）
 type ExchangeOptions struct {
 	APIKey    string
@@ -9,7 +17,43 @@ type ExchangeOptions struct {
 	Proxy     string
 	BaseURL   string
 	Debug     bool
-	Options   map[string]interface{} // 其他自定义选项
+	// SpotBaseURL 现货 API 地址，优先于 BaseURL
+	SpotBaseURL string
+	// PerpBaseURL 永续合约 API 地址（Binance 为 fapi 地址），为空时使用交易所默认地址
+	PerpBaseURL string
+	// Timeout 单次 HTTP 请求超时，为 0 时使用 common.DefaultHTTPTimeout
+	Timeout time.Duration
+	// HTTPClient 替换底层 HTTP 客户端（如录制回放测试），代理和超时设置仍然生效
+	HTTPClient *http.Client
+	// CorrelationHeader 关联ID请求头名称（从 context 读取关联ID）
+	CorrelationHeader string
+	// RequestHook 请求发送前的回调
+	RequestHook func(ctx context.Context, method, path string, params map[string]interface{})
+	// ResponseHook 请求结束后的回调
+	ResponseHook func(ctx context.Context, method, path string, status int, latency time.Duration, err error)
+	// Logger 请求和响应诊断日志输出，为 nil 时不输出（WithDebug 时输出到标准输出）
+	Logger Logger
+	// CancelOrdersOnDrain Drain 时撤销通过本实例创建且仍未结束的订单
+	CancelOrdersOnDrain bool
+	// RateLimitWeight 限流权重，每 RateLimitInterval 最多消耗的请求权重，为 0 时不限流
+	RateLimitWeight int
+	// RateLimitInterval 限流周期
+	RateLimitInterval time.Duration
+	// RateLimitReject 超出限流时立即返回 common.ErrRateLimitExceeded，默认阻塞等待
+	RateLimitReject bool
+	// RetryMaxRetries 临时错误（429/5xx/网络错误）的最大重试次数，为 0 时不重试
+	RetryMaxRetries int
+	// RetryBaseDelay 首次重试前的等待时间，之后每次翻倍
+	RetryBaseDelay time.Duration
+	// TimeSync 定期同步服务器时间，签名时间戳按本实例测得的时间偏移校正
+	TimeSync bool
+	// MarketCacheTTL 市场信息缓存有效期，超过后 LoadMarkets(ctx, false) 重新获取，为 0 时不过期
+	MarketCacheTTL time.Duration
+	// RecvWindow 签名请求接收窗口（毫秒），为 0 时使用各交易所默认值
+	RecvWindow int
+	// StreamStateHandler WebSocket 连接状态变化回调
+	StreamStateHandler func(event model.StreamEvent)
+	Options            map[string]interface{} // 其他自定义选项
 }
 
 // Option 配置选项函数类型（用于 Exchange 初始化）
@@ -43,7 +87,7 @@ func WithSandbox(sandbox bool) Option {
 	}
 }
 
-// WithProxy 设置代理
+// WithProxy 设置代理（http、https、socks5 或 socks5h），REST 请求和 WebSocket 连接共用
 func WithProxy(proxy string) Option {
 	return func(opts *ExchangeOptions) {
 		opts.Proxy = proxy
@@ -57,6 +101,20 @@ func WithBaseURL(baseURL string) Option {
 	}
 }
 
+// WithMarketBaseURL 按市场类型设置 API 地址（如区域域名或录制回放代理），在创建 HTTP 客户端前生效
+// model.MarketTypeSpot 设置现货地址；model.MarketTypeSwap、model.MarketTypeFuture 设置永续合约地址
+// 现货和合约共用同一域名的交易所（Bybit、OKX、Gate、Bitget）两者都设置且不同时创建失败
+func WithMarketBaseURL(marketType model.MarketType, baseURL string) Option {
+	return func(opts *ExchangeOptions) {
+		switch marketType {
+		case model.MarketTypeSpot:
+			opts.SpotBaseURL = baseURL
+		case model.MarketTypeSwap, model.MarketTypeFuture:
+			opts.PerpBaseURL = baseURL
+		}
+	}
+}
+
 // WithDebug 设置是否启用调试模式
 func WithDebug(debug bool) Option {
 	return func(opts *ExchangeOptions) {
@@ -64,6 +122,120 @@ func WithDebug(debug bool) Option {
 	}
 }
 
+// WithTimeout 设置单次 HTTP 请求超时（默认 30 秒），ctx 的截止时间更早时以 ctx 为准
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *ExchangeOptions) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithHTTPClient 替换底层 HTTP 客户端，使用其 Transport 发送 REST 请求（如 go-vcr 的 recorder）
+// client 本身不会被修改；WithProxy 只能作用于 *http.Transport，WithTimeout 优先于 client.Timeout
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *ExchangeOptions) {
+		opts.HTTPClient = client
+	}
+}
+
+// WithCorrelationHeader 设置关联ID请求头名称（如 X-Request-ID），关联ID通过 common.WithCorrelationID 写入 context
+func WithCorrelationHeader(header string) Option {
+	return func(opts *ExchangeOptions) {
+		opts.CorrelationHeader = header
+	}
+}
+
+// WithRequestHook 设置请求发送前的回调（可从 ctx 中读取关联ID）
+func WithRequestHook(hook func(ctx context.Context, method, path string, params map[string]interface{})) Option {
+	return func(opts *ExchangeOptions) {
+		opts.RequestHook = hook
+	}
+}
+
+// WithResponseHook 设置请求结束后的回调，可用于记录耗时和状态码等指标
+// status 为 HTTP 状态码（未收到响应时为 0），err 为本次请求的错误；配置重试时每次尝试各回调一次
+func WithResponseHook(hook func(ctx context.Context, method, path string, status int, latency time.Duration, err error)) Option {
+	return func(opts *ExchangeOptions) {
+		opts.ResponseHook = hook
+	}
+}
+
+// WithStreamStateHandler 设置 WebSocket 连接状态变化回调（连接建立、意外断开、主动关闭）
+// 适用于 Binance、OKX、Bybit、Gate 的 Watch* 方法，回调在读写协程中同步调用，不应阻塞
+func WithStreamStateHandler(fn func(event model.StreamEvent)) Option {
+	return func(opts *ExchangeOptions) {
+		opts.StreamStateHandler = fn
+	}
+}
+
+// Logger 分级日志接口，可适配应用已有的日志库
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// WithLogger 设置日志输出，请求和响应（Debug 级别）及请求失败（Error 级别）通过 logger 输出，请求头和查询串中的 API Key、签名已隐藏
+// 默认不输出日志；同时设置 WithDebug 时以 logger 为准
+func WithLogger(logger Logger) Option {
+	return func(opts *ExchangeOptions) {
+		opts.Logger = logger
+	}
+}
+
+// WithCancelOrdersOnDrain 设置 Drain 时撤销通过本实例创建且仍未结束的订单（默认关闭）
+func WithCancelOrdersOnDrain(cancel bool) Option {
+	return func(opts *ExchangeOptions) {
+		opts.CancelOrdersOnDrain = cancel
+	}
+}
+
+// WithRateLimit 设置客户端限流：每 interval 最多消耗 weight 请求权重（Binance 按官方接口权重计算，其他交易所每个请求权重为 1）
+func WithRateLimit(weight int, interval time.Duration) Option {
+	return func(opts *ExchangeOptions) {
+		opts.RateLimitWeight = weight
+		opts.RateLimitInterval = interval
+	}
+}
+
+// WithRateLimitReject 设置超出限流时立即返回 common.ErrRateLimitExceeded，而不是阻塞等待
+func WithRateLimitReject(reject bool) Option {
+	return func(opts *ExchangeOptions) {
+		opts.RateLimitReject = reject
+	}
+}
+
+// WithRetry 设置 GET 请求遇到 429/5xx/网络错误时自动重试，等待时间从 baseDelay 开始翻倍，交易所返回 Retry-After 时至少等待该时长
+// 下单等非 GET 请求默认不重试，单次调用可通过 common.WithRetryPolicy 覆盖
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(opts *ExchangeOptions) {
+		opts.RetryMaxRetries = maxRetries
+		opts.RetryBaseDelay = baseDelay
+	}
+}
+
+// WithTimeSync 设置定期同步服务器时间（默认关闭），本地时钟偏差导致签名时间戳超出接收窗口时启用
+// 偏移按交易所实例分别测量，Drain 时停止同步
+func WithTimeSync(enabled bool) Option {
+	return func(opts *ExchangeOptions) {
+		opts.TimeSync = enabled
+	}
+}
+
+// WithMarketCacheTTL 设置市场信息缓存有效期（默认不过期），有效期内重复调用 LoadMarkets(ctx, false) 不发送请求
+func WithMarketCacheTTL(ttl time.Duration) Option {
+	return func(opts *ExchangeOptions) {
+		opts.MarketCacheTTL = ttl
+	}
+}
+
+// WithRecvWindow 设置签名请求接收窗口（毫秒），网络延迟较大时放宽，超过交易所上限时按上限发送（Binance 60000）
+// 目前 Binance 和 Bybit 生效，Binance 默认不发送（交易所默认 5000），Bybit 默认 5000
+func WithRecvWindow(ms int) Option {
+	return func(opts *ExchangeOptions) {
+		opts.RecvWindow = ms
+	}
+}
+
 // WithOption 设置自定义选项
 func WithOption(key string, value interface{}) Option {
 	return func(opts *ExchangeOptions) {
@@ -74,3 +74,11 @@ func GetTime(t *time.Time) (time.Time, bool) {
 	}
 	return *t, true
 }
+
+// GetAccountType 返回账户类型，未设置时返回现货账户
+func GetAccountType(a *AccountType) AccountType {
+	if a == nil || *a == "" {
+		return AccountSpot
+	}
+	return *a
+}
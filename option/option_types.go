@@ -128,6 +128,8 @@ const (
 	IOC TimeInForce = "IOC"
 	// FOK Fill or Kill 无法全部立即成交就撤销
 	FOK TimeInForce = "FOK"
+	// GTX Good Till Crossing 只做 Maker（Post Only），会立即成交时撤销，仅限价单可用，等同于 WithPostOnly(true)
+	GTX TimeInForce = "GTX"
 )
 
 // String 返回字符串表示
@@ -160,6 +162,45 @@ func (t TimeInForce) IsFOK() bool {
 	return t == FOK
 }
 
+// IsGTX 判断是否为 GTX（只做 Maker）
+func (t TimeInForce) IsGTX() bool {
+	return t == GTX
+}
+
+// TriggerType 条件单触发类型
+type TriggerType string
+
+const (
+	// StopLoss 止损：买单在价格上涨到触发价时触发，卖单在价格下跌到触发价时触发
+	StopLoss TriggerType = "STOP_LOSS"
+	// TakeProfit 止盈：买单在价格下跌到触发价时触发，卖单在价格上涨到触发价时触发
+	TakeProfit TriggerType = "TAKE_PROFIT"
+)
+
+// String 返回字符串表示
+func (t TriggerType) String() string {
+	return string(t)
+}
+
+// IsStopLoss 判断是否为止损
+func (t TriggerType) IsStopLoss() bool {
+	return t == StopLoss
+}
+
+// IsTakeProfit 判断是否为止盈
+func (t TriggerType) IsTakeProfit() bool {
+	return t == TakeProfit
+}
+
+// TriggersOnRise 判断 side（BUY/SELL）方向的条件单是否在价格上涨到触发价时触发
+func (t TriggerType) TriggersOnRise(side string) bool {
+	buy := strings.EqualFold(side, "BUY")
+	if t == TakeProfit {
+		return !buy
+	}
+	return buy
+}
+
 // MarginType 保证金类型
 type MarginType string
 
@@ -194,3 +235,76 @@ func (m MarginType) IsIsolated() bool {
 func (m MarginType) IsCrossed() bool {
 	return m == CROSSED
 }
+
+// AccountType 账户类型（用于资金划转）
+type AccountType string
+
+const (
+	// AccountSpot 现货账户
+	AccountSpot AccountType = "SPOT"
+	// AccountFutures 合约账户（U本位永续）
+	AccountFutures AccountType = "FUTURES"
+	// AccountMargin 杠杆账户（全仓）
+	AccountMargin AccountType = "MARGIN"
+	// AccountFunding 资金账户
+	AccountFunding AccountType = "FUNDING"
+)
+
+// String 返回字符串表示
+func (a AccountType) String() string {
+	return string(a)
+}
+
+// GridRunType 网格类型
+type GridRunType string
+
+const (
+	// GridArithmetic 等差网格
+	GridArithmetic GridRunType = "ARITHMETIC"
+	// GridGeometric 等比网格
+	GridGeometric GridRunType = "GEOMETRIC"
+)
+
+// GridParams 网格策略参数
+type GridParams struct {
+	// MinPrice 区间最低价
+	MinPrice string
+	// MaxPrice 区间最高价
+	MaxPrice string
+	// GridNum 网格数量
+	GridNum int
+	// RunType 网格类型，默认等差
+	RunType GridRunType
+	// Investment 投入金额（现货网格为计价币数量，合约网格为保证金）
+	Investment string
+	// Direction 合约网格方向（long/short/neutral），现货网格忽略
+	Direction string
+	// Leverage 合约网格杠杆，现货网格忽略
+	Leverage int
+}
+
+// SpotOrderRequest 批量下单中的单个现货订单（参数与 CreateOrder 一致）
+type SpotOrderRequest struct {
+	// Symbol 交易对
+	Symbol string
+	// Side 买卖方向
+	Side SpotOrderSide
+	// Amount 数量
+	Amount string
+	// Opts 订单选项（设置 WithPrice 时为限价单，否则为市价单）
+	Opts []ArgsOption
+}
+
+// PerpOrderRequest 批量下单中的单个合约订单（参数与 CreateOrder 一致）
+type PerpOrderRequest struct {
+	// Symbol 交易对
+	Symbol string
+	// Amount 数量
+	Amount string
+	// Side 开平仓方向
+	Side PerpOrderSide
+	// Type 订单类型
+	Type OrderType
+	// Opts 订单选项
+	Opts []ArgsOption
+}
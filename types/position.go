@@ -3,6 +3,8 @@ package types
 import (
 	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // PositionSide 持仓方向
@@ -33,15 +35,15 @@ func (s PositionSide) IsShort() bool {
 type Position struct {
 	Symbol           string                 `json:"symbol"`            // 交易对
 	Side             PositionSide           `json:"side"`              // 持仓方向
-	Amount           float64                `json:"amount"`            // 持仓数量
-	EntryPrice       float64                `json:"entry_price"`       // 开仓价格
-	MarkPrice        float64                `json:"mark_price"`        // 标记价格
-	LiquidationPrice float64                `json:"liquidation_price"` // 强平价格
-	UnrealizedPnl    float64                `json:"unrealized_pnl"`    // 未实现盈亏
-	RealizedPnl      float64                `json:"realized_pnl"`      // 已实现盈亏
-	Leverage         float64                `json:"leverage"`          // 杠杆倍数
-	Margin           float64                `json:"margin"`            // 保证金
-	Percentage       float64                `json:"percentage"`        // 持仓占比
+	Amount           decimal.Decimal        `json:"amount"`            // 持仓数量
+	EntryPrice       decimal.Decimal        `json:"entry_price"`       // 开仓价格
+	MarkPrice        decimal.Decimal        `json:"mark_price"`        // 标记价格
+	LiquidationPrice decimal.Decimal        `json:"liquidation_price"` // 强平价格
+	UnrealizedPnl    decimal.Decimal        `json:"unrealized_pnl"`    // 未实现盈亏
+	RealizedPnl      decimal.Decimal        `json:"realized_pnl"`      // 已实现盈亏
+	Leverage         decimal.Decimal        `json:"leverage"`          // 杠杆倍数
+	Margin           decimal.Decimal        `json:"margin"`            // 保证金
+	Percentage       decimal.Decimal        `json:"percentage"`        // 持仓占比
 	Timestamp        time.Time              `json:"timestamp"`         // 时间戳
 	Info             map[string]interface{} `json:"info"`              // 交易所原始信息
 }